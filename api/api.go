@@ -55,8 +55,8 @@ func startAPIService(port int, startChannels *runner.StartChannels, sig *infoGat
 }
 
 func startAPIServiceWithoutRunner(port int, startChannels *runner.StartChannels, sig *infoGatherer.SpecInfoGatherer) {
-	apiHandler := &gaugeAPIMessageHandler{specInfoGatherer: sig}
-	gaugeConnectionHandler, err := conn.NewGaugeConnectionHandler(port, apiHandler)
+	apiHandler := newGaugeAPIMessageHandler(sig)
+	gaugeConnectionHandler, err := newAPIConnectionHandler(port, apiHandler)
 	if err != nil {
 		startChannels.ErrorChan <- fmt.Errorf("Connection error. %s", err.Error())
 		return
@@ -70,6 +70,14 @@ func startAPIServiceWithoutRunner(port int, startChannels *runner.StartChannels,
 	go gaugeConnectionHandler.HandleMultipleConnections()
 }
 
+// newAPIConnectionHandler listens on the given port, or on one of the API ports if the port is 0.
+func newAPIConnectionHandler(port int, apiHandler *gaugeAPIMessageHandler) (*conn.GaugeConnectionHandler, error) {
+	if port != 0 {
+		return conn.NewGaugeConnectionHandler(port, apiHandler)
+	}
+	return conn.NewGaugeConnectionHandlerOnPorts(config.APIPorts(), apiHandler)
+}
+
 func ConnectToRunner(killChannel chan bool, debug bool, outputStreamWriter io.Writer) (runner.Runner, error) {
 	manifest, err := manifest.ProjectManifest()
 	if err != nil {
@@ -84,12 +92,18 @@ func ConnectToRunner(killChannel chan bool, debug bool, outputStreamWriter io.Wr
 	return runner, nil
 }
 
-func runAPIServiceIndefinitely(port int, specDirs []string) {
+// runAPIServiceIndefinitely serves the API on the given port, and the Daemon gRPC service on grpcPort unless it is
+// negative.
+func runAPIServiceIndefinitely(port, grpcPort int, specDirs []string) {
 	startChan := &runner.StartChannels{RunnerChan: make(chan runner.Runner), ErrorChan: make(chan error), KillChan: make(chan bool)}
 
 	sig := &infoGatherer.SpecInfoGatherer{SpecDirs: specDirs}
 	sig.Init()
-	go startAPIServiceWithoutRunner(port, startChan, sig)
+	apiHandler := newGaugeAPIMessageHandler(sig)
+	go startDaemonAPIService(port, startChan, apiHandler)
+	if grpcPort >= 0 {
+		go startDaemonService(grpcPort, apiHandler, startChan.ErrorChan)
+	}
 	go checkParentIsAlive(startChan)
 
 	logger.Infof(true, "Gauge daemon initialized and listening on port: %d", port)
@@ -116,8 +130,9 @@ func checkParentIsAlive(startChannels *runner.StartChannels) {
 	}
 }
 
-// RunInBackground runs Gauge in daemonized mode on the given apiPort
-func RunInBackground(apiPort string, specDirs []string) {
+// RunInBackground runs Gauge in daemonized mode on the given apiPort. The Daemon gRPC service of api/daemon is served
+// on grpcPort, if given.
+func RunInBackground(apiPort, grpcPort string, specDirs []string) {
 	var port int
 	var err error
 	if apiPort != "" {
@@ -132,14 +147,21 @@ func RunInBackground(apiPort string, specDirs []string) {
 			logger.Fatalf(true, fmt.Sprintf("Failed to start API Service. %s \n", err.Error()))
 		}
 	}
-	runAPIServiceIndefinitely(port, specDirs)
+	daemonPort := -1
+	if grpcPort != "" {
+		daemonPort, err = strconv.Atoi(grpcPort)
+		if err != nil || daemonPort < 0 {
+			logger.Fatalf(true, fmt.Sprintf("Invalid gRPC port number: %s", grpcPort))
+		}
+	}
+	runAPIServiceIndefinitely(port, daemonPort, specDirs)
 }
 
 func Start(specsDir []string) *conn.GaugeConnectionHandler {
 	sig := &infoGatherer.SpecInfoGatherer{SpecDirs: specsDir}
 	sig.Init()
-	apiHandler := &gaugeAPIMessageHandler{specInfoGatherer: sig}
-	gaugeConnectionHandler, err := conn.NewGaugeConnectionHandler(0, apiHandler)
+	apiHandler := newGaugeAPIMessageHandler(sig)
+	gaugeConnectionHandler, err := newAPIConnectionHandler(0, apiHandler)
 	if err != nil {
 		logger.Fatalf(true, err.Error())
 	}
@@ -18,14 +18,15 @@
 package api
 
 import (
+	"fmt"
 	"net"
 	"path/filepath"
+	"sync"
 
 	"github.com/getgauge/common"
 	"github.com/getgauge/gauge/api/infoGatherer"
 	"github.com/getgauge/gauge/conceptExtractor"
 	"github.com/getgauge/gauge/config"
-	"github.com/getgauge/gauge/conn"
 	"github.com/getgauge/gauge/formatter"
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/gauge_messages"
@@ -41,65 +42,101 @@ import (
 type gaugeAPIMessageHandler struct {
 	specInfoGatherer *infoGatherer.SpecInfoGatherer
 	Runner           runner.Runner
+	clients          *apiClients
+	// writeMutex serializes requests that modify project files, as they may come from different clients at once.
+	writeMutex sync.Mutex
+}
+
+func newGaugeAPIMessageHandler(sig *infoGatherer.SpecInfoGatherer) *gaugeAPIMessageHandler {
+	return &gaugeAPIMessageHandler{specInfoGatherer: sig, clients: newAPIClients()}
+}
+
+// ConnectionOpened registers a newly connected client.
+func (handler *gaugeAPIMessageHandler) ConnectionOpened(connection net.Conn) {
+	client := handler.clients.get(connection)
+	logger.Debugf(false, "API client %d connected from %s", client.id, connection.RemoteAddr())
+}
+
+// ConnectionClosed forgets a client once its connection is closed.
+func (handler *gaugeAPIMessageHandler) ConnectionClosed(connection net.Conn) {
+	if client := handler.clients.remove(connection); client != nil {
+		logger.Debugf(false, "API client %d disconnected", client.id)
+	}
 }
 
 func (handler *gaugeAPIMessageHandler) MessageBytesReceived(bytesRead []byte, connection net.Conn) {
+	client := handler.clients.get(connection)
 	apiMessage := &gauge_messages.APIMessage{}
 	var responseMessage *gauge_messages.APIMessage
 	err := proto.Unmarshal(bytesRead, apiMessage)
 	if err != nil {
-		logger.Errorf(false, "Failed to read API proto message: %s\n", err.Error())
+		logger.Errorf(false, "Failed to read API proto message from client %d: %s\n", client.id, err.Error())
 		responseMessage = handler.getErrorMessage(err)
 	} else {
-		logger.Debugf(false, "Api Request Received: %s", apiMessage)
-		messageType := apiMessage.GetMessageType()
-		switch messageType {
-		case gauge_messages.APIMessage_GetProjectRootRequest:
-			responseMessage = handler.projectRootRequestResponse(apiMessage)
-			break
-		case gauge_messages.APIMessage_GetInstallationRootRequest:
-			responseMessage = handler.installationRootRequestResponse(apiMessage)
-			break
-		case gauge_messages.APIMessage_GetAllStepsRequest:
-			responseMessage = handler.getAllStepsRequestResponse(apiMessage)
-			break
-		case gauge_messages.APIMessage_SpecsRequest:
-			responseMessage = handler.getSpecsRequestResponse(apiMessage)
-			break
-		case gauge_messages.APIMessage_GetStepValueRequest:
-			responseMessage = handler.getStepValueRequestResponse(apiMessage)
-			break
-		case gauge_messages.APIMessage_GetLanguagePluginLibPathRequest:
-			responseMessage = handler.getLanguagePluginLibPath(apiMessage)
-			break
-		case gauge_messages.APIMessage_GetAllConceptsRequest:
-			responseMessage = handler.getAllConceptsRequestResponse(apiMessage)
-			break
-		case gauge_messages.APIMessage_PerformRefactoringRequest:
-			responseMessage = handler.performRefactoring(apiMessage)
-			handler.performRefresh(responseMessage.PerformRefactoringResponse.FilesChanged)
-			break
-		case gauge_messages.APIMessage_ExtractConceptRequest:
-			responseMessage = handler.extractConcept(apiMessage)
-			break
-		case gauge_messages.APIMessage_FormatSpecsRequest:
-			responseMessage = handler.formatSpecs(apiMessage)
-			break
-		default:
-			responseMessage = handler.createUnsupportedAPIMessageResponse(apiMessage)
-		}
+		logger.Debugf(false, "Api Request Received from client %d: %s", client.id, apiMessage)
+		responseMessage = handler.processRequest(client, apiMessage)
 	}
-	handler.sendMessage(responseMessage, connection)
+	handler.sendMessage(responseMessage, client)
 }
 
-func (handler *gaugeAPIMessageHandler) sendMessage(message *gauge_messages.APIMessage, connection net.Conn) {
-	logger.Debugf(false, "Sending API response: %s", message)
-	dataBytes, err := proto.Marshal(message)
-	if err != nil {
-		logger.Errorf(false, "Failed to respond to API request. Could not Marshal response %s\n", err.Error())
+// processRequest answers a single request. A request that fails unexpectedly gets an error response instead of
+// bringing down the service for every other connected client.
+func (handler *gaugeAPIMessageHandler) processRequest(client *apiClient, apiMessage *gauge_messages.APIMessage) (responseMessage *gauge_messages.APIMessage) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Errorf(false, "Failed to process API request %d from client %d: %v", apiMessage.MessageId, client.id, r)
+			responseMessage = handler.getErrorResponse(apiMessage, fmt.Errorf("Failed to process request: %v", r))
+		}
+	}()
+	messageType := apiMessage.GetMessageType()
+	switch messageType {
+	case gauge_messages.APIMessage_GetProjectRootRequest:
+		responseMessage = handler.projectRootRequestResponse(apiMessage)
+		break
+	case gauge_messages.APIMessage_GetInstallationRootRequest:
+		responseMessage = handler.installationRootRequestResponse(apiMessage)
+		break
+	case gauge_messages.APIMessage_GetAllStepsRequest:
+		responseMessage = handler.getAllStepsRequestResponse(apiMessage)
+		break
+	case gauge_messages.APIMessage_SpecsRequest:
+		responseMessage = handler.getSpecsRequestResponse(apiMessage)
+		break
+	case gauge_messages.APIMessage_GetStepValueRequest:
+		responseMessage = handler.getStepValueRequestResponse(apiMessage)
+		break
+	case gauge_messages.APIMessage_GetLanguagePluginLibPathRequest:
+		responseMessage = handler.getLanguagePluginLibPath(apiMessage)
+		break
+	case gauge_messages.APIMessage_GetAllConceptsRequest:
+		responseMessage = handler.getAllConceptsRequestResponse(apiMessage)
+		break
+	case gauge_messages.APIMessage_PerformRefactoringRequest:
+		handler.writeMutex.Lock()
+		defer handler.writeMutex.Unlock()
+		responseMessage = handler.performRefactoring(apiMessage)
+		handler.performRefresh(responseMessage.PerformRefactoringResponse.FilesChanged)
+		break
+	case gauge_messages.APIMessage_ExtractConceptRequest:
+		handler.writeMutex.Lock()
+		defer handler.writeMutex.Unlock()
+		responseMessage = handler.extractConcept(apiMessage)
+		break
+	case gauge_messages.APIMessage_FormatSpecsRequest:
+		handler.writeMutex.Lock()
+		defer handler.writeMutex.Unlock()
+		responseMessage = handler.formatSpecs(apiMessage)
+		break
+	default:
+		responseMessage = handler.createUnsupportedAPIMessageResponse(apiMessage)
 	}
-	if err := conn.Write(connection, dataBytes); err != nil {
-		logger.Errorf(false, "Failed to respond to API request. Could not write response %s\n", err.Error())
+	return responseMessage
+}
+
+func (handler *gaugeAPIMessageHandler) sendMessage(message *gauge_messages.APIMessage, client *apiClient) {
+	logger.Debugf(false, "Sending API response to client %d: %s", client.id, message)
+	if err := client.send(message); err != nil {
+		logger.Errorf(false, "Failed to respond to API request from client %d. %s\n", client.id, err.Error())
 	}
 }
 
@@ -18,12 +18,14 @@
 package api
 
 import (
+	"net"
 	"testing"
 
 	"github.com/getgauge/gauge/api/infoGatherer"
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/gauge_messages"
 	"github.com/getgauge/gauge/parser"
+	"github.com/golang/protobuf/proto"
 	. "gopkg.in/check.v1"
 )
 
@@ -59,3 +61,67 @@ func (s *MySuite) TestCreateSpecsResponseMessageFor(c *C) {
 	c.Assert(len(m.GetDetails()[2].ParseErrors), Equals, 0)
 	c.Assert(m.GetDetails()[2].Spec.GetSpecHeading(), Equals, "Spec heading 2")
 }
+
+func sendAPIRequest(c *C, handler *gaugeAPIMessageHandler, connection net.Conn, request *gauge_messages.APIMessage) {
+	data, err := proto.Marshal(request)
+	c.Assert(err, IsNil)
+	go handler.MessageBytesReceived(data, connection)
+}
+
+func readMessage(c *C, connection net.Conn) []byte {
+	data := make([]byte, 8192)
+	n, err := connection.Read(data)
+	c.Assert(err, IsNil)
+	length, bytesRead := proto.DecodeVarint(data[:n])
+	return data[bytesRead : uint64(bytesRead)+length]
+}
+
+func readAPIResponse(c *C, connection net.Conn) *gauge_messages.APIMessage {
+	response := &gauge_messages.APIMessage{}
+	c.Assert(proto.Unmarshal(readMessage(c, connection), response), IsNil)
+	return response
+}
+
+func (s *MySuite) TestResponsesAreSentToTheClientThatMadeTheRequest(c *C) {
+	h := newGaugeAPIMessageHandler(&infoGatherer.SpecInfoGatherer{})
+	server1, client1 := net.Pipe()
+	server2, client2 := net.Pipe()
+	defer client1.Close()
+	defer client2.Close()
+	h.ConnectionOpened(server1)
+	h.ConnectionOpened(server2)
+
+	sendAPIRequest(c, h, server2, &gauge_messages.APIMessage{MessageId: 2, MessageType: gauge_messages.APIMessage_GetProjectRootRequest})
+	response := readAPIResponse(c, client2)
+	c.Assert(response.MessageId, Equals, int64(2))
+	c.Assert(response.MessageType, Equals, gauge_messages.APIMessage_GetProjectRootResponse)
+
+	sendAPIRequest(c, h, server1, &gauge_messages.APIMessage{MessageId: 1, MessageType: gauge_messages.APIMessage_GetProjectRootRequest})
+	response = readAPIResponse(c, client1)
+	c.Assert(response.MessageId, Equals, int64(1))
+
+	c.Assert(h.clients.get(server1).id, Not(Equals), h.clients.get(server2).id)
+}
+
+func (s *MySuite) TestFailingRequestGetsErrorResponse(c *C) {
+	h := newGaugeAPIMessageHandler(nil)
+	server, client := net.Pipe()
+	defer client.Close()
+
+	sendAPIRequest(c, h, server, &gauge_messages.APIMessage{MessageId: 3, MessageType: gauge_messages.APIMessage_GetAllStepsRequest})
+	response := readAPIResponse(c, client)
+
+	c.Assert(response.MessageId, Equals, int64(3))
+	c.Assert(response.MessageType, Equals, gauge_messages.APIMessage_ErrorResponse)
+}
+
+func (s *MySuite) TestClientIsForgottenWhenConnectionCloses(c *C) {
+	h := newGaugeAPIMessageHandler(&infoGatherer.SpecInfoGatherer{})
+	server, client := net.Pipe()
+	defer client.Close()
+	h.ConnectionOpened(server)
+
+	h.ConnectionClosed(server)
+
+	c.Assert(len(h.clients.clients), Equals, 0)
+}
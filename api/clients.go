@@ -0,0 +1,97 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"net"
+	"sync"
+
+	"github.com/getgauge/gauge/conn"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/golang/protobuf/proto"
+)
+
+// apiClient is an IDE or tool connected to the API service. Messages to a client are written one at a time, so
+// responses sent from different goroutines do not interleave on its connection.
+type apiClient struct {
+	id         int
+	connection net.Conn
+	mutex      sync.Mutex
+}
+
+func (c *apiClient) send(message *gauge_messages.APIMessage) error {
+	dataBytes, err := proto.Marshal(message)
+	if err != nil {
+		return err
+	}
+	return c.write(dataBytes)
+}
+
+func (c *apiClient) write(dataBytes []byte) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return conn.Write(c.connection, dataBytes)
+}
+
+// apiClients keeps track of the clients currently connected to the API service.
+type apiClients struct {
+	mutex   sync.RWMutex
+	lastID  int
+	clients map[net.Conn]*apiClient
+}
+
+func newAPIClients() *apiClients {
+	return &apiClients{clients: make(map[net.Conn]*apiClient)}
+}
+
+// get returns the client for the given connection, registering it if it is not known yet.
+func (c *apiClients) get(connection net.Conn) *apiClient {
+	c.mutex.RLock()
+	client, ok := c.clients[connection]
+	c.mutex.RUnlock()
+	if ok {
+		return client
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if client, ok := c.clients[connection]; ok {
+		return client
+	}
+	c.lastID++
+	client = &apiClient{id: c.lastID, connection: connection}
+	c.clients[connection] = client
+	return client
+}
+
+func (c *apiClients) remove(connection net.Conn) *apiClient {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	client := c.clients[connection]
+	delete(c.clients, connection)
+	return client
+}
+
+func (c *apiClients) all() []*apiClient {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	clients := make([]*apiClient, 0, len(c.clients))
+	for _, client := range c.clients {
+		clients = append(clients, client)
+	}
+	return clients
+}
@@ -0,0 +1,212 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/api/daemon"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/util"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Complete gives the completion candidates at the position of the request. The file is read from disk, unless its
+// content is given for a file with unsaved changes.
+func (s *daemonService) Complete(_ context.Context, request *daemon.CompletionRequest) (*daemon.CompletionResponse, error) {
+	file := request.File
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(config.ProjectRoot, file)
+	}
+	content := request.Content
+	if content == "" {
+		var err error
+		if content, err = common.ReadFileContents(file); err != nil {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+	}
+	lines := util.GetLinesFromText(content)
+	if request.Line < 1 || int(request.Line) > len(lines) {
+		return nil, status.Errorf(codes.InvalidArgument, "Line %d is not in %s", request.Line, request.File)
+	}
+	completionContext, prefix, candidates := s.handler.completionCandidates(file, lines, int(request.Line)-1, int(request.Character))
+	return &daemon.CompletionResponse{Context: completionContext, Prefix: prefix, Candidates: rankCandidates(candidates, prefix)}, nil
+}
+
+func (handler *gaugeAPIMessageHandler) completionCandidates(file string, lines []string, lineNo, character int) (daemon.CompletionResponse_Context, string, []*daemon.CompletionCandidate) {
+	line := []rune(lines[lineNo])
+	if character < 0 {
+		character = 0
+	}
+	if character > len(line) {
+		character = len(line)
+	}
+	beforeCursor := string(line[:character])
+	if isInTagsLine(lines, lineNo) {
+		return daemon.CompletionResponse_TAG, tagPrefix(beforeCursor), handler.tagCandidates(beforeCursor)
+	}
+	if !strings.HasPrefix(strings.TrimSpace(beforeCursor), "*") {
+		return daemon.CompletionResponse_NONE, "", []*daemon.CompletionCandidate{}
+	}
+	if open, prefix := openParameter(beforeCursor); open != 0 {
+		argType := gauge.Static
+		if open == '<' {
+			argType = gauge.Dynamic
+		}
+		return daemon.CompletionResponse_PARAMETER, prefix, handler.parameterCandidates(file, argType)
+	}
+	prefix := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(beforeCursor), "*"))
+	return daemon.CompletionResponse_STEP, prefix, handler.stepCandidates()
+}
+
+func (handler *gaugeAPIMessageHandler) stepCandidates() []*daemon.CompletionCandidate {
+	seen := make(map[string]bool)
+	var candidates []*daemon.CompletionCandidate
+	for _, concept := range handler.specInfoGatherer.Concepts() {
+		text := concept.GetStepValue().GetParameterizedStepValue()
+		if !seen[text] {
+			seen[text] = true
+			candidates = append(candidates, &daemon.CompletionCandidate{Text: text, Kind: daemon.CompletionCandidate_CONCEPT})
+		}
+	}
+	for _, step := range handler.specInfoGatherer.Steps(true) {
+		text := parser.CreateStepValue(step).ParameterizedStepValue
+		if !seen[text] {
+			seen[text] = true
+			candidates = append(candidates, &daemon.CompletionCandidate{Text: text, Kind: daemon.CompletionCandidate_STEP})
+		}
+	}
+	return candidates
+}
+
+func (handler *gaugeAPIMessageHandler) parameterCandidates(file string, argType gauge.ArgType) []*daemon.CompletionCandidate {
+	var candidates []*daemon.CompletionCandidate
+	for _, param := range handler.specInfoGatherer.Params(file, argType) {
+		candidates = append(candidates, &daemon.CompletionCandidate{Text: param.Value, Kind: daemon.CompletionCandidate_PARAMETER})
+	}
+	return candidates
+}
+
+// tagCandidates gives the known tags, leaving out the ones already given on the line.
+func (handler *gaugeAPIMessageHandler) tagCandidates(beforeCursor string) []*daemon.CompletionCandidate {
+	given := make(map[string]bool)
+	for _, tag := range strings.Split(beforeCursor[strings.Index(beforeCursor, ":")+1:], ",") {
+		given[strings.TrimSpace(tag)] = true
+	}
+	var candidates []*daemon.CompletionCandidate
+	for _, tag := range handler.specInfoGatherer.Tags() {
+		if !given[tag] {
+			candidates = append(candidates, &daemon.CompletionCandidate{Text: tag, Kind: daemon.CompletionCandidate_TAG})
+		}
+	}
+	return candidates
+}
+
+// isInTagsLine tells if the line has tags, either after the tags keyword or continuing the tags of the previous line.
+func isInTagsLine(lines []string, lineNo int) bool {
+	l := strings.ToLower(strings.Join(strings.Fields(lines[lineNo]), ""))
+	for _, tags := range parser.KeywordSpellings(parser.TagsKeyword) {
+		if strings.HasPrefix(l, strings.ToLower(strings.Join(strings.Fields(tags), ""))+":") {
+			return true
+		}
+	}
+	return lineNo > 0 && strings.HasSuffix(strings.TrimSpace(lines[lineNo-1]), ",") && isInTagsLine(lines, lineNo-1)
+}
+
+func tagPrefix(beforeCursor string) string {
+	i := strings.LastIndexAny(beforeCursor, ":,")
+	return strings.TrimSpace(beforeCursor[i+1:])
+}
+
+// openParameter gives the character opening the parameter the cursor is in, '"' or '<', and what has been typed
+// of the parameter so far. It gives 0 if the cursor is not in a parameter.
+func openParameter(beforeCursor string) (rune, string) {
+	var open rune
+	var start int
+	for i, c := range beforeCursor {
+		switch {
+		case open == 0 && (c == '"' || c == '<'):
+			open, start = c, i+1
+		case open == '"' && c == '"', open == '<' && c == '>':
+			open = 0
+		}
+	}
+	if open == 0 {
+		return 0, ""
+	}
+	return open, beforeCursor[start:]
+}
+
+// rankCandidates drops the candidates that do not match the prefix and orders the rest by how well they match.
+// Candidates starting with the prefix come first, followed by those with a word starting with it, those containing
+// it and those containing its characters in order. Matching ignores case.
+func rankCandidates(candidates []*daemon.CompletionCandidate, prefix string) []*daemon.CompletionCandidate {
+	prefix = strings.ToLower(prefix)
+	ranked := make([]*daemon.CompletionCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		if c.Score = int32(matchScore(strings.ToLower(c.Text), prefix)); c.Score > 0 {
+			ranked = append(ranked, c)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		if ranked[i].Text != ranked[j].Text {
+			return ranked[i].Text < ranked[j].Text
+		}
+		return ranked[i].Kind < ranked[j].Kind
+	})
+	return ranked
+}
+
+func matchScore(text, prefix string) int {
+	switch {
+	case strings.HasPrefix(text, prefix):
+		return 4
+	case strings.Contains(" "+text, " "+prefix):
+		return 3
+	case strings.Contains(text, prefix):
+		return 2
+	case isSubsequence(prefix, text):
+		return 1
+	}
+	return 0
+}
+
+func isSubsequence(s, text string) bool {
+	t := []rune(text)
+	i := 0
+	for _, c := range s {
+		for i < len(t) && t[i] != c {
+			i++
+		}
+		if i == len(t) {
+			return false
+		}
+		i++
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/getgauge/gauge/api/daemon"
+	"github.com/getgauge/gauge/api/infoGatherer"
+	"github.com/getgauge/gauge/config"
+	"golang.org/x/net/context"
+	. "gopkg.in/check.v1"
+)
+
+const completionSpec = `# Login
+
+tags: smoke, login
+
+|user |password|
+|-----|--------|
+|alice|secret  |
+
+## Successful login
+* Login as <user> with "admin"
+* Open the dashboard
+`
+
+const completionConcept = `# Login and open <page>
+* Login as "bob" with "pass"
+`
+
+func (s *MySuite) TestRankCandidates(c *C) {
+	candidates := []*daemon.CompletionCandidate{
+		{Text: "Close the gate", Kind: daemon.CompletionCandidate_STEP},
+		{Text: "Log out", Kind: daemon.CompletionCandidate_STEP},
+		{Text: "Login as <user> with <password>", Kind: daemon.CompletionCandidate_STEP},
+		{Text: "Verify the login page", Kind: daemon.CompletionCandidate_STEP},
+		{Text: "Go to blog in", Kind: daemon.CompletionCandidate_STEP},
+		{Text: "Delete account", Kind: daemon.CompletionCandidate_STEP},
+	}
+
+	got := rankCandidates(candidates, "log")
+
+	c.Assert(got, DeepEquals, []*daemon.CompletionCandidate{
+		{Text: "Log out", Kind: daemon.CompletionCandidate_STEP, Score: 4},
+		{Text: "Login as <user> with <password>", Kind: daemon.CompletionCandidate_STEP, Score: 4},
+		{Text: "Verify the login page", Kind: daemon.CompletionCandidate_STEP, Score: 3},
+		{Text: "Go to blog in", Kind: daemon.CompletionCandidate_STEP, Score: 2},
+		{Text: "Close the gate", Kind: daemon.CompletionCandidate_STEP, Score: 1},
+	})
+}
+
+func (s *MySuite) TestOpenParameter(c *C) {
+	open, prefix := openParameter(`* Login as <us`)
+	c.Assert(open, Equals, '<')
+	c.Assert(prefix, Equals, "us")
+
+	open, prefix = openParameter(`* Login as <user> with "ad`)
+	c.Assert(open, Equals, '"')
+	c.Assert(prefix, Equals, "ad")
+
+	open, _ = openParameter(`* Login as <user> with "admin" `)
+	c.Assert(open, Equals, rune(0))
+}
+
+func (s *MySuite) TestIsInTagsLine(c *C) {
+	lines := []string{"# Spec", "Tags: a,", " b,", "c", "* step"}
+
+	c.Assert(isInTagsLine(lines, 1), Equals, true)
+	c.Assert(isInTagsLine(lines, 2), Equals, true)
+	c.Assert(isInTagsLine(lines, 3), Equals, true)
+	c.Assert(isInTagsLine(lines, 4), Equals, false)
+}
+
+func (s *MySuite) TestComplete(c *C) {
+	projectRoot, _ := ioutil.TempDir("", "gaugeTest")
+	defer os.RemoveAll(projectRoot)
+	projectRoot, _ = filepath.EvalSymlinks(projectRoot)
+	specsDir := filepath.Join(projectRoot, "specs")
+	os.MkdirAll(specsDir, 0755)
+	specFile := filepath.Join(specsDir, "login.spec")
+	ioutil.WriteFile(specFile, []byte(completionSpec), 0644)
+	ioutil.WriteFile(filepath.Join(specsDir, "login.cpt"), []byte(completionConcept), 0644)
+	defer func(root string) { config.ProjectRoot = root }(config.ProjectRoot)
+	config.ProjectRoot = projectRoot
+	sig := &infoGatherer.SpecInfoGatherer{SpecDirs: []string{specsDir}}
+	sig.Init()
+	client, stop := serveDaemon(c, newDaemonService(newGaugeAPIMessageHandler(sig)))
+	defer stop()
+
+	complete := func(line, character int, content string) *daemon.CompletionResponse {
+		response, err := client.Complete(context.Background(), &daemon.CompletionRequest{File: "specs/login.spec", Line: int32(line), Character: int32(character), Content: content})
+		c.Assert(err, IsNil)
+		return response
+	}
+
+	steps := complete(11, len("* Open"), "")
+	c.Assert(steps.Context, Equals, daemon.CompletionResponse_STEP)
+	c.Assert(steps.Prefix, Equals, "Open")
+	c.Assert(steps.Candidates[0], DeepEquals, &daemon.CompletionCandidate{Text: "Open the dashboard", Kind: daemon.CompletionCandidate_STEP, Score: 4})
+
+	concepts := complete(12, len("* Login and"), completionSpec+"* Login and\n")
+	c.Assert(concepts.Candidates[0], DeepEquals, &daemon.CompletionCandidate{Text: "Login and open <page>", Kind: daemon.CompletionCandidate_CONCEPT, Score: 4})
+
+	columns := complete(10, len("* Login as <us"), "")
+	c.Assert(columns.Context, Equals, daemon.CompletionResponse_PARAMETER)
+	c.Assert(columns.Candidates, DeepEquals, []*daemon.CompletionCandidate{{Text: "user", Kind: daemon.CompletionCandidate_PARAMETER, Score: 4}})
+
+	tags := complete(3, len("tags: smoke, "), "")
+	c.Assert(tags.Context, Equals, daemon.CompletionResponse_TAG)
+	c.Assert(tags.Candidates, DeepEquals, []*daemon.CompletionCandidate{{Text: "login", Kind: daemon.CompletionCandidate_TAG, Score: 4}})
+}
@@ -0,0 +1,762 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: daemon.proto
+
+package daemon
+
+import (
+	fmt "fmt"
+	gauge_messages "github.com/getgauge/gauge/gauge_messages"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type CompletionResponse_Context int32
+
+const (
+	CompletionResponse_NONE      CompletionResponse_Context = 0
+	CompletionResponse_STEP      CompletionResponse_Context = 1
+	CompletionResponse_PARAMETER CompletionResponse_Context = 2
+	CompletionResponse_TAG       CompletionResponse_Context = 3
+)
+
+var CompletionResponse_Context_name = map[int32]string{
+	0: "NONE",
+	1: "STEP",
+	2: "PARAMETER",
+	3: "TAG",
+}
+
+var CompletionResponse_Context_value = map[string]int32{
+	"NONE":      0,
+	"STEP":      1,
+	"PARAMETER": 2,
+	"TAG":       3,
+}
+
+func (x CompletionResponse_Context) String() string {
+	return proto.EnumName(CompletionResponse_Context_name, int32(x))
+}
+
+func (CompletionResponse_Context) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{5, 0}
+}
+
+type CompletionCandidate_Kind int32
+
+const (
+	CompletionCandidate_STEP      CompletionCandidate_Kind = 0
+	CompletionCandidate_CONCEPT   CompletionCandidate_Kind = 1
+	CompletionCandidate_PARAMETER CompletionCandidate_Kind = 2
+	CompletionCandidate_TAG       CompletionCandidate_Kind = 3
+)
+
+var CompletionCandidate_Kind_name = map[int32]string{
+	0: "STEP",
+	1: "CONCEPT",
+	2: "PARAMETER",
+	3: "TAG",
+}
+
+var CompletionCandidate_Kind_value = map[string]int32{
+	"STEP":      0,
+	"CONCEPT":   1,
+	"PARAMETER": 2,
+	"TAG":       3,
+}
+
+func (x CompletionCandidate_Kind) String() string {
+	return proto.EnumName(CompletionCandidate_Kind_name, int32(x))
+}
+
+func (CompletionCandidate_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{6, 0}
+}
+
+// / Subscribes to the changes of the spec and concept files of the project.
+type FileChangesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileChangesRequest) Reset()         { *m = FileChangesRequest{} }
+func (m *FileChangesRequest) String() string { return proto.CompactTextString(m) }
+func (*FileChangesRequest) ProtoMessage()    {}
+func (*FileChangesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{0}
+}
+
+func (m *FileChangesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileChangesRequest.Unmarshal(m, b)
+}
+func (m *FileChangesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileChangesRequest.Marshal(b, m, deterministic)
+}
+func (m *FileChangesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileChangesRequest.Merge(m, src)
+}
+func (m *FileChangesRequest) XXX_Size() int {
+	return xxx_messageInfo_FileChangesRequest.Size(m)
+}
+func (m *FileChangesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileChangesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileChangesRequest proto.InternalMessageInfo
+
+// / Specs and concepts reloaded after files of the project changed on disk. Specs re-parsed because a concept they
+// / use has changed are included.
+type FileChanges struct {
+	// / The specs reloaded from the changed spec files, with their parse errors
+	Specs []*gauge_messages.SpecsResponse_SpecDetail `protobuf:"bytes,1,rep,name=specs,proto3" json:"specs,omitempty"`
+	// / The spec files removed
+	RemovedSpecs []string `protobuf:"bytes,2,rep,name=removedSpecs,proto3" json:"removedSpecs,omitempty"`
+	// / Tells if a concept file changed, in which case concepts has all the concepts of the project
+	ConceptsChanged      bool                          `protobuf:"varint,3,opt,name=conceptsChanged,proto3" json:"conceptsChanged,omitempty"`
+	Concepts             []*gauge_messages.ConceptInfo `protobuf:"bytes,4,rep,name=concepts,proto3" json:"concepts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *FileChanges) Reset()         { *m = FileChanges{} }
+func (m *FileChanges) String() string { return proto.CompactTextString(m) }
+func (*FileChanges) ProtoMessage()    {}
+func (*FileChanges) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{1}
+}
+
+func (m *FileChanges) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileChanges.Unmarshal(m, b)
+}
+func (m *FileChanges) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileChanges.Marshal(b, m, deterministic)
+}
+func (m *FileChanges) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileChanges.Merge(m, src)
+}
+func (m *FileChanges) XXX_Size() int {
+	return xxx_messageInfo_FileChanges.Size(m)
+}
+func (m *FileChanges) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileChanges.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileChanges proto.InternalMessageInfo
+
+func (m *FileChanges) GetSpecs() []*gauge_messages.SpecsResponse_SpecDetail {
+	if m != nil {
+		return m.Specs
+	}
+	return nil
+}
+
+func (m *FileChanges) GetRemovedSpecs() []string {
+	if m != nil {
+		return m.RemovedSpecs
+	}
+	return nil
+}
+
+func (m *FileChanges) GetConceptsChanged() bool {
+	if m != nil {
+		return m.ConceptsChanged
+	}
+	return false
+}
+
+func (m *FileChanges) GetConcepts() []*gauge_messages.ConceptInfo {
+	if m != nil {
+		return m.Concepts
+	}
+	return nil
+}
+
+// / Specs to run, with the options of gauge run
+type ExecuteSpecsRequest struct {
+	// / The spec files or directories to run. A scenario is selected with the line number of its heading, as in
+	// / specs/login.spec:12. All the specs of the project are run if none are given.
+	Specs []string `protobuf:"bytes,1,rep,name=specs,proto3" json:"specs,omitempty"`
+	// / The tag expression filtering the scenarios to run
+	Tags     string `protobuf:"bytes,2,opt,name=tags,proto3" json:"tags,omitempty"`
+	Parallel bool   `protobuf:"varint,3,opt,name=parallel,proto3" json:"parallel,omitempty"`
+	// / The number of parallel execution streams. The number of cores is used if it is not given.
+	Streams              int32    `protobuf:"varint,4,opt,name=streams,proto3" json:"streams,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExecuteSpecsRequest) Reset()         { *m = ExecuteSpecsRequest{} }
+func (m *ExecuteSpecsRequest) String() string { return proto.CompactTextString(m) }
+func (*ExecuteSpecsRequest) ProtoMessage()    {}
+func (*ExecuteSpecsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{2}
+}
+
+func (m *ExecuteSpecsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecuteSpecsRequest.Unmarshal(m, b)
+}
+func (m *ExecuteSpecsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecuteSpecsRequest.Marshal(b, m, deterministic)
+}
+func (m *ExecuteSpecsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecuteSpecsRequest.Merge(m, src)
+}
+func (m *ExecuteSpecsRequest) XXX_Size() int {
+	return xxx_messageInfo_ExecuteSpecsRequest.Size(m)
+}
+func (m *ExecuteSpecsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecuteSpecsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecuteSpecsRequest proto.InternalMessageInfo
+
+func (m *ExecuteSpecsRequest) GetSpecs() []string {
+	if m != nil {
+		return m.Specs
+	}
+	return nil
+}
+
+func (m *ExecuteSpecsRequest) GetTags() string {
+	if m != nil {
+		return m.Tags
+	}
+	return ""
+}
+
+func (m *ExecuteSpecsRequest) GetParallel() bool {
+	if m != nil {
+		return m.Parallel
+	}
+	return false
+}
+
+func (m *ExecuteSpecsRequest) GetStreams() int32 {
+	if m != nil {
+		return m.Streams
+	}
+	return 0
+}
+
+// / An event of the execution, or its end
+type ExecuteSpecsResponse struct {
+	// / One execution event in the machine readable (--machine-readable) JSON format
+	Event string `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	// / Set on the last response of the stream, once the execution is over
+	Finished bool `protobuf:"varint,2,opt,name=finished,proto3" json:"finished,omitempty"`
+	// / The exit code of the execution, as gauge run would exit with
+	ExitCode             int32    `protobuf:"varint,3,opt,name=exitCode,proto3" json:"exitCode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExecuteSpecsResponse) Reset()         { *m = ExecuteSpecsResponse{} }
+func (m *ExecuteSpecsResponse) String() string { return proto.CompactTextString(m) }
+func (*ExecuteSpecsResponse) ProtoMessage()    {}
+func (*ExecuteSpecsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{3}
+}
+
+func (m *ExecuteSpecsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecuteSpecsResponse.Unmarshal(m, b)
+}
+func (m *ExecuteSpecsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecuteSpecsResponse.Marshal(b, m, deterministic)
+}
+func (m *ExecuteSpecsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecuteSpecsResponse.Merge(m, src)
+}
+func (m *ExecuteSpecsResponse) XXX_Size() int {
+	return xxx_messageInfo_ExecuteSpecsResponse.Size(m)
+}
+func (m *ExecuteSpecsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecuteSpecsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecuteSpecsResponse proto.InternalMessageInfo
+
+func (m *ExecuteSpecsResponse) GetEvent() string {
+	if m != nil {
+		return m.Event
+	}
+	return ""
+}
+
+func (m *ExecuteSpecsResponse) GetFinished() bool {
+	if m != nil {
+		return m.Finished
+	}
+	return false
+}
+
+func (m *ExecuteSpecsResponse) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+// / A position in a spec or concept file to complete at
+type CompletionRequest struct {
+	// / The file, absolute or relative to the project root
+	File string `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	// / The line of the cursor, starting at 1
+	Line int32 `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`
+	// / The offset of the cursor in the line
+	Character int32 `protobuf:"varint,3,opt,name=character,proto3" json:"character,omitempty"`
+	// / The content of a file with unsaved changes. The file is read from disk if it is not given.
+	Content              string   `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompletionRequest) Reset()         { *m = CompletionRequest{} }
+func (m *CompletionRequest) String() string { return proto.CompactTextString(m) }
+func (*CompletionRequest) ProtoMessage()    {}
+func (*CompletionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{4}
+}
+
+func (m *CompletionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompletionRequest.Unmarshal(m, b)
+}
+func (m *CompletionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompletionRequest.Marshal(b, m, deterministic)
+}
+func (m *CompletionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompletionRequest.Merge(m, src)
+}
+func (m *CompletionRequest) XXX_Size() int {
+	return xxx_messageInfo_CompletionRequest.Size(m)
+}
+func (m *CompletionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompletionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompletionRequest proto.InternalMessageInfo
+
+func (m *CompletionRequest) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *CompletionRequest) GetLine() int32 {
+	if m != nil {
+		return m.Line
+	}
+	return 0
+}
+
+func (m *CompletionRequest) GetCharacter() int32 {
+	if m != nil {
+		return m.Character
+	}
+	return 0
+}
+
+func (m *CompletionRequest) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+// / The candidates for the context at the cursor
+type CompletionResponse struct {
+	// / Tells if the cursor is at a step, a parameter of a step or a tag. There are no candidates elsewhere.
+	Context CompletionResponse_Context `protobuf:"varint,1,opt,name=context,proto3,enum=gauge.daemon.CompletionResponse_Context" json:"context,omitempty"`
+	// / What has been typed of the candidate so far
+	Prefix string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// / The candidates matching the prefix, the best match first
+	Candidates           []*CompletionCandidate `protobuf:"bytes,3,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *CompletionResponse) Reset()         { *m = CompletionResponse{} }
+func (m *CompletionResponse) String() string { return proto.CompactTextString(m) }
+func (*CompletionResponse) ProtoMessage()    {}
+func (*CompletionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{5}
+}
+
+func (m *CompletionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompletionResponse.Unmarshal(m, b)
+}
+func (m *CompletionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompletionResponse.Marshal(b, m, deterministic)
+}
+func (m *CompletionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompletionResponse.Merge(m, src)
+}
+func (m *CompletionResponse) XXX_Size() int {
+	return xxx_messageInfo_CompletionResponse.Size(m)
+}
+func (m *CompletionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompletionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompletionResponse proto.InternalMessageInfo
+
+func (m *CompletionResponse) GetContext() CompletionResponse_Context {
+	if m != nil {
+		return m.Context
+	}
+	return CompletionResponse_NONE
+}
+
+func (m *CompletionResponse) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *CompletionResponse) GetCandidates() []*CompletionCandidate {
+	if m != nil {
+		return m.Candidates
+	}
+	return nil
+}
+
+// / A step text, concept heading, data table column or static parameter value, or tag
+type CompletionCandidate struct {
+	Text string                   `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Kind CompletionCandidate_Kind `protobuf:"varint,2,opt,name=kind,proto3,enum=gauge.daemon.CompletionCandidate_Kind" json:"kind,omitempty"`
+	// / The higher the score, the better the candidate matches the prefix
+	Score                int32    `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompletionCandidate) Reset()         { *m = CompletionCandidate{} }
+func (m *CompletionCandidate) String() string { return proto.CompactTextString(m) }
+func (*CompletionCandidate) ProtoMessage()    {}
+func (*CompletionCandidate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ec90cbc4aa12fc6, []int{6}
+}
+
+func (m *CompletionCandidate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompletionCandidate.Unmarshal(m, b)
+}
+func (m *CompletionCandidate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompletionCandidate.Marshal(b, m, deterministic)
+}
+func (m *CompletionCandidate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompletionCandidate.Merge(m, src)
+}
+func (m *CompletionCandidate) XXX_Size() int {
+	return xxx_messageInfo_CompletionCandidate.Size(m)
+}
+func (m *CompletionCandidate) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompletionCandidate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompletionCandidate proto.InternalMessageInfo
+
+func (m *CompletionCandidate) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+func (m *CompletionCandidate) GetKind() CompletionCandidate_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return CompletionCandidate_STEP
+}
+
+func (m *CompletionCandidate) GetScore() int32 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("gauge.daemon.CompletionResponse_Context", CompletionResponse_Context_name, CompletionResponse_Context_value)
+	proto.RegisterEnum("gauge.daemon.CompletionCandidate_Kind", CompletionCandidate_Kind_name, CompletionCandidate_Kind_value)
+	proto.RegisterType((*FileChangesRequest)(nil), "gauge.daemon.FileChangesRequest")
+	proto.RegisterType((*FileChanges)(nil), "gauge.daemon.FileChanges")
+	proto.RegisterType((*ExecuteSpecsRequest)(nil), "gauge.daemon.ExecuteSpecsRequest")
+	proto.RegisterType((*ExecuteSpecsResponse)(nil), "gauge.daemon.ExecuteSpecsResponse")
+	proto.RegisterType((*CompletionRequest)(nil), "gauge.daemon.CompletionRequest")
+	proto.RegisterType((*CompletionResponse)(nil), "gauge.daemon.CompletionResponse")
+	proto.RegisterType((*CompletionCandidate)(nil), "gauge.daemon.CompletionCandidate")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DaemonClient is the client API for Daemon service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DaemonClient interface {
+	// / Streams the specs and concepts reloaded after the files of the project change on disk, from the time of
+	// / subscription until the client cancels it.
+	SubscribeFileChanges(ctx context.Context, in *FileChangesRequest, opts ...grpc.CallOption) (Daemon_SubscribeFileChangesClient, error)
+	// / Runs specs in the daemon, the way gauge run would, and streams the events of the execution back. Only one
+	// / execution runs at a time.
+	ExecuteSpecs(ctx context.Context, in *ExecuteSpecsRequest, opts ...grpc.CallOption) (Daemon_ExecuteSpecsClient, error)
+	// / Gives the completion candidates at a position in a spec or concept file, ranked by how well they match what
+	// / has been typed.
+	Complete(ctx context.Context, in *CompletionRequest, opts ...grpc.CallOption) (*CompletionResponse, error)
+}
+
+type daemonClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDaemonClient(cc *grpc.ClientConn) DaemonClient {
+	return &daemonClient{cc}
+}
+
+func (c *daemonClient) SubscribeFileChanges(ctx context.Context, in *FileChangesRequest, opts ...grpc.CallOption) (Daemon_SubscribeFileChangesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Daemon_serviceDesc.Streams[0], "/gauge.daemon.Daemon/SubscribeFileChanges", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daemonSubscribeFileChangesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Daemon_SubscribeFileChangesClient interface {
+	Recv() (*FileChanges, error)
+	grpc.ClientStream
+}
+
+type daemonSubscribeFileChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *daemonSubscribeFileChangesClient) Recv() (*FileChanges, error) {
+	m := new(FileChanges)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *daemonClient) ExecuteSpecs(ctx context.Context, in *ExecuteSpecsRequest, opts ...grpc.CallOption) (Daemon_ExecuteSpecsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Daemon_serviceDesc.Streams[1], "/gauge.daemon.Daemon/ExecuteSpecs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daemonExecuteSpecsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Daemon_ExecuteSpecsClient interface {
+	Recv() (*ExecuteSpecsResponse, error)
+	grpc.ClientStream
+}
+
+type daemonExecuteSpecsClient struct {
+	grpc.ClientStream
+}
+
+func (x *daemonExecuteSpecsClient) Recv() (*ExecuteSpecsResponse, error) {
+	m := new(ExecuteSpecsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *daemonClient) Complete(ctx context.Context, in *CompletionRequest, opts ...grpc.CallOption) (*CompletionResponse, error) {
+	out := new(CompletionResponse)
+	err := c.cc.Invoke(ctx, "/gauge.daemon.Daemon/Complete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DaemonServer is the server API for Daemon service.
+type DaemonServer interface {
+	// / Streams the specs and concepts reloaded after the files of the project change on disk, from the time of
+	// / subscription until the client cancels it.
+	SubscribeFileChanges(*FileChangesRequest, Daemon_SubscribeFileChangesServer) error
+	// / Runs specs in the daemon, the way gauge run would, and streams the events of the execution back. Only one
+	// / execution runs at a time.
+	ExecuteSpecs(*ExecuteSpecsRequest, Daemon_ExecuteSpecsServer) error
+	// / Gives the completion candidates at a position in a spec or concept file, ranked by how well they match what
+	// / has been typed.
+	Complete(context.Context, *CompletionRequest) (*CompletionResponse, error)
+}
+
+func RegisterDaemonServer(s *grpc.Server, srv DaemonServer) {
+	s.RegisterService(&_Daemon_serviceDesc, srv)
+}
+
+func _Daemon_SubscribeFileChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FileChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).SubscribeFileChanges(m, &daemonSubscribeFileChangesServer{stream})
+}
+
+type Daemon_SubscribeFileChangesServer interface {
+	Send(*FileChanges) error
+	grpc.ServerStream
+}
+
+type daemonSubscribeFileChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *daemonSubscribeFileChangesServer) Send(m *FileChanges) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Daemon_ExecuteSpecs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExecuteSpecsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).ExecuteSpecs(m, &daemonExecuteSpecsServer{stream})
+}
+
+type Daemon_ExecuteSpecsServer interface {
+	Send(*ExecuteSpecsResponse) error
+	grpc.ServerStream
+}
+
+type daemonExecuteSpecsServer struct {
+	grpc.ServerStream
+}
+
+func (x *daemonExecuteSpecsServer) Send(m *ExecuteSpecsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Daemon_Complete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompletionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).Complete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gauge.daemon.Daemon/Complete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).Complete(ctx, req.(*CompletionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Daemon_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "gauge.daemon.Daemon",
+	HandlerType: (*DaemonServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Complete",
+			Handler:    _Daemon_Complete_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeFileChanges",
+			Handler:       _Daemon_SubscribeFileChanges_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExecuteSpecs",
+			Handler:       _Daemon_ExecuteSpecs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "daemon.proto",
+}
+
+func init() { proto.RegisterFile("daemon.proto", fileDescriptor_3ec90cbc4aa12fc6) }
+
+var fileDescriptor_3ec90cbc4aa12fc6 = []byte{
+	// 609 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0xdb, 0x6e, 0xd3, 0x40,
+	0x10, 0xad, 0x1b, 0x27, 0x71, 0xa6, 0xa1, 0x84, 0x6d, 0x84, 0x4c, 0x40, 0x22, 0xf8, 0x01, 0xf9,
+	0x29, 0xaa, 0x8a, 0x2a, 0x24, 0x1e, 0x90, 0x52, 0x37, 0x20, 0x84, 0x7a, 0xd1, 0x36, 0x08, 0x89,
+	0xb7, 0xad, 0x3d, 0x49, 0x57, 0x38, 0x6b, 0xe3, 0xdd, 0x54, 0xf9, 0x03, 0xfe, 0x87, 0x6f, 0xe1,
+	0x2b, 0xf8, 0x0a, 0xb4, 0xbb, 0x76, 0x70, 0x2f, 0xa1, 0x6f, 0x7b, 0x66, 0x67, 0xe6, 0x9c, 0xf1,
+	0x1c, 0x2f, 0x74, 0x13, 0x86, 0x8b, 0x4c, 0x8c, 0xf2, 0x22, 0x53, 0x19, 0xe9, 0xce, 0xd9, 0x72,
+	0x8e, 0x23, 0x1b, 0x1b, 0x74, 0x58, 0xce, 0xed, 0x45, 0xd0, 0x07, 0xf2, 0x81, 0xa7, 0x18, 0x5d,
+	0x31, 0x31, 0x47, 0x49, 0xf1, 0xc7, 0x12, 0xa5, 0x0a, 0x7e, 0x3b, 0xb0, 0x53, 0x0b, 0x93, 0xf7,
+	0xd0, 0x94, 0x39, 0xc6, 0xd2, 0x77, 0x86, 0x8d, 0x70, 0xe7, 0x20, 0x1c, 0xd9, 0x76, 0x0b, 0x94,
+	0x92, 0xcd, 0x51, 0x8e, 0x2e, 0xf4, 0x25, 0x45, 0x99, 0x67, 0x42, 0xa2, 0x41, 0xc7, 0xa8, 0x18,
+	0x4f, 0xa9, 0x2d, 0x23, 0x01, 0x74, 0x0b, 0x5c, 0x64, 0xd7, 0x98, 0x98, 0x4c, 0x7f, 0x7b, 0xd8,
+	0x08, 0x3b, 0xf4, 0x46, 0x8c, 0x84, 0xf0, 0x38, 0xce, 0x44, 0x8c, 0xb9, 0x92, 0x96, 0x36, 0xf1,
+	0x1b, 0x43, 0x27, 0xf4, 0xe8, 0xed, 0x30, 0x79, 0x0b, 0x5e, 0x15, 0xf2, 0x5d, 0x23, 0xe8, 0xf9,
+	0x6d, 0x41, 0x91, 0xbd, 0xff, 0x24, 0x66, 0x19, 0x5d, 0x27, 0x07, 0x4b, 0xd8, 0x9b, 0xac, 0x30,
+	0x5e, 0x2a, 0x2c, 0x05, 0x9b, 0x69, 0x49, 0xbf, 0x3e, 0x5d, 0xa7, 0xd2, 0x4c, 0xc0, 0x55, 0x6c,
+	0xae, 0xb5, 0x3a, 0x61, 0x87, 0x9a, 0x33, 0x19, 0x80, 0x97, 0xb3, 0x82, 0xa5, 0x29, 0xa6, 0xa5,
+	0xb8, 0x35, 0x26, 0x3e, 0xb4, 0xa5, 0x2a, 0x90, 0x2d, 0xb4, 0x28, 0x27, 0x6c, 0xd2, 0x0a, 0x06,
+	0x09, 0xf4, 0x6f, 0xd2, 0xda, 0xef, 0xa4, 0x79, 0xf1, 0x1a, 0x85, 0xf2, 0x1d, 0x43, 0x61, 0x81,
+	0xe6, 0x98, 0x71, 0xc1, 0xe5, 0x15, 0x26, 0x86, 0xdb, 0xa3, 0x6b, 0xac, 0xef, 0x70, 0xc5, 0x55,
+	0x94, 0x25, 0x68, 0xf8, 0x9b, 0x74, 0x8d, 0x03, 0x09, 0x4f, 0xa2, 0x6c, 0x91, 0xa7, 0xa8, 0x78,
+	0x26, 0xaa, 0xd1, 0x08, 0xb8, 0x33, 0x9e, 0x62, 0xc9, 0x60, 0xce, 0x3a, 0x96, 0x72, 0x81, 0xa6,
+	0x79, 0x93, 0x9a, 0x33, 0x79, 0x01, 0x9d, 0xf8, 0x8a, 0x15, 0x2c, 0x56, 0x58, 0x94, 0x9d, 0xff,
+	0x05, 0xf4, 0x68, 0x71, 0x26, 0x94, 0x96, 0xea, 0x9a, 0x46, 0x15, 0x0c, 0xfe, 0x38, 0x40, 0xea,
+	0xac, 0xe5, 0x64, 0x47, 0x65, 0xc1, 0xca, 0xce, 0xb6, 0xbb, 0x76, 0x4c, 0x69, 0xca, 0xbb, 0x25,
+	0xa3, 0xc8, 0xe6, 0xd3, 0xaa, 0x90, 0x3c, 0x85, 0x56, 0x5e, 0xe0, 0x8c, 0xaf, 0xca, 0x0d, 0x94,
+	0x88, 0x8c, 0x01, 0x62, 0x26, 0x12, 0x9e, 0x30, 0x85, 0xd2, 0x6f, 0x98, 0xfd, 0xbf, 0xda, 0xd4,
+	0x3e, 0xaa, 0x32, 0x69, 0xad, 0x28, 0x38, 0x84, 0x76, 0x49, 0x47, 0x3c, 0x70, 0x4f, 0xcf, 0x4e,
+	0x27, 0xbd, 0x2d, 0x7d, 0xba, 0x98, 0x4e, 0xce, 0x7b, 0x0e, 0x79, 0x04, 0x9d, 0xf3, 0x31, 0x1d,
+	0x9f, 0x4c, 0xa6, 0x13, 0xda, 0xdb, 0x26, 0x6d, 0x68, 0x4c, 0xc7, 0x1f, 0x7b, 0x8d, 0xe0, 0x97,
+	0x03, 0x7b, 0xf7, 0xb4, 0x36, 0x4e, 0xa9, 0x46, 0xd5, 0x4e, 0xd1, 0x7d, 0xdf, 0x81, 0xfb, 0x9d,
+	0x0b, 0xbb, 0xc1, 0xdd, 0x83, 0xd7, 0x0f, 0xea, 0x1b, 0x7d, 0xe6, 0x22, 0xa1, 0xa6, 0xc6, 0xf8,
+	0x31, 0xce, 0x8a, 0x6a, 0xc5, 0x16, 0x04, 0x87, 0xe0, 0xea, 0x9c, 0xb5, 0xce, 0x2d, 0xb2, 0x03,
+	0xed, 0xe8, 0xec, 0x34, 0x9a, 0x9c, 0x4f, 0x37, 0x8b, 0x3e, 0xf8, 0xb9, 0x0d, 0xad, 0x63, 0x43,
+	0x4b, 0xbe, 0x40, 0xff, 0x62, 0x79, 0x29, 0xe3, 0x82, 0x5f, 0x62, 0xfd, 0xef, 0x1e, 0xde, 0x54,
+	0x77, 0xf7, 0x3d, 0x18, 0x3c, 0xdb, 0x98, 0xb1, 0xef, 0x90, 0xaf, 0xd0, 0xad, 0xdb, 0x9b, 0xdc,
+	0x5a, 0xc6, 0x3d, 0x7f, 0xdc, 0x20, 0xf8, 0x5f, 0x8a, 0x35, 0xc4, 0xbe, 0x43, 0x4e, 0xc0, 0x2b,
+	0xbf, 0x14, 0x92, 0x97, 0x9b, 0x0d, 0x64, 0x5b, 0x0e, 0x1f, 0x72, 0xd8, 0x91, 0xf7, 0xad, 0x65,
+	0x2f, 0x2f, 0x5b, 0xe6, 0xed, 0x7b, 0xf3, 0x77, 0x00, 0xfc, 0xbf, 0x2f, 0xda, 0x24, 0x05, 0x00,
+	0x00,
+}
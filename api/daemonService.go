@@ -0,0 +1,159 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/getgauge/gauge/api/daemon"
+	"github.com/getgauge/gauge/api/infoGatherer"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/conn"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// fileChangesQueueSize is the number of file changes a subscriber may fall behind by before it is disconnected
+const fileChangesQueueSize = 100
+
+// daemonService serves the Daemon service of api/daemon, which the clients of gauge daemon subscribe to over gRPC.
+type daemonService struct {
+	handler     *gaugeAPIMessageHandler
+	mutex       sync.Mutex
+	subscribers map[chan *daemon.FileChanges]bool
+	executing   int32
+}
+
+func newDaemonService(handler *gaugeAPIMessageHandler) *daemonService {
+	s := &daemonService{handler: handler, subscribers: make(map[chan *daemon.FileChanges]bool)}
+	if handler.specInfoGatherer != nil {
+		handler.specInfoGatherer.OnChange(s.notifyFilesChanged)
+	}
+	return s
+}
+
+// SubscribeFileChanges streams the specs and concepts reloaded after files change on disk, until the client cancels.
+func (s *daemonService) SubscribeFileChanges(_ *daemon.FileChangesRequest, stream daemon.Daemon_SubscribeFileChangesServer) error {
+	changes := s.subscribe()
+	defer s.unsubscribe(changes)
+	for {
+		select {
+		case c, ok := <-changes:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "The subscriber did not keep up with the file changes")
+			}
+			if err := stream.Send(c); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+func (s *daemonService) subscribe() chan *daemon.FileChanges {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	changes := make(chan *daemon.FileChanges, fileChangesQueueSize)
+	s.subscribers[changes] = true
+	return changes
+}
+
+func (s *daemonService) unsubscribe(changes chan *daemon.FileChanges) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.subscribers, changes)
+}
+
+// notifyFilesChanged sends the reloaded specs and concepts to every subscriber. A change to any concept file sends
+// all the concepts of the project.
+func (s *daemonService) notifyFilesChanged(files []string) {
+	changes := s.fileChanges(files)
+	if len(changes.Specs) == 0 && len(changes.RemovedSpecs) == 0 && !changes.ConceptsChanged {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for subscriber := range s.subscribers {
+		select {
+		case subscriber <- changes:
+		default:
+			logger.Warningf(false, "A subscriber of the file changes is disconnected as it did not keep up with them.")
+			close(subscriber)
+			delete(s.subscribers, subscriber)
+		}
+	}
+}
+
+func (s *daemonService) fileChanges(files []string) *daemon.FileChanges {
+	sig := s.handler.specInfoGatherer
+	changes := &daemon.FileChanges{}
+	var details []*infoGatherer.SpecDetail
+	for _, file := range files {
+		if util.IsConcept(file) {
+			changes.ConceptsChanged = true
+		}
+		if !util.IsSpec(file) {
+			continue
+		}
+		if d := sig.GetAvailableSpecDetails([]string{file}); len(d) > 0 {
+			details = append(details, d...)
+		} else {
+			changes.RemovedSpecs = append(changes.RemovedSpecs, file)
+		}
+	}
+	if len(details) > 0 {
+		changes.Specs = s.handler.createSpecsResponseMessageFor(details).Details
+	}
+	if changes.ConceptsChanged {
+		changes.Concepts = sig.Concepts()
+	}
+	return changes
+}
+
+// startDaemonService serves the Daemon service on the given port of api_listen_address, with the TLS settings of the
+// API. Errors are sent to the error channel of the daemon.
+func startDaemonService(port int, handler *gaugeAPIMessageHandler, errChan chan error) {
+	host := config.APIListenAddress()
+	tlsConfig, err := conn.APITLSConfig(host)
+	if err != nil {
+		errChan <- err
+		return
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		errChan <- err
+		return
+	}
+	var opts []grpc.ServerOption
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	server := grpc.NewServer(opts...)
+	daemon.RegisterDaemonServer(server, newDaemonService(handler))
+	logger.Infof(true, "Gauge daemon serving gRPC on %s", listener.Addr().String())
+	if err := server.Serve(listener); err != nil {
+		errChan <- err
+	}
+}
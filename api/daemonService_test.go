@@ -0,0 +1,92 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"net"
+	"time"
+
+	"github.com/getgauge/gauge/api/daemon"
+	"github.com/getgauge/gauge/api/infoGatherer"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	. "gopkg.in/check.v1"
+)
+
+func serveDaemon(c *C, service *daemonService) (daemon.DaemonClient, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, IsNil)
+	server := grpc.NewServer()
+	daemon.RegisterDaemonServer(server, service)
+	go server.Serve(listener)
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	c.Assert(err, IsNil)
+	return daemon.NewDaemonClient(conn), func() {
+		conn.Close()
+		server.Stop()
+	}
+}
+
+func subscriberCount(s *daemonService) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.subscribers)
+}
+
+func (s *MySuite) TestFileChangesAreSentToAllSubscribers(c *C) {
+	service := newDaemonService(newGaugeAPIMessageHandler(&infoGatherer.SpecInfoGatherer{}))
+	client, stop := serveDaemon(c, service)
+	defer stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var streams []daemon.Daemon_SubscribeFileChangesClient
+	for i := 0; i < 2; i++ {
+		stream, err := client.SubscribeFileChanges(ctx, &daemon.FileChangesRequest{})
+		c.Assert(err, IsNil)
+		streams = append(streams, stream)
+	}
+	for deadline := time.Now().Add(5 * time.Second); subscriberCount(service) < 2; time.Sleep(10 * time.Millisecond) {
+		c.Assert(time.Now().Before(deadline), Equals, true, Commentf("the subscribers did not join"))
+	}
+
+	service.notifyFilesChanged([]string{"removed.spec"})
+
+	for _, stream := range streams {
+		changes, err := stream.Recv()
+		c.Assert(err, IsNil)
+		c.Assert(changes.RemovedSpecs, DeepEquals, []string{"removed.spec"})
+		c.Assert(changes.Specs, HasLen, 0)
+		c.Assert(changes.ConceptsChanged, Equals, false)
+	}
+}
+
+func (s *MySuite) TestSubscriberNotKeepingUpIsDisconnected(c *C) {
+	service := newDaemonService(newGaugeAPIMessageHandler(&infoGatherer.SpecInfoGatherer{}))
+	changes := service.subscribe()
+
+	for i := 0; i <= fileChangesQueueSize; i++ {
+		service.notifyFilesChanged([]string{"removed.spec"})
+	}
+
+	c.Assert(subscriberCount(service), Equals, 0)
+	for i := 0; i < fileChangesQueueSize; i++ {
+		<-changes
+	}
+	_, ok := <-changes
+	c.Assert(ok, Equals, false)
+}
@@ -0,0 +1,85 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"bytes"
+	"sync"
+	"sync/atomic"
+
+	"github.com/getgauge/gauge/api/daemon"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/reporter"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExecuteSpecs runs the specs of an ExecuteSpecsRequest in the daemon and returns the exit code of the run. It is set
+// by the daemon command, which sets the run flags from the request.
+var ExecuteSpecs func(request *daemon.ExecuteSpecsRequest) int
+
+// ExecuteSpecs runs the requested specs and streams the machine readable events of the run to the client, followed
+// by the exit code. Only one execution can run at a time, as it uses the runner and plugins of the project.
+func (s *daemonService) ExecuteSpecs(request *daemon.ExecuteSpecsRequest, stream daemon.Daemon_ExecuteSpecsServer) error {
+	if ExecuteSpecs == nil {
+		return status.Error(codes.Unimplemented, "Executing specs is not supported by this API service")
+	}
+	if request.Parallel && request.Streams < 0 {
+		return status.Errorf(codes.InvalidArgument, "Invalid number of streams %d", request.Streams)
+	}
+	if !atomic.CompareAndSwapInt32(&s.executing, 0, 1) {
+		return status.Error(codes.FailedPrecondition, "An execution is already in progress")
+	}
+	defer atomic.StoreInt32(&s.executing, 0)
+	logger.Infof(false, "Executing specs %v for a daemon client", request.Specs)
+	w := &eventWriter{stream: stream}
+	reporter.AddSink(w)
+	exitCode := ExecuteSpecs(request)
+	reporter.RemoveSink(w)
+	return stream.Send(&daemon.ExecuteSpecsResponse{Finished: true, ExitCode: int32(exitCode)})
+}
+
+// eventWriter sends each line of machine readable execution events as a response to the client. Events are dropped
+// once the client has gone away, without failing the execution.
+type eventWriter struct {
+	stream daemon.Daemon_ExecuteSpecsServer
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+	failed bool
+}
+
+func (w *eventWriter) Write(b []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.buffer.Write(b)
+	for {
+		data := w.buffer.Bytes()
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		if !w.failed && i > 0 {
+			if err := w.stream.Send(&daemon.ExecuteSpecsResponse{Event: string(data[:i])}); err != nil {
+				logger.Debugf(false, "Failed to send execution event to a daemon client. %s", err.Error())
+				w.failed = true
+			}
+		}
+		w.buffer.Next(i + 1)
+	}
+	return len(b), nil
+}
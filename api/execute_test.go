@@ -0,0 +1,105 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"io"
+
+	"github.com/getgauge/gauge/api/daemon"
+	"github.com/getgauge/gauge/api/infoGatherer"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	. "gopkg.in/check.v1"
+)
+
+func receiveExecution(c *C, stream daemon.Daemon_ExecuteSpecsClient) ([]*daemon.ExecuteSpecsResponse, error) {
+	var responses []*daemon.ExecuteSpecsResponse
+	for {
+		r, err := stream.Recv()
+		if err == io.EOF {
+			return responses, nil
+		}
+		if err != nil {
+			return responses, err
+		}
+		responses = append(responses, r)
+	}
+}
+
+func (s *MySuite) TestExecuteSpecsStreamsEventsAndExitCode(c *C) {
+	requests := make(chan *daemon.ExecuteSpecsRequest, 1)
+	defer func(e func(*daemon.ExecuteSpecsRequest) int) { ExecuteSpecs = e }(ExecuteSpecs)
+	ExecuteSpecs = func(r *daemon.ExecuteSpecsRequest) int {
+		requests <- r
+		return 1
+	}
+	client, stop := serveDaemon(c, newDaemonService(newGaugeAPIMessageHandler(&infoGatherer.SpecInfoGatherer{})))
+	defer stop()
+	request := &daemon.ExecuteSpecsRequest{Specs: []string{"specs/foo.spec:4"}, Tags: "smoke", Parallel: true, Streams: 2}
+
+	stream, err := client.ExecuteSpecs(context.Background(), request)
+	c.Assert(err, IsNil)
+	responses, err := receiveExecution(c, stream)
+
+	c.Assert(err, IsNil)
+	c.Assert(responses, HasLen, 1)
+	c.Assert(responses[0].Finished, Equals, true)
+	c.Assert(responses[0].ExitCode, Equals, int32(1))
+	r := <-requests
+	c.Assert(r.Specs, DeepEquals, request.Specs)
+	c.Assert(r.Tags, Equals, "smoke")
+	c.Assert(r.Parallel, Equals, true)
+	c.Assert(r.Streams, Equals, int32(2))
+}
+
+func (s *MySuite) TestExecuteSpecsIsRejectedWhileAnotherExecutionIsRunning(c *C) {
+	defer func(e func(*daemon.ExecuteSpecsRequest) int) { ExecuteSpecs = e }(ExecuteSpecs)
+	ExecuteSpecs = func(r *daemon.ExecuteSpecsRequest) int { return 0 }
+	service := newDaemonService(newGaugeAPIMessageHandler(&infoGatherer.SpecInfoGatherer{}))
+	service.executing = 1
+	client, stop := serveDaemon(c, service)
+	defer stop()
+
+	stream, err := client.ExecuteSpecs(context.Background(), &daemon.ExecuteSpecsRequest{})
+	c.Assert(err, IsNil)
+	_, err = receiveExecution(c, stream)
+
+	c.Assert(status.Code(err), Equals, codes.FailedPrecondition)
+	c.Assert(status.Convert(err).Message(), Equals, "An execution is already in progress")
+}
+
+type executionStream struct {
+	daemon.Daemon_ExecuteSpecsServer
+	events []string
+}
+
+func (s *executionStream) Send(r *daemon.ExecuteSpecsResponse) error {
+	s.events = append(s.events, r.Event)
+	return nil
+}
+
+func (s *MySuite) TestEventWriterSendsEachEventAsAResponse(c *C) {
+	stream := &executionStream{}
+	w := &eventWriter{stream: stream}
+
+	w.Write([]byte(`{"type":"specStart"}` + "\n" + `{"type":"scen`))
+	w.Write([]byte(`arioStart"}` + "\n"))
+
+	c.Assert(stream.events, DeepEquals, []string{`{"type":"specStart"}`, `{"type":"scenarioStart"}`})
+}
@@ -20,6 +20,7 @@ package infoGatherer
 import (
 	"io/ioutil"
 	"path/filepath"
+	"sort"
 	"sync"
 
 	"github.com/fsnotify/fsnotify"
@@ -40,9 +41,15 @@ type SpecInfoGatherer struct {
 	stepsCache        stepsCache
 	paramsCache       paramsCache
 	tagsCache         tagsCache
+	listeners         changeListeners
 	SpecDirs          []string
 }
 
+type changeListeners struct {
+	mutex     sync.RWMutex
+	listeners []func(files []string)
+}
+
 type conceptCache struct {
 	mutex    sync.RWMutex
 	concepts map[string][]*gauge.Concept
@@ -258,10 +265,13 @@ func (s *SpecInfoGatherer) addToStepsCache(fileName string, allSteps []*gauge.St
 }
 
 func (s *SpecInfoGatherer) getParsedSpecs(specFiles []string) []*SpecDetail {
+	// The concept dictionary is shared by all API clients and changes with concept files, so it is guarded by the concepts cache lock.
+	s.conceptsCache.mutex.Lock()
 	if s.conceptDictionary == nil {
 		s.conceptDictionary = gauge.NewConceptDictionary()
 	}
 	parsedSpecs, parseResults := parser.ParseSpecFiles(specFiles, s.conceptDictionary, gauge.NewBuildErrors())
+	s.conceptsCache.mutex.Unlock()
 	specs := make(map[string]*SpecDetail)
 
 	for _, spec := range parsedSpecs {
@@ -338,14 +348,27 @@ func (s *SpecInfoGatherer) OnSpecFileModify(file string) {
 }
 
 func (s *SpecInfoGatherer) OnConceptFileModify(file string) {
+	s.onConceptFileModify(file)
+}
+
+// onConceptFileModify updates the caches for the given concept file and returns the specs that were re-parsed because
+// they use one of its concepts.
+func (s *SpecInfoGatherer) onConceptFileModify(file string) []string {
+	changedConcepts := s.updateConceptsCache(file)
+	return s.reloadSpecsUsing(changedConcepts)
+}
+
+func (s *SpecInfoGatherer) updateConceptsCache(file string) map[string]bool {
 	s.conceptsCache.mutex.Lock()
 	defer s.conceptsCache.mutex.Unlock()
 
 	logger.Infof(false, "Concept file added / modified: %s", file)
+	changedConcepts := conceptStepValues(s.conceptsCache.concepts[file])
 	s.deleteFromConceptDictionary(file)
 	concepts, parseErrors, err := parser.AddConcepts([]string{file}, s.conceptDictionary)
 	if err != nil {
-		logger.Fatalf(true, "Unable to update concepts : %s", err.Error())
+		logger.Errorf(false, "Unable to update concepts : %s", err.Error())
+		return changedConcepts
 	}
 	if len(parseErrors) > 0 {
 		res := &parser.ParseResult{}
@@ -359,11 +382,47 @@ func (s *SpecInfoGatherer) OnConceptFileModify(file string) {
 		c := gauge.Concept{ConceptStep: concept, FileName: file}
 		s.addToConceptsCache(file, &c)
 		stepsFromConcept = append(stepsFromConcept, getStepsFromConcept(&c)...)
+		changedConcepts[concept.Value] = true
 	}
+	s.stepsCache.mutex.Lock()
 	s.addToStepsCache(file, stepsFromConcept)
+	s.stepsCache.mutex.Unlock()
 	s.paramsCache.mutex.Lock()
 	defer s.paramsCache.mutex.Unlock()
 	s.updateParamsCacheFromConcepts(file, s.conceptsCache.concepts[file])
+	return changedConcepts
+}
+
+// reloadSpecsUsing re-parses the cached specs that use any of the given concepts, so that they pick up the changed
+// concept definitions. It returns the files of the re-parsed specs.
+func (s *SpecInfoGatherer) reloadSpecsUsing(concepts map[string]bool) []string {
+	if len(concepts) == 0 {
+		return nil
+	}
+	var files []string
+	s.specsCache.mutex.RLock()
+	for file, detail := range s.specsCache.specDetails {
+		for _, step := range getStepsFromSpec(detail.Spec) {
+			if concepts[step.Value] {
+				files = append(files, file)
+				break
+			}
+		}
+	}
+	s.specsCache.mutex.RUnlock()
+	sort.Strings(files)
+	for _, file := range files {
+		s.OnSpecFileModify(file)
+	}
+	return files
+}
+
+func conceptStepValues(concepts []*gauge.Concept) map[string]bool {
+	values := make(map[string]bool)
+	for _, c := range concepts {
+		values[c.ConceptStep.Value] = true
+	}
+	return values
 }
 
 func (s *SpecInfoGatherer) onSpecFileRemove(file string) {
@@ -379,42 +438,63 @@ func (s *SpecInfoGatherer) removeStepsFromCache(fileName string) {
 	delete(s.stepsCache.steps, fileName)
 }
 
-func (s *SpecInfoGatherer) onConceptFileRemove(file string) {
+func (s *SpecInfoGatherer) onConceptFileRemove(file string) []string {
 	logger.Infof(false, "Concept file removed: %s", file)
 	s.conceptsCache.mutex.Lock()
-	defer s.conceptsCache.mutex.Unlock()
+	removedConcepts := conceptStepValues(s.conceptsCache.concepts[file])
 	s.deleteFromConceptDictionary(file)
 	delete(s.conceptsCache.concepts, file)
 	s.removeStepsFromCache(file)
+	s.conceptsCache.mutex.Unlock()
+	return s.reloadSpecsUsing(removedConcepts)
 }
 
-func (s *SpecInfoGatherer) onFileAdd(watcher *fsnotify.Watcher, file string) {
+func (s *SpecInfoGatherer) onFileAdd(watcher *fsnotify.Watcher, file string) []string {
 	if util.IsDir(file) {
 		addDirToFileWatcher(watcher, file)
 	}
-	s.onFileModify(watcher, file)
+	return s.onFileModify(watcher, file)
 }
 
-func (s *SpecInfoGatherer) onFileModify(watcher *fsnotify.Watcher, file string) {
+func (s *SpecInfoGatherer) onFileModify(watcher *fsnotify.Watcher, file string) []string {
 	if util.IsSpec(file) {
 		s.OnSpecFileModify(file)
+		return []string{file}
 	} else if util.IsConcept(file) {
-		s.OnConceptFileModify(file)
+		return append([]string{file}, s.onConceptFileModify(file)...)
 	}
+	return nil
 }
 
-func (s *SpecInfoGatherer) onFileRemove(watcher *fsnotify.Watcher, file string) {
+func (s *SpecInfoGatherer) onFileRemove(watcher *fsnotify.Watcher, file string) []string {
 	if util.IsSpec(file) {
 		s.onSpecFileRemove(file)
+		return []string{file}
 	} else if util.IsConcept(file) {
-		s.onConceptFileRemove(file)
-	} else {
-		removeWatcherOn(watcher, file)
+		return append([]string{file}, s.onConceptFileRemove(file)...)
 	}
+	removeWatcherOn(watcher, file)
+	return nil
 }
 
-func (s *SpecInfoGatherer) onFileRename(watcher *fsnotify.Watcher, file string) {
-	s.onFileRemove(watcher, file)
+func (s *SpecInfoGatherer) onFileRename(watcher *fsnotify.Watcher, file string) []string {
+	return s.onFileRemove(watcher, file)
+}
+
+// OnChange registers a listener that is called with the spec and concept files that were reloaded after they changed
+// on disk. Specs re-parsed because a concept they use has changed are included.
+func (s *SpecInfoGatherer) OnChange(listener func(files []string)) {
+	s.listeners.mutex.Lock()
+	defer s.listeners.mutex.Unlock()
+	s.listeners.listeners = append(s.listeners.listeners, listener)
+}
+
+func (s *SpecInfoGatherer) notifyChange(files []string) {
+	s.listeners.mutex.RLock()
+	defer s.listeners.mutex.RUnlock()
+	for _, listener := range s.listeners.listeners {
+		listener(files)
+	}
 }
 
 func (s *SpecInfoGatherer) handleEvent(event fsnotify.Event, watcher *fsnotify.Watcher) {
@@ -426,15 +506,19 @@ func (s *SpecInfoGatherer) handleEvent(event fsnotify.Event, watcher *fsnotify.W
 		return
 	}
 	if util.IsSpec(file) || util.IsConcept(file) || util.IsDir(file) {
+		var changed []string
 		switch event.Op {
 		case fsnotify.Create:
-			s.onFileAdd(watcher, file)
+			changed = s.onFileAdd(watcher, file)
 		case fsnotify.Write:
-			s.onFileModify(watcher, file)
+			changed = s.onFileModify(watcher, file)
 		case fsnotify.Rename:
-			s.onFileRename(watcher, file)
+			changed = s.onFileRename(watcher, file)
 		case fsnotify.Remove:
-			s.onFileRemove(watcher, file)
+			changed = s.onFileRemove(watcher, file)
+		}
+		if len(changed) > 0 {
+			s.notifyChange(changed)
 		}
 	}
 }
@@ -578,6 +662,8 @@ func (s *SpecInfoGatherer) Tags() []string {
 
 // SearchConceptDictionary searches for a concept in concept dictionary
 func (s *SpecInfoGatherer) SearchConceptDictionary(stepValue string) *gauge.Concept {
+	s.conceptsCache.mutex.RLock()
+	defer s.conceptsCache.mutex.RUnlock()
 	return s.conceptDictionary.Search(stepValue)
 }
 
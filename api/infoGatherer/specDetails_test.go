@@ -23,6 +23,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/getgauge/gauge/config"
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/util"
@@ -511,6 +512,47 @@ func (s *MySuite) TestAllStepsOnFileRename(c *C) {
 	c.Assert(len(specInfoGatherer.AllSteps(true)), Equals, 2)
 }
 
+func (s *MySuite) TestConceptFileChangeReloadsSpecsUsingIt(c *C) {
+	conceptFile, _ := createFileIn(s.specsDir, "concept.cpt", concept4)
+	conceptFile, _ = filepath.Abs(conceptFile)
+	specFile, _ := createFileIn(s.specsDir, "spec.spec", specWithConcept)
+	specFile, _ = filepath.Abs(specFile)
+	otherSpecFile, _ := createFileIn(s.specsDir, "spec1.spec", spec1)
+	otherSpecFile, _ = filepath.Abs(otherSpecFile)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+	specInfoGatherer.initStepsCache()
+	specInfoGatherer.initParamsCache()
+	specInfoGatherer.initTagsCache()
+	c.Assert(specInfoGatherer.specsCache.specDetails[specFile].Spec.Scenarios[0].Steps[1].IsConcept, Equals, true)
+
+	ioutil.WriteFile(conceptFile, concept1, 0644)
+	changed := specInfoGatherer.onFileModify(nil, conceptFile)
+
+	c.Assert(changed, DeepEquals, []string{conceptFile, specFile})
+	c.Assert(specInfoGatherer.specsCache.specDetails[specFile].Spec.Scenarios[0].Steps[1].IsConcept, Equals, false)
+}
+
+func (s *MySuite) TestHandleEventNotifiesChangeListeners(c *C) {
+	specFile, _ := createFileIn(s.specsDir, "spec1.spec", spec1)
+	specFile, _ = filepath.Abs(specFile)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+	specInfoGatherer.initStepsCache()
+	specInfoGatherer.initParamsCache()
+	specInfoGatherer.initTagsCache()
+	var notified []string
+	specInfoGatherer.OnChange(func(files []string) { notified = files })
+
+	ioutil.WriteFile(specFile, spec2, 0644)
+	specInfoGatherer.handleEvent(fsnotify.Event{Name: specFile, Op: fsnotify.Write}, nil)
+
+	c.Assert(notified, DeepEquals, []string{specFile})
+	c.Assert(len(specInfoGatherer.specsCache.specDetails[specFile].Spec.Scenarios[0].Steps), Equals, 3)
+}
+
 func createFileIn(dir string, fileName string, data []byte) (string, error) {
 	os.MkdirAll(dir, 0755)
 	err := ioutil.WriteFile(filepath.Join(dir, fileName), data, 0644)
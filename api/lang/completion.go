@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"strings"
 
+	"github.com/getgauge/gauge/parser"
 	"github.com/sourcegraph/go-langserver/pkg/lsp"
 	"github.com/sourcegraph/jsonrpc2"
 )
@@ -28,15 +29,14 @@ import (
 type insertTextFormat int
 
 const (
-	text          insertTextFormat = 1
-	snippet       insertTextFormat = 2
-	concept                        = "Concept"
-	step                           = "Step"
-	tag                            = "Tag"
-	tagIdentifier                  = "tags:"
-	emptyString                    = ""
-	colon                          = ":"
-	comma                          = ","
+	text        insertTextFormat = 1
+	snippet     insertTextFormat = 2
+	concept                      = "Concept"
+	step                         = "Step"
+	tag                          = "Tag"
+	emptyString                  = ""
+	colon                        = ":"
+	comma                        = ","
 )
 
 type completionItem struct {
@@ -78,9 +78,13 @@ func completion(req *jsonrpc2.Request) (interface{}, error) {
 }
 
 func isInTagsContext(line int, uri lsp.DocumentURI) bool {
-	if strings.HasPrefix(strings.ToLower(strings.Join(strings.Fields(getLine(uri, line)), "")), tagIdentifier) {
-		return true
-	} else if line != 0 && (endsWithComma(getLine(uri, line-1)) && isInTagsContext(line-1, uri)) {
+	l := strings.ToLower(strings.Join(strings.Fields(getLine(uri, line)), ""))
+	for _, tags := range parser.KeywordSpellings(parser.TagsKeyword) {
+		if strings.HasPrefix(l, strings.ToLower(strings.Join(strings.Fields(tags), ""))+colon) {
+			return true
+		}
+	}
+	if line != 0 && (endsWithComma(getLine(uri, line-1)) && isInTagsContext(line-1, uri)) {
 		return true
 	}
 	return false
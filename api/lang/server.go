@@ -216,6 +216,18 @@ func (h *LangHandler) Handle(ctx context.Context, conn jsonrpc2.JSONRPC2, req *j
 			logDebug(req, err.Error())
 		}
 		return val, err
+	case "gauge/specAST":
+		val, err := specAST(req)
+		if err != nil {
+			logDebug(req, err.Error())
+		}
+		return val, err
+	case "gauge/stepImplementation":
+		val, err := stepImplementation(req)
+		if err != nil {
+			logDebug(req, err.Error())
+		}
+		return val, err
 	case "gauge/specs":
 		val, err := specs()
 		if err != nil {
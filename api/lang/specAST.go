@@ -0,0 +1,57 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package lang
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/getgauge/gauge/ast"
+	"github.com/getgauge/gauge/util"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+type specASTParams struct {
+	TextDocument lsp.TextDocumentIdentifier `json:"textDocument"`
+}
+
+// specASTResult is the syntax tree of a spec given for gauge/specAST requests, with its steps resolved to concepts,
+// along with the errors found while parsing the spec.
+type specASTResult struct {
+	Spec   *ast.Spec    `json:"spec"`
+	Errors []*ast.Error `json:"errors,omitempty"`
+}
+
+func specAST(req *jsonrpc2.Request) (interface{}, error) {
+	var params specASTParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return nil, fmt.Errorf("failed to parse request %s", err)
+	}
+	file := util.ConvertURItoFilePath(params.TextDocument.URI)
+	content, err := getContentFromFileOrDisk(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read file %s", err)
+	}
+	conceptDictionary, err := validateConcepts(make(map[lsp.DocumentURI][]lsp.Diagnostic))
+	if err != nil {
+		return nil, err
+	}
+	spec, errs := ast.ParseSpecTextWithConcepts(content, file, conceptDictionary)
+	return specASTResult{Spec: spec, Errors: errs}, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package lang
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/getgauge/gauge/ast"
+	"github.com/getgauge/gauge/util"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+func TestSpecASTResolvesConcepts(t *testing.T) {
+	setup()
+	openFilesCache.add(util.ConvertPathToURI(conceptFile), "# login as <user>\n* enter <user>\n")
+	specText := `# Spec heading
+tags: smoke
+
+## Scenario heading
+* login as "admin"
+* check
+   |id|
+   |--|
+   |1 |
+`
+	uri := util.ConvertPathToURI(specFile)
+	openFilesCache.add(uri, specText)
+	b, _ := json.Marshal(specASTParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}})
+	p := json.RawMessage(b)
+
+	got, err := specAST(&jsonrpc2.Request{Params: &p})
+
+	if err != nil {
+		t.Fatalf("expected no error. Got: %s", err.Error())
+	}
+	s := got.(specASTResult)
+	if s.Spec.Heading.Text != "Spec heading" || s.Spec.Heading.Position.Line != 1 || !reflect.DeepEqual(s.Spec.Tags.Values, []string{"smoke"}) || len(s.Errors) != 0 {
+		t.Errorf("unexpected spec: %+v", s)
+	}
+	if len(s.Spec.Scenarios) != 1 || s.Spec.Scenarios[0].Heading.Position.Line != 4 || len(s.Spec.Scenarios[0].Steps) != 2 {
+		t.Fatalf("unexpected scenarios: %+v", s.Spec.Scenarios)
+	}
+
+	login := s.Spec.Scenarios[0].Steps[0]
+	if !reflect.DeepEqual(login.Concept, &ast.Pos{File: conceptFile, Line: 1}) {
+		t.Errorf("want concept location %s:1, got %+v", conceptFile, login.Concept)
+	}
+	if len(login.ConceptSteps) != 1 || login.ConceptSteps[0].Value != "enter {}" || login.ConceptSteps[0].Position.Line != 2 {
+		t.Fatalf("unexpected concept steps: %+v", login.ConceptSteps)
+	}
+	wantArgs := []*ast.Arg{{Kind: ast.Static, Text: "admin"}}
+	if !reflect.DeepEqual(login.ConceptSteps[0].Args, wantArgs) {
+		t.Errorf("want resolved args %+v, got %+v", wantArgs, login.ConceptSteps[0].Args)
+	}
+
+	table := s.Spec.Scenarios[0].Steps[1].Args[0].Table
+	if !reflect.DeepEqual(table.Headers, []string{"id"}) || !reflect.DeepEqual(table.Rows, [][]string{{"1"}}) {
+		t.Errorf("unexpected table parameter: %+v", table)
+	}
+}
+
+func TestSpecASTHasParseErrors(t *testing.T) {
+	setup()
+	uri := util.ConvertPathToURI(specFile)
+	openFilesCache.add(uri, "# Spec heading\n## Scenario heading\n* step with <unknown>\n")
+	b, _ := json.Marshal(specASTParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}})
+	p := json.RawMessage(b)
+
+	got, err := specAST(&jsonrpc2.Request{Params: &p})
+
+	if err != nil {
+		t.Fatalf("expected no error. Got: %s", err.Error())
+	}
+	errs := got.(specASTResult).Errors
+	if len(errs) != 1 || errs[0].Position.Line != 3 {
+		t.Errorf("want a parse error on line 3, got %+v", errs)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package lang
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/getgauge/gauge/parser"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+type stepImplementationParams struct {
+	Step string `json:"step"`
+}
+
+// StepImplementation is where a step is implemented, given for gauge/stepImplementation requests. For a step
+// implemented in code, Signatures are the step texts the implementation is registered with, more than one if the
+// step has aliases. For a concept, the location is that of its heading. Line numbers start at 1.
+type StepImplementation struct {
+	StepValue  string   `json:"stepValue"`
+	FileName   string   `json:"fileName"`
+	LineNo     int      `json:"lineNo"`
+	EndLineNo  int      `json:"endLineNo"`
+	Signatures []string `json:"signatures,omitempty"`
+	HasAlias   bool     `json:"hasAlias,omitempty"`
+	IsConcept  bool     `json:"isConcept,omitempty"`
+}
+
+func stepImplementation(req *jsonrpc2.Request) (interface{}, error) {
+	var params stepImplementationParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return nil, fmt.Errorf("failed to parse request %s", err)
+	}
+	stepText := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(params.Step), "*"))
+	if stepText == "" {
+		return nil, fmt.Errorf("step text is empty")
+	}
+	stepValue, err := parser.ExtractStepValueAndParams(stepText, false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid step %s", err)
+	}
+	if concept := provider.SearchConceptDictionary(stepValue.StepValue); concept != nil {
+		return &StepImplementation{
+			StepValue: stepValue.StepValue,
+			FileName:  concept.FileName,
+			LineNo:    concept.ConceptStep.LineNo,
+			EndLineNo: concept.ConceptStep.LineNo,
+			IsConcept: true,
+		}, nil
+	}
+	if lRunner.runner == nil {
+		return nil, fmt.Errorf("runner is not available to find the implementation of %s", stepText)
+	}
+	response, err := getStepNameResponse(stepValue.StepValue)
+	if err != nil {
+		return nil, err
+	}
+	if response == nil || !response.GetIsStepPresent() {
+		return nil, nil
+	}
+	return &StepImplementation{
+		StepValue:  stepValue.StepValue,
+		FileName:   response.GetFileName(),
+		LineNo:     int(response.GetSpan().GetStart()),
+		EndLineNo:  int(response.GetSpan().GetEnd()),
+		Signatures: response.GetStepName(),
+		HasAlias:   response.GetHasAlias(),
+	}, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package lang
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/getgauge/gauge/gauge"
+	gm "github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/runner"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+type noConceptsInfoProvider struct {
+	dummyInfoProvider
+}
+
+func (p noConceptsInfoProvider) SearchConceptDictionary(stepValue string) *gauge.Concept {
+	return nil
+}
+
+func stepImplementationRequest(step string) *jsonrpc2.Request {
+	b, _ := json.Marshal(stepImplementationParams{Step: step})
+	p := json.RawMessage(b)
+	return &jsonrpc2.Request{Params: &p}
+}
+
+func TestStepImplementation(t *testing.T) {
+	provider = &noConceptsInfoProvider{}
+	responses := map[gm.Message_MessageType]interface{}{}
+	responses[gm.Message_StepNameResponse] = &gm.StepNameResponse{
+		IsStepPresent: true,
+		StepName:      []string{"Say <greeting> to <name>", "Greet <name> with <greeting>"},
+		HasAlias:      true,
+		FileName:      "StepImplementation.java",
+		Span:          &gm.Span{Start: 12, End: 15},
+	}
+	lRunner.runner = &runner.GrpcRunner{Client: &mockLspClient{responses: responses}, Timeout: time.Second * 30}
+
+	got, err := stepImplementationRequestResult(t, `* Say "hello" to <name>`)
+	if err != nil {
+		t.Fatalf("Got error %s", err.Error())
+	}
+
+	want := &StepImplementation{
+		StepValue:  "Say {} to {}",
+		FileName:   "StepImplementation.java",
+		LineNo:     12,
+		EndLineNo:  15,
+		Signatures: []string{"Say <greeting> to <name>", "Greet <name> with <greeting>"},
+		HasAlias:   true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want: `%v`,\n got: `%v`", want, got)
+	}
+}
+
+func TestStepImplementationForUnimplementedStep(t *testing.T) {
+	provider = &noConceptsInfoProvider{}
+	responses := map[gm.Message_MessageType]interface{}{}
+	responses[gm.Message_StepNameResponse] = &gm.StepNameResponse{IsStepPresent: false}
+	lRunner.runner = &runner.GrpcRunner{Client: &mockLspClient{responses: responses}, Timeout: time.Second * 30}
+
+	got, err := stepImplementation(stepImplementationRequest("Unimplemented step"))
+	if err != nil {
+		t.Fatalf("Got error %s", err.Error())
+	}
+	if got != nil {
+		t.Errorf("Expected no implementation, got: `%v`", got)
+	}
+}
+
+func TestStepImplementationForConcept(t *testing.T) {
+	provider = &dummyInfoProvider{}
+
+	got, err := stepImplementationRequestResult(t, "concept1")
+	if err != nil {
+		t.Fatalf("Got error %s", err.Error())
+	}
+
+	want := &StepImplementation{StepValue: "concept1", FileName: "concept_uri.cpt", LineNo: 1, EndLineNo: 1, IsConcept: true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want: `%v`,\n got: `%v`", want, got)
+	}
+}
+
+func stepImplementationRequestResult(t *testing.T, step string) (*StepImplementation, error) {
+	got, err := stepImplementation(stepImplementationRequest(step))
+	if err != nil {
+		return nil, err
+	}
+	impl, ok := got.(*StepImplementation)
+	if !ok {
+		t.Fatalf("Expected a step implementation, got: `%v`", got)
+	}
+	return impl, nil
+}
@@ -63,7 +63,8 @@ func (r *mockLspClient) Refactor(ctx context.Context, in *gm.RefactorRequest, op
 	return r.responses[gm.Message_RefactorResponse].(*gm.RefactorResponse), r.err
 }
 func (r *mockLspClient) GetStepName(ctx context.Context, in *gm.StepNameRequest, opts ...grpc.CallOption) (*gm.StepNameResponse, error) {
-	return r.responses[gm.Message_StepNameResponse].(*gm.StepNameResponse), r.err
+	res, _ := r.responses[gm.Message_StepNameResponse].(*gm.StepNameResponse)
+	return res, r.err
 }
 
 func (r *mockLspClient) GetGlobPatterns(ctx context.Context, in *gm.Empty, opts ...grpc.CallOption) (*gm.ImplementationFileGlobPatternResponse, error) {
@@ -0,0 +1,54 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/conn"
+	"github.com/getgauge/gauge/runner"
+)
+
+// newDaemonConnectionHandler listens for the API clients of the daemon on api_listen_address. Clients beyond localhost
+// are served only over TLS, and only if they present a certificate signed by one of the CAs in api_tls_client_ca_file.
+func newDaemonConnectionHandler(port int, apiHandler *gaugeAPIMessageHandler) (*conn.GaugeConnectionHandler, error) {
+	host := config.APIListenAddress()
+	tlsConfig, err := conn.APITLSConfig(host)
+	if err != nil {
+		return nil, err
+	}
+	return conn.NewTLSGaugeConnectionHandler(host, port, tlsConfig, apiHandler)
+}
+
+func startDaemonAPIService(port int, startChannels *runner.StartChannels, apiHandler *gaugeAPIMessageHandler) {
+	gaugeConnectionHandler, err := newDaemonConnectionHandler(port, apiHandler)
+	if err != nil {
+		startChannels.ErrorChan <- fmt.Errorf("Connection error. %s", err.Error())
+		return
+	}
+	if port == 0 {
+		if err := common.SetEnvVariable(common.APIPortEnvVariableName, strconv.Itoa(gaugeConnectionHandler.ConnectionPortNumber())); err != nil {
+			startChannels.ErrorChan <- fmt.Errorf("Failed to set Env variable %s. %s", common.APIPortEnvVariableName, err.Error())
+			return
+		}
+	}
+	go gaugeConnectionHandler.HandleMultipleConnections()
+}
@@ -0,0 +1,134 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/getgauge/gauge/api/infoGatherer"
+	"github.com/getgauge/gauge/conn"
+	. "gopkg.in/check.v1"
+)
+
+// writeCertificate writes a self signed certificate for localhost and its key, and gives the file names.
+func writeCertificate(c *C, dir, name string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	c.Assert(err, IsNil)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	c.Assert(err, IsNil)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	c.Assert(err, IsNil)
+	certFile, keyFile := filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	c.Assert(ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644), IsNil)
+	c.Assert(ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600), IsNil)
+	return certFile, keyFile
+}
+
+func (s *MySuite) TestDaemonRefusesToListenBeyondLocalhostWithoutTLS(c *C) {
+	os.Setenv("api_listen_address", "0.0.0.0")
+	defer os.Unsetenv("api_listen_address")
+
+	_, err := newDaemonConnectionHandler(0, newGaugeAPIMessageHandler(&infoGatherer.SpecInfoGatherer{}))
+
+	c.Assert(err, ErrorMatches, "Listening on 0.0.0.0 needs api_tls_cert_file, api_tls_key_file and api_tls_client_ca_file to be set")
+}
+
+func (s *MySuite) TestDaemonRefusesToListenBeyondLocalhostWithoutClientVerification(c *C) {
+	dir, err := ioutil.TempDir("", "tls")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+	serverCert, serverKey := writeCertificate(c, dir, "daemon")
+	os.Setenv("api_listen_address", "0.0.0.0")
+	os.Setenv("api_tls_cert_file", serverCert)
+	os.Setenv("api_tls_key_file", serverKey)
+	defer func() {
+		os.Unsetenv("api_listen_address")
+		os.Unsetenv("api_tls_cert_file")
+		os.Unsetenv("api_tls_key_file")
+	}()
+
+	_, err = newDaemonConnectionHandler(0, newGaugeAPIMessageHandler(&infoGatherer.SpecInfoGatherer{}))
+
+	c.Assert(err, ErrorMatches, "Listening on 0.0.0.0 needs api_tls_cert_file, api_tls_key_file and api_tls_client_ca_file to be set")
+}
+
+func (s *MySuite) TestDaemonServesOnlyClientsWithVerifiedCertificates(c *C) {
+	dir, err := ioutil.TempDir("", "tls")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+	serverCert, serverKey := writeCertificate(c, dir, "daemon")
+	clientCert, clientKey := writeCertificate(c, dir, "client")
+	tlsConfig, err := conn.ServerTLSConfig(serverCert, serverKey, clientCert)
+	c.Assert(err, IsNil)
+	h, err := conn.NewTLSGaugeConnectionHandler("127.0.0.1", 0, tlsConfig, nil)
+	c.Assert(err, IsNil)
+	address := net.JoinHostPort("127.0.0.1", strconv.Itoa(h.ConnectionPortNumber()))
+	roots := x509.NewCertPool()
+	pem, err := ioutil.ReadFile(serverCert)
+	c.Assert(err, IsNil)
+	roots.AppendCertsFromPEM(pem)
+	accepted := make(chan net.Conn, 2)
+	go func() {
+		for {
+			conn, err := h.AcceptConnection(5*time.Second, make(chan error))
+			if err != nil {
+				return
+			}
+			go conn.(*tls.Conn).Handshake()
+			accepted <- conn
+		}
+	}()
+
+	cert, err := tls.LoadX509KeyPair(clientCert, clientKey)
+	c.Assert(err, IsNil)
+	client, err := tls.Dial("tcp", address, &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{cert}})
+	c.Assert(err, IsNil)
+	client.Close()
+
+	anonymous, err := tls.Dial("tcp", address, &tls.Config{RootCAs: roots})
+	if err == nil {
+		// the server rejects the missing client certificate after the handshake completes on the client side
+		_, err = anonymous.Read(make([]byte, 1))
+		anonymous.Close()
+	}
+	c.Assert(err, NotNil)
+}
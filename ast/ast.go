@@ -0,0 +1,187 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+/*
+Package ast gives the syntax tree of specs and concepts, for tools like linters, generators and IDE plugins.
+Every node knows its position in the source, and the tree can be traversed with Walk or Inspect, or serialized as JSON.
+The nodes do not depend on the types used for execution, so they remain the same as the execution model changes.
+*/
+package ast
+
+import "fmt"
+
+// Pos is the position of a node in a spec or concept file. Lines start at 1.
+type Pos struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+func (p Pos) String() string {
+	return fmt.Sprintf("%s:%d", p.File, p.Line)
+}
+
+// Node is implemented by all the nodes of the tree.
+type Node interface {
+	Pos() Pos
+}
+
+// Spec is a parsed spec file. Items holds the children in the order they appear in the file, and is left out of
+// the JSON as it repeats the other fields.
+type Spec struct {
+	File      string      `json:"file"`
+	Heading   *Heading    `json:"heading,omitempty"`
+	Tags      *Tags       `json:"tags,omitempty"`
+	DataTable *Table      `json:"dataTable,omitempty"`
+	Comments  []*Comment  `json:"comments,omitempty"`
+	Contexts  []*Step     `json:"contexts,omitempty"`
+	Scenarios []*Scenario `json:"scenarios,omitempty"`
+	TearDown  *TearDown   `json:"tearDown,omitempty"`
+	Items     []Node      `json:"-"`
+}
+
+// Scenario is a scenario of a spec. EndLine is the last line belonging to the scenario.
+type Scenario struct {
+	Heading   *Heading   `json:"heading"`
+	Tags      *Tags      `json:"tags,omitempty"`
+	DataTable *Table     `json:"dataTable,omitempty"`
+	Comments  []*Comment `json:"comments,omitempty"`
+	Steps     []*Step    `json:"steps,omitempty"`
+	Items     []Node     `json:"-"`
+	EndLine   int        `json:"endLine"`
+}
+
+// Heading is the heading of a spec or a scenario.
+type Heading struct {
+	Position Pos    `json:"position"`
+	Text     string `json:"text"`
+}
+
+// Comment is free text in a spec or concept file.
+type Comment struct {
+	Position Pos    `json:"position"`
+	Text     string `json:"text"`
+}
+
+// Tags holds the tags of a spec or a scenario, which may be written over multiple lines.
+type Tags struct {
+	Position Pos      `json:"position"`
+	Values   []string `json:"values"`
+}
+
+// Table is a data table or a table parameter of a step. When the table is read from a file,
+// External is set and Source holds the file it is read from.
+type Table struct {
+	Position Pos        `json:"position"`
+	Headers  []string   `json:"headers"`
+	Rows     [][]string `json:"rows"`
+	External bool       `json:"external,omitempty"`
+	Source   string     `json:"source,omitempty"`
+}
+
+// TearDown is the teardown section of a spec and the steps which run after every scenario.
+type TearDown struct {
+	Position Pos     `json:"position"`
+	Steps    []*Step `json:"steps,omitempty"`
+}
+
+// ArgKind is the kind of a step parameter.
+type ArgKind string
+
+const (
+	// Static is a parameter written in quotes.
+	Static ArgKind = "static"
+	// Dynamic is a parameter referring to a column of a data table, or to a parameter of a concept.
+	Dynamic ArgKind = "dynamic"
+	// TableArg is an inline table written below the step.
+	TableArg ArgKind = "table"
+	// SpecialString is a special parameter whose value is read from a file, e.g. <file:data.txt>.
+	SpecialString ArgKind = "special_string"
+	// SpecialTable is a special parameter whose table is read from a csv file, e.g. <table:data.csv>.
+	SpecialTable ArgKind = "special_table"
+)
+
+// Arg is a parameter of a step. Text is the parameter as written in the step. Table is set for table parameters.
+type Arg struct {
+	Kind  ArgKind `json:"kind"`
+	Text  string  `json:"text,omitempty"`
+	Table *Table  `json:"table,omitempty"`
+}
+
+// Step is a step of a spec or of a concept. Text is the step as written, and Value is the step
+// with its parameters replaced by {}. For steps resolved to a concept, Concept is the position of the concept
+// heading, and ConceptSteps are the steps of the concept with its parameters replaced by the arguments of the step.
+type Step struct {
+	Position     Pos     `json:"position"`
+	Text         string  `json:"text"`
+	Value        string  `json:"value"`
+	Args         []*Arg  `json:"args,omitempty"`
+	Concept      *Pos    `json:"concept,omitempty"`
+	ConceptSteps []*Step `json:"conceptSteps,omitempty"`
+}
+
+// ConceptFile is a parsed concept file. Comments holds the comments before the first concept.
+type ConceptFile struct {
+	File     string     `json:"file"`
+	Comments []*Comment `json:"comments,omitempty"`
+	Concepts []*Concept `json:"concepts,omitempty"`
+}
+
+// Concept is a concept heading and the steps it is made of. Params holds the names of its parameters.
+type Concept struct {
+	Position Pos        `json:"position"`
+	Text     string     `json:"text"`
+	Value    string     `json:"value"`
+	Params   []string   `json:"params,omitempty"`
+	Comments []*Comment `json:"comments,omitempty"`
+	Steps    []*Step    `json:"steps,omitempty"`
+	Items    []Node     `json:"-"`
+}
+
+// Pos gives the position of the spec heading, or the beginning of the file when there is none.
+func (s *Spec) Pos() Pos {
+	if s.Heading != nil {
+		return s.Heading.Position
+	}
+	return Pos{File: s.File, Line: 1}
+}
+
+// Pos gives the position of the scenario heading.
+func (s *Scenario) Pos() Pos { return s.Heading.Position }
+
+// Pos gives the position of the heading.
+func (h *Heading) Pos() Pos { return h.Position }
+
+// Pos gives the position of the comment.
+func (c *Comment) Pos() Pos { return c.Position }
+
+// Pos gives the position of the first line of tags.
+func (t *Tags) Pos() Pos { return t.Position }
+
+// Pos gives the position of the table header, or of the table reference for external tables.
+func (t *Table) Pos() Pos { return t.Position }
+
+// Pos gives the position of the teardown separator.
+func (t *TearDown) Pos() Pos { return t.Position }
+
+// Pos gives the position of the step.
+func (s *Step) Pos() Pos { return s.Position }
+
+// Pos gives the beginning of the concept file.
+func (f *ConceptFile) Pos() Pos { return Pos{File: f.File, Line: 1} }
+
+// Pos gives the position of the concept heading.
+func (c *Concept) Pos() Pos { return c.Position }
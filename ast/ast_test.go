@@ -0,0 +1,168 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package ast
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+const specText = `# Spec heading
+tags: api, smoke
+
+A comment
+
+* context step "a"
+
+## Scenario one
+tags: fast
+* step with a table
+   |id|name|
+   |--|----|
+   |1 |foo |
+
+## Scenario two
+* step with "param"
+
+___
+* teardown step
+`
+
+func (s *MySuite) TestParseSpecText(c *C) {
+	spec, errs := ParseSpecText(specText, "foo.spec")
+
+	c.Assert(len(errs), Equals, 0)
+	c.Assert(spec.Heading, DeepEquals, &Heading{Position: Pos{File: "foo.spec", Line: 1}, Text: "Spec heading"})
+	c.Assert(spec.Tags, DeepEquals, &Tags{Position: Pos{File: "foo.spec", Line: 2}, Values: []string{"api", "smoke"}})
+	c.Assert(spec.Comments[0].Position.Line, Equals, 3)
+	c.Assert(spec.Contexts[0], DeepEquals, &Step{Position: Pos{File: "foo.spec", Line: 6}, Text: `context step "a"`, Value: "context step {}", Args: []*Arg{{Kind: Static, Text: "a"}}})
+	c.Assert(len(spec.Scenarios), Equals, 2)
+
+	scn := spec.Scenarios[0]
+	c.Assert(scn.Heading.Text, Equals, "Scenario one")
+	c.Assert(scn.Pos().Line, Equals, 8)
+	c.Assert(scn.Tags.Values, DeepEquals, []string{"fast"})
+	c.Assert(scn.Steps[0].Args[0].Kind, Equals, TableArg)
+	c.Assert(scn.Steps[0].Args[0].Table, DeepEquals, &Table{Position: Pos{File: "foo.spec", Line: 11}, Headers: []string{"id", "name"}, Rows: [][]string{{"1", "foo"}}})
+
+	c.Assert(spec.TearDown.Position.Line, Equals, 18)
+	c.Assert(len(spec.TearDown.Steps), Equals, 1)
+	c.Assert(spec.TearDown.Steps[0].Text, Equals, "teardown step")
+}
+
+func (s *MySuite) TestParseSpecTextWithErrors(c *C) {
+	spec, errs := ParseSpecText("# Spec heading\n## Scenario\n* step with <unknown>\n", "foo.spec")
+
+	c.Assert(spec.Heading.Text, Equals, "Spec heading")
+	c.Assert(len(errs), Not(Equals), 0)
+	c.Assert(errs[0].Position, Equals, Pos{File: "foo.spec", Line: 3})
+}
+
+func (s *MySuite) TestParseConceptsText(c *C) {
+	text := `// about the concepts
+
+# concept with <a> and <b>
+* step <a>
+// between steps
+* another step <b>
+`
+	file, errs := ParseConceptsText(text, "foo.cpt")
+
+	c.Assert(len(errs), Equals, 0)
+	c.Assert(file.Comments[0].Text, Equals, "// about the concepts")
+	c.Assert(len(file.Concepts), Equals, 1)
+	cpt := file.Concepts[0]
+	c.Assert(cpt.Pos(), Equals, Pos{File: "foo.cpt", Line: 3})
+	c.Assert(cpt.Value, Equals, "concept with {} and {}")
+	c.Assert(cpt.Params, DeepEquals, []string{"a", "b"})
+	c.Assert(len(cpt.Steps), Equals, 2)
+	c.Assert(cpt.Steps[1].Args, DeepEquals, []*Arg{{Kind: Dynamic, Text: "b"}})
+	c.Assert(cpt.Comments[0].Position.Line, Equals, 5)
+}
+
+// Blank lines are comments, as in the rest of gauge, so that formatters can retain them.
+func (s *MySuite) TestInspectVisitsNodesInSourceOrder(c *C) {
+	spec, _ := ParseSpecText(specText, "foo.spec")
+	var visited []string
+
+	Inspect(spec, func(n Node) bool {
+		if n != nil {
+			visited = append(visited, fmt.Sprintf("%T:%d", n, n.Pos().Line))
+		}
+		return true
+	})
+
+	c.Assert(visited, DeepEquals, []string{
+		"*ast.Spec:1", "*ast.Heading:1", "*ast.Tags:2", "*ast.Comment:3", "*ast.Comment:4", "*ast.Comment:5", "*ast.Step:6",
+		"*ast.Scenario:8", "*ast.Heading:8", "*ast.Tags:9", "*ast.Step:10", "*ast.Table:11", "*ast.Comment:14",
+		"*ast.Scenario:15", "*ast.Heading:15", "*ast.Step:16",
+		"*ast.TearDown:18", "*ast.Step:19",
+	})
+}
+
+func (s *MySuite) TestInspectSkipsChildren(c *C) {
+	spec, _ := ParseSpecText(specText, "foo.spec")
+	steps := 0
+
+	Inspect(spec, func(n Node) bool {
+		if _, ok := n.(*Step); ok {
+			steps++
+		}
+		_, isScenario := n.(*Scenario)
+		return !isScenario
+	})
+
+	c.Assert(steps, Equals, 2)
+}
+
+func (s *MySuite) TestParseSpecTextWithConceptsResolvesConceptSteps(c *C) {
+	concepts, _ := new(parser.ConceptParser).Parse("# login as <user>\n* enter <user>\n", "foo.cpt")
+	dictionary := gauge.NewConceptDictionary()
+	_, err := parser.AddConcept(concepts, "foo.cpt", dictionary)
+	c.Assert(err, IsNil)
+
+	spec, errs := ParseSpecTextWithConcepts("# Spec\n## Scenario\n* login as \"admin\"\n", "foo.spec", dictionary)
+
+	c.Assert(len(errs), Equals, 0)
+	login := spec.Scenarios[0].Steps[0]
+	c.Assert(login.Concept, DeepEquals, &Pos{File: "foo.cpt", Line: 1})
+	c.Assert(login.ConceptSteps, DeepEquals, []*Step{{Position: Pos{File: "foo.cpt", Line: 2}, Text: "enter <user>", Value: "enter {}",
+		Args: []*Arg{{Kind: Static, Text: "admin"}}}})
+}
+
+func (s *MySuite) TestSpecIsSerializedWithoutItems(c *C) {
+	spec, _ := ParseSpecText("# Spec\n## Scenario\n* step\n", "foo.spec")
+
+	b, err := json.Marshal(spec)
+
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, `{"file":"foo.spec","heading":{"position":{"file":"foo.spec","line":1},"text":"Spec"},`+
+		`"scenarios":[{"heading":{"position":{"file":"foo.spec","line":2},"text":"Scenario"},`+
+		`"steps":[{"position":{"file":"foo.spec","line":3},"text":"step","value":"step"}],"endLine":3}]}`)
+}
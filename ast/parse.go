@@ -0,0 +1,265 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package ast
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+)
+
+// Error is a parse error at a position in a spec or concept file.
+type Error struct {
+	Position Pos    `json:"position"`
+	Message  string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s %s", e.Position, e.Message)
+}
+
+// ParseSpec parses the spec file at the given path. A tree is given for whatever could be parsed,
+// even when there are errors.
+func ParseSpec(file string) (*Spec, []*Error) {
+	text, err := common.ReadFileContents(file)
+	if err != nil {
+		return nil, []*Error{{Position: Pos{File: file}, Message: err.Error()}}
+	}
+	return ParseSpecText(text, file)
+}
+
+// ParseSpecText parses the contents of a spec file. Steps are not resolved against concepts.
+func ParseSpecText(text, file string) (*Spec, []*Error) {
+	spec, res := new(parser.SpecParser).ParseSpecText(text, file)
+	return newSpec(spec, file, nil), newErrors(res.ParseErrors, file)
+}
+
+// ParseSpecTextWithConcepts parses the contents of a spec file, resolving the steps which are concepts of the
+// given dictionary.
+func ParseSpecTextWithConcepts(text, file string, concepts *gauge.ConceptDictionary) (*Spec, []*Error) {
+	spec, res, err := new(parser.SpecParser).Parse(text, concepts, file)
+	if err != nil {
+		return &Spec{File: file}, []*Error{{Position: Pos{File: file}, Message: err.Error()}}
+	}
+	return newSpec(spec, file, concepts), newErrors(res.ParseErrors, file)
+}
+
+// ParseConcepts parses the concept file at the given path.
+func ParseConcepts(file string) (*ConceptFile, []*Error) {
+	text, err := common.ReadFileContents(file)
+	if err != nil {
+		return nil, []*Error{{Position: Pos{File: file}, Message: err.Error()}}
+	}
+	return ParseConceptsText(text, file)
+}
+
+// ParseConceptsText parses the contents of a concept file.
+func ParseConceptsText(text, file string) (*ConceptFile, []*Error) {
+	concepts, res := new(parser.ConceptParser).Parse(text, file)
+	f := &ConceptFile{File: file}
+	for i, c := range concepts {
+		if i == 0 {
+			f.Comments = newComments(c.PreComments, file)
+		}
+		f.Concepts = append(f.Concepts, newConcept(c, file))
+	}
+	return f, newErrors(res.ParseErrors, file)
+}
+
+func newErrors(parseErrors []parser.ParseError, file string) []*Error {
+	var errs []*Error
+	for _, e := range parseErrors {
+		if e.FileName != "" {
+			file = e.FileName
+		}
+		errs = append(errs, &Error{Position: Pos{File: file, Line: e.LineNo}, Message: e.Message})
+	}
+	return errs
+}
+
+func newSpec(spec *gauge.Specification, file string, concepts *gauge.ConceptDictionary) *Spec {
+	s := &Spec{File: file}
+	if spec == nil {
+		return s
+	}
+	if spec.Heading != nil {
+		s.Heading = &Heading{Position: Pos{File: file, Line: spec.Heading.LineNo}, Text: spec.Heading.Value}
+	}
+	for _, item := range spec.Items {
+		switch i := item.(type) {
+		case *gauge.Comment:
+			c := newComment(i, file)
+			s.Comments = append(s.Comments, c)
+			s.Items = append(s.Items, c)
+		case *gauge.Tags:
+			s.Tags = newTags(i, file)
+			s.Items = append(s.Items, s.Tags)
+		case *gauge.DataTable:
+			s.DataTable = newDataTable(i, file)
+			s.Items = append(s.Items, s.DataTable)
+		case *gauge.Scenario:
+			scn := newScenario(i, file, concepts)
+			s.Scenarios = append(s.Scenarios, scn)
+			s.Items = append(s.Items, scn)
+		case *gauge.TearDown:
+			s.TearDown = &TearDown{Position: Pos{File: file, Line: i.LineNo}}
+			s.Items = append(s.Items, s.TearDown)
+		case *gauge.Step:
+			if s.TearDown != nil {
+				s.TearDown.Steps = append(s.TearDown.Steps, newStep(i, file, concepts))
+				continue
+			}
+			step := newStep(i, file, concepts)
+			s.Contexts = append(s.Contexts, step)
+			s.Items = append(s.Items, step)
+		}
+	}
+	return s
+}
+
+func newScenario(scenario *gauge.Scenario, file string, concepts *gauge.ConceptDictionary) *Scenario {
+	s := &Scenario{Heading: &Heading{Position: Pos{File: file, Line: scenario.Heading.LineNo}, Text: scenario.Heading.Value}}
+	if scenario.Span != nil {
+		s.EndLine = scenario.Span.End
+	}
+	for _, item := range scenario.Items {
+		switch i := item.(type) {
+		case *gauge.Comment:
+			c := newComment(i, file)
+			s.Comments = append(s.Comments, c)
+			s.Items = append(s.Items, c)
+		case *gauge.Tags:
+			s.Tags = newTags(i, file)
+			s.Items = append(s.Items, s.Tags)
+		case *gauge.DataTable:
+			s.DataTable = newDataTable(i, file)
+			s.Items = append(s.Items, s.DataTable)
+		case *gauge.Step:
+			step := newStep(i, file, concepts)
+			s.Steps = append(s.Steps, step)
+			s.Items = append(s.Items, step)
+		}
+	}
+	return s
+}
+
+func newConcept(concept *gauge.Step, file string) *Concept {
+	c := &Concept{Position: Pos{File: file, Line: concept.LineNo}, Text: concept.LineText, Value: concept.Value}
+	for _, arg := range concept.Args {
+		c.Params = append(c.Params, arg.Value)
+	}
+	for _, item := range concept.Items {
+		switch i := item.(type) {
+		case *gauge.Comment:
+			comment := newComment(i, file)
+			c.Comments = append(c.Comments, comment)
+			c.Items = append(c.Items, comment)
+		case *gauge.Step:
+			if i == concept {
+				continue
+			}
+			step := newStep(i, file, nil)
+			c.Steps = append(c.Steps, step)
+			c.Items = append(c.Items, step)
+		}
+	}
+	return c
+}
+
+func newStep(step *gauge.Step, file string, concepts *gauge.ConceptDictionary) *Step {
+	s := &Step{Position: Pos{File: file, Line: step.LineNo}, Text: step.LineText, Value: step.Value}
+	args := step.Args
+	if concepts != nil {
+		args = resolvedArgs(step)
+	}
+	for _, arg := range args {
+		a := &Arg{Kind: ArgKind(arg.ArgType), Text: arg.Value}
+		switch arg.ArgType {
+		case gauge.TableArg:
+			a.Text = ""
+			a.Table = newTable(&arg.Table, file, step.LineNo+1)
+		case gauge.SpecialString, gauge.SpecialTable:
+			a.Text = arg.Name
+		}
+		s.Args = append(s.Args, a)
+	}
+	if step.IsConcept && concepts != nil {
+		if c := concepts.Search(step.Value); c != nil {
+			s.Concept = &Pos{File: c.FileName, Line: c.ConceptStep.LineNo}
+		}
+		for _, cs := range step.ConceptSteps {
+			s.ConceptSteps = append(s.ConceptSteps, newStep(cs, cs.FileName, concepts))
+		}
+	}
+	return s
+}
+
+// resolvedArgs gives the arguments of the step, with the parameters of the concept it is in replaced by their values.
+func resolvedArgs(step *gauge.Step) []*gauge.StepArg {
+	if step.Parent == nil {
+		return step.Args
+	}
+	var args []*gauge.StepArg
+	for _, arg := range step.Args {
+		if arg.ArgType == gauge.Dynamic {
+			if a, err := step.Parent.GetArg(arg.Value); err == nil && a != nil {
+				arg = a
+			}
+		}
+		args = append(args, arg)
+	}
+	return args
+}
+
+func newDataTable(table *gauge.DataTable, file string) *Table {
+	t := newTable(&table.Table, file, table.LineNo)
+	if table.IsExternal {
+		t.Position.Line = table.LineNo
+		t.External = true
+		if i := strings.Index(table.Value, ":"); i >= 0 {
+			t.Source = strings.TrimSpace(table.Value[i+1:])
+		}
+	}
+	return t
+}
+
+func newTable(table *gauge.Table, file string, line int) *Table {
+	if table.LineNo != 0 {
+		line = table.LineNo
+	}
+	return &Table{Position: Pos{File: file, Line: line}, Headers: table.Headers, Rows: table.Rows()}
+}
+
+func newComments(comments []*gauge.Comment, file string) []*Comment {
+	var c []*Comment
+	for _, comment := range comments {
+		c = append(c, newComment(comment, file))
+	}
+	return c
+}
+
+func newComment(comment *gauge.Comment, file string) *Comment {
+	return &Comment{Position: Pos{File: file, Line: comment.LineNo}, Text: comment.Value}
+}
+
+func newTags(tags *gauge.Tags, file string) *Tags {
+	return &Tags{Position: Pos{File: file, Line: tags.LineNo}, Values: tags.Values()}
+}
@@ -0,0 +1,82 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package ast
+
+// Visitor is called by Walk for every node. If the visitor w it returns is not nil,
+// Walk visits the children of the node with w, followed by w.Visit(nil).
+type Visitor interface {
+	Visit(node Node) (w Visitor)
+}
+
+// Walk traverses the tree depth first, visiting the children of a node in the order they appear in the file.
+func Walk(v Visitor, node Node) {
+	if v = v.Visit(node); v == nil {
+		return
+	}
+	switch n := node.(type) {
+	case *Spec:
+		if n.Heading != nil {
+			Walk(v, n.Heading)
+		}
+		walkNodes(v, n.Items)
+	case *Scenario:
+		Walk(v, n.Heading)
+		walkNodes(v, n.Items)
+	case *TearDown:
+		for _, s := range n.Steps {
+			Walk(v, s)
+		}
+	case *Step:
+		for _, a := range n.Args {
+			if a.Table != nil {
+				Walk(v, a.Table)
+			}
+		}
+	case *ConceptFile:
+		for _, c := range n.Comments {
+			Walk(v, c)
+		}
+		for _, c := range n.Concepts {
+			Walk(v, c)
+		}
+	case *Concept:
+		walkNodes(v, n.Items)
+	}
+	v.Visit(nil)
+}
+
+func walkNodes(v Visitor, nodes []Node) {
+	for _, n := range nodes {
+		Walk(v, n)
+	}
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses the tree like Walk, calling f for every node and then with nil after the children
+// of the node. The children of a node are skipped when f returns false for it.
+func Inspect(node Node, f func(Node) bool) {
+	Walk(inspector(f), node)
+}
@@ -0,0 +1,67 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/orphans"
+	"github.com/spf13/cobra"
+)
+
+var cleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Kills the runner and plugin processes left behind by previous runs",
+	Long: `Kills the runner and plugin processes left behind by previous runs of gauge which crashed or were killed.
+
+Gauge records the processes started by every run in the pids directory of the gauge home.
+A process is killed only if the run which started it is no longer running. This is also done at the start of every run.`,
+	Example: "  gauge cleanup",
+	Run: func(cmd *cobra.Command, args []string) {
+		killed, err := orphans.Cleanup()
+		if err != nil {
+			exit(err, "")
+		}
+		if len(killed) == 0 {
+			logger.Info(true, "No orphaned runner or plugin processes found.")
+			return
+		}
+		for _, p := range killed {
+			logger.Infof(true, "Killed %s process %d (%s).", p.Kind, p.Pid, p.Command)
+		}
+	},
+	DisableAutoGenTag: true,
+}
+
+func init() {
+	GaugeCmd.AddCommand(cleanupCmd)
+}
+
+// cleanupOrphans kills the processes left behind by previous runs before a new run starts.
+func cleanupOrphans() {
+	killed, err := orphans.Cleanup()
+	if err != nil {
+		logger.Debugf(true, "Failed to clean up processes of previous runs. %s", err.Error())
+		return
+	}
+	for _, p := range killed {
+		logger.Debugf(true, "Killed %s process %d (%s) left behind by a previous run.", p.Kind, p.Pid, p.Command)
+	}
+	if len(killed) > 0 {
+		logger.Infof(true, "Killed %d runner or plugin process(es) left behind by previous runs.", len(killed))
+	}
+}
@@ -20,11 +20,13 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"os"
+	"strings"
 
 	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/conn"
 	"github.com/getgauge/gauge/env"
 	"github.com/getgauge/gauge/execution"
+	"github.com/getgauge/gauge/execution/resultformat"
 	"github.com/getgauge/gauge/filter"
 	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/order"
@@ -41,11 +43,13 @@ const (
 	dirDefault             = "."
 	machineReadableDefault = false
 	gaugeVersionDefault    = false
+	pprofDefault           = ""
 
 	logLevelName        = "log-level"
 	dirName             = "dir"
 	machineReadableName = "machine-readable"
 	gaugeVersionName    = "version"
+	pprofName           = "pprof"
 )
 
 var (
@@ -64,11 +68,18 @@ var (
 		},
 		DisableAutoGenTag: true,
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			util.SetupProxy()
 			skel.CreateSkelFilesIfRequired()
 			track.Init()
 			config.SetProjectRoot(args)
 			setGlobalFlags()
-			initPackageFlags()
+			setConnectionTimeouts()
+			if err := initPackageFlags(); err != nil {
+				exit(err, "")
+			}
+			if err := startProfiling(pprofProfilers); err != nil {
+				exit(err, "")
+			}
 		},
 		PersistentPostRun: notifyTelemetryIfNeeded,
 	}
@@ -76,6 +87,7 @@ var (
 	dir             string
 	machineReadable bool
 	gaugeVersion    bool
+	pprofProfilers  string
 )
 
 type notification struct {
@@ -146,11 +158,13 @@ Complete manual is available at https://manpage.gauge.org/.{{end}}
 	GaugeCmd.PersistentFlags().StringVarP(&logLevel, logLevelName, "l", logLevelDefault, "Set level of logging to debug, info, warning, error or critical")
 	GaugeCmd.PersistentFlags().StringVarP(&dir, dirName, "d", dirDefault, "Set the working directory for the current command, accepts a path relative to current directory")
 	GaugeCmd.PersistentFlags().BoolVarP(&machineReadable, machineReadableName, "m", machineReadableDefault, "Prints output in JSON format")
+	GaugeCmd.PersistentFlags().StringVarP(&pprofProfilers, pprofName, "", pprofDefault, "Write profiles of gauge to .gauge/profiles. Possible options are: `cpu`, `mem`, `trace` or a comma separated combination")
 	GaugeCmd.Flags().BoolVarP(&gaugeVersion, gaugeVersionName, "v", gaugeVersionDefault, "Print Gauge and plugin versions")
 }
 
 func Parse() error {
 	InitHelp(GaugeCmd)
+	defer stopProfiling()
 	return GaugeCmd.Execute()
 }
 
@@ -174,7 +188,18 @@ func setGlobalFlags() {
 	util.SetWorkingDir(dir)
 }
 
-func initPackageFlags() {
+func setConnectionTimeouts() {
+	conn.ReadTimeout = config.ConnectionReadTimeout()
+	conn.WriteTimeout = config.ConnectionWriteTimeout()
+	conn.DialTimeout = config.ConnectionDialTimeout()
+	conn.KeepAlive = config.ConnectionKeepAlive()
+}
+
+func initPackageFlags() error {
+	m, err := suiteMetadata()
+	if err != nil {
+		return err
+	}
 	if parallel {
 		simpleConsole = true
 		reporter.IsParallel = true
@@ -182,23 +207,41 @@ func initPackageFlags() {
 	reporter.SimpleConsoleOutput = simpleConsole
 	reporter.Verbose = verbose
 	reporter.MachineReadable = machineReadable
+	reporter.Mode = reporterMode
+	reporter.CIFormat = ciFormat
+	reporter.ColorMode = colorMode
+	reporter.Theme = theme
+	resultformat.Formats = resultFormats()
+	execution.Metadata = m
+	execution.ReportingPlugins = reportingPlugins
 	execution.MachineReadable = machineReadable
-	execution.ExecuteTags = tags
+	execution.Timeout = timeout
+	execution.ExecuteTags = filter.TagExpression(tags, excludeTags)
 	execution.SetTableRows(rows)
 	validation.TableRows = rows
 	execution.NumberOfExecutionStreams = streams
 	execution.InParallel = parallel
 	execution.Strategy = strategy
-	filter.ExecuteTags = tags
+	execution.RetrySuite = retrySuite
+	filter.ExecuteTags = filter.TagExpression(tags, excludeTags)
 	order.Sorted = sort
+	order.FailuresFirst = failuresFirst
 	filter.Distribute = group
+	filter.Deterministic = strings.ToLower(strategy) == execution.Deterministic
 	filter.NumberOfExecutionStreams = streams
 	reporter.NumberOfExecutionStreams = streams
 	validation.HideSuggestion = hideSuggestion
-	if group != -1 {
+	if group != -1 && !filter.Deterministic {
 		execution.Strategy = execution.Eager
 	}
 	filter.ScenariosName = scenarios
+	filter.SpecPattern = specPattern
+	filter.ScenarioPattern = scenarioPattern
+	filter.ShardIndex = shardIndex
+	filter.ShardCount = shardCount
+	filter.Smart = smart
+	filter.Budget = budget
+	return nil
 }
 
 var exit = func(err error, additionalText string) {
@@ -208,10 +251,13 @@ var exit = func(err error, additionalText string) {
 	if additionalText != "" {
 		logger.Infof(true, additionalText)
 	}
-	os.Exit(0)
+	logger.Exit(0)
 }
 
 func loadEnvAndInitLogger(cmd *cobra.Command) {
+	if e := env.SetVariables(variables); e != nil {
+		logger.Fatal(true, e.Error())
+	}
 	if e := env.LoadEnv(environment); e != nil {
 		logger.Fatalf(true, e.Error())
 	}
@@ -0,0 +1,71 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/execution/compose"
+	"github.com/getgauge/gauge/logger"
+	"github.com/spf13/cobra"
+)
+
+var composeCmd = &cobra.Command{
+	Use:   "compose [flags] <file>",
+	Short: "Execute multiple runs described in a file and merge their summaries",
+	Long: `Execute multiple runs described in a file and merge their summaries.
+
+Every run has a name, and optionally specs, env, tags and other flags of gauge run.
+The runs are executed one after the other, or in parallel if "parallel" is true.
+Each run writes its reports to a directory named after it in the reports directory.`,
+	Example: `  gauge compose runs.json
+
+  {
+    "parallel": false,
+    "runs": [
+      {"name": "api", "specs": ["specs/api"], "env": "api", "tags": "smoke"},
+      {"name": "ui", "specs": ["specs/ui"], "env": "ui", "flags": ["--parallel", "-n", "2"]}
+    ]
+  }`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit(fmt.Errorf("Composition file is required"), cmd.UsageString())
+		}
+		if err := config.SetProjectRoot([]string{}); err != nil {
+			exit(err, cmd.UsageString())
+		}
+		loadEnvAndInitLogger(cmd)
+		c, err := compose.Load(args[0])
+		if err != nil {
+			exit(err, "")
+		}
+		gauge, err := os.Executable()
+		if err != nil {
+			exit(fmt.Errorf("Failed to find the gauge executable. %s", err.Error()), "")
+		}
+		exitCode := compose.Summarize(c.Execute(gauge))
+		logger.Exit(exitCode)
+	},
+	DisableAutoGenTag: true,
+}
+
+func init() {
+	GaugeCmd.AddCommand(composeCmd)
+}
@@ -19,9 +19,12 @@ package cmd
 
 import (
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/getgauge/gauge/config"
 	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/util"
 	"github.com/spf13/cobra"
 )
 
@@ -58,10 +61,66 @@ var (
 		DisableAutoGenTag: true,
 	}
 	list bool
+
+	proxyTestCmd = &cobra.Command{
+		Use:   "proxy-test",
+		Short: "Checks if the plugin repository, update and templates urls are reachable through the proxy",
+		Long: `Checks if the plugin repository, update and templates urls are reachable through the proxy.
+
+The proxy is taken from proxy_url, proxy_user and proxy_password, or from HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.`,
+		Example: `  gauge config proxy-test`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if !testProxy([]string{config.GaugeRepositoryUrl(), config.GaugeUpdateUrl(), config.GaugeTemplatesUrl()}) {
+				logger.Exit(1)
+			}
+		},
+		DisableAutoGenTag: true,
+	}
 )
 
+const proxyTestTimeout = 10 * time.Second
+
+// testProxy requests each url through the proxy gauge uses and prints the outcome. Returns false if any url is unreachable.
+func testProxy(urls []string) bool {
+	client := &http.Client{Timeout: proxyTestTimeout}
+	reachable := true
+	for _, u := range urls {
+		req, err := http.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			logger.Infof(true, "%s\tinvalid url. %s", u, err.Error())
+			reachable = false
+			continue
+		}
+		via := "direct"
+		proxy, err := util.Proxy(req)
+		if err != nil {
+			logger.Infof(true, "%s\tinvalid proxy. %s", u, err.Error())
+			reachable = false
+			continue
+		}
+		if proxy != nil {
+			via = "via " + util.RedactedProxy(proxy)
+		}
+		res, err := client.Do(req)
+		if err != nil {
+			logger.Infof(true, "%s\t%s\tunreachable. %s", u, via, err.Error())
+			reachable = false
+			continue
+		}
+		res.Body.Close()
+		if res.StatusCode == http.StatusProxyAuthRequired {
+			logger.Infof(true, "%s\t%s\tproxy authentication required. Set proxy_user and proxy_password with gauge config", u, via)
+			reachable = false
+			continue
+		}
+		logger.Infof(true, "%s\t%s\t%s", u, via, res.Status)
+	}
+	return reachable
+}
+
 func init() {
 	GaugeCmd.AddCommand(configCmd)
+	configCmd.AddCommand(proxyTestCmd)
 	configCmd.Flags().BoolVarP(&list, "list", "", false, "List all global properties")
 	configCmd.Flags().BoolVarP(&machineReadable, "machine-readable", "m", false, "Print all properties in JSON format")
 }
@@ -21,10 +21,14 @@ import (
 	"os"
 
 	"github.com/getgauge/gauge/api"
+	"github.com/getgauge/gauge/api/daemon"
 	"github.com/getgauge/gauge/api/infoGatherer"
 	"github.com/getgauge/gauge/api/lang"
 	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/execution"
+	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/manifest"
+	"github.com/getgauge/gauge/reporter"
 	"github.com/getgauge/gauge/track"
 	"github.com/getgauge/gauge/util"
 	"github.com/spf13/cobra"
@@ -54,22 +58,40 @@ var (
 				return
 			}
 			go track.ScheduleDaemonTracking("api", language)
+			api.ExecuteSpecs = executeForAPIClient
 			port := ""
 			specs := util.GetSpecDirs()
 			if len(args) > 0 {
 				port = args[0]
 				specs = getSpecsDir(args[1:])
 			}
-			api.RunInBackground(port, specs)
+			api.RunInBackground(port, grpcPort, specs)
 		},
 		PersistentPostRun: func(cmd *cobra.Command, args []string) { /* noop */ },
 		DisableAutoGenTag: true,
 	}
-	lsp bool
+	lsp      bool
+	grpcPort string
 )
 
+// executeForAPIClient runs the specs requested by a client of the daemon, with the options of the request in place
+// of the run flags. The flags set by an earlier request are reset first.
+func executeForAPIClient(request *daemon.ExecuteSpecsRequest) int {
+	tags, parallel, streams = request.Tags, request.Parallel, streamsDefault
+	if request.Streams > 0 {
+		streams = int(request.Streams)
+	}
+	simpleConsole, reporter.IsParallel = simpleConsoleDefault, false
+	if err := initPackageFlags(); err != nil {
+		logger.Errorf(false, "%s", err.Error())
+		return execution.ExecutionFailed
+	}
+	return execution.ExecuteSpecs(getSpecsDir(request.Specs))
+}
+
 func init() {
 	GaugeCmd.AddCommand(daemonCmd)
 	daemonCmd.Flags().BoolVarP(&lsp, "lsp", "", false, "Start language server")
 	daemonCmd.Flags().MarkHidden("lsp")
+	daemonCmd.Flags().StringVarP(&grpcPort, "grpc-port", "", "", "Also serve the gRPC service of the daemon on the given port")
 }
@@ -0,0 +1,76 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/getgauge/gauge/execution/diff"
+	"github.com/spf13/cobra"
+)
+
+const (
+	slowerByName       = "slower-by"
+	minSlowdownName    = "min-slowdown"
+	diffFormatName     = "format"
+	slowerByDefault    = 50
+	minSlowdownDefault = 1000
+	diffFormatDefault  = diff.Text
+)
+
+var (
+	diffCmd = &cobra.Command{
+		Use:   "diff [flags] <before> <after>",
+		Short: "Compare the results of two runs",
+		Long: `Compare the results of two runs, and list the scenarios which are newly failing, passing or skipped
+in the later run, and the ones which got significantly slower. The results of a run are read from its
+event log, logs/events.ndjson, or from the result saved with save_execution_result, .gauge/last_run_result.`,
+		Example: `  gauge diff main/logs/events.ndjson logs/events.ndjson
+  gauge diff --format markdown --slower-by 100 --min-slowdown 5000 before.ndjson after.ndjson`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				exit(fmt.Errorf("Invalid Command. Usage: gauge diff <before> <after>"), cmd.UsageString())
+			}
+			before, err := diff.Load(args[0])
+			if err != nil {
+				exit(err, "")
+			}
+			after, err := diff.Load(args[1])
+			if err != nil {
+				exit(err, "")
+			}
+			d := diff.Compare(before, after, diff.Slowdown{Percent: slowerBy, Min: int64(minSlowdown)})
+			out, err := d.Format(diffFormat)
+			if err != nil {
+				exit(err, cmd.UsageString())
+			}
+			fmt.Println(out)
+		},
+		DisableAutoGenTag: true,
+	}
+	slowerBy    int
+	minSlowdown int
+	diffFormat  string
+)
+
+func init() {
+	GaugeCmd.AddCommand(diffCmd)
+	diffCmd.Flags().IntVarP(&slowerBy, slowerByName, "", slowerByDefault, "Percentage by which a scenario has to get slower to be reported")
+	diffCmd.Flags().IntVarP(&minSlowdown, minSlowdownName, "", minSlowdownDefault, "Milliseconds by which a scenario has to get slower to be reported")
+	diffCmd.Flags().StringVarP(&diffFormat, diffFormatName, "", diffFormatDefault, "Format of the comparison: text, markdown or json")
+}
@@ -0,0 +1,46 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/getgauge/gauge/doctor"
+	"github.com/getgauge/gauge/logger"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Checks the environment for common problems",
+	Long: `Checks the environment for common problems and prints how to fix them.
+
+It checks the gauge home directory, local ports, the connection to the plugin repository and the compatibility of installed plugins.
+Inside a gauge project, it also checks the language runner and its toolchain, the plugins of the project, the env and file permissions.`,
+	Example: "  gauge doctor",
+	Run: func(cmd *cobra.Command, args []string) {
+		if doctor.Print(os.Stdout, doctor.Diagnose()) {
+			logger.Exit(1)
+		}
+	},
+	DisableAutoGenTag: true,
+}
+
+func init() {
+	GaugeCmd.AddCommand(doctorCmd)
+}
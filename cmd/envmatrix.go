@@ -0,0 +1,119 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution/compose"
+	"github.com/getgauge/gauge/logger"
+	"github.com/spf13/cobra"
+)
+
+const (
+	envMatrixName    = "env-matrix"
+	envMatrixDefault = ""
+	// envMatrixKey is the metadata naming the environment of a run of the matrix
+	envMatrixKey = "environment"
+)
+
+var envMatrix string
+
+// matrixEnvironments gives the environments of the matrix, given either as a comma separated list or as a file with
+// an environment on every line. An environment on a line of the file can itself be a comma separated list.
+func matrixEnvironments(matrix string) ([]string, error) {
+	entries := strings.Split(matrix, ",")
+	if common.FileExists(matrix) {
+		contents, err := common.ReadFileContents(matrix)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read %s. %s", matrix, err.Error())
+		}
+		entries = strings.Split(contents, "\n")
+	}
+	var envs []string
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if e == "" || strings.HasPrefix(e, "#") {
+			continue
+		}
+		if seen[e] {
+			return nil, fmt.Errorf("Duplicate environment %s in the --%s flag", e, envMatrixName)
+		}
+		seen[e] = true
+		envs = append(envs, e)
+	}
+	if len(envs) == 0 {
+		return nil, fmt.Errorf("No environments found in the --%s flag", envMatrixName)
+	}
+	return envs, nil
+}
+
+// matrixProcesses gives a run of the args in every environment, which is added to the metadata of the suite result.
+// Every run writes its reports to a directory named after its environment in the reports directory.
+func matrixProcesses(args, envs []string) []*compose.Process {
+	args = withoutFlag(withoutFlag(args, envMatrixName, ""), environmentName, "e")
+	reportsDir := os.Getenv(env.GaugeReportsDir)
+	if reportsDir == "" {
+		reportsDir = "reports"
+	}
+	var processes []*compose.Process
+	for i, e := range envs {
+		a := append(append([]string{}, args...), "--"+skipCommandSaveName, "--"+environmentName, e, "--"+metaName, envMatrixKey+"="+e)
+		processes = append(processes, &compose.Process{
+			Name:       e,
+			Dir:        config.ProjectRoot,
+			Args:       a,
+			Env:        []string{fmt.Sprintf("%s=%s", env.GaugeReportsDir, filepath.Join(reportsDir, strings.NewReplacer(",", "_", "/", "_", "\\", "_").Replace(e)))},
+			StatusFile: filepath.Join(os.TempDir(), fmt.Sprintf("gauge-env-matrix-%d-%d.json", os.Getpid(), i)),
+		})
+	}
+	return processes
+}
+
+// executeEnvMatrix executes the run once in every environment of the matrix, one after the other, summarizes
+// the results of every environment along with the total, and exits with the exit code of the first failed run.
+func executeEnvMatrix(cmd *cobra.Command) {
+	if cmd.Flags().Changed(environmentName) {
+		exit(fmt.Errorf("The --%s flag cannot be used along with --%s", envMatrixName, environmentName), cmd.UsageString())
+	}
+	if failed || repeat {
+		exit(fmt.Errorf("The --%s flag cannot be used along with --%s or --%s", envMatrixName, failedName, repeatName), cmd.UsageString())
+	}
+	envs, err := matrixEnvironments(envMatrix)
+	if err != nil {
+		exit(err, cmd.UsageString())
+	}
+	loadEnvAndInitLogger(cmd)
+	gauge, err := os.Executable()
+	if err != nil {
+		exit(fmt.Errorf("Failed to find the gauge executable. %s", err.Error()), "")
+	}
+	processes := matrixProcesses(os.Args[1:], envs)
+	exitCode := compose.Summarize(compose.ExecuteAll(gauge, processes, false))
+	for _, p := range processes {
+		os.Remove(p.StatusFile)
+	}
+	logger.Exit(exitCode)
+}
@@ -0,0 +1,75 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/formatter"
+	"github.com/getgauge/gauge/logger"
+	"github.com/spf13/cobra"
+)
+
+const (
+	redactName           = "redact"
+	outName              = "out"
+	sensitiveTagsName    = "sensitive-tags"
+	redactDefault        = false
+	outDefault           = "gauge_export"
+	sensitiveTagsDefault = "sensitive"
+)
+
+var (
+	exportCmd = &cobra.Command{
+		Use:   "export [flags] [args]",
+		Short: "Export specs and concepts to share them",
+		Long: `Export specs and concepts to a directory, keeping their paths relative to the project root.
+With --redact, table values and file parameters are replaced by placeholders, and so are the step
+parameters of specs and scenarios tagged with a sensitive tag. The structure of the specs is kept,
+so that it can be attached to public issues without leaking data.`,
+		Example: `  gauge export --redact specs/
+  gauge export --redact --sensitive-tags "secret,pii" --out report specs/login.spec`,
+		Run: func(cmd *cobra.Command, args []string) {
+			loadEnvAndInitLogger(cmd)
+			if err := config.SetProjectRoot(args); err != nil {
+				exit(err, cmd.UsageString())
+			}
+			var redactor *formatter.Redactor
+			if redact {
+				redactor = formatter.NewRedactor(strings.Split(sensitiveTags, ","))
+			}
+			exported, err := formatter.Export(getSpecsDir(args), out, redactor)
+			logger.Infof(true, "Exported %d file(s) to %s", len(exported), out)
+			if err != nil {
+				logger.Fatal(true, err.Error())
+			}
+		},
+		DisableAutoGenTag: true,
+	}
+	redact        bool
+	out           string
+	sensitiveTags string
+)
+
+func init() {
+	GaugeCmd.AddCommand(exportCmd)
+	exportCmd.Flags().BoolVarP(&redact, redactName, "", redactDefault, "Replace table values, file parameters and parameters of sensitive specs with placeholders")
+	exportCmd.Flags().StringVarP(&out, outName, "", outDefault, "Directory to which the specs and concepts are exported")
+	exportCmd.Flags().StringVarP(&sensitiveTags, sensitiveTagsName, "", sensitiveTagsDefault, "Comma separated tags marking specs and scenarios whose parameters are redacted")
+}
@@ -0,0 +1,76 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/graph"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/parser"
+	"github.com/spf13/cobra"
+)
+
+const (
+	graphFormatName    = "format"
+	graphFormatDefault = graph.DOT
+)
+
+var (
+	graphCmd = &cobra.Command{
+		Use:   "graph [flags] [args]",
+		Short: "Export the graph of the concepts used by specs and concepts",
+		Long: `Export the graph of the concepts used by the specs and by other concepts, in the dot format of Graphviz or as json.
+
+Every concept has its fan-in, the number of specs and concepts using it, its fan-out, the number of concepts it uses,
+and its depth, the number of levels of concepts it is made of. Concepts with a high fan-in are drawn bolder.`,
+		Example: `  gauge graph specs/ | dot -Tsvg -o concepts.svg
+  gauge graph --format json`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := config.SetProjectRoot(args); err != nil {
+				exit(err, cmd.UsageString())
+			}
+			loadEnvAndInitLogger(cmd)
+			if graphFormat != graph.DOT && graphFormat != graph.JSON {
+				exit(fmt.Errorf("Invalid input(%s) to --%s flag. It should be %s or %s", graphFormat, graphFormatName, graph.DOT, graph.JSON), cmd.UsageString())
+			}
+			concepts, res, err := parser.ParseConcepts()
+			if err != nil {
+				logger.Fatalf(true, "Unable to parse concepts. %s", err.Error())
+			}
+			specs, failed := parser.ParseSpecs(getSpecsDir(args), concepts, gauge.NewBuildErrors())
+			if failed || !res.Ok {
+				logger.Exit(1)
+			}
+			out, err := graph.Build(specs, concepts).Format(graphFormat)
+			if err != nil {
+				logger.Fatalf(true, "Failed to export the graph. %s", err.Error())
+			}
+			fmt.Println(out)
+		},
+		DisableAutoGenTag: true,
+	}
+	graphFormat string
+)
+
+func init() {
+	GaugeCmd.AddCommand(graphCmd)
+	graphCmd.Flags().StringVarP(&graphFormat, graphFormatName, "", graphFormatDefault, "Format of the graph: dot or json")
+}
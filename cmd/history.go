@@ -0,0 +1,103 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/execution/history"
+	"github.com/spf13/cobra"
+)
+
+const (
+	historyLastDefault     = 10
+	historyScenarioDefault = ""
+
+	historyLastName     = "last"
+	historyScenarioName = "scenario"
+)
+
+var (
+	historyCmd = &cobra.Command{
+		Use:   "history [flags]",
+		Short: "Sum up the recent runs",
+		Long: `Sum up the recent runs, the latest first, from the results of the runs kept in .gauge/runs.db.
+
+Each run is listed with its time, environment, tags, duration and the number of scenarios passed, failed and skipped.
+With --scenario, the outcome of the scenario in each of the recent runs is listed instead.`,
+		Example: `  gauge history
+  gauge history --last 20
+  gauge history --scenario "Login with valid credentials"`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) > 0 {
+				exit(fmt.Errorf("Invalid Command. Usage: gauge history [flags]"), cmd.UsageString())
+			}
+			if historyLast < 1 {
+				exit(fmt.Errorf("Invalid input(%d) to --%s flag", historyLast, historyLastName), cmd.UsageString())
+			}
+			if err := config.SetProjectRoot(args); err != nil {
+				exit(err, cmd.UsageString())
+			}
+			loadEnvAndInitLogger(cmd)
+			runs, err := history.Runs()
+			if err != nil {
+				exit(err, "")
+			}
+			if len(runs) == 0 {
+				exit(fmt.Errorf("No runs have been recorded yet"), "")
+			}
+			recent := history.Recent(runs, historyLast)
+			if historyScenario == "" {
+				printHistory(recent, func() error { return history.WriteRuns(os.Stdout, recent) })
+				return
+			}
+			outcomes := history.Timeline(recent, historyScenario)
+			if len(outcomes) == 0 {
+				exit(fmt.Errorf("Scenario %s is not in the last %d runs", historyScenario, historyLast), "")
+			}
+			printHistory(outcomes, func() error { return history.WriteTimeline(os.Stdout, outcomes) })
+		},
+		DisableAutoGenTag: true,
+	}
+	historyLast     int
+	historyScenario string
+)
+
+// printHistory prints the runs or outcomes as JSON when the output is machine readable, else as a table.
+func printHistory(v interface{}, writeTable func() error) {
+	if machineReadable {
+		b, err := json.MarshalIndent(v, "", "\t")
+		if err != nil {
+			exit(err, "")
+		}
+		fmt.Println(string(b))
+		return
+	}
+	if err := writeTable(); err != nil {
+		exit(err, "")
+	}
+}
+
+func init() {
+	GaugeCmd.AddCommand(historyCmd)
+	historyCmd.Flags().IntVarP(&historyLast, historyLastName, "", historyLastDefault, "Number of recent runs to sum up")
+	historyCmd.Flags().StringVarP(&historyScenario, historyScenarioName, "", historyScenarioDefault, "List the outcome of the scenario with the given heading in the recent runs")
+}
@@ -26,26 +26,56 @@ import (
 
 var (
 	initCmd = &cobra.Command{
-		Use:     "init [flags] <template>",
-		Short:   "Initialize project structure in the current directory",
-		Long:    `Initialize project structure in the current directory.`,
-		Example: "  gauge init java",
+		Use:   "init [flags] <template>",
+		Short: "Initialize project structure in the current directory",
+		Long: `Initialize project structure in the current directory.
+
+The template can be the name of a Gauge template, a git repository or a path to a local directory.
+Occurrences of {{project_name}} in the files of a git or local template are replaced with the name of the current directory,
+and the language runner and plugins listed in its manifest.json are installed.`,
+		Example: `  gauge init java
+  gauge init github.com/org/gauge-template-java-selenium
+  gauge init ../my-template
+  gauge init --language java --plugins html-report,xml-report -d my-project -m`,
 		Run: func(cmd *cobra.Command, args []string) {
 			if templates {
 				projectInit.ListTemplates()
 				return
 			}
-			if len(args) < 1 {
+			if len(args) > 0 {
+				if initTemplate != "" {
+					exit(fmt.Errorf("Template is given both as an argument and with --%s", initTemplateName), cmd.UsageString())
+				}
+				initTemplate = args[0]
+			}
+			if initTemplate == "" && initLanguage == "" {
 				exit(fmt.Errorf("Missing argument <template name>. To see all the templates, run 'gauge list-templates'"), cmd.UsageString())
 			}
-			projectInit.InitializeProject(args[0], machineReadable)
+			projectInit.InitializeProject(projectInit.Options{
+				Template:        initTemplate,
+				Language:        initLanguage,
+				Plugins:         initPlugins,
+				MachineReadable: machineReadable,
+			})
 		},
 		DisableAutoGenTag: true,
 	}
-	templates bool
+	templates    bool
+	initTemplate string
+	initLanguage string
+	initPlugins  []string
+)
+
+const (
+	initTemplateName = "template"
+	initLanguageName = "language"
+	initPluginsName  = "plugins"
 )
 
 func init() {
 	GaugeCmd.AddCommand(initCmd)
 	initCmd.Flags().BoolVarP(&templates, "templates", "t", false, "Lists all available templates")
+	initCmd.Flags().StringVarP(&initTemplate, initTemplateName, "", "", "Template to initialize the project from. Can be a template name, a git repository or a local directory")
+	initCmd.Flags().StringVarP(&initLanguage, initLanguageName, "", "", "Language runner of the project. Defaults to the language of the template")
+	initCmd.Flags().StringSliceVarP(&initPlugins, initPluginsName, "", nil, "Comma separated plugins to register in the project. Replaces the default plugins of a new project")
 }
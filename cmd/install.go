@@ -18,6 +18,8 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/plugin/install"
 	"github.com/spf13/cobra"
@@ -27,15 +29,21 @@ var (
 	installCmd = &cobra.Command{
 		Use:   "install [flags] [plugin]",
 		Short: "Download and install plugin(s)",
-		Long:  `Download and install specified plugin or all plugins in the project's 'manifest.json' file.`,
+		Long: `Download and install specified plugin or all plugins in the project's 'manifest.json' file.
+
+The versions of the project's plugins are recorded in 'gauge.lock', and installed at those versions on other machines.`,
 		Example: `  gauge install
   gauge install java
+  gauge install --frozen
   gauge install java -f gauge-java-0.6.3-darwin.x86_64.zip`,
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) < 1 {
-				install.AllPlugins(machineReadable)
+				install.AllPlugins(machineReadable, frozen)
 				return
 			}
+			if frozen {
+				exit(fmt.Errorf("--frozen installs the plugins of the project and does not take a plugin name"), cmd.UsageString())
+			}
 			if zip != "" {
 				install.HandleInstallResult(install.InstallPluginFromZipFile(zip, args[0]), args[0], true)
 			} else {
@@ -44,15 +52,20 @@ var (
 			if err := install.AddPluginToProject(args[0]); err != nil {
 				logger.Fatalf(true, "Failed to add plugin %s to project : %s\n", args[0], err.Error())
 			}
+			if err := install.LockPlugin(args[0], pVersion); err != nil {
+				logger.Errorf(true, "Failed to lock plugin %s : %s", args[0], err.Error())
+			}
 		},
 		DisableAutoGenTag: true,
 	}
 	zip      string
 	pVersion string
+	frozen   bool
 )
 
 func init() {
 	GaugeCmd.AddCommand(installCmd)
 	installCmd.Flags().StringVarP(&zip, "file", "f", "", "Installs the plugin from zip file")
 	installCmd.Flags().StringVarP(&pVersion, "version", "v", "", "Version of plugin to be installed")
+	installCmd.Flags().BoolVarP(&frozen, "frozen", "", false, "Installs the plugin versions in gauge.lock and fails if it does not match manifest.json")
 }
@@ -0,0 +1,101 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/lint"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/parser"
+	"github.com/spf13/cobra"
+)
+
+const (
+	similarityName    = "similarity"
+	similarityDefault = 80
+	fixName           = "fix"
+	fixDefault        = false
+)
+
+var (
+	lintCmd = &cobra.Command{
+		Use:   "lint [flags] [args]",
+		Short: "Find specs and concepts which can be organized better",
+		Long: `Find specs and concepts which can be organized better.
+
+It reports scenarios whose steps are identical or similar to those of another scenario, with the parameters left out,
+so that they can be consolidated into concepts. It also reports concepts with more steps than lint_max_concept_steps,
+and, if set in the properties, concept files outside lint_concepts_dir or defining more than one concept, as per
+lint_one_concept_per_file. With --fix, such concept files are moved and split. Exits with a non-zero status if a problem is found.`,
+		Example: `  gauge lint specs/
+  gauge lint --similarity 90 specs/
+  gauge lint --fix`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := config.SetProjectRoot(args); err != nil {
+				exit(err, cmd.UsageString())
+			}
+			loadEnvAndInitLogger(cmd)
+			if similarity < 1 || similarity > 100 {
+				exit(fmt.Errorf("Invalid input(%d) to --%s flag. It should be between 1 and 100", similarity, similarityName), cmd.UsageString())
+			}
+			lint.MinSimilarity = similarity
+			lint.ConceptsDir = env.LintConceptsDir()
+			lint.OneConceptPerFile = env.LintOneConceptPerFile()
+			lint.MaxConceptSteps = env.LintMaxConceptSteps()
+			parser.WarningsAsErrors = warningsAsErrors
+			concepts, res, err := parser.ParseConcepts()
+			if err != nil {
+				logger.Fatalf(true, "Unable to parse concepts. %s", err.Error())
+			}
+			specs, failed := parser.ParseSpecs(getSpecsDir(args), concepts, gauge.NewBuildErrors())
+			if failed || !res.Ok {
+				logger.Exit(1)
+			}
+			findings := lint.Lint(specs, concepts)
+			if fix {
+				remaining, fixes, err := lint.Fix(findings, concepts)
+				for _, f := range fixes {
+					fmt.Println(f)
+				}
+				if err != nil {
+					logger.Fatalf(true, "Failed to fix the concept files. %s", err.Error())
+				}
+				findings = remaining
+			}
+			lint.Print(os.Stdout, findings)
+			if len(findings) > 0 {
+				logger.Exit(1)
+			}
+		},
+		DisableAutoGenTag: true,
+	}
+	similarity int
+	fix        bool
+)
+
+func init() {
+	GaugeCmd.AddCommand(lintCmd)
+	lintCmd.Flags().IntVarP(&similarity, similarityName, "", similarityDefault, "Report scenarios sharing at least the given percentage of steps as duplicates")
+	lintCmd.Flags().BoolVarP(&fix, fixName, "", fixDefault, "Move the concept files outside lint_concepts_dir under it, and split those defining more than one concept when lint_one_concept_per_file is set")
+	lintCmd.Flags().BoolVarP(&warningsAsErrors, warningsAsErrorsName, "", warningsAsErrorsDefault, "Fail if the specs or concepts have parse warnings, like deprecated syntax or empty tags")
+}
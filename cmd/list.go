@@ -35,6 +35,9 @@ var (
 		Long:    `List specifications, scenarios or tags for a gauge project`,
 		Example: `  gauge list --tags specs`,
 		Run: func(cmd *cobra.Command, args []string) {
+			if workspaceFile != "" {
+				executeInWorkspace(false)
+			}
 			specs, failed := parser.ParseSpecs(getSpecsDir(args), gauge.NewConceptDictionary(), gauge.NewBuildErrors())
 			if failed {
 				return
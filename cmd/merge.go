@@ -0,0 +1,129 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/formatter"
+	"github.com/getgauge/gauge/logger"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+const (
+	preferName  = "prefer"
+	preferFirst = "first"
+	preferLast  = "last"
+)
+
+var (
+	mergeCmd = &cobra.Command{
+		Use:   "merge [flags] <spec files> --into <spec file>",
+		Short: "Merge specs into a single spec",
+		Long: `Merge the scenarios of specs into a single spec, keeping the tags, metadata and comments of all of them.
+The merged spec has the heading of the first spec. Scenarios which are the same in several specs are added once.
+
+Contexts, teardown steps, data tables, metadata values and scenarios with the same heading which differ between
+the specs are conflicts. They are resolved with the version of the first or last spec having them if --prefer is
+given, otherwise the version to keep is asked for. Inline data tables with the same headers are combined.`,
+		Example: `  gauge merge specs/login.spec specs/signin.spec --into specs/login.spec
+  gauge merge specs/a.spec specs/b.spec --into specs/merged.spec --prefer last`,
+		Run: func(cmd *cobra.Command, args []string) {
+			loadEnvAndInitLogger(cmd)
+			if len(args) < 2 || mergeInto == "" {
+				exit(fmt.Errorf("Merge needs at least two spec files and a spec file to merge them into."), cmd.UsageString())
+			}
+			resolve, err := mergeResolver(prefer)
+			if err != nil {
+				exit(err, cmd.UsageString())
+			}
+			if err := config.SetProjectRoot(args); err != nil {
+				exit(err, cmd.UsageString())
+			}
+			if err := formatter.MergeSpecs(args, mergeInto, resolve); err != nil {
+				logger.Fatal(true, err.Error())
+			}
+			logger.Infof(true, "Merged %s into %s", strings.Join(args, ", "), mergeInto)
+		},
+		DisableAutoGenTag: true,
+	}
+
+	mergeInto string
+	prefer    string
+)
+
+func init() {
+	mergeCmd.Flags().StringVarP(&mergeInto, intoName, "", "", "Spec file into which the specs are merged")
+	mergeCmd.Flags().StringVarP(&prefer, preferName, "", "", "Resolve conflicts with the version of the 'first' or 'last' spec instead of asking")
+	GaugeCmd.AddCommand(mergeCmd)
+}
+
+func mergeResolver(prefer string) (formatter.Resolver, error) {
+	switch prefer {
+	case preferFirst:
+		return formatter.PreferFirst, nil
+	case preferLast:
+		return formatter.PreferLast, nil
+	case "":
+		if !isatty.IsTerminal(os.Stdin.Fd()) {
+			return unresolved, nil
+		}
+		return askToResolve(bufio.NewReader(os.Stdin), os.Stdout), nil
+	}
+	return nil, fmt.Errorf("Invalid value '%s' for --%s. It should be %s or %s.", prefer, preferName, preferFirst, preferLast)
+}
+
+func unresolved(c *formatter.Conflict) (int, error) {
+	var files []string
+	for _, v := range c.Versions {
+		files = append(files, v.Files...)
+	}
+	return 0, fmt.Errorf("Conflicting %s in %s. Use --%s %s or --%s %s to choose which to keep.",
+		c.Subject, strings.Join(files, ", "), preferName, preferFirst, preferName, preferLast)
+}
+
+// askToResolve shows each version of a conflict and reads the number of the one to keep.
+func askToResolve(in *bufio.Reader, out io.Writer) formatter.Resolver {
+	return func(c *formatter.Conflict) (int, error) {
+		fmt.Fprintf(out, "\nConflicting %s:\n", c.Subject)
+		for i, v := range c.Versions {
+			text := v.Text
+			if text == "" {
+				text = "(none)"
+			}
+			fmt.Fprintf(out, "\n[%d] %s\n%s\n", i+1, strings.Join(v.Files, ", "), text)
+		}
+		for {
+			fmt.Fprintf(out, "\nKeep which version? [1-%d]: ", len(c.Versions))
+			line, err := in.ReadString('\n')
+			if n, convErr := strconv.Atoi(strings.TrimSpace(line)); convErr == nil && n >= 1 && n <= len(c.Versions) {
+				return n - 1, nil
+			}
+			if err != nil {
+				return 0, fmt.Errorf("No version chosen for the %s", c.Subject)
+			}
+		}
+	}
+}
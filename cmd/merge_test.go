@@ -0,0 +1,62 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/getgauge/gauge/formatter"
+)
+
+func TestAskToResolveReadsTheChosenVersion(t *testing.T) {
+	var out bytes.Buffer
+	resolve := askToResolve(bufio.NewReader(strings.NewReader("3\nsecond\n2\n")), &out)
+	conflict := &formatter.Conflict{Subject: "contexts", Versions: []*formatter.Version{
+		{Files: []string{"a.spec"}, Text: "* Open the app"},
+		{Files: []string{"b.spec"}},
+	}}
+
+	chosen, err := resolve(conflict)
+
+	if err != nil || chosen != 1 {
+		t.Errorf("Expected the second version to be chosen, got %d, %v", chosen, err)
+	}
+	if !strings.Contains(out.String(), "[1] a.spec\n* Open the app") || !strings.Contains(out.String(), "[2] b.spec\n(none)") {
+		t.Errorf("Expected the versions to be shown, got %q", out.String())
+	}
+}
+
+func TestAskToResolveWithoutAChoice(t *testing.T) {
+	var out bytes.Buffer
+	resolve := askToResolve(bufio.NewReader(strings.NewReader("")), &out)
+
+	_, err := resolve(&formatter.Conflict{Subject: "data table", Versions: []*formatter.Version{{}, {}}})
+
+	if err == nil {
+		t.Error("Expected an error when no version is chosen")
+	}
+}
+
+func TestMergeResolverWithInvalidPreference(t *testing.T) {
+	if _, err := mergeResolver("second"); err == nil {
+		t.Error("Expected an error for an invalid --prefer")
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/move"
+	"github.com/getgauge/gauge/util"
+	"github.com/spf13/cobra"
+)
+
+var mvCmd = &cobra.Command{
+	Use:   "mv [flags] <old path> <new path>",
+	Short: "Move or rename spec and concept files",
+	Long: `Move or rename a spec or concept file, or a directory of them, within the project.
+A file or directory moved to an existing directory is moved into it.
+
+The references to the moved specs are rewritten along with the move, so that their results history, spec durations,
+failures of the last run (for gauge run --failed) and owners are kept. The move is undone if they cannot be rewritten.`,
+	Example: `  gauge mv specs/login.spec specs/auth/sign_in.spec
+  gauge mv specs/payments specs/checkout/`,
+	Run: func(cmd *cobra.Command, args []string) {
+		loadEnvAndInitLogger(cmd)
+		if len(args) != 2 {
+			exit(fmt.Errorf("Move needs the path to move and its new path."), cmd.UsageString())
+		}
+		if err := config.SetProjectRoot(args); err != nil {
+			exit(err, cmd.UsageString())
+		}
+		updated, err := move.Move(args[0], args[1])
+		if err != nil {
+			logger.Fatal(true, err.Error())
+		}
+		for _, f := range updated {
+			logger.Debugf(true, "Updated the references in %s", util.RelPathToProjectRoot(f))
+		}
+		logger.Infof(true, "Moved %s to %s", args[0], args[1])
+	},
+	DisableAutoGenTag: true,
+}
+
+func init() {
+	GaugeCmd.AddCommand(mvCmd)
+}
@@ -0,0 +1,184 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"strings"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/manifest"
+	"github.com/spf13/pflag"
+)
+
+const (
+	cpuProfile   = "cpu"
+	memProfile   = "mem"
+	traceProfile = "trace"
+	profilesDir  = "profiles"
+)
+
+var stopProfilers []func()
+
+// startProfiling starts the given comma separated profilers, writing the profiles to .gauge/profiles in project root.
+func startProfiling(profiles string) error {
+	if strings.TrimSpace(profiles) == "" {
+		return nil
+	}
+	dir := filepath.Join(config.ProjectRoot, common.DotGauge, profilesDir)
+	if err := os.MkdirAll(dir, common.NewDirectoryPermissions); err != nil {
+		return fmt.Errorf("Failed to create directory %s. Reason: %s", dir, err.Error())
+	}
+	logger.OnFatal(stopProfiling)
+	for _, p := range strings.Split(profiles, ",") {
+		switch strings.ToLower(strings.TrimSpace(p)) {
+		case cpuProfile:
+			f, err := createProfileFile(dir, "cpu.pprof")
+			if err != nil {
+				return err
+			}
+			if err := pprof.StartCPUProfile(f); err != nil {
+				f.Close()
+				return fmt.Errorf("Failed to start cpu profile. Reason: %s", err.Error())
+			}
+			stopProfilers = append(stopProfilers, func() {
+				pprof.StopCPUProfile()
+				f.Close()
+			})
+		case memProfile:
+			stopProfilers = append(stopProfilers, func() {
+				f, err := createProfileFile(dir, "mem.pprof")
+				if err != nil {
+					logger.Error(true, err.Error())
+					return
+				}
+				defer f.Close()
+				runtime.GC()
+				if err := pprof.WriteHeapProfile(f); err != nil {
+					logger.Errorf(true, "Failed to write memory profile. Reason: %s", err.Error())
+				}
+			})
+		case traceProfile:
+			f, err := createProfileFile(dir, "trace.out")
+			if err != nil {
+				return err
+			}
+			if err := trace.Start(f); err != nil {
+				f.Close()
+				return fmt.Errorf("Failed to start trace. Reason: %s", err.Error())
+			}
+			stopProfilers = append(stopProfilers, func() {
+				trace.Stop()
+				f.Close()
+			})
+		default:
+			return fmt.Errorf("Invalid input(%s) to --%s flag. Possible options are: %s, %s, %s", p, pprofName, cpuProfile, memProfile, traceProfile)
+		}
+	}
+	logger.Debugf(true, "Writing profiles to %s", dir)
+	return nil
+}
+
+func createProfileFile(dir, name string) (*os.File, error) {
+	f, err := os.Create(filepath.Join(dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create profile file. Reason: %s", err.Error())
+	}
+	return f, nil
+}
+
+// stopProfiling stops all the running profilers and flushes the profiles. It is safe to call multiple times.
+// Besides the normal return of gauge, it is run by logger.Fatalf and logger.Exit.
+func stopProfiling() {
+	for _, stop := range stopProfilers {
+		stop()
+	}
+	stopProfilers = nil
+}
+
+// applyExecutionProfiles applies the execution profiles named in the comma separated profiles, as defined in the manifest.
+// It sets the flags and variables of the profiles and gives the reporting plugins of the profiles, if any of them has reporters.
+// The flags and variables given on the command line take precedence over the ones in the profiles.
+func applyExecutionProfiles(flags *pflag.FlagSet, profiles string) ([]string, error) {
+	if strings.TrimSpace(profiles) == "" {
+		return nil, nil
+	}
+	m, err := manifest.ProjectManifest()
+	if err != nil {
+		return nil, err
+	}
+	changed := make(map[string]bool)
+	flags.Visit(func(f *pflag.Flag) {
+		changed[f.Name] = true
+	})
+	givenVars := make(map[string]bool)
+	if changed[variableName] {
+		vars, _ := flags.GetStringArray(variableName)
+		for _, v := range vars {
+			givenVars[strings.TrimSpace(strings.SplitN(v, "=", 2)[0])] = true
+		}
+	}
+	var reporters []string
+	for _, name := range strings.Split(profiles, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		p, ok := m.Profiles[name]
+		if !ok || p == nil {
+			return nil, fmt.Errorf("Profile %s is not defined in %s", name, common.ManifestFile)
+		}
+		for flagName, value := range p.Flags {
+			if changed[flagName] {
+				continue
+			}
+			if flags.Lookup(flagName) == nil || flagName == profileName {
+				return nil, fmt.Errorf("Invalid flag %s in profile %s", flagName, name)
+			}
+			values, ok := value.([]interface{})
+			if !ok {
+				values = []interface{}{value}
+			}
+			for _, v := range values {
+				if err := flags.Set(flagName, fmt.Sprint(v)); err != nil {
+					return nil, fmt.Errorf("Invalid value %v for flag %s in profile %s. %s", v, flagName, name, err.Error())
+				}
+			}
+		}
+		for key, value := range p.Env {
+			if givenVars[key] {
+				continue
+			}
+			if err := flags.Set(variableName, key+"="+value); err != nil {
+				return nil, fmt.Errorf("Invalid variable %s in profile %s. %s", key, name, err.Error())
+			}
+		}
+		if p.Reporters != nil {
+			reporters = append(reporters, p.Reporters...)
+		}
+		logger.Debugf(true, "Using profile %s", name)
+	}
+	return reporters, nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/execution/history"
+	"github.com/spf13/cobra"
+)
+
+const (
+	queryLastDefault  = 0
+	queryLimitDefault = 0
+
+	queryLastName  = "last"
+	queryLimitName = "limit"
+)
+
+var (
+	queryCmd = &cobra.Command{
+		Use:   "query [flags] <query>",
+		Short: "Query the results of the previous runs",
+		Long: `Query the results of the previous runs, kept in .gauge/runs.db for the last results_history_size runs.
+
+A query is one of:
+` + queryNames() + `
+The scenarios are summed up over the runs queried. Durations are in milliseconds.`,
+		Example: `  gauge query flaky
+  gauge query failures --last 20
+  gauge query slowest --limit 5`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				exit(fmt.Errorf("Invalid Command. Usage: gauge query [flags] <query>"), cmd.UsageString())
+			}
+			if queryLast < 0 {
+				exit(fmt.Errorf("Invalid input(%d) to --%s flag", queryLast, queryLastName), cmd.UsageString())
+			}
+			if queryLimit < 0 {
+				exit(fmt.Errorf("Invalid input(%d) to --%s flag", queryLimit, queryLimitName), cmd.UsageString())
+			}
+			q, err := history.FindQuery(args[0])
+			if err != nil {
+				exit(err, cmd.UsageString())
+			}
+			if err := config.SetProjectRoot([]string{}); err != nil {
+				exit(err, cmd.UsageString())
+			}
+			loadEnvAndInitLogger(cmd)
+			runs, err := history.Runs()
+			if err != nil {
+				exit(err, "")
+			}
+			if len(runs) == 0 {
+				exit(fmt.Errorf("No runs have been recorded yet"), "")
+			}
+			res := q.Execute(runs, queryLast, queryLimit)
+			if machineReadable {
+				b, err := res.JSON()
+				if err != nil {
+					exit(err, "")
+				}
+				fmt.Println(string(b))
+				return
+			}
+			if err := res.Write(os.Stdout); err != nil {
+				exit(err, "")
+			}
+		},
+		DisableAutoGenTag: true,
+	}
+	queryLast  int
+	queryLimit int
+)
+
+func queryNames() string {
+	var names string
+	for _, q := range history.Queries {
+		names += fmt.Sprintf("  %-10s %s\n", q.Name, q.Description)
+	}
+	return names
+}
+
+func init() {
+	GaugeCmd.AddCommand(queryCmd)
+	queryCmd.Flags().IntVarP(&queryLast, queryLastName, "", queryLastDefault, "Query the given number of last runs instead of the default of the query")
+	queryCmd.Flags().IntVarP(&queryLimit, queryLimitName, "", queryLimitDefault, "List at most the given number of rows instead of the default of the query")
+}
@@ -0,0 +1,55 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rerunCmd = &cobra.Command{
+		Use:   "rerun [flags]",
+		Short: "Repeat the last run",
+		Long:  `Repeat the last run with the same flags and arguments, optionally restricted to the scenarios which failed in it.`,
+		Example: `  gauge rerun
+  gauge rerun --failed`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) > 0 {
+				exit(fmt.Errorf("Invalid Command. Usage: gauge rerun [--failed]"), cmd.UsageString())
+			}
+			if err := config.SetProjectRoot(args); err != nil {
+				exit(err, cmd.UsageString())
+			}
+			if rerunFailed {
+				executeFailed(runCmd)
+			} else {
+				repeatLastExecution(runCmd)
+			}
+		},
+		DisableAutoGenTag: true,
+	}
+	rerunFailed bool
+)
+
+func init() {
+	GaugeCmd.AddCommand(rerunCmd)
+	rerunCmd.Flags().BoolVarP(&rerunFailed, failedName, "f", failedDefault, "Repeat only the scenarios failed in the last run")
+}
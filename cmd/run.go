@@ -23,12 +23,16 @@ import (
 	"strconv"
 
 	"strings"
+	"time"
 
 	"github.com/getgauge/gauge/config"
 	"github.com/getgauge/gauge/execution"
 	"github.com/getgauge/gauge/execution/rerun"
+	"github.com/getgauge/gauge/execution/resultformat"
+	"github.com/getgauge/gauge/filter"
 	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/plugin/install"
+	"github.com/getgauge/gauge/reporter"
 	"github.com/getgauge/gauge/util"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -41,14 +45,30 @@ const (
 	repeatDefault          = false
 	parallelDefault        = false
 	sortDefault            = false
+	failuresFirstDefault   = false
+	smartDefault           = false
+	budgetDefault          = 10 * time.Minute
 	installPluginsDefault  = true
 	environmentDefault     = "default"
 	tagsDefault            = ""
+	excludeTagsDefault     = ""
 	rowsDefault            = ""
 	strategyDefault        = "lazy"
 	groupDefault           = -1
 	failSafeDefault        = false
 	skipCommandSaveDefault = false
+	shardIndexDefault      = 1
+	shardCountDefault      = 1
+	reporterDefault        = ""
+	ciFormatDefault        = ""
+	resultFormatDefault    = ""
+	specPatternDefault     = ""
+	scenarioPatternDefault = ""
+	retrySuiteDefault      = 0
+	timeoutDefault         = time.Duration(0)
+	colorDefault           = reporter.ColorAuto
+	themeDefault           = reporter.DefaultTheme
+	profileDefault         = ""
 
 	verboseName         = "verbose"
 	simpleConsoleName   = "simple-console"
@@ -56,9 +76,13 @@ const (
 	repeatName          = "repeat"
 	parallelName        = "parallel"
 	sortName            = "sort"
+	failuresFirstName   = "failures-first"
+	smartName           = "smart"
+	budgetName          = "budget"
 	installPluginsName  = "install-plugins"
 	environmentName     = "env"
 	tagsName            = "tags"
+	excludeTagsName     = "exclude-tags"
 	rowsName            = "table-rows"
 	strategyName        = "strategy"
 	groupName           = "group"
@@ -66,6 +90,20 @@ const (
 	failSafeName        = "fail-safe"
 	skipCommandSaveName = "skip-save"
 	scenarioName        = "scenario"
+	shardIndexName      = "shard-index"
+	shardCountName      = "shard-count"
+	reporterName        = "reporter"
+	ciFormatName        = "ci-format"
+	resultFormatName    = "result-format"
+	variableName        = "var"
+	metaName            = "meta"
+	specPatternName     = "spec-pattern"
+	scenarioPatternName = "scenario-pattern"
+	retrySuiteName      = "retry-suite"
+	timeoutName         = "timeout"
+	colorName           = "color"
+	themeName           = "theme"
+	profileName         = "profile"
 )
 
 var overrideRerunFlags = []string{verboseName, simpleConsoleName, machineReadableName, dirName, logLevelName}
@@ -77,14 +115,56 @@ var (
 		Short: "Run specs",
 		Long:  `Run specs.`,
 		Example: `  gauge run specs/
-  gauge run --tags "login" -s -p specs/`,
+  gauge run --tags "login" -s -p specs/
+  gauge run --profile smoke specs/`,
 		Run: func(cmd *cobra.Command, args []string) {
+			if workspaceFile != "" {
+				executeInWorkspace(true)
+			}
 			if err := config.SetProjectRoot(args); err != nil {
 				exit(err, cmd.UsageString())
 			}
+			var err error
+			if reportingPlugins, err = applyExecutionProfiles(cmd.Flags(), profile); err != nil {
+				exit(err, cmd.UsageString())
+			}
 			if er := handleConflictingParams(cmd.Flags(), args); er != nil {
 				exit(er, "")
 			}
+			if er := initPackageFlags(); er != nil {
+				exit(er, cmd.UsageString())
+			}
+			if er := validateShardFlags(); er != nil {
+				exit(er, cmd.UsageString())
+			}
+			if er := validateRetrySuiteFlag(); er != nil {
+				exit(er, cmd.UsageString())
+			}
+			if er := validateBudgetFlag(); er != nil {
+				exit(er, cmd.UsageString())
+			}
+			if !reporter.IsValidMode(reporterMode) {
+				exit(fmt.Errorf("Invalid input(%s) to --%s flag", reporterMode, reporterName), cmd.UsageString())
+			}
+			if !reporter.IsValidColorMode(colorMode) {
+				exit(fmt.Errorf("Invalid input(%s) to --%s flag", colorMode, colorName), cmd.UsageString())
+			}
+			if !reporter.IsValidTheme(theme) {
+				exit(fmt.Errorf("Invalid input(%s) to --%s flag", theme, themeName), cmd.UsageString())
+			}
+			if !reporter.IsValidCIFormat(ciFormat) {
+				exit(fmt.Errorf("Invalid input(%s) to --%s flag", ciFormat, ciFormatName), cmd.UsageString())
+			}
+			if err := resultformat.Validate(resultFormats()); err != nil {
+				exit(err, cmd.UsageString())
+			}
+			if err := filter.ValidatePatterns(specPattern, scenarioPattern); err != nil {
+				exit(err, cmd.UsageString())
+			}
+			if envMatrix != "" {
+				executeEnvMatrix(cmd)
+			}
+			cleanupOrphans()
 			if repeat {
 				repeatLastExecution(cmd)
 			} else if failed {
@@ -101,9 +181,13 @@ var (
 	repeat              bool
 	parallel            bool
 	sort                bool
+	failuresFirst       bool
+	smart               bool
+	budget              time.Duration
 	installPlugins      bool
 	environment         string
 	tags                string
+	excludeTags         string
 	rows                string
 	strategy            string
 	streams             int
@@ -112,6 +196,21 @@ var (
 	skipCommandSave     bool
 	scenarios           []string
 	scenarioNameDefault []string
+	shardIndex          int
+	shardCount          int
+	reporterMode        string
+	ciFormat            string
+	resultFormat        string
+	variables           []string
+	meta                []string
+	specPattern         string
+	scenarioPattern     string
+	retrySuite          int
+	timeout             time.Duration
+	colorMode           string
+	theme               string
+	profile             string
+	reportingPlugins    []string
 )
 
 func init() {
@@ -121,12 +220,16 @@ func init() {
 	f.BoolVarP(&simpleConsole, simpleConsoleName, "", simpleConsoleDefault, "Removes colouring and simplifies the console output")
 	f.StringVarP(&environment, environmentName, "e", environmentDefault, "Specifies the environment to use")
 	f.StringVarP(&tags, tagsName, "t", tagsDefault, "Executes the specs and scenarios tagged with given tags")
-	f.StringVarP(&rows, rowsName, "r", rowsDefault, "Executes the specs and scenarios only for the selected rows. It can be specified by range as 2-4 or as list 2,4")
-	f.BoolVarP(&parallel, parallelName, "p", parallelDefault, "Execute specs in parallel")
+	f.StringVarP(&excludeTags, excludeTagsName, "", excludeTagsDefault, "Skips the specs and scenarios tagged with any of the given comma separated tags, e.g. wip,slow")
+	f.StringVarP(&rows, rowsName, "r", rowsDefault, "Executes the specs and scenarios only for the selected rows. It can be specified by range as 2-4, as list 2,4 or as a mix of both like 1,3,5-7")
+	f.BoolVarP(&parallel, parallelName, "p", parallelDefault, "Execute specs in parallel. Specs tagged serial or having parallelizable=false metadata are executed in a single stream after the rest")
 	f.IntVarP(&streams, streamsName, "n", streamsDefault, "Specify number of parallel execution streams")
 	f.IntVarP(&group, groupName, "g", groupDefault, "Specify which group of specification to execute based on -n flag")
-	f.StringVarP(&strategy, strategyName, "", strategyDefault, "Set the parallelization strategy for execution. Possible options are: `eager`, `lazy`")
+	f.StringVarP(&strategy, strategyName, "", strategyDefault, "Set the parallelization strategy for execution. Possible options are: `eager`, `lazy`, `deterministic`")
 	f.BoolVarP(&sort, sortName, "s", sortDefault, "Run specs in Alphabetical Order")
+	f.BoolVarP(&smart, smartName, "", smartDefault, "Run only the scenarios worth running the most which fit in the --budget, like the recent failures, the ones in changed specs and the ones covering widely used steps and concepts")
+	f.DurationVarP(&budget, budgetName, "", budgetDefault, "Set the time within which the scenarios selected by --smart are expected to run, as per their durations in the results history, e.g. 10m")
+	f.BoolVarP(&failuresFirst, failuresFirstName, "", failuresFirstDefault, "Run the scenarios failed in the recent runs first, followed by the ones in specs changed recently as per git")
 	f.BoolVarP(&installPlugins, installPluginsName, "i", installPluginsDefault, "Install All Missing Plugins")
 	f.BoolVarP(&failed, failedName, "f", failedDefault, "Run only the scenarios failed in previous run. This cannot be used in conjunction with any other argument")
 	f.BoolVarP(&repeat, repeatName, "", repeatDefault, "Repeat last run. This cannot be used in conjunction with any other argument")
@@ -134,7 +237,44 @@ func init() {
 	f.BoolVarP(&failSafe, failSafeName, "", failSafeDefault, "Force return 0 exit code, even in case of failures.")
 	f.BoolVarP(&skipCommandSave, skipCommandSaveName, "", skipCommandSaveDefault, "Skip saving last command in lastRunCmd.json")
 	f.MarkHidden(skipCommandSaveName)
-	f.StringArrayVar(&scenarios, scenarioName, scenarioNameDefault, "Set scenarios for running specs with scenario name")
+	f.StringVarP(&specPattern, specPatternName, "", specPatternDefault, "Executes the specs whose heading matches the given regular expression, e.g. ^\\[API\\]")
+	f.StringVarP(&scenarioPattern, scenarioPatternName, "", scenarioPatternDefault, "Executes the scenarios whose heading matches the given regular expression")
+	f.StringArrayVar(&scenarios, scenarioName, scenarioNameDefault, "Set scenarios for running specs with scenario name. Use * and ? wildcards for a glob or enclose in slashes for a regex, as in /^Login/")
+	f.IntVarP(&shardIndex, shardIndexName, "", shardIndexDefault, "Specify which shard of scenarios to execute based on --shard-count flag. Shards are numbered from 1")
+	f.StringVarP(&reporterMode, reporterName, "", reporterDefault, "Set the console reporter. Possible options are: `progress`, `summary`")
+	f.IntVarP(&shardCount, shardCountName, "", shardCountDefault, "Specify the number of shards to partition the scenarios into, e.g. one per CI job")
+	f.StringVarP(&colorMode, colorName, "", colorDefault, "Set when the console output is colored. Possible options are: `auto`, `always`, `never`. With auto, NO_COLOR or GAUGE_NO_COLOR disable colors")
+	f.StringVarP(&theme, themeName, "", themeDefault, "Set the colors and symbols of the console output. Possible options are: `default`, `high-contrast`, `monochrome`")
+	f.StringVarP(&ciFormat, ciFormatName, "", ciFormatDefault, "Emit messages for the CI server along with the console output. Possible options are: `teamcity`, `azure`")
+	f.StringArrayVar(&variables, variableName, []string{}, "Set a variable as key=value, available to the step implementations and hooks as an environment variable. Can be repeated")
+	f.StringArrayVar(&meta, metaName, []string{}, "Add metadata as key=value to the suite result, e.g. the build number. Can be repeated")
+	f.DurationVarP(&timeout, timeoutName, "", timeoutDefault, "Stop the execution once it runs longer than the given duration, e.g. 45m. The remaining scenarios are skipped, the after suite hooks run and the exit code is 4")
+	f.IntVarP(&retrySuite, retrySuiteName, "", retrySuiteDefault, "Execute the failed scenarios again, in up to the given number of additional passes, once all the specs are executed")
+	f.StringVarP(&envMatrix, envMatrixName, "", envMatrixDefault, "Execute the specs once in every environment given as a comma separated list, or in a file with an environment on every line, and summarize the results of every environment")
+	f.StringVarP(&profile, profileName, "", profileDefault, "Apply the comma separated execution profiles defined in manifest.json. A profile sets flags, environment variables and reporting plugins of the run")
+	f.StringVarP(&resultFormat, resultFormatName, "", resultFormatDefault, "Write the result to the reports directory in the given comma separated formats. Possible options are: `xunit`, `nunit3`")
+}
+
+func suiteMetadata() (map[string]string, error) {
+	m := make(map[string]string)
+	for _, kv := range meta {
+		v := strings.SplitN(kv, "=", 2)
+		if len(v) != 2 || strings.TrimSpace(v[0]) == "" {
+			return nil, fmt.Errorf("Invalid input(%s) to --%s flag. Metadata should be given as key=value", kv, metaName)
+		}
+		m[strings.TrimSpace(v[0])] = v[1]
+	}
+	return m, nil
+}
+
+func resultFormats() []string {
+	var formats []string
+	for _, f := range strings.Split(resultFormat, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			formats = append(formats, f)
+		}
+	}
+	return formats
 }
 
 func executeFailed(cmd *cobra.Command) {
@@ -189,7 +329,7 @@ func lookupFlagFromArgs(cmd *cobra.Command, arg string) *pflag.Flag {
 
 func installMissingPlugins(flag bool) {
 	if flag && os.Getenv("GAUGE_PLUGIN_INSTALL") != "false" {
-		install.AllPlugins(machineReadable)
+		install.AllPlugins(machineReadable, false)
 	}
 }
 
@@ -207,7 +347,7 @@ func execute(cmd *cobra.Command, args []string) {
 	if failSafe && exitCode != execution.ParseFailed {
 		exitCode = 0
 	}
-	os.Exit(exitCode)
+	logger.Exit(exitCode)
 }
 
 var repeatLastExecution = func(cmd *cobra.Command) {
@@ -233,3 +373,27 @@ func handleConflictingParams(setFlags *pflag.FlagSet, args []string) error {
 	}
 	return nil
 }
+
+func validateShardFlags() error {
+	if shardCount < 1 {
+		return fmt.Errorf("Invalid input(%d) to --%s flag. It should be greater than 0", shardCount, shardCountName)
+	}
+	if shardIndex < 1 || shardIndex > shardCount {
+		return fmt.Errorf("Invalid input(%d) to --%s flag. It should be between 1 and %d", shardIndex, shardIndexName, shardCount)
+	}
+	return nil
+}
+
+func validateBudgetFlag() error {
+	if smart && budget <= 0 {
+		return fmt.Errorf("Invalid input(%s) to --%s flag. It should be greater than 0", budget, budgetName)
+	}
+	return nil
+}
+
+func validateRetrySuiteFlag() error {
+	if retrySuite < 0 {
+		return fmt.Errorf("Invalid input(%d) to --%s flag. It should not be negative", retrySuite, retrySuiteName)
+	}
+	return nil
+}
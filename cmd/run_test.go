@@ -3,6 +3,7 @@ package cmd
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -16,6 +17,7 @@ import (
 	"reflect"
 
 	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
 	"github.com/spf13/cobra"
 )
 
@@ -483,3 +485,119 @@ func TestCorrectFlagsAreSetForFailed(t *testing.T) {
 func subEnv() []string {
 	return append(os.Environ(), []string{"TEST_EXITS=1", "GAUGE_PLUGIN_INSTALL=false"}...)
 }
+
+func TestRerunShouldRepeatLastExecution(t *testing.T) {
+	var repeated *cobra.Command
+	old := repeatLastExecution
+	repeatLastExecution = func(cmd *cobra.Command) { repeated = cmd }
+	defer func() { repeatLastExecution = old }()
+
+	rerunCmd.Run(rerunCmd, []string{})
+
+	if repeated != runCmd {
+		t.Errorf("Expected gauge rerun to repeat the last run")
+	}
+}
+
+func TestApplyExecutionProfilesDoesNotOverrideCommandLineFlags(t *testing.T) {
+	os.MkdirAll(path, 0755)
+	manifest := `{"Language": "java", "Profiles": {"smoke": {"Flags": {"tags": "smoke", "n": 4, "parallel": true, "var": ["a=1", "b=2"]}, "Env": {"c": "3"}}}}`
+	if err := ioutil.WriteFile(filepath.Join(path, "manifest.json"), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(filepath.Join(path, "manifest.json"))
+	var tags string
+	var n int
+	var parallel bool
+	var vars []string
+	flags := pflag.NewFlagSet("run", pflag.ContinueOnError)
+	flags.StringVar(&tags, "tags", "", "")
+	flags.IntVar(&n, "n", 1, "")
+	flags.BoolVar(&parallel, "parallel", false, "")
+	flags.StringArrayVar(&vars, "var", []string{}, "")
+	flags.Parse([]string{"--tags", "login"})
+
+	reporters, err := applyExecutionProfiles(flags, "smoke")
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err.Error())
+	}
+
+	if tags != "login" || n != 4 || !parallel || !reflect.DeepEqual(vars, []string{"a=1", "b=2", "c=3"}) || reporters != nil {
+		t.Errorf("Profile not applied as expected. tags: %s, n: %d, parallel: %v, var: %v, reporters: %v", tags, n, parallel, vars, reporters)
+	}
+	if _, err := applyExecutionProfiles(flags, "nightly"); err == nil {
+		t.Errorf("Expected error for a profile not defined in manifest")
+	}
+}
+
+func TestApplyExecutionProfilesNamedLikeProfilers(t *testing.T) {
+	os.MkdirAll(path, 0755)
+	manifest := `{"Language": "java", "Profiles": {"cpu": {"Env": {"d": "4", "e": "5"}, "Reporters": ["html-report"]}}}`
+	if err := ioutil.WriteFile(filepath.Join(path, "manifest.json"), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(filepath.Join(path, "manifest.json"))
+	var vars []string
+	flags := pflag.NewFlagSet("run", pflag.ContinueOnError)
+	flags.StringArrayVar(&vars, "var", []string{}, "")
+	flags.Parse([]string{"--var", "d=1"})
+
+	reporters, err := applyExecutionProfiles(flags, "cpu")
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(vars, []string{"d=1", "e=5"}) || !reflect.DeepEqual(reporters, []string{"html-report"}) {
+		t.Errorf("Profile not applied as expected. var: %v, reporters: %v", vars, reporters)
+	}
+}
+
+func TestWithoutWorkspaceFlag(t *testing.T) {
+	got := withoutWorkspaceFlag([]string{"run", "--workspace", "ws.json", "-p", "--workspace=ws.json", "specs"})
+	want := []string{"run", "-p", "specs"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v  Got %v", want, got)
+	}
+}
+
+func TestMatrixEnvironmentsFromList(t *testing.T) {
+	got, err := matrixEnvironments(" chrome, firefox ,,")
+	want := []string{"chrome", "firefox"}
+
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v  Got %v, %v", want, got, err)
+	}
+	if _, err := matrixEnvironments("chrome,chrome"); err == nil {
+		t.Errorf("Expected error for a duplicate environment")
+	}
+}
+
+func TestMatrixEnvironmentsFromFile(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "gauge-env-matrix")
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "matrix.txt")
+	ioutil.WriteFile(file, []byte("# browsers\nchrome,staging\n\nfirefox\n"), 0644)
+
+	got, err := matrixEnvironments(file)
+	want := []string{"chrome,staging", "firefox"}
+
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v  Got %v, %v", want, got, err)
+	}
+}
+
+func TestMatrixProcesses(t *testing.T) {
+	os.Setenv(env.GaugeReportsDir, "out")
+	defer os.Unsetenv(env.GaugeReportsDir)
+
+	p := matrixProcesses([]string{"run", "--env-matrix", "chrome,staging", "-e", "dev", "-p", "specs"}, []string{"chrome,staging"})
+
+	want := []string{"run", "-p", "specs", "--skip-save", "--env", "chrome,staging", "--meta", "environment=chrome,staging"}
+	if len(p) != 1 || !reflect.DeepEqual(p[0].Args, want) {
+		t.Fatalf("Expected args %v  Got %v", want, p[0].Args)
+	}
+	if wantEnv := env.GaugeReportsDir + "=" + filepath.Join("out", "chrome_staging"); p[0].Env[0] != wantEnv {
+		t.Errorf("Expected %s  Got %s", wantEnv, p[0].Env[0])
+	}
+}
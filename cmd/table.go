@@ -0,0 +1,105 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/formatter"
+	"github.com/getgauge/gauge/logger"
+	"github.com/spf13/cobra"
+)
+
+const (
+	intoName = "into"
+	toName   = "to"
+)
+
+var (
+	tableCmd = &cobra.Command{
+		Use:   "table [command]",
+		Short: "Convert data tables between inline and external tables",
+		Long:  `Convert data tables between inline tables in specs and external csv files.`,
+		Example: `  gauge table import data.csv --into specs/example.spec
+  gauge table export specs/example.spec --to data.csv`,
+		Run: func(cmd *cobra.Command, args []string) {
+			exit(nil, cmd.UsageString())
+		},
+		DisableAutoGenTag: true,
+	}
+
+	tableImportCmd = &cobra.Command{
+		Use:   "import [flags] <csv or markdown file>",
+		Short: "Import a table as an inline data table of a spec",
+		Long: `Import a table from a csv or markdown file as a formatted inline data table of a spec.
+Replaces the existing data table of the spec, or of the scenario if --scenario is given.`,
+		Example: `  gauge table import data.csv --into specs/example.spec
+  gauge table import data.md --into specs/example.spec --scenario "Vowel counts"`,
+		Run: func(cmd *cobra.Command, args []string) {
+			loadEnvAndInitLogger(cmd)
+			if len(args) != 1 || into == "" {
+				exit(fmt.Errorf("Import needs a table file and a spec file to import it into."), cmd.UsageString())
+			}
+			if err := config.SetProjectRoot([]string{into}); err != nil {
+				exit(err, cmd.UsageString())
+			}
+			if err := formatter.ImportTable(args[0], into, tableScenario); err != nil {
+				logger.Fatal(true, err.Error())
+			}
+			logger.Infof(true, "Imported %s into %s", args[0], into)
+		},
+		DisableAutoGenTag: true,
+	}
+
+	tableExportCmd = &cobra.Command{
+		Use:   "export [flags] <spec file>",
+		Short: "Export the inline data table of a spec to a csv file",
+		Long: `Export the inline data table of a spec, or of the scenario if --scenario is given, to a csv file.
+The data table of the spec is replaced by a reference to the csv file.`,
+		Example: `  gauge table export specs/example.spec --to data.csv`,
+		Run: func(cmd *cobra.Command, args []string) {
+			loadEnvAndInitLogger(cmd)
+			if len(args) != 1 || to == "" {
+				exit(fmt.Errorf("Export needs a spec file and a csv file to export the table to."), cmd.UsageString())
+			}
+			if err := config.SetProjectRoot(args); err != nil {
+				exit(err, cmd.UsageString())
+			}
+			if err := formatter.ExportTable(args[0], to, tableScenario); err != nil {
+				logger.Fatal(true, err.Error())
+			}
+			logger.Infof(true, "Exported the table of %s to %s", args[0], to)
+		},
+		DisableAutoGenTag: true,
+	}
+
+	into          string
+	to            string
+	tableScenario string
+)
+
+func init() {
+	tableImportCmd.Flags().StringVarP(&into, intoName, "", "", "Spec file into which the table is imported")
+	tableImportCmd.Flags().StringVarP(&tableScenario, scenarioName, "", "", "Heading of the scenario whose data table is replaced")
+	tableExportCmd.Flags().StringVarP(&to, toName, "", "", "Csv file to which the table is exported")
+	tableExportCmd.Flags().StringVarP(&tableScenario, scenarioName, "", "", "Heading of the scenario whose data table is exported")
+	tableCmd.AddCommand(tableImportCmd)
+	tableCmd.AddCommand(tableExportCmd)
+	GaugeCmd.AddCommand(tableCmd)
+}
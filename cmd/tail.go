@@ -0,0 +1,55 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/execution"
+	"github.com/getgauge/gauge/logger"
+	"github.com/spf13/cobra"
+)
+
+var tailCmd = &cobra.Command{
+	Use:   "tail [flags]",
+	Short: "Report the execution in progress in the project",
+	Long: `Attach to the execution in progress in the project, e.g. started by a CI agent, and report it on the console as it happens.
+
+The execution is reported from its start, as gauge run reports it, followed by the summary once it ends.
+Step failures are reported with their messages. Screenshots, stack traces and hook failures are only in the reports.`,
+	Example: `  gauge tail
+  gauge tail --simple-console`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) > 0 {
+			exit(fmt.Errorf("Invalid Command. Usage: gauge tail [flags]"), cmd.UsageString())
+		}
+		if err := config.SetProjectRoot(args); err != nil {
+			exit(err, cmd.UsageString())
+		}
+		loadEnvAndInitLogger(cmd)
+		exitCode := execution.Tail()
+		logger.Exit(exitCode)
+	},
+	DisableAutoGenTag: true,
+}
+
+func init() {
+	GaugeCmd.AddCommand(tailCmd)
+	tailCmd.Flags().BoolVarP(&simpleConsole, simpleConsoleName, "", simpleConsoleDefault, "Removes colouring and simplifies the console output")
+}
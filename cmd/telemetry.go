@@ -26,6 +26,7 @@ import (
 
 	"github.com/getgauge/gauge/config"
 	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/track"
 	"github.com/spf13/cobra"
 )
 
@@ -80,13 +81,14 @@ var (
 	offCmd = &cobra.Command{
 		Use:     "off",
 		Short:   "Turn telemetry off",
-		Long:    "Turn telemetry off.",
+		Long:    "Turn telemetry off. Usage data not yet sent is discarded.",
 		Example: "  gauge telemetry off",
 		Run: func(cmd *cobra.Command, args []string) {
 			if err := config.UpdateTelemetry("false"); err != nil {
 				logger.Fatalf(true, err.Error())
 			}
 			config.RecordTelemetryConsentSet()
+			track.ClearSpool()
 		},
 		DisableAutoGenTag: true,
 	}
@@ -0,0 +1,75 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/manifest"
+	"github.com/getgauge/gauge/plugin/install"
+	"github.com/getgauge/gauge/version"
+	"github.com/spf13/cobra"
+)
+
+var (
+	upgradeCmd = &cobra.Command{
+		Use:   "upgrade [flags]",
+		Short: "Upgrades the installed plugins to their newest compatible versions",
+		Long: `Upgrades the installed plugins to their newest versions compatible with this version of gauge.
+
+Inside a gauge project, plugins are kept within the versions given in the PluginVersions of manifest.json.
+The upgrades are printed before they are done. Gauge itself is only reported, since it is upgraded with its installer.`,
+		Example: `  gauge upgrade
+  gauge upgrade --dry-run`,
+		Run: func(cmd *cobra.Command, args []string) {
+			plan, err := install.UpgradePlan(pluginVersionConstraints())
+			if err != nil {
+				logger.Fatal(true, err.Error())
+			}
+			install.PrintUpgradePlan(plan)
+			if upgradeDryRun || len(plan) == 0 {
+				return
+			}
+			if failed := install.UpgradeAll(plan, machineReadable); len(failed) > 0 {
+				logger.Fatal(true, fmt.Sprintf("Failed to upgrade '%s' plugins.", strings.Join(failed, ", ")))
+			}
+		},
+		DisableAutoGenTag: true,
+	}
+	upgradeDryRun bool
+)
+
+func init() {
+	GaugeCmd.AddCommand(upgradeCmd)
+	upgradeCmd.Flags().BoolVarP(&upgradeDryRun, "dry-run", "", false, "Only prints the upgrades, without doing them")
+}
+
+// pluginVersionConstraints returns the plugin versions allowed by the manifest of the current project, if any.
+func pluginVersionConstraints() map[string]version.VersionSupport {
+	if err := config.SetProjectRoot([]string{}); err != nil {
+		return nil
+	}
+	m, err := manifest.ProjectManifest()
+	if err != nil {
+		return nil
+	}
+	return m.PluginVersions
+}
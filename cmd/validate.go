@@ -19,13 +19,16 @@ package cmd
 
 import (
 	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/parser"
 	"github.com/getgauge/gauge/validation"
 	"github.com/spf13/cobra"
 )
 
 const (
-	hideSuggestionDefault = false
-	hideSuggestionName    = "hide-suggestion"
+	hideSuggestionDefault   = false
+	hideSuggestionName      = "hide-suggestion"
+	warningsAsErrorsDefault = false
+	warningsAsErrorsName    = "warnings-as-errors"
 )
 
 var (
@@ -35,8 +38,12 @@ var (
 		Long:    `Check for validation and parse errors.`,
 		Example: "  gauge validate specs/",
 		Run: func(cmd *cobra.Command, args []string) {
+			if workspaceFile != "" {
+				executeInWorkspace(false)
+			}
 			loadEnvAndInitLogger(cmd)
 			validation.HideSuggestion = hideSuggestion
+			parser.WarningsAsErrors = warningsAsErrors
 			if err := config.SetProjectRoot(args); err != nil {
 				exit(err, cmd.UsageString())
 			}
@@ -44,11 +51,13 @@ var (
 		},
 		DisableAutoGenTag: true,
 	}
-	hideSuggestion bool
+	hideSuggestion   bool
+	warningsAsErrors bool
 )
 
 func init() {
 	GaugeCmd.AddCommand(validateCmd)
 	validateCmd.Flags().BoolVarP(&hideSuggestion, "hide-suggestion", "", false, "Prints a step implementation stub for every unimplemented step")
+	validateCmd.Flags().BoolVarP(&warningsAsErrors, warningsAsErrorsName, "", warningsAsErrorsDefault, "Fail if the specs or concepts have parse warnings, like deprecated syntax or empty tags")
 
 }
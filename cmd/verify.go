@@ -0,0 +1,74 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/lint"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/verify"
+	"github.com/spf13/cobra"
+)
+
+const (
+	skipValidationName    = "skip-validation"
+	skipValidationDefault = false
+)
+
+var (
+	verifyCmd = &cobra.Command{
+		Use:   "verify [flags] [args]",
+		Short: "Check specs and concepts without executing them",
+		Long: `Check specs and concepts without executing them.
+
+The specs and concepts are parsed, their steps are validated against the runner, linted and checked to be formatted.
+The problems found by all of these are reported together. Exits with a non-zero status if any problem is found.`,
+		Example: `  gauge verify specs/
+  gauge verify --skip-validation specs/`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := config.SetProjectRoot(args); err != nil {
+				exit(err, cmd.UsageString())
+			}
+			loadEnvAndInitLogger(cmd)
+			if similarity < 1 || similarity > 100 {
+				exit(fmt.Errorf("Invalid input(%d) to --%s flag. It should be between 1 and 100", similarity, similarityName), cmd.UsageString())
+			}
+			lint.MinSimilarity = similarity
+			verify.SkipValidation = skipValidation
+			report, err := verify.Verify(getSpecsDir(args))
+			if err != nil {
+				logger.Fatalf(true, "Unable to verify. %s", err.Error())
+			}
+			verify.Print(os.Stdout, report)
+			if report.Problems() > 0 {
+				logger.Exit(1)
+			}
+		},
+		DisableAutoGenTag: true,
+	}
+	skipValidation bool
+)
+
+func init() {
+	GaugeCmd.AddCommand(verifyCmd)
+	verifyCmd.Flags().IntVarP(&similarity, similarityName, "", similarityDefault, "Report scenarios sharing at least the given percentage of steps as duplicates")
+	verifyCmd.Flags().BoolVarP(&skipValidation, skipValidationName, "", skipValidationDefault, "Do not validate the steps against the runner")
+}
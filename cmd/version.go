@@ -20,9 +20,9 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath"
 
+	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/track"
 
 	"github.com/getgauge/gauge/plugin/pluginInfo"
@@ -92,7 +92,7 @@ func printTextVersion() {
 	if err != nil {
 		fmt.Println("No plugins found")
 		fmt.Println("Plugins can be installed with `gauge install {plugin-name}`")
-		os.Exit(0)
+		logger.Exit(0)
 	}
 	for _, pluginInfo := range allPluginsWithVersion {
 		fmt.Printf("%s (%s)\n", pluginInfo.Name, filepath.Base(pluginInfo.Path))
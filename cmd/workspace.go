@@ -0,0 +1,93 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/getgauge/gauge/execution/compose"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/workspace"
+)
+
+const (
+	workspaceName    = "workspace"
+	workspaceDefault = ""
+)
+
+var workspaceFile string
+
+func init() {
+	GaugeCmd.PersistentFlags().StringVarP(&workspaceFile, workspaceName, "", workspaceDefault, "Execute the run, validate or list command in every gauge project listed in the given workspace file")
+}
+
+// executeInWorkspace executes the current command in every project of the workspace, one after the other,
+// and exits with the exit code of the first project which failed. The results of runs are aggregated.
+func executeInWorkspace(run bool) {
+	projects, err := workspace.Load(workspaceFile)
+	if err != nil {
+		exit(err, "")
+	}
+	gauge, err := os.Executable()
+	if err != nil {
+		exit(fmt.Errorf("Failed to find the gauge executable. %s", err.Error()), "")
+	}
+	root, _ := filepath.Abs(filepath.Dir(workspaceFile))
+	args := withoutWorkspaceFlag(os.Args[1:])
+	var processes []*compose.Process
+	for i, p := range projects {
+		name, err := filepath.Rel(root, p)
+		if err != nil {
+			name = p
+		}
+		process := &compose.Process{Name: name, Dir: p, Args: args}
+		if run {
+			process.StatusFile = filepath.Join(os.TempDir(), fmt.Sprintf("gauge-workspace-%d-%d.json", os.Getpid(), i))
+		}
+		processes = append(processes, process)
+	}
+	exitCode := compose.Summarize(compose.ExecuteAll(gauge, processes, false))
+	for _, p := range processes {
+		if p.StatusFile != "" {
+			os.Remove(p.StatusFile)
+		}
+	}
+	logger.Exit(exitCode)
+}
+
+func withoutWorkspaceFlag(args []string) []string {
+	return withoutFlag(args, workspaceName, "")
+}
+
+// withoutFlag removes the flag, given by its name or shorthand, along with its value from the args.
+func withoutFlag(args []string, name, shorthand string) []string {
+	var a []string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--"+name, shorthand != "" && args[i] == "-"+shorthand:
+			i++
+		case strings.HasPrefix(args[i], "--"+name+"="), shorthand != "" && strings.HasPrefix(args[i], "-"+shorthand):
+		default:
+			a = append(a, args[i])
+		}
+	}
+	return a
+}
@@ -18,6 +18,7 @@
 package config
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -36,19 +37,41 @@ const (
 	runnerConnectionTimeout = "runner_connection_timeout"
 	pluginConnectionTimeout = "plugin_connection_timeout"
 	pluginKillTimeOut       = "plugin_kill_timeout"
+	pluginRequestTimeout    = "plugin_request_timeout"
 	runnerRequestTimeout    = "runner_request_timeout"
 	ideRequestTimeout       = "ide_request_timeout"
 	checkUpdates            = "check_updates"
 	telemetryEnabled        = "gauge_telemetry_enabled"
 	telemetryConsent        = "gauge_telemetry_action_recorded"
 	telemetryLoggingEnabled = "gauge_telemetry_log_enabled"
+	pluginSigningKeys       = "plugin_signing_keys"
+	pluginSignatureRequired = "plugin_signature_required"
+	proxyURL                = "proxy_url"
+	proxyUser               = "proxy_user"
+	proxyPassword           = "proxy_password"
+	apiPorts                = "api_ports"
+	runnerPorts             = "runner_ports"
+	pluginPorts             = "plugin_ports"
+	unixSockets             = "unix_sockets"
+	namedPipes              = "named_pipes"
+	apiListenAddress        = "api_listen_address"
+	apiTLSCertFile          = "api_tls_cert_file"
+	apiTLSKeyFile           = "api_tls_key_file"
+	apiTLSClientCAFile      = "api_tls_client_ca_file"
+	connectionReadTimeout   = "connection_read_timeout"
+	connectionWriteTimeout  = "connection_write_timeout"
+	connectionDialTimeout   = "connection_dial_timeout"
+	connectionKeepAlive     = "connection_keep_alive"
 
 	defaultRunnerConnectionTimeout = time.Second * 25
 	defaultPluginConnectionTimeout = time.Second * 10
 	defaultPluginKillTimeout       = time.Second * 4
+	defaultPluginRequestTimeout    = time.Second * 10
 	defaultRefactorTimeout         = time.Second * 10
 	defaultRunnerRequestTimeout    = time.Second * 30
 	defaultIdeRequestTimeout       = time.Second * 30
+	defaultConnectionDialTimeout   = time.Second * 30
+	defaultConnectionKeepAlive     = time.Second * 15
 	LayoutForTimeStamp             = "Jan 2, 2006 at 3:04pm"
 )
 
@@ -73,6 +96,12 @@ func PluginKillTimeout() time.Duration {
 	return convertToTime(intervalString, defaultPluginKillTimeout, pluginKillTimeOut)
 }
 
+// PluginRequestTimeout gets timeout in milliseconds for plugins to respond to requests during execution
+func PluginRequestTimeout() time.Duration {
+	intervalString := getFromConfig(pluginRequestTimeout)
+	return convertToTime(intervalString, defaultPluginRequestTimeout, pluginRequestTimeout)
+}
+
 // CheckUpdates determines if update check is enabled
 func CheckUpdates() bool {
 	allow := getFromConfig(checkUpdates)
@@ -102,6 +131,93 @@ func IdeRequestTimeout() time.Duration {
 	return convertToTime(intervalString, defaultIdeRequestTimeout, ideRequestTimeout)
 }
 
+// ConnectionReadTimeout gets timeout in milliseconds to wait for runners and plugins to respond to messages which have
+// no timeout of their own. Zero waits indefinitely.
+func ConnectionReadTimeout() time.Duration {
+	return convertToTime(fromEnvOrConfig(connectionReadTimeout), 0, connectionReadTimeout)
+}
+
+// ConnectionWriteTimeout gets timeout in milliseconds for writing a message to runners and plugins. Zero waits indefinitely.
+func ConnectionWriteTimeout() time.Duration {
+	return convertToTime(fromEnvOrConfig(connectionWriteTimeout), 0, connectionWriteTimeout)
+}
+
+// ConnectionDialTimeout gets timeout in milliseconds for connecting to runners which gauge dials, like the grpc runner
+// for the language server.
+func ConnectionDialTimeout() time.Duration {
+	return convertToTime(fromEnvOrConfig(connectionDialTimeout), defaultConnectionDialTimeout, connectionDialTimeout)
+}
+
+// ConnectionKeepAlive gets the interval in milliseconds of TCP keep-alives on the connections of runners and plugins.
+// Zero disables keep-alives.
+func ConnectionKeepAlive() time.Duration {
+	return convertToTime(fromEnvOrConfig(connectionKeepAlive), defaultConnectionKeepAlive, connectionKeepAlive)
+}
+
+// APIPorts gets the ports and port ranges the API can listen on, like 50000-50010,50020. Empty allows any free port.
+func APIPorts() string {
+	return fromEnvOrConfig(apiPorts)
+}
+
+// RunnerPorts gets the ports and port ranges gauge can listen on for the language runner to connect. Empty allows any free port.
+func RunnerPorts() string {
+	return fromEnvOrConfig(runnerPorts)
+}
+
+// PluginPorts gets the ports and port ranges gauge can listen on for plugins to connect. Empty allows any free port.
+func PluginPorts() string {
+	return fromEnvOrConfig(pluginPorts)
+}
+
+// UnixSockets determines if runners and plugins which support it are connected to over unix sockets instead of localhost ports
+func UnixSockets() bool {
+	allow := os.Getenv(unixSockets)
+	if allow == "" {
+		allow = getFromConfig(unixSockets)
+	}
+	return convertToBool(allow, unixSockets, true)
+}
+
+// NamedPipes determines if runners and plugins which support it are connected to over named pipes on windows, in
+// preference to unix sockets and localhost ports.
+func NamedPipes() bool {
+	allow := os.Getenv(namedPipes)
+	if allow == "" {
+		allow = getFromConfig(namedPipes)
+	}
+	return convertToBool(allow, namedPipes, true)
+}
+
+// APIListenAddress gets the address the daemon listens on for API clients. Addresses beyond localhost need TLS with verified client certificates.
+func APIListenAddress() string {
+	if address := fromEnvOrConfig(apiListenAddress); address != "" {
+		return address
+	}
+	return "127.0.0.1"
+}
+
+// APITLSCertFile gets the certificate file the daemon uses to serve API clients over TLS. Empty disables TLS.
+func APITLSCertFile() string {
+	return fromEnvOrConfig(apiTLSCertFile)
+}
+
+// APITLSKeyFile gets the private key file of the certificate the daemon uses to serve API clients over TLS.
+func APITLSKeyFile() string {
+	return fromEnvOrConfig(apiTLSKeyFile)
+}
+
+// APITLSClientCAFile gets the file of CA certificates which API clients of the daemon must present a certificate from. Empty does not verify clients, which is allowed only on localhost.
+func APITLSClientCAFile() string {
+	return fromEnvOrConfig(apiTLSClientCAFile)
+}
+
+func fromEnvOrConfig(name string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return getFromConfig(name)
+}
+
 // GaugeRepositoryUrl fetches the repository URL to locate plugins
 func GaugeRepositoryUrl() string {
 	return getFromConfig(gaugeRepositoryURL)
@@ -132,6 +248,37 @@ func TelemetryLogEnabled() bool {
 	return convertToBool(log, telemetryLoggingEnabled, false)
 }
 
+// PluginSigningKeys returns the base64 encoded ed25519 public keys trusted to sign downloaded plugins
+func PluginSigningKeys() []string {
+	var keys []string
+	for _, k := range strings.Split(getFromConfig(pluginSigningKeys), ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// PluginSignatureRequired determines if downloaded plugins must have a valid signature from a trusted key, either via config
+// or by setting PLUGIN_SIGNATURE_REQUIRED environment variable
+func PluginSignatureRequired() bool {
+	r := os.Getenv(strings.ToUpper(pluginSignatureRequired))
+	if r == "" {
+		r = getFromConfig(pluginSignatureRequired)
+	}
+	return convertToBool(r, pluginSignatureRequired, false)
+}
+
+// ProxyURL fetches the proxy to be used for all requests of gauge, in place of HTTP_PROXY and HTTPS_PROXY environment variables
+func ProxyURL() string {
+	return strings.TrimSpace(getFromConfig(proxyURL))
+}
+
+// ProxyCredentials fetches the user name and password to authenticate with the proxy
+func ProxyCredentials() (string, string) {
+	return getFromConfig(proxyUser), getFromConfig(proxyPassword)
+}
+
 // TelemetryConsent determines if user has opted in/out of telemetry either via config or by setting
 // GAUGE_TELEMETRY_ENABLED environment variable
 func TelemetryConsent() bool {
@@ -161,7 +308,7 @@ func SetProjectRoot(args []string) error {
 	if len(args) != 0 {
 		value = args[0]
 	}
-	root, err := common.GetProjectRootFromSpecPath(value)
+	root, err := findProjectRoot(value)
 	if err != nil {
 		return err
 	}
@@ -169,6 +316,43 @@ func SetProjectRoot(args []string) error {
 	return setCurrentProjectEnvVariable()
 }
 
+// discoverProjectRootEnv holds the name of the environment variable which, when set to false, stops gauge
+// from looking for the manifest in the parent directories of the working directory, e.g. in a monorepo.
+const discoverProjectRootEnv = "GAUGE_DISCOVER_PROJECT_ROOT"
+
+// findProjectRoot gives the nearest directory, starting from the working directory and walking up, which has a manifest.
+// If there is none, it looks up from the directory of the given spec path. When the discovery is turned off, only the
+// working directory and the directory of the spec path are checked.
+func findProjectRoot(specPath string) (string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("Failed to find project root directory. %s", err.Error())
+	}
+	if discover, err := strconv.ParseBool(os.Getenv(discoverProjectRootEnv)); err == nil && !discover {
+		err := isValidGaugeProject(wd)
+		if err != nil && specPath != "" {
+			dir, _ := filepath.Split(specPath)
+			if specDir, e := filepath.Abs(dir); e == nil && isValidGaugeProject(specDir) == nil {
+				return specDir, nil
+			}
+		}
+		return wd, err
+	}
+	root, err := common.GetProjectRootFromSpecPath(specPath)
+	if err != nil {
+		return "", fmt.Errorf("%s is not a gauge project. %s not found in it or any of its parent directories", wd, common.ManifestFile)
+	}
+	return root, nil
+}
+
+// isValidGaugeProject tells if the directory is a gauge project, reporting the directory which was checked.
+func isValidGaugeProject(dir string) error {
+	if !common.FileExists(filepath.Join(dir, common.ManifestFile)) {
+		return fmt.Errorf("%s is not a gauge project. %s not found in it", dir, common.ManifestFile)
+	}
+	return nil
+}
+
 // UniqueID gets the unique installation ID.
 func UniqueID() string {
 	configDir, err := common.GetConfigurationDir()
@@ -189,7 +373,11 @@ func setCurrentProjectEnvVariable() error {
 	return common.SetEnvVariable(common.GaugeProjectRootEnv, ProjectRoot)
 }
 
+// A property which is not set, as in a gauge.properties written by an older version, takes its default silently.
 func convertToTime(value string, defaultValue time.Duration, name string) time.Duration {
+	if value == "" {
+		return defaultValue
+	}
 	intValue, err := strconv.Atoi(value)
 	if err != nil {
 		APILog.Warningf("Incorrect value for %s in property file. Cannot convert %s to time", name, value)
@@ -199,6 +387,9 @@ func convertToTime(value string, defaultValue time.Duration, name string) time.D
 }
 
 func convertToBool(value string, property string, defaultValue bool) bool {
+	if strings.TrimSpace(value) == "" {
+		return defaultValue
+	}
 	boolValue, err := strconv.ParseBool(strings.TrimSpace(value))
 	if err != nil {
 		APILog.Warningf("Incorrect value for %s in property file. Cannot convert %s to boolean.", property, value)
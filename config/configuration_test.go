@@ -18,6 +18,7 @@
 package config
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -65,6 +66,19 @@ func TestRunnerRequestTimeout(t *testing.T) {
 	}
 }
 
+func TestConnectionTimeoutsWhenNotSet(t *testing.T) {
+	getFromConfig = stubGetFromConfig
+	if got := ConnectionReadTimeout(); got != 0 {
+		t.Errorf("Expected ConnectionReadTimeout == 0, got %s", got)
+	}
+	if got := ConnectionDialTimeout(); got != defaultConnectionDialTimeout {
+		t.Errorf("Expected ConnectionDialTimeout == defaultConnectionDialTimeout(%s), got %s", defaultConnectionDialTimeout, got)
+	}
+	if got := ConnectionKeepAlive(); got != defaultConnectionKeepAlive {
+		t.Errorf("Expected ConnectionKeepAlive == defaultConnectionKeepAlive(%s), got %s", defaultConnectionKeepAlive, got)
+	}
+}
+
 func TestAllowUpdates(t *testing.T) {
 	getFromConfig = stubGetFromConfig
 	if !CheckUpdates() {
@@ -109,3 +123,49 @@ func TestReadUniqueID(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestFindProjectRootWalksUpToNearestManifest(t *testing.T) {
+	root, _ := ioutil.TempDir("", "gauge-monorepo")
+	defer os.RemoveAll(root)
+	project := filepath.Join(root, "tests", "api")
+	sub := filepath.Join(project, "specs", "users")
+	os.MkdirAll(sub, 0755)
+	ioutil.WriteFile(filepath.Join(project, common.ManifestFile), []byte(`{"Language": "java"}`), 0644)
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	os.Chdir(sub)
+	sub, _ = os.Getwd()
+	project = filepath.Dir(filepath.Dir(sub))
+
+	got, err := findProjectRoot("")
+	if err != nil || got != project {
+		t.Errorf("Expected project root %s, got %s, %v", project, got, err)
+	}
+
+	os.Setenv(discoverProjectRootEnv, "false")
+	defer os.Unsetenv(discoverProjectRootEnv)
+	_, err = findProjectRoot("")
+	want := fmt.Sprintf("%s is not a gauge project. %s not found in it", sub, common.ManifestFile)
+	if err == nil || err.Error() != want {
+		t.Errorf("Expected error %s, got %v", want, err)
+	}
+}
+
+func TestFindProjectRootFromSpecPathWithoutDiscovery(t *testing.T) {
+	root, _ := ioutil.TempDir("", "gauge-monorepo")
+	defer os.RemoveAll(root)
+	project := filepath.Join(root, "api")
+	os.MkdirAll(project, 0755)
+	ioutil.WriteFile(filepath.Join(project, common.ManifestFile), []byte(`{"Language": "java"}`), 0644)
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	os.Chdir(root)
+	os.Setenv(discoverProjectRootEnv, "false")
+	defer os.Unsetenv(discoverProjectRootEnv)
+
+	got, err := findProjectRoot(filepath.Join(project, "example.spec"))
+
+	if err != nil || got != project {
+		t.Errorf("Expected project root %s, got %s, %v", project, got, err)
+	}
+}
@@ -27,7 +27,16 @@ func TestJSONFormatter(t *testing.T) {
 	want := []string{
 		"-------------------------------------------------------------------",
 		"Key                           	Value                              ",
+		"api_listen_address            	127.0.0.1                          ",
+		"api_ports                     	                                   ",
+		"api_tls_cert_file             	                                   ",
+		"api_tls_client_ca_file        	                                   ",
+		"api_tls_key_file              	                                   ",
 		"check_updates                 	true                               ",
+		"connection_dial_timeout       	30000                              ",
+		"connection_keep_alive         	15000                              ",
+		"connection_read_timeout       	0                                  ",
+		"connection_write_timeout      	0                                  ",
 		"gauge_repository_url          	https://downloads.gauge.org/plugin ",
 		"gauge_telemetry_action_recorded	false                              ",
 		"gauge_telemetry_enabled       	true                               ",
@@ -35,10 +44,20 @@ func TestJSONFormatter(t *testing.T) {
 		"gauge_templates_url           	https://templates.gauge.org        ",
 		"gauge_update_url              	https://downloads.gauge.org/gauge  ",
 		"ide_request_timeout           	30000                              ",
+		"named_pipes                   	true                               ",
 		"plugin_connection_timeout     	10000                              ",
 		"plugin_kill_timeout           	4000                               ",
+		"plugin_ports                  	                                   ",
+		"plugin_request_timeout        	10000                              ",
+		"plugin_signature_required     	false                              ",
+		"plugin_signing_keys           	                                   ",
+		"proxy_password                	                                   ",
+		"proxy_url                     	                                   ",
+		"proxy_user                    	                                   ",
 		"runner_connection_timeout     	30000                              ",
+		"runner_ports                  	                                   ",
 		"runner_request_timeout        	30000                              ",
+		"unix_sockets                  	true                               ",
 	}
 	p := Properties()
 	var properties []property
@@ -107,12 +107,31 @@ func Properties() *properties {
 		runnerConnectionTimeout: newProperty(runnerConnectionTimeout, "30000", "Timeout in milliseconds for making a connection to the language runner."),
 		pluginConnectionTimeout: newProperty(pluginConnectionTimeout, "10000", "Timeout in milliseconds for making a connection to plugins."),
 		pluginKillTimeOut:       newProperty(pluginKillTimeOut, "4000", "Timeout in milliseconds for a plugin to stop after a kill message has been sent."),
+		pluginRequestTimeout:    newProperty(pluginRequestTimeout, "10000", "Timeout in milliseconds for plugins to respond to requests during execution."),
 		runnerRequestTimeout:    newProperty(runnerRequestTimeout, "30000", "Timeout in milliseconds for requests from the language runner."),
 		ideRequestTimeout:       newProperty(ideRequestTimeout, "30000", "Timeout in milliseconds for requests from runner when invoked for ide."),
 		checkUpdates:            newProperty(checkUpdates, "true", "Allow Gauge and its plugin updates to be notified."),
 		telemetryEnabled:        newProperty(telemetryEnabled, "true", "Allow Gauge to collect anonymous usage statistics"),
 		telemetryLoggingEnabled: newProperty(telemetryLoggingEnabled, "false", "Log request sent to Gauge telemetry engine"),
 		telemetryConsent:        newProperty(telemetryConsent, "false", "Record user opt in/out for telemetry"),
+		pluginSigningKeys:       newProperty(pluginSigningKeys, "", "Comma separated base64 encoded ed25519 public keys trusted to sign plugins."),
+		pluginSignatureRequired: newProperty(pluginSignatureRequired, "false", "Allow only plugins with a valid signature from a trusted key to be installed."),
+		proxyURL:                newProperty(proxyURL, "", "Proxy for plugin downloads, update checks and telemetry. Defaults to HTTP_PROXY and HTTPS_PROXY."),
+		proxyUser:               newProperty(proxyUser, "", "User name to authenticate with the proxy."),
+		proxyPassword:           newProperty(proxyPassword, "", "Password to authenticate with the proxy."),
+		apiPorts:                newProperty(apiPorts, "", "Comma separated ports and port ranges, like 50000-50010, for the gauge API to listen on. Empty allows any free port."),
+		runnerPorts:             newProperty(runnerPorts, "", "Comma separated ports and port ranges for gauge to listen on for the language runner. Empty allows any free port."),
+		apiListenAddress:        newProperty(apiListenAddress, "127.0.0.1", "Address the gauge daemon listens on for API clients. Addresses beyond localhost need api_tls_cert_file, api_tls_key_file and api_tls_client_ca_file."),
+		apiTLSCertFile:          newProperty(apiTLSCertFile, "", "Certificate file for the gauge daemon to serve API clients over TLS."),
+		apiTLSKeyFile:           newProperty(apiTLSKeyFile, "", "Private key file of the certificate for the gauge daemon to serve API clients over TLS."),
+		apiTLSClientCAFile:      newProperty(apiTLSClientCAFile, "", "CA certificates file to verify the certificates API clients of the gauge daemon must present."),
+		connectionReadTimeout:   newProperty(connectionReadTimeout, "0", "Timeout in milliseconds to wait for runners and plugins to respond to messages which have no timeout of their own. 0 waits indefinitely."),
+		connectionWriteTimeout:  newProperty(connectionWriteTimeout, "0", "Timeout in milliseconds for writing a message to runners and plugins. 0 waits indefinitely."),
+		connectionDialTimeout:   newProperty(connectionDialTimeout, "30000", "Timeout in milliseconds for connecting to runners which gauge dials."),
+		connectionKeepAlive:     newProperty(connectionKeepAlive, "15000", "Interval in milliseconds of TCP keep-alives on connections to runners and plugins. 0 disables keep-alives."),
+		unixSockets:             newProperty(unixSockets, "true", "Connect to runners and plugins which support it over unix sockets instead of localhost ports."),
+		namedPipes:              newProperty(namedPipes, "true", "Connect to runners and plugins which support it over named pipes on windows instead of localhost ports."),
+		pluginPorts:             newProperty(pluginPorts, "", "Comma separated ports and port ranges for gauge to listen on for plugins. Empty allows any free port."),
 	}}
 }
 
@@ -163,7 +182,11 @@ func List(machineReadable bool) (string, error) {
 	if machineReadable {
 		f = &jsonFormatter{}
 	}
-	return MergedProperties().Format(f)
+	p := MergedProperties()
+	if pwd := p.p[proxyPassword]; pwd.Value != "" {
+		pwd.Value = "********"
+	}
+	return p.Format(f)
 }
 
 func newProperty(key, defaultValue, description string) *property {
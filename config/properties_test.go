@@ -103,9 +103,36 @@ func TestMergedProperties(t *testing.T) {
 var propertiesContent = "# Version " + version.CurrentGaugeVersion.String() + `
 # This file contains Gauge specific internal configurations. Do not delete
 
+# Address the gauge daemon listens on for API clients. Addresses beyond localhost need api_tls_cert_file, api_tls_key_file and api_tls_client_ca_file.
+api_listen_address = 127.0.0.1
+
+# Comma separated ports and port ranges, like 50000-50010, for the gauge API to listen on. Empty allows any free port.
+api_ports = 
+
+# Certificate file for the gauge daemon to serve API clients over TLS.
+api_tls_cert_file = 
+
+# CA certificates file to verify the certificates API clients of the gauge daemon must present.
+api_tls_client_ca_file = 
+
+# Private key file of the certificate for the gauge daemon to serve API clients over TLS.
+api_tls_key_file = 
+
 # Allow Gauge and its plugin updates to be notified.
 check_updates = true
 
+# Timeout in milliseconds for connecting to runners which gauge dials.
+connection_dial_timeout = 30000
+
+# Interval in milliseconds of TCP keep-alives on connections to runners and plugins. 0 disables keep-alives.
+connection_keep_alive = 15000
+
+# Timeout in milliseconds to wait for runners and plugins to respond to messages which have no timeout of their own. 0 waits indefinitely.
+connection_read_timeout = 0
+
+# Timeout in milliseconds for writing a message to runners and plugins. 0 waits indefinitely.
+connection_write_timeout = 0
+
 # Url to get plugin versions
 gauge_repository_url = https://downloads.gauge.org/plugin
 
@@ -127,17 +154,47 @@ gauge_update_url = https://downloads.gauge.org/gauge
 # Timeout in milliseconds for requests from runner when invoked for ide.
 ide_request_timeout = 30000
 
+# Connect to runners and plugins which support it over named pipes on windows instead of localhost ports.
+named_pipes = true
+
 # Timeout in milliseconds for making a connection to plugins.
 plugin_connection_timeout = 10000
 
 # Timeout in milliseconds for a plugin to stop after a kill message has been sent.
 plugin_kill_timeout = 4000
 
+# Comma separated ports and port ranges for gauge to listen on for plugins. Empty allows any free port.
+plugin_ports = 
+
+# Timeout in milliseconds for plugins to respond to requests during execution.
+plugin_request_timeout = 10000
+
+# Allow only plugins with a valid signature from a trusted key to be installed.
+plugin_signature_required = false
+
+# Comma separated base64 encoded ed25519 public keys trusted to sign plugins.
+plugin_signing_keys = 
+
+# Password to authenticate with the proxy.
+proxy_password = 
+
+# Proxy for plugin downloads, update checks and telemetry. Defaults to HTTP_PROXY and HTTPS_PROXY.
+proxy_url = 
+
+# User name to authenticate with the proxy.
+proxy_user = 
+
 # Timeout in milliseconds for making a connection to the language runner.
 runner_connection_timeout = 30000
 
+# Comma separated ports and port ranges for gauge to listen on for the language runner. Empty allows any free port.
+runner_ports = 
+
 # Timeout in milliseconds for requests from the language runner.
 runner_request_timeout = 30000
+
+# Connect to runners and plugins which support it over unix sockets instead of localhost ports.
+unix_sockets = true
 `
 
 func TestPropertiesString(t *testing.T) {
@@ -19,8 +19,10 @@ package conn
 
 import (
 	"bytes"
+	"crypto/tls"
 	"fmt"
 	"net"
+	"strconv"
 	"time"
 
 	"github.com/getgauge/gauge/logger"
@@ -32,8 +34,15 @@ type messageHandler interface {
 	MessageBytesReceived([]byte, net.Conn)
 }
 
+// connectionListener can be implemented by a message handler that serves several clients and needs to know when a
+// client connects or goes away.
+type connectionListener interface {
+	ConnectionOpened(net.Conn)
+	ConnectionClosed(net.Conn)
+}
+
 type GaugeConnectionHandler struct {
-	tcpListener    *net.TCPListener
+	listener       net.Listener
 	messageHandler messageHandler
 }
 
@@ -48,18 +57,31 @@ func NewGaugeConnectionHandler(port int, messageHandler messageHandler) (*GaugeC
 		return nil, err
 	}
 
-	return &GaugeConnectionHandler{tcpListener: listener, messageHandler: messageHandler}, nil
+	return &GaugeConnectionHandler{listener: listener, messageHandler: messageHandler}, nil
+}
+
+// NewTLSGaugeConnectionHandler listens on the given host and port over TLS, or over plain TCP if tlsConfig is nil.
+func NewTLSGaugeConnectionHandler(host string, port int, tlsConfig *tls.Config, messageHandler messageHandler) (*GaugeConnectionHandler, error) {
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+	return &GaugeConnectionHandler{listener: listener, messageHandler: messageHandler}, nil
 }
 
 func (connectionHandler *GaugeConnectionHandler) AcceptConnection(connectionTimeOut time.Duration, errChannel chan error) (net.Conn, error) {
 	connectionChannel := make(chan net.Conn)
 
 	go func() {
-		connection, err := connectionHandler.tcpListener.Accept()
+		connection, err := connectionHandler.listener.Accept()
 		if err != nil {
 			errChannel <- err
 		}
 		if connection != nil {
+			setKeepAlive(connection)
 			connectionChannel <- connection
 		}
 	}()
@@ -73,7 +95,17 @@ func (connectionHandler *GaugeConnectionHandler) AcceptConnection(connectionTime
 		}
 		return conn, nil
 	case <-time.After(connectionTimeOut):
-		return nil, fmt.Errorf("Timed out connecting to %v", connectionHandler.tcpListener.Addr())
+		return nil, fmt.Errorf("Timed out connecting to %v", connectionHandler.listener.Addr())
+	}
+}
+
+// setKeepAlive applies KeepAlive to tcp connections, so that a peer which went away without closing is noticed.
+func setKeepAlive(connection net.Conn) {
+	if c, ok := connection.(*net.TCPConn); ok {
+		c.SetKeepAlive(KeepAlive > 0)
+		if KeepAlive > 0 {
+			c.SetKeepAlivePeriod(KeepAlive)
+		}
 	}
 }
 
@@ -82,11 +114,12 @@ func (connectionHandler *GaugeConnectionHandler) acceptConnectionWithoutTimeout(
 	connectionChannel := make(chan net.Conn)
 
 	go func() {
-		connection, err := connectionHandler.tcpListener.Accept()
+		connection, err := connectionHandler.listener.Accept()
 		if err != nil {
 			errChannel <- err
 		}
 		if connection != nil {
+			setKeepAlive(connection)
 			connectionChannel <- connection
 		}
 	}()
@@ -103,6 +136,10 @@ func (connectionHandler *GaugeConnectionHandler) acceptConnectionWithoutTimeout(
 }
 
 func (connectionHandler *GaugeConnectionHandler) handleConnectionMessages(conn net.Conn) {
+	if listener, ok := connectionHandler.messageHandler.(connectionListener); ok {
+		listener.ConnectionOpened(conn)
+		defer listener.ConnectionClosed(conn)
+	}
 	buffer := new(bytes.Buffer)
 	data := make([]byte, 8192)
 	for {
@@ -121,7 +158,7 @@ func (connectionHandler *GaugeConnectionHandler) handleConnectionMessages(conn n
 func (connectionHandler *GaugeConnectionHandler) processMessage(buffer *bytes.Buffer, conn net.Conn) {
 	for {
 		messageLength, bytesRead := proto.DecodeVarint(buffer.Bytes())
-		if messageLength > 0 && messageLength < uint64(buffer.Len()) {
+		if messageLength > 0 && messageLength+uint64(bytesRead) <= uint64(buffer.Len()) {
 			messageBoundary := int(messageLength) + bytesRead
 			receivedBytes := buffer.Bytes()[bytesRead : messageLength+uint64(bytesRead)]
 			connectionHandler.messageHandler.MessageBytesReceived(receivedBytes, conn)
@@ -144,8 +181,30 @@ func (connectionHandler *GaugeConnectionHandler) HandleMultipleConnections() {
 }
 
 func (connectionHandler *GaugeConnectionHandler) ConnectionPortNumber() int {
-	if connectionHandler.tcpListener != nil {
-		return connectionHandler.tcpListener.Addr().(*net.TCPAddr).Port
+	if connectionHandler.listener != nil {
+		if addr, ok := connectionHandler.listener.Addr().(*net.TCPAddr); ok {
+			return addr.Port
+		}
 	}
 	return 0
 }
+
+// SocketPath gives the path of the unix socket listened on, or an empty string when listening on a port.
+func (connectionHandler *GaugeConnectionHandler) SocketPath() string {
+	if connectionHandler.listener != nil {
+		if addr, ok := connectionHandler.listener.Addr().(*net.UnixAddr); ok {
+			return addr.Name
+		}
+	}
+	return ""
+}
+
+// PipeName gives the name of the windows named pipe listened on, or an empty string when not listening on a pipe.
+func (connectionHandler *GaugeConnectionHandler) PipeName() string {
+	if connectionHandler.listener != nil {
+		if addr, ok := connectionHandler.listener.Addr().(pipeAddr); ok {
+			return string(addr)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package conn
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+)
+
+type recordingHandler struct {
+	messages [][]byte
+	opened   chan net.Conn
+	closed   chan net.Conn
+}
+
+func (h *recordingHandler) MessageBytesReceived(b []byte, conn net.Conn) {
+	h.messages = append(h.messages, append([]byte{}, b...))
+}
+
+func (h *recordingHandler) ConnectionOpened(conn net.Conn) {
+	h.opened <- conn
+}
+
+func (h *recordingHandler) ConnectionClosed(conn net.Conn) {
+	h.closed <- conn
+}
+
+func TestProcessMessageWaitsForCompleteMessage(t *testing.T) {
+	h := &recordingHandler{}
+	handler := &GaugeConnectionHandler{messageHandler: h}
+	message := bytes.Repeat([]byte("a"), 200)
+	data := append(proto.EncodeVarint(uint64(len(message))), message...)
+	buffer := new(bytes.Buffer)
+
+	buffer.Write(data[:len(data)-1])
+	handler.processMessage(buffer, nil)
+	if len(h.messages) != 0 {
+		t.Fatalf("Expected incomplete message not to be handled, got %d messages", len(h.messages))
+	}
+
+	buffer.Write(data[len(data)-1:])
+	handler.processMessage(buffer, nil)
+	if len(h.messages) != 1 || !bytes.Equal(h.messages[0], message) {
+		t.Fatalf("Expected complete message to be handled once, got %d messages", len(h.messages))
+	}
+}
+
+func TestHandleConnectionMessagesNotifiesListener(t *testing.T) {
+	h := &recordingHandler{opened: make(chan net.Conn, 1), closed: make(chan net.Conn, 1)}
+	handler := &GaugeConnectionHandler{messageHandler: h}
+	server, client := net.Pipe()
+
+	go handler.handleConnectionMessages(server)
+	if got := <-h.opened; got != server {
+		t.Errorf("Expected listener to be told about the opened connection")
+	}
+	client.Close()
+	if got := <-h.closed; got != server {
+		t.Errorf("Expected listener to be told about the closed connection")
+	}
+}
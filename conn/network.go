@@ -31,6 +31,18 @@ import (
 	"github.com/golang/protobuf/proto"
 )
 
+// ReadTimeout is how long to wait for a response to a message sent without a timeout of its own. Zero waits indefinitely.
+var ReadTimeout time.Duration
+
+// WriteTimeout is how long writing a message may take. Zero waits indefinitely.
+var WriteTimeout time.Duration
+
+// DialTimeout is how long connecting to a runner which gauge dials may take.
+var DialTimeout = 30 * time.Second
+
+// KeepAlive is the interval of TCP keep-alives on accepted connections. Zero disables keep-alives.
+var KeepAlive = 15 * time.Second
+
 type response struct {
 	result chan *gauge_messages.Message
 	err    chan error
@@ -46,7 +58,7 @@ func (r *response) stopTimer() {
 func (r *response) addTimer(timeout time.Duration, message *gauge_messages.Message) {
 	if timeout > 0 {
 		r.timer = time.AfterFunc(timeout, func() {
-			r.err <- fmt.Errorf("Request timed out for Message with ID => %v and Type => %s", message.GetMessageId(), message.GetMessageType().String())
+			r.err <- timeoutError(message)
 		})
 	}
 }
@@ -56,10 +68,11 @@ type messages struct {
 	sync.Mutex
 }
 
-func (m *messages) get(k int64) response {
+func (m *messages) get(k int64) (response, bool) {
 	m.Lock()
 	defer m.Unlock()
-	return m.m[k]
+	res, ok := m.m[k]
+	return res, ok
 }
 
 func (m *messages) put(k int64, res response) {
@@ -76,19 +89,16 @@ func (m *messages) delete(k int64) {
 
 var m = &messages{m: make(map[int64]response)}
 
-func writeDataAndGetResponse(conn net.Conn, messageBytes []byte) ([]byte, error) {
-	if err := Write(conn, messageBytes); err != nil {
-		return nil, err
-	}
-	return readResponse(conn)
-}
-
 func readResponse(conn net.Conn) ([]byte, error) {
 	buffer := new(bytes.Buffer)
 	data := make([]byte, 8192)
 	for {
 		n, err := conn.Read(data)
 		if err != nil {
+			if isTimeout(err) {
+				// the connection is still good, the response is dropped when it comes
+				return nil, err
+			}
 			conn.Close()
 			return nil, fmt.Errorf("Connection closed [%s] cause: %s", conn.RemoteAddr(), err.Error())
 		}
@@ -104,10 +114,54 @@ func readResponse(conn net.Conn) ([]byte, error) {
 func Write(conn net.Conn, messageBytes []byte) error {
 	messageLen := proto.EncodeVarint(uint64(len(messageBytes)))
 	data := append(messageLen, messageBytes...)
+	if WriteTimeout > 0 {
+		conn.SetWriteDeadline(time.Now().Add(WriteTimeout))
+		defer conn.SetWriteDeadline(time.Time{})
+	}
 	_, err := conn.Write(data)
 	return err
 }
 
+// WriteParts writes a message of the given length, like Write, with its bytes given one part at a time by parts so that
+// the whole message need not be held in memory. The parts must add up to length bytes.
+func WriteParts(conn net.Conn, length int, parts func(write func([]byte) error) error) error {
+	written := 0
+	write := func(b []byte) error {
+		if WriteTimeout > 0 {
+			conn.SetWriteDeadline(time.Now().Add(WriteTimeout))
+		}
+		n, err := conn.Write(b)
+		written += n
+		return err
+	}
+	if WriteTimeout > 0 {
+		defer conn.SetWriteDeadline(time.Time{})
+	}
+	if err := write(proto.EncodeVarint(uint64(length))); err != nil {
+		return err
+	}
+	written = 0
+	if err := parts(write); err != nil {
+		return err
+	}
+	if written != length {
+		return fmt.Errorf("Wrote %d bytes of a message of %d bytes", written, length)
+	}
+	return nil
+}
+
+// readDeadline gives the time to wait for the response to a message until. The timeout of the message takes precedence
+// over ReadTimeout.
+func readDeadline(timeout time.Duration) time.Time {
+	if timeout <= 0 {
+		timeout = ReadTimeout
+	}
+	if timeout <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(timeout)
+}
+
 func WriteGaugeMessage(message *gauge_messages.Message, conn net.Conn) error {
 	messageID := common.GetUniqueID()
 	message.MessageId = messageID
@@ -120,34 +174,60 @@ func WriteGaugeMessage(message *gauge_messages.Message, conn net.Conn) error {
 }
 
 func getResponseForGaugeMessage(message *gauge_messages.Message, conn net.Conn, res response, timeout time.Duration) {
-	message.MessageId = common.GetUniqueID()
 	res.addTimer(timeout, message)
-	handle := func(err error) {
+	handle := func(err error) bool {
 		if err != nil {
 			res.stopTimer()
 			res.err <- err
 		}
+		return err != nil
 	}
 
 	data, err := proto.Marshal(message)
-
-	handle(err)
+	if handle(err) {
+		return
+	}
 	m.put(message.GetMessageId(), res)
 
-	responseBytes, err := writeDataAndGetResponse(conn, data)
-	handle(err)
+	if handle(Write(conn, data)) {
+		return
+	}
+	conn.SetReadDeadline(readDeadline(timeout))
+	defer conn.SetReadDeadline(time.Time{})
+	for {
+		responseBytes, err := readResponse(conn)
+		if isTimeout(err) {
+			err = timeoutError(message)
+		}
+		if handle(err) {
+			return
+		}
 
-	responseMessage := &gauge_messages.Message{}
-	err = proto.Unmarshal(responseBytes, responseMessage)
-	handle(err)
+		responseMessage := &gauge_messages.Message{}
+		if handle(proto.Unmarshal(responseBytes, responseMessage)) || handle(checkUnsupportedResponseMessage(responseMessage)) {
+			return
+		}
 
-	err = checkUnsupportedResponseMessage(responseMessage)
-	handle(err)
+		responseRes, ok := m.get(responseMessage.GetMessageId())
+		if !ok {
+			// a late response to a message which timed out
+			continue
+		}
+		responseRes.stopTimer()
+		m.delete(responseMessage.GetMessageId())
+		responseRes.result <- responseMessage
+		return
+	}
+}
+
+// isTimeout tells if the error is from a deadline passing. Errors of named pipes on windows are not net errors.
+func isTimeout(err error) bool {
+	t, ok := err.(interface{ Timeout() bool })
+	return ok && t.Timeout()
+}
 
-	responseRes := m.get(responseMessage.GetMessageId())
-	responseRes.stopTimer()
-	responseRes.result <- responseMessage
-	m.delete(responseMessage.GetMessageId())
+func timeoutError(message *gauge_messages.Message) error {
+	return fmt.Errorf("Request timed out for Message with ID => %v and Type => %s", message.GetMessageId(), message.GetMessageType().String())
 }
 
 func checkUnsupportedResponseMessage(message *gauge_messages.Message) error {
@@ -157,13 +237,16 @@ func checkUnsupportedResponseMessage(message *gauge_messages.Message) error {
 	return nil
 }
 
-// Sends request to plugin for a message. If response is not received for the given message within the configured timeout, an error is thrown
-// To wait indefinitely for the response from the plugin, set timeout value as 0.
+// Sends request to plugin for a message. If response is not received for the given message within the given timeout, an error is thrown
+// To wait for the response as long as ReadTimeout allows, set timeout value as 0.
 func GetResponseForMessageWithTimeout(message *gauge_messages.Message, conn net.Conn, timeout time.Duration) (*gauge_messages.Message, error) {
-	res := response{result: make(chan *gauge_messages.Message), err: make(chan error)}
+	// buffered, so that the goroutine does not block on a response nobody waits for after a timeout
+	res := response{result: make(chan *gauge_messages.Message, 1), err: make(chan error, 2)}
+	message.MessageId = common.GetUniqueID()
 	go getResponseForGaugeMessage(message, conn, res, timeout)
 	select {
 	case err := <-res.err:
+		m.delete(message.GetMessageId())
 		return nil, err
 	case res := <-res.result:
 		return res, nil
@@ -241,3 +241,78 @@ func TestGetResponseForGaugeMessageShoudNotErrorIfNoTimeoutIsSpecified(t *testin
 		t.Errorf("expected : %v\ngot : %v", responseMessage, res)
 	}
 }
+
+func readMessage(t *testing.T, c net.Conn) *gauge_messages.Message {
+	data, err := readResponse(c)
+	if err != nil {
+		t.Errorf("Expected to read a message. Got %s", err.Error())
+	}
+	message := &gauge_messages.Message{}
+	proto.Unmarshal(data, message)
+	return message
+}
+
+func TestGetResponseForGaugeMessageDropsResponsesAfterReadTimeout(t *testing.T) {
+	gauge, runner := net.Pipe()
+	defer gauge.Close()
+	defer runner.Close()
+	requests := make(chan *gauge_messages.Message, 2)
+	go func() {
+		for i := 0; i < 2; i++ {
+			requests <- readMessage(t, runner)
+		}
+	}()
+
+	_, err := GetResponseForMessageWithTimeout(&gauge_messages.Message{MessageType: gauge_messages.Message_StepNamesRequest}, gauge, 50*time.Millisecond)
+	if err == nil {
+		t.Fatalf("Expected the request to time out")
+	}
+	late := <-requests
+
+	go func() {
+		next := <-requests
+		WriteGaugeMessage(&gauge_messages.Message{MessageType: gauge_messages.Message_StepNamesResponse}, runner)
+		Write(runner, mustMarshal(&gauge_messages.Message{MessageId: late.MessageId, MessageType: gauge_messages.Message_StepNamesResponse}))
+		Write(runner, mustMarshal(&gauge_messages.Message{MessageId: next.MessageId, MessageType: gauge_messages.Message_StepNameResponse}))
+	}()
+	res, err := GetResponseForMessageWithTimeout(&gauge_messages.Message{MessageType: gauge_messages.Message_StepNameRequest}, gauge, time.Second)
+
+	if err != nil {
+		t.Fatalf("Expected the response to the second request. Got %s", err.Error())
+	}
+	if res.GetMessageType() != gauge_messages.Message_StepNameResponse {
+		t.Errorf("Expected StepNameResponse. Got %s", res.GetMessageType())
+	}
+}
+
+func TestGetResponseForGaugeMessageWithoutTimeoutUsesReadTimeout(t *testing.T) {
+	defer func(d time.Duration) { ReadTimeout = d }(ReadTimeout)
+	ReadTimeout = 50 * time.Millisecond
+	gauge, runner := net.Pipe()
+	defer gauge.Close()
+	defer runner.Close()
+	go readMessage(t, runner)
+
+	_, err := GetResponseForMessageWithTimeout(&gauge_messages.Message{MessageType: gauge_messages.Message_StepNamesRequest}, gauge, 0)
+
+	if err == nil {
+		t.Errorf("Expected the request to time out")
+	}
+}
+
+func TestWriteTimesOutWhenNobodyReads(t *testing.T) {
+	defer func(d time.Duration) { WriteTimeout = d }(WriteTimeout)
+	WriteTimeout = 50 * time.Millisecond
+	gauge, runner := net.Pipe()
+	defer gauge.Close()
+	defer runner.Close()
+
+	if err := Write(gauge, []byte("hello")); !isTimeout(err) {
+		t.Errorf("Expected the write to time out. Got %v", err)
+	}
+}
+
+func mustMarshal(message *gauge_messages.Message) []byte {
+	data, _ := proto.Marshal(message)
+	return data
+}
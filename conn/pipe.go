@@ -0,0 +1,47 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package conn
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+)
+
+var pipes int32
+
+// pipeAddr is the name of a windows named pipe, like \\.\pipe\gauge-1234-1
+type pipeAddr string
+
+func (a pipeAddr) Network() string { return "pipe" }
+func (a pipeAddr) String() string  { return string(a) }
+
+func pipeName() string {
+	return fmt.Sprintf(`\\.\pipe\gauge-%d-%d`, os.Getpid(), atomic.AddInt32(&pipes, 1))
+}
+
+// NewGaugePipeConnectionHandler listens on a windows named pipe instead of a localhost port, for runners and plugins
+// which support it. Named pipes do not go through the network stack, so they are not held up by antivirus and firewall
+// software inspecting localhost traffic. It fails on other platforms.
+func NewGaugePipeConnectionHandler(messageHandler messageHandler) (*GaugeConnectionHandler, error) {
+	listener, err := listenPipe(pipeName())
+	if err != nil {
+		return nil, err
+	}
+	return &GaugeConnectionHandler{listener: listener, messageHandler: messageHandler}, nil
+}
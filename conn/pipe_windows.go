@@ -0,0 +1,178 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package conn
+
+import (
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	pipeAccessDuplex          = 0x3
+	fileFlagFirstPipeInstance = 0x80000
+	fileFlagOverlapped        = 0x40000000
+	pipeRejectRemoteClients   = 0x8
+	pipeUnlimitedInstances    = 255
+	pipeBufferSize            = 64 * 1024
+	errorPipeConnected        = syscall.Errno(535)
+)
+
+var (
+	kernel32                = syscall.NewLazyDLL("kernel32.dll")
+	procCreateNamedPipeW    = kernel32.NewProc("CreateNamedPipeW")
+	procConnectNamedPipe    = kernel32.NewProc("ConnectNamedPipe")
+	procCreateEventW        = kernel32.NewProc("CreateEventW")
+	procGetOverlappedResult = kernel32.NewProc("GetOverlappedResult")
+	// errClosed has the message of net.ErrClosed, which is only there from Go 1.16.
+	errClosed = errors.New("use of closed network connection")
+)
+
+// pipeListener accepts connections on a named pipe. An instance of the pipe is always created ahead of Accept, so that
+// clients started before Accept is called find the pipe.
+type pipeListener struct {
+	name        string
+	acceptMutex sync.Mutex
+	mutex       sync.Mutex
+	next        syscall.Handle
+	accepting   bool
+	closed      bool
+}
+
+func listenPipe(name string) (net.Listener, error) {
+	h, err := createPipe(name, true)
+	if err != nil {
+		return nil, &net.OpError{Op: "listen", Net: "pipe", Addr: pipeAddr(name), Err: err}
+	}
+	return &pipeListener{name: name, next: h}, nil
+}
+
+func createPipe(name string, first bool) (syscall.Handle, error) {
+	n, err := syscall.UTF16PtrFromString(name)
+	if err != nil {
+		return syscall.InvalidHandle, err
+	}
+	mode := uint32(pipeAccessDuplex | fileFlagOverlapped)
+	if first {
+		mode |= fileFlagFirstPipeInstance
+	}
+	r, _, e := procCreateNamedPipeW.Call(uintptr(unsafe.Pointer(n)), uintptr(mode), pipeRejectRemoteClients,
+		pipeUnlimitedInstances, pipeBufferSize, pipeBufferSize, 0, 0)
+	if syscall.Handle(r) == syscall.InvalidHandle {
+		return syscall.InvalidHandle, e
+	}
+	return syscall.Handle(r), nil
+}
+
+func (l *pipeListener) Accept() (net.Conn, error) {
+	l.acceptMutex.Lock()
+	defer l.acceptMutex.Unlock()
+	l.mutex.Lock()
+	if l.closed {
+		l.mutex.Unlock()
+		return nil, l.closedError()
+	}
+	h := l.next
+	l.accepting = true
+	l.mutex.Unlock()
+
+	err := l.waitForClient(h)
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.accepting = false
+	if err == nil && !l.closed {
+		l.next, err = createPipe(l.name, false)
+	}
+	if l.closed {
+		err = l.closedError()
+	}
+	if err != nil {
+		syscall.CloseHandle(h)
+		l.closed = true
+		return nil, err
+	}
+	return &pipeConn{File: os.NewFile(uintptr(h), l.name), addr: pipeAddr(l.name)}, nil
+}
+
+// waitForClient blocks until a client opens the pipe instance. The handle is not yet known to the runtime poller, so
+// the overlapped connect is waited for with an event. Close cancels the wait.
+func (l *pipeListener) waitForClient(h syscall.Handle) error {
+	e, _, err := procCreateEventW.Call(0, 1, 0, 0)
+	if e == 0 {
+		return err
+	}
+	defer syscall.CloseHandle(syscall.Handle(e))
+	o := &syscall.Overlapped{HEvent: syscall.Handle(e)}
+	r, _, err := procConnectNamedPipe.Call(uintptr(h), uintptr(unsafe.Pointer(o)))
+	if r != 0 || err == errorPipeConnected {
+		return nil
+	}
+	if err != syscall.ERROR_IO_PENDING {
+		return err
+	}
+	l.mutex.Lock()
+	if l.closed {
+		syscall.CancelIoEx(h, o)
+	}
+	l.mutex.Unlock()
+	if _, err := syscall.WaitForSingleObject(o.HEvent, syscall.INFINITE); err != nil {
+		return err
+	}
+	var n uint32
+	r, _, err = procGetOverlappedResult.Call(uintptr(h), uintptr(unsafe.Pointer(o)), uintptr(unsafe.Pointer(&n)), 0)
+	if r == 0 {
+		return err
+	}
+	return nil
+}
+
+func (l *pipeListener) closedError() error {
+	return &net.OpError{Op: "accept", Net: "pipe", Addr: pipeAddr(l.name), Err: errClosed}
+}
+
+func (l *pipeListener) Close() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+	if l.accepting {
+		// Accept closes the handle once the wait is cancelled
+		return syscall.CancelIoEx(l.next, nil)
+	}
+	return syscall.CloseHandle(l.next)
+}
+
+func (l *pipeListener) Addr() net.Addr {
+	return pipeAddr(l.name)
+}
+
+// pipeConn is a connected instance of a named pipe. The handle is opened for overlapped io, so os.File reads and writes
+// go through the runtime poller and support deadlines.
+type pipeConn struct {
+	*os.File
+	addr pipeAddr
+}
+
+func (c *pipeConn) LocalAddr() net.Addr  { return c.addr }
+func (c *pipeConn) RemoteAddr() net.Addr { return c.addr }
@@ -0,0 +1,74 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package conn
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestGaugePipeConnectionHandlerAcceptsConnections(t *testing.T) {
+	h, err := NewGaugePipeConnectionHandler(nil)
+	if err != nil {
+		t.Fatalf("Expected no error. Got %s", err.Error())
+	}
+	defer h.listener.Close()
+	if h.PipeName() == "" || h.ConnectionPortNumber() != 0 {
+		t.Fatalf("Expected to listen on a named pipe. Got %q, port %d", h.PipeName(), h.ConnectionPortNumber())
+	}
+
+	go func() {
+		if c, err := os.OpenFile(h.PipeName(), os.O_RDWR, 0); err == nil {
+			c.Write([]byte("hello"))
+			c.Close()
+		}
+	}()
+	c, err := h.AcceptConnection(5*time.Second, make(chan error))
+	if err != nil {
+		t.Fatalf("Expected a connection. Got %s", err.Error())
+	}
+	defer c.Close()
+	data := make([]byte, 5)
+	if _, err := c.Read(data); err != nil || string(data) != "hello" {
+		t.Errorf("Expected to read from the connection. Got %q, %v", data, err)
+	}
+}
+
+func TestGaugePipeListenerCloseStopsAccept(t *testing.T) {
+	h, err := NewGaugePipeConnectionHandler(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	errChannel := make(chan error)
+	go func() {
+		_, err := h.listener.Accept()
+		errChannel <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	h.listener.Close()
+
+	select {
+	case err := <-errChannel:
+		if err == nil {
+			t.Errorf("Expected accept to fail on a closed listener")
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("Expected close to stop accept")
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package conn
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const maxPort = 65535
+
+// ParsePorts parses a comma separated list of ports and port ranges, like 50000-50010,50020. An empty list allows any port, which is given as 0.
+func ParsePorts(list string) ([]int, error) {
+	if strings.TrimSpace(list) == "" {
+		return []int{0}, nil
+	}
+	var ports []int
+	for _, p := range strings.Split(list, ",") {
+		p = strings.TrimSpace(p)
+		bounds := strings.SplitN(p, "-", 2)
+		from, err := parsePort(bounds[0])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid port %q in %q", p, list)
+		}
+		to := from
+		if len(bounds) == 2 {
+			if to, err = parsePort(bounds[1]); err != nil || to < from {
+				return nil, fmt.Errorf("Invalid port range %q in %q", p, list)
+			}
+		}
+		for port := from; port <= to; port++ {
+			ports = append(ports, port)
+		}
+	}
+	return ports, nil
+}
+
+func parsePort(s string) (int, error) {
+	port, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || port < 1 || port > maxPort {
+		return 0, fmt.Errorf("%s is not a valid port", s)
+	}
+	return port, nil
+}
+
+// NewGaugeConnectionHandlerOnPorts listens on the first free port out of a list of ports and port ranges, which
+// lets gauge work where local firewalls allow only certain ports. An empty list listens on any free port.
+func NewGaugeConnectionHandlerOnPorts(list string, messageHandler messageHandler) (*GaugeConnectionHandler, error) {
+	ports, err := ParsePorts(list)
+	if err != nil {
+		return nil, err
+	}
+	var handler *GaugeConnectionHandler
+	for _, port := range ports {
+		if handler, err = NewGaugeConnectionHandler(port, messageHandler); err == nil {
+			return handler, nil
+		}
+	}
+	if strings.TrimSpace(list) == "" {
+		return nil, err
+	}
+	return nil, fmt.Errorf("None of the ports %s is free. %s", list, err.Error())
+}
@@ -0,0 +1,81 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package conn
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePorts(t *testing.T) {
+	got, err := ParsePorts("50000-50002, 50010")
+	if err != nil {
+		t.Fatalf("Expected no error. Got %s", err.Error())
+	}
+	if want := []int{50000, 50001, 50002, 50010}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v. Got %v", want, got)
+	}
+	if got, _ := ParsePorts(" "); !reflect.DeepEqual(got, []int{0}) {
+		t.Errorf("Expected an empty list to allow any port. Got %v", got)
+	}
+}
+
+func TestParsePortsWithInvalidPorts(t *testing.T) {
+	for list, want := range map[string]string{
+		"abc":         `Invalid port "abc" in "abc"`,
+		"70000":       `Invalid port "70000" in "70000"`,
+		"50010-50000": `Invalid port range "50010-50000" in "50010-50000"`,
+		"50000,":      `Invalid port "" in "50000,"`,
+	} {
+		if _, err := ParsePorts(list); err == nil || err.Error() != want {
+			t.Errorf("Expected error %q for %q. Got %v", want, list, err)
+		}
+	}
+}
+
+func TestNewGaugeConnectionHandlerOnPortsSkipsPortsInUse(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	used := l.Addr().(*net.TCPAddr).Port
+	free, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	freePort := free.Addr().(*net.TCPAddr).Port
+	free.Close()
+
+	h, err := NewGaugeConnectionHandlerOnPorts(fmt.Sprintf("%d,%d", used, freePort), nil)
+	if err != nil {
+		t.Fatalf("Expected no error. Got %s", err.Error())
+	}
+	defer h.listener.Close()
+	if h.ConnectionPortNumber() != freePort {
+		t.Errorf("Expected to listen on %d. Got %d", freePort, h.ConnectionPortNumber())
+	}
+
+	_, err = NewGaugeConnectionHandlerOnPorts(fmt.Sprintf("%d", used), nil)
+	if err == nil || !strings.HasPrefix(err.Error(), fmt.Sprintf("None of the ports %d is free.", used)) {
+		t.Errorf("Expected an error when all ports are in use. Got %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package conn
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+)
+
+var sockets int32
+
+// socketAddress gives a new address for a unix socket. On linux the socket is abstract, which is named with a leading @
+// and leaves no file behind. Elsewhere the socket is a file in the temp directory.
+func socketAddress() string {
+	name := fmt.Sprintf("gauge-%d-%d", os.Getpid(), atomic.AddInt32(&sockets, 1))
+	if runtime.GOOS == "linux" {
+		return "@" + name
+	}
+	return filepath.Join(os.TempDir(), name+".sock")
+}
+
+// NewGaugeSocketConnectionHandler listens on a unix socket instead of a localhost port, for runners and plugins which
+// support it. This avoids port conflicts and firewall prompts.
+func NewGaugeSocketConnectionHandler(messageHandler messageHandler) (*GaugeConnectionHandler, error) {
+	address := socketAddress()
+	if runtime.GOOS != "linux" {
+		// a socket file left behind by an earlier process with the same pid
+		os.Remove(address)
+	}
+	listener, err := net.Listen("unix", address)
+	if err != nil {
+		return nil, err
+	}
+	return &GaugeConnectionHandler{listener: listener, messageHandler: messageHandler}, nil
+}
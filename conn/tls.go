@@ -0,0 +1,76 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package conn
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+
+	"github.com/getgauge/gauge/config"
+)
+
+// ServerTLSConfig gives the TLS configuration of gauge serving its clients, from the given certificate and key files, or
+// nil if they are not set. Clients must present a certificate signed by one of the CAs in clientCAFile, if it is set.
+func ServerTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	if certFile == "" && keyFile == "" {
+		if clientCAFile != "" {
+			return nil, fmt.Errorf("api_tls_client_ca_file needs api_tls_cert_file and api_tls_key_file to be set")
+		}
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load the TLS certificate of gauge. %s", err.Error())
+	}
+	c := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	if clientCAFile == "" {
+		return c, nil
+	}
+	pem, err := ioutil.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read the client CA certificates. %s", err.Error())
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("No certificates found in %s", clientCAFile)
+	}
+	c.ClientCAs, c.ClientAuth = pool, tls.RequireAndVerifyClientCert
+	return c, nil
+}
+
+// APITLSConfig gives the TLS configuration of the API clients served on the given host, from api_tls_cert_file,
+// api_tls_key_file and api_tls_client_ca_file. Clients beyond localhost are served only over TLS, and only if they
+// present a verified certificate.
+func APITLSConfig(host string) (*tls.Config, error) {
+	tlsConfig, err := ServerTLSConfig(config.APITLSCertFile(), config.APITLSKeyFile(), config.APITLSClientCAFile())
+	if err != nil {
+		return nil, err
+	}
+	if !isLoopback(host) && (tlsConfig == nil || tlsConfig.ClientAuth != tls.RequireAndVerifyClientCert) {
+		return nil, fmt.Errorf("Listening on %s needs api_tls_cert_file, api_tls_key_file and api_tls_client_ca_file to be set", host)
+	}
+	return tlsConfig, nil
+}
+
+func isLoopback(host string) bool {
+	ip := net.ParseIP(host)
+	return host == "localhost" || (ip != nil && ip.IsLoopback())
+}
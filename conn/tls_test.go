@@ -0,0 +1,58 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package conn
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestServerTLSConfigIsNilWithoutCertificate(t *testing.T) {
+	tlsConfig, err := ServerTLSConfig("", "", "")
+
+	if err != nil || tlsConfig != nil {
+		t.Errorf("Expected no TLS configuration, got %v, %v", tlsConfig, err)
+	}
+}
+
+func TestServerTLSConfigNeedsCertificateForClientVerification(t *testing.T) {
+	_, err := ServerTLSConfig("", "", "ca.crt")
+
+	if err == nil || err.Error() != "api_tls_client_ca_file needs api_tls_cert_file and api_tls_key_file to be set" {
+		t.Errorf("Expected error for the client CA without a certificate, got %v", err)
+	}
+}
+
+func TestServerTLSConfigWithMissingCertificate(t *testing.T) {
+	_, err := ServerTLSConfig("missing.crt", "missing.key", "")
+
+	if err == nil || !strings.HasPrefix(err.Error(), "Failed to load the TLS certificate of gauge.") {
+		t.Errorf("Expected error for the missing certificate, got %v", err)
+	}
+}
+
+func TestAPITLSConfigAllowsPlainConnectionsOnLocalhost(t *testing.T) {
+	for _, host := range []string{"localhost", "127.0.0.1", "::1"} {
+		if tlsConfig, err := APITLSConfig(host); err != nil || tlsConfig != nil {
+			t.Errorf("Expected plain connections on %s, got %v, %v", host, tlsConfig, err)
+		}
+	}
+	if _, err := APITLSConfig("0.0.0.0"); err == nil {
+		t.Errorf("Expected error for plain connections beyond localhost")
+	}
+}
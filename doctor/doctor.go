@@ -0,0 +1,352 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package doctor diagnoses the environment gauge runs in and suggests fixes for the problems it finds.
+package doctor
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/conn"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/manifest"
+	"github.com/getgauge/gauge/plugin"
+	"github.com/getgauge/gauge/plugin/install"
+	"github.com/getgauge/gauge/plugin/pluginInfo"
+	"github.com/getgauge/gauge/runner"
+	"github.com/getgauge/gauge/util"
+	"github.com/getgauge/gauge/version"
+)
+
+// Status tells how a check went.
+type Status int
+
+const (
+	// OK means no problem was found.
+	OK Status = iota
+	// Warning means gauge works, but something may go wrong.
+	Warning
+	// Failure means gauge will not work until the problem is fixed.
+	Failure
+)
+
+func (s Status) String() string {
+	switch s {
+	case Warning:
+		return "WARN"
+	case Failure:
+		return "FAIL"
+	}
+	return "OK"
+}
+
+// Diagnosis is the outcome of a check, with a fix when there is a problem.
+type Diagnosis struct {
+	Check   string
+	Status  Status
+	Message string
+	Fix     string
+}
+
+const connectivityTimeout = 10 * time.Second
+
+var lookPath = exec.LookPath
+
+// languageToolchains are the executables a language runner needs, any of which is enough.
+var languageToolchains = map[string][]string{
+	"java":   {"java"},
+	"js":     {"node"},
+	"ts":     {"node"},
+	"python": {"python3", "python"},
+	"ruby":   {"ruby"},
+	"csharp": {"dotnet"},
+	"dotnet": {"dotnet"},
+	"go":     {"go"},
+	"golang": {"go"},
+}
+
+// Diagnose runs all the checks. Checks of the project are run only inside a gauge project.
+func Diagnose() []Diagnosis {
+	diagnoses := []Diagnosis{checkGaugeHome(), checkPorts(), checkConnectivity(config.GaugeRepositoryUrl())}
+	diagnoses = append(diagnoses, checkInstalledPlugins()...)
+	m, err := manifest.ProjectManifest()
+	if config.ProjectRoot == "" || err != nil {
+		return append(diagnoses, Diagnosis{Check: "project", Status: Warning, Message: "Not inside a gauge project, so the project is not checked", Fix: "Run gauge doctor from a directory with a manifest.json"})
+	}
+	diagnoses = append(diagnoses, checkRunner(m.Language), checkToolchain(m.Language))
+	diagnoses = append(diagnoses, checkProjectPlugins(m)...)
+	return append(diagnoses, checkEnv(), checkProjectPermissions())
+}
+
+// Print writes the diagnoses, with fixes for the problems. Returns true if any check failed.
+func Print(w io.Writer, diagnoses []Diagnosis) bool {
+	failed := false
+	for _, d := range diagnoses {
+		fmt.Fprintf(w, "[%s]\t%s: %s\n", d.Status, d.Check, d.Message)
+		if d.Status != OK && d.Fix != "" {
+			fmt.Fprintf(w, "\tFix: %s\n", d.Fix)
+		}
+		failed = failed || d.Status == Failure
+	}
+	return failed
+}
+
+func checkGaugeHome() Diagnosis {
+	d := Diagnosis{Check: "gauge home"}
+	home, err := common.GetGaugeHomeDirectory()
+	if err != nil {
+		d.Status, d.Message, d.Fix = Failure, err.Error(), "Set the GAUGE_HOME environment variable to a writable directory"
+		return d
+	}
+	if err := os.MkdirAll(home, common.NewDirectoryPermissions); err != nil {
+		d.Status, d.Message, d.Fix = Failure, err.Error(), fmt.Sprintf("Create %s or set GAUGE_HOME to a writable directory", home)
+		return d
+	}
+	if err := checkWritable(home); err != nil {
+		d.Status, d.Message, d.Fix = Failure, fmt.Sprintf("%s is not writable. %s", home, err.Error()), fmt.Sprintf("Give the current user write permission on %s", home)
+		return d
+	}
+	d.Message = fmt.Sprintf("%s is writable", home)
+	return d
+}
+
+func checkPorts() Diagnosis {
+	d := Diagnosis{Check: "ports"}
+	for _, name := range []string{common.GaugePortEnvName, common.APIPortEnvVariableName} {
+		p := os.Getenv(name)
+		if p == "" {
+			continue
+		}
+		if _, err := strconv.Atoi(p); err != nil {
+			d.Status, d.Message, d.Fix = Failure, fmt.Sprintf("%s=%s is not a port number", name, p), fmt.Sprintf("Set %s to a free port or unset it", name)
+			return d
+		}
+		if err := listen("127.0.0.1:" + p); err != nil {
+			d.Status, d.Message, d.Fix = Failure, fmt.Sprintf("Port %s given by %s is not available. %s", p, name, err.Error()), fmt.Sprintf("Stop the process using port %s or set %s to a free port", p, name)
+			return d
+		}
+	}
+	for _, p := range []struct{ name, ports string }{{"api_ports", config.APIPorts()}, {"runner_ports", config.RunnerPorts()}, {"plugin_ports", config.PluginPorts()}} {
+		if p.ports == "" {
+			continue
+		}
+		if err := listenOnAny(p.ports); err != nil {
+			d.Status, d.Message, d.Fix = Failure, fmt.Sprintf("%s = %s cannot be used. %s", p.name, p.ports, err.Error()), fmt.Sprintf("Free a port in %s or change it with gauge config %s", p.name, p.name)
+			return d
+		}
+	}
+	if err := listen("127.0.0.1:0"); err != nil {
+		d.Status, d.Message, d.Fix = Failure, fmt.Sprintf("Cannot listen on localhost. %s", err.Error()), "Allow gauge to open local ports in the firewall"
+		return d
+	}
+	d.Message = "gauge can listen on localhost"
+	return d
+}
+
+// listenOnAny checks that one of the ports in a list of ports and port ranges is free.
+func listenOnAny(list string) error {
+	ports, err := conn.ParsePorts(list)
+	if err != nil {
+		return err
+	}
+	for _, port := range ports {
+		if err = listen(fmt.Sprintf("127.0.0.1:%d", port)); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("None of the ports is free. %s", err.Error())
+}
+
+func listen(address string) error {
+	l, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	return l.Close()
+}
+
+func checkConnectivity(url string) Diagnosis {
+	d := Diagnosis{Check: "plugin repository"}
+	res, err := (&http.Client{Timeout: connectivityTimeout}).Get(url)
+	if err != nil {
+		d.Status, d.Message, d.Fix = Warning, fmt.Sprintf("%s is unreachable. %s", url, err.Error()), "Configure a proxy with gauge config proxy_url and check it with gauge config proxy-test"
+		return d
+	}
+	res.Body.Close()
+	if res.StatusCode >= http.StatusBadRequest {
+		d.Status, d.Message, d.Fix = Warning, fmt.Sprintf("%s responded with %s", url, res.Status), "Check gauge_repository_url with gauge config gauge_repository_url"
+		return d
+	}
+	d.Message = fmt.Sprintf("%s is reachable", url)
+	return d
+}
+
+func checkInstalledPlugins() []Diagnosis {
+	var diagnoses []Diagnosis
+	plugins, err := pluginInfo.GetAllInstalledPluginsWithVersion()
+	if err != nil {
+		return nil
+	}
+	for _, p := range plugins {
+		support, err := gaugeVersionSupport(p.Name)
+		if err != nil {
+			continue
+		}
+		d := Diagnosis{Check: "plugin " + p.Name, Message: fmt.Sprintf("%s is compatible with gauge %s", p.Version, version.CurrentGaugeVersion)}
+		if err := version.CheckCompatibility(version.CurrentGaugeVersion, support); err != nil {
+			d.Status = Warning
+			d.Message = fmt.Sprintf("%s is not compatible with gauge %s. %s", p.Version, version.CurrentGaugeVersion, err.Error())
+			d.Fix = fmt.Sprintf("Run gauge update %s", p.Name)
+		}
+		diagnoses = append(diagnoses, d)
+	}
+	return diagnoses
+}
+
+func gaugeVersionSupport(pluginName string) (*version.VersionSupport, error) {
+	if plugin.IsLanguagePlugin(pluginName) {
+		r, err := runner.GetRunnerInfo(pluginName)
+		if err != nil {
+			return nil, err
+		}
+		return &r.GaugeVersionSupport, nil
+	}
+	pd, err := plugin.GetPluginDescriptor(pluginName, "")
+	if err != nil {
+		return nil, err
+	}
+	return &pd.GaugeVersionSupport, nil
+}
+
+func checkRunner(language string) Diagnosis {
+	d := Diagnosis{Check: "language runner"}
+	if !install.IsCompatiblePluginInstalled(language, true) {
+		d.Status, d.Message, d.Fix = Failure, fmt.Sprintf("No version of %s compatible with gauge %s is installed", language, version.CurrentGaugeVersion), fmt.Sprintf("Run gauge install %s", language)
+		return d
+	}
+	r, err := runner.GetRunnerInfo(language)
+	if err != nil {
+		d.Status, d.Message, d.Fix = Failure, err.Error(), fmt.Sprintf("Run gauge uninstall %s and gauge install %s", language, language)
+		return d
+	}
+	command := r.Run.Linux
+	switch runtime.GOOS {
+	case "windows":
+		command = r.Run.Windows
+	case "darwin":
+		command = r.Run.Darwin
+	}
+	if len(command) > 0 && !executableExists(language, command[0]) {
+		d.Status, d.Message, d.Fix = Failure, fmt.Sprintf("Command %s of %s %s is not found", command[0], language, r.Version), fmt.Sprintf("Run gauge uninstall %s and gauge install %s", language, language)
+		return d
+	}
+	d.Message = fmt.Sprintf("%s %s is installed", language, r.Version)
+	return d
+}
+
+func executableExists(language, command string) bool {
+	if _, err := lookPath(command); err == nil {
+		return true
+	}
+	dir, err := plugin.GetInstallDir(language, "")
+	if err != nil {
+		return false
+	}
+	return common.FileExists(filepath.Join(dir, command))
+}
+
+func checkToolchain(language string) Diagnosis {
+	d := Diagnosis{Check: "toolchain"}
+	executables, ok := languageToolchains[language]
+	if !ok {
+		d.Message = fmt.Sprintf("No toolchain is known for %s", language)
+		return d
+	}
+	for _, e := range executables {
+		if path, err := lookPath(e); err == nil {
+			d.Message = fmt.Sprintf("%s is available at %s", e, path)
+			return d
+		}
+	}
+	d.Status = Failure
+	d.Message = fmt.Sprintf("%s is not found on PATH", strings.Join(executables, " or "))
+	d.Fix = fmt.Sprintf("Install %s and add it to PATH", executables[0])
+	return d
+}
+
+func checkProjectPlugins(m *manifest.Manifest) []Diagnosis {
+	var diagnoses []Diagnosis
+	for _, p := range m.Plugins {
+		if !install.IsCompatiblePluginInstalled(p, false) {
+			diagnoses = append(diagnoses, Diagnosis{Check: "plugin " + p, Status: Failure, Message: fmt.Sprintf("%s is used by the project, but no compatible version is installed", p), Fix: "Run gauge install"})
+		}
+	}
+	return diagnoses
+}
+
+func checkEnv() Diagnosis {
+	d := Diagnosis{Check: "env"}
+	envDir := filepath.Join(config.ProjectRoot, common.EnvDirectoryName, common.DefaultEnvDir)
+	if !common.DirExists(envDir) {
+		d.Status, d.Message, d.Fix = Warning, fmt.Sprintf("%s does not exist", envDir), fmt.Sprintf("Create %s with a default.properties file", envDir)
+		return d
+	}
+	if err := env.LoadEnv(common.DefaultEnvDir); err != nil {
+		d.Status, d.Message, d.Fix = Failure, err.Error(), "Fix the properties files in the env directory"
+		return d
+	}
+	d.Message = "default env is loaded"
+	return d
+}
+
+func checkProjectPermissions() Diagnosis {
+	d := Diagnosis{Check: "permissions"}
+	if err := checkWritable(config.ProjectRoot); err != nil {
+		d.Status, d.Message, d.Fix = Failure, fmt.Sprintf("%s is not writable. %s", config.ProjectRoot, err.Error()), "Give the current user write permission on the project, which gauge needs for reports and logs"
+		return d
+	}
+	for _, dir := range util.GetSpecDirs() {
+		if !common.DirExists(filepath.Join(config.ProjectRoot, dir)) && !common.DirExists(dir) {
+			d.Status, d.Message, d.Fix = Warning, fmt.Sprintf("Specs directory %s does not exist", dir), "Create it or set gauge_specs_dir in env/default/default.properties"
+			return d
+		}
+	}
+	d.Message = fmt.Sprintf("%s is writable", config.ProjectRoot)
+	return d
+}
+
+func checkWritable(dir string) error {
+	f, err := ioutil.TempFile(dir, ".gauge-doctor")
+	if err != nil {
+		return err
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
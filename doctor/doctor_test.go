@@ -0,0 +1,128 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package doctor
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/getgauge/common"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func stubLookPath(available ...string) func() {
+	old := lookPath
+	lookPath = func(file string) (string, error) {
+		for _, a := range available {
+			if a == file {
+				return "/usr/bin/" + file, nil
+			}
+		}
+		return "", fmt.Errorf("%s not found", file)
+	}
+	return func() { lookPath = old }
+}
+
+func (s *MySuite) TestCheckToolchainFindsAnyOfTheExecutables(c *C) {
+	defer stubLookPath("python")()
+
+	d := checkToolchain("python")
+
+	c.Assert(d.Status, Equals, OK)
+	c.Assert(d.Message, Equals, "python is available at /usr/bin/python")
+}
+
+func (s *MySuite) TestCheckToolchainWhenExecutableIsMissing(c *C) {
+	defer stubLookPath()()
+
+	d := checkToolchain("java")
+
+	c.Assert(d.Status, Equals, Failure)
+	c.Assert(d.Message, Equals, "java is not found on PATH")
+	c.Assert(d.Fix, Equals, "Install java and add it to PATH")
+}
+
+func (s *MySuite) TestCheckToolchainForUnknownLanguage(c *C) {
+	c.Assert(checkToolchain("cobol").Status, Equals, OK)
+}
+
+func (s *MySuite) TestCheckPortsWhenGivenPortIsInUse(c *C) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, IsNil)
+	defer l.Close()
+	_, port, _ := net.SplitHostPort(l.Addr().String())
+	old := os.Getenv(common.GaugePortEnvName)
+	defer os.Setenv(common.GaugePortEnvName, old)
+	os.Setenv(common.GaugePortEnvName, port)
+
+	d := checkPorts()
+
+	c.Assert(d.Status, Equals, Failure)
+	c.Assert(d.Fix, Equals, fmt.Sprintf("Stop the process using port %s or set GAUGE_PORT to a free port", port))
+}
+
+func (s *MySuite) TestCheckPortsWhenConfiguredPortsAreInUse(c *C) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, IsNil)
+	defer l.Close()
+	_, port, _ := net.SplitHostPort(l.Addr().String())
+	defer os.Unsetenv("runner_ports")
+	os.Setenv("runner_ports", port)
+
+	d := checkPorts()
+
+	c.Assert(d.Status, Equals, Failure)
+	c.Assert(d.Fix, Equals, "Free a port in runner_ports or change it with gauge config runner_ports")
+}
+
+func (s *MySuite) TestCheckPortsWithInvalidConfiguredPorts(c *C) {
+	defer os.Unsetenv("plugin_ports")
+	os.Setenv("plugin_ports", "60010-60000")
+
+	d := checkPorts()
+
+	c.Assert(d.Status, Equals, Failure)
+	c.Assert(d.Message, Equals, `plugin_ports = 60010-60000 cannot be used. Invalid port range "60010-60000" in "60010-60000"`)
+}
+
+func (s *MySuite) TestPrintShowsFixesAndReportsFailures(c *C) {
+	var b bytes.Buffer
+
+	failed := Print(&b, []Diagnosis{
+		{Check: "ports", Status: OK, Message: "gauge can listen on localhost", Fix: "ignored"},
+		{Check: "toolchain", Status: Failure, Message: "java is not found on PATH", Fix: "Install java and add it to PATH"},
+	})
+
+	c.Assert(failed, Equals, true)
+	c.Assert(b.String(), Equals, "[OK]\tports: gauge can listen on localhost\n[FAIL]\ttoolchain: java is not found on PATH\n\tFix: Install java and add it to PATH\n")
+}
+
+func (s *MySuite) TestPrintWithOnlyWarnings(c *C) {
+	var b bytes.Buffer
+
+	c.Assert(Print(&b, []Diagnosis{{Check: "env", Status: Warning, Message: "env/default does not exist"}}), Equals, false)
+}
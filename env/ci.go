@@ -0,0 +1,58 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package env
+
+import "os"
+
+// ciVariables maps the metadata keys to the environment variables set by a CI server for the build.
+type ciVariables struct {
+	name     string
+	detect   string
+	build    string
+	commit   string
+	branch   string
+	buildURL string
+}
+
+var ciServers = []ciVariables{
+	{name: "github-actions", detect: "GITHUB_ACTIONS", build: "GITHUB_RUN_ID", commit: "GITHUB_SHA", branch: "GITHUB_REF_NAME"},
+	{name: "gitlab", detect: "GITLAB_CI", build: "CI_PIPELINE_ID", commit: "CI_COMMIT_SHA", branch: "CI_COMMIT_REF_NAME", buildURL: "CI_PIPELINE_URL"},
+	{name: "azure-pipelines", detect: "TF_BUILD", build: "BUILD_BUILDID", commit: "BUILD_SOURCEVERSION", branch: "BUILD_SOURCEBRANCHNAME"},
+	{name: "circleci", detect: "CIRCLECI", build: "CIRCLE_BUILD_NUM", commit: "CIRCLE_SHA1", branch: "CIRCLE_BRANCH", buildURL: "CIRCLE_BUILD_URL"},
+	{name: "travis", detect: "TRAVIS", build: "TRAVIS_BUILD_NUMBER", commit: "TRAVIS_COMMIT", branch: "TRAVIS_BRANCH", buildURL: "TRAVIS_BUILD_WEB_URL"},
+	{name: "teamcity", detect: "TEAMCITY_VERSION", build: "BUILD_NUMBER", commit: "BUILD_VCS_NUMBER"},
+	{name: "jenkins", detect: "JENKINS_URL", build: "BUILD_NUMBER", commit: "GIT_COMMIT", branch: "GIT_BRANCH", buildURL: "BUILD_URL"},
+}
+
+// CIMetadata detects the CI server running the build and returns the details of the build, like the build number,
+// commit and branch. It returns nil if the CI server is not known.
+func CIMetadata() map[string]string {
+	for _, ci := range ciServers {
+		if os.Getenv(ci.detect) == "" {
+			continue
+		}
+		m := map[string]string{"ci": ci.name}
+		for key, variable := range map[string]string{"build": ci.build, "commit": ci.commit, "branch": ci.branch, "buildUrl": ci.buildURL} {
+			if v := os.Getenv(variable); variable != "" && v != "" {
+				m[key] = v
+			}
+		}
+		return m
+	}
+	return nil
+}
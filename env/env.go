@@ -25,6 +25,7 @@ import (
 
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/dmotylev/goproperties"
 	"github.com/getgauge/common"
@@ -43,8 +44,9 @@ const (
 	// for every run.
 	OverwriteReports = "overwrite_reports"
 	// ScreenshotOnFailure indicates if failure should invoke screenshot
-	ScreenshotOnFailure = "screenshot_on_failure"
-	saveExecutionResult = "save_execution_result"
+	ScreenshotOnFailure   = "screenshot_on_failure"
+	saveExecutionResult   = "save_execution_result"
+	captureScenarioOutput = "capture_scenario_output"
 	// CsvDelimiter holds delimiter used to parse csv files
 	CsvDelimiter           = "csv_delimiter"
 	allowMultilineStep     = "allow_multiline_step"
@@ -52,6 +54,115 @@ const (
 	enableMultithreading   = "enable_multithreading"
 	useTestGA              = "use_test_ga"
 	telemetryInterval      = "gauge_telemetry_interval"
+	enableParseCache       = "enable_parse_cache"
+	spillResultsToDisk     = "spill_results_to_disk"
+	// WebhookURLs holds the comma separated URLs to which the suite lifecycle events are posted
+	WebhookURLs = "webhook_urls"
+	// WebhookHeaders holds the comma separated headers, as name:value, sent with every webhook request
+	WebhookHeaders = "webhook_headers"
+	// WebhookEvents holds the comma separated events to be posted to the webhooks. All events are posted if empty.
+	WebhookEvents = "webhook_events"
+	// WebhookOwnerURLs holds the comma separated owner=url pairs. The failures of specs are posted to the webhooks of their owners,
+	// instead of the ones given by webhook_urls.
+	WebhookOwnerURLs = "webhook_owner_urls"
+	// WebhookTimeout holds the timeout in seconds for every webhook request
+	WebhookTimeout = "webhook_timeout"
+	// WebhookRetries holds the number of times a failed webhook request is retried
+	WebhookRetries = "webhook_retries"
+	// WebhookRetryDelay holds the time in milliseconds before the first retry of a failed webhook request, doubled on every retry
+	WebhookRetryDelay = "webhook_retry_delay"
+	// WebhookSecret holds the secret with which the webhook payloads are signed, using HMAC-SHA256. Payloads are not signed if empty.
+	WebhookSecret = "webhook_secret"
+	// WebhookDeadLetterFile holds the file to which the payloads which could not be delivered are appended, logs/webhook-dead-letters.ndjson if empty
+	WebhookDeadLetterFile = "webhook_dead_letter_file"
+	// TestRailURL holds the URL of the TestRail instance to which the results are pushed
+	TestRailURL = "testrail_url"
+	// TestRailUser holds the user used to authenticate with TestRail
+	TestRailUser = "testrail_user"
+	// TestRailAPIKey holds the API key (or password) used to authenticate with TestRail
+	TestRailAPIKey = "testrail_api_key"
+	// TestRailRunID holds the ID of the TestRail run to which the results are added
+	TestRailRunID = "testrail_run_id"
+	// XrayResultsFile holds the path of the Xray import file to be generated for the scenarios tagged with jira keys
+	XrayResultsFile = "xray_results_file"
+	// XrayURL holds the URL of the Jira instance to which the Xray import file is uploaded
+	XrayURL = "xray_url"
+	// XrayToken holds the personal access token used to authenticate with Jira
+	XrayToken = "xray_token"
+	// XrayUser holds the user used to authenticate with Jira, if a token is not given
+	XrayUser = "xray_user"
+	// XrayPassword holds the password used to authenticate with Jira, if a token is not given
+	XrayPassword = "xray_password"
+	// XrayTestExecutionKey holds the key of an existing test execution to be updated, instead of creating a new one
+	XrayTestExecutionKey = "xray_test_execution_key"
+	// ReportPortalEndpoint holds the URL of the ReportPortal instance to which the execution is reported
+	ReportPortalEndpoint = "rp_endpoint"
+	// ReportPortalProject holds the ReportPortal project in which the launches are created
+	ReportPortalProject = "rp_project"
+	// ReportPortalToken holds the API token used to authenticate with ReportPortal
+	ReportPortalToken = "rp_token"
+	// ReportPortalLaunch holds the name of the launch. The name of the project is used if not set.
+	ReportPortalLaunch = "rp_launch"
+	// StreamPins holds the comma separated tag:stream pairs, which pin the specs having the tag to a parallel stream
+	StreamPins = "gauge_stream_pins"
+	// SpecLanguageProperty holds the language in which the keywords of specs and concepts are written, e.g. de, es, fr
+	SpecLanguageProperty = "gauge_spec_language"
+	// TableAlignment holds how the cells of tables are padded, by display width or by rune count
+	TableAlignment = "table_alignment"
+	// maxConceptNestingDepth holds the depth of nested concepts beyond which a warning is shown. Zero disables the warning.
+	maxConceptNestingDepth = "max_concept_nesting_depth"
+	// slowStepThreshold holds the time in milliseconds beyond which a step is reported as slow. Zero disables the reporting.
+	slowStepThreshold = "slow_step_threshold_ms"
+	// maxRunnerRestarts holds the number of times a runner which quits unexpectedly is restarted during an execution. Zero disables the restarts.
+	maxRunnerRestarts = "max_runner_restarts"
+	// parallelProgressInterval holds the interval in seconds at which the scenarios executed by all the streams are summed up on the console in parallel runs. Zero disables the summary.
+	parallelProgressInterval = "parallel_progress_interval"
+	// resultsHistorySize holds the number of runs whose results are kept in .gauge/runs.db for gauge query. Zero disables it.
+	resultsHistorySize = "results_history_size"
+	// lintConceptsDir holds the directory, relative to the project root, under which gauge lint expects the concept files. Empty disables the rule.
+	lintConceptsDir = "lint_concepts_dir"
+	// lintOneConceptPerFile makes gauge lint report the concept files defining more than one concept.
+	lintOneConceptPerFile = "lint_one_concept_per_file"
+	// lintMaxConceptSteps holds the number of steps beyond which gauge lint reports a concept. Zero disables the rule.
+	lintMaxConceptSteps = "lint_max_concept_steps"
+	// HookTags holds the semicolon separated level:tag expression pairs, which restrict the hooks of a level to the specs and scenarios matching the expression
+	HookTags = "gauge_hook_tags"
+	// TagReport holds the comma separated formats, json and csv, in which the results of the scenarios are summed up per tag
+	// in <reports dir>/tags at the end of the execution. No report is written if empty.
+	TagReport = "tag_report"
+	// TraceabilityReport holds the comma separated formats, json, csv and html, in which the scenarios tagged with each requirement
+	// are listed with their results in <reports dir>/traceability at the end of the execution. No report is written if empty.
+	TraceabilityReport = "traceability_report"
+	// RequirementTagPattern holds the regular expression which the tags naming a requirement match as a whole
+	RequirementTagPattern = "requirement_tag_pattern"
+	// OwnersFile holds the path of the file mapping spec paths to the teams owning them, like CODEOWNERS
+	OwnersFile = "owners_file"
+	// EventSink holds the system to which the execution events are published, kafka-rest-proxy or nats. Events are not published if empty.
+	EventSink = "event_sink"
+	// EventSinkURLs holds the comma separated URLs of the Kafka REST proxies, e.g. https://proxy:8082, or of the NATS
+	// servers, e.g. tls://nats:4222, tried in order
+	EventSinkURLs = "event_sink_urls"
+	// EventSinkTopic holds the Kafka topic or NATS subject to which the execution events are published
+	EventSinkTopic = "event_sink_topic"
+	// EventSinkAuth holds the credentials of the event sink, as user:password or as a token
+	EventSinkAuth = "event_sink_auth"
+	// ResultStreamAddress holds the address, e.g. localhost:8050, on which the execution events and the spec results are
+	// streamed over gRPC during the execution. Results are not streamed if empty.
+	ResultStreamAddress = "result_stream_address"
+	// FixturesComposeFile holds the docker-compose file of the services started before the suite and torn down after it
+	FixturesComposeFile = "fixtures_compose_file"
+	// FixturesStartCommand holds the command starting the services before the suite, when they are not given by a compose file
+	FixturesStartCommand = "fixtures_start_command"
+	// FixturesStopCommand holds the command tearing down the services after the suite, when they are not given by a compose file
+	FixturesStopCommand = "fixtures_stop_command"
+	// FixturesHealthCheck holds the command which succeeds once the services are ready
+	FixturesHealthCheck = "fixtures_health_check"
+	// FixturesHealthTimeout holds the time in seconds to wait for the services to be ready
+	FixturesHealthTimeout = "fixtures_health_timeout"
+	// FixturesEnvFile holds the file of name=value pairs, e.g. written by the start command, exported to the runner
+	FixturesEnvFile = "fixtures_env_file"
+	// SpecKeywordsProperty holds the comma separated keyword=translation pairs, which override the translations of the spec language
+	SpecKeywordsProperty = "gauge_spec_keywords"
 )
 
 var envVars map[string]string
@@ -106,6 +217,21 @@ func LoadEnv(envName string) error {
 	return nil
 }
 
+// SetVariables sets the variables passed as key=value in the environment, so that they are available to the runner.
+// They take precedence over the properties in the env files and the variables already set in the shell.
+func SetVariables(vars []string) error {
+	for _, v := range vars {
+		kv := strings.SplitN(v, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return fmt.Errorf("Invalid variable '%s'. Variables should be given as key=value", v)
+		}
+		if err := common.SetEnvVariable(strings.TrimSpace(kv[0]), kv[1]); err != nil {
+			return fmt.Errorf("Failed to set variable %s. %s", kv[0], err.Error())
+		}
+	}
+	return nil
+}
+
 func loadDefaultEnvVars() {
 	addEnvVar(SpecsDir, "specs")
 	addEnvVar(GaugeReportsDir, "reports")
@@ -113,10 +239,29 @@ func loadDefaultEnvVars() {
 	addEnvVar(OverwriteReports, "true")
 	addEnvVar(ScreenshotOnFailure, "true")
 	addEnvVar(saveExecutionResult, "false")
+	addEnvVar(captureScenarioOutput, "false")
 	addEnvVar(CsvDelimiter, ",")
 	addEnvVar(allowMultilineStep, "false")
 	addEnvVar(allowScenarioDatatable, "false")
 	addEnvVar(useTestGA, "false")
+	addEnvVar(enableParseCache, "false")
+	addEnvVar(spillResultsToDisk, "false")
+	addEnvVar(WebhookTimeout, "10")
+	addEnvVar(WebhookRetries, "3")
+	addEnvVar(WebhookRetryDelay, "1000")
+	addEnvVar(SpecLanguageProperty, "en")
+	addEnvVar(TableAlignment, "display_width")
+	addEnvVar(maxConceptNestingDepth, strconv.Itoa(defaultMaxConceptNestingDepth))
+	addEnvVar(slowStepThreshold, "0")
+	addEnvVar(maxRunnerRestarts, strconv.Itoa(defaultMaxRunnerRestarts))
+	addEnvVar(parallelProgressInterval, strconv.Itoa(defaultParallelProgressInterval))
+	addEnvVar(resultsHistorySize, strconv.Itoa(defaultResultsHistorySize))
+	addEnvVar(lintOneConceptPerFile, "false")
+	addEnvVar(lintMaxConceptSteps, strconv.Itoa(defaultLintMaxConceptSteps))
+	addEnvVar(RequirementTagPattern, "REQ-[0-9]+")
+	addEnvVar(OwnersFile, "OWNERS")
+	addEnvVar(FixturesHealthTimeout, "60")
+	addEnvVar(EventSinkTopic, "gauge.events")
 }
 
 func loadEnvDir(envName string) error {
@@ -240,12 +385,23 @@ var SaveExecutionResult = func() bool {
 	return convertToBool(saveExecutionResult, false)
 }
 
+// CaptureScenarioOutput determines if the output of the runner is written to a file for every scenario
+var CaptureScenarioOutput = func() bool {
+	return convertToBool(captureScenarioOutput, false)
+}
+
 // EnableMultiThreadedExecution determines if threads should be used instead of process
 // for each parallel stream
 var EnableMultiThreadedExecution = func() bool {
 	return convertToBool(enableMultithreading, false)
 }
 
+// SpillResultsToDisk determines if the results of completed specs should be written to disk
+// during execution, instead of being held in memory till the end of the suite
+var SpillResultsToDisk = func() bool {
+	return convertToBool(spillResultsToDisk, false)
+}
+
 // UseTestGA checks if test google analytics account needs to be used
 var UseTestGA = func() bool {
 	return strings.ToLower(os.Getenv(useTestGA)) == "true"
@@ -255,3 +411,138 @@ var UseTestGA = func() bool {
 var TelemetryInterval = func() string {
 	return strings.ToLower(os.Getenv(telemetryInterval))
 }
+
+// SpecLanguage gives the language in which the keywords of specs and concepts are written.
+var SpecLanguage = func() string {
+	return strings.ToLower(strings.TrimSpace(os.Getenv(SpecLanguageProperty)))
+}
+
+// SpecKeywords gives the keyword translations configured for the project, which take precedence
+// over the built-in translations of the spec language.
+var SpecKeywords = func() map[string]string {
+	keywords := make(map[string]string)
+	for _, pair := range strings.Split(os.Getenv(SpecKeywordsProperty), ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" || strings.TrimSpace(kv[1]) == "" {
+			continue
+		}
+		keywords[strings.ToLower(strings.TrimSpace(kv[0]))] = strings.TrimSpace(kv[1])
+	}
+	return keywords
+}
+
+const defaultMaxConceptNestingDepth = 10
+
+// MaxConceptNestingDepth gives the depth of nested concepts beyond which a warning is shown. Zero disables the warning.
+var MaxConceptNestingDepth = func() int {
+	v := strings.TrimSpace(os.Getenv(maxConceptNestingDepth))
+	if v == "" {
+		return defaultMaxConceptNestingDepth
+	}
+	depth, err := strconv.Atoi(v)
+	if err != nil || depth < 0 {
+		logger.Warningf(true, "Incorrect value for %s in property file. Cannot convert %s to a non negative number.", maxConceptNestingDepth, v)
+		return defaultMaxConceptNestingDepth
+	}
+	return depth
+}
+
+// EnableParseCache determines if the parsed spec and concept files should be cached in .gauge/cache,
+// so that unchanged files are not parsed again.
+var EnableParseCache = func() bool {
+	return strings.ToLower(os.Getenv(enableParseCache)) == "true"
+}
+
+const defaultMaxRunnerRestarts = 3
+
+// MaxRunnerRestarts gives the number of times a runner which quits unexpectedly is restarted during an execution.
+var MaxRunnerRestarts = func() int {
+	v := strings.TrimSpace(os.Getenv(maxRunnerRestarts))
+	if v == "" {
+		return defaultMaxRunnerRestarts
+	}
+	restarts, err := strconv.Atoi(v)
+	if err != nil || restarts < 0 {
+		logger.Warningf(true, "Incorrect value for %s in property file. Cannot convert %s to a non negative number.", maxRunnerRestarts, v)
+		return defaultMaxRunnerRestarts
+	}
+	return restarts
+}
+
+const defaultParallelProgressInterval = 10
+
+// ParallelProgressInterval gives the interval at which the progress of all the streams is summed up on the console in
+// parallel runs. Zero disables the summary.
+var ParallelProgressInterval = func() time.Duration {
+	v := strings.TrimSpace(os.Getenv(parallelProgressInterval))
+	if v == "" {
+		return defaultParallelProgressInterval * time.Second
+	}
+	seconds, err := strconv.Atoi(v)
+	if err != nil || seconds < 0 {
+		logger.Warningf(true, "Incorrect value for %s in property file. Cannot convert %s to a non negative number.", parallelProgressInterval, v)
+		return defaultParallelProgressInterval * time.Second
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+const defaultResultsHistorySize = 100
+
+// ResultsHistorySize gives the number of runs whose results are kept for querying. Zero disables keeping them.
+var ResultsHistorySize = func() int {
+	v := strings.TrimSpace(os.Getenv(resultsHistorySize))
+	if v == "" {
+		return defaultResultsHistorySize
+	}
+	size, err := strconv.Atoi(v)
+	if err != nil || size < 0 {
+		logger.Warningf(true, "Incorrect value for %s in property file. Cannot convert %s to a non negative number.", resultsHistorySize, v)
+		return defaultResultsHistorySize
+	}
+	return size
+}
+
+// LintConceptsDir gives the directory, relative to the project root, under which the concept files are expected.
+// Empty if the concept files can be anywhere.
+var LintConceptsDir = func() string {
+	dir := strings.TrimSpace(os.Getenv(lintConceptsDir))
+	if dir == "" {
+		return ""
+	}
+	return filepath.Clean(dir)
+}
+
+// LintOneConceptPerFile determines if the concept files defining more than one concept are reported by gauge lint.
+var LintOneConceptPerFile = func() bool {
+	return convertToBool(lintOneConceptPerFile, false)
+}
+
+const defaultLintMaxConceptSteps = 15
+
+// LintMaxConceptSteps gives the number of steps beyond which a concept is reported by gauge lint. Zero disables the rule.
+var LintMaxConceptSteps = func() int {
+	v := strings.TrimSpace(os.Getenv(lintMaxConceptSteps))
+	if v == "" {
+		return defaultLintMaxConceptSteps
+	}
+	steps, err := strconv.Atoi(v)
+	if err != nil || steps < 0 {
+		logger.Warningf(true, "Incorrect value for %s in property file. Cannot convert %s to a non negative number.", lintMaxConceptSteps, v)
+		return defaultLintMaxConceptSteps
+	}
+	return steps
+}
+
+// SlowStepThreshold gives the time in milliseconds beyond which a step is reported as slow. Zero disables the reporting.
+var SlowStepThreshold = func() int64 {
+	v := strings.TrimSpace(os.Getenv(slowStepThreshold))
+	if v == "" {
+		return 0
+	}
+	threshold, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || threshold < 0 {
+		logger.Warningf(true, "Incorrect value for %s in property file. Cannot convert %s to a non negative number.", slowStepThreshold, v)
+		return 0
+	}
+	return threshold
+}
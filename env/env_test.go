@@ -228,3 +228,50 @@ func (s *MySuite) TestCurrentEnvironmentIsPopulated(c *C) {
 	c.Assert(e, Equals, nil)
 	c.Assert(CurrentEnvironments(), Equals, "default,foo")
 }
+
+func (s *MySuite) TestVariablesTakePrecedenceOverEnvFiles(c *C) {
+	os.Clearenv()
+	config.ProjectRoot = "_testdata/proj2"
+
+	e := SetVariables([]string{"gauge_reports_dir=cli_reports", "browser=firefox", "query=a=b"})
+	c.Assert(e, Equals, nil)
+	e = LoadEnv("default")
+
+	c.Assert(e, Equals, nil)
+	c.Assert(os.Getenv("gauge_reports_dir"), Equals, "cli_reports")
+	c.Assert(os.Getenv("browser"), Equals, "firefox")
+	c.Assert(os.Getenv("query"), Equals, "a=b")
+}
+
+func (s *MySuite) TestSetVariablesWithInvalidVariable(c *C) {
+	e := SetVariables([]string{"browser"})
+
+	c.Assert(e, ErrorMatches, "Invalid variable 'browser'.*")
+}
+
+func (s *MySuite) TestCIMetadata(c *C) {
+	os.Clearenv()
+	os.Setenv("GITLAB_CI", "true")
+	os.Setenv("CI_PIPELINE_ID", "42")
+	os.Setenv("CI_COMMIT_SHA", "abc123")
+	os.Setenv("CI_COMMIT_REF_NAME", "main")
+
+	c.Assert(CIMetadata(), DeepEquals, map[string]string{"ci": "gitlab", "build": "42", "commit": "abc123", "branch": "main"})
+}
+
+func (s *MySuite) TestCIMetadataOutsideCI(c *C) {
+	os.Clearenv()
+
+	c.Assert(CIMetadata(), IsNil)
+}
+
+func (s *MySuite) TestSlowStepThreshold(c *C) {
+	defer os.Unsetenv(slowStepThreshold)
+
+	os.Setenv(slowStepThreshold, "1500")
+	c.Assert(SlowStepThreshold(), Equals, int64(1500))
+	os.Setenv(slowStepThreshold, "-1")
+	c.Assert(SlowStepThreshold(), Equals, int64(0))
+	os.Setenv(slowStepThreshold, "")
+	c.Assert(SlowStepThreshold(), Equals, int64(0))
+}
@@ -0,0 +1,303 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package compose executes a composite run, made of multiple sub-runs of gauge, each with its own specs,
+// environment and tags, one after the other or in parallel, and merges their summaries.
+package compose
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution"
+	"github.com/getgauge/gauge/logger"
+)
+
+const composeDir = "compose"
+
+// SubRun is a sub-run of the composite run.
+type SubRun struct {
+	Name  string   `json:"name"`
+	Specs []string `json:"specs"`
+	Env   string   `json:"env"`
+	Tags  string   `json:"tags"`
+	// Flags holds any other flags of gauge run, e.g. ["--parallel", "-n", "4"]
+	Flags []string `json:"flags"`
+}
+
+// Composition describes the sub-runs and whether they are executed in parallel.
+type Composition struct {
+	Parallel bool      `json:"parallel"`
+	Runs     []*SubRun `json:"runs"`
+}
+
+// Status is the summary of a sub-run, as written to the execution status file by gauge run.
+type Status struct {
+	SpecsExecuted int `json:"specsExecuted"`
+	SpecsPassed   int `json:"specsPassed"`
+	SpecsFailed   int `json:"specsFailed"`
+	SpecsSkipped  int `json:"specsSkipped"`
+	SceExecuted   int `json:"sceExecuted"`
+	ScePassed     int `json:"scePassed"`
+	SceFailed     int `json:"sceFailed"`
+	SceSkipped    int `json:"sceSkipped"`
+}
+
+func (s *Status) add(o *Status) {
+	s.SpecsExecuted += o.SpecsExecuted
+	s.SpecsPassed += o.SpecsPassed
+	s.SpecsFailed += o.SpecsFailed
+	s.SpecsSkipped += o.SpecsSkipped
+	s.SceExecuted += o.SceExecuted
+	s.ScePassed += o.ScePassed
+	s.SceFailed += o.SceFailed
+	s.SceSkipped += o.SceSkipped
+}
+
+// Process is a child gauge process, e.g. of a sub-run.
+type Process struct {
+	Name string
+	Dir  string
+	Args []string
+	// Env holds the environment variables set for the process, in addition to the ones of gauge
+	Env []string
+	// StatusFile holds the file to which the process writes its execution status, if it is gauge run
+	StatusFile string
+}
+
+// Outcome is the result of a process.
+type Outcome struct {
+	Name     string
+	ExitCode int
+	Status   *Status
+	Err      error
+}
+
+// Load reads the composition from the given file. The file is read as JSON, which is also valid YAML in flow style.
+func Load(file string) (*Composition, error) {
+	contents, err := common.ReadFileContents(file)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read %s. %s", file, err.Error())
+	}
+	c := &Composition{}
+	if err := json.Unmarshal([]byte(contents), c); err != nil {
+		return nil, fmt.Errorf("Failed to parse %s. %s", file, err.Error())
+	}
+	return c, c.validate()
+}
+
+func (c *Composition) validate() error {
+	if len(c.Runs) == 0 {
+		return fmt.Errorf("No runs found in the composition")
+	}
+	names := make(map[string]bool)
+	for i, r := range c.Runs {
+		if strings.TrimSpace(r.Name) == "" {
+			return fmt.Errorf("Run %d in the composition does not have a name", i+1)
+		}
+		if names[r.Name] {
+			return fmt.Errorf("Duplicate run %s in the composition", r.Name)
+		}
+		names[r.Name] = true
+	}
+	return nil
+}
+
+// process gives the child gauge process of the sub-run, which writes its reports to a directory
+// named after the sub-run in the reports directory.
+func (r *SubRun) process() *Process {
+	args := []string{"run", "--skip-save"}
+	if r.Env != "" {
+		args = append(args, "--env", r.Env)
+	}
+	if r.Tags != "" {
+		args = append(args, "--tags", r.Tags)
+	}
+	args = append(append(args, r.Flags...), r.Specs...)
+	reportsDir := os.Getenv(env.GaugeReportsDir)
+	if reportsDir == "" {
+		reportsDir = "reports"
+	}
+	return &Process{
+		Name:       r.Name,
+		Dir:        config.ProjectRoot,
+		Args:       args,
+		Env:        []string{fmt.Sprintf("%s=%s", env.GaugeReportsDir, filepath.Join(reportsDir, r.Name))},
+		StatusFile: filepath.Join(config.ProjectRoot, common.DotGauge, composeDir, r.Name+".json"),
+	}
+}
+
+// Execute runs the sub-runs using the given gauge executable, in parallel or one after the other.
+func (c *Composition) Execute(gauge string) []*Outcome {
+	var processes []*Process
+	for _, r := range c.Runs {
+		processes = append(processes, r.process())
+	}
+	return ExecuteAll(gauge, processes, c.Parallel)
+}
+
+// ExecuteAll runs the processes using the given gauge executable, in parallel or one after the other.
+// The output of the processes running in parallel is prefixed with their names.
+func ExecuteAll(gauge string, processes []*Process, parallel bool) []*Outcome {
+	outcomes := make([]*Outcome, len(processes))
+	if !parallel {
+		for i, p := range processes {
+			logger.Infof(true, "Executing %s", p.Name)
+			outcomes[i] = execute(gauge, p, os.Stdout)
+		}
+		return outcomes
+	}
+	wg := &sync.WaitGroup{}
+	for i, p := range processes {
+		wg.Add(1)
+		go func(i int, p *Process) {
+			defer wg.Done()
+			out := newPrefixWriter(os.Stdout, fmt.Sprintf("[%s] ", p.Name))
+			outcomes[i] = execute(gauge, p, out)
+			out.flush()
+		}(i, p)
+	}
+	wg.Wait()
+	return outcomes
+}
+
+var execute = func(gauge string, p *Process, out io.Writer) *Outcome {
+	cmd := exec.Command(gauge, p.Args...)
+	cmd.Dir = p.Dir
+	cmd.Stdout = out
+	cmd.Stderr = out
+	cmd.Env = append(os.Environ(), p.Env...)
+	if p.StatusFile != "" {
+		os.Remove(p.StatusFile)
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", execution.ExecutionStatusFileEnv, p.StatusFile))
+	}
+	o := &Outcome{Name: p.Name}
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			o.ExitCode = exitCode(exitErr)
+		} else {
+			o.ExitCode, o.Err = 1, fmt.Errorf("Failed to execute %s. %s", p.Name, err.Error())
+			return o
+		}
+	}
+	if p.StatusFile != "" {
+		o.Status, o.Err = readStatus(p.StatusFile)
+	}
+	return o
+}
+
+func exitCode(err *exec.ExitError) int {
+	if s, ok := err.Sys().(interface{ ExitStatus() int }); ok {
+		return s.ExitStatus()
+	}
+	return 1
+}
+
+func readStatus(file string) (*Status, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read the execution status. %s", err.Error())
+	}
+	s := &Status{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("Invalid execution status. %s", err.Error())
+	}
+	return s, nil
+}
+
+// Summarize logs the summary of every process and the total, and gives the exit code of the composite run,
+// which is the exit code of the first process that failed. Only the exit code is logged for the processes
+// which do not write their execution status.
+func Summarize(outcomes []*Outcome) int {
+	total := &Status{}
+	exit := 0
+	for _, o := range outcomes {
+		if exit == 0 && o.ExitCode != 0 {
+			exit = o.ExitCode
+		}
+		if o.Err != nil {
+			logger.Errorf(true, "%s:\t%s", o.Name, o.Err.Error())
+			if exit == 0 {
+				exit = 1
+			}
+			continue
+		}
+		if o.Status == nil {
+			logger.Infof(true, "%s:\texited with %d", o.Name, o.ExitCode)
+			continue
+		}
+		total.add(o.Status)
+		logStatus(o.Name, o.Status)
+	}
+	logStatus("Total", total)
+	return exit
+}
+
+func logStatus(name string, s *Status) {
+	logger.Infof(true, "%s:\tSpecifications: %d executed, %d passed, %d failed, %d skipped\tScenarios: %d executed, %d passed, %d failed, %d skipped",
+		name, s.SpecsExecuted, s.SpecsPassed, s.SpecsFailed, s.SpecsSkipped, s.SceExecuted, s.ScePassed, s.SceFailed, s.SceSkipped)
+}
+
+// prefixWriter prefixes every line written to it, so that the output of the parallel sub-runs can be told apart.
+type prefixWriter struct {
+	out    io.Writer
+	prefix string
+	line   []byte
+}
+
+var outMutex = &sync.Mutex{}
+
+func newPrefixWriter(out io.Writer, prefix string) *prefixWriter {
+	return &prefixWriter{out: out, prefix: prefix}
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.line = append(p.line, b...)
+	for {
+		i := bytes.IndexByte(p.line, '\n')
+		if i == -1 {
+			return len(b), nil
+		}
+		p.writeLine(p.line[:i+1])
+		p.line = p.line[i+1:]
+	}
+}
+
+// flush writes the last line, if it does not end with a new line.
+func (p *prefixWriter) flush() {
+	if len(p.line) > 0 {
+		p.writeLine(append(p.line, '\n'))
+		p.line = nil
+	}
+}
+
+func (p *prefixWriter) writeLine(line []byte) {
+	outMutex.Lock()
+	defer outMutex.Unlock()
+	p.out.Write(append([]byte(p.prefix), line...))
+}
@@ -0,0 +1,101 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package compose
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func writeComposition(c *C, contents string) string {
+	f := filepath.Join(c.MkDir(), "runs.json")
+	c.Assert(ioutil.WriteFile(f, []byte(contents), 0644), IsNil)
+	return f
+}
+
+func (s *MySuite) TestLoad(c *C) {
+	f := writeComposition(c, `{"parallel": true, "runs": [{"name": "api", "specs": ["specs/api"], "env": "api", "tags": "smoke", "flags": ["-n", "2"]}]}`)
+
+	comp, err := Load(f)
+
+	c.Assert(err, IsNil)
+	c.Assert(comp.Parallel, Equals, true)
+	p := comp.Runs[0].process()
+	c.Assert(p.Args, DeepEquals, []string{"run", "--skip-save", "--env", "api", "--tags", "smoke", "-n", "2", "specs/api"})
+	c.Assert(p.Env, DeepEquals, []string{"gauge_reports_dir=" + filepath.Join("reports", "api")})
+}
+
+func (s *MySuite) TestLoadValidatesRuns(c *C) {
+	_, err := Load(writeComposition(c, `{"runs": []}`))
+	c.Assert(err, ErrorMatches, "No runs found in the composition")
+
+	_, err = Load(writeComposition(c, `{"runs": [{"specs": ["specs"]}]}`))
+	c.Assert(err, ErrorMatches, "Run 1 in the composition does not have a name")
+
+	_, err = Load(writeComposition(c, `{"runs": [{"name": "api"}, {"name": "api"}]}`))
+	c.Assert(err, ErrorMatches, "Duplicate run api in the composition")
+}
+
+func (s *MySuite) TestExecuteInParallelRetainsOrderOfRuns(c *C) {
+	old := execute
+	defer func() { execute = old }()
+	execute = func(gauge string, p *Process, out io.Writer) *Outcome {
+		return &Outcome{Name: p.Name, Status: &Status{}}
+	}
+	comp := &Composition{Parallel: true, Runs: []*SubRun{{Name: "api"}, {Name: "ui"}}}
+
+	outcomes := comp.Execute("gauge")
+
+	c.Assert(outcomes[0].Name, Equals, "api")
+	c.Assert(outcomes[1].Name, Equals, "ui")
+}
+
+func (s *MySuite) TestSummarizeGivesExitCodeOfFirstFailedRun(c *C) {
+	outcomes := []*Outcome{
+		{Name: "api", Status: &Status{}},
+		{Name: "ui", ExitCode: 1, Status: &Status{SceFailed: 1}},
+		{Name: "db", ExitCode: 2, Err: os.ErrNotExist},
+		{Name: "validate", ExitCode: 0},
+	}
+
+	c.Assert(Summarize(outcomes), Equals, 1)
+	c.Assert(Summarize(outcomes[:1]), Equals, 0)
+}
+
+func (s *MySuite) TestPrefixWriter(c *C) {
+	out := &bytes.Buffer{}
+	w := newPrefixWriter(out, "[api] ")
+
+	w.Write([]byte("first\nsec"))
+	w.Write([]byte("ond\nlast"))
+	w.flush()
+
+	c.Assert(out.String(), Equals, "[api] first\n[api] second\n[api] last\n")
+}
@@ -0,0 +1,222 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package diff compares the results of two runs, to tell which scenarios started failing, passing or being skipped,
+// and which got significantly slower. The results are read from the event log of a run, logs/events.ndjson, or from
+// the result saved with save_execution_result, .gauge/last_run_result.
+package diff
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/getgauge/gauge/execution/eventlog"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/util"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	passed  = "passed"
+	failed  = "failed"
+	skipped = "skipped"
+)
+
+// Scenario is the result of a scenario in a run.
+type Scenario struct {
+	Spec     string `json:"spec"`
+	Name     string `json:"scenario"`
+	Outcome  string `json:"outcome"`
+	Duration int64  `json:"durationMs"`
+}
+
+// Run holds the results of the scenarios of a run. The rows of table driven scenarios are told apart by the order in
+// which they ran.
+type Run struct {
+	scenarios map[string]*Scenario
+	keys      []string
+}
+
+func newRun() *Run {
+	return &Run{scenarios: make(map[string]*Scenario)}
+}
+
+func (r *Run) add(s *Scenario) {
+	k := s.Spec + "\x00" + s.Name
+	for i := 2; r.scenarios[k] != nil; i++ {
+		k = fmt.Sprintf("%s\x00%s\x00%d", s.Spec, s.Name, i)
+	}
+	r.scenarios[k] = s
+	r.keys = append(r.keys, k)
+}
+
+// Len gives the number of scenarios in the run.
+func (r *Run) Len() int {
+	return len(r.keys)
+}
+
+// Load reads the results of a run from an event log or a saved execution result.
+func Load(file string) (*Run, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		r, err := loadEventLog(data)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read the event log %s. %s", file, err.Error())
+		}
+		return r, nil
+	}
+	r, err := loadSuiteResult(data)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read %s. It is neither an event log nor a saved execution result. %s", file, err.Error())
+	}
+	return r, nil
+}
+
+func loadEventLog(data []byte) (*Run, error) {
+	r := newRun()
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var record eventlog.Record
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, err
+		}
+		if record.Event == "scenarioEnd" {
+			r.add(&Scenario{Spec: record.File, Name: record.Name, Outcome: record.Outcome, Duration: record.Duration})
+		}
+	}
+	return r, scanner.Err()
+}
+
+func loadSuiteResult(data []byte) (*Run, error) {
+	res := &gauge_messages.ProtoSuiteResult{}
+	if err := proto.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	r := newRun()
+	for _, specRes := range res.GetSpecResults() {
+		spec := filepath.ToSlash(util.RelPathToProjectRoot(specRes.GetProtoSpec().GetFileName()))
+		for _, item := range specRes.GetProtoSpec().GetItems() {
+			sce := item.GetScenario()
+			if item.GetItemType() == gauge_messages.ProtoItem_TableDrivenScenario {
+				sce = item.GetTableDrivenScenario().GetScenario()
+			}
+			if sce == nil {
+				continue
+			}
+			r.add(&Scenario{Spec: spec, Name: sce.GetScenarioHeading(), Outcome: outcome(sce.GetExecutionStatus()), Duration: sce.GetExecutionTime()})
+		}
+	}
+	return r, nil
+}
+
+func outcome(status gauge_messages.ExecutionStatus) string {
+	switch status {
+	case gauge_messages.ExecutionStatus_FAILED:
+		return failed
+	case gauge_messages.ExecutionStatus_PASSED:
+		return passed
+	}
+	return skipped
+}
+
+// Change is a scenario whose result differs between the runs.
+type Change struct {
+	Spec           string `json:"spec"`
+	Name           string `json:"scenario"`
+	Before         string `json:"before,omitempty"`
+	After          string `json:"after"`
+	BeforeDuration int64  `json:"beforeDurationMs,omitempty"`
+	AfterDuration  int64  `json:"afterDurationMs"`
+}
+
+// Diff holds the scenarios whose results changed between two runs. Scenarios which are new in the later run are
+// included among the failing and skipped ones.
+type Diff struct {
+	NewlyFailing []Change `json:"newlyFailing"`
+	NewlyPassing []Change `json:"newlyPassing"`
+	NewlySkipped []Change `json:"newlySkipped"`
+	Slower       []Change `json:"slower"`
+}
+
+// Empty tells if there are no changes.
+func (d *Diff) Empty() bool {
+	return len(d.NewlyFailing)+len(d.NewlyPassing)+len(d.NewlySkipped)+len(d.Slower) == 0
+}
+
+// Slowdown tells how much slower a scenario has to get to be reported, by both a percentage and a number of milliseconds.
+type Slowdown struct {
+	Percent int
+	Min     int64
+}
+
+func (s Slowdown) significant(before, after int64) bool {
+	return after-before >= s.Min && after*100 >= before*int64(100+s.Percent) && after > before
+}
+
+// Compare gives the changes in the results of the scenarios from one run to the other.
+func Compare(before, after *Run, slowdown Slowdown) *Diff {
+	d := &Diff{}
+	for _, k := range after.keys {
+		a := after.scenarios[k]
+		c := Change{Spec: a.Spec, Name: a.Name, After: a.Outcome, AfterDuration: a.Duration}
+		b, existed := before.scenarios[k]
+		if existed {
+			c.Before, c.BeforeDuration = b.Outcome, b.Duration
+		}
+		switch {
+		case a.Outcome == failed && c.Before != failed:
+			d.NewlyFailing = append(d.NewlyFailing, c)
+		case a.Outcome == passed && existed && c.Before != passed:
+			d.NewlyPassing = append(d.NewlyPassing, c)
+		case a.Outcome == skipped && c.Before != skipped:
+			d.NewlySkipped = append(d.NewlySkipped, c)
+		case existed && a.Outcome == passed && slowdown.significant(b.Duration, a.Duration):
+			d.Slower = append(d.Slower, c)
+		}
+	}
+	for _, changes := range [][]Change{d.NewlyFailing, d.NewlyPassing, d.NewlySkipped} {
+		sortChanges(changes)
+	}
+	// the scenarios which slowed down the most come first
+	sort.SliceStable(d.Slower, func(i, j int) bool {
+		return d.Slower[i].AfterDuration-d.Slower[i].BeforeDuration > d.Slower[j].AfterDuration-d.Slower[j].BeforeDuration
+	})
+	return d
+}
+
+func sortChanges(changes []Change) {
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Spec != changes[j].Spec {
+			return changes[i].Spec < changes[j].Spec
+		}
+		return strings.ToLower(changes[i].Name) < strings.ToLower(changes[j].Name)
+	})
+}
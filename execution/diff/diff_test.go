@@ -0,0 +1,173 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package diff
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/golang/protobuf/proto"
+)
+
+func writeFile(t *testing.T, dir, name string, data []byte) string {
+	f := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(f, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+const eventLog = `{"time":"t","event":"suiteStart","id":"suite","stream":0}
+{"time":"t","event":"specStart","id":"1","parentId":"suite","stream":0,"name":"Login","file":"specs/login.spec","line":1}
+{"time":"t","event":"scenarioStart","id":"2","parentId":"1","stream":0,"name":"Login works","file":"specs/login.spec","line":3}
+{"time":"t","event":"scenarioEnd","id":"2","parentId":"1","stream":0,"name":"Login works","file":"specs/login.spec","line":3,"outcome":"failed","durationMs":1200}
+{"time":"t","event":"scenarioEnd","id":"3","parentId":"1","stream":0,"name":"Row","file":"specs/login.spec","line":9,"outcome":"passed","durationMs":10}
+{"time":"t","event":"scenarioEnd","id":"4","parentId":"1","stream":0,"name":"Row","file":"specs/login.spec","line":9,"outcome":"skipped"}
+{"time":"t","event":"suiteEnd","id":"suite","stream":0,"outcome":"failed","durationMs":1300}
+`
+
+func TestLoadEventLog(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "diff")
+	defer os.RemoveAll(dir)
+
+	r, err := Load(writeFile(t, dir, "events.ndjson", []byte(eventLog)))
+
+	if err != nil {
+		t.Fatalf("Expected no error. Got %s", err.Error())
+	}
+	want := []*Scenario{
+		{Spec: "specs/login.spec", Name: "Login works", Outcome: failed, Duration: 1200},
+		{Spec: "specs/login.spec", Name: "Row", Outcome: passed, Duration: 10},
+		{Spec: "specs/login.spec", Name: "Row", Outcome: skipped},
+	}
+	var got []*Scenario
+	for _, k := range r.keys {
+		got = append(got, r.scenarios[k])
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v. Got %v", want, got)
+	}
+}
+
+func TestLoadSavedExecutionResult(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "diff")
+	defer os.RemoveAll(dir)
+	res := &gauge_messages.ProtoSuiteResult{SpecResults: []*gauge_messages.ProtoSpecResult{{
+		ProtoSpec: &gauge_messages.ProtoSpec{FileName: "specs/cart.spec", Items: []*gauge_messages.ProtoItem{
+			{ItemType: gauge_messages.ProtoItem_Comment},
+			{ItemType: gauge_messages.ProtoItem_Scenario, Scenario: &gauge_messages.ProtoScenario{ScenarioHeading: "Add", ExecutionStatus: gauge_messages.ExecutionStatus_PASSED, ExecutionTime: 30}},
+			{ItemType: gauge_messages.ProtoItem_TableDrivenScenario, TableDrivenScenario: &gauge_messages.ProtoTableDrivenScenario{
+				Scenario: &gauge_messages.ProtoScenario{ScenarioHeading: "Remove", ExecutionStatus: gauge_messages.ExecutionStatus_NOTEXECUTED}}},
+		}},
+	}}}
+	data, _ := proto.Marshal(res)
+
+	r, err := Load(writeFile(t, dir, "last_run_result", data))
+
+	if err != nil {
+		t.Fatalf("Expected no error. Got %s", err.Error())
+	}
+	if r.Len() != 2 || r.scenarios[r.keys[0]].Outcome != passed || r.scenarios[r.keys[1]].Outcome != skipped {
+		t.Errorf("Unexpected scenarios %v", r.scenarios)
+	}
+}
+
+func run(scenarios ...*Scenario) *Run {
+	r := newRun()
+	for _, s := range scenarios {
+		r.add(s)
+	}
+	return r
+}
+
+func TestCompare(t *testing.T) {
+	before := run(
+		&Scenario{Spec: "a.spec", Name: "Breaks", Outcome: passed, Duration: 100},
+		&Scenario{Spec: "a.spec", Name: "Fixed", Outcome: failed, Duration: 100},
+		&Scenario{Spec: "a.spec", Name: "Slow", Outcome: passed, Duration: 1000},
+		&Scenario{Spec: "a.spec", Name: "Bit slower", Outcome: passed, Duration: 1000},
+		&Scenario{Spec: "b.spec", Name: "Ignored", Outcome: passed, Duration: 100},
+		&Scenario{Spec: "b.spec", Name: "Still failing", Outcome: failed, Duration: 100},
+	)
+	after := run(
+		&Scenario{Spec: "a.spec", Name: "Breaks", Outcome: failed, Duration: 100},
+		&Scenario{Spec: "a.spec", Name: "Fixed", Outcome: passed, Duration: 100},
+		&Scenario{Spec: "a.spec", Name: "Slow", Outcome: passed, Duration: 3000},
+		&Scenario{Spec: "a.spec", Name: "Bit slower", Outcome: passed, Duration: 1400},
+		&Scenario{Spec: "b.spec", Name: "Ignored", Outcome: skipped},
+		&Scenario{Spec: "b.spec", Name: "Still failing", Outcome: failed, Duration: 100},
+		&Scenario{Spec: "b.spec", Name: "New", Outcome: failed, Duration: 100},
+	)
+
+	d := Compare(before, after, Slowdown{Percent: 50, Min: 1000})
+
+	want := &Diff{
+		NewlyFailing: []Change{
+			{Spec: "a.spec", Name: "Breaks", Before: passed, After: failed, BeforeDuration: 100, AfterDuration: 100},
+			{Spec: "b.spec", Name: "New", After: failed, AfterDuration: 100},
+		},
+		NewlyPassing: []Change{{Spec: "a.spec", Name: "Fixed", Before: failed, After: passed, BeforeDuration: 100, AfterDuration: 100}},
+		NewlySkipped: []Change{{Spec: "b.spec", Name: "Ignored", Before: passed, After: skipped, BeforeDuration: 100}},
+		Slower:       []Change{{Spec: "a.spec", Name: "Slow", Before: passed, After: passed, BeforeDuration: 1000, AfterDuration: 3000}},
+	}
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf("Expected %+v.\nGot %+v", want, d)
+	}
+}
+
+func TestFormatText(t *testing.T) {
+	d := &Diff{
+		NewlyFailing: []Change{{Spec: "a.spec", Name: "Breaks", Before: passed, After: failed}, {Spec: "b.spec", Name: "New", After: failed}},
+		Slower:       []Change{{Spec: "a.spec", Name: "Slow", Before: passed, After: passed, BeforeDuration: 1000, AfterDuration: 3000}},
+	}
+
+	got, _ := d.Format(Text)
+
+	want := `Newly failing scenarios (2):
+  a.spec: Breaks (failed, was passed)
+  b.spec: New (failed, new)
+Slower scenarios (1):
+  a.spec: Slow (3s, was 1s)`
+	if got != want {
+		t.Errorf("Expected\n%s\nGot\n%s", want, got)
+	}
+}
+
+func TestFormatMarkdown(t *testing.T) {
+	d := &Diff{NewlyPassing: []Change{{Spec: "a.spec", Name: "Pay | refund", Before: failed, After: passed}}}
+
+	got, _ := d.Format(Markdown)
+
+	want := "### Newly passing scenarios (1)\n\n| Spec | Scenario | Change |\n| --- | --- | --- |\n| a.spec | Pay \\| refund | passed, was failed |"
+	if got != want {
+		t.Errorf("Expected\n%s\nGot\n%s", want, got)
+	}
+}
+
+func TestFormatWithoutChanges(t *testing.T) {
+	if got, _ := (&Diff{}).Format(Text); got != "No changes in the results of the scenarios." {
+		t.Errorf("Unexpected output %s", got)
+	}
+	if _, err := (&Diff{}).Format("html"); err == nil {
+		t.Errorf("Expected an error for an unknown format")
+	}
+}
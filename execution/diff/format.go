@@ -0,0 +1,114 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Formats in which a diff can be written.
+const (
+	Text     = "text"
+	Markdown = "markdown"
+	JSON     = "json"
+)
+
+type section struct {
+	title   string
+	changes []Change
+}
+
+func (d *Diff) sections() []section {
+	return []section{
+		{"Newly failing", d.NewlyFailing},
+		{"Newly passing", d.NewlyPassing},
+		{"Newly skipped", d.NewlySkipped},
+		{"Slower", d.Slower},
+	}
+}
+
+// Format writes the diff in the given format, one of Text, Markdown and JSON.
+func (d *Diff) Format(format string) (string, error) {
+	switch format {
+	case Text:
+		return d.text(), nil
+	case Markdown:
+		return d.markdown(), nil
+	case JSON:
+		b, err := json.MarshalIndent(d, "", "\t")
+		return string(b), err
+	}
+	return "", fmt.Errorf("Unknown format %s. The format can be %s, %s or %s.", format, Text, Markdown, JSON)
+}
+
+func (d *Diff) text() string {
+	if d.Empty() {
+		return "No changes in the results of the scenarios."
+	}
+	var b strings.Builder
+	for _, s := range d.sections() {
+		if len(s.changes) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "%s scenarios (%d):\n", s.title, len(s.changes))
+		for _, c := range s.changes {
+			fmt.Fprintf(&b, "  %s: %s %s\n", c.Spec, c.Name, c.detail())
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (d *Diff) markdown() string {
+	if d.Empty() {
+		return "No changes in the results of the scenarios."
+	}
+	var b strings.Builder
+	for _, s := range d.sections() {
+		if len(s.changes) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "### %s scenarios (%d)\n\n| Spec | Scenario | Change |\n| --- | --- | --- |\n", s.title, len(s.changes))
+		for _, c := range s.changes {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", escape(c.Spec), escape(c.Name), strings.Trim(c.detail(), "()"))
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n\n")
+}
+
+// detail tells how the result of the scenario changed, like (failed, was passed) or (4s, was 1s).
+func (c Change) detail() string {
+	if c.Before == c.After {
+		return fmt.Sprintf("(%s, was %s)", duration(c.AfterDuration), duration(c.BeforeDuration))
+	}
+	if c.Before == "" {
+		return fmt.Sprintf("(%s, new)", c.After)
+	}
+	return fmt.Sprintf("(%s, was %s)", c.After, c.Before)
+}
+
+func duration(ms int64) time.Duration {
+	return (time.Duration(ms) * time.Millisecond).Round(10 * time.Millisecond)
+}
+
+func escape(s string) string {
+	return strings.Replace(s, "|", `\|`, -1)
+}
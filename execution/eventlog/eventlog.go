@@ -0,0 +1,248 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package eventlog writes every execution event as a line of JSON to logs/events.ndjson, so that the execution
+// can be replayed by external tools without a live connection to gauge.
+package eventlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/util"
+)
+
+// Streams is the number of parallel execution streams, zero for a serial execution.
+var Streams int
+
+const (
+	eventLogFile = "events.ndjson"
+	suiteID      = "suite"
+)
+
+var topics = map[event.Topic]string{
+	event.SuiteStart:    "suiteStart",
+	event.SpecStart:     "specStart",
+	event.ScenarioStart: "scenarioStart",
+	event.ConceptStart:  "conceptStart",
+	event.StepStart:     "stepStart",
+	event.StepEnd:       "stepEnd",
+	event.ConceptEnd:    "conceptEnd",
+	event.ScenarioEnd:   "scenarioEnd",
+	event.SpecEnd:       "specEnd",
+	event.SuiteEnd:      "suiteEnd",
+}
+
+// Record is a line in the event log. The start and end events of an item share the same id, and
+// parentId is the id of the item within which the event occurred.
+type Record struct {
+	Time     string `json:"time"`
+	Event    string `json:"event"`
+	ID       string `json:"id"`
+	ParentID string `json:"parentId,omitempty"`
+	Stream   int    `json:"stream"`
+	Name     string `json:"name,omitempty"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Outcome  string `json:"outcome,omitempty"`
+	Duration int64  `json:"durationMs,omitempty"`
+	Error    string `json:"error,omitempty"`
+	// Streams is the number of parallel execution streams, given at the start of the suite. It is zero for a serial execution.
+	Streams int `json:"streams,omitempty"`
+}
+
+type eventLog struct {
+	writer io.Writer
+	next   int
+	// parents holds the ids of the items currently executing in each stream, innermost last
+	parents map[int][]string
+}
+
+func newEventLog(w io.Writer) *eventLog {
+	return &eventLog{writer: w, parents: make(map[int][]string)}
+}
+
+// Recorder gives the records of the execution events, as written to the event log, for other sinks of the events.
+// The events have to be recorded in the order they occur.
+type Recorder struct {
+	l *eventLog
+}
+
+// NewRecorder creates a recorder for the events of an execution.
+func NewRecorder() *Recorder {
+	return &Recorder{l: newEventLog(nil)}
+}
+
+// Record gives the record of the event.
+func (r *Recorder) Record(e event.ExecutionEvent) *Record {
+	return r.l.record(e)
+}
+
+// ListenExecutionEvents writes every execution event to the event log, replacing the log of the previous run.
+func ListenExecutionEvents(wg *sync.WaitGroup) {
+	path := logger.LogFilePath(eventLogFile)
+	if err := os.MkdirAll(filepath.Dir(path), common.NewDirectoryPermissions); err != nil {
+		logger.Errorf(false, "Failed to create directory %s. %s", filepath.Dir(path), err.Error())
+		return
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, common.NewFilePermissions)
+	if err != nil {
+		logger.Errorf(false, "Failed to create event log %s. %s", path, err.Error())
+		return
+	}
+	ch := make(chan event.ExecutionEvent, 0)
+	event.Register(ch, event.SuiteStart, event.SpecStart, event.ScenarioStart, event.ConceptStart, event.StepStart, event.StepEnd, event.ConceptEnd, event.ScenarioEnd, event.SpecEnd, event.SuiteEnd)
+	wg.Add(1)
+
+	go func() {
+		l := newEventLog(f)
+		for {
+			e := <-ch
+			l.write(l.record(e))
+			if e.Topic == event.SuiteEnd {
+				f.Close()
+				wg.Done()
+			}
+		}
+	}()
+}
+
+func (l *eventLog) write(r *Record) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		logger.Errorf(false, "Failed to write %s event to event log. %s", r.Event, err.Error())
+		return
+	}
+	l.writer.Write(append(b, '\n'))
+}
+
+func (l *eventLog) start(stream int) (string, string) {
+	l.next++
+	id := fmt.Sprintf("%d", l.next)
+	parent := l.parent(stream)
+	l.parents[stream] = append(l.parents[stream], id)
+	return id, parent
+}
+
+func (l *eventLog) end(stream int) (string, string) {
+	p := l.parents[stream]
+	if len(p) == 0 {
+		return "", l.parent(stream)
+	}
+	l.parents[stream] = p[:len(p)-1]
+	return p[len(p)-1], l.parent(stream)
+}
+
+func (l *eventLog) parent(stream int) string {
+	if p := l.parents[stream]; len(p) > 0 {
+		return p[len(p)-1]
+	}
+	return suiteID
+}
+
+func (l *eventLog) record(e event.ExecutionEvent) *Record {
+	r := &Record{Time: time.Now().Format(time.RFC3339Nano), Event: topics[e.Topic], Stream: e.Stream}
+	switch e.Topic {
+	case event.SuiteStart:
+		r.ID, r.Streams = suiteID, Streams
+	case event.SuiteEnd:
+		r.ID = suiteID
+		res := e.Result.(*result.SuiteResult)
+		r.Outcome, r.Duration = outcome(res.IsFailed, false), res.ExecutionTime
+	case event.SpecStart, event.ScenarioStart, event.ConceptStart, event.StepStart:
+		r.ID, r.ParentID = l.start(e.Stream)
+		r.Name, r.File, r.Line = describe(e.Item)
+		if e.Topic == event.ScenarioStart && e.Result != nil && skipped(e.Result) {
+			r.Outcome = outcome(false, true)
+		}
+	default:
+		r.ID, r.ParentID = l.end(e.Stream)
+		if e.Item != nil {
+			r.Name, r.File, r.Line = describe(e.Item)
+		}
+		if e.Result != nil {
+			r.Outcome, r.Duration = outcome(e.Result.GetFailed(), skipped(e.Result)), e.Result.ExecTime()
+			r.Error = errorMessage(e.Result)
+		}
+	}
+	if r.File == "" && r.Name != "" {
+		r.File = filepath.ToSlash(util.RelPathToProjectRoot(e.ExecutionInfo.GetCurrentSpec().GetFileName()))
+	}
+	return r
+}
+
+func describe(item gauge.Item) (string, string, int) {
+	switch i := item.(type) {
+	case *gauge.Specification:
+		return i.Heading.Value, filepath.ToSlash(util.RelPathToProjectRoot(i.FileName)), i.Heading.LineNo
+	case *gauge.Scenario:
+		return i.Heading.Value, "", i.Heading.LineNo
+	case *gauge.Step:
+		return i.LineText, filepath.ToSlash(util.RelPathToProjectRoot(i.FileName)), i.LineNo
+	case gauge.Step:
+		return i.LineText, filepath.ToSlash(util.RelPathToProjectRoot(i.FileName)), i.LineNo
+	}
+	return "", "", 0
+}
+
+func outcome(failed, skipped bool) string {
+	if failed {
+		return "failed"
+	}
+	if skipped {
+		return "skipped"
+	}
+	return "passed"
+}
+
+func skipped(res result.Result) bool {
+	switch r := res.(type) {
+	case *result.ScenarioResult:
+		return r.ProtoScenario.GetExecutionStatus() == gauge_messages.ExecutionStatus_SKIPPED
+	case *result.SpecResult:
+		return r.Skipped
+	case *result.StepResult:
+		return r.ProtoStepExecResult().GetSkipped()
+	}
+	return false
+}
+
+func errorMessage(res result.Result) string {
+	switch r := res.(type) {
+	case *result.ScenarioResult:
+		return r.FailureMessage()
+	case *result.StepResult:
+		if r.GetFailed() {
+			return r.GetErrorMessage()
+		}
+	}
+	for _, h := range append(res.GetPreHook(), res.GetPostHook()...) {
+		return h.GetErrorMessage()
+	}
+	return ""
+}
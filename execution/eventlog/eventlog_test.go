@@ -0,0 +1,88 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package eventlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) TestEventLogWritesRecordsWithParents(c *C) {
+	b := &bytes.Buffer{}
+	l := newEventLog(b)
+	info := gauge_messages.ExecutionInfo{CurrentSpec: &gauge_messages.SpecInfo{FileName: "foo.spec"}}
+	spec := &gauge.Specification{Heading: &gauge.Heading{Value: "spec", LineNo: 1}, FileName: "foo.spec"}
+	sce := &gauge.Scenario{Heading: &gauge.Heading{Value: "scenario", LineNo: 3}}
+	step := &gauge.Step{LineText: "a step", LineNo: 4, FileName: "foo.spec"}
+	stepRes := result.NewStepResult(&gauge_messages.ProtoStep{StepExecutionResult: &gauge_messages.ProtoStepExecutionResult{ExecutionResult: &gauge_messages.ProtoExecutionResult{Failed: true, ErrorMessage: "boom", ExecutionTime: 5}}})
+	sceRes := result.NewScenarioResult(&gauge_messages.ProtoScenario{ExecutionStatus: gauge_messages.ExecutionStatus_FAILED})
+	skippedRes := result.NewScenarioResult(&gauge_messages.ProtoScenario{ExecutionStatus: gauge_messages.ExecutionStatus_SKIPPED})
+	Streams = 2
+	defer func() { Streams = 0 }()
+
+	for _, e := range []event.ExecutionEvent{
+		event.NewExecutionEvent(event.SuiteStart, nil, nil, 0, gauge_messages.ExecutionInfo{}),
+		event.NewExecutionEvent(event.SpecStart, spec, nil, 1, info),
+		event.NewExecutionEvent(event.ScenarioStart, sce, sceRes, 1, info),
+		event.NewExecutionEvent(event.StepStart, step, nil, 1, info),
+		event.NewExecutionEvent(event.StepEnd, *step, stepRes, 1, info),
+		event.NewExecutionEvent(event.ScenarioEnd, sce, sceRes, 1, info),
+		event.NewExecutionEvent(event.ScenarioStart, sce, skippedRes, 1, info),
+		event.NewExecutionEvent(event.ScenarioEnd, sce, skippedRes, 1, info),
+		event.NewExecutionEvent(event.SpecEnd, spec, &result.SpecResult{ProtoSpec: &gauge_messages.ProtoSpec{}, IsFailed: true}, 1, info),
+		event.NewExecutionEvent(event.SuiteEnd, nil, &result.SuiteResult{IsFailed: true}, 0, gauge_messages.ExecutionInfo{}),
+	} {
+		l.write(l.record(e))
+	}
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	c.Assert(len(lines), Equals, 10)
+	var records []Record
+	for _, line := range lines {
+		var r Record
+		c.Assert(json.Unmarshal([]byte(line), &r), IsNil)
+		r.Time = ""
+		records = append(records, r)
+	}
+	c.Assert(records, DeepEquals, []Record{
+		{Event: "suiteStart", ID: "suite", Streams: 2},
+		{Event: "specStart", ID: "1", ParentID: "suite", Stream: 1, Name: "spec", File: "foo.spec", Line: 1},
+		{Event: "scenarioStart", ID: "2", ParentID: "1", Stream: 1, Name: "scenario", File: "foo.spec", Line: 3},
+		{Event: "stepStart", ID: "3", ParentID: "2", Stream: 1, Name: "a step", File: "foo.spec", Line: 4},
+		{Event: "stepEnd", ID: "3", ParentID: "2", Stream: 1, Name: "a step", File: "foo.spec", Line: 4, Outcome: "failed", Duration: 5, Error: "boom"},
+		{Event: "scenarioEnd", ID: "2", ParentID: "1", Stream: 1, Name: "scenario", File: "foo.spec", Line: 3, Outcome: "failed"},
+		{Event: "scenarioStart", ID: "4", ParentID: "1", Stream: 1, Name: "scenario", File: "foo.spec", Line: 3, Outcome: "skipped"},
+		{Event: "scenarioEnd", ID: "4", ParentID: "1", Stream: 1, Name: "scenario", File: "foo.spec", Line: 3, Outcome: "skipped"},
+		{Event: "specEnd", ID: "1", ParentID: "suite", Stream: 1, Name: "spec", File: "foo.spec", Line: 1, Outcome: "failed"},
+		{Event: "suiteEnd", ID: "suite", Outcome: "failed"},
+	})
+}
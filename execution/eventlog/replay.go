@@ -0,0 +1,198 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package eventlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+)
+
+// followInterval is the interval at which the event log is polled for new records.
+var followInterval = 200 * time.Millisecond
+
+// Path gives the path of the event log of the project.
+func Path() string {
+	return logger.LogFilePath(eventLogFile)
+}
+
+// Follow sends the records of the event log on the channel as they are written, from the first one, till the end
+// of the suite is recorded. It gives up once stopped tells that the execution is gone, with nothing more written.
+// The channel is closed on return.
+func Follow(path string, records chan<- *Record, stopped func() bool) error {
+	defer close(records)
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("Failed to open event log %s. %s", path, err.Error())
+	}
+	defer f.Close()
+	reader := bufio.NewReader(f)
+	var partial []byte
+	for {
+		// checked before reading, so that everything written before the execution was gone is read
+		gone := stopped()
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			partial = append(partial, line...)
+			if gone {
+				return fmt.Errorf("The execution ended before its end was recorded in %s", path)
+			}
+			time.Sleep(followInterval)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("Failed to read event log %s. %s", path, err.Error())
+		}
+		line, partial = append(partial, line...), nil
+		r := &Record{}
+		if err := json.Unmarshal(line, r); err != nil {
+			return fmt.Errorf("Invalid record in event log %s. %s", path, err.Error())
+		}
+		records <- r
+		if r.Event == topics[event.SuiteEnd] {
+			return nil
+		}
+	}
+}
+
+// Replayer converts the records of an event log back to execution events, to report them as they were reported
+// during the execution. The items and results of the events only have what the records hold, i.e. the names,
+// locations, outcomes, durations and step errors. The records have to be replayed in the order they were written.
+type Replayer struct {
+	specs     map[string]*gauge.Specification
+	results   map[string]*result.SpecResult
+	scenarios map[string]*gauge.Scenario
+	// current holds the spec executing in each stream
+	current map[int]*gauge.Specification
+	suite   *result.SuiteResult
+}
+
+// NewReplayer creates a replayer for the records of an execution.
+func NewReplayer() *Replayer {
+	return &Replayer{
+		specs:     make(map[string]*gauge.Specification),
+		results:   make(map[string]*result.SpecResult),
+		scenarios: make(map[string]*gauge.Scenario),
+		current:   make(map[int]*gauge.Specification),
+		suite:     &result.SuiteResult{},
+	}
+}
+
+// Event gives the execution event of the record. It is false for a record of an unknown event.
+func (r *Replayer) Event(rec *Record) (event.ExecutionEvent, bool) {
+	ei := gauge_messages.ExecutionInfo{}
+	if spec, ok := r.current[rec.Stream]; ok {
+		ei.CurrentSpec = &gauge_messages.SpecInfo{Name: spec.Heading.Value, FileName: spec.FileName}
+	}
+	newEvent := func(t event.Topic, i gauge.Item, res result.Result) (event.ExecutionEvent, bool) {
+		return event.NewExecutionEvent(t, i, res, rec.Stream, ei), true
+	}
+	switch rec.Event {
+	case topics[event.SuiteStart]:
+		return newEvent(event.SuiteStart, nil, nil)
+	case topics[event.SpecStart]:
+		spec := &gauge.Specification{Heading: &gauge.Heading{Value: rec.Name, LineNo: rec.Line}, FileName: rec.File}
+		r.specs[rec.ID], r.current[rec.Stream] = spec, spec
+		ei.CurrentSpec = &gauge_messages.SpecInfo{Name: spec.Heading.Value, FileName: spec.FileName}
+		r.results[rec.ID] = &result.SpecResult{ProtoSpec: &gauge_messages.ProtoSpec{SpecHeading: rec.Name, FileName: rec.File}}
+		return newEvent(event.SpecStart, spec, r.results[rec.ID])
+	case topics[event.ScenarioStart]:
+		sce := &gauge.Scenario{Heading: &gauge.Heading{Value: rec.Name, LineNo: rec.Line}}
+		r.scenarios[rec.ID] = sce
+		return newEvent(event.ScenarioStart, sce, scenarioResult(rec))
+	case topics[event.ConceptStart], topics[event.StepStart]:
+		step := &gauge.Step{LineText: rec.Name, Value: strings.TrimSpace(rec.Name), LineNo: rec.Line, FileName: rec.File, IsConcept: rec.Event == topics[event.ConceptStart]}
+		if step.IsConcept {
+			return newEvent(event.ConceptStart, step, nil)
+		}
+		return newEvent(event.StepStart, step, nil)
+	case topics[event.StepEnd]:
+		step := gauge.Step{LineText: rec.Name, Value: strings.TrimSpace(rec.Name), LineNo: rec.Line, FileName: rec.File}
+		res := result.NewStepResult(&gauge_messages.ProtoStep{ActualText: rec.Name, StepExecutionResult: &gauge_messages.ProtoStepExecutionResult{
+			ExecutionResult: executionResult(rec), Skipped: rec.Outcome == outcome(false, true)}})
+		res.StepFailed = rec.Outcome == outcome(true, false)
+		return newEvent(event.StepEnd, step, res)
+	case topics[event.ConceptEnd]:
+		res := result.NewConceptResult(&gauge_messages.ProtoConcept{ConceptExecutionResult: &gauge_messages.ProtoStepExecutionResult{ExecutionResult: executionResult(rec)}})
+		return newEvent(event.ConceptEnd, nil, res)
+	case topics[event.ScenarioEnd]:
+		sce, ok := r.scenarios[rec.ID]
+		if !ok {
+			return event.ExecutionEvent{}, false
+		}
+		delete(r.scenarios, rec.ID)
+		res := scenarioResult(rec)
+		if specResult, ok := r.results[rec.ParentID]; ok {
+			specResult.ScenarioCount++
+			if res.GetFailed() {
+				specResult.ScenarioFailedCount++
+			} else if res.ProtoScenario.ExecutionStatus == gauge_messages.ExecutionStatus_SKIPPED {
+				specResult.ScenarioSkippedCount++
+			}
+		}
+		return newEvent(event.ScenarioEnd, sce, res)
+	case topics[event.SpecEnd]:
+		spec, ok := r.specs[rec.ID]
+		if !ok {
+			return event.ExecutionEvent{}, false
+		}
+		res := r.results[rec.ID]
+		delete(r.specs, rec.ID)
+		delete(r.results, rec.ID)
+		delete(r.current, rec.Stream)
+		res.IsFailed, res.Skipped, res.ExecutionTime = rec.Outcome == outcome(true, false), rec.Outcome == outcome(false, true), rec.Duration
+		r.suite.AddSpecResult(res)
+		return newEvent(event.SpecEnd, spec, res)
+	case topics[event.SuiteEnd]:
+		r.suite.SetSpecsSkippedCount()
+		r.suite.IsFailed, r.suite.ExecutionTime = rec.Outcome == outcome(true, false), rec.Duration
+		return newEvent(event.SuiteEnd, nil, r.suite)
+	}
+	return event.ExecutionEvent{}, false
+}
+
+func scenarioResult(rec *Record) *result.ScenarioResult {
+	status := gauge_messages.ExecutionStatus_PASSED
+	switch rec.Outcome {
+	case outcome(true, false):
+		status = gauge_messages.ExecutionStatus_FAILED
+	case outcome(false, true):
+		status = gauge_messages.ExecutionStatus_SKIPPED
+	}
+	return result.NewScenarioResult(&gauge_messages.ProtoScenario{ScenarioHeading: rec.Name, ExecutionStatus: status,
+		Failed: status == gauge_messages.ExecutionStatus_FAILED, Skipped: status == gauge_messages.ExecutionStatus_SKIPPED, ExecutionTime: rec.Duration})
+}
+
+func executionResult(rec *Record) *gauge_messages.ProtoExecutionResult {
+	failed := rec.Outcome == outcome(true, false)
+	r := &gauge_messages.ProtoExecutionResult{Failed: failed, ExecutionTime: rec.Duration}
+	if failed {
+		r.ErrorMessage = rec.Error
+	}
+	return r
+}
@@ -0,0 +1,115 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package eventlog
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestFollowReadsRecordsAsTheyAreWritten(c *C) {
+	dir, err := ioutil.TempDir("", "eventlog")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, eventLogFile)
+	c.Assert(ioutil.WriteFile(path, []byte(`{"event":"suiteStart","id":"suite","streams":2}`+"\n"+`{"event":"specSt`), 0644), IsNil)
+	followInterval = time.Millisecond
+	defer func() { followInterval = 200 * time.Millisecond }()
+
+	records := make(chan *Record)
+	followed := make(chan error, 1)
+	go func() { followed <- Follow(path, records, func() bool { return false }) }()
+	c.Assert(<-records, DeepEquals, &Record{Event: "suiteStart", ID: "suite", Streams: 2})
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	c.Assert(err, IsNil)
+	f.WriteString(`art","id":"1"}` + "\n" + `{"event":"suiteEnd","id":"suite"}` + "\n")
+	f.Close()
+
+	c.Assert(<-records, DeepEquals, &Record{Event: "specStart", ID: "1"})
+	c.Assert(<-records, DeepEquals, &Record{Event: "suiteEnd", ID: "suite"})
+	c.Assert(<-followed, IsNil)
+	_, open := <-records
+	c.Assert(open, Equals, false)
+}
+
+func (s *MySuite) TestFollowGivesUpOnceTheExecutionIsGone(c *C) {
+	dir, err := ioutil.TempDir("", "eventlog")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, eventLogFile)
+	c.Assert(ioutil.WriteFile(path, []byte(`{"event":"suiteStart","id":"suite"}`+"\n"), 0644), IsNil)
+
+	records := make(chan *Record, 1)
+	err = Follow(path, records, func() bool { return true })
+
+	c.Assert(err, ErrorMatches, "The execution ended before its end was recorded in .*")
+	c.Assert((<-records).Event, Equals, "suiteStart")
+}
+
+func (s *MySuite) TestReplayerGivesEventsOfRecords(c *C) {
+	r := NewReplayer()
+	events := make([]event.ExecutionEvent, 0)
+	for _, rec := range []*Record{
+		{Event: "suiteStart", ID: "suite", Streams: 2},
+		{Event: "specStart", ID: "1", ParentID: "suite", Stream: 1, Name: "spec", File: "foo.spec", Line: 1},
+		{Event: "scenarioStart", ID: "2", ParentID: "1", Stream: 1, Name: "scenario", Line: 3},
+		{Event: "stepStart", ID: "3", ParentID: "2", Stream: 1, Name: "a step", File: "foo.spec", Line: 4},
+		{Event: "stepEnd", ID: "3", ParentID: "2", Stream: 1, Name: "a step", File: "foo.spec", Line: 4, Outcome: "failed", Duration: 5, Error: "boom"},
+		{Event: "scenarioEnd", ID: "2", ParentID: "1", Stream: 1, Name: "scenario", Line: 3, Outcome: "failed", Duration: 5},
+		{Event: "scenarioStart", ID: "4", ParentID: "1", Stream: 1, Name: "skipped", Line: 6, Outcome: "skipped"},
+		{Event: "scenarioEnd", ID: "4", ParentID: "1", Stream: 1, Name: "skipped", Line: 6, Outcome: "skipped"},
+		{Event: "specEnd", ID: "1", ParentID: "suite", Stream: 1, Name: "spec", File: "foo.spec", Line: 1, Outcome: "failed", Duration: 5},
+		{Event: "unknown", ID: "5"},
+		{Event: "suiteEnd", ID: "suite", Outcome: "failed", Duration: 7},
+	} {
+		if e, ok := r.Event(rec); ok {
+			events = append(events, e)
+		}
+	}
+
+	c.Assert(len(events), Equals, 10)
+	spec := events[1].Item.(*gauge.Specification)
+	c.Assert(spec.Heading.Value, Equals, "spec")
+	c.Assert(spec.FileName, Equals, "foo.spec")
+	c.Assert(events[2].ExecutionInfo.CurrentSpec.FileName, Equals, "foo.spec")
+	c.Assert(events[3].Item.(*gauge.Step).Value, Equals, "a step")
+	stepRes := events[4].Result.(*result.StepResult)
+	c.Assert(stepRes.GetStepFailed(), Equals, true)
+	c.Assert(stepRes.GetErrorMessage(), Equals, "boom")
+	c.Assert(events[4].Stream, Equals, 1)
+	c.Assert(events[5].Result.GetFailed(), Equals, true)
+	c.Assert(events[6].Result.(*result.ScenarioResult).ProtoScenario.Skipped, Equals, true)
+	c.Assert(events[8].Item, Equals, spec)
+	specRes := events[8].Result.(*result.SpecResult)
+	c.Assert(specRes.ScenarioCount, Equals, 2)
+	c.Assert(specRes.ScenarioFailedCount, Equals, 1)
+	c.Assert(specRes.ScenarioSkippedCount, Equals, 1)
+	suiteRes := events[9].Result.(*result.SuiteResult)
+	c.Assert(suiteRes.IsFailed, Equals, true)
+	c.Assert(suiteRes.SpecsFailedCount, Equals, 1)
+	c.Assert(suiteRes.ExecutionTime, Equals, int64(7))
+	c.Assert(suiteRes.SpecResults, DeepEquals, []*result.SpecResult{specRes})
+}
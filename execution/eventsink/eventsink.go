@@ -0,0 +1,201 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package eventsink publishes the execution events to Kafka, through a Kafka REST proxy, or to NATS as they occur, for the
+// test telemetry to be aggregated centrally. The events are the records of the event log, along with the id of the run.
+package eventsink
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/eventlog"
+	"github.com/getgauge/gauge/logger"
+)
+
+const (
+	// KafkaRESTProxy publishes the events to a Kafka topic through a Kafka REST proxy
+	KafkaRESTProxy = "kafka-rest-proxy"
+	// NATS publishes the events to a NATS subject
+	NATS = "nats"
+
+	queueSize = 1000
+	// batchSize is the maximum number of events published at once
+	batchSize = 100
+)
+
+// Message is an event as published to the sink.
+type Message struct {
+	Run     string `json:"run"`
+	Project string `json:"project"`
+	*eventlog.Record
+}
+
+type sink interface {
+	publish(messages [][]byte) error
+	close()
+}
+
+type settings struct {
+	kind     string
+	urls     []string
+	topic    string
+	user     string
+	password string
+	token    string
+}
+
+func loadSettings() (*settings, error) {
+	s := &settings{kind: strings.ToLower(strings.TrimSpace(os.Getenv(env.EventSink))), topic: strings.TrimSpace(os.Getenv(env.EventSinkTopic))}
+	if s.kind != KafkaRESTProxy && s.kind != NATS {
+		return nil, fmt.Errorf("Invalid value for %s. Possible values are %s and %s", env.EventSink, KafkaRESTProxy, NATS)
+	}
+	for _, u := range strings.Split(os.Getenv(env.EventSinkURLs), ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			s.urls = append(s.urls, u)
+		}
+	}
+	if len(s.urls) == 0 {
+		return nil, fmt.Errorf("No URLs given by %s", env.EventSinkURLs)
+	}
+	if s.topic == "" {
+		return nil, fmt.Errorf("No topic given by %s", env.EventSinkTopic)
+	}
+	if auth := strings.TrimSpace(os.Getenv(env.EventSinkAuth)); auth != "" {
+		if up := strings.SplitN(auth, ":", 2); len(up) == 2 {
+			s.user, s.password = up[0], up[1]
+		} else {
+			s.token = auth
+		}
+	}
+	return s, nil
+}
+
+var newSink = func(s *settings) (sink, error) {
+	if s.kind == KafkaRESTProxy {
+		return newKafkaSink(s), nil
+	}
+	n, err := newNATSSink(s)
+	if err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// ListenExecutionEvents publishes every execution event to the sink given by the event_sink property, if any.
+// The events are published in batches, in the order they occur, without blocking the execution, and are all
+// published before the end of the suite is signalled. Publishing stops at the first failure. The events which
+// occur while the queue is full, because the sink cannot keep up, are dropped and counted.
+func ListenExecutionEvents(wg *sync.WaitGroup) {
+	if strings.TrimSpace(os.Getenv(env.EventSink)) == "" {
+		return
+	}
+	s, err := loadSettings()
+	if err != nil {
+		logger.Errorf(true, "Execution events are not published. %s", err.Error())
+		return
+	}
+	ch := make(chan event.ExecutionEvent, 0)
+	event.Register(ch, event.SuiteStart, event.SpecStart, event.ScenarioStart, event.ConceptStart, event.StepStart, event.StepEnd, event.ConceptEnd, event.ScenarioEnd, event.SpecEnd, event.SuiteEnd)
+	wg.Add(1)
+
+	queue := make(chan []byte, queueSize)
+	published := make(chan bool)
+	go func() {
+		publishAll(s, queue)
+		published <- true
+	}()
+
+	go func() {
+		recorder := eventlog.NewRecorder()
+		run := fmt.Sprintf("%s-%d", time.Now().Format("20060102T150405"), os.Getpid())
+		project := filepath.Base(config.ProjectRoot)
+		dropped := 0
+		for {
+			e := <-ch
+			b, err := json.Marshal(&Message{Run: run, Project: project, Record: recorder.Record(e)})
+			if err != nil {
+				logger.Errorf(false, "Failed to marshal execution event. %s", err.Error())
+			} else if !enqueue(queue, b) {
+				dropped++
+			}
+			if e.Topic == event.SuiteEnd {
+				if dropped > 0 {
+					logger.Warningf(true, "%d execution events were not published to the %s event sink, as it could not keep up with the execution.", dropped, s.kind)
+				}
+				close(queue)
+				<-published
+				wg.Done()
+			}
+		}
+	}()
+}
+
+// enqueue queues the message to be published, without waiting for room in the queue. It gives false if the queue
+// is full and the message is dropped.
+func enqueue(queue chan<- []byte, m []byte) bool {
+	select {
+	case queue <- m:
+		return true
+	default:
+		return false
+	}
+}
+
+// publishAll publishes the messages of the queue in batches, till the queue is closed. Once publishing fails,
+// the remaining messages are dropped.
+func publishAll(s *settings, queue chan []byte) {
+	sk, err := newSink(s)
+	if err != nil {
+		logger.Errorf(true, "Failed to connect to the %s event sink. %s", s.kind, err.Error())
+		for range queue {
+		}
+		return
+	}
+	defer sk.close()
+	failed := false
+	for m := range queue {
+		batch := [][]byte{m}
+		for more := true; more && len(batch) < batchSize; {
+			select {
+			case next, ok := <-queue:
+				if !ok {
+					more = false
+					break
+				}
+				batch = append(batch, next)
+			default:
+				more = false
+			}
+		}
+		if failed {
+			continue
+		}
+		if err := sk.publish(batch); err != nil {
+			logger.Errorf(true, "Failed to publish execution events to the %s event sink. Publishing is stopped. %s", s.kind, err.Error())
+			failed = true
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package eventsink
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/getgauge/gauge/env"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) TearDownTest(c *C) {
+	for _, p := range []string{env.EventSink, env.EventSinkURLs, env.EventSinkTopic, env.EventSinkAuth} {
+		os.Unsetenv(p)
+	}
+}
+
+func (s *MySuite) TestLoadSettings(c *C) {
+	os.Setenv(env.EventSink, "NATS")
+	os.Setenv(env.EventSinkURLs, "nats://a:4222, tls://b:4222")
+	os.Setenv(env.EventSinkTopic, "ci.gauge")
+	os.Setenv(env.EventSinkAuth, "ci:secret:1")
+
+	got, err := loadSettings()
+
+	c.Assert(err, IsNil)
+	c.Assert(*got, DeepEquals, settings{kind: NATS, urls: []string{"nats://a:4222", "tls://b:4222"}, topic: "ci.gauge", user: "ci", password: "secret:1"})
+}
+
+func (s *MySuite) TestLoadSettingsWithToken(c *C) {
+	os.Setenv(env.EventSink, "nats")
+	os.Setenv(env.EventSinkURLs, "a:4222")
+	os.Setenv(env.EventSinkTopic, "ci.gauge")
+	os.Setenv(env.EventSinkAuth, "s3cr3t")
+
+	settings, err := loadSettings()
+
+	c.Assert(err, IsNil)
+	c.Assert(settings.token, Equals, "s3cr3t")
+}
+
+func (s *MySuite) TestLoadSettingsWithInvalidSink(c *C) {
+	os.Setenv(env.EventSink, "rabbitmq")
+
+	_, err := loadSettings()
+
+	c.Assert(err, ErrorMatches, "Invalid value for event_sink.*")
+}
+
+func (s *MySuite) TestLoadSettingsWithoutURLs(c *C) {
+	os.Setenv(env.EventSink, "kafka-rest-proxy")
+
+	_, err := loadSettings()
+
+	c.Assert(err, ErrorMatches, "No URLs given by event_sink_urls")
+}
+
+func (s *MySuite) TestEnqueueDropsMessagesWhenQueueIsFull(c *C) {
+	queue := make(chan []byte, 1)
+
+	c.Assert(enqueue(queue, []byte("first")), Equals, true)
+	c.Assert(enqueue(queue, []byte("second")), Equals, false)
+	c.Assert(string(<-queue), Equals, "first")
+}
+
+func (s *MySuite) TestKafkaPublishesBatchToTopic(c *C) {
+	var got kafkaRecords
+	var path, contentType, user string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, contentType = r.URL.Path, r.Header.Get("Content-Type")
+		user, _, _ = r.BasicAuth()
+		json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer server.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+	k := newKafkaSink(&settings{urls: []string{down.URL, server.URL + "/"}, topic: "gauge.events", user: "ci", password: "secret"})
+
+	err := k.publish([][]byte{[]byte(`{"event":"suiteStart"}`), []byte(`{"event":"suiteEnd"}`)})
+
+	c.Assert(err, IsNil)
+	c.Assert(path, Equals, "/topics/gauge.events")
+	c.Assert(contentType, Equals, kafkaContentType)
+	c.Assert(user, Equals, "ci")
+	c.Assert(len(got.Records), Equals, 2)
+	c.Assert(string(got.Records[1].Value), Equals, `{"event":"suiteEnd"}`)
+}
+
+func (s *MySuite) TestKafkaPublishFailsWhenAllProxiesFail(c *C) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer down.Close()
+	k := newKafkaSink(&settings{urls: []string{down.URL}, topic: "gauge.events"})
+
+	err := k.publish([][]byte{[]byte(`{}`)})
+
+	c.Assert(err, ErrorMatches, ".*responded with 401 Unauthorized")
+}
+
+// fakeNATS accepts a connection, greeting with the given INFO, and answers every PING, recording the lines sent by
+// the client. The connection is upgraded to TLS after the INFO if tlsConfig is given.
+func fakeNATS(c *C, lines chan string, info, reply string, tlsConfig *tls.Config) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, IsNil)
+	go func() {
+		defer l.Close()
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		conn.Write([]byte("INFO " + info + "\r\n"))
+		if tlsConfig != nil {
+			conn = tls.Server(conn, tlsConfig)
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				close(lines)
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			lines <- line
+			if line == "PING" {
+				conn.Write([]byte(reply))
+			}
+		}
+	}()
+	return l.Addr().String()
+}
+
+func receivedLines(lines chan string) []string {
+	var got []string
+	for line := range lines {
+		got = append(got, line)
+	}
+	return got
+}
+
+func (s *MySuite) TestNATSPublishesToSubject(c *C) {
+	lines := make(chan string, 10)
+	address := fakeNATS(c, lines, `{"server_id":"test"}`, "PONG\r\n", nil)
+
+	n, err := newNATSSink(&settings{urls: []string{"nats://" + address}, topic: "gauge.events", token: "s3cr3t"})
+	c.Assert(err, IsNil)
+	err = n.publish([][]byte{[]byte(`{"event":"suiteStart"}`)})
+	n.close()
+
+	c.Assert(err, IsNil)
+	c.Assert(receivedLines(lines), DeepEquals, []string{
+		`CONNECT {"verbose":false,"pedantic":false,"name":"gauge"}`,
+		"PING",
+		"PUB gauge.events 22",
+		`{"event":"suiteStart"}`,
+		"PING",
+	})
+}
+
+func (s *MySuite) TestNATSSendsCredentialsOverTLSToServerRequiringThem(c *C) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	natsRootCAs = x509.NewCertPool()
+	natsRootCAs.AddCert(server.Certificate())
+	defer func() { natsRootCAs = nil }()
+	lines := make(chan string, 10)
+	address := fakeNATS(c, lines, `{"tls_required":true,"auth_required":true}`, "PONG\r\n", server.TLS)
+
+	n, err := newNATSSink(&settings{urls: []string{address}, topic: "gauge.events", user: "ci", password: "secret"})
+	c.Assert(err, IsNil)
+	n.close()
+
+	c.Assert(receivedLines(lines), DeepEquals, []string{
+		`CONNECT {"verbose":false,"pedantic":false,"name":"gauge","user":"ci","pass":"secret"}`,
+		"PING",
+	})
+}
+
+func (s *MySuite) TestNATSDoesNotSendCredentialsInCleartext(c *C) {
+	lines := make(chan string, 10)
+	address := fakeNATS(c, lines, `{"auth_required":true}`, "PONG\r\n", nil)
+
+	_, err := newNATSSink(&settings{urls: []string{address}, topic: "gauge.events", token: "s3cr3t"})
+
+	c.Assert(err, ErrorMatches, "NATS server .* requires credentials, which are sent only over TLS. Use a tls:// URL")
+	c.Assert(receivedLines(lines), HasLen, 0)
+}
+
+func (s *MySuite) TestNATSPublishFailsForEventsBeyondMaximumPayload(c *C) {
+	lines := make(chan string, 10)
+	address := fakeNATS(c, lines, `{"max_payload":8}`, "PONG\r\n", nil)
+
+	n, err := newNATSSink(&settings{urls: []string{address}, topic: "gauge.events"})
+	c.Assert(err, IsNil)
+	defer n.close()
+
+	c.Assert(n.publish([][]byte{[]byte(`{"event":"suiteStart"}`)}), ErrorMatches, "Event of 22 bytes exceeds the maximum payload of 8 bytes of the NATS server")
+}
+
+func (s *MySuite) TestNATSConnectFailsOnServerError(c *C) {
+	lines := make(chan string, 10)
+	address := fakeNATS(c, lines, `{"server_id":"test"}`, "-ERR 'Authorization Violation'\r\n", nil)
+
+	_, err := newNATSSink(&settings{urls: []string{address}, topic: "gauge.events"})
+
+	c.Assert(err, ErrorMatches, "NATS server responded with 'Authorization Violation'")
+}
@@ -0,0 +1,93 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package eventsink
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const kafkaContentType = "application/vnd.kafka.json.v2+json"
+
+// kafkaSink publishes to a topic through the REST proxy of Kafka, trying the proxies in order.
+type kafkaSink struct {
+	client   *http.Client
+	proxies  []string
+	topic    string
+	user     string
+	password string
+}
+
+type kafkaRecords struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Value json.RawMessage `json:"value"`
+}
+
+func newKafkaSink(s *settings) *kafkaSink {
+	return &kafkaSink{client: &http.Client{Timeout: 10 * time.Second}, proxies: s.urls, topic: s.topic, user: s.user, password: s.password}
+}
+
+func (k *kafkaSink) publish(messages [][]byte) error {
+	records := kafkaRecords{}
+	for _, m := range messages {
+		records.Records = append(records.Records, kafkaRecord{Value: m})
+	}
+	body, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	var errs []string
+	for _, proxy := range k.proxies {
+		if err = k.post(proxy, body); err == nil {
+			return nil
+		}
+		errs = append(errs, err.Error())
+	}
+	return fmt.Errorf("%s", strings.Join(errs, ". "))
+}
+
+func (k *kafkaSink) post(proxy string, body []byte) error {
+	u := strings.TrimSuffix(proxy, "/") + "/topics/" + url.PathEscape(k.topic)
+	req, err := http.NewRequest(http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", kafkaContentType)
+	if k.user != "" {
+		req.SetBasicAuth(k.user, k.password)
+	}
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s responded with %s", u, resp.Status)
+	}
+	return nil
+}
+
+func (k *kafkaSink) close() {}
@@ -0,0 +1,200 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package eventsink
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+const natsTimeout = 10 * time.Second
+
+// natsRootCAs verifies the certificates of the NATS servers. The roots of the system are used if nil.
+var natsRootCAs *x509.CertPool
+
+// natsSink publishes to a subject of the first NATS server which can be connected to.
+type natsSink struct {
+	conn       net.Conn
+	reader     *bufio.Reader
+	subject    string
+	maxPayload int
+}
+
+// natsInfo is the INFO the server greets with, of which the fields deciding how to connect are read.
+type natsInfo struct {
+	TLSRequired  bool `json:"tls_required"`
+	AuthRequired bool `json:"auth_required"`
+	MaxPayload   int  `json:"max_payload"`
+}
+
+type natsConnect struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	Name     string `json:"name"`
+	User     string `json:"user,omitempty"`
+	Pass     string `json:"pass,omitempty"`
+	Token    string `json:"auth_token,omitempty"`
+}
+
+func newNATSSink(s *settings) (*natsSink, error) {
+	var errs []string
+	for _, server := range s.urls {
+		n, err := connectNATS(server, s)
+		if err == nil {
+			return n, nil
+		}
+		errs = append(errs, err.Error())
+	}
+	return nil, fmt.Errorf("%s", strings.Join(errs, ". "))
+}
+
+// connectNATS connects to the server given as tls://host:port, nats://host:port or host:port. The connection is
+// upgraded to TLS for tls:// URLs and for servers requiring it, and the credentials are sent only to the servers
+// requiring them, and only over TLS.
+func connectNATS(server string, s *settings) (*natsSink, error) {
+	address, useTLS := strings.TrimPrefix(server, "nats://"), strings.HasPrefix(server, "tls://")
+	address = strings.TrimPrefix(address, "tls://")
+	conn, err := net.DialTimeout("tcp", address, natsTimeout)
+	if err != nil {
+		return nil, err
+	}
+	n := &natsSink{conn: conn, reader: bufio.NewReader(conn), subject: s.topic}
+	info, err := n.readInfo()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	n.maxPayload = info.MaxPayload
+	if useTLS || info.TLSRequired {
+		if err := n.upgradeToTLS(address); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	connect := natsConnect{Name: "gauge"}
+	if info.AuthRequired {
+		if _, secure := n.conn.(*tls.Conn); !secure {
+			conn.Close()
+			return nil, fmt.Errorf("NATS server %s requires credentials, which are sent only over TLS. Use a tls:// URL", address)
+		}
+		connect.User, connect.Pass, connect.Token = s.user, s.password, s.token
+	}
+	b, err := json.Marshal(connect)
+	if err != nil {
+		n.conn.Close()
+		return nil, err
+	}
+	if err := n.write(fmt.Sprintf("CONNECT %s\r\n", b)); err != nil {
+		n.conn.Close()
+		return nil, err
+	}
+	if err := n.flush(); err != nil {
+		n.conn.Close()
+		return nil, err
+	}
+	return n, nil
+}
+
+func (n *natsSink) readInfo() (*natsInfo, error) {
+	line, err := n.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return nil, fmt.Errorf("NATS server greeted with %s instead of INFO", line)
+	}
+	info := &natsInfo{}
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), info); err != nil {
+		return nil, fmt.Errorf("Failed to read the INFO of the NATS server. %s", err.Error())
+	}
+	return info, nil
+}
+
+func (n *natsSink) upgradeToTLS(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	conn := tls.Client(n.conn, &tls.Config{ServerName: host, RootCAs: natsRootCAs, MinVersion: tls.VersionTLS12})
+	conn.SetDeadline(time.Now().Add(natsTimeout))
+	if err := conn.Handshake(); err != nil {
+		return fmt.Errorf("TLS handshake with NATS server %s failed. %s", address, err.Error())
+	}
+	n.conn, n.reader = conn, bufio.NewReader(conn)
+	return nil
+}
+
+func (n *natsSink) publish(messages [][]byte) error {
+	var b strings.Builder
+	for _, m := range messages {
+		if n.maxPayload > 0 && len(m) > n.maxPayload {
+			return fmt.Errorf("Event of %d bytes exceeds the maximum payload of %d bytes of the NATS server", len(m), n.maxPayload)
+		}
+		fmt.Fprintf(&b, "PUB %s %d\r\n%s\r\n", n.subject, len(m), m)
+	}
+	if err := n.write(b.String()); err != nil {
+		return err
+	}
+	return n.flush()
+}
+
+// flush waits for the server to process everything sent so far, by a PING answered with a PONG.
+// Errors of the server, like an authorization violation, are given instead.
+func (n *natsSink) flush() error {
+	if err := n.write("PING\r\n"); err != nil {
+		return err
+	}
+	for {
+		line, err := n.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if err := n.write("PONG\r\n"); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("NATS server responded with %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+func (n *natsSink) write(s string) error {
+	n.conn.SetWriteDeadline(time.Now().Add(natsTimeout))
+	_, err := n.conn.Write([]byte(s))
+	return err
+}
+
+func (n *natsSink) readLine() (string, error) {
+	n.conn.SetReadDeadline(time.Now().Add(natsTimeout))
+	line, err := n.reader.ReadString('\n')
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+func (n *natsSink) close() {
+	n.conn.Close()
+}
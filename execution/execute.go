@@ -29,6 +29,7 @@
    Strategy
     	- Lazy : Lazy is a parallelization strategy for execution. In this case tests assignment will be dynamic during execution, i.e. assign the next spec in line to the stream that has completed it’s previous execution and is waiting for more work.
     	- Eager : Eager is a parallelization strategy for execution. In this case tests are distributed before execution, thus making them an equal number based distribution.
+    	- Deterministic : Deterministic is a parallelization strategy for execution. In this case tests are distributed before execution, with the stream of each spec depending only on its path and the number of streams.
 */
 package execution
 
@@ -55,19 +56,37 @@ import (
 	"github.com/getgauge/gauge/config"
 	"github.com/getgauge/gauge/env"
 	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/eventlog"
+	"github.com/getgauge/gauge/execution/eventsink"
+	"github.com/getgauge/gauge/execution/fixtures"
+	"github.com/getgauge/gauge/execution/history"
+	"github.com/getgauge/gauge/execution/reportportal"
 	"github.com/getgauge/gauge/execution/rerun"
 	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/execution/resultformat"
+	"github.com/getgauge/gauge/execution/resultstream"
+	"github.com/getgauge/gauge/execution/status"
+	"github.com/getgauge/gauge/execution/tagreport"
+	"github.com/getgauge/gauge/execution/testrail"
+	"github.com/getgauge/gauge/execution/traceability"
+	"github.com/getgauge/gauge/execution/webhook"
+	"github.com/getgauge/gauge/execution/xray"
+	"github.com/getgauge/gauge/filter"
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/manifest"
+	"github.com/getgauge/gauge/owners"
+	"github.com/getgauge/gauge/parser"
 	"github.com/getgauge/gauge/plugin/install"
 	"github.com/getgauge/gauge/reporter"
 	"github.com/getgauge/gauge/runner"
+	"github.com/getgauge/gauge/util"
 	"github.com/getgauge/gauge/validation"
 )
 
 const (
 	executionStatusFile = "executionStatus.json"
+	maxSlowSteps        = 10
 )
 
 // NumberOfExecutionStreams shows the number of execution streams, in parallel execution.
@@ -79,6 +98,12 @@ var InParallel bool
 // MachineReadable indicates that the output is in json format
 var MachineReadable bool
 
+// ReportingPlugins are started for the execution in place of the plugins of the manifest, if set by an execution profile.
+var ReportingPlugins []string
+
+// Metadata is embedded in the suite result, along with the details of the build detected from the CI server.
+var Metadata map[string]string
+
 type suiteExecutor interface {
 	run() *result.SuiteResult
 }
@@ -98,11 +123,43 @@ type executionInfo struct {
 	stream          int
 }
 
+// stopFixtures tears down the fixtures of the execution. Besides the end of the execution, it is called when gauge
+// exits on a fatal error or on a second interrupt.
+var stopFixtures = func() {}
+
+// startFixtures starts the fixtures of the suite once the specs are validated. The runner which validated the specs
+// does not know the connection details of the fixtures, so it is restarted to inherit them. The runner is killed if
+// the fixtures or the restarted runner fail to start.
+func startFixtures(res *validation.ValidationResult) error {
+	stopFixtures = func() {}
+	stop, started, err := fixtures.Start()
+	if err != nil || !started {
+		if err != nil {
+			res.Runner.Kill()
+		}
+		return err
+	}
+	stopFixtures = stop
+	res.Runner.Kill()
+	m, err := manifest.ProjectManifest()
+	if err == nil {
+		res.Runner, err = runner.Start(m, reporter.RunnerWriter(0), make(chan bool), false)
+	}
+	if err != nil {
+		stopFixtures()
+		return fmt.Errorf("Failed to restart the runner with the connection details of the fixtures. %s", err.Error())
+	}
+	return nil
+}
+
 func newExecutionInfo(s *gauge.SpecCollection, r runner.Runner, ph plugin.Handler, e *gauge.BuildErrors, p bool, stream int) *executionInfo {
 	m, err := manifest.ProjectManifest()
 	if err != nil {
 		logger.Fatalf(true, err.Error())
 	}
+	if ReportingPlugins != nil {
+		m.Plugins = ReportingPlugins
+	}
 	return &executionInfo{
 		manifest:        m,
 		specs:           s,
@@ -143,17 +200,84 @@ var ExecuteSpecs = func(specDirs []string) int {
 		}
 		return ExecutionFailed
 	}
+	if err := startFixtures(res); err != nil {
+		logger.Errorf(true, "%s", err.Error())
+		return ExecutionFailed
+	}
+	defer stopFixtures()
+	defer logger.OnFatal(stopFixtures)()
+	if InParallel {
+		// the rows of the data table specs are distributed across the streams, so they are created up front
+		res.SpecCollection = gauge.NewSpecCollection(parser.GetSpecsForDataTableRows(res.SpecCollection.Specs(), res.ErrMap), false)
+	}
 	event.InitRegistry()
 	wg := &sync.WaitGroup{}
+	setExpectations(res.SpecCollection, res.ErrMap)
 	reporter.ListenExecutionEvents(wg)
+	reporter.ListenCIEvents(wg)
+	if InParallel {
+		eventlog.Streams = NumberOfExecutionStreams
+	}
+	eventlog.ListenExecutionEvents(wg)
+	eventsink.ListenExecutionEvents(wg)
+	resultstream.ListenExecutionEvents(wg)
 	rerun.ListenFailedScenarios(wg, specDirs)
+	history.ListenSpecDurations(wg)
+	history.ListenRuns(wg)
+	if InParallel && !MachineReadable && reporter.Mode == "" {
+		// the output of the streams is interleaved, so the overall progress is summed up every now and then
+		status.ProgressInterval = env.ParallelProgressInterval()
+	}
+	status.ListenExecutionEvents(wg)
+	webhook.ListenSuiteEvents(wg)
+	testrail.ListenScenarioResults(wg)
+	xray.ListenScenarioResults(wg)
+	reportportal.ListenExecutionEvents(wg)
+	resultformat.ListenSuiteEnd(wg)
+	tagreport.ListenSuiteEnd(wg)
+	traceability.ListenSuiteEnd(wg)
 	if env.SaveExecutionResult() {
 		ListenSuiteEndAndSaveResult(wg)
 	}
+	initSpillStore()
+	// the spilled results are read by the listeners of the end of the suite, so they are removed after them
+	defer removeSpilledResults()
 	defer wg.Wait()
+	resetAbort()
+	hookTags = filter.HookTags()
+	endWatch := watchForStop(Timeout)
 	ei := newExecutionInfo(res.SpecCollection, res.Runner, nil, res.ErrMap, InParallel, 0)
 	e := newExecution(ei)
-	return printExecutionResult(e.run(), res.ParseOk)
+	suiteResult := e.run()
+	endWatch()
+	return printExecutionResult(suiteResult, res.ParseOk)
+}
+
+// suiteMetadata gives the metadata of the suite. The values given by the user take precedence over the ones detected from the CI server.
+func suiteMetadata() map[string]string {
+	m := env.CIMetadata()
+	if m == nil && len(Metadata) == 0 {
+		return nil
+	}
+	if m == nil {
+		m = make(map[string]string)
+	}
+	for k, v := range Metadata {
+		m[k] = v
+	}
+	return m
+}
+
+func setExpectations(s *gauge.SpecCollection, errMap *gauge.BuildErrors) {
+	reporter.ExpectedScenarios = 0
+	for _, spec := range s.Specs() {
+		reporter.ExpectedScenarios += parser.NewDataTableRows(spec, errMap).ScenarioCount()
+	}
+	streams := 1
+	if InParallel {
+		streams = NumberOfExecutionStreams
+	}
+	reporter.ExpectedDuration = history.ExpectedDuration(s.SpecNames(), streams)
 }
 
 func newExecution(executionInfo *executionInfo) suiteExecutor {
@@ -201,9 +325,19 @@ func statusJSON(executedSpecs, passedSpecs, failedSpecs, skippedSpecs, executedS
 	return s
 }
 
+// ExecutionStatusFileEnv holds the name of the environment variable which overrides the file the execution status is written to.
+const ExecutionStatusFileEnv = "GAUGE_EXECUTION_STATUS_FILE"
+
+func executionStatusFilePath() string {
+	if f := os.Getenv(ExecutionStatusFileEnv); f != "" {
+		return f
+	}
+	return filepath.Join(config.ProjectRoot, common.DotGauge, executionStatusFile)
+}
+
 func writeExecutionResult(content string) {
-	executionStatusFile := filepath.Join(config.ProjectRoot, common.DotGauge, executionStatusFile)
-	dotGaugeDir := filepath.Join(config.ProjectRoot, common.DotGauge)
+	executionStatusFile := executionStatusFilePath()
+	dotGaugeDir := filepath.Dir(executionStatusFile)
 	if err := os.MkdirAll(dotGaugeDir, common.NewDirectoryPermissions); err != nil {
 		logger.Fatalf(true, "Failed to create directory in %s. Reason: %s", dotGaugeDir, err.Error())
 	}
@@ -216,7 +350,7 @@ func writeExecutionResult(content string) {
 // ReadLastExecutionResult returns the result of previous execution in JSON format
 // This is stored in $GAUGE_PROJECT_ROOT/.gauge/executionStatus.json file after every execution
 func ReadLastExecutionResult() (interface{}, error) {
-	contents, err := common.ReadFileContents(filepath.Join(config.ProjectRoot, common.DotGauge, executionStatusFile))
+	contents, err := common.ReadFileContents(executionStatusFilePath())
 	if err != nil {
 		logger.Fatalf(true, "Failed to read execution status information. Reason: %s", err.Error())
 	}
@@ -229,6 +363,29 @@ func ReadLastExecutionResult() (interface{}, error) {
 }
 
 func printExecutionResult(suiteResult *result.SuiteResult, isParsingOk bool) int {
+	if suiteResult.Tags != "" {
+		logger.Infof(true, "Tags:\t\t%s", suiteResult.Tags)
+	}
+	s := printCounts(suiteResult)
+	printSlowSteps(suiteResult.SlowSteps)
+	printFailuresByOwner(suiteResult)
+	logger.Infof(true, "\nTotal time taken: %s", time.Millisecond*time.Duration(suiteResult.ExecutionTime))
+	writeExecutionResult(s)
+
+	if !isParsingOk {
+		return ParseFailed
+	}
+	if reason, code := stopReason(); reason != "" {
+		return code
+	}
+	if suiteResult.IsFailed {
+		return ExecutionFailed
+	}
+	return Success
+}
+
+// printCounts prints the number of specs and scenarios executed, passed, failed and skipped, and gives them as JSON.
+func printCounts(suiteResult *result.SuiteResult) string {
 	nSkippedSpecs := suiteResult.SpecsSkippedCount
 	var nExecutedSpecs int
 	if len(suiteResult.SpecResults) != 0 {
@@ -256,19 +413,45 @@ func printExecutionResult(suiteResult *result.SuiteResult, isParsingOk bool) int
 		nPassedScenarios = 0
 	}
 
-	s := statusJSON(nExecutedSpecs, nPassedSpecs, nFailedSpecs, nSkippedSpecs, nExecutedScenarios, nPassedScenarios, nFailedScenarios, nSkippedScenarios)
 	logger.Infof(true, "Specifications:\t%d executed\t%d passed\t%d failed\t%d skipped", nExecutedSpecs, nPassedSpecs, nFailedSpecs, nSkippedSpecs)
 	logger.Infof(true, "Scenarios:\t%d executed\t%d passed\t%d failed\t%d skipped", nExecutedScenarios, nPassedScenarios, nFailedScenarios, nSkippedScenarios)
-	logger.Infof(true, "\nTotal time taken: %s", time.Millisecond*time.Duration(suiteResult.ExecutionTime))
-	writeExecutionResult(s)
+	return statusJSON(nExecutedSpecs, nPassedSpecs, nFailedSpecs, nSkippedSpecs, nExecutedScenarios, nPassedScenarios, nFailedScenarios, nSkippedScenarios)
+}
 
-	if !isParsingOk {
-		return ParseFailed
+func printSlowSteps(slowSteps []*result.SlowStep) {
+	if len(slowSteps) == 0 {
+		return
 	}
-	if suiteResult.IsFailed {
-		return ExecutionFailed
+	logger.Infof(true, "\nSlowest steps (above %dms):", env.SlowStepThreshold())
+	for _, s := range slowSteps {
+		logger.Infof(true, "\t%s\t%s\t%s > %s", time.Millisecond*time.Duration(s.ExecutionTime), s.Text, util.RelPathToProjectRoot(s.SpecFile), s.Scenario)
+	}
+}
+
+func printFailuresByOwner(suiteResult *result.SuiteResult) {
+	if !suiteResult.IsFailed {
+		return
+	}
+	o, err := owners.Load()
+	if err != nil {
+		logger.Warningf(true, "Failures are not grouped by owner. %s", err.Error())
+		return
+	}
+	groups := o.GroupFailures(suiteResult)
+	if len(groups) == 0 {
+		return
+	}
+	logger.Infof(true, "\nFailures by owner:")
+	for _, g := range groups {
+		owner := g.Owner
+		if owner == "" {
+			owner = "(no owner)"
+		}
+		logger.Infof(true, "\t%s\t%d failure(s)", owner, len(g.Failures))
+		for _, f := range g.Failures {
+			logger.Infof(true, "\t\t%s", f)
+		}
 	}
-	return Success
 }
 
 func validateFlags() error {
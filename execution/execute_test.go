@@ -19,6 +19,7 @@ package execution
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/getgauge/gauge/gauge"
 
@@ -75,6 +76,14 @@ func (s *MySuite) TestValidateFlagsWithStartegyLazy(c *C) {
 	c.Assert(err, Equals, nil)
 }
 
+func (s *MySuite) TestValidateFlagsWithStartegyDeterministic(c *C) {
+	InParallel = true
+	Strategy = "deterministic"
+	NumberOfExecutionStreams = 1
+	err := validateFlags()
+	c.Assert(err, Equals, nil)
+}
+
 func (s *MySuite) TestValidateFlagsWithInvalidStrategy(c *C) {
 	InParallel = true
 	Strategy = "sdf"
@@ -89,3 +98,18 @@ func (s *MySuite) TestValidateFlagsWithInvalidStream(c *C) {
 	err := validateFlags()
 	c.Assert(err.Error(), Equals, "invalid input(-1) to --n flag")
 }
+
+func (s *MySuite) TestSuiteMetadataOverridesDetectedCIMetadata(c *C) {
+	os.Setenv("TRAVIS", "true")
+	os.Setenv("TRAVIS_BUILD_NUMBER", "7")
+	os.Setenv("TRAVIS_BRANCH", "feature")
+	Metadata = map[string]string{"branch": "main", "team": "qa"}
+	defer func() {
+		os.Unsetenv("TRAVIS")
+		os.Unsetenv("TRAVIS_BUILD_NUMBER")
+		os.Unsetenv("TRAVIS_BRANCH")
+		Metadata = nil
+	}()
+
+	c.Assert(suiteMetadata(), DeepEquals, map[string]string{"ci": "travis", "build": "7", "branch": "main", "team": "qa"})
+}
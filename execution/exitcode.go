@@ -9,4 +9,8 @@ const (
 	ParseFailed = 2
 	// ValidationFailed indicates one or more validation errors
 	ValidationFailed = 3
+	// TimedOut indicates the execution was stopped as it took longer than the timeout
+	TimedOut = 4
+	// Interrupted indicates the execution was stopped by SIGINT or SIGTERM
+	Interrupted = 5
 )
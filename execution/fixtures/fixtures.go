@@ -0,0 +1,294 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package fixtures starts the external services of the suite, like databases, once the specs are validated and tears
+// them down after the suite. The services are given by a docker-compose file, or by commands starting and stopping them.
+// Their connection details are exported as environment variables, which the runner of the execution inherits.
+package fixtures
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/logger"
+)
+
+// healthCheckInterval is the time to wait before retrying a failed health check
+var healthCheckInterval = time.Second
+
+type fixtures struct {
+	composeFile   string
+	start         string
+	stop          string
+	healthCheck   string
+	healthTimeout time.Duration
+	envFile       string
+}
+
+// Service is a service of the compose file, as given by docker compose ps.
+type Service struct {
+	Service    string      `json:"Service"`
+	Publishers []Publisher `json:"Publishers"`
+}
+
+// Publisher is a port of a service published on the host.
+type Publisher struct {
+	URL           string `json:"URL"`
+	TargetPort    int    `json:"TargetPort"`
+	PublishedPort int    `json:"PublishedPort"`
+}
+
+// command runs the command in the project root, writing its output to out.
+var command = func(out io.Writer, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = config.ProjectRoot
+	cmd.Stdout = out
+	cmd.Stderr = out
+	return cmd.Run()
+}
+
+func shell(c string) []string {
+	if runtime.GOOS == "windows" {
+		return []string{"cmd", "/C", c}
+	}
+	return []string{"sh", "-c", c}
+}
+
+func load() (*fixtures, error) {
+	f := &fixtures{
+		composeFile: strings.TrimSpace(os.Getenv(env.FixturesComposeFile)),
+		start:       strings.TrimSpace(os.Getenv(env.FixturesStartCommand)),
+		stop:        strings.TrimSpace(os.Getenv(env.FixturesStopCommand)),
+		healthCheck: strings.TrimSpace(os.Getenv(env.FixturesHealthCheck)),
+		envFile:     strings.TrimSpace(os.Getenv(env.FixturesEnvFile)),
+	}
+	if f.composeFile == "" && f.start == "" {
+		return nil, nil
+	}
+	if f.composeFile != "" && (f.start != "" || f.stop != "") {
+		return nil, fmt.Errorf("Fixtures can be given either by %s or by %s and %s", env.FixturesComposeFile, env.FixturesStartCommand, env.FixturesStopCommand)
+	}
+	timeout, err := strconv.Atoi(strings.TrimSpace(os.Getenv(env.FixturesHealthTimeout)))
+	if err != nil || timeout <= 0 {
+		return nil, fmt.Errorf("Invalid value for %s. It should be a positive number of seconds", env.FixturesHealthTimeout)
+	}
+	f.healthTimeout = time.Duration(timeout) * time.Second
+	return f, nil
+}
+
+// Start starts the services, waits until they are ready and exports their connection details. It returns a function
+// tearing the services down, which does nothing if no services are configured, and tells if any services were started.
+// The function tears the services down only once, however often it is called. The services are torn down right away
+// if they fail to start.
+func Start() (func(), bool, error) {
+	f, err := load()
+	if err != nil || f == nil {
+		return func() {}, false, err
+	}
+	if err := f.up(); err != nil {
+		f.down()
+		return func() {}, false, err
+	}
+	var once sync.Once
+	return func() { once.Do(f.down) }, true, nil
+}
+
+func (f *fixtures) compose(args ...string) []string {
+	return append([]string{"docker", "compose", "-f", f.composeFile}, args...)
+}
+
+func (f *fixtures) up() error {
+	logger.Infof(true, "Starting fixtures.")
+	start := shell(f.start)
+	if f.composeFile != "" {
+		start = f.compose("up", "-d", "--wait")
+	}
+	if err := command(os.Stdout, start[0], start[1:]...); err != nil {
+		return fmt.Errorf("Failed to start fixtures. %s", err.Error())
+	}
+	if err := f.waitUntilHealthy(); err != nil {
+		return err
+	}
+	vars, err := f.connectionDetails()
+	if err != nil {
+		return err
+	}
+	for _, v := range vars {
+		nv := strings.SplitN(v, "=", 2)
+		logger.Debugf(true, "Exporting %s from fixtures.", nv[0])
+		os.Setenv(nv[0], nv[1])
+	}
+	return nil
+}
+
+func (f *fixtures) down() {
+	stop := shell(f.stop)
+	if f.composeFile != "" {
+		stop = f.compose("down", "-v")
+	} else if f.stop == "" {
+		return
+	}
+	logger.Infof(true, "Tearing down fixtures.")
+	if err := command(os.Stdout, stop[0], stop[1:]...); err != nil {
+		logger.Errorf(true, "Failed to tear down fixtures. %s", err.Error())
+	}
+}
+
+func (f *fixtures) waitUntilHealthy() error {
+	if f.healthCheck == "" {
+		return nil
+	}
+	check := shell(f.healthCheck)
+	deadline := time.Now().Add(f.healthTimeout)
+	for {
+		err := command(ioutil.Discard, check[0], check[1:]...)
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Fixtures are not ready after %s. %s", f.healthTimeout, err.Error())
+		}
+		time.Sleep(healthCheckInterval)
+	}
+}
+
+// connectionDetails gives the published ports of the services of the compose file, followed by the pairs of the env file.
+func (f *fixtures) connectionDetails() ([]string, error) {
+	var vars []string
+	if f.composeFile != "" {
+		out := &bytes.Buffer{}
+		ps := f.compose("ps", "--format", "json")
+		if err := command(out, ps[0], ps[1:]...); err != nil {
+			return nil, fmt.Errorf("Failed to list the services of %s. %s", f.composeFile, err.Error())
+		}
+		services, err := ParseServices(out.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		vars = ServiceVars(services)
+	}
+	if f.envFile == "" {
+		return vars, nil
+	}
+	file := f.envFile
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(config.ProjectRoot, file)
+	}
+	pairs, err := readEnvFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return append(vars, pairs...), nil
+}
+
+// ParseServices reads the output of docker compose ps in JSON, which is an array or one object per line depending on
+// the version of docker compose.
+func ParseServices(out []byte) ([]*Service, error) {
+	out = bytes.TrimSpace(out)
+	var services []*Service
+	if bytes.HasPrefix(out, []byte("[")) {
+		if err := json.Unmarshal(out, &services); err != nil {
+			return nil, fmt.Errorf("Failed to parse the services. %s", err.Error())
+		}
+		return services, nil
+	}
+	for _, line := range bytes.Split(out, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		s := &Service{}
+		if err := json.Unmarshal(line, s); err != nil {
+			return nil, fmt.Errorf("Failed to parse the services. %s", err.Error())
+		}
+		services = append(services, s)
+	}
+	return services, nil
+}
+
+// ServiceVars gives the host and published ports of the services, e.g. DB_HOST, DB_PORT and DB_PORT_5432 for a service
+// db publishing its port 5432. DB_PORT is the first published port of the service.
+func ServiceVars(services []*Service) []string {
+	var vars []string
+	for _, s := range services {
+		name := varName(s.Service)
+		exported := false
+		for _, p := range s.Publishers {
+			if p.PublishedPort == 0 {
+				continue
+			}
+			if !exported {
+				vars = append(vars, fmt.Sprintf("%s_HOST=%s", name, host(p.URL)), fmt.Sprintf("%s_PORT=%d", name, p.PublishedPort))
+				exported = true
+			}
+			vars = append(vars, fmt.Sprintf("%s_PORT_%d=%d", name, p.TargetPort, p.PublishedPort))
+		}
+	}
+	return vars
+}
+
+func varName(service string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, service)
+}
+
+func host(url string) string {
+	if url == "" || url == "0.0.0.0" || url == "::" {
+		return "localhost"
+	}
+	return url
+}
+
+func readEnvFile(file string) ([]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read %s. %s", file, err.Error())
+	}
+	defer f.Close()
+	var pairs []string
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		nv := strings.SplitN(strings.TrimPrefix(line, "export "), "=", 2)
+		if len(nv) != 2 || strings.TrimSpace(nv[0]) == "" {
+			return nil, fmt.Errorf("Invalid line %d in %s. Lines should be given as name=value", n, file)
+		}
+		pairs = append(pairs, strings.TrimSpace(nv[0])+"="+strings.TrimSpace(nv[1]))
+	}
+	return pairs, scanner.Err()
+}
@@ -0,0 +1,187 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package fixtures
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+var properties = []string{env.FixturesComposeFile, env.FixturesStartCommand, env.FixturesStopCommand, env.FixturesHealthCheck, env.FixturesHealthTimeout, env.FixturesEnvFile}
+
+func (s *MySuite) SetUpTest(c *C) {
+	healthCheckInterval = time.Millisecond
+	os.Setenv(env.FixturesHealthTimeout, "1")
+}
+
+func (s *MySuite) TearDownTest(c *C) {
+	for _, p := range properties {
+		os.Unsetenv(p)
+	}
+}
+
+// fakeCommand records the commands run, failing the ones for which fail gives an error.
+func fakeCommand(ran *[]string, output string, fail func(string) error) func() {
+	original := command
+	command = func(out io.Writer, name string, args ...string) error {
+		c := strings.Join(append([]string{name}, args...), " ")
+		*ran = append(*ran, c)
+		if strings.Contains(c, " ps ") {
+			fmt.Fprint(out, output)
+		}
+		if fail != nil {
+			return fail(c)
+		}
+		return nil
+	}
+	return func() { command = original }
+}
+
+func (s *MySuite) TestStartWithoutFixtures(c *C) {
+	var ran []string
+	defer fakeCommand(&ran, "", nil)()
+
+	stop, started, err := Start()
+	stop()
+
+	c.Assert(err, IsNil)
+	c.Assert(started, Equals, false)
+	c.Assert(ran, IsNil)
+}
+
+func (s *MySuite) TestStartWithComposeAndCommands(c *C) {
+	os.Setenv(env.FixturesComposeFile, "docker-compose.yml")
+	os.Setenv(env.FixturesStartCommand, "make up")
+
+	_, _, err := Start()
+
+	c.Assert(err, ErrorMatches, "Fixtures can be given either by fixtures_compose_file or by .*")
+}
+
+func (s *MySuite) TestStartAndStopComposeFile(c *C) {
+	os.Setenv(env.FixturesComposeFile, "docker-compose.yml")
+	defer os.Unsetenv("DB_PORT")
+	var ran []string
+	defer fakeCommand(&ran, `{"Service":"db","Publishers":[{"URL":"0.0.0.0","TargetPort":5432,"PublishedPort":49153}]}`, nil)()
+
+	stop, started, err := Start()
+
+	c.Assert(err, IsNil)
+	c.Assert(started, Equals, true)
+	c.Assert(os.Getenv("DB_PORT"), Equals, "49153")
+	stop()
+	stop()
+	c.Assert(ran, DeepEquals, []string{
+		"docker compose -f docker-compose.yml up -d --wait",
+		"docker compose -f docker-compose.yml ps --format json",
+		"docker compose -f docker-compose.yml down -v",
+	})
+}
+
+func (s *MySuite) TestStartWaitsUntilHealthy(c *C) {
+	os.Setenv(env.FixturesStartCommand, "make up")
+	os.Setenv(env.FixturesStopCommand, "make down")
+	os.Setenv(env.FixturesHealthCheck, "curl localhost")
+	var ran []string
+	checks := 0
+	defer fakeCommand(&ran, "", func(cmd string) error {
+		if strings.HasSuffix(cmd, "curl localhost") {
+			if checks++; checks < 3 {
+				return fmt.Errorf("not ready")
+			}
+		}
+		return nil
+	})()
+
+	_, _, err := Start()
+
+	c.Assert(err, IsNil)
+	c.Assert(checks, Equals, 3)
+}
+
+func (s *MySuite) TestStartTearsDownWhenNotHealthy(c *C) {
+	os.Setenv(env.FixturesStartCommand, "make up")
+	os.Setenv(env.FixturesStopCommand, "make down")
+	os.Setenv(env.FixturesHealthCheck, "curl localhost")
+	healthCheckInterval = 100 * time.Millisecond
+	var ran []string
+	defer fakeCommand(&ran, "", func(cmd string) error {
+		if strings.HasSuffix(cmd, "curl localhost") {
+			return fmt.Errorf("not ready")
+		}
+		return nil
+	})()
+
+	_, _, err := Start()
+
+	c.Assert(err, ErrorMatches, "Fixtures are not ready after 1s. not ready")
+	c.Assert(strings.HasSuffix(ran[len(ran)-1], "make down"), Equals, true)
+}
+
+func (s *MySuite) TestStartExportsEnvFile(c *C) {
+	config.ProjectRoot = c.MkDir()
+	defer func() { config.ProjectRoot = "" }()
+	ioutil.WriteFile(filepath.Join(config.ProjectRoot, "fixtures.env"), []byte("# services\nexport SMTP_URL = smtp://localhost:2525\n\nQUEUE=amqp://localhost\n"), 0644)
+	os.Setenv(env.FixturesStartCommand, "make up")
+	os.Setenv(env.FixturesEnvFile, "fixtures.env")
+	defer os.Unsetenv("SMTP_URL")
+	defer os.Unsetenv("QUEUE")
+	var ran []string
+	defer fakeCommand(&ran, "", nil)()
+
+	_, _, err := Start()
+
+	c.Assert(err, IsNil)
+	c.Assert(os.Getenv("SMTP_URL"), Equals, "smtp://localhost:2525")
+	c.Assert(os.Getenv("QUEUE"), Equals, "amqp://localhost")
+}
+
+func (s *MySuite) TestParseServicesAsArrayOrLines(c *C) {
+	array, err := ParseServices([]byte(`[{"Service":"db","Publishers":[]},{"Service":"smtp"}]`))
+	c.Assert(err, IsNil)
+	lines, err := ParseServices([]byte("{\"Service\":\"db\",\"Publishers\":[]}\n{\"Service\":\"smtp\"}\n"))
+	c.Assert(err, IsNil)
+
+	c.Assert(array, DeepEquals, lines)
+	c.Assert(len(array), Equals, 2)
+}
+
+func (s *MySuite) TestServiceVars(c *C) {
+	services := []*Service{
+		{Service: "user-db", Publishers: []Publisher{{URL: "0.0.0.0", TargetPort: 5432, PublishedPort: 49153}, {URL: "0.0.0.0", TargetPort: 8080}, {URL: "127.0.0.1", TargetPort: 9187, PublishedPort: 49154}}},
+		{Service: "worker"},
+	}
+
+	c.Assert(ServiceVars(services), DeepEquals, []string{"USER_DB_HOST=localhost", "USER_DB_PORT=49153", "USER_DB_PORT_5432=49153", "USER_DB_PORT_9187=49154"})
+}
@@ -0,0 +1,135 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package history stores information about previous executions in the .gauge folder,
+// so that subsequent runs can make better scheduling decisions, and the results of the previous runs can be queried.
+package history
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/util"
+)
+
+const specDurationsFile = "spec_durations.json"
+
+// ListenSpecDurations listens to the suite end event and records the execution time of each spec.
+func ListenSpecDurations(wg *sync.WaitGroup) {
+	ch := make(chan event.ExecutionEvent, 0)
+	event.Register(ch, event.SuiteEnd)
+	wg.Add(1)
+
+	go func() {
+		for {
+			e := <-ch
+			if e.Topic == event.SuiteEnd {
+				recordSpecDurations(e.Result.(*result.SuiteResult))
+				wg.Done()
+			}
+		}
+	}()
+}
+
+func recordSpecDurations(res *result.SuiteResult) {
+	durations := SpecDurations()
+	for _, r := range res.SpecResults {
+		if r.Skipped || r.ProtoSpec == nil {
+			continue
+		}
+		durations[util.RelPathToProjectRoot(r.ProtoSpec.GetFileName())] = r.ExecutionTime
+	}
+	writeSpecDurations(durations)
+}
+
+func writeSpecDurations(durations map[string]int64) {
+	dotGaugeDir := filepath.Join(config.ProjectRoot, common.DotGauge)
+	if err := os.MkdirAll(dotGaugeDir, common.NewDirectoryPermissions); err != nil {
+		logger.Errorf(true, "Failed to create directory in %s. Reason: %s", dotGaugeDir, err.Error())
+		return
+	}
+	b, err := json.MarshalIndent(durations, "", "\t")
+	if err != nil {
+		logger.Errorf(true, "Unable to marshal spec durations. %s", err.Error())
+		return
+	}
+	f := filepath.Join(dotGaugeDir, specDurationsFile)
+	if err = ioutil.WriteFile(f, b, common.NewFilePermissions); err != nil {
+		logger.Errorf(true, "Failed to write to %s. Reason: %s", f, err.Error())
+	}
+}
+
+// SpecDurations returns the execution time in milliseconds of each spec from previous runs,
+// keyed by the spec path relative to project root. It is empty if no runs have been recorded.
+var SpecDurations = func() map[string]int64 {
+	durations := make(map[string]int64)
+	f := filepath.Join(config.ProjectRoot, common.DotGauge, specDurationsFile)
+	if !common.FileExists(f) {
+		return durations
+	}
+	contents, err := common.ReadFileContents(f)
+	if err != nil {
+		logger.Debugf(true, "Failed to read spec durations. Reason: %s", err.Error())
+		return durations
+	}
+	if err = json.Unmarshal([]byte(contents), &durations); err != nil {
+		logger.Debugf(true, "Invalid spec durations in %s. Reason: %s", f, err.Error())
+		return make(map[string]int64)
+	}
+	return durations
+}
+
+// ExpectedDuration estimates the time to execute the given spec files in the given number of streams, using the
+// durations of previous runs. Files without a recorded duration are assumed to take the average time of the known files.
+// It is 0 if no runs have been recorded.
+func ExpectedDuration(specFiles []string, streams int) time.Duration {
+	durations := SpecDurations()
+	if len(durations) == 0 {
+		return 0
+	}
+	var total, known int64
+	for _, d := range durations {
+		total += d
+	}
+	average := total / int64(len(durations))
+	seen := make(map[string]bool)
+	for _, f := range specFiles {
+		f = util.RelPathToProjectRoot(f)
+		if seen[f] {
+			continue
+		}
+		seen[f] = true
+		if d, ok := durations[f]; ok {
+			known += d
+		} else {
+			known += average
+		}
+	}
+	if streams > 1 {
+		known = known / int64(streams)
+	}
+	return time.Duration(known) * time.Millisecond
+}
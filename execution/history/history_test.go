@@ -0,0 +1,69 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package history
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge_messages"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) SetUpTest(c *C) {
+	dir, err := ioutil.TempDir("", "history")
+	c.Assert(err, IsNil)
+	config.ProjectRoot = dir
+}
+
+func (s *MySuite) TearDownTest(c *C) {
+	os.RemoveAll(config.ProjectRoot)
+}
+
+func (s *MySuite) TestSpecDurationsIsEmptyWithoutPreviousRuns(c *C) {
+	c.Assert(len(SpecDurations()), Equals, 0)
+}
+
+func (s *MySuite) TestRecordSpecDurationsMergesWithPreviousRuns(c *C) {
+	spec1 := filepath.Join(config.ProjectRoot, "specs", "spec1.spec")
+	spec2 := filepath.Join(config.ProjectRoot, "specs", "spec2.spec")
+	recordSpecDurations(&result.SuiteResult{SpecResults: []*result.SpecResult{
+		{ProtoSpec: &gauge_messages.ProtoSpec{FileName: spec1}, ExecutionTime: 100},
+		{ProtoSpec: &gauge_messages.ProtoSpec{FileName: spec2}, ExecutionTime: 200},
+	}})
+
+	recordSpecDurations(&result.SuiteResult{SpecResults: []*result.SpecResult{
+		{ProtoSpec: &gauge_messages.ProtoSpec{FileName: spec1}, ExecutionTime: 300},
+		{ProtoSpec: &gauge_messages.ProtoSpec{FileName: spec2}, ExecutionTime: 0, Skipped: true},
+	}})
+
+	c.Assert(SpecDurations(), DeepEquals, map[string]int64{
+		filepath.Join("specs", "spec1.spec"): 300,
+		filepath.Join("specs", "spec2.spec"): 200,
+	})
+}
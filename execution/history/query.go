@@ -0,0 +1,249 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	runsEntity      = "runs"
+	scenariosEntity = "scenarios"
+)
+
+// fields are the fields of each entity, in the order in which they are listed.
+var fields = map[string][]string{
+	runsEntity:      {"id", "time", "environment", "tags", "status", "duration", "scenarios", "passes", "failures", "skips"},
+	scenariosEntity: {"spec", "scenario", "runs", "passes", "failures", "skips", "failure_rate", "flips", "avg_duration", "max_duration", "last_status", "last_error"},
+}
+
+// hiddenFields are the fields which are only given in the machine readable output, as they are too long for a table.
+var hiddenFields = map[string]bool{"last_error": true}
+
+// Query is a canned query of the results history. It lists the runs, or the scenarios summed up over the runs
+// queried, which match it, in its order.
+type Query struct {
+	Name        string
+	Description string
+	entity      string
+	// lastRuns is the number of last runs queried by default, all the runs kept if 0
+	lastRuns int
+	// limit is the number of rows listed by default, all the rows if 0
+	limit   int
+	matches func(Row) bool
+	orderBy string
+	desc    bool
+}
+
+// Queries are the queries which can be given by their names to gauge query.
+var Queries = []*Query{
+	{Name: "recent", Description: "the last runs, the latest first", entity: runsEntity, lastRuns: 10, orderBy: "id", desc: true},
+	{Name: "failing", Description: "the scenarios which failed in their last run, the most failing first", entity: scenariosEntity,
+		matches: func(r Row) bool { return r["last_status"] == failed }, orderBy: "failures", desc: true},
+	{Name: "failures", Description: "the scenarios which failed more than once in the last runs, the most failing first", entity: scenariosEntity,
+		lastRuns: 10, matches: func(r Row) bool { return r["failures"].(int) > 1 }, orderBy: "failures", desc: true},
+	{Name: "flaky", Description: "the scenarios which changed between passing and failing in the last runs, the most changing first",
+		entity: scenariosEntity, lastRuns: 20, matches: func(r Row) bool { return r["flips"].(int) > 0 }, orderBy: "flips", desc: true},
+	{Name: "slowest", Description: "the scenarios taking the longest on average", entity: scenariosEntity, limit: 10,
+		orderBy: "avg_duration", desc: true},
+}
+
+// Row is a run or scenario listed by a query, by field.
+type Row map[string]interface{}
+
+// QueryResult is the outcome of a query.
+type QueryResult struct {
+	entity string
+	Rows   []Row
+}
+
+// FindQuery gives the canned query of the name.
+func FindQuery(name string) (*Query, error) {
+	var names []string
+	for _, q := range Queries {
+		if strings.EqualFold(q.Name, name) {
+			return q, nil
+		}
+		names = append(names, q.Name)
+	}
+	return nil, fmt.Errorf("Unknown query %s. Possible queries are %s", name, strings.Join(names, ", "))
+}
+
+// Execute runs the query on the runs of the history, oldest first. The number of last runs queried and of rows listed
+// default to the ones of the query when 0.
+func (q *Query) Execute(runs []*RunResult, lastRuns, limit int) *QueryResult {
+	if lastRuns == 0 {
+		lastRuns = q.lastRuns
+	}
+	if limit == 0 {
+		limit = q.limit
+	}
+	if lastRuns > 0 && len(runs) > lastRuns {
+		runs = runs[len(runs)-lastRuns:]
+	}
+	var rows []Row
+	if q.entity == runsEntity {
+		rows = runRows(runs)
+	} else {
+		rows = scenarioRows(runs)
+	}
+	matching := make([]Row, 0)
+	for _, r := range rows {
+		if q.matches == nil || q.matches(r) {
+			matching = append(matching, r)
+		}
+	}
+	sort.SliceStable(matching, func(i, j int) bool {
+		if q.desc {
+			return less(matching[j][q.orderBy], matching[i][q.orderBy])
+		}
+		return less(matching[i][q.orderBy], matching[j][q.orderBy])
+	})
+	if limit > 0 && len(matching) > limit {
+		matching = matching[:limit]
+	}
+	return &QueryResult{entity: q.entity, Rows: matching}
+}
+
+func less(a, b interface{}) bool {
+	switch n := a.(type) {
+	case int:
+		return n < b.(int)
+	case int64:
+		return n < b.(int64)
+	}
+	return false
+}
+
+func runRows(runs []*RunResult) []Row {
+	rows := make([]Row, 0, len(runs))
+	for _, r := range runs {
+		passes, failures, skips := r.counts()
+		rows = append(rows, Row{"id": r.ID, "time": r.Time, "environment": r.Environment, "tags": r.Tags, "status": r.Status,
+			"duration": r.Duration, "scenarios": passes + failures + skips, "passes": passes, "failures": failures, "skips": skips})
+	}
+	return rows
+}
+
+// summary sums up the results of a scenario over the runs.
+type summary struct {
+	spec, name                 string
+	runs, passes, failures     int
+	skips, flips               int
+	totalDuration, maxDuration int64
+	lastStatus, lastError      string
+	lastExecuted               string
+}
+
+func (s *summary) add(status string, duration int64, err string) {
+	s.runs++
+	s.lastStatus = status
+	if status == skipped {
+		s.skips++
+		return
+	}
+	if status == failed {
+		s.failures++
+		s.lastError = err
+	} else {
+		s.passes++
+	}
+	if s.lastExecuted != "" && s.lastExecuted != status {
+		s.flips++
+	}
+	s.lastExecuted = status
+	s.totalDuration += duration
+	if duration > s.maxDuration {
+		s.maxDuration = duration
+	}
+}
+
+// scenarioRows sums up the scenarios over the runs. They are listed by spec, in the order of their first run.
+func scenarioRows(runs []*RunResult) []Row {
+	var summaries []*summary
+	byKey := make(map[string]*summary)
+	get := func(spec, name string) *summary {
+		key := spec + "\x00" + name
+		s, ok := byKey[key]
+		if !ok {
+			s = &summary{spec: spec, name: name}
+			byKey[key] = s
+			summaries = append(summaries, s)
+		}
+		return s
+	}
+	for _, r := range runs {
+		for _, spec := range r.Specs {
+			for _, sce := range spec.Scenarios {
+				get(spec.Spec, sce.Scenario).add(sce.Status, sce.Duration, sce.Error)
+			}
+		}
+	}
+	sort.SliceStable(summaries, func(i, j int) bool { return summaries[i].spec < summaries[j].spec })
+	rows := make([]Row, 0, len(summaries))
+	for _, s := range summaries {
+		executed := s.passes + s.failures
+		var rate float64
+		var average int64
+		if executed > 0 {
+			rate = math.Round(float64(s.failures)*1000/float64(executed)) / 10
+			average = s.totalDuration / int64(executed)
+		}
+		rows = append(rows, Row{"spec": s.spec, "scenario": s.name, "runs": s.runs, "passes": s.passes, "failures": s.failures, "skips": s.skips,
+			"failure_rate": rate, "flips": s.flips, "avg_duration": average, "max_duration": s.maxDuration, "last_status": s.lastStatus,
+			"last_error": s.lastError})
+	}
+	return rows
+}
+
+// Write writes the rows as a table, with a column for each field.
+func (r *QueryResult) Write(w io.Writer) error {
+	var columns []string
+	for _, f := range fields[r.entity] {
+		if !hiddenFields[f] {
+			columns = append(columns, f)
+		}
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(columns, "\t"))
+	for _, row := range r.Rows {
+		values := make([]string, len(columns))
+		for i, c := range columns {
+			switch v := row[c].(type) {
+			case float64:
+				values[i] = strconv.FormatFloat(v, 'f', 1, 64)
+			default:
+				values[i] = fmt.Sprintf("%v", v)
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	return tw.Flush()
+}
+
+// JSON gives the rows as JSON, with all the fields.
+func (r *QueryResult) JSON() ([]byte, error) {
+	return json.MarshalIndent(r.Rows, "", "\t")
+}
@@ -0,0 +1,91 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package history
+
+import (
+	"bytes"
+
+	. "gopkg.in/check.v1"
+)
+
+func queryRuns() []*RunResult {
+	run := func(id int, login, logout string) *RunResult {
+		return &RunResult{ID: id, Status: passed, Duration: int64(id * 100), Specs: []*SpecRun{
+			{Spec: "specs/login.spec", Heading: "Login", Status: login, Duration: 50, Scenarios: []*ScenarioRun{
+				{Scenario: "Valid login", Status: login, Duration: int64(id * 10), Error: map[bool]string{true: "timeout"}[login == failed]},
+				{Scenario: "Logout", Status: logout, Duration: 20},
+			}},
+		}}
+	}
+	return []*RunResult{run(1, failed, passed), run(2, passed, passed), run(3, failed, skipped), run(4, failed, passed)}
+}
+
+func (s *MySuite) TestQueryScenariosWithFailuresInLastRuns(c *C) {
+	q, err := FindQuery("failures")
+	c.Assert(err, IsNil)
+
+	res := q.Execute(queryRuns(), 3, 0)
+
+	c.Assert(res.Rows, DeepEquals, []Row{{"spec": "specs/login.spec", "scenario": "Valid login", "runs": 3, "passes": 1, "failures": 2,
+		"skips": 0, "failure_rate": 66.7, "flips": 1, "avg_duration": int64(30), "max_duration": int64(40), "last_status": failed, "last_error": "timeout"}})
+}
+
+func (s *MySuite) TestQueryFlakyScenariosOrderedByFlips(c *C) {
+	q, err := FindQuery("FLAKY")
+	c.Assert(err, IsNil)
+
+	res := q.Execute(queryRuns(), 0, 0)
+
+	c.Assert(len(res.Rows), Equals, 1)
+	c.Assert(res.Rows[0]["scenario"], Equals, "Valid login")
+	c.Assert(res.Rows[0]["flips"], Equals, 2)
+}
+
+func (s *MySuite) TestQueryRecentRunsWithLimit(c *C) {
+	q, err := FindQuery("recent")
+	c.Assert(err, IsNil)
+
+	res := q.Execute(queryRuns(), 0, 2)
+
+	var ids []interface{}
+	for _, r := range res.Rows {
+		ids = append(ids, r["id"])
+	}
+	c.Assert(ids, DeepEquals, []interface{}{4, 3})
+	c.Assert(res.Rows[1]["failures"], Equals, 1)
+	c.Assert(res.Rows[1]["skips"], Equals, 1)
+}
+
+func (s *MySuite) TestQuerySlowestScenarios(c *C) {
+	q, err := FindQuery("slowest")
+	c.Assert(err, IsNil)
+
+	res := q.Execute(queryRuns(), 0, 0)
+
+	b := &bytes.Buffer{}
+	c.Assert(res.Write(b), IsNil)
+	c.Assert(b.String(), Equals, "spec              scenario     runs  passes  failures  skips  failure_rate  flips  avg_duration  max_duration  last_status\n"+
+		"specs/login.spec  Valid login  4     1       3         0      75.0          2      25            40            failed\n"+
+		"specs/login.spec  Logout       4     3       0         1      0.0           0      20            20            passed\n")
+}
+
+func (s *MySuite) TestFindUnknownQuery(c *C) {
+	_, err := FindQuery("scenarios where failures > 3")
+
+	c.Assert(err, ErrorMatches, "Unknown query scenarios where failures > 3. Possible queries are recent, failing, failures, flaky, slowest")
+}
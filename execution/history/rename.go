@@ -0,0 +1,126 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package history
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+)
+
+// RenameSpecs gives the new contents of the spec durations and the results history, with the paths of the specs
+// renamed by rename, keyed by file. rename is given the paths relative to the project root, with forward slashes,
+// and tells whether the spec was renamed. Files which do not refer to a renamed spec are left out.
+func RenameSpecs(rename func(string) (string, bool)) (map[string][]byte, error) {
+	contents := make(map[string][]byte)
+	durations := SpecDurations()
+	renamed := make(map[string]int64)
+	changed := false
+	for spec, d := range durations {
+		if to, ok := rename(filepath.ToSlash(spec)); ok {
+			spec, changed = filepath.FromSlash(to), true
+		}
+		renamed[spec] = d
+	}
+	if changed {
+		b, err := json.MarshalIndent(renamed, "", "\t")
+		if err != nil {
+			return nil, err
+		}
+		contents[filepath.Join(config.ProjectRoot, common.DotGauge, specDurationsFile)] = b
+	}
+
+	if common.FileExists(runsFilePath()) {
+		b, err := renameRunSpecs(rename)
+		if err != nil {
+			return nil, err
+		}
+		if b != nil {
+			contents[runsFilePath()] = b
+		}
+	}
+	return contents, nil
+}
+
+// renameRunSpecs renames the specs in a copy of the results history, and gives the contents of the copy. It is nil if
+// no spec in the history was renamed.
+func renameRunSpecs(rename func(string) (string, bool)) ([]byte, error) {
+	original, err := ioutil.ReadFile(runsFilePath())
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(runsFilePath()), runsFile)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(original)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	changed, err := renameSpecsIn(tmp.Name(), rename)
+	if err != nil || !changed {
+		return nil, err
+	}
+	return ioutil.ReadFile(tmp.Name())
+}
+
+func renameSpecsIn(file string, rename func(string) (string, bool)) (bool, error) {
+	db, err := openRuns(file)
+	if err != nil {
+		return false, err
+	}
+	defer db.Close()
+	rows, err := db.Query("SELECT DISTINCT spec FROM specs")
+	if err != nil {
+		return false, err
+	}
+	renamed := make(map[string]string)
+	for rows.Next() {
+		var spec string
+		if err := rows.Scan(&spec); err != nil {
+			rows.Close()
+			return false, err
+		}
+		if to, ok := rename(spec); ok {
+			renamed[spec] = to
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil || len(renamed) == 0 {
+		return false, err
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return false, err
+	}
+	for from, to := range renamed {
+		if _, err := tx.Exec("UPDATE specs SET spec = ? WHERE spec = ?", to, from); err != nil {
+			tx.Rollback()
+			return false, err
+		}
+	}
+	return true, tx.Commit()
+}
@@ -0,0 +1,63 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package history
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestRenameSpecs(c *C) {
+	writeSpecDurations(map[string]int64{filepath.Join("specs", "a.spec"): 100, filepath.Join("specs", "b.spec"): 200})
+	c.Assert(addRun(&RunResult{Specs: []*SpecRun{{Spec: "specs/a.spec"}, {Spec: "specs/b.spec"}}}, 5), IsNil)
+	rename := func(p string) (string, bool) {
+		return strings.Replace(p, "a.spec", "login.spec", 1), p == "specs/a.spec"
+	}
+
+	contents, err := RenameSpecs(rename)
+
+	c.Assert(err, IsNil)
+	c.Assert(contents, HasLen, 2)
+	durations := make(map[string]int64)
+	c.Assert(json.Unmarshal(contents[filepath.Join(config.ProjectRoot, common.DotGauge, specDurationsFile)], &durations), IsNil)
+	c.Assert(durations, DeepEquals, map[string]int64{filepath.Join("specs", "login.spec"): 100, filepath.Join("specs", "b.spec"): 200})
+	renamed := filepath.Join(c.MkDir(), runsFile)
+	c.Assert(ioutil.WriteFile(renamed, contents[runsFilePath()], 0644), IsNil)
+	runs, err := readRuns(renamed)
+	c.Assert(err, IsNil)
+	c.Assert(runs[0].Specs[0].Spec, Equals, "specs/login.spec")
+	c.Assert(runs[0].Specs[1].Spec, Equals, "specs/b.spec")
+	runs, err = Runs()
+	c.Assert(err, IsNil)
+	c.Assert(runs[0].Specs[0].Spec, Equals, "specs/a.spec")
+}
+
+func (s *MySuite) TestRenameSpecsWithoutReferences(c *C) {
+	writeSpecDurations(map[string]int64{filepath.Join("specs", "b.spec"): 200})
+
+	contents, err := RenameSpecs(func(p string) (string, bool) { return p, false })
+
+	c.Assert(err, IsNil)
+	c.Assert(contents, HasLen, 0)
+}
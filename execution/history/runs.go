@@ -0,0 +1,322 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package history
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/util"
+	// registers the sqlite3 driver of database/sql, in which the results history is kept
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const runsFile = "runs.db"
+
+const (
+	passed  = "passed"
+	failed  = "failed"
+	skipped = "skipped"
+)
+
+// RunResult is the result of an execution, as kept in the results history.
+type RunResult struct {
+	ID          int        `json:"id"`
+	Time        string     `json:"time"`
+	Environment string     `json:"environment,omitempty"`
+	Tags        string     `json:"tags,omitempty"`
+	Status      string     `json:"status"`
+	Duration    int64      `json:"durationMs"`
+	Specs       []*SpecRun `json:"specs"`
+}
+
+// SpecRun is the result of a spec in a run.
+type SpecRun struct {
+	Spec      string         `json:"spec"`
+	Heading   string         `json:"heading"`
+	Status    string         `json:"status"`
+	Duration  int64          `json:"durationMs"`
+	Scenarios []*ScenarioRun `json:"scenarios"`
+}
+
+// ScenarioRun is the result of a scenario in a run. The scenarios of a data table are told apart by their row.
+type ScenarioRun struct {
+	Scenario string `json:"scenario"`
+	Status   string `json:"status"`
+	Duration int64  `json:"durationMs"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ListenRuns listens to the suite end event and adds the results of the run to the results history,
+// dropping the oldest runs beyond the results_history_size property.
+func ListenRuns(wg *sync.WaitGroup) {
+	size := env.ResultsHistorySize()
+	if size == 0 {
+		return
+	}
+	ch := make(chan event.ExecutionEvent, 0)
+	event.Register(ch, event.SuiteEnd)
+	wg.Add(1)
+
+	go func() {
+		for {
+			e := <-ch
+			if e.Topic == event.SuiteEnd {
+				if err := addRun(newRun(e.Result.(*result.SuiteResult)), size); err != nil {
+					logger.Errorf(true, "Failed to add the results to the history. %s", err.Error())
+				}
+				wg.Done()
+			}
+		}
+	}()
+}
+
+func newRun(res *result.SuiteResult) *RunResult {
+	r := &RunResult{Time: time.Now().Format(time.RFC3339), Environment: res.Environment, Tags: res.Tags,
+		Status: status(res.IsFailed, false), Duration: res.ExecutionTime}
+	res.EachSpecResult(func(specRes *result.SpecResult) {
+		s := &SpecRun{
+			Spec:     filepath.ToSlash(util.RelPathToProjectRoot(specRes.ProtoSpec.GetFileName())),
+			Heading:  specRes.ProtoSpec.GetSpecHeading(),
+			Status:   status(specRes.IsFailed, specRes.Skipped),
+			Duration: specRes.ExecutionTime,
+		}
+		for _, item := range specRes.ProtoSpec.GetItems() {
+			switch item.GetItemType() {
+			case gauge_messages.ProtoItem_Scenario:
+				s.Scenarios = append(s.Scenarios, newScenarioRun(item.GetScenario(), item.GetScenario().GetScenarioHeading()))
+			case gauge_messages.ProtoItem_TableDrivenScenario:
+				tds := item.GetTableDrivenScenario()
+				name := tds.GetScenario().GetScenarioHeading()
+				if tds.GetIsSpecTableDriven() {
+					name = fmt.Sprintf("%s [row %d]", name, tds.GetTableRowIndex()+1)
+				}
+				if tds.GetIsScenarioTableDriven() {
+					name = fmt.Sprintf("%s [scenario row %d]", name, tds.GetScenarioTableRowIndex()+1)
+				}
+				s.Scenarios = append(s.Scenarios, newScenarioRun(tds.GetScenario(), name))
+			}
+		}
+		r.Specs = append(r.Specs, s)
+	})
+	return r
+}
+
+func newScenarioRun(sce *gauge_messages.ProtoScenario, name string) *ScenarioRun {
+	st := sce.GetExecutionStatus()
+	s := &ScenarioRun{Scenario: name, Status: status(st == gauge_messages.ExecutionStatus_FAILED, st == gauge_messages.ExecutionStatus_SKIPPED),
+		Duration: sce.GetExecutionTime()}
+	if st == gauge_messages.ExecutionStatus_FAILED {
+		s.Error = result.NewScenarioResult(sce).FailureMessage()
+	}
+	return s
+}
+
+func status(isFailed, isSkipped bool) string {
+	if isFailed {
+		return failed
+	}
+	if isSkipped {
+		return skipped
+	}
+	return passed
+}
+
+func runsFilePath() string {
+	return filepath.Join(config.ProjectRoot, common.DotGauge, runsFile)
+}
+
+// schema creates the tables of the results history. The specs of a run and the scenarios of a spec are numbered in
+// the order in which they are listed in the run.
+const schema = `
+CREATE TABLE IF NOT EXISTS runs (
+	id INTEGER PRIMARY KEY,
+	time TEXT NOT NULL,
+	environment TEXT NOT NULL,
+	tags TEXT NOT NULL,
+	status TEXT NOT NULL,
+	duration INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS specs (
+	run INTEGER NOT NULL,
+	number INTEGER NOT NULL,
+	spec TEXT NOT NULL,
+	heading TEXT NOT NULL,
+	status TEXT NOT NULL,
+	duration INTEGER NOT NULL,
+	PRIMARY KEY (run, number)
+);
+CREATE TABLE IF NOT EXISTS scenarios (
+	run INTEGER NOT NULL,
+	spec INTEGER NOT NULL,
+	number INTEGER NOT NULL,
+	scenario TEXT NOT NULL,
+	status TEXT NOT NULL,
+	duration INTEGER NOT NULL,
+	error TEXT NOT NULL,
+	PRIMARY KEY (run, spec, number)
+);
+CREATE INDEX IF NOT EXISTS specs_spec ON specs (spec);
+`
+
+// openRuns opens the results history in the file, creating it if it does not exist.
+func openRuns(file string) (*sql.DB, error) {
+	if err := os.MkdirAll(filepath.Dir(file), common.NewDirectoryPermissions); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite3", file)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("Failed to create the results history in %s. %s", file, err.Error())
+	}
+	return db, nil
+}
+
+// addRun adds the run to the history, keeping the last size runs.
+func addRun(r *RunResult, size int) error {
+	db, err := openRuns(runsFilePath())
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := insertRun(tx, r, size); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertRun(tx *sql.Tx, r *RunResult, size int) error {
+	res, err := tx.Exec("INSERT INTO runs (time, environment, tags, status, duration) VALUES (?, ?, ?, ?, ?)",
+		r.Time, r.Environment, r.Tags, r.Status, r.Duration)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	r.ID = int(id)
+	for i, s := range r.Specs {
+		if _, err := tx.Exec("INSERT INTO specs (run, number, spec, heading, status, duration) VALUES (?, ?, ?, ?, ?, ?)",
+			id, i, s.Spec, s.Heading, s.Status, s.Duration); err != nil {
+			return err
+		}
+		for j, sce := range s.Scenarios {
+			if _, err := tx.Exec("INSERT INTO scenarios (run, spec, number, scenario, status, duration, error) VALUES (?, ?, ?, ?, ?, ?, ?)",
+				id, i, j, sce.Scenario, sce.Status, sce.Duration, sce.Error); err != nil {
+				return err
+			}
+		}
+	}
+	for _, table := range []string{"scenarios", "specs"} {
+		if _, err := tx.Exec("DELETE FROM "+table+" WHERE run <= ?", id-int64(size)); err != nil {
+			return err
+		}
+	}
+	_, err = tx.Exec("DELETE FROM runs WHERE id <= ?", id-int64(size))
+	return err
+}
+
+// Runs reads the results history, oldest run first. It is empty if no runs have been recorded.
+func Runs() ([]*RunResult, error) {
+	if !common.FileExists(runsFilePath()) {
+		return nil, nil
+	}
+	return readRuns(runsFilePath())
+}
+
+func readRuns(file string) ([]*RunResult, error) {
+	db, err := openRuns(file)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	var runs []*RunResult
+	byID := make(map[int]*RunResult)
+	rows, err := db.Query("SELECT id, time, environment, tags, status, duration FROM runs ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		r := &RunResult{}
+		if err := rows.Scan(&r.ID, &r.Time, &r.Environment, &r.Tags, &r.Status, &r.Duration); err != nil {
+			return nil, err
+		}
+		runs = append(runs, r)
+		byID[r.ID] = r
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	specs, err := db.Query("SELECT run, spec, heading, status, duration FROM specs ORDER BY run, number")
+	if err != nil {
+		return nil, err
+	}
+	defer specs.Close()
+	for specs.Next() {
+		var run int
+		s := &SpecRun{}
+		if err := specs.Scan(&run, &s.Spec, &s.Heading, &s.Status, &s.Duration); err != nil {
+			return nil, err
+		}
+		if r, ok := byID[run]; ok {
+			r.Specs = append(r.Specs, s)
+		}
+	}
+	if err := specs.Err(); err != nil {
+		return nil, err
+	}
+
+	scenarios, err := db.Query("SELECT run, spec, scenario, status, duration, error FROM scenarios ORDER BY run, spec, number")
+	if err != nil {
+		return nil, err
+	}
+	defer scenarios.Close()
+	for scenarios.Next() {
+		var run, spec int
+		sce := &ScenarioRun{}
+		if err := scenarios.Scan(&run, &spec, &sce.Scenario, &sce.Status, &sce.Duration, &sce.Error); err != nil {
+			return nil, err
+		}
+		if r, ok := byID[run]; ok && spec < len(r.Specs) {
+			r.Specs[spec].Scenarios = append(r.Specs[spec].Scenarios, sce)
+		}
+	}
+	return runs, scenarios.Err()
+}
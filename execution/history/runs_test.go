@@ -0,0 +1,96 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package history
+
+import (
+	"path/filepath"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge_messages"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestNewRunHasTheResultOfEachScenario(c *C) {
+	failing := &gauge_messages.ProtoScenario{ScenarioHeading: "Login fails", ExecutionStatus: gauge_messages.ExecutionStatus_FAILED, ExecutionTime: 20,
+		ScenarioItems: []*gauge_messages.ProtoItem{{ItemType: gauge_messages.ProtoItem_Step, Step: &gauge_messages.ProtoStep{ActualText: "Login",
+			StepExecutionResult: &gauge_messages.ProtoStepExecutionResult{ExecutionResult: &gauge_messages.ProtoExecutionResult{Failed: true, ErrorMessage: "timeout"}}}}}}
+	row := &gauge_messages.ProtoScenario{ScenarioHeading: "Login works", ExecutionStatus: gauge_messages.ExecutionStatus_PASSED, ExecutionTime: 10}
+	res := &result.SuiteResult{IsFailed: true, ExecutionTime: 30, Environment: "ci", SpecResults: []*result.SpecResult{
+		{ProtoSpec: &gauge_messages.ProtoSpec{SpecHeading: "Login", FileName: filepath.Join(config.ProjectRoot, "specs", "login.spec"), Items: []*gauge_messages.ProtoItem{
+			{ItemType: gauge_messages.ProtoItem_Scenario, Scenario: failing},
+			{ItemType: gauge_messages.ProtoItem_TableDrivenScenario, TableDrivenScenario: &gauge_messages.ProtoTableDrivenScenario{Scenario: row, IsSpecTableDriven: true, TableRowIndex: 1}},
+		}}, IsFailed: true, ExecutionTime: 30},
+		{ProtoSpec: &gauge_messages.ProtoSpec{SpecHeading: "Cart", FileName: filepath.Join(config.ProjectRoot, "specs", "cart.spec")}, Skipped: true},
+	}}
+
+	r := newRun(res)
+
+	c.Assert(r.Status, Equals, failed)
+	c.Assert(r.Environment, Equals, "ci")
+	c.Assert(r.Duration, Equals, int64(30))
+	c.Assert(r.Specs[0].Scenarios[0].Error, Matches, "(?s).*timeout.*")
+	r.Specs[0].Scenarios[0].Error = ""
+	c.Assert(r.Specs, DeepEquals, []*SpecRun{
+		{Spec: "specs/login.spec", Heading: "Login", Status: failed, Duration: 30, Scenarios: []*ScenarioRun{
+			{Scenario: "Login fails", Status: failed, Duration: 20},
+			{Scenario: "Login works [row 2]", Status: passed, Duration: 10},
+		}},
+		{Spec: "specs/cart.spec", Heading: "Cart", Status: skipped},
+	})
+}
+
+func (s *MySuite) TestAddRunKeepsTheLastRuns(c *C) {
+	for i := 0; i < 3; i++ {
+		c.Assert(addRun(&RunResult{Status: passed}, 2), IsNil)
+	}
+
+	runs, err := Runs()
+
+	c.Assert(err, IsNil)
+	c.Assert(len(runs), Equals, 2)
+	c.Assert(runs[0].ID, Equals, 2)
+	c.Assert(runs[1].ID, Equals, 3)
+}
+
+func (s *MySuite) TestRunsReadsBackTheAddedRuns(c *C) {
+	run := &RunResult{Time: "2018-07-02T10:00:00Z", Environment: "ci", Tags: "smoke", Status: failed, Duration: 30, Specs: []*SpecRun{
+		{Spec: "specs/login.spec", Heading: "Login", Status: failed, Duration: 30, Scenarios: []*ScenarioRun{
+			{Scenario: "Login fails", Status: failed, Duration: 20, Error: "timeout"},
+			{Scenario: "Login works", Status: passed, Duration: 10},
+		}},
+		{Spec: "specs/cart.spec", Heading: "Cart", Status: skipped},
+	}}
+	c.Assert(addRun(&RunResult{Status: passed}, 5), IsNil)
+	c.Assert(addRun(run, 5), IsNil)
+
+	runs, err := Runs()
+
+	c.Assert(err, IsNil)
+	c.Assert(len(runs), Equals, 2)
+	c.Assert(runs[0].Specs, HasLen, 0)
+	c.Assert(runs[1], DeepEquals, run)
+	c.Assert(run.ID, Equals, 2)
+}
+
+func (s *MySuite) TestRunsIsEmptyWithoutPreviousRuns(c *C) {
+	runs, err := Runs()
+
+	c.Assert(err, IsNil)
+	c.Assert(len(runs), Equals, 0)
+}
@@ -0,0 +1,96 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package history
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/getgauge/gauge/logger"
+)
+
+// RecentRuns is the number of the latest runs in which a failure counts as recent.
+const RecentRuns = 5
+
+// ScenarioKey identifies a scenario across runs by the path of its spec relative to the project root and its heading.
+// The rows of a data table driven scenario share the key of the scenario.
+func ScenarioKey(spec, scenario string) string {
+	for _, suffix := range []string{" [row ", " [scenario row "} {
+		if i := strings.Index(scenario, suffix); i >= 0 {
+			scenario = scenario[:i]
+		}
+	}
+	return filepath.ToSlash(spec) + "\x00" + strings.ToLower(strings.TrimSpace(scenario))
+}
+
+// RecentFailures gives the keys of the scenarios that failed in any of the last n runs.
+func RecentFailures(runs []*RunResult, n int) map[string]bool {
+	failures := make(map[string]bool)
+	for _, r := range Recent(runs, n) {
+		for _, s := range r.Specs {
+			for _, sce := range s.Scenarios {
+				if sce.Status == failed {
+					failures[ScenarioKey(s.Spec, sce.Scenario)] = true
+				}
+			}
+		}
+	}
+	return failures
+}
+
+// FailedRecently gives the keys of the scenarios that failed in the last RecentRuns runs. It is empty if the history cannot be read.
+var FailedRecently = func() map[string]bool {
+	runs, err := Runs()
+	if err != nil {
+		logger.Debugf(true, "Failed to read the results history. %s", err.Error())
+	}
+	return RecentFailures(runs, RecentRuns)
+}
+
+// ScenarioDurations gives the duration in milliseconds of the scenarios as of the latest run which executed them, keyed by ScenarioKey.
+// It is empty if the history cannot be read.
+var ScenarioDurations = func() map[string]int64 {
+	runs, err := Runs()
+	if err != nil {
+		logger.Debugf(true, "Failed to read the results history. %s", err.Error())
+	}
+	return scenarioDurations(runs)
+}
+
+// scenarioDurations gives the latest durations of the scenarios in the runs. The duration of a data table driven scenario
+// is the average of its rows. Skipped scenarios are not taken into account.
+func scenarioDurations(runs []*RunResult) map[string]int64 {
+	durations := make(map[string]int64)
+	for _, r := range Recent(runs, 0) {
+		total, count := make(map[string]int64), make(map[string]int64)
+		for _, s := range r.Specs {
+			for _, sce := range s.Scenarios {
+				key := ScenarioKey(s.Spec, sce.Scenario)
+				if _, ok := durations[key]; ok || sce.Status == skipped {
+					continue
+				}
+				total[key] += sce.Duration
+				count[key]++
+			}
+		}
+		for key, t := range total {
+			durations[key] = t / count[key]
+		}
+	}
+	return durations
+}
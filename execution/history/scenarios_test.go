@@ -0,0 +1,48 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package history
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestScenarioKeySharedByDataTableRows(c *C) {
+	c.Assert(ScenarioKey("specs/a.spec", "Login [row 2]"), Equals, ScenarioKey("specs/a.spec", "login"))
+	c.Assert(ScenarioKey("specs/a.spec", "Login [scenario row 1]"), Equals, ScenarioKey("specs/a.spec", "Login"))
+	c.Assert(ScenarioKey("specs/a.spec", "Login"), Not(Equals), ScenarioKey("specs/b.spec", "Login"))
+}
+
+func (s *MySuite) TestRecentFailuresInTheLastRuns(c *C) {
+	run := func(status string) *RunResult {
+		return &RunResult{Specs: []*SpecRun{{Spec: "a.spec", Scenarios: []*ScenarioRun{{Scenario: "Login [row 1]", Status: status}}}}}
+	}
+	runs := []*RunResult{run(failed), run(passed), run(passed)}
+
+	c.Assert(RecentFailures(runs, 2), DeepEquals, map[string]bool{})
+	c.Assert(RecentFailures(runs, 3), DeepEquals, map[string]bool{ScenarioKey("a.spec", "Login"): true})
+}
+
+func (s *MySuite) TestScenarioDurationsFromTheLatestRunExecutingThem(c *C) {
+	runs := []*RunResult{
+		{Specs: []*SpecRun{{Spec: "a.spec", Scenarios: []*ScenarioRun{{Scenario: "Login", Status: passed, Duration: 100}, {Scenario: "Search", Status: passed, Duration: 50}}}}},
+		{Specs: []*SpecRun{{Spec: "a.spec", Scenarios: []*ScenarioRun{{Scenario: "Login [row 1]", Status: passed, Duration: 10}, {Scenario: "Login [row 2]", Status: failed, Duration: 30},
+			{Scenario: "Search", Status: skipped}}}}},
+	}
+
+	c.Assert(scenarioDurations(runs), DeepEquals, map[string]int64{ScenarioKey("a.spec", "Login"): 20, ScenarioKey("a.spec", "Search"): 50})
+}
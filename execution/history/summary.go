@@ -0,0 +1,123 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package history
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// ScenarioOutcome is the outcome of a scenario in a run.
+type ScenarioOutcome struct {
+	Run  int    `json:"run"`
+	Time string `json:"time"`
+	Spec string `json:"spec"`
+	*ScenarioRun
+}
+
+// counts gives the number of scenarios passed, failed and skipped in the run.
+func (r *RunResult) counts() (passes, failures, skips int) {
+	for _, s := range r.Specs {
+		for _, sce := range s.Scenarios {
+			switch sce.Status {
+			case passed:
+				passes++
+			case failed:
+				failures++
+			default:
+				skips++
+			}
+		}
+	}
+	return
+}
+
+// Recent gives the last n runs of the history, the latest first.
+func Recent(runs []*RunResult, n int) []*RunResult {
+	if n > 0 && len(runs) > n {
+		runs = runs[len(runs)-n:]
+	}
+	recent := make([]*RunResult, 0, len(runs))
+	for i := len(runs) - 1; i >= 0; i-- {
+		recent = append(recent, runs[i])
+	}
+	return recent
+}
+
+// Timeline gives the outcomes of the scenario in the runs, in their order. The scenario is matched by its heading,
+// ignoring case, and matches all the rows of a data table. Scenarios of the same heading in different specs are all given.
+func Timeline(runs []*RunResult, scenario string) []*ScenarioOutcome {
+	name := strings.ToLower(strings.TrimSpace(scenario))
+	outcomes := make([]*ScenarioOutcome, 0)
+	for _, r := range runs {
+		for _, s := range r.Specs {
+			for _, sce := range s.Scenarios {
+				heading := strings.ToLower(sce.Scenario)
+				if heading == name || strings.HasPrefix(heading, name+" [") {
+					outcomes = append(outcomes, &ScenarioOutcome{Run: r.ID, Time: r.Time, Spec: s.Spec, ScenarioRun: sce})
+				}
+			}
+		}
+	}
+	return outcomes
+}
+
+// WriteRuns writes a table of the runs, with the number of scenarios passed, failed and skipped in each.
+func WriteRuns(w io.Writer, runs []*RunResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Run\tTime\tEnvironment\tTags\tStatus\tDuration\tPassed\tFailed\tSkipped")
+	for _, r := range runs {
+		passes, failures, skips := r.counts()
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%d\t%d\t%d\n", r.ID, r.Time, r.Environment, r.Tags, r.Status, duration(r.Duration), passes, failures, skips)
+	}
+	return tw.Flush()
+}
+
+// WriteTimeline writes a table of the outcomes of a scenario, with the error of each failure shortened to a line.
+func WriteTimeline(w io.Writer, outcomes []*ScenarioOutcome) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Run\tTime\tSpec\tScenario\tStatus\tDuration\tError")
+	for _, o := range outcomes {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", o.Run, o.Time, o.Spec, o.Scenario, o.Status, duration(o.Duration), oneLine(o.Error))
+	}
+	return tw.Flush()
+}
+
+func duration(ms int64) time.Duration {
+	return time.Duration(ms) * time.Millisecond
+}
+
+// maxErrorLength is the number of characters of an error shown in a table.
+const maxErrorLength = 100
+
+func oneLine(s string) string {
+	var lines []string
+	for _, l := range strings.Split(s, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			lines = append(lines, l)
+		}
+	}
+	line := []rune(strings.Join(lines, " "))
+	if len(line) > maxErrorLength {
+		return string(line[:maxErrorLength-3]) + "..."
+	}
+	return string(line)
+}
@@ -0,0 +1,68 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package history
+
+import (
+	"bytes"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestRecentGivesTheLastRunsLatestFirst(c *C) {
+	runs := queryRuns()
+
+	recent := Recent(runs, 2)
+
+	c.Assert(recent, DeepEquals, []*RunResult{runs[3], runs[2]})
+	c.Assert(len(Recent(runs, 10)), Equals, 4)
+}
+
+func (s *MySuite) TestTimelineMatchesScenarioHeadingIgnoringCase(c *C) {
+	runs := []*RunResult{{ID: 1, Time: "t1", Specs: []*SpecRun{{Spec: "specs/a.spec", Scenarios: []*ScenarioRun{
+		{Scenario: "Login [row 1]", Status: passed},
+		{Scenario: "Login [row 2]", Status: failed},
+		{Scenario: "Login twice", Status: passed},
+	}}}}}
+
+	outcomes := Timeline(runs, " login ")
+
+	c.Assert(len(outcomes), Equals, 2)
+	c.Assert(*outcomes[1], DeepEquals, ScenarioOutcome{Run: 1, Time: "t1", Spec: "specs/a.spec", ScenarioRun: runs[0].Specs[0].Scenarios[1]})
+}
+
+func (s *MySuite) TestWriteRunsWithCounts(c *C) {
+	b := &bytes.Buffer{}
+
+	c.Assert(WriteRuns(b, []*RunResult{{ID: 3, Time: "2020-01-01T10:00:00Z", Environment: "ci", Tags: "smoke", Status: failed, Duration: 1500,
+		Specs: queryRuns()[2].Specs}}), IsNil)
+
+	c.Assert(b.String(), Equals, "Run  Time                  Environment  Tags   Status  Duration  Passed  Failed  Skipped\n"+
+		"3    2020-01-01T10:00:00Z  ci           smoke  failed  1.5s      0       1       1\n")
+}
+
+func (s *MySuite) TestWriteTimelineShortensErrors(c *C) {
+	b := &bytes.Buffer{}
+	long := "Failed Step: Login\n" + strings.Repeat("x", 200)
+
+	c.Assert(WriteTimeline(b, []*ScenarioOutcome{{Run: 1, Time: "t1", Spec: "specs/a.spec", ScenarioRun: &ScenarioRun{Scenario: "Login", Status: failed, Duration: 20, Error: long}}}), IsNil)
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	c.Assert(len(lines), Equals, 2)
+	c.Assert(strings.HasSuffix(lines[1], "Failed Step: Login "+strings.Repeat("x", maxErrorLength-22)+"..."), Equals, true)
+}
@@ -0,0 +1,61 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/getgauge/gauge/logger"
+)
+
+var exit = logger.Exit
+
+// watchInterrupts stops the execution on the first SIGINT or SIGTERM, and exits right away on the next one.
+// An interrupt from the terminal also reaches the runners, which may stop before running the after hooks.
+// It returns a function to stop watching.
+func watchInterrupts() func() {
+	signals := make(chan os.Signal, 2)
+	done := make(chan bool)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		for {
+			select {
+			case s := <-signals:
+				onInterrupt(s)
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+func onInterrupt(s os.Signal) {
+	if stopExecution("Execution interrupted by "+s.String(), Interrupted) {
+		return
+	}
+	logger.Errorf(true, "Interrupted again, exiting without waiting for the execution to complete.")
+	killTrackedRunners()
+	stopFixtures()
+	exit(Interrupted)
+}
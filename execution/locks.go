@@ -0,0 +1,80 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/logger"
+)
+
+// LocksKey is the metadata naming the resources a scenario holds while it executes, e.g. locks=database, smtp.
+// No two scenarios holding the same resource execute at the same time. The locks of a spec are held by all of its scenarios.
+const LocksKey = "locks"
+
+type resourceLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+var scenarioLocks = &resourceLocks{locks: make(map[string]*sync.Mutex)}
+
+// lockNames gives the resources held by a scenario of a spec, sorted so that they are always locked in the same order.
+func lockNames(spec, scenario *gauge.Meta) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, m := range []*gauge.Meta{spec, scenario} {
+		v, _ := m.Get(LocksKey)
+		for _, name := range strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// acquire waits until all the resources are free and holds them. It returns a function to release them.
+func (r *resourceLocks) acquire(names []string) func() {
+	var held []*sync.Mutex
+	for _, name := range names {
+		l := r.lock(name)
+		logger.Debugf(true, "Acquiring lock %s.", name)
+		l.Lock()
+		held = append(held, l)
+	}
+	return func() {
+		for i := len(held) - 1; i >= 0; i-- {
+			held[i].Unlock()
+		}
+	}
+}
+
+func (r *resourceLocks) lock(name string) *sync.Mutex {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.locks[name] == nil {
+		r.locks[name] = &sync.Mutex{}
+	}
+	return r.locks[name]
+}
@@ -0,0 +1,80 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"sync"
+	"time"
+
+	"github.com/getgauge/gauge/gauge"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestLockNamesOfSpecAndScenario(c *C) {
+	spec, _ := gauge.NewMeta(1, "locks=smtp, database")
+	scenario, _ := gauge.NewMeta(3, "locks=database queue", "owner=team-a")
+
+	c.Assert(lockNames(spec, scenario), DeepEquals, []string{"database", "queue", "smtp"})
+	c.Assert(lockNames(nil, nil), IsNil)
+}
+
+func (s *MySuite) TestScenariosHoldingTheSameLockDoNotRunTogether(c *C) {
+	locks := &resourceLocks{locks: make(map[string]*sync.Mutex)}
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	var wg sync.WaitGroup
+	for _, names := range [][]string{{"database"}, {"database", "smtp"}, {"database"}} {
+		wg.Add(1)
+		go func(names []string) {
+			defer wg.Done()
+			release := locks.acquire(names)
+			defer release()
+			mu.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}(names)
+	}
+	wg.Wait()
+
+	c.Assert(maxRunning, Equals, 1)
+}
+
+func (s *MySuite) TestScenariosHoldingOtherLocksRunTogether(c *C) {
+	locks := &resourceLocks{locks: make(map[string]*sync.Mutex)}
+	release := locks.acquire([]string{"database"})
+	defer release()
+	acquired := make(chan bool)
+
+	go func() {
+		locks.acquire([]string{"smtp"})()
+		acquired <- true
+	}()
+
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		c.Fatal("lock on smtp waited for database")
+	}
+}
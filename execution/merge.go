@@ -24,6 +24,7 @@ import (
 
 	"github.com/getgauge/gauge/execution/result"
 	m "github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
 )
 
 func mergeDataTableSpecResults(sResult *result.SuiteResult) *result.SuiteResult {
@@ -37,6 +38,7 @@ func mergeDataTableSpecResults(sResult *result.SuiteResult) *result.SuiteResult
 	suiteRes.ProjectName = sResult.ProjectName
 	suiteRes.Environment = sResult.Environment
 	suiteRes.Tags = sResult.Tags
+	suiteRes.Metadata = sResult.Metadata
 	suiteRes.PreHookMessages = append(suiteRes.PreHookMessages, sResult.PreHookMessages...)
 	suiteRes.PostHookMessages = append(suiteRes.PostHookMessages, sResult.PostHookMessages...)
 	suiteRes.PreHookScreenshots = append(suiteRes.PreHookScreenshots, sResult.PreHookScreenshots...)
@@ -49,7 +51,7 @@ func mergeDataTableSpecResults(sResult *result.SuiteResult) *result.SuiteResult
 	for _, res := range combinedResults {
 		mergedRes := res[0]
 		if len(res) > 1 {
-			mergedRes = mergeResults(res)
+			mergedRes = mergeSpilledResults(res)
 		}
 		if mergedRes.GetFailed() {
 			suiteRes.SpecsFailedCount++
@@ -61,47 +63,100 @@ func mergeDataTableSpecResults(sResult *result.SuiteResult) *result.SuiteResult
 	return suiteRes
 }
 
-func mergeResults(results []*result.SpecResult) *result.SpecResult {
-	specResult := &result.SpecResult{ProtoSpec: &m.ProtoSpec{IsTableDriven: true}}
-	var scnResults []*m.ProtoItem
-	table := &m.ProtoTable{}
-	dataTableScnResults := make(map[string][]*m.ProtoTableDrivenScenario)
-	max := results[0].ExecutionTime
+// mergeSpilledResults merges the results of the rows of a data table, reading their items if they were spilled.
+// The rows are read one at a time and the merged result is spilled in turn.
+func mergeSpilledResults(results []*result.SpecResult) *result.SpecResult {
+	store := results[0].SpillStore()
+	if store == nil {
+		return mergeResults(results)
+	}
+	r := newRowsMerger()
 	for _, res := range results {
-		specResult.ExecutionTime += res.ExecutionTime
-		specResult.Errors = res.Errors
-		if res.ExecutionTime > max {
-			max = res.ExecutionTime
+		l, err := res.Load()
+		if err != nil {
+			logger.Errorf(true, "%s", err.Error())
+			l = res
 		}
-		if res.GetFailed() {
-			specResult.IsFailed = true
-		}
-		for _, item := range res.ProtoSpec.Items {
-			switch item.ItemType {
-			case m.ProtoItem_Scenario:
-				scnResults = append(scnResults, item)
-				modifySpecStats(item.Scenario, specResult)
-			case m.ProtoItem_TableDrivenScenario:
-				scnResults = append(scnResults, item)
-				heading := item.TableDrivenScenario.Scenario.ScenarioHeading
-				item.TableDrivenScenario.TableRowIndex = int32(len(table.Rows) - 1)
-				dataTableScnResults[heading] = append(dataTableScnResults[heading], item.TableDrivenScenario)
-			case m.ProtoItem_Table:
-				table.Headers = item.Table.Headers
-				table.Rows = append(table.Rows, item.Table.Rows...)
-			}
+		r.add(l)
+	}
+	merged := r.merged()
+	spilled, err := store.Spill(merged)
+	if err != nil {
+		logger.Debugf(true, "Holding result in memory. %s", err.Error())
+		return merged
+	}
+	return spilled
+}
+
+func mergeResults(results []*result.SpecResult) *result.SpecResult {
+	r := newRowsMerger()
+	for _, res := range results {
+		r.add(res)
+	}
+	return r.merged()
+}
+
+// rowsMerger merges the results of the rows of a data table as they are added, so that a row's result need not be
+// held once it is added.
+type rowsMerger struct {
+	specResult          *result.SpecResult
+	first               *m.ProtoSpec
+	scnResults          []*m.ProtoItem
+	table               *m.ProtoTable
+	dataTableScnResults map[string][]*m.ProtoTableDrivenScenario
+	max                 int64
+}
+
+func newRowsMerger() *rowsMerger {
+	return &rowsMerger{
+		specResult:          &result.SpecResult{ProtoSpec: &m.ProtoSpec{IsTableDriven: true}},
+		table:               &m.ProtoTable{},
+		dataTableScnResults: make(map[string][]*m.ProtoTableDrivenScenario),
+	}
+}
+
+func (r *rowsMerger) add(res *result.SpecResult) {
+	if r.first == nil {
+		r.first = res.ProtoSpec
+		r.max = res.ExecutionTime
+	}
+	r.specResult.ExecutionTime += res.ExecutionTime
+	r.specResult.Errors = res.Errors
+	if res.ExecutionTime > r.max {
+		r.max = res.ExecutionTime
+	}
+	if res.GetFailed() {
+		r.specResult.IsFailed = true
+	}
+	for _, item := range res.ProtoSpec.Items {
+		switch item.ItemType {
+		case m.ProtoItem_Scenario:
+			r.scnResults = append(r.scnResults, item)
+			modifySpecStats(item.Scenario, r.specResult)
+		case m.ProtoItem_TableDrivenScenario:
+			r.scnResults = append(r.scnResults, item)
+			heading := item.TableDrivenScenario.Scenario.ScenarioHeading
+			item.TableDrivenScenario.TableRowIndex = int32(len(r.table.Rows) - 1)
+			r.dataTableScnResults[heading] = append(r.dataTableScnResults[heading], item.TableDrivenScenario)
+		case m.ProtoItem_Table:
+			r.table.Headers = item.Table.Headers
+			r.table.Rows = append(r.table.Rows, item.Table.Rows...)
 		}
-		addHookFailure(table, res.GetPreHook(), specResult.AddPreHook)
-		addHookFailure(table, res.GetPostHook(), specResult.AddPostHook)
 	}
+	addHookFailure(r.table, res.GetPreHook(), r.specResult.AddPreHook)
+	addHookFailure(r.table, res.GetPostHook(), r.specResult.AddPostHook)
+}
+
+func (r *rowsMerger) merged() *result.SpecResult {
+	specResult := r.specResult
 	if InParallel {
-		specResult.ExecutionTime = max
+		specResult.ExecutionTime = r.max
 	}
-	aggregateDataTableScnStats(dataTableScnResults, specResult)
-	specResult.ProtoSpec.FileName = results[0].ProtoSpec.FileName
-	specResult.ProtoSpec.Tags = results[0].ProtoSpec.Tags
-	specResult.ProtoSpec.SpecHeading = results[0].ProtoSpec.SpecHeading
-	specResult.ProtoSpec.Items = getItems(table, scnResults, results)
+	aggregateDataTableScnStats(r.dataTableScnResults, specResult)
+	specResult.ProtoSpec.FileName = r.first.FileName
+	specResult.ProtoSpec.Tags = r.first.Tags
+	specResult.ProtoSpec.SpecHeading = r.first.SpecHeading
+	specResult.ProtoSpec.Items = getItems(r.table, r.scnResults, r.first.Items)
 	return specResult
 }
 
@@ -112,9 +167,9 @@ func addHookFailure(table *m.ProtoTable, f []*m.ProtoHookFailure, add func(...*m
 	add(f...)
 }
 
-func getItems(table *m.ProtoTable, scnResults []*m.ProtoItem, results []*result.SpecResult) (items []*m.ProtoItem) {
+func getItems(table *m.ProtoTable, scnResults []*m.ProtoItem, layout []*m.ProtoItem) (items []*m.ProtoItem) {
 	index := 0
-	for _, item := range results[0].ProtoSpec.Items {
+	for _, item := range layout {
 		switch item.ItemType {
 		case m.ProtoItem_Scenario, m.ProtoItem_TableDrivenScenario:
 			items = append(items, scnResults[index])
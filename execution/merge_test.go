@@ -280,6 +280,42 @@ func TestMergeDataTableSpecResults(t *testing.T) {
 	}
 }
 
+func TestMergeSpilledResultsOfDataTableRows(t *testing.T) {
+	store, err := result.NewSpillStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Remove()
+	row := func(status gm.ExecutionStatus) *result.SpecResult {
+		return &result.SpecResult{ProtoSpec: &gm.ProtoSpec{SpecHeading: "heading", FileName: "filename", Items: []*gm.ProtoItem{
+			{ItemType: gm.ProtoItem_Table, Table: &gm.ProtoTable{Headers: &gm.ProtoTableRow{Cells: []string{"a"}}, Rows: []*gm.ProtoTableRow{{Cells: []string{"1"}}}}},
+			{ItemType: gm.ProtoItem_Scenario, Scenario: &gm.ProtoScenario{ExecutionStatus: status, ScenarioHeading: "scenario"}},
+		}}}
+	}
+	var rows []*result.SpecResult
+	for _, status := range []gm.ExecutionStatus{gm.ExecutionStatus_PASSED, gm.ExecutionStatus_FAILED} {
+		spilled, err := store.Spill(row(status))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows = append(rows, spilled)
+	}
+
+	got := mergeSpilledResults(rows)
+
+	if got.SpillStore() != store || len(got.ProtoSpec.Items) != 0 {
+		t.Errorf("Expected the merged result to be spilled. Got %v", got.ProtoSpec)
+	}
+	loaded, err := got.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := mergeResults([]*result.SpecResult{row(gm.ExecutionStatus_PASSED), row(gm.ExecutionStatus_FAILED)})
+	if len(loaded.ProtoSpec.Items) != len(want.ProtoSpec.Items) || loaded.ScenarioFailedCount != want.ScenarioFailedCount || loaded.ScenarioCount != 2 {
+		t.Errorf("Merge spilled results failed.\n\tWant: %v\n\tGot: %v", want, loaded)
+	}
+}
+
 func TestGetItems(t *testing.T) {
 	table := &gm.ProtoTable{Headers: &gm.ProtoTableRow{Cells: []string{"a"}}}
 	res := []*result.SpecResult{{
@@ -294,7 +330,7 @@ func TestGetItems(t *testing.T) {
 	scnRes := []*gm.ProtoItem{
 		{ItemType: gm.ProtoItem_Scenario}, {ItemType: gm.ProtoItem_TableDrivenScenario}, {ItemType: gm.ProtoItem_Scenario},
 	}
-	got := getItems(table, scnRes, res)
+	got := getItems(table, scnRes, res[0].ProtoSpec.Items)
 
 	want := []*gm.ProtoItem{{ItemType: gm.ProtoItem_Table, Table: table}, {ItemType: gm.ProtoItem_Scenario}, {ItemType: gm.ProtoItem_TableDrivenScenario}, {ItemType: gm.ProtoItem_Scenario}}
 
@@ -27,23 +27,24 @@ import (
 
 	"os"
 
-	"github.com/getgauge/common"
 	"github.com/getgauge/gauge/config"
 	"github.com/getgauge/gauge/conn"
 	"github.com/getgauge/gauge/env"
 	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/history"
 	"github.com/getgauge/gauge/execution/result"
 	"github.com/getgauge/gauge/filter"
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/gauge_messages"
 	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/manifest"
+	"github.com/getgauge/gauge/order"
 	"github.com/getgauge/gauge/plugin"
 	"github.com/getgauge/gauge/reporter"
 	"github.com/getgauge/gauge/runner"
 )
 
-// Strategy for execution, can be either 'Eager' or 'Lazy'
+// Strategy for execution, can be either 'Eager', 'Lazy' or 'Deterministic'
 var Strategy string
 
 // Eager is a parallelization strategy for execution. In this case tests are distributed before execution, thus making them an equal number based distribution.
@@ -52,6 +53,9 @@ const Eager string = "eager"
 // Lazy is a parallelization strategy for execution. In this case tests assignment will be dynamic during execution, i.e. assign the next spec in line to the stream that has completed it’s previous execution and is waiting for more work.
 const Lazy string = "lazy"
 
+// Deterministic is a parallelization strategy for execution. In this case tests are distributed before execution, with the stream of each spec depending only on its path and the number of streams, so that repeated runs execute a spec on the same stream.
+const Deterministic string = "deterministic"
+
 type parallelExecution struct {
 	wg                       sync.WaitGroup
 	manifest                 *manifest.Manifest
@@ -63,6 +67,7 @@ type parallelExecution struct {
 	numberOfExecutionStreams int
 	errMaps                  *gauge.BuildErrors
 	startTime                time.Time
+	streamDirs               streamDirs
 }
 
 func newParallelExecution(e *executionInfo) *parallelExecution {
@@ -100,29 +105,42 @@ func (e *parallelExecution) numberOfStreams() int {
 
 func (e *parallelExecution) start() {
 	e.startTime = time.Now()
-	event.Notify(event.NewExecutionEvent(event.SuiteStart, nil, nil, 0, gauge_messages.ExecutionInfo{}))
 	e.pluginHandler = plugin.StartPlugins(e.manifest)
+	event.Notify(event.NewExecutionEvent(event.SuiteStart, nil, nil, 0, gauge_messages.ExecutionInfo{}))
 }
 
 func (e *parallelExecution) run() *result.SuiteResult {
 	e.start()
 
-	nStreams := e.numberOfStreams()
-	logger.Infof(true, "Executing in %s parallel streams.", strconv.Itoa(nStreams))
-
-	resChan := make(chan *result.SuiteResult)
-	if e.isMultithreaded() {
-		logger.Debugf(true, "Using multithreading for parallel execution.")
-		go e.executeMultithreaded(nStreams, resChan)
-	} else if isLazy() {
-		go e.executeLazily(nStreams, resChan)
-	} else {
-		go e.executeEagerly(nStreams, resChan)
+	serial, rest := filter.SerialSpecs(e.specCollection.Specs())
+	if len(serial) > 0 {
+		e.specCollection = gauge.NewSpecCollection(rest, false)
 	}
 
 	var res []*result.SuiteResult
-	for r := range resChan {
-		res = append(res, r)
+	if e.specCollection.Size() > 0 {
+		nStreams := e.numberOfStreams()
+		logger.Infof(true, "Executing in %s parallel streams.", strconv.Itoa(nStreams))
+
+		resChan := make(chan *result.SuiteResult)
+		if e.isMultithreaded() {
+			logger.Debugf(true, "Using multithreading for parallel execution.")
+			go e.executeMultithreaded(nStreams, resChan)
+		} else if isLazy() {
+			go e.executeLazily(nStreams, resChan)
+		} else if isDeterministic() {
+			// the number of streams asked for decides the distribution, not the number of streams used for the specs at hand
+			go e.executeDistributed(filter.DistributeSpecsDeterministically(e.specCollection.Specs(), e.numberOfExecutionStreams), resChan)
+		} else {
+			go e.executeEagerly(nStreams, resChan)
+		}
+
+		for r := range resChan {
+			res = append(res, r)
+		}
+	}
+	if len(serial) > 0 {
+		res = append(res, e.executeSerially(serial))
 	}
 	e.aggregateResults(res)
 
@@ -130,10 +148,32 @@ func (e *parallelExecution) run() *result.SuiteResult {
 	return e.suiteResult
 }
 
+// executeSerially executes the specs which cannot run alongside others in a single stream, once all the parallel
+// streams are done.
+func (e *parallelExecution) executeSerially(specs []*gauge.Specification) *result.SuiteResult {
+	logger.Infof(true, "Executing %d serial specifications in a single stream.", len(specs))
+	resChan := make(chan *result.SuiteResult, 1)
+	e.wg.Add(1)
+	e.startSpecsExecution(gauge.NewSpecCollection(specs, false), resChan, 1)
+	return <-resChan
+}
+
 func (e *parallelExecution) executeLazily(totalStreams int, resChan chan *result.SuiteResult) {
+	if d := history.SpecDurations(); len(d) > 0 && !order.Sorted && !order.FailuresFirst {
+		e.specCollection = gauge.NewSpecCollection(order.SortByPriority(filter.SortSpecsByDuration(e.specCollection.Specs(), d)), false)
+	}
+	pinned, rest := filter.PinSpecs(e.specCollection.Specs(), filter.StreamPins(), totalStreams)
 	e.wg.Add(totalStreams)
-	for i := 0; i < totalStreams; i++ {
-		go e.startStream(e.specCollection, resChan, i+1)
+	if len(pinned) == 0 {
+		for i := 0; i < totalStreams; i++ {
+			go e.startStream(e.specCollection, resChan, i+1)
+		}
+	} else {
+		// every stream executes the specs pinned to it first, and then picks up the rest from the shared collection
+		shared := gauge.NewSpecCollection(rest, false)
+		for i := 0; i < totalStreams; i++ {
+			go e.startStream(gauge.NewSpecCollection(pinned[i+1], false).Chain(shared), resChan, i+1)
+		}
 	}
 	e.wg.Wait()
 	close(resChan)
@@ -144,11 +184,7 @@ func (e *parallelExecution) executeMultithreaded(totalStreams int, resChan chan
 	handlers := make([]*conn.GaugeConnectionHandler, 0)
 	var ports []string
 	for i := 0; i < totalStreams; i++ {
-		port, err := conn.GetPortFromEnvironmentVariable(common.GaugePortEnvName)
-		if err != nil {
-			port = 0
-		}
-		handler, err := conn.NewGaugeConnectionHandler(port, nil)
+		handler, err := runner.NewConnectionHandler()
 		if err != nil {
 			fmt.Println(err)
 		}
@@ -181,9 +217,16 @@ func (e *parallelExecution) startMultithreaded(r runner.Runner, resChan chan *re
 }
 
 func (e *parallelExecution) executeEagerly(distributions int, resChan chan *result.SuiteResult) {
-	specs := filter.DistributeSpecs(e.specCollection.Specs(), distributions)
-	e.wg.Add(distributions)
+	e.executeDistributed(filter.DistributeSpecs(e.specCollection.Specs(), distributions), resChan)
+}
+
+// executeDistributed executes each group of specs in a stream of its own.
+func (e *parallelExecution) executeDistributed(specs []*gauge.SpecCollection, resChan chan *result.SuiteResult) {
 	for i, s := range specs {
+		if s == nil {
+			continue
+		}
+		e.wg.Add(1)
 		go e.startSpecsExecution(s, resChan, i+1)
 	}
 	e.wg.Wait()
@@ -195,7 +238,7 @@ func (e *parallelExecution) startStream(s *gauge.SpecCollection, resChan chan *r
 	if os.Getenv("GAUGE_CUSTOM_BUILD_PATH") == "" {
 		os.Setenv("GAUGE_CUSTOM_BUILD_PATH", path.Join(os.Getenv("GAUGE_PROJECT_ROOT"), "gauge_bin"))
 	}
-	runner, err := runner.Start(e.manifest, reporter.ParallelReporter(stream), make(chan bool), false)
+	runner, err := e.startStreamRunner(stream)
 	if err != nil {
 		logger.Errorf(true, "Failed to start runner. %s", err.Error())
 		resChan <- &result.SuiteResult{UnhandledErrors: []error{fmt.Errorf("Failed to start runner. %s", err.Error())}}
@@ -209,7 +252,7 @@ func (e *parallelExecution) startSpecsExecution(s *gauge.SpecCollection, resChan
 	if os.Getenv("GAUGE_CUSTOM_BUILD_PATH") == "" {
 		os.Setenv("GAUGE_CUSTOM_BUILD_PATH", path.Join(os.Getenv("GAUGE_PROJECT_ROOT"), "gauge_bin"))
 	}
-	runner, err := runner.Start(e.manifest, reporter.ParallelReporter(stream), make(chan bool), false)
+	runner, err := e.startStreamRunner(stream)
 	if err != nil {
 		logger.Errorf(true, "Failed to start runner. %s", err.Error())
 		logger.Debugf(true, "Skipping %d specifications", s.Size())
@@ -219,29 +262,34 @@ func (e *parallelExecution) startSpecsExecution(s *gauge.SpecCollection, resChan
 	e.startSpecsExecutionWithRunner(s, resChan, runner, stream)
 }
 
+// startStreamRunner starts the runner of a stream, with the scratch directory of the stream cleaned once in the run.
+func (e *parallelExecution) startStreamRunner(stream int) (runner.Runner, error) {
+	if err := e.streamDirs.prepare(stream); err != nil {
+		return nil, err
+	}
+	return startRunner(e.manifest, stream)()
+}
+
 func (e *parallelExecution) startSpecsExecutionWithRunner(s *gauge.SpecCollection, resChan chan *result.SuiteResult, runner runner.Runner, stream int) {
 	executionInfo := newExecutionInfo(s, runner, e.pluginHandler, e.errMaps, false, stream)
 	se := newSimpleExecution(executionInfo, false)
 	se.execute()
-	runner.Kill()
+	se.runner.Kill()
 	resChan <- se.suiteResult
 }
 
 func (e *parallelExecution) finish() {
 	e.suiteResult = mergeDataTableSpecResults(e.suiteResult)
+	e.suiteResult.CollectSlowSteps(env.SlowStepThreshold(), maxSlowSteps)
+	addStopError(e.suiteResult)
 	event.Notify(event.NewExecutionEvent(event.SuiteEnd, nil, e.suiteResult, 0, gauge_messages.ExecutionInfo{}))
-	message := &gauge_messages.Message{
-		MessageType: gauge_messages.Message_SuiteExecutionResult,
-		SuiteExecutionResult: &gauge_messages.SuiteExecutionResult{
-			SuiteResult: gauge.ConvertToProtoSuiteResult(e.suiteResult),
-		},
-	}
-	e.pluginHandler.NotifyPlugins(message)
+	notifySuiteResult(e.pluginHandler, e.suiteResult)
 	e.pluginHandler.GracefullyKillPlugins()
 }
 
 func (e *parallelExecution) aggregateResults(suiteResults []*result.SuiteResult) {
 	r := result.NewSuiteResult(ExecuteTags, e.startTime)
+	r.Metadata = suiteMetadata()
 	for _, result := range suiteResults {
 		r.SpecsFailedCount += result.SpecsFailedCount
 		r.SpecResults = append(r.SpecResults, result.SpecResults...)
@@ -267,9 +315,13 @@ func isLazy() bool {
 	return strings.ToLower(Strategy) == Lazy
 }
 
+func isDeterministic() bool {
+	return strings.ToLower(Strategy) == Deterministic
+}
+
 func isValidStrategy(strategy string) bool {
 	strategy = strings.ToLower(strategy)
-	return strategy == Lazy || strategy == Eager
+	return strategy == Lazy || strategy == Eager || strategy == Deterministic
 }
 
 func (e *parallelExecution) isMultithreaded() bool {
@@ -0,0 +1,151 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/manifest"
+	"github.com/getgauge/gauge/reporter"
+	"github.com/getgauge/gauge/runner"
+)
+
+// contactChecker is implemented by the runners which can tell if they quit unexpectedly.
+type contactChecker interface {
+	LostContact() bool
+}
+
+// recoveringRunner restarts the runner when it quits unexpectedly in the middle of an execution, so that the remaining
+// scenarios are executed on a new runner instead of failing one after the other. The scenario which was running when
+// the runner quit fails. Once the runner cannot be restarted any more, the remaining scenarios are skipped.
+type recoveringRunner struct {
+	mutex    sync.Mutex
+	runner   runner.Runner
+	start    func() (runner.Runner, error)
+	crashed  bool
+	restarts int
+}
+
+func newRecoveringRunner(r runner.Runner, start func() (runner.Runner, error)) *recoveringRunner {
+	return &recoveringRunner{runner: r, start: start}
+}
+
+func (r *recoveringRunner) current() runner.Runner {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.runner
+}
+
+func (r *recoveringRunner) hasCrashed() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.crashed
+}
+
+// ExecuteAndGetStatus fails without contacting the runner once it has quit, until it is restarted.
+func (r *recoveringRunner) ExecuteAndGetStatus(m *gauge_messages.Message) *gauge_messages.ProtoExecutionResult {
+	if r.hasCrashed() {
+		return &gauge_messages.ProtoExecutionResult{Failed: true, ErrorMessage: "Runner quit unexpectedly."}
+	}
+	current := r.current()
+	res := current.ExecuteAndGetStatus(m)
+	if c, ok := current.(contactChecker); ok && res.GetFailed() && c.LostContact() {
+		r.mutex.Lock()
+		r.crashed = true
+		r.mutex.Unlock()
+		res.ErrorMessage = fmt.Sprintf("Runner quit unexpectedly. %s", res.GetErrorMessage())
+	}
+	return res
+}
+
+func (r *recoveringRunner) ExecuteMessageWithTimeout(m *gauge_messages.Message) (*gauge_messages.Message, error) {
+	return r.current().ExecuteMessageWithTimeout(m)
+}
+
+func (r *recoveringRunner) Alive() bool {
+	return r.current().Alive()
+}
+
+func (r *recoveringRunner) Kill() error {
+	return r.current().Kill()
+}
+
+func (r *recoveringRunner) Connection() net.Conn {
+	return r.current().Connection()
+}
+
+func (r *recoveringRunner) IsMultithreaded() bool {
+	return r.current().IsMultithreaded()
+}
+
+func (r *recoveringRunner) Pid() int {
+	return r.current().Pid()
+}
+
+// recover starts a new runner if the runner has quit, and initializes the suite data store on it.
+// It returns true if the runner was restarted. The execution is aborted if the runner cannot be restarted.
+func (r *recoveringRunner) recover() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if !r.crashed || abortReason() != "" {
+		return false
+	}
+	if r.restarts >= env.MaxRunnerRestarts() {
+		abortExecution(fmt.Sprintf("Runner quit unexpectedly after %d restart(s)", r.restarts))
+		return false
+	}
+	r.runner.Kill()
+	newRunner, err := r.start()
+	if err != nil {
+		abortExecution(fmt.Sprintf("Failed to restart the runner which quit unexpectedly. %s", err.Error()))
+		return false
+	}
+	r.restarts++
+	r.runner, r.crashed = newRunner, false
+	logger.Warningf(true, "Restarted the runner which quit unexpectedly, restart %d of %d.", r.restarts, env.MaxRunnerRestarts())
+	m := &gauge_messages.Message{MessageType: gauge_messages.Message_SuiteDataStoreInit,
+		SuiteDataStoreInitRequest: &gauge_messages.SuiteDataStoreInitRequest{}}
+	if res := newRunner.ExecuteAndGetStatus(m); res.GetFailed() {
+		logger.Errorf(true, "Failed to initialize suite datastore on the restarted runner. Error: %s", res.GetErrorMessage())
+	}
+	return true
+}
+
+// startRunner starts a new runner writing to the console of the stream. Runners of parallel streams get the environment
+// of their stream.
+func startRunner(m *manifest.Manifest, stream int) func() (runner.Runner, error) {
+	return func() (runner.Runner, error) {
+		if stream > 0 {
+			return runner.StartWithEnv(m, streamEnv(stream), reporter.RunnerWriter(stream), make(chan bool), false)
+		}
+		return runner.Start(m, reporter.RunnerWriter(0), make(chan bool), false)
+	}
+}
+
+// recoverRunner restarts the runner of the execution if it has quit, and tells if it was restarted.
+func recoverRunner(r runner.Runner) bool {
+	if rr, ok := r.(*recoveringRunner); ok {
+		return rr.recover()
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/runner"
+)
+
+type crashingRunner struct {
+	mockRunner
+	lost bool
+}
+
+func (r *crashingRunner) LostContact() bool {
+	return r.lost
+}
+
+func TestCrashedRunnerIsRestartedForTheRemainingScenarios(t *testing.T) {
+	defer resetAbort()
+	specText := newSpecBuilder().specHeading("A spec heading").
+		scenarioHeading("Crashing scenario").
+		step("crashing step").
+		step("next step").
+		scenarioHeading("Next scenario").
+		step("next step").
+		String()
+	spec, _, _ := new(parser.SpecParser).Parse(specText, gauge.NewConceptDictionary(), "")
+	spec.FileName = "FILE"
+	var messages []string
+	first := &crashingRunner{}
+	first.ExecuteAndGetStatusFunc = func(m *gauge_messages.Message) *gauge_messages.ProtoExecutionResult {
+		if m.GetExecuteStepRequest().GetActualStepText() == "crashing step" {
+			first.lost = true
+			return &gauge_messages.ProtoExecutionResult{Failed: true, ErrorMessage: "Connection closed"}
+		}
+		messages = append(messages, "first: "+m.MessageType.String())
+		return &gauge_messages.ProtoExecutionResult{}
+	}
+	second := &crashingRunner{}
+	second.ExecuteAndGetStatusFunc = func(m *gauge_messages.Message) *gauge_messages.ProtoExecutionResult {
+		messages = append(messages, "second: "+m.MessageType.String())
+		return &gauge_messages.ProtoExecutionResult{}
+	}
+	h := &mockPluginHandler{NotifyPluginsfunc: func(m *gauge_messages.Message) {}, GracefullyKillPluginsfunc: func() {}}
+	r := newRecoveringRunner(first, func() (runner.Runner, error) { return second, nil })
+	e := &simpleExecution{runner: r, pluginHandler: h, errMaps: gauge.NewBuildErrors(), currentExecutionInfo: &gauge_messages.ExecutionInfo{}}
+
+	results := e.executeSpecs(gauge.NewSpecCollection([]*gauge.Specification{spec}, false))
+
+	scenarios := results[0].ScenarioResults()
+	if scenarios[0].GetExecutionStatus() != gauge_messages.ExecutionStatus_FAILED {
+		t.Errorf("Expected the crashing scenario to fail. Got %s", scenarios[0].GetExecutionStatus())
+	}
+	if scenarios[1].GetExecutionStatus() != gauge_messages.ExecutionStatus_PASSED {
+		t.Errorf("Expected the next scenario to pass on the restarted runner. Got %s", scenarios[1].GetExecutionStatus())
+	}
+	want := []string{"second: SuiteDataStoreInit", "second: SpecDataStoreInit", "second: ScenarioDataStoreInit"}
+	got := strings.Join(messages, ",")
+	if !strings.Contains(got, strings.Join(want, ",")) {
+		t.Errorf("Expected the data stores to be initialized on the restarted runner. Got %v", messages)
+	}
+	if strings.Contains(got, "first: StepExecutionEnding") || r.current() != second {
+		t.Errorf("Expected the crashed runner not to be used after it quit. Got %v", messages)
+	}
+}
+
+func TestCrashedRunnerAbortsExecutionOnceRestartsAreExhausted(t *testing.T) {
+	defer resetAbort()
+	defer func(f func() int) { env.MaxRunnerRestarts = f }(env.MaxRunnerRestarts)
+	env.MaxRunnerRestarts = func() int { return 0 }
+	crashed := &crashingRunner{lost: true}
+	crashed.ExecuteAndGetStatusFunc = func(m *gauge_messages.Message) *gauge_messages.ProtoExecutionResult {
+		return &gauge_messages.ProtoExecutionResult{Failed: true, ErrorMessage: "Connection closed"}
+	}
+	r := newRecoveringRunner(crashed, func() (runner.Runner, error) {
+		t.Fatal("Expected the runner not to be restarted")
+		return nil, nil
+	})
+
+	res := r.ExecuteAndGetStatus(&gauge_messages.Message{MessageType: gauge_messages.Message_ExecuteStep})
+
+	if res.GetErrorMessage() != "Runner quit unexpectedly. Connection closed" {
+		t.Errorf("Expected the failure to say that the runner quit. Got %q", res.GetErrorMessage())
+	}
+	if r.recover() {
+		t.Error("Expected the runner not to be restarted")
+	}
+	if got := abortReason(); got != "Runner quit unexpectedly after 0 restart(s)" {
+		t.Errorf("Expected the execution to be aborted. Got %q", got)
+	}
+	if res := r.ExecuteAndGetStatus(&gauge_messages.Message{}); res.GetErrorMessage() != "Runner quit unexpectedly." {
+		t.Errorf("Expected messages to fail without the runner. Got %q", res.GetErrorMessage())
+	}
+}
+
+func TestCrashedRunnerAbortsExecutionWhenRestartFails(t *testing.T) {
+	defer resetAbort()
+	crashed := &crashingRunner{lost: true}
+	crashed.ExecuteAndGetStatusFunc = func(m *gauge_messages.Message) *gauge_messages.ProtoExecutionResult {
+		return &gauge_messages.ProtoExecutionResult{Failed: true}
+	}
+	r := newRecoveringRunner(crashed, func() (runner.Runner, error) { return nil, errors.New("Timed out connecting to java") })
+	r.ExecuteAndGetStatus(&gauge_messages.Message{})
+
+	if r.recover() {
+		t.Error("Expected the runner not to be restarted")
+	}
+	if got := abortReason(); got != "Failed to restart the runner which quit unexpectedly. Timed out connecting to java" {
+		t.Errorf("Expected the execution to be aborted. Got %q", got)
+	}
+}
+
+func TestFailuresWithoutLostContactDoNotRestartTheRunner(t *testing.T) {
+	r := newRecoveringRunner(&mockRunner{ExecuteAndGetStatusFunc: func(m *gauge_messages.Message) *gauge_messages.ProtoExecutionResult {
+		return &gauge_messages.ProtoExecutionResult{Failed: true, ErrorMessage: "assertion failed"}
+	}}, nil)
+
+	res := r.ExecuteAndGetStatus(&gauge_messages.Message{})
+
+	if res.GetErrorMessage() != "assertion failed" || r.recover() {
+		t.Errorf("Expected a failing step not to restart the runner. Got %q", res.GetErrorMessage())
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package reportportal
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"time"
+
+	"github.com/getgauge/gauge/logger"
+)
+
+const (
+	timeout   = 30 * time.Second
+	queueSize = 1000
+)
+
+type logEntry struct {
+	LaunchUUID string `json:"launchUuid"`
+	ItemUUID   string `json:"itemUuid,omitempty"`
+	Time       int64  `json:"time"`
+	Message    string `json:"message"`
+	Level      string `json:"level"`
+	File       *file  `json:"file,omitempty"`
+	data       []byte
+}
+
+type file struct {
+	Name string `json:"name"`
+}
+
+// client sends the requests to ReportPortal in the order they are queued, without blocking the caller.
+// The ids of launches and items are generated by the client, so requests do not wait for the responses of earlier ones.
+type client struct {
+	endpoint string
+	project  string
+	token    string
+	http     *http.Client
+	queue    chan func() error
+	done     chan bool
+	failed   int
+}
+
+func newClient(endpoint, project, token string) *client {
+	c := &client{endpoint: endpoint, project: project, token: token, http: &http.Client{Timeout: timeout}, queue: make(chan func() error, queueSize), done: make(chan bool)}
+	go func() {
+		for send := range c.queue {
+			if err := send(); err != nil {
+				c.failed++
+				logger.Errorf(false, "Request to ReportPortal failed. %s", err.Error())
+			}
+		}
+		c.done <- true
+	}()
+	return c
+}
+
+func (c *client) url(path string) string {
+	return fmt.Sprintf("%s/api/v1/%s%s", c.endpoint, c.project, path)
+}
+
+func (c *client) send(method, path string, body interface{}) {
+	c.queue <- func() error {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		return c.do(method, path, "application/json", bytes.NewReader(b))
+	}
+}
+
+// sendLogs sends the log entries, along with their attachments, in a single batch request.
+func (c *client) sendLogs(entries []logEntry) {
+	if len(entries) == 0 {
+		return
+	}
+	c.queue <- func() error {
+		body := &bytes.Buffer{}
+		w := multipart.NewWriter(body)
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Disposition", `form-data; name="json_request_part"`)
+		h.Set("Content-Type", "application/json")
+		part, err := w.CreatePart(h)
+		if err != nil {
+			return err
+		}
+		if err := json.NewEncoder(part).Encode(entries); err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if e.File == nil {
+				continue
+			}
+			h := textproto.MIMEHeader{}
+			h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, e.File.Name))
+			h.Set("Content-Type", http.DetectContentType(e.data))
+			part, err := w.CreatePart(h)
+			if err != nil {
+				return err
+			}
+			part.Write(e.data)
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+		return c.do(http.MethodPost, "/log", w.FormDataContentType(), body)
+	}
+}
+
+func (c *client) do(method, path, contentType string, body io.Reader) error {
+	req, err := http.NewRequest(method, c.url(path), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("%s %s responded with %s. %s", method, path, resp.Status, string(msg))
+	}
+	return nil
+}
+
+// close waits for all the queued requests to be sent, and returns the number of requests which failed.
+func (c *client) close() int {
+	close(c.queue)
+	<-c.done
+	return c.failed
+}
+
+func newUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func now() int64 {
+	return time.Now().UnixNano() / int64(time.Millisecond)
+}
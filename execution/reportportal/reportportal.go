@@ -0,0 +1,209 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package reportportal streams the execution to a ReportPortal instance. Every execution is reported as a launch,
+// with the specs as suites and the scenarios as steps. Failures are logged against the scenarios along with
+// the stacktraces and screenshots.
+package reportportal
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/util"
+)
+
+const logBatchSize = 20
+
+type attribute struct {
+	Value string `json:"value"`
+}
+
+type startRQ struct {
+	UUID       string      `json:"uuid"`
+	Name       string      `json:"name"`
+	StartTime  int64       `json:"startTime"`
+	Type       string      `json:"type,omitempty"`
+	Mode       string      `json:"mode,omitempty"`
+	LaunchUUID string      `json:"launchUuid,omitempty"`
+	CodeRef    string      `json:"codeRef,omitempty"`
+	Attributes []attribute `json:"attributes,omitempty"`
+}
+
+type finishRQ struct {
+	EndTime    int64  `json:"endTime"`
+	Status     string `json:"status,omitempty"`
+	LaunchUUID string `json:"launchUuid,omitempty"`
+}
+
+type launch struct {
+	client    *client
+	uuid      string
+	specs     map[int]string
+	scenarios map[int]string
+	logs      []logEntry
+}
+
+func newLaunch(c *client) *launch {
+	return &launch{client: c, uuid: newUUID(), specs: make(map[int]string), scenarios: make(map[int]string)}
+}
+
+// ListenExecutionEvents reports the execution to the ReportPortal instance given by rp_endpoint, if set.
+func ListenExecutionEvents(wg *sync.WaitGroup) {
+	endpoint := strings.TrimRight(strings.TrimSpace(os.Getenv(env.ReportPortalEndpoint)), "/")
+	if endpoint == "" {
+		return
+	}
+	project, token := strings.TrimSpace(os.Getenv(env.ReportPortalProject)), strings.TrimSpace(os.Getenv(env.ReportPortalToken))
+	if project == "" || token == "" {
+		logger.Errorf(true, "Execution will not be reported to ReportPortal. %s and %s should be set.", env.ReportPortalProject, env.ReportPortalToken)
+		return
+	}
+	ch := make(chan event.ExecutionEvent, 0)
+	event.Register(ch, event.SuiteStart, event.SpecStart, event.ScenarioStart, event.StepEnd, event.ScenarioEnd, event.SpecEnd, event.SuiteEnd)
+	wg.Add(1)
+
+	go func() {
+		l := newLaunch(newClient(endpoint, project, token))
+		for {
+			e := <-ch
+			l.handle(e)
+			if e.Topic == event.SuiteEnd {
+				if failed := l.client.close(); failed > 0 {
+					logger.Warningf(true, "%d request(s) to ReportPortal failed. Check the logs for details.", failed)
+				}
+				wg.Done()
+			}
+		}
+	}()
+}
+
+func (l *launch) handle(e event.ExecutionEvent) {
+	switch e.Topic {
+	case event.SuiteStart:
+		name := strings.TrimSpace(os.Getenv(env.ReportPortalLaunch))
+		if name == "" {
+			name = filepath.Base(config.ProjectRoot)
+		}
+		l.client.send(http.MethodPost, "/launch", startRQ{UUID: l.uuid, Name: name, StartTime: now(), Mode: "DEFAULT", Attributes: []attribute{{Value: env.CurrentEnvironments()}}})
+	case event.SpecStart:
+		spec := e.Item.(*gauge.Specification)
+		l.specs[e.Stream] = newUUID()
+		l.client.send(http.MethodPost, "/item", startRQ{UUID: l.specs[e.Stream], Name: spec.Heading.Value, StartTime: now(), Type: "SUITE", LaunchUUID: l.uuid,
+			CodeRef: filepath.ToSlash(util.RelPathToProjectRoot(spec.FileName)), Attributes: attributes(spec.Tags)})
+	case event.ScenarioStart:
+		sce := e.Item.(*gauge.Scenario)
+		l.scenarios[e.Stream] = newUUID()
+		l.client.send(http.MethodPost, "/item/"+l.specs[e.Stream], startRQ{UUID: l.scenarios[e.Stream], Name: sce.Heading.Value, StartTime: now(), Type: "STEP", LaunchUUID: l.uuid,
+			CodeRef: fmt.Sprintf("%s:%d", filepath.ToSlash(util.RelPathToProjectRoot(e.ExecutionInfo.GetCurrentSpec().GetFileName())), sce.Heading.LineNo), Attributes: attributes(sce.Tags)})
+	case event.StepEnd:
+		l.logStep(l.scenarios[e.Stream], e.Item.(gauge.Step), e.Result.(*result.StepResult))
+	case event.ScenarioEnd:
+		l.logHookFailures(l.scenarios[e.Stream], e.Result)
+		l.flush()
+		l.client.send(http.MethodPut, "/item/"+l.scenarios[e.Stream], finishRQ{EndTime: now(), Status: status(e.Result.(*result.ScenarioResult).ProtoScenario.GetExecutionStatus()), LaunchUUID: l.uuid})
+		delete(l.scenarios, e.Stream)
+	case event.SpecEnd:
+		res := e.Result.(*result.SpecResult)
+		l.logHookFailures(l.specs[e.Stream], res)
+		l.flush()
+		st := "passed"
+		if res.GetFailed() {
+			st = "failed"
+		} else if res.Skipped {
+			st = "skipped"
+		}
+		l.client.send(http.MethodPut, "/item/"+l.specs[e.Stream], finishRQ{EndTime: now(), Status: st, LaunchUUID: l.uuid})
+		delete(l.specs, e.Stream)
+	case event.SuiteEnd:
+		l.logHookFailures("", e.Result)
+		for _, err := range e.Result.(*result.SuiteResult).UnhandledErrors {
+			l.log("", "error", err.Error(), nil)
+		}
+		l.flush()
+		l.client.send(http.MethodPut, "/launch/"+l.uuid+"/finish", finishRQ{EndTime: now()})
+	}
+}
+
+func (l *launch) logStep(item string, step gauge.Step, res *result.StepResult) {
+	r := res.ProtoStepExecResult().GetExecutionResult()
+	for _, m := range r.GetMessage() {
+		l.log(item, "info", m, nil)
+	}
+	for _, s := range r.GetScreenshots() {
+		l.log(item, "info", "Screenshot", s)
+	}
+	if res.GetStepFailed() {
+		l.log(item, "error", fmt.Sprintf("Failed Step: %s\n%s\n%s", step.LineText, r.GetErrorMessage(), r.GetStackTrace()), r.GetFailureScreenshot())
+	}
+	l.logHookFailures(item, res)
+}
+
+func (l *launch) logHookFailures(item string, res result.Result) {
+	for _, f := range append(res.GetPreHook(), res.GetPostHook()...) {
+		l.log(item, "error", fmt.Sprintf("%s\n%s", f.GetErrorMessage(), f.GetStackTrace()), f.GetFailureScreenshot())
+	}
+}
+
+// log adds a log entry to the item, with the screenshot as attachment if given. An empty item logs against the launch.
+func (l *launch) log(item, level, message string, screenshot []byte) {
+	entry := logEntry{LaunchUUID: l.uuid, ItemUUID: item, Time: now(), Message: message, Level: level}
+	if len(screenshot) > 0 {
+		entry.File = &file{Name: newUUID() + ".png"}
+		entry.data = screenshot
+	}
+	l.logs = append(l.logs, entry)
+	if len(l.logs) >= logBatchSize {
+		l.flush()
+	}
+}
+
+func (l *launch) flush() {
+	l.client.sendLogs(l.logs)
+	l.logs = nil
+}
+
+func attributes(tags *gauge.Tags) []attribute {
+	var attrs []attribute
+	if tags != nil {
+		for _, t := range tags.Values() {
+			attrs = append(attrs, attribute{Value: t})
+		}
+	}
+	return attrs
+}
+
+func status(s gauge_messages.ExecutionStatus) string {
+	switch s {
+	case gauge_messages.ExecutionStatus_FAILED:
+		return "failed"
+	case gauge_messages.ExecutionStatus_SKIPPED:
+		return "skipped"
+	}
+	return "passed"
+}
@@ -0,0 +1,118 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package reportportal
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+type request struct {
+	method string
+	path   string
+	uuid   string
+	logs   []logEntry
+	files  int
+}
+
+func newServer(c *C) (*httptest.Server, *[]request) {
+	var mu sync.Mutex
+	requests := &[]request{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Header.Get("Authorization"), Equals, "Bearer token")
+		req := request{method: r.Method, path: r.URL.Path}
+		if r.URL.Path == "/api/v1/proj/log" {
+			c.Assert(r.ParseMultipartForm(1<<20), IsNil)
+			c.Assert(json.Unmarshal([]byte(r.MultipartForm.Value["json_request_part"][0]), &req.logs), IsNil)
+			req.files = len(r.MultipartForm.File["file"])
+		} else if r.Method == http.MethodPost {
+			var rq startRQ
+			c.Assert(json.NewDecoder(r.Body).Decode(&rq), IsNil)
+			req.uuid = rq.UUID
+		}
+		mu.Lock()
+		*requests = append(*requests, req)
+		mu.Unlock()
+	}))
+	return server, requests
+}
+
+func (s *MySuite) TestLaunchReportsFailedScenarioWithLogs(c *C) {
+	server, requests := newServer(c)
+	defer server.Close()
+	l := newLaunch(newClient(server.URL, "proj", "token"))
+	spec := &gauge.Specification{Heading: &gauge.Heading{Value: "spec"}, FileName: "foo.spec"}
+	sce := &gauge.Scenario{Heading: &gauge.Heading{Value: "scenario"}}
+	stepRes := result.NewStepResult(&gauge_messages.ProtoStep{StepExecutionResult: &gauge_messages.ProtoStepExecutionResult{ExecutionResult: &gauge_messages.ProtoExecutionResult{Failed: true, ErrorMessage: "expected 1", FailureScreenshot: []byte("png")}}})
+	stepRes.SetStepFailure()
+	sceRes := result.NewScenarioResult(&gauge_messages.ProtoScenario{ExecutionStatus: gauge_messages.ExecutionStatus_FAILED})
+	specRes := &result.SpecResult{ProtoSpec: &gauge_messages.ProtoSpec{}, IsFailed: true}
+
+	for _, e := range []event.ExecutionEvent{
+		event.NewExecutionEvent(event.SuiteStart, nil, nil, 0, gauge_messages.ExecutionInfo{}),
+		event.NewExecutionEvent(event.SpecStart, spec, specRes, 0, gauge_messages.ExecutionInfo{}),
+		event.NewExecutionEvent(event.ScenarioStart, sce, sceRes, 0, gauge_messages.ExecutionInfo{}),
+		event.NewExecutionEvent(event.StepEnd, gauge.Step{LineText: "a step"}, stepRes, 0, gauge_messages.ExecutionInfo{}),
+		event.NewExecutionEvent(event.ScenarioEnd, sce, sceRes, 0, gauge_messages.ExecutionInfo{}),
+		event.NewExecutionEvent(event.SpecEnd, spec, specRes, 0, gauge_messages.ExecutionInfo{}),
+		event.NewExecutionEvent(event.SuiteEnd, nil, &result.SuiteResult{}, 0, gauge_messages.ExecutionInfo{}),
+	} {
+		l.handle(e)
+	}
+	c.Assert(l.client.close(), Equals, 0)
+
+	c.Assert(len(*requests), Equals, 7)
+	paths := []string{}
+	for _, r := range *requests {
+		paths = append(paths, r.method+" "+r.path)
+	}
+	specID, sceID := (*requests)[1].uuid, (*requests)[2].uuid
+	c.Assert(paths, DeepEquals, []string{
+		"POST /api/v1/proj/launch",
+		"POST /api/v1/proj/item",
+		"POST /api/v1/proj/item/" + specID,
+		"POST /api/v1/proj/log",
+		"PUT /api/v1/proj/item/" + sceID,
+		"PUT /api/v1/proj/item/" + specID,
+		"PUT /api/v1/proj/launch/" + l.uuid + "/finish",
+	})
+	logs := (*requests)[3]
+	c.Assert(len(logs.logs), Equals, 1)
+	c.Assert(logs.logs[0].Level, Equals, "error")
+	c.Assert(logs.logs[0].Message, Equals, "Failed Step: a step\nexpected 1\n")
+	c.Assert(logs.files, Equals, 1)
+}
+
+func (s *MySuite) TestNewUUID(c *C) {
+	c.Assert(newUUID(), Matches, "[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}")
+}
@@ -24,6 +24,8 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/getgauge/common"
@@ -227,3 +229,38 @@ var WritePrevArgs = func(cmdArgs []string) {
 		logger.Fatalf(true, "Failed to write to %s. Reason: %s", prevCmdFile, err.Error())
 	}
 }
+
+// RenameSpecs gives the new contents of the failures of the last run, with the paths of the specs renamed by rename,
+// keyed by file. rename is given the paths relative to the project root, with forward slashes, and tells whether the
+// spec was renamed. It is empty if no failure refers to a renamed spec.
+func RenameSpecs(rename func(string) (string, bool)) (map[string][]byte, error) {
+	contents := make(map[string][]byte)
+	failuresFile := filepath.Join(config.ProjectRoot, common.DotGauge, failedFile)
+	if !common.FileExists(failuresFile) {
+		return contents, nil
+	}
+	b, err := ioutil.ReadFile(failuresFile)
+	if err != nil {
+		return nil, err
+	}
+	meta := newFailedMetaData()
+	if err := json.Unmarshal(b, meta); err != nil {
+		return nil, fmt.Errorf("Invalid last run information in %s. %s", failuresFile, err.Error())
+	}
+	changed := false
+	for i, item := range meta.FailedItems {
+		spec, line := item, ""
+		if colon := strings.LastIndex(item, ":"); colon != -1 {
+			if _, err := strconv.Atoi(item[colon+1:]); err == nil {
+				spec, line = item[:colon], item[colon:]
+			}
+		}
+		if to, ok := rename(filepath.ToSlash(spec)); ok {
+			meta.FailedItems[i], changed = filepath.FromSlash(to)+line, true
+		}
+	}
+	if changed {
+		contents[failuresFile] = []byte(getJSON(meta))
+	}
+	return contents, nil
+}
@@ -18,6 +18,7 @@
 package rerun
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -134,3 +135,19 @@ func (s *MySuite) TestGetAllFailedItems(c *C) {
 
 	c.Assert(failedItems, DeepEquals, []string{"scn1", "scn2", "scn3"})
 }
+
+func (s *MySuite) TestRenameSpecs(c *C) {
+	config.ProjectRoot = c.MkDir()
+	failedMeta.Args = []string{"--tags", "smoke"}
+	failedMeta.FailedItems = []string{filepath.Join("specs", "a.spec") + ":12", filepath.Join("specs", "a.spec"), filepath.Join("specs", "b.spec")}
+	writeFailedMeta(getJSON(failedMeta))
+	rename := func(p string) (string, bool) { return "specs/login.spec", p == "specs/a.spec" }
+
+	contents, err := RenameSpecs(rename)
+
+	c.Assert(err, IsNil)
+	meta := newFailedMetaData()
+	c.Assert(json.Unmarshal(contents[filepath.Join(config.ProjectRoot, common.DotGauge, failedFile)], meta), IsNil)
+	c.Assert(meta.Args, DeepEquals, []string{"--tags", "smoke"})
+	c.Assert(meta.FailedItems, DeepEquals, []string{filepath.Join("specs", "login.spec") + ":12", filepath.Join("specs", "login.spec"), filepath.Join("specs", "b.spec")})
+}
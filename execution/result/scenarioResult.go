@@ -18,6 +18,8 @@
 package result
 
 import (
+	"fmt"
+
 	"github.com/getgauge/gauge/gauge_messages"
 )
 
@@ -26,6 +28,8 @@ type ScenarioResult struct {
 	ScenarioDataTableRow      *gauge_messages.ProtoTable
 	ScenarioDataTableRowIndex int
 	ScenarioDataTable         *gauge_messages.ProtoTable
+	// OutputFile holds the file to which the output of the runner during the scenario is written, if it is captured
+	OutputFile string
 }
 
 func NewScenarioResult(sce *gauge_messages.ProtoScenario) *ScenarioResult {
@@ -107,3 +111,43 @@ func (s ScenarioResult) AddPostHook(f ...*gauge_messages.ProtoHookFailure) {
 func (s ScenarioResult) Item() interface{} {
 	return s.ProtoScenario
 }
+
+// FailureMessage returns the error message of the hook or step which failed the scenario, or empty if the scenario did not fail.
+func (s ScenarioResult) FailureMessage() string {
+	msg, _ := s.Failure()
+	return msg
+}
+
+// Failure returns the error message and stacktrace of the hook or step which failed the scenario.
+// Both are empty if the scenario did not fail.
+func (s ScenarioResult) Failure() (string, string) {
+	for _, h := range []*gauge_messages.ProtoHookFailure{s.ProtoScenario.GetPreHookFailure(), s.ProtoScenario.GetPostHookFailure()} {
+		if h != nil {
+			return h.GetErrorMessage(), h.GetStackTrace()
+		}
+	}
+	for _, items := range [][]*gauge_messages.ProtoItem{s.ProtoScenario.GetContexts(), s.ProtoScenario.GetScenarioItems(), s.ProtoScenario.GetTearDownSteps()} {
+		for _, item := range items {
+			if msg, stacktrace := itemFailure(item); msg != "" {
+				return msg, stacktrace
+			}
+		}
+	}
+	return "", ""
+}
+
+func itemFailure(item *gauge_messages.ProtoItem) (string, string) {
+	switch item.GetItemType() {
+	case gauge_messages.ProtoItem_Step:
+		if r := item.GetStep().GetStepExecutionResult().GetExecutionResult(); r.GetFailed() {
+			return fmt.Sprintf("Failed Step: %s\n%s", item.GetStep().GetActualText(), r.GetErrorMessage()), r.GetStackTrace()
+		}
+	case gauge_messages.ProtoItem_Concept:
+		for _, step := range item.GetConcept().GetSteps() {
+			if msg, stacktrace := itemFailure(step); msg != "" {
+				return msg, stacktrace
+			}
+		}
+	}
+	return "", ""
+}
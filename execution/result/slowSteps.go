@@ -0,0 +1,78 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package result
+
+import (
+	"sort"
+
+	"github.com/getgauge/gauge/gauge_messages"
+)
+
+// SlowStep is a step which took longer than the slow step threshold to execute.
+type SlowStep struct {
+	Text          string
+	SpecFile      string
+	Scenario      string
+	ExecutionTime int64 // in milliseconds
+}
+
+// CollectSlowSteps sets the steps which took longer than threshold milliseconds, the slowest first and at most max of them.
+// A threshold of zero collects nothing.
+func (sr *SuiteResult) CollectSlowSteps(threshold int64, max int) {
+	sr.SlowSteps = nil
+	if threshold <= 0 {
+		return
+	}
+	sr.EachSpecResult(func(specResult *SpecResult) {
+		spec := specResult.ProtoSpec
+		for _, item := range spec.GetItems() {
+			scenario := item.GetScenario()
+			if item.GetTableDrivenScenario() != nil {
+				scenario = item.GetTableDrivenScenario().GetScenario()
+			}
+			if scenario == nil {
+				continue
+			}
+			for _, items := range [][]*gauge_messages.ProtoItem{scenario.GetContexts(), scenario.GetScenarioItems(), scenario.GetTearDownSteps()} {
+				sr.collectSlowSteps(items, threshold, spec.GetFileName(), scenario.GetScenarioHeading())
+			}
+		}
+	})
+	sort.SliceStable(sr.SlowSteps, func(i, j int) bool {
+		return sr.SlowSteps[i].ExecutionTime > sr.SlowSteps[j].ExecutionTime
+	})
+	if len(sr.SlowSteps) > max {
+		sr.SlowSteps = sr.SlowSteps[:max]
+	}
+}
+
+func (sr *SuiteResult) collectSlowSteps(items []*gauge_messages.ProtoItem, threshold int64, specFile, scenario string) {
+	for _, item := range items {
+		if concept := item.GetConcept(); concept != nil {
+			sr.collectSlowSteps(concept.GetSteps(), threshold, specFile, scenario)
+			continue
+		}
+		step := item.GetStep()
+		if step == nil {
+			continue
+		}
+		if t := step.GetStepExecutionResult().GetExecutionResult().GetExecutionTime(); t > threshold {
+			sr.SlowSteps = append(sr.SlowSteps, &SlowStep{Text: step.GetActualText(), SpecFile: specFile, Scenario: scenario, ExecutionTime: t})
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package result
+
+import (
+	"github.com/getgauge/gauge/gauge_messages"
+	gc "gopkg.in/check.v1"
+)
+
+func stepItem(text string, time int64) *gauge_messages.ProtoItem {
+	res := &gauge_messages.ProtoStepExecutionResult{ExecutionResult: &gauge_messages.ProtoExecutionResult{ExecutionTime: time}}
+	return &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_Step, Step: &gauge_messages.ProtoStep{ActualText: text, StepExecutionResult: res}}
+}
+
+func (s *MySuite) TestCollectSlowSteps(c *gc.C) {
+	concept := &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_Concept, Concept: &gauge_messages.ProtoConcept{
+		ConceptStep: &gauge_messages.ProtoStep{ActualText: "concept", StepExecutionResult: &gauge_messages.ProtoStepExecutionResult{ExecutionResult: &gauge_messages.ProtoExecutionResult{ExecutionTime: 900}}},
+		Steps:       []*gauge_messages.ProtoItem{stepItem("step in concept", 800), stepItem("fast step in concept", 100)},
+	}}
+	scenario := &gauge_messages.ProtoScenario{
+		ScenarioHeading: "scenario",
+		Contexts:        []*gauge_messages.ProtoItem{stepItem("context", 600)},
+		ScenarioItems:   []*gauge_messages.ProtoItem{stepItem("fast step", 10), concept},
+		TearDownSteps:   []*gauge_messages.ProtoItem{stepItem("teardown", 700)},
+	}
+	tableDriven := &gauge_messages.ProtoScenario{ScenarioHeading: "table driven", ScenarioItems: []*gauge_messages.ProtoItem{stepItem("row step", 1000)}}
+	spec := &gauge_messages.ProtoSpec{FileName: "example.spec", Items: []*gauge_messages.ProtoItem{
+		{ItemType: gauge_messages.ProtoItem_Scenario, Scenario: scenario},
+		{ItemType: gauge_messages.ProtoItem_TableDrivenScenario, TableDrivenScenario: &gauge_messages.ProtoTableDrivenScenario{Scenario: tableDriven}},
+	}}
+	sr := &SuiteResult{SpecResults: []*SpecResult{{ProtoSpec: spec}}}
+
+	sr.CollectSlowSteps(500, 3)
+
+	c.Assert(sr.SlowSteps, gc.DeepEquals, []*SlowStep{
+		{Text: "row step", SpecFile: "example.spec", Scenario: "table driven", ExecutionTime: 1000},
+		{Text: "step in concept", SpecFile: "example.spec", Scenario: "scenario", ExecutionTime: 800},
+		{Text: "teardown", SpecFile: "example.spec", Scenario: "scenario", ExecutionTime: 700},
+	})
+}
+
+func (s *MySuite) TestCollectSlowStepsWithoutThreshold(c *gc.C) {
+	scenario := &gauge_messages.ProtoScenario{ScenarioItems: []*gauge_messages.ProtoItem{stepItem("step", 1000)}}
+	spec := &gauge_messages.ProtoSpec{Items: []*gauge_messages.ProtoItem{{ItemType: gauge_messages.ProtoItem_Scenario, Scenario: scenario}}}
+	sr := &SuiteResult{SpecResults: []*SpecResult{{ProtoSpec: spec}}}
+
+	sr.CollectSlowSteps(0, 10)
+
+	c.Assert(sr.SlowSteps, gc.IsNil)
+}
@@ -32,6 +32,8 @@ type SpecResult struct {
 	Skipped              bool
 	ScenarioSkippedCount int
 	Errors               []*gauge_messages.Error
+	// spilled locates the items of the result, when they are held by a spill store instead of in memory
+	spilled *spilledItems
 }
 
 // SetFailure sets the result to failed
@@ -106,6 +108,63 @@ func (specResult *SpecResult) AddTableRelatedScenarioResult(scenarioResults [][]
 	specResult.ScenarioCount += numberOfScenarios
 }
 
+// ScenarioResults gives the results of the scenarios of the spec, in the order they were executed.
+func (specResult *SpecResult) ScenarioResults() []*gauge_messages.ProtoScenario {
+	var scenarios []*gauge_messages.ProtoScenario
+	for _, item := range specResult.ProtoSpec.Items {
+		if s := scenarioOf(item); s != nil {
+			scenarios = append(scenarios, *s)
+		}
+	}
+	return scenarios
+}
+
+// ReplaceScenarioResult replaces the result of the scenario at the given position in ScenarioResults, e.g. with the result
+// of retrying it. The spec is no longer failed if it was failed only because of the replaced scenario.
+func (specResult *SpecResult) ReplaceScenarioResult(index int, scenario *gauge_messages.ProtoScenario) {
+	for _, item := range specResult.ProtoSpec.Items {
+		s := scenarioOf(item)
+		if s == nil {
+			continue
+		}
+		if index > 0 {
+			index--
+			continue
+		}
+		wasFailed := (*s).GetExecutionStatus() == gauge_messages.ExecutionStatus_FAILED
+		*s = scenario
+		specResult.AddExecTime(scenario.GetExecutionTime())
+		if !wasFailed || scenario.GetExecutionStatus() == gauge_messages.ExecutionStatus_FAILED {
+			return
+		}
+		specResult.ScenarioFailedCount--
+		if item.GetItemType() == gauge_messages.ProtoItem_TableDrivenScenario && !item.GetTableDrivenScenario().GetIsScenarioTableDriven() {
+			specResult.removeFailedDataTableRow(item.GetTableDrivenScenario().GetTableRowIndex())
+		}
+		specResult.IsFailed = specResult.ScenarioFailedCount > 0 || len(specResult.GetPreHook()) > 0 || len(specResult.GetPostHook()) > 0
+		return
+	}
+}
+
+func scenarioOf(item *gauge_messages.ProtoItem) **gauge_messages.ProtoScenario {
+	switch item.GetItemType() {
+	case gauge_messages.ProtoItem_Scenario:
+		return &item.Scenario
+	case gauge_messages.ProtoItem_TableDrivenScenario:
+		return &item.TableDrivenScenario.Scenario
+	}
+	return nil
+}
+
+func (specResult *SpecResult) removeFailedDataTableRow(row int32) {
+	for i, r := range specResult.FailedDataTableRows {
+		if r == row {
+			specResult.FailedDataTableRows = append(specResult.FailedDataTableRows[:i], specResult.FailedDataTableRows[i+1:]...)
+			return
+		}
+	}
+}
+
 func (specResult *SpecResult) AddExecTime(execTime int64) {
 	specResult.ExecutionTime += execTime
 }
@@ -63,3 +63,21 @@ func (s *MySuite) TestAddTableRelatedScenarioResult(c *gc.C) {
 	c.Assert(specResult.ScenarioFailedCount, gc.Equals, 0)
 
 }
+
+func (s *MySuite) TestReplaceScenarioResult(c *gc.C) {
+	failed := &gauge_messages.ProtoScenario{ScenarioHeading: "flaky", ExecutionStatus: gauge_messages.ExecutionStatus_FAILED}
+	specResult := &SpecResult{ProtoSpec: &gauge_messages.ProtoSpec{Items: []*gauge_messages.ProtoItem{
+		{ItemType: gauge_messages.ProtoItem_Comment, Comment: &gauge_messages.ProtoComment{Text: "comment"}},
+		{ItemType: gauge_messages.ProtoItem_Scenario, Scenario: &gauge_messages.ProtoScenario{ScenarioHeading: "stable"}},
+		{ItemType: gauge_messages.ProtoItem_TableDrivenScenario, TableDrivenScenario: &gauge_messages.ProtoTableDrivenScenario{Scenario: failed, TableRowIndex: 1}},
+	}}, IsFailed: true, ScenarioFailedCount: 1, FailedDataTableRows: []int32{1}}
+	passed := &gauge_messages.ProtoScenario{ScenarioHeading: "flaky", ExecutionStatus: gauge_messages.ExecutionStatus_PASSED, ExecutionTime: 10}
+
+	specResult.ReplaceScenarioResult(1, passed)
+
+	c.Assert(specResult.ScenarioResults()[1], gc.Equals, passed)
+	c.Assert(specResult.GetFailed(), gc.Equals, false)
+	c.Assert(specResult.ScenarioFailedCount, gc.Equals, 0)
+	c.Assert(specResult.FailedDataTableRows, gc.HasLen, 0)
+	c.Assert(specResult.ExecutionTime, gc.Equals, int64(10))
+}
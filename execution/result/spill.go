@@ -0,0 +1,126 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package result
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+	"github.com/golang/protobuf/proto"
+)
+
+// SpillStore holds the items of completed spec results on disk, so that large suites do not keep the entire result
+// tree in memory during execution. A spilled spec result retains everything but its items, which are read back one
+// spec at a time by the consumers of the suite result.
+type SpillStore struct {
+	dir   string
+	mutex sync.Mutex
+	count int
+}
+
+type spilledItems struct {
+	store *SpillStore
+	file  string
+}
+
+// NewSpillStore creates a spill store in a new temporary directory.
+func NewSpillStore() (*SpillStore, error) {
+	dir, err := ioutil.TempDir("", "gauge-results")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create directory for spilling results. %s", err.Error())
+	}
+	return &SpillStore{dir: dir}, nil
+}
+
+// Spill writes a deep copy of the items of the spec result to disk, and gives a spec result holding everything but
+// the items, which are read back by Load. The given result is not changed, as the listeners of the execution events
+// may still be reading it. It is freed once they are done with it.
+func (s *SpillStore) Spill(r *SpecResult) (*SpecResult, error) {
+	spec := proto.Clone(r.ProtoSpec).(*gauge_messages.ProtoSpec)
+	b, err := proto.Marshal(&gauge_messages.ProtoSpec{Items: spec.Items})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal result of %s. %s", spec.GetFileName(), err.Error())
+	}
+	s.mutex.Lock()
+	f := filepath.Join(s.dir, strconv.Itoa(s.count))
+	s.count++
+	s.mutex.Unlock()
+	if err = ioutil.WriteFile(f, b, common.NewFilePermissions); err != nil {
+		return nil, fmt.Errorf("Failed to write result of %s. %s", spec.GetFileName(), err.Error())
+	}
+	spec.Items = nil
+	spilled := *r
+	spilled.ProtoSpec = spec
+	spilled.spilled = &spilledItems{store: s, file: f}
+	return &spilled, nil
+}
+
+// Remove deletes the spilled items. It is called once all the consumers of the suite result are done.
+func (s *SpillStore) Remove() error {
+	return os.RemoveAll(s.dir)
+}
+
+// SpillStore gives the store to which the spec result was spilled, or nil if it is held in memory.
+func (r *SpecResult) SpillStore() *SpillStore {
+	if r.spilled == nil {
+		return nil
+	}
+	return r.spilled.store
+}
+
+// Load gives the spec result with its items. The items of a spilled result are read into a copy of it, so that they
+// are held in memory only while the copy is used. A result which is not spilled is given as it is.
+func (r *SpecResult) Load() (*SpecResult, error) {
+	if r.spilled == nil {
+		return r, nil
+	}
+	b, err := ioutil.ReadFile(r.spilled.file)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read result of %s. %s", r.ProtoSpec.GetFileName(), err.Error())
+	}
+	items := &gauge_messages.ProtoSpec{}
+	if err = proto.Unmarshal(b, items); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal result of %s. %s", r.ProtoSpec.GetFileName(), err.Error())
+	}
+	loaded := *r
+	loaded.ProtoSpec = proto.Clone(r.ProtoSpec).(*gauge_messages.ProtoSpec)
+	loaded.ProtoSpec.Items = items.Items
+	loaded.spilled = nil
+	return &loaded, nil
+}
+
+// EachSpecResult calls f with every spec result of the suite, along with its items. Spilled results are read one at
+// a time, so that the whole result tree is not held in memory. A spilled result which cannot be read is given without
+// its items.
+func (sr *SuiteResult) EachSpecResult(f func(*SpecResult)) {
+	for _, r := range sr.SpecResults {
+		loaded, err := r.Load()
+		if err != nil {
+			logger.Errorf(true, "%s", err.Error())
+			loaded = r
+		}
+		f(loaded)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package result
+
+import (
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/gauge_messages"
+	gc "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestSpillAndLoadSpecResultItems(c *gc.C) {
+	items := []*gauge_messages.ProtoItem{
+		{ItemType: gauge_messages.ProtoItem_Comment, Comment: &gauge_messages.ProtoComment{Text: "comment"}},
+		{ItemType: gauge_messages.ProtoItem_Scenario, Scenario: &gauge_messages.ProtoScenario{ScenarioHeading: "scenario", ExecutionStatus: gauge_messages.ExecutionStatus_FAILED}},
+	}
+	specResult := &SpecResult{ProtoSpec: &gauge_messages.ProtoSpec{FileName: "foo.spec", Items: items}, IsFailed: true}
+	store, err := NewSpillStore()
+	c.Assert(err, gc.IsNil)
+
+	spilled, err := store.Spill(specResult)
+	c.Assert(err, gc.IsNil)
+	c.Assert(len(specResult.ProtoSpec.Items), gc.Equals, 2)
+	c.Assert(len(spilled.ProtoSpec.Items), gc.Equals, 0)
+	c.Assert(spilled.ProtoSpec.FileName, gc.Equals, "foo.spec")
+	c.Assert(spilled.IsFailed, gc.Equals, true)
+	c.Assert(spilled.SpillStore(), gc.Equals, store)
+
+	loaded, err := spilled.Load()
+	c.Assert(err, gc.IsNil)
+	c.Assert(len(loaded.ProtoSpec.Items), gc.Equals, 2)
+	c.Assert(loaded.ProtoSpec.Items[0].Comment.Text, gc.Equals, "comment")
+	c.Assert(loaded.ProtoSpec.Items[1].Scenario.ScenarioHeading, gc.Equals, "scenario")
+	c.Assert(len(spilled.ProtoSpec.Items), gc.Equals, 0)
+
+	c.Assert(store.Remove(), gc.IsNil)
+	c.Assert(common.DirExists(store.dir), gc.Equals, false)
+}
+
+func (s *MySuite) TestEachSpecResultReadsSpilledResults(c *gc.C) {
+	store, err := NewSpillStore()
+	c.Assert(err, gc.IsNil)
+	defer store.Remove()
+	inMemory := &SpecResult{ProtoSpec: &gauge_messages.ProtoSpec{FileName: "foo.spec", Items: []*gauge_messages.ProtoItem{{ItemType: gauge_messages.ProtoItem_Comment}}}}
+	spilled, err := store.Spill(&SpecResult{ProtoSpec: &gauge_messages.ProtoSpec{FileName: "bar.spec", Items: []*gauge_messages.ProtoItem{{ItemType: gauge_messages.ProtoItem_Scenario}}}})
+	c.Assert(err, gc.IsNil)
+	sr := &SuiteResult{SpecResults: []*SpecResult{inMemory, spilled}}
+
+	var items []gauge_messages.ProtoItem_ItemType
+	sr.EachSpecResult(func(r *SpecResult) {
+		for _, i := range r.ProtoSpec.Items {
+			items = append(items, i.ItemType)
+		}
+	})
+
+	c.Assert(items, gc.DeepEquals, []gauge_messages.ProtoItem_ItemType{gauge_messages.ProtoItem_Comment, gauge_messages.ProtoItem_Scenario})
+}
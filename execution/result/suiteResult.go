@@ -44,6 +44,8 @@ type SuiteResult struct {
 	PostHookMessages    []string
 	PreHookScreenshots  [][]byte
 	PostHookScreenshots [][]byte
+	Metadata            map[string]string
+	SlowSteps           []*SlowStep
 }
 
 // NewSuiteResult is a constructor for SuitResult
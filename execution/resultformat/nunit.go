@@ -0,0 +1,197 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package resultformat
+
+import (
+	"encoding/xml"
+	"strconv"
+	"time"
+
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/version"
+)
+
+type nunitTestRun struct {
+	XMLName       xml.Name       `xml:"test-run"`
+	ID            string         `xml:"id,attr"`
+	TestCaseCount int            `xml:"testcasecount,attr"`
+	Result        string         `xml:"result,attr"`
+	Total         int            `xml:"total,attr"`
+	Passed        int            `xml:"passed,attr"`
+	Failed        int            `xml:"failed,attr"`
+	Inconclusive  int            `xml:"inconclusive,attr"`
+	Skipped       int            `xml:"skipped,attr"`
+	Asserts       int            `xml:"asserts,attr"`
+	EngineVersion string         `xml:"engine-version,attr"`
+	StartTime     string         `xml:"start-time,attr"`
+	EndTime       string         `xml:"end-time,attr"`
+	Duration      string         `xml:"duration,attr"`
+	Suite         nunitTestSuite `xml:"test-suite"`
+}
+
+type nunitTestSuite struct {
+	Type          string           `xml:"type,attr"`
+	ID            string           `xml:"id,attr"`
+	Name          string           `xml:"name,attr"`
+	FullName      string           `xml:"fullname,attr"`
+	TestCaseCount int              `xml:"testcasecount,attr"`
+	Result        string           `xml:"result,attr"`
+	Label         string           `xml:"label,attr,omitempty"`
+	Site          string           `xml:"site,attr,omitempty"`
+	Duration      string           `xml:"duration,attr"`
+	Total         int              `xml:"total,attr"`
+	Passed        int              `xml:"passed,attr"`
+	Failed        int              `xml:"failed,attr"`
+	Skipped       int              `xml:"skipped,attr"`
+	Failure       *nunitFailure    `xml:"failure"`
+	Suites        []nunitTestSuite `xml:"test-suite"`
+	Cases         []nunitTestCase  `xml:"test-case"`
+}
+
+type nunitTestCase struct {
+	ID         string           `xml:"id,attr"`
+	Name       string           `xml:"name,attr"`
+	FullName   string           `xml:"fullname,attr"`
+	Result     string           `xml:"result,attr"`
+	Duration   string           `xml:"duration,attr"`
+	Properties *nunitProperties `xml:"properties"`
+	Failure    *nunitFailure    `xml:"failure"`
+	Reason     *nunitReason     `xml:"reason"`
+}
+
+type nunitProperties struct {
+	Properties []nunitProperty `xml:"property"`
+}
+
+type nunitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type nunitFailure struct {
+	Message    cdata `xml:"message"`
+	StackTrace cdata `xml:"stack-trace"`
+}
+
+type nunitReason struct {
+	Message cdata `xml:"message"`
+}
+
+type nunitIDSequence struct {
+	next int
+}
+
+func (s *nunitIDSequence) id() string {
+	s.next++
+	return strconv.Itoa(s.next)
+}
+
+func newNUnitFailure(f *failure) *nunitFailure {
+	if f == nil {
+		return nil
+	}
+	return &nunitFailure{Message: cdata{f.message}, StackTrace: cdata{f.stacktrace}}
+}
+
+func nunitResult(status gauge_messages.ExecutionStatus) string {
+	switch status {
+	case gauge_messages.ExecutionStatus_FAILED:
+		return "Failed"
+	case gauge_messages.ExecutionStatus_SKIPPED:
+		return "Skipped"
+	}
+	return "Passed"
+}
+
+func nunitSuiteResult(failed bool) string {
+	if failed {
+		return "Failed"
+	}
+	return "Passed"
+}
+
+func toNUnit(r *run) interface{} {
+	ids := &nunitIDSequence{}
+	total, passed, failed, skipped := r.total(), r.count(gauge_messages.ExecutionStatus_PASSED), r.count(gauge_messages.ExecutionStatus_FAILED), r.count(gauge_messages.ExecutionStatus_SKIPPED)
+	runFailed := failed > 0 || len(r.errors) > 0
+	root := nunitTestSuite{
+		Type:          "TestSuite",
+		ID:            ids.id(),
+		Name:          r.project,
+		FullName:      r.project,
+		TestCaseCount: total,
+		Result:        nunitSuiteResult(runFailed),
+		Duration:      seconds(r.duration),
+		Total:         total,
+		Passed:        passed,
+		Failed:        failed,
+		Skipped:       skipped,
+	}
+	if len(r.errors) > 0 {
+		root.Label, root.Site = "Error", "SetUp"
+		root.Failure = newNUnitFailure(&r.errors[0])
+	}
+	for _, s := range r.specs {
+		specFailed := s.count(gauge_messages.ExecutionStatus_FAILED) > 0 || s.failure != nil
+		fixture := nunitTestSuite{
+			Type:          "TestFixture",
+			ID:            ids.id(),
+			Name:          s.name,
+			FullName:      s.fileName,
+			TestCaseCount: len(s.cases),
+			Result:        nunitSuiteResult(specFailed),
+			Duration:      seconds(s.duration),
+			Total:         len(s.cases),
+			Passed:        s.count(gauge_messages.ExecutionStatus_PASSED),
+			Failed:        s.count(gauge_messages.ExecutionStatus_FAILED),
+			Skipped:       s.count(gauge_messages.ExecutionStatus_SKIPPED),
+			Failure:       newNUnitFailure(s.failure),
+		}
+		if s.failure != nil {
+			fixture.Label, fixture.Site = "Error", "SetUp"
+		}
+		for _, c := range s.cases {
+			tc := nunitTestCase{ID: ids.id(), Name: c.name, FullName: s.name + "." + c.name, Result: nunitResult(c.status), Duration: seconds(c.duration), Failure: newNUnitFailure(c.failure)}
+			if len(c.tags) > 0 {
+				tc.Properties = &nunitProperties{}
+				for _, tag := range c.tags {
+					tc.Properties.Properties = append(tc.Properties.Properties, nunitProperty{Name: "Category", Value: tag})
+				}
+			}
+			if c.status == gauge_messages.ExecutionStatus_SKIPPED {
+				tc.Reason = &nunitReason{Message: cdata{c.skipReason}}
+			}
+			fixture.Cases = append(fixture.Cases, tc)
+		}
+		root.Suites = append(root.Suites, fixture)
+	}
+	return nunitTestRun{
+		ID:            "0",
+		TestCaseCount: total,
+		Result:        root.Result,
+		Total:         total,
+		Passed:        passed,
+		Failed:        failed,
+		Skipped:       skipped,
+		EngineVersion: version.FullVersion(),
+		StartTime:     r.start.UTC().Format(time.RFC3339),
+		EndTime:       r.end.UTC().Format(time.RFC3339),
+		Duration:      seconds(r.duration),
+		Suite:         root,
+	}
+}
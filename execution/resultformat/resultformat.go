@@ -0,0 +1,248 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package resultformat writes the result of the suite as xUnit v2 and NUnit3 XML files, for tools which only ingest those formats.
+package resultformat
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/util"
+)
+
+const (
+	// XUnit writes the result in xUnit.net v2 XML format
+	XUnit = "xunit"
+	// NUnit3 writes the result in NUnit3 XML format
+	NUnit3 = "nunit3"
+
+	resultFile = "result.xml"
+)
+
+// Formats are the formats in which the result of the suite is written to the reports directory.
+var Formats []string
+
+var writers = map[string]func(*run) interface{}{
+	XUnit:  toXUnit,
+	NUnit3: toNUnit,
+}
+
+// Validate checks if all the given formats are supported.
+func Validate(formats []string) error {
+	for _, f := range formats {
+		if _, ok := writers[f]; !ok {
+			return fmt.Errorf("Invalid result format '%s'. Possible formats are %s and %s", f, XUnit, NUnit3)
+		}
+	}
+	return nil
+}
+
+// run is the result of the suite, in the shape common to the result formats.
+type run struct {
+	project  string
+	start    time.Time
+	end      time.Time
+	duration int64
+	errors   []failure
+	specs    []*spec
+}
+
+type spec struct {
+	name     string
+	fileName string
+	duration int64
+	failure  *failure
+	cases    []*testCase
+}
+
+type testCase struct {
+	name       string
+	tags       []string
+	status     gauge_messages.ExecutionStatus
+	duration   int64
+	failure    *failure
+	skipReason string
+}
+
+type failure struct {
+	message    string
+	stacktrace string
+}
+
+type cdata struct {
+	Value string `xml:",cdata"`
+}
+
+func (s *spec) count(status gauge_messages.ExecutionStatus) int {
+	n := 0
+	for _, c := range s.cases {
+		if c.status == status {
+			n++
+		}
+	}
+	return n
+}
+
+func (r *run) count(status gauge_messages.ExecutionStatus) int {
+	n := 0
+	for _, s := range r.specs {
+		n += s.count(status)
+	}
+	return n
+}
+
+func (r *run) total() int {
+	n := 0
+	for _, s := range r.specs {
+		n += len(s.cases)
+	}
+	return n
+}
+
+func hookFailure(hooks ...[]*gauge_messages.ProtoHookFailure) *failure {
+	for _, h := range hooks {
+		if len(h) > 0 {
+			return &failure{message: h[0].GetErrorMessage(), stacktrace: h[0].GetStackTrace()}
+		}
+	}
+	return nil
+}
+
+func newRun(res *result.SuiteResult, start, end time.Time) *run {
+	r := &run{project: filepath.Base(config.ProjectRoot), start: start, end: end, duration: res.ExecutionTime}
+	if f := hookFailure(res.GetPreHook(), res.GetPostHook()); f != nil {
+		r.errors = append(r.errors, *f)
+	}
+	for _, e := range res.UnhandledErrors {
+		r.errors = append(r.errors, failure{message: e.Error()})
+	}
+	res.EachSpecResult(func(specRes *result.SpecResult) {
+		r.specs = append(r.specs, newSpec(specRes))
+	})
+	return r
+}
+
+func newSpec(res *result.SpecResult) *spec {
+	s := &spec{
+		name:     res.ProtoSpec.GetSpecHeading(),
+		fileName: filepath.ToSlash(util.RelPathToProjectRoot(res.ProtoSpec.GetFileName())),
+		duration: res.ExecutionTime,
+		failure:  hookFailure(res.GetPreHook(), res.GetPostHook()),
+	}
+	for _, item := range res.ProtoSpec.GetItems() {
+		switch item.GetItemType() {
+		case gauge_messages.ProtoItem_Scenario:
+			s.cases = append(s.cases, newTestCase(item.GetScenario(), item.GetScenario().GetScenarioHeading()))
+		case gauge_messages.ProtoItem_TableDrivenScenario:
+			tds := item.GetTableDrivenScenario()
+			name := tds.GetScenario().GetScenarioHeading()
+			if tds.GetIsScenarioTableDriven() {
+				name = fmt.Sprintf("%s [row %d]", name, tds.GetScenarioTableRowIndex()+1)
+			}
+			s.cases = append(s.cases, newTestCase(tds.GetScenario(), name))
+		}
+	}
+	return s
+}
+
+func newTestCase(sce *gauge_messages.ProtoScenario, name string) *testCase {
+	c := &testCase{name: name, tags: sce.GetTags(), status: sce.GetExecutionStatus(), duration: sce.GetExecutionTime()}
+	switch c.status {
+	case gauge_messages.ExecutionStatus_FAILED:
+		msg, stacktrace := result.NewScenarioResult(sce).Failure()
+		c.failure = &failure{message: msg, stacktrace: stacktrace}
+	case gauge_messages.ExecutionStatus_SKIPPED:
+		c.skipReason = strings.Join(sce.GetSkipErrors(), "\n")
+	}
+	return c
+}
+
+// seconds formats a duration in milliseconds as seconds.
+func seconds(ms int64) string {
+	return fmt.Sprintf("%.3f", float64(ms)/1000)
+}
+
+// ListenSuiteEnd writes the result of the suite in each of the Formats, to <reports dir>/<format>/result.xml.
+func ListenSuiteEnd(wg *sync.WaitGroup) {
+	if len(Formats) == 0 {
+		return
+	}
+	ch := make(chan event.ExecutionEvent, 0)
+	event.Register(ch, event.SuiteStart, event.SuiteEnd)
+	wg.Add(1)
+
+	go func() {
+		start := time.Now()
+		for {
+			e := <-ch
+			switch e.Topic {
+			case event.SuiteStart:
+				start = time.Now()
+			case event.SuiteEnd:
+				r := newRun(e.Result.(*result.SuiteResult), start, time.Now())
+				for _, f := range Formats {
+					write(r, f)
+				}
+				wg.Done()
+			}
+		}
+	}()
+}
+
+func write(r *run, format string) {
+	b, err := xml.MarshalIndent(writers[format](r), "", "  ")
+	if err != nil {
+		logger.Errorf(true, "Failed to generate %s result. %s", format, err.Error())
+		return
+	}
+	dir := filepath.Join(reportsDir(), format)
+	if err := os.MkdirAll(dir, common.NewDirectoryPermissions); err != nil {
+		logger.Errorf(true, "Failed to create directory %s. %s", dir, err.Error())
+		return
+	}
+	file := filepath.Join(dir, resultFile)
+	if err := ioutil.WriteFile(file, append([]byte(xml.Header), b...), common.NewFilePermissions); err != nil {
+		logger.Errorf(true, "Failed to write %s result to %s. %s", format, file, err.Error())
+		return
+	}
+	logger.Infof(true, "Successfully generated %s result in => %s", format, file)
+}
+
+func reportsDir() string {
+	dir := os.Getenv(env.GaugeReportsDir)
+	if dir == "" {
+		dir = "reports"
+	}
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(config.ProjectRoot, dir)
+}
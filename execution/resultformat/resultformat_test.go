@@ -0,0 +1,118 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package resultformat
+
+import (
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge_messages"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) SetUpTest(c *C) {
+	dir, err := ioutil.TempDir("", "resultformat")
+	c.Assert(err, IsNil)
+	config.ProjectRoot = dir
+}
+
+func (s *MySuite) TearDownTest(c *C) {
+	os.RemoveAll(config.ProjectRoot)
+	os.Unsetenv(env.GaugeReportsDir)
+}
+
+func failedStep(msg string) *gauge_messages.ProtoItem {
+	return &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_Step, Step: &gauge_messages.ProtoStep{ActualText: "a step",
+		StepExecutionResult: &gauge_messages.ProtoStepExecutionResult{ExecutionResult: &gauge_messages.ProtoExecutionResult{Failed: true, ErrorMessage: msg, StackTrace: "at foo"}}}}
+}
+
+func suiteResult() *result.SuiteResult {
+	spec := &gauge_messages.ProtoSpec{SpecHeading: "Login", FileName: filepath.Join(config.ProjectRoot, "specs", "login.spec"), Items: []*gauge_messages.ProtoItem{
+		{ItemType: gauge_messages.ProtoItem_Scenario, Scenario: &gauge_messages.ProtoScenario{ScenarioHeading: "Valid user", ExecutionStatus: gauge_messages.ExecutionStatus_PASSED, ExecutionTime: 1500, Tags: []string{"smoke"}}},
+		{ItemType: gauge_messages.ProtoItem_Scenario, Scenario: &gauge_messages.ProtoScenario{ScenarioHeading: "Invalid user", ExecutionStatus: gauge_messages.ExecutionStatus_FAILED, ScenarioItems: []*gauge_messages.ProtoItem{failedStep("expected <error>")}}},
+		{ItemType: gauge_messages.ProtoItem_TableDrivenScenario, TableDrivenScenario: &gauge_messages.ProtoTableDrivenScenario{IsScenarioTableDriven: true, ScenarioTableRowIndex: 1,
+			Scenario: &gauge_messages.ProtoScenario{ScenarioHeading: "Locked user", ExecutionStatus: gauge_messages.ExecutionStatus_SKIPPED, SkipErrors: []string{"step not implemented"}}}},
+	}}
+	return &result.SuiteResult{ExecutionTime: 2000, SpecResults: []*result.SpecResult{{ProtoSpec: spec, ExecutionTime: 1500, IsFailed: true}}, UnhandledErrors: []error{errors.New("runner crashed")}}
+}
+
+func (s *MySuite) TestValidate(c *C) {
+	c.Assert(Validate([]string{XUnit, NUnit3}), IsNil)
+	c.Assert(Validate([]string{"junit"}), ErrorMatches, "Invalid result format 'junit'.*")
+}
+
+func (s *MySuite) TestNewRun(c *C) {
+	r := newRun(suiteResult(), time.Now(), time.Now())
+
+	c.Assert(r.total(), Equals, 3)
+	c.Assert(r.count(gauge_messages.ExecutionStatus_FAILED), Equals, 1)
+	c.Assert(r.errors, DeepEquals, []failure{{message: "runner crashed"}})
+	sp := r.specs[0]
+	c.Assert(sp.fileName, Equals, "specs/login.spec")
+	c.Assert(sp.cases[1].failure, DeepEquals, &failure{message: "Failed Step: a step\nexpected <error>", stacktrace: "at foo"})
+	c.Assert(sp.cases[2].name, Equals, "Locked user [row 2]")
+	c.Assert(sp.cases[2].skipReason, Equals, "step not implemented")
+}
+
+func (s *MySuite) TestToXUnit(c *C) {
+	b, err := xml.Marshal(toXUnit(newRun(suiteResult(), time.Date(2017, 3, 4, 10, 20, 30, 0, time.UTC), time.Now())))
+	c.Assert(err, IsNil)
+	out := string(b)
+
+	c.Assert(strings.Contains(out, `<assembly name="`+filepath.Base(config.ProjectRoot)+`" run-date="2017-03-04" run-time="10:20:30" time="2.000" total="3" passed="1" failed="1" skipped="1" errors="1">`), Equals, true)
+	c.Assert(strings.Contains(out, `<collection name="Login" time="1.500" total="3" passed="1" failed="1" skipped="1">`), Equals, true)
+	c.Assert(strings.Contains(out, `<test name="Login: Valid user" type="specs/login.spec" method="Valid user" time="1.500" result="Pass"><traits><trait name="tag" value="smoke"></trait></traits></test>`), Equals, true)
+	c.Assert(strings.Contains(out, `result="Fail"><failure><message><![CDATA[Failed Step: a step`+"\n"+`expected <error>]]></message><stack-trace><![CDATA[at foo]]></stack-trace></failure></test>`), Equals, true)
+	c.Assert(strings.Contains(out, `result="Skip"><reason><![CDATA[step not implemented]]></reason></test>`), Equals, true)
+}
+
+func (s *MySuite) TestToNUnit(c *C) {
+	b, err := xml.Marshal(toNUnit(newRun(suiteResult(), time.Now(), time.Now())))
+	c.Assert(err, IsNil)
+	out := string(b)
+
+	c.Assert(strings.HasPrefix(out, `<test-run id="0" testcasecount="3" result="Failed" total="3" passed="1" failed="1" inconclusive="0" skipped="1"`), Equals, true)
+	c.Assert(strings.Contains(out, `<test-suite type="TestFixture" id="2" name="Login" fullname="specs/login.spec" testcasecount="3" result="Failed" duration="1.500" total="3" passed="1" failed="1" skipped="1">`), Equals, true)
+	c.Assert(strings.Contains(out, `<test-case id="3" name="Valid user" fullname="Login.Valid user" result="Passed" duration="1.500"><properties><property name="Category" value="smoke"></property></properties></test-case>`), Equals, true)
+	c.Assert(strings.Contains(out, `<test-case id="5" name="Locked user [row 2]" fullname="Login.Locked user [row 2]" result="Skipped" duration="0.000"><reason><message><![CDATA[step not implemented]]></message></reason></test-case>`), Equals, true)
+}
+
+func (s *MySuite) TestWriteToReportsDir(c *C) {
+	os.Setenv(env.GaugeReportsDir, "out")
+
+	write(newRun(suiteResult(), time.Now(), time.Now()), NUnit3)
+
+	b, err := ioutil.ReadFile(filepath.Join(config.ProjectRoot, "out", NUnit3, resultFile))
+	c.Assert(err, IsNil)
+	c.Assert(strings.HasPrefix(string(b), xml.Header+"<test-run"), Equals, true)
+}
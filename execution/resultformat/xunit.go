@@ -0,0 +1,147 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package resultformat
+
+import (
+	"encoding/xml"
+
+	"github.com/getgauge/gauge/gauge_messages"
+)
+
+type xunitAssemblies struct {
+	XMLName    xml.Name        `xml:"assemblies"`
+	Timestamp  string          `xml:"timestamp,attr"`
+	Assemblies []xunitAssembly `xml:"assembly"`
+}
+
+type xunitAssembly struct {
+	Name        string            `xml:"name,attr"`
+	RunDate     string            `xml:"run-date,attr"`
+	RunTime     string            `xml:"run-time,attr"`
+	Time        string            `xml:"time,attr"`
+	Total       int               `xml:"total,attr"`
+	Passed      int               `xml:"passed,attr"`
+	Failed      int               `xml:"failed,attr"`
+	Skipped     int               `xml:"skipped,attr"`
+	Errors      int               `xml:"errors,attr"`
+	ErrorList   []xunitError      `xml:"errors>error"`
+	Collections []xunitCollection `xml:"collection"`
+}
+
+type xunitError struct {
+	Type    string        `xml:"type,attr"`
+	Name    string        `xml:"name,attr,omitempty"`
+	Failure *xunitFailure `xml:"failure"`
+}
+
+type xunitCollection struct {
+	Name    string      `xml:"name,attr"`
+	Time    string      `xml:"time,attr"`
+	Total   int         `xml:"total,attr"`
+	Passed  int         `xml:"passed,attr"`
+	Failed  int         `xml:"failed,attr"`
+	Skipped int         `xml:"skipped,attr"`
+	Tests   []xunitTest `xml:"test"`
+}
+
+type xunitTest struct {
+	Name    string        `xml:"name,attr"`
+	Type    string        `xml:"type,attr"`
+	Method  string        `xml:"method,attr"`
+	Time    string        `xml:"time,attr"`
+	Result  string        `xml:"result,attr"`
+	Traits  *xunitTraits  `xml:"traits"`
+	Failure *xunitFailure `xml:"failure"`
+	Reason  *cdata        `xml:"reason"`
+}
+
+type xunitTraits struct {
+	Traits []xunitTrait `xml:"trait"`
+}
+
+type xunitTrait struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type xunitFailure struct {
+	Message    cdata `xml:"message"`
+	StackTrace cdata `xml:"stack-trace"`
+}
+
+func newXUnitFailure(f *failure) *xunitFailure {
+	if f == nil {
+		return nil
+	}
+	return &xunitFailure{Message: cdata{f.message}, StackTrace: cdata{f.stacktrace}}
+}
+
+func xunitResult(status gauge_messages.ExecutionStatus) string {
+	switch status {
+	case gauge_messages.ExecutionStatus_FAILED:
+		return "Fail"
+	case gauge_messages.ExecutionStatus_SKIPPED:
+		return "Skip"
+	}
+	return "Pass"
+}
+
+func toXUnit(r *run) interface{} {
+	a := xunitAssembly{
+		Name:    r.project,
+		RunDate: r.start.Format("2006-01-02"),
+		RunTime: r.start.Format("15:04:05"),
+		Time:    seconds(r.duration),
+		Total:   r.total(),
+		Passed:  r.count(gauge_messages.ExecutionStatus_PASSED),
+		Failed:  r.count(gauge_messages.ExecutionStatus_FAILED),
+		Skipped: r.count(gauge_messages.ExecutionStatus_SKIPPED),
+	}
+	for i := range r.errors {
+		a.ErrorList = append(a.ErrorList, xunitError{Type: "assembly-cleanup", Failure: newXUnitFailure(&r.errors[i])})
+	}
+	for _, s := range r.specs {
+		if s.failure != nil {
+			a.ErrorList = append(a.ErrorList, xunitError{Type: "test-collection-cleanup", Name: s.name, Failure: newXUnitFailure(s.failure)})
+		}
+		col := xunitCollection{
+			Name:    s.name,
+			Time:    seconds(s.duration),
+			Total:   len(s.cases),
+			Passed:  s.count(gauge_messages.ExecutionStatus_PASSED),
+			Failed:  s.count(gauge_messages.ExecutionStatus_FAILED),
+			Skipped: s.count(gauge_messages.ExecutionStatus_SKIPPED),
+		}
+		for _, c := range s.cases {
+			t := xunitTest{Name: s.name + ": " + c.name, Type: s.fileName, Method: c.name, Time: seconds(c.duration), Result: xunitResult(c.status), Failure: newXUnitFailure(c.failure)}
+			if len(c.tags) > 0 {
+				t.Traits = &xunitTraits{}
+				for _, tag := range c.tags {
+					t.Traits.Traits = append(t.Traits.Traits, xunitTrait{Name: "tag", Value: tag})
+				}
+			}
+			if c.status == gauge_messages.ExecutionStatus_SKIPPED {
+				t.Reason = &cdata{c.skipReason}
+			}
+			col.Tests = append(col.Tests, t)
+		}
+		a.Collections = append(a.Collections, col)
+	}
+	a.Errors = len(a.ErrorList)
+	return xunitAssemblies{Timestamp: r.end.Format("01/02/2006 15:04:05"), Assemblies: []xunitAssembly{a}}
+}
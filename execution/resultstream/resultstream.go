@@ -0,0 +1,269 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package resultstream serves the execution events and the spec results over gRPC as they occur, on the address given
+// by the result_stream_address property, for dashboards to subscribe to with clients generated from resultstream.proto,
+// like ResultStreamClient.
+package resultstream
+
+import (
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/getgauge/gauge/conn"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	queueSize = 1000
+	// shutdownTimeout is the time given to the subscribers to receive the remaining messages at the end of the suite
+	shutdownTimeout = 10 * time.Second
+)
+
+// Events streams the execution events to the subscriber until the end of the suite.
+func (h *hub) Events(_ *gauge_messages.Empty, stream ResultStream_EventsServer) error {
+	return h.serve(stream, false)
+}
+
+// SpecResults streams the spec results to the subscriber until the end of the suite.
+func (h *hub) SpecResults(_ *gauge_messages.Empty, stream ResultStream_SpecResultsServer) error {
+	return h.serve(stream, true)
+}
+
+func (h *hub) serve(stream grpc.ServerStream, specResults bool) error {
+	s := h.subscribe(specResults)
+	defer h.unsubscribe(s)
+	for {
+		select {
+		case m, ok := <-s.messages:
+			if !ok {
+				if s.dropped {
+					return status.Error(codes.ResourceExhausted, "The subscriber did not keep up with the execution")
+				}
+				return nil
+			}
+			if err := stream.SendMsg(m); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+type subscriber struct {
+	specResults bool
+	messages    chan proto.Message
+	// dropped is set when the subscriber is disconnected for not receiving the messages as fast as they occur
+	dropped bool
+}
+
+// hub broadcasts the messages to the subscribers. The spec results are kept, so that they are all sent to
+// the subscribers joining during the execution.
+type hub struct {
+	mutex       sync.Mutex
+	subscribers map[*subscriber]bool
+	specResults []proto.Message
+	closed      bool
+}
+
+func newHub() *hub {
+	return &hub{subscribers: make(map[*subscriber]bool)}
+}
+
+func (h *hub) subscribe(specResults bool) *subscriber {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	s := &subscriber{specResults: specResults, messages: make(chan proto.Message, queueSize+len(h.specResults))}
+	if specResults {
+		for _, r := range h.specResults {
+			s.messages <- r
+		}
+	}
+	if h.closed {
+		close(s.messages)
+	} else {
+		h.subscribers[s] = true
+	}
+	return s
+}
+
+func (h *hub) unsubscribe(s *subscriber) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	delete(h.subscribers, s)
+}
+
+func (h *hub) publish(m proto.Message, specResult bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if specResult {
+		h.specResults = append(h.specResults, m)
+	}
+	for s := range h.subscribers {
+		if s.specResults != specResult {
+			continue
+		}
+		select {
+		case s.messages <- m:
+		default:
+			logger.Warningf(true, "A subscriber of the result stream is disconnected as it did not keep up with the execution.")
+			s.dropped = true
+			close(s.messages)
+			delete(h.subscribers, s)
+		}
+	}
+}
+
+// close ends the streams of all the subscribers once they receive the messages already published.
+func (h *hub) close() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.closed = true
+	for s := range h.subscribers {
+		close(s.messages)
+		delete(h.subscribers, s)
+	}
+}
+
+// ListenExecutionEvents serves the execution events and the spec results on the address given by the
+// result_stream_address property, if any. Subscribers are served with the TLS settings of the gauge API, i.e.
+// api_tls_cert_file, api_tls_key_file and api_tls_client_ca_file, which are needed beyond localhost. The streams
+// end once the suite result is sent, before the end of the suite is signalled.
+func ListenExecutionEvents(wg *sync.WaitGroup) {
+	address := strings.TrimSpace(os.Getenv(env.ResultStreamAddress))
+	if address == "" {
+		return
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		logger.Errorf(true, "Results are not streamed. Invalid address %s. %s", address, err.Error())
+		return
+	}
+	tlsConfig, err := conn.APITLSConfig(host)
+	if err != nil {
+		logger.Errorf(true, "Results are not streamed. %s", err.Error())
+		return
+	}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		logger.Errorf(true, "Results are not streamed. Failed to listen on %s. %s", address, err.Error())
+		return
+	}
+	logger.Infof(true, "Streaming results on %s", listener.Addr().String())
+	var opts []grpc.ServerOption
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	serveEvents(listener, newHub(), wg, opts...)
+}
+
+func serveEvents(listener net.Listener, h *hub, wg *sync.WaitGroup, opts ...grpc.ServerOption) {
+	server := grpc.NewServer(opts...)
+	RegisterResultStreamServer(server, h)
+	go server.Serve(listener)
+
+	ch := make(chan event.ExecutionEvent, 0)
+	event.Register(ch, event.SuiteStart, event.SpecStart, event.ScenarioStart, event.StepStart, event.StepEnd, event.ScenarioEnd, event.SpecEnd, event.SuiteEnd)
+	wg.Add(1)
+	go func() {
+		for {
+			e := <-ch
+			for _, m := range messages(e) {
+				h.publish(proto.Clone(m), false)
+			}
+			if e.Topic == event.SpecEnd {
+				if r, ok := e.Result.(*result.SpecResult); ok {
+					h.publish(proto.Clone(gauge.ConvertToProtoSpecResult(r)), true)
+				}
+			}
+			if e.Topic == event.SuiteEnd {
+				h.close()
+				stop(server)
+				wg.Done()
+			}
+		}
+	}()
+}
+
+// stop waits for the streams to end, for at most the shutdown timeout.
+func stop(server *grpc.Server) {
+	stopped := make(chan bool)
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		server.Stop()
+	}
+}
+
+// messages converts the execution event to the messages sent to the plugins on its occurrence.
+func messages(e event.ExecutionEvent) []*gauge_messages.Message {
+	ei := e.ExecutionInfo
+	switch e.Topic {
+	case event.SuiteStart:
+		return []*gauge_messages.Message{{MessageType: gauge_messages.Message_ExecutionStarting,
+			ExecutionStartingRequest: &gauge_messages.ExecutionStartingRequest{}}}
+	case event.SpecStart:
+		return []*gauge_messages.Message{{MessageType: gauge_messages.Message_SpecExecutionStarting,
+			SpecExecutionStartingRequest: &gauge_messages.SpecExecutionStartingRequest{CurrentExecutionInfo: &ei}}}
+	case event.ScenarioStart:
+		return []*gauge_messages.Message{{MessageType: gauge_messages.Message_ScenarioExecutionStarting,
+			ScenarioExecutionStartingRequest: &gauge_messages.ScenarioExecutionStartingRequest{CurrentExecutionInfo: &ei}}}
+	case event.StepStart:
+		return []*gauge_messages.Message{{MessageType: gauge_messages.Message_StepExecutionStarting,
+			StepExecutionStartingRequest: &gauge_messages.StepExecutionStartingRequest{CurrentExecutionInfo: &ei}}}
+	case event.StepEnd:
+		return []*gauge_messages.Message{{MessageType: gauge_messages.Message_StepExecutionEnding,
+			StepExecutionEndingRequest: &gauge_messages.StepExecutionEndingRequest{CurrentExecutionInfo: &ei}}}
+	case event.ScenarioEnd:
+		m := []*gauge_messages.Message{{MessageType: gauge_messages.Message_ScenarioExecutionEnding,
+			ScenarioExecutionEndingRequest: &gauge_messages.ScenarioExecutionEndingRequest{CurrentExecutionInfo: &ei}}}
+		if r, ok := e.Result.(*result.ScenarioResult); ok && r.ProtoScenario != nil {
+			item := &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_Scenario, Scenario: r.ProtoScenario, FileName: ei.GetCurrentSpec().GetFileName()}
+			m = append(m, &gauge_messages.Message{MessageType: gauge_messages.Message_SuiteExecutionResultItem,
+				SuiteExecutionResultItem: &gauge_messages.SuiteExecutionResultItem{ResultItem: item}})
+		}
+		return m
+	case event.SpecEnd:
+		return []*gauge_messages.Message{{MessageType: gauge_messages.Message_SpecExecutionEnding,
+			SpecExecutionEndingRequest: &gauge_messages.SpecExecutionEndingRequest{CurrentExecutionInfo: &ei}}}
+	case event.SuiteEnd:
+		// the spec results are streamed through SpecResults, and their scenarios as they end
+		if r, ok := e.Result.(*result.SuiteResult); ok {
+			return []*gauge_messages.Message{{MessageType: gauge_messages.Message_SuiteExecutionResult,
+				SuiteExecutionResult: &gauge_messages.SuiteExecutionResult{SuiteResult: gauge.ConvertToProtoSuiteSummary(r)}}}
+		}
+	}
+	return nil
+}
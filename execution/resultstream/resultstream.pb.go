@@ -0,0 +1,212 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: resultstream.proto
+
+package resultstream
+
+import (
+	fmt "fmt"
+	gauge_messages "github.com/getgauge/gauge/gauge_messages"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ResultStreamClient is the client API for ResultStream service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ResultStreamClient interface {
+	// / Streams the execution events from the time of subscription, as the messages sent to the plugins,
+	// / i.e. ExecutionStarting, SpecExecutionStarting, ScenarioExecutionStarting, StepExecutionStarting and their
+	// / endings. A SuiteExecutionResultItem with the result of the scenario follows each ScenarioExecutionEnding,
+	// / and the stream ends with the SuiteExecutionResult, without the spec results streamed by SpecResults.
+	Events(ctx context.Context, in *gauge_messages.Empty, opts ...grpc.CallOption) (ResultStream_EventsClient, error)
+	// / Streams the result of each spec once it is executed, starting with those executed before the subscription.
+	SpecResults(ctx context.Context, in *gauge_messages.Empty, opts ...grpc.CallOption) (ResultStream_SpecResultsClient, error)
+}
+
+type resultStreamClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewResultStreamClient(cc *grpc.ClientConn) ResultStreamClient {
+	return &resultStreamClient{cc}
+}
+
+func (c *resultStreamClient) Events(ctx context.Context, in *gauge_messages.Empty, opts ...grpc.CallOption) (ResultStream_EventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ResultStream_serviceDesc.Streams[0], "/gauge.resultstream.ResultStream/Events", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &resultStreamEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ResultStream_EventsClient interface {
+	Recv() (*gauge_messages.Message, error)
+	grpc.ClientStream
+}
+
+type resultStreamEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *resultStreamEventsClient) Recv() (*gauge_messages.Message, error) {
+	m := new(gauge_messages.Message)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *resultStreamClient) SpecResults(ctx context.Context, in *gauge_messages.Empty, opts ...grpc.CallOption) (ResultStream_SpecResultsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ResultStream_serviceDesc.Streams[1], "/gauge.resultstream.ResultStream/SpecResults", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &resultStreamSpecResultsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ResultStream_SpecResultsClient interface {
+	Recv() (*gauge_messages.ProtoSpecResult, error)
+	grpc.ClientStream
+}
+
+type resultStreamSpecResultsClient struct {
+	grpc.ClientStream
+}
+
+func (x *resultStreamSpecResultsClient) Recv() (*gauge_messages.ProtoSpecResult, error) {
+	m := new(gauge_messages.ProtoSpecResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ResultStreamServer is the server API for ResultStream service.
+type ResultStreamServer interface {
+	// / Streams the execution events from the time of subscription, as the messages sent to the plugins,
+	// / i.e. ExecutionStarting, SpecExecutionStarting, ScenarioExecutionStarting, StepExecutionStarting and their
+	// / endings. A SuiteExecutionResultItem with the result of the scenario follows each ScenarioExecutionEnding,
+	// / and the stream ends with the SuiteExecutionResult, without the spec results streamed by SpecResults.
+	Events(*gauge_messages.Empty, ResultStream_EventsServer) error
+	// / Streams the result of each spec once it is executed, starting with those executed before the subscription.
+	SpecResults(*gauge_messages.Empty, ResultStream_SpecResultsServer) error
+}
+
+func RegisterResultStreamServer(s *grpc.Server, srv ResultStreamServer) {
+	s.RegisterService(&_ResultStream_serviceDesc, srv)
+}
+
+func _ResultStream_Events_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(gauge_messages.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ResultStreamServer).Events(m, &resultStreamEventsServer{stream})
+}
+
+type ResultStream_EventsServer interface {
+	Send(*gauge_messages.Message) error
+	grpc.ServerStream
+}
+
+type resultStreamEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *resultStreamEventsServer) Send(m *gauge_messages.Message) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ResultStream_SpecResults_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(gauge_messages.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ResultStreamServer).SpecResults(m, &resultStreamSpecResultsServer{stream})
+}
+
+type ResultStream_SpecResultsServer interface {
+	Send(*gauge_messages.ProtoSpecResult) error
+	grpc.ServerStream
+}
+
+type resultStreamSpecResultsServer struct {
+	grpc.ServerStream
+}
+
+func (x *resultStreamSpecResultsServer) Send(m *gauge_messages.ProtoSpecResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _ResultStream_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "gauge.resultstream.ResultStream",
+	HandlerType: (*ResultStreamServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Events",
+			Handler:       _ResultStream_Events_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SpecResults",
+			Handler:       _ResultStream_SpecResults_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "resultstream.proto",
+}
+
+func init() { proto.RegisterFile("resultstream.proto", fileDescriptor_25944b6f2131943e) }
+
+var fileDescriptor_25944b6f2131943e = []byte{
+	// 153 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x2a, 0x4a, 0x2d, 0x2e,
+	0xcd, 0x29, 0x29, 0x2e, 0x29, 0x4a, 0x4d, 0xcc, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12,
+	0x4a, 0x4f, 0x2c, 0x4d, 0x4f, 0xd5, 0x43, 0x96, 0x91, 0xe2, 0x2a, 0x2e, 0x48, 0x4d, 0x86, 0xc8,
+	0x4b, 0xf1, 0xe5, 0xa6, 0x16, 0x17, 0x27, 0xa6, 0xa7, 0x16, 0x43, 0xf9, 0x9c, 0x39, 0xc5, 0x05,
+	0x10, 0xa6, 0xd1, 0x64, 0x46, 0x2e, 0x9e, 0x20, 0xb0, 0xbe, 0x60, 0xb0, 0x3e, 0x21, 0x2b, 0x2e,
+	0x36, 0xd7, 0xb2, 0xd4, 0xbc, 0x92, 0x62, 0x21, 0x51, 0x3d, 0x88, 0xb1, 0x70, 0xcd, 0xae, 0xb9,
+	0x05, 0x25, 0x95, 0x52, 0xe2, 0xe8, 0xc2, 0xbe, 0x10, 0x86, 0x01, 0xa3, 0x90, 0x3b, 0x17, 0x77,
+	0x70, 0x41, 0x6a, 0x32, 0xc4, 0x3c, 0x9c, 0x06, 0xc8, 0xa3, 0x0b, 0x07, 0x80, 0x5c, 0x82, 0xd0,
+	0x68, 0xc0, 0xe8, 0xc4, 0x17, 0xc5, 0x83, 0xec, 0x99, 0x24, 0x36, 0xb0, 0x63, 0x8d, 0x01, 0x03,
+	0x00, 0x30, 0x40, 0xdf, 0x0a, 0xfd, 0x00, 0x00, 0x00,
+}
@@ -0,0 +1,153 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package resultstream
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	"google.golang.org/grpc"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) TestHubSendsSpecResultsOfThePastToNewSubscribers(c *C) {
+	h := newHub()
+	first := &gauge_messages.ProtoSpecResult{ExecutionTime: 1}
+	h.publish(first, true)
+	h.publish(&gauge_messages.Message{}, false)
+
+	sub := h.subscribe(true)
+	second := &gauge_messages.ProtoSpecResult{ExecutionTime: 2}
+	h.publish(second, true)
+	h.close()
+
+	var got []interface{}
+	for m := range sub.messages {
+		got = append(got, m)
+	}
+	c.Assert(got, DeepEquals, []interface{}{first, second})
+}
+
+func (s *MySuite) TestHubEndsStreamsOfSubscribersJoiningAfterClose(c *C) {
+	h := newHub()
+	h.close()
+
+	_, ok := <-h.subscribe(false).messages
+
+	c.Assert(ok, Equals, false)
+}
+
+func (s *MySuite) TestHubDisconnectsSubscriberNotKeepingUp(c *C) {
+	h := newHub()
+	sub := h.subscribe(false)
+	for i := 0; i <= queueSize; i++ {
+		h.publish(&gauge_messages.Message{}, false)
+	}
+
+	c.Assert(sub.dropped, Equals, true)
+	c.Assert(h.subscribers, HasLen, 0)
+	c.Assert(len(sub.messages), Equals, queueSize)
+}
+
+func (s *MySuite) TestStreamsEventsAndSpecResults(c *C) {
+	event.InitRegistry()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, IsNil)
+	h := newHub()
+	wg := &sync.WaitGroup{}
+	serveEvents(listener, h, wg)
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	c.Assert(err, IsNil)
+	defer conn.Close()
+	client := NewResultStreamClient(conn)
+	events, err := client.Events(context.Background(), &gauge_messages.Empty{})
+	c.Assert(err, IsNil)
+	specResults, err := client.SpecResults(context.Background(), &gauge_messages.Empty{})
+	c.Assert(err, IsNil)
+	for deadline := time.Now().Add(5 * time.Second); subscribers(h) < 2; time.Sleep(10 * time.Millisecond) {
+		c.Assert(time.Now().Before(deadline), Equals, true, Commentf("the subscribers did not join"))
+	}
+
+	spec := &gauge.Specification{Heading: &gauge.Heading{Value: "Spec"}, FileName: "specs/a.spec"}
+	ei := gauge_messages.ExecutionInfo{CurrentSpec: &gauge_messages.SpecInfo{Name: "Spec", FileName: "specs/a.spec"}}
+	specResult := &result.SpecResult{ProtoSpec: &gauge_messages.ProtoSpec{SpecHeading: "Spec"}, ScenarioCount: 1}
+	event.Notify(event.NewExecutionEvent(event.SpecStart, spec, specResult, 1, ei))
+	event.Notify(event.NewExecutionEvent(event.SpecEnd, spec, specResult, 1, ei))
+	event.Notify(event.NewExecutionEvent(event.SuiteEnd, nil, &result.SuiteResult{ProjectName: "project"}, 0, gauge_messages.ExecutionInfo{}))
+	wg.Wait()
+
+	var types []gauge_messages.Message_MessageType
+	for _, m := range receiveAll(c, events, func() interface{} { return new(gauge_messages.Message) }) {
+		types = append(types, m.(*gauge_messages.Message).MessageType)
+	}
+	c.Assert(types, DeepEquals, []gauge_messages.Message_MessageType{gauge_messages.Message_SpecExecutionStarting,
+		gauge_messages.Message_SpecExecutionEnding, gauge_messages.Message_SuiteExecutionResult})
+
+	results := receiveAll(c, specResults, func() interface{} { return new(gauge_messages.ProtoSpecResult) })
+	c.Assert(results, HasLen, 1)
+	c.Assert(results[0].(*gauge_messages.ProtoSpecResult).ProtoSpec.SpecHeading, Equals, "Spec")
+	c.Assert(results[0].(*gauge_messages.ProtoSpecResult).ScenarioCount, Equals, int32(1))
+}
+
+func (s *MySuite) TestMessagesFollowScenarioEndingWithItsResult(c *C) {
+	ei := gauge_messages.ExecutionInfo{CurrentSpec: &gauge_messages.SpecInfo{FileName: "specs/a.spec"}}
+	scenario := &gauge_messages.ProtoScenario{ScenarioHeading: "Scenario", ExecutionStatus: gauge_messages.ExecutionStatus_FAILED}
+
+	m := messages(event.NewExecutionEvent(event.ScenarioEnd, nil, result.NewScenarioResult(scenario), 1, ei))
+
+	c.Assert(m, HasLen, 2)
+	c.Assert(m[0].MessageType, Equals, gauge_messages.Message_ScenarioExecutionEnding)
+	c.Assert(m[0].ScenarioExecutionEndingRequest.CurrentExecutionInfo.CurrentSpec.FileName, Equals, "specs/a.spec")
+	c.Assert(m[1].MessageType, Equals, gauge_messages.Message_SuiteExecutionResultItem)
+	c.Assert(m[1].SuiteExecutionResultItem.ResultItem.Scenario, Equals, scenario)
+	c.Assert(m[1].SuiteExecutionResultItem.ResultItem.FileName, Equals, "specs/a.spec")
+}
+
+func subscribers(h *hub) int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return len(h.subscribers)
+}
+
+func receiveAll(c *C, stream grpc.ClientStream, newMessage func() interface{}) []interface{} {
+	var got []interface{}
+	for {
+		m := newMessage()
+		err := stream.RecvMsg(m)
+		if err == io.EOF {
+			return got
+		}
+		c.Assert(err, IsNil)
+		got = append(got, m)
+	}
+}
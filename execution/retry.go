@@ -0,0 +1,106 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"fmt"
+
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+)
+
+// RetrySuite is the number of additional passes in which the failed scenarios are executed again, once all the specs are executed.
+var RetrySuite int
+
+// executedSpec holds the scenarios of an executed spec in the order of their results, so that the failed ones can be retried.
+type executedSpec struct {
+	spec      *gauge.Specification
+	result    *result.SpecResult
+	scenarios []*gauge.Scenario
+}
+
+// failedScenarios gives the positions of the failed scenarios in the given results of the spec.
+func (s *executedSpec) failedScenarios(res *result.SpecResult) []int {
+	var failed []int
+	for i, r := range res.ScenarioResults() {
+		if r.GetExecutionStatus() == gauge_messages.ExecutionStatus_FAILED && i < len(s.scenarios) {
+			failed = append(failed, i)
+		}
+	}
+	return failed
+}
+
+// replaceResult replaces the result of the spec, which the suite result also holds, with the given one after its scenarios
+// are retried. The new result is spilled again if the earlier one was spilled.
+func (s *executedSpec) replaceResult(res *result.SpecResult) {
+	if res == s.result {
+		return
+	}
+	if store := s.result.SpillStore(); store != nil {
+		if spilled, err := store.Spill(res); err == nil {
+			res = spilled
+		} else {
+			logger.Debugf(true, "Holding result in memory. %s", err.Error())
+		}
+	}
+	*s.result = *res
+}
+
+// retryFailedScenarios executes the failed scenarios again, up to RetrySuite times, before the after suite hooks are run.
+// Every retried scenario is executed along with the spec hooks. Its result replaces the earlier one and says how many times it was retried.
+func (e *simpleExecution) retryFailedScenarios() {
+	for attempt := 1; attempt <= RetrySuite; attempt++ {
+		retried := 0
+		for _, s := range e.executed {
+			if s.result.SpillStore() != nil && s.result.ScenarioFailedCount == 0 {
+				continue
+			}
+			res, err := s.result.Load()
+			if err != nil {
+				logger.Errorf(true, "%s", err.Error())
+				continue
+			}
+			failed := s.failedScenarios(res)
+			if len(failed) == 0 {
+				continue
+			}
+			spec := *s.spec
+			spec.Scenarios = make([]*gauge.Scenario, len(failed))
+			for i, index := range failed {
+				spec.Scenarios[i] = s.scenarios[index]
+			}
+			se := newSpecExecutor(&spec, e.runner, e.pluginHandler, e.errMaps, e.stream)
+			results := se.execute(true, true, true).ScenarioResults()
+			for i, index := range failed {
+				if i >= len(results) {
+					break
+				}
+				results[i].PreHookMessages = append(results[i].PreHookMessages, fmt.Sprintf("Retried %d time(s) after failing", attempt))
+				res.ReplaceScenarioResult(index, results[i])
+			}
+			s.replaceResult(res)
+			retried += len(failed)
+		}
+		if retried == 0 {
+			return
+		}
+		logger.Infof(true, "Retried %d failed scenario(s), pass %d of %d.", retried, attempt, RetrySuite)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"testing"
+
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/parser"
+)
+
+func TestRetryFailedScenariosReplacesResultsOfScenariosPassingOnRetry(t *testing.T) {
+	RetrySuite = 2
+	defer func() { RetrySuite = 0 }()
+	specText := newSpecBuilder().specHeading("A spec heading").
+		scenarioHeading("Stable scenario").
+		step("stable step").
+		scenarioHeading("Flaky scenario").
+		step("flaky step").
+		scenarioHeading("Broken scenario").
+		step("broken step").
+		String()
+	spec, _, _ := new(parser.SpecParser).Parse(specText, gauge.NewConceptDictionary(), "")
+	spec.FileName = "FILE"
+	executed := make(map[string]int)
+	r := &mockRunner{}
+	r.ExecuteAndGetStatusFunc = func(m *gauge_messages.Message) *gauge_messages.ProtoExecutionResult {
+		if m.MessageType != gauge_messages.Message_ExecuteStep {
+			return &gauge_messages.ProtoExecutionResult{}
+		}
+		step := m.GetExecuteStepRequest().GetActualStepText()
+		executed[step]++
+		failed := step == "broken step" || (step == "flaky step" && executed[step] == 1)
+		return &gauge_messages.ProtoExecutionResult{Failed: failed}
+	}
+	h := &mockPluginHandler{NotifyPluginsfunc: func(m *gauge_messages.Message) {}, GracefullyKillPluginsfunc: func() {}}
+	e := &simpleExecution{runner: r, pluginHandler: h, errMaps: gauge.NewBuildErrors(), currentExecutionInfo: &gauge_messages.ExecutionInfo{}}
+
+	results := e.executeSpecs(gauge.NewSpecCollection([]*gauge.Specification{spec}, false))
+	e.retryFailedScenarios()
+
+	want := map[string]int{"stable step": 1, "flaky step": 2, "broken step": 3}
+	for step, n := range want {
+		if executed[step] != n {
+			t.Errorf("Expected %q to be executed %d time(s). Got %d", step, n, executed[step])
+		}
+	}
+	res := results[0]
+	if !res.GetFailed() || res.ScenarioFailedCount != 1 {
+		t.Errorf("Expected spec to fail only for the broken scenario. Got failed: %v, failed scenarios: %d", res.GetFailed(), res.ScenarioFailedCount)
+	}
+	scenarios := res.ScenarioResults()
+	if len(scenarios) != 3 {
+		t.Fatalf("Expected 3 scenario results. Got %d", len(scenarios))
+	}
+	if scenarios[1].GetExecutionStatus() != gauge_messages.ExecutionStatus_PASSED {
+		t.Errorf("Expected flaky scenario to pass. Got %s", scenarios[1].GetExecutionStatus())
+	}
+	if got := scenarios[1].GetPreHookMessages(); len(got) != 1 || got[0] != "Retried 1 time(s) after failing" {
+		t.Errorf("Expected flaky scenario to be marked as retried once. Got %v", got)
+	}
+	if got := scenarios[2].GetPreHookMessages(); len(got) != 1 || got[0] != "Retried 2 time(s) after failing" {
+		t.Errorf("Expected broken scenario to be marked as retried twice. Got %v", got)
+	}
+	if len(scenarios[0].GetPreHookMessages()) != 0 {
+		t.Errorf("Expected stable scenario not to be retried. Got %v", scenarios[0].GetPreHookMessages())
+	}
+}
@@ -18,11 +18,10 @@
 package execution
 
 import (
+	"io"
 	"os"
 	"path/filepath"
 
-	"io/ioutil"
-
 	"sync"
 
 	"github.com/getgauge/common"
@@ -30,6 +29,7 @@ import (
 	"github.com/getgauge/gauge/execution/event"
 	"github.com/getgauge/gauge/execution/result"
 	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
 	"github.com/getgauge/gauge/logger"
 	"github.com/golang/protobuf/proto"
 )
@@ -56,20 +56,43 @@ func ListenSuiteEndAndSaveResult(wg *sync.WaitGroup) {
 	}()
 }
 
+// writeResult saves the suite result. Protobuf merges concatenated messages when decoding them, so the suite result is
+// written without spec results followed by a message with each spec result, reading spilled spec results back one at a time.
 func writeResult(res *result.SuiteResult) {
 	dotGaugeDir := filepath.Join(config.ProjectRoot, dotGauge)
 	resultFile := filepath.Join(config.ProjectRoot, dotGauge, lastRunResult)
 	if err := os.MkdirAll(dotGaugeDir, common.NewDirectoryPermissions); err != nil {
 		logger.Errorf(true, "Failed to create directory in %s. Reason: %s", dotGaugeDir, err.Error())
 	}
-	r, err := proto.Marshal(gauge.ConvertToProtoSuiteResult(res))
-	if err != nil {
-		logger.Errorf(true, "Unable to marshal suite execution result, skipping save. %s", err.Error())
+	f, err := os.OpenFile(resultFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, common.NewFilePermissions)
+	if err == nil {
+		err = writeProtoSuiteResult(f, res)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
 	}
-	err = ioutil.WriteFile(resultFile, r, common.NewFilePermissions)
 	if err != nil {
 		logger.Errorf(true, "Failed to write to %s. Reason: %s", resultFile, err.Error())
 	} else {
 		logger.Debugf(true, "Last run result saved to %s", resultFile)
 	}
 }
+
+func writeProtoSuiteResult(w io.Writer, res *result.SuiteResult) error {
+	b, err := proto.Marshal(gauge.ConvertToProtoSuiteSummary(res))
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(b); err != nil {
+		return err
+	}
+	gauge.EachProtoSpecResult(res, func(r *gauge_messages.ProtoSpecResult) {
+		if err != nil {
+			return
+		}
+		if b, err = proto.Marshal(&gauge_messages.ProtoSuiteResult{SpecResults: []*gauge_messages.ProtoSpecResult{r}}); err == nil {
+			_, err = w.Write(b)
+		}
+	})
+	return err
+}
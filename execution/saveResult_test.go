@@ -17,6 +17,7 @@
 package execution
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
@@ -24,6 +25,8 @@ import (
 	"github.com/getgauge/common"
 	"github.com/getgauge/gauge/config"
 	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/golang/protobuf/proto"
 )
 
 func TestIfResultFileIsCreated(t *testing.T) {
@@ -38,3 +41,25 @@ func TestIfResultFileIsCreated(t *testing.T) {
 	}
 	os.RemoveAll(filepath.Join(config.ProjectRoot, dotGauge))
 }
+
+func TestResultFileHasTheSpecResults(t *testing.T) {
+	res := &result.SuiteResult{ProjectName: "project", SpecResults: []*result.SpecResult{
+		{ProtoSpec: &gauge_messages.ProtoSpec{FileName: "a.spec"}},
+		{ProtoSpec: &gauge_messages.ProtoSpec{FileName: "b.spec"}},
+	}}
+	defer os.RemoveAll(filepath.Join(config.ProjectRoot, dotGauge))
+
+	writeResult(res)
+
+	b, err := ioutil.ReadFile(filepath.Join(config.ProjectRoot, dotGauge, lastRunResult))
+	if err != nil {
+		t.Fatalf("Expected result file. Got %s", err.Error())
+	}
+	got := &gauge_messages.ProtoSuiteResult{}
+	if err := proto.Unmarshal(b, got); err != nil {
+		t.Fatalf("Expected a suite result. Got %s", err.Error())
+	}
+	if got.ProjectName != "project" || len(got.SpecResults) != 2 || got.SpecResults[1].ProtoSpec.FileName != "b.spec" {
+		t.Errorf("Expected the suite result with its spec results. Got %v", got)
+	}
+}
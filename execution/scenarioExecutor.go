@@ -19,15 +19,19 @@ package execution
 
 import (
 	"fmt"
+	"sync"
 
 	"errors"
 
+	"github.com/getgauge/gauge/env"
 	"github.com/getgauge/gauge/execution/event"
 	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/filter"
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/gauge_messages"
 	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/plugin"
+	"github.com/getgauge/gauge/reporter"
 	"github.com/getgauge/gauge/runner"
 	"github.com/getgauge/gauge/validation"
 )
@@ -43,6 +47,33 @@ type scenarioExecutor struct {
 	teardowns            []*gauge.Step
 }
 
+// aborted holds the reason for which the execution was aborted, by a plugin, a runner which could not be restarted,
+// a timeout or an interrupt. The remaining scenarios are skipped with it.
+var aborted = struct {
+	sync.Mutex
+	reason string
+}{}
+
+func abortExecution(reason string) {
+	aborted.Lock()
+	defer aborted.Unlock()
+	if aborted.reason == "" {
+		aborted.reason = reason
+	}
+}
+
+func resetAbort() {
+	aborted.Lock()
+	defer aborted.Unlock()
+	aborted.reason = ""
+}
+
+func abortReason() string {
+	aborted.Lock()
+	defer aborted.Unlock()
+	return aborted.reason
+}
+
 func newScenarioExecutor(r runner.Runner, ph plugin.Handler, ei *gauge_messages.ExecutionInfo, errMap *gauge.BuildErrors, contexts []*gauge.Step, teardowns []*gauge.Step, stream int) *scenarioExecutor {
 	return &scenarioExecutor{
 		runner:               r,
@@ -65,6 +96,11 @@ func (e *scenarioExecutor) execute(i gauge.Item, r result.Result) {
 		setSkipInfoInResult(scenarioResult, scenario, e.errMap)
 		return
 	}
+	if _, ok := e.errMap.ScenarioErrs[scenario]; !ok {
+		if reason := e.interceptScenario(scenarioResult); reason != "" {
+			e.errMap.ScenarioErrs[scenario] = []error{fmt.Errorf("skipped Reason: %s", reason)}
+		}
+	}
 	if _, ok := e.errMap.ScenarioErrs[scenario]; ok {
 		setSkipInfoInResult(scenarioResult, scenario, e.errMap)
 		event.Notify(event.NewExecutionEvent(event.ScenarioStart, scenario, scenarioResult, e.stream, *e.currentExecutionInfo))
@@ -73,6 +109,13 @@ func (e *scenarioExecutor) execute(i gauge.Item, r result.Result) {
 	}
 	event.Notify(event.NewExecutionEvent(event.ScenarioStart, scenario, scenarioResult, e.stream, *e.currentExecutionInfo))
 	defer event.Notify(event.NewExecutionEvent(event.ScenarioEnd, scenario, scenarioResult, e.stream, *e.currentExecutionInfo))
+	if env.CaptureScenarioOutput() {
+		// the output is saved before the end of the scenario is notified, so that the listeners get the output file
+		reporter.StartCapture(e.stream)
+		defer func() {
+			saveScenarioOutput(e.currentExecutionInfo.GetCurrentSpec().GetFileName(), scenario, scenarioResult, reporter.StopCapture(e.stream))
+		}()
+	}
 
 	res := e.initScenarioDataStore()
 	if res.GetFailed() {
@@ -93,6 +136,26 @@ func (e *scenarioExecutor) execute(i gauge.Item, r result.Result) {
 	scenarioResult.UpdateExecutionTime()
 }
 
+// interceptScenario gives the reason for which the scenario should be skipped, either because the execution was aborted
+// or because a plugin intercepting execution asked for it. Messages from the plugins are added to the scenario result.
+func (e *scenarioExecutor) interceptScenario(scenarioResult *result.ScenarioResult) string {
+	if reason := abortReason(); reason != "" {
+		return reason
+	}
+	message := &gauge_messages.Message{MessageType: gauge_messages.Message_ScenarioExecutionStarting,
+		ScenarioExecutionStartingRequest: &gauge_messages.ScenarioExecutionStartingRequest{CurrentExecutionInfo: e.currentExecutionInfo}}
+	i := e.pluginHandler.InterceptScenario(message)
+	scenarioResult.ProtoScenario.PreHookMessages = append(scenarioResult.ProtoScenario.PreHookMessages, i.Messages...)
+	if i.Abort {
+		abortExecution(i.Reason)
+		return i.Reason
+	}
+	if i.Skip {
+		return i.Reason
+	}
+	return ""
+}
+
 func (e *scenarioExecutor) initScenarioDataStore() *gauge_messages.ProtoExecutionResult {
 	msg := &gauge_messages.Message{MessageType: gauge_messages.Message_ScenarioDataStoreInit,
 		ScenarioDataStoreInitRequest: &gauge_messages.ScenarioDataStoreInitRequest{}}
@@ -121,8 +184,11 @@ func (e *scenarioExecutor) notifyBeforeScenarioHook(scenarioResult *result.Scena
 	message := &gauge_messages.Message{MessageType: gauge_messages.Message_ScenarioExecutionStarting,
 		ScenarioExecutionStartingRequest: &gauge_messages.ScenarioExecutionStartingRequest{CurrentExecutionInfo: e.currentExecutionInfo}}
 	e.pluginHandler.NotifyPlugins(message)
+	if !runsHook(filter.BeforeScenarioHook, e.currentExecutionInfo) {
+		return
+	}
 	res := executeHook(message, scenarioResult, e.runner)
-	scenarioResult.ProtoScenario.PreHookMessages = res.Message
+	scenarioResult.ProtoScenario.PreHookMessages = append(scenarioResult.ProtoScenario.PreHookMessages, res.Message...)
 	scenarioResult.ProtoScenario.PreHookScreenshots = res.Screenshots
 	if res.GetFailed() {
 		setScenarioFailure(e.currentExecutionInfo)
@@ -133,12 +199,14 @@ func (e *scenarioExecutor) notifyBeforeScenarioHook(scenarioResult *result.Scena
 func (e *scenarioExecutor) notifyAfterScenarioHook(scenarioResult *result.ScenarioResult) {
 	message := &gauge_messages.Message{MessageType: gauge_messages.Message_ScenarioExecutionEnding,
 		ScenarioExecutionEndingRequest: &gauge_messages.ScenarioExecutionEndingRequest{CurrentExecutionInfo: e.currentExecutionInfo}}
-	res := executeHook(message, scenarioResult, e.runner)
-	scenarioResult.ProtoScenario.PostHookMessages = res.Message
-	scenarioResult.ProtoScenario.PostHookScreenshots = res.Screenshots
-	if res.GetFailed() {
-		setScenarioFailure(e.currentExecutionInfo)
-		handleHookFailure(scenarioResult, res, result.AddPostHook)
+	if runsHook(filter.AfterScenarioHook, e.currentExecutionInfo) {
+		res := executeHook(message, scenarioResult, e.runner)
+		scenarioResult.ProtoScenario.PostHookMessages = res.Message
+		scenarioResult.ProtoScenario.PostHookScreenshots = res.Screenshots
+		if res.GetFailed() {
+			setScenarioFailure(e.currentExecutionInfo)
+			handleHookFailure(scenarioResult, res, result.AddPostHook)
+		}
 	}
 	e.pluginHandler.NotifyPlugins(message)
 }
@@ -18,12 +18,15 @@
 package execution
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/filter"
 	"github.com/getgauge/gauge/gauge"
 
 	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/plugin"
 )
 
 func TestNotifyBeforeScenarioShouldAddBeforeScenarioHookMessages(t *testing.T) {
@@ -157,3 +160,91 @@ func TestNotifyAfterScenarioShouldAddAfterScenarioHookScreenshots(t *testing.T)
 		}
 	}
 }
+
+func TestScenarioSkippedWhenPluginAsksForIt(t *testing.T) {
+	defer resetAbort()
+	r := &mockRunner{}
+	r.ExecuteAndGetStatusFunc = func(m *gauge_messages.Message) *gauge_messages.ProtoExecutionResult {
+		t.Errorf("Expected scenario to be skipped, got %s", m.MessageType)
+		return &gauge_messages.ProtoExecutionResult{}
+	}
+	h := &mockPluginHandler{InterceptScenariofunc: func(m *gauge_messages.Message) *plugin.Interception {
+		return &plugin.Interception{Skip: true, Reason: "Skipped by plugin quarantine: flaky", Messages: []string{"owner: team-a"}}
+	}}
+	sce := newScenarioExecutor(r, h, &gauge_messages.ExecutionInfo{}, gauge.NewBuildErrors(), nil, nil, 0)
+	scenario := &gauge.Scenario{Heading: &gauge.Heading{Value: "A scenario"}, Span: &gauge.Span{Start: 2, End: 10}}
+	scenarioResult := result.NewScenarioResult(gauge.NewProtoScenario(scenario))
+
+	sce.execute(scenario, scenarioResult)
+
+	if !scenarioResult.ProtoScenario.Skipped {
+		t.Fatalf("Expected scenario to be skipped")
+	}
+	want := []string{"skipped Reason: Skipped by plugin quarantine: flaky"}
+	if !reflect.DeepEqual(scenarioResult.ProtoScenario.SkipErrors, want) {
+		t.Errorf("Expected skip errors %v, got %v", want, scenarioResult.ProtoScenario.SkipErrors)
+	}
+	if !reflect.DeepEqual(scenarioResult.ProtoScenario.PreHookMessages, []string{"owner: team-a"}) {
+		t.Errorf("Expected plugin messages in result, got %v", scenarioResult.ProtoScenario.PreHookMessages)
+	}
+	if abortReason() != "" {
+		t.Errorf("Expected execution not to be aborted")
+	}
+}
+
+func TestScenariosSkippedAfterPluginAbortsExecution(t *testing.T) {
+	defer resetAbort()
+	r := &mockRunner{}
+	r.ExecuteAndGetStatusFunc = func(m *gauge_messages.Message) *gauge_messages.ProtoExecutionResult {
+		t.Errorf("Expected scenario to be skipped, got %s", m.MessageType)
+		return &gauge_messages.ProtoExecutionResult{}
+	}
+	intercepted := 0
+	h := &mockPluginHandler{InterceptScenariofunc: func(m *gauge_messages.Message) *plugin.Interception {
+		intercepted++
+		return &plugin.Interception{Abort: true, Reason: "Execution aborted by plugin guard: environment is down"}
+	}}
+	sce := newScenarioExecutor(r, h, &gauge_messages.ExecutionInfo{}, gauge.NewBuildErrors(), nil, nil, 0)
+
+	for _, heading := range []string{"first", "second"} {
+		scenario := &gauge.Scenario{Heading: &gauge.Heading{Value: heading}, Span: &gauge.Span{Start: 2, End: 10}}
+		scenarioResult := result.NewScenarioResult(gauge.NewProtoScenario(scenario))
+		sce.execute(scenario, scenarioResult)
+		want := []string{"skipped Reason: Execution aborted by plugin guard: environment is down"}
+		if !reflect.DeepEqual(scenarioResult.ProtoScenario.SkipErrors, want) {
+			t.Errorf("Expected skip errors %v, got %v", want, scenarioResult.ProtoScenario.SkipErrors)
+		}
+	}
+	if intercepted != 1 {
+		t.Errorf("Expected plugins not to be asked after execution is aborted, asked %d times", intercepted)
+	}
+}
+
+func TestScenarioHooksRunOnlyForScenariosMatchingHookTags(t *testing.T) {
+	hookTags = map[string]string{filter.BeforeScenarioHook: "web", filter.AfterScenarioHook: "api"}
+	defer func() { hookTags = nil }()
+	var hooks []gauge_messages.Message_MessageType
+	r := &mockRunner{}
+	r.ExecuteAndGetStatusFunc = func(m *gauge_messages.Message) *gauge_messages.ProtoExecutionResult {
+		hooks = append(hooks, m.MessageType)
+		return &gauge_messages.ProtoExecutionResult{}
+	}
+	var notified []gauge_messages.Message_MessageType
+	h := &mockPluginHandler{NotifyPluginsfunc: func(m *gauge_messages.Message) { notified = append(notified, m.MessageType) }, GracefullyKillPluginsfunc: func() {}}
+	ei := &gauge_messages.ExecutionInfo{
+		CurrentSpec:     &gauge_messages.SpecInfo{Tags: []string{"web"}},
+		CurrentScenario: &gauge_messages.ScenarioInfo{Tags: []string{"login"}},
+	}
+	sce := newScenarioExecutor(r, h, ei, nil, nil, nil, 0)
+	scenarioResult := result.NewScenarioResult(gauge.NewProtoScenario(&gauge.Scenario{Heading: &gauge.Heading{Value: "A scenario"}, Span: &gauge.Span{Start: 2, End: 10}}))
+
+	sce.notifyBeforeScenarioHook(scenarioResult)
+	sce.notifyAfterScenarioHook(scenarioResult)
+
+	if !reflect.DeepEqual(hooks, []gauge_messages.Message_MessageType{gauge_messages.Message_ScenarioExecutionStarting}) {
+		t.Errorf("Expected only the before scenario hook to run. Got %v", hooks)
+	}
+	if len(notified) != 2 {
+		t.Errorf("Expected plugins to be notified of both hooks. Got %v", notified)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/util"
+)
+
+// scenarioOutputDir is the directory in the reports directory to which the output of every scenario is written
+var scenarioOutputDir = filepath.Join("artifacts", "output")
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// scenarioOutputFile gives the file to which the output of the scenario is written. It is named after the line and the
+// heading of the scenario, and the row of the data table it is executed for, in a directory named after the spec file.
+func scenarioOutputFile(specFile string, scenario *gauge.Scenario) string {
+	reportsDir := os.Getenv(env.GaugeReportsDir)
+	if reportsDir == "" {
+		reportsDir = "reports"
+	}
+	if !filepath.IsAbs(reportsDir) {
+		reportsDir = filepath.Join(config.ProjectRoot, reportsDir)
+	}
+	spec := strings.TrimSuffix(filepath.ToSlash(util.RelPathToProjectRoot(specFile)), filepath.Ext(specFile))
+	name := fmt.Sprintf("%d-%s", scenario.Heading.LineNo, strings.Trim(unsafeFileChars.ReplaceAllString(scenario.Heading.Value, "_"), "_"))
+	if scenario.SpecDataTableRow.IsInitialized() {
+		name += fmt.Sprintf("-row-%d", scenario.SpecDataTableRowIndex+1)
+	}
+	if scenario.ScenarioDataTableRow.IsInitialized() {
+		name += fmt.Sprintf("-scenario-row-%d", scenario.ScenarioDataTableRowIndex+1)
+	}
+	return filepath.Join(reportsDir, scenarioOutputDir, unsafeFileChars.ReplaceAllString(spec, "_"), name+".log")
+}
+
+// saveScenarioOutput writes the output of the runner captured during the scenario to a file, and sets the file in the
+// result of the scenario. Nothing is written if the runner wrote nothing.
+func saveScenarioOutput(specFile string, scenario *gauge.Scenario, r *result.ScenarioResult, output []byte) {
+	if len(output) == 0 {
+		return
+	}
+	file := scenarioOutputFile(specFile, scenario)
+	if err := os.MkdirAll(filepath.Dir(file), common.NewDirectoryPermissions); err != nil {
+		logger.Errorf(true, "Failed to write the output of scenario %s. %s", scenario.Heading.Value, err.Error())
+		return
+	}
+	if err := ioutil.WriteFile(file, output, common.NewFilePermissions); err != nil {
+		logger.Errorf(true, "Failed to write the output of scenario %s. %s", scenario.Heading.Value, err.Error())
+		return
+	}
+	r.OutputFile = file
+}
@@ -0,0 +1,61 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestScenarioOutputFile(c *C) {
+	config.ProjectRoot = filepath.FromSlash("/project")
+	defer func() { config.ProjectRoot = "" }()
+	os.Setenv(env.GaugeReportsDir, "out")
+	defer os.Unsetenv(env.GaugeReportsDir)
+	scenario := &gauge.Scenario{Heading: &gauge.Heading{Value: "Login as admin/user", LineNo: 12}}
+	scenario.SpecDataTableRow.AddHeaders([]string{"a"})
+	scenario.SpecDataTableRowIndex = 1
+
+	file := scenarioOutputFile(filepath.FromSlash("/project/specs/login.spec"), scenario)
+
+	c.Assert(file, Equals, filepath.FromSlash("/project/out/artifacts/output/specs_login/12-Login_as_admin_user-row-2.log"))
+}
+
+func (s *MySuite) TestSaveScenarioOutput(c *C) {
+	config.ProjectRoot = c.MkDir()
+	defer func() { config.ProjectRoot = "" }()
+	scenario := &gauge.Scenario{Heading: &gauge.Heading{Value: "Scenario", LineNo: 3}}
+	r := &result.ScenarioResult{}
+	specFile := filepath.Join(config.ProjectRoot, "specs", "a.spec")
+
+	saveScenarioOutput(specFile, scenario, r, nil)
+	c.Assert(r.OutputFile, Equals, "")
+
+	saveScenarioOutput(specFile, scenario, r, []byte("connecting\n"))
+	c.Assert(r.OutputFile, Equals, filepath.Join(config.ProjectRoot, "reports", "artifacts", "output", "specs_a", "3-Scenario.log"))
+	b, err := ioutil.ReadFile(r.OutputFile)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, "connecting\n")
+}
@@ -21,12 +21,14 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/getgauge/gauge/env"
 	"github.com/getgauge/gauge/execution/event"
 	"github.com/getgauge/gauge/execution/result"
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/gauge_messages"
 	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/manifest"
+	"github.com/getgauge/gauge/parser"
 	"github.com/getgauge/gauge/plugin"
 	"github.com/getgauge/gauge/runner"
 )
@@ -50,19 +52,26 @@ type simpleExecution struct {
 	errMaps              *gauge.BuildErrors
 	startTime            time.Time
 	stream               int
+	executed             []*executedSpec
+	expandDataTableRows  bool
 }
 
-func newSimpleExecution(executionInfo *executionInfo, combineDataTableSpecs bool) *simpleExecution {
-	if combineDataTableSpecs {
-		executionInfo.specs = gauge.NewSpecCollection(executionInfo.specs.Specs(), true)
+// newSimpleExecution creates an execution of the given specs. If expandDataTableRows is set, the specs are expected to
+// be the ones which are not yet split into their data table rows, and the rows are created as they are executed.
+func newSimpleExecution(executionInfo *executionInfo, expandDataTableRows bool) *simpleExecution {
+	r := executionInfo.runner
+	if r != nil {
+		r = newRecoveringRunner(r, startRunner(executionInfo.manifest, executionInfo.stream))
 	}
+	trackRunner(r)
 	return &simpleExecution{
-		manifest:       executionInfo.manifest,
-		specCollection: executionInfo.specs,
-		runner:         executionInfo.runner,
-		pluginHandler:  executionInfo.pluginHandler,
-		errMaps:        executionInfo.errMaps,
-		stream:         executionInfo.stream,
+		manifest:            executionInfo.manifest,
+		specCollection:      executionInfo.specs,
+		runner:              r,
+		pluginHandler:       executionInfo.pluginHandler,
+		errMaps:             executionInfo.errMaps,
+		stream:              executionInfo.stream,
+		expandDataTableRows: expandDataTableRows,
 	}
 }
 
@@ -75,6 +84,7 @@ func (e *simpleExecution) run() *result.SuiteResult {
 
 func (e *simpleExecution) execute() {
 	e.suiteResult = result.NewSuiteResult(ExecuteTags, e.startTime)
+	e.suiteResult.Metadata = suiteMetadata()
 	setResultMeta := func() {
 		e.suiteResult.UpdateExecTime(e.startTime)
 		e.suiteResult.SetSpecsSkippedCount()
@@ -90,8 +100,10 @@ func (e *simpleExecution) execute() {
 	e.notifyBeforeSuite()
 	if !e.suiteResult.GetFailed() {
 		results := e.executeSpecs(e.specCollection)
+		e.retryFailedScenarios()
 		e.suiteResult.AddSpecResults(results)
 	}
+	recoverRunner(e.runner)
 	e.notifyAfterSuite()
 
 	setResultMeta()
@@ -99,12 +111,14 @@ func (e *simpleExecution) execute() {
 
 func (e *simpleExecution) start() {
 	e.startTime = time.Now()
-	event.Notify(event.NewExecutionEvent(event.SuiteStart, nil, nil, 0, gauge_messages.ExecutionInfo{}))
 	e.pluginHandler = plugin.StartPlugins(e.manifest)
+	event.Notify(event.NewExecutionEvent(event.SuiteStart, nil, nil, 0, gauge_messages.ExecutionInfo{}))
 }
 
 func (e *simpleExecution) finish() {
 	e.suiteResult = mergeDataTableSpecResults(e.suiteResult)
+	e.suiteResult.CollectSlowSteps(env.SlowStepThreshold(), maxSlowSteps)
+	addStopError(e.suiteResult)
 	event.Notify(event.NewExecutionEvent(event.SuiteEnd, nil, e.suiteResult, 0, gauge_messages.ExecutionInfo{}))
 	e.notifyExecutionResult()
 	e.stopAllPlugins()
@@ -120,33 +134,117 @@ func (e *simpleExecution) stopAllPlugins() {
 func (e *simpleExecution) executeSpecs(sc *gauge.SpecCollection) (results []*result.SpecResult) {
 	for sc.HasNext() {
 		specs := sc.Next()
-		var preHookFailures, postHookFailures []*gauge_messages.ProtoHookFailure
-		var specResults []*result.SpecResult
-		var before, after = true, false
-		for i, spec := range specs {
-			if i == len(specs)-1 {
-				after = true
-			}
-			res := newSpecExecutor(spec, e.runner, e.pluginHandler, e.errMaps, e.stream).execute(before, preHookFailures == nil, after)
-			before = false
-			specResults = append(specResults, res)
-			preHookFailures = append(preHookFailures, res.GetPreHook()...)
-			postHookFailures = append(postHookFailures, res.GetPostHook()...)
-			res.ProtoSpec.PreHookFailures, res.ProtoSpec.PostHookFailures = []*gauge_messages.ProtoHookFailure{}, []*gauge_messages.ProtoHookFailure{}
+		if !e.expandDataTableRows {
+			results = append(results, e.executeRows(specs)...)
+			continue
 		}
-		for _, res := range specResults {
-			for _, preHook := range preHookFailures {
-				res.AddPreHook(&gauge_messages.ProtoHookFailure{StackTrace: preHook.StackTrace, ErrorMessage: preHook.ErrorMessage, ScreenShot: preHook.ScreenShot, TableRowIndex: preHook.TableRowIndex})
+		for _, spec := range specs {
+			rows := parser.NewDataTableRows(spec, e.errMaps)
+			if RetrySuite > 0 || rows.Len() < 2 {
+				// the failed scenarios of the rows are retried once all the specs are executed, so the rows are held
+				var specRows []*gauge.Specification
+				for i := 0; i < rows.Len(); i++ {
+					specRows = append(specRows, rows.Spec(i))
+				}
+				results = append(results, e.executeRows(specRows)...)
+				continue
 			}
-			for _, postHook := range postHookFailures {
-				res.AddPostHook(&gauge_messages.ProtoHookFailure{StackTrace: postHook.StackTrace, ErrorMessage: postHook.ErrorMessage, ScreenShot: postHook.ScreenShot, TableRowIndex: postHook.TableRowIndex})
-			}
-			results = append(results, res)
+			results = append(results, e.executeAndMergeRows(rows))
 		}
 	}
 	return results
 }
 
+// executeRows executes the given rows of a spec, with the before spec hooks on the first row and the after spec hooks on the last.
+// The hook failures are reported on every row, whose results are merged once the suite is executed.
+func (e *simpleExecution) executeRows(specs []*gauge.Specification) (results []*result.SpecResult) {
+	var preHookFailures, postHookFailures []*gauge_messages.ProtoHookFailure
+	var specResults []*result.SpecResult
+	var before, after = true, false
+	for i, spec := range specs {
+		if i == len(specs)-1 {
+			after = true
+		}
+		recoverRunner(e.runner)
+		se := newSpecExecutor(spec, e.runner, e.pluginHandler, e.errMaps, e.stream)
+		res := se.execute(before, preHookFailures == nil, after)
+		e.executed = append(e.executed, &executedSpec{spec: spec, result: res, scenarios: se.executedScenarios})
+		before = false
+		specResults = append(specResults, res)
+		preHookFailures = append(preHookFailures, res.GetPreHook()...)
+		postHookFailures = append(postHookFailures, res.GetPostHook()...)
+		res.ProtoSpec.PreHookFailures, res.ProtoSpec.PostHookFailures = []*gauge_messages.ProtoHookFailure{}, []*gauge_messages.ProtoHookFailure{}
+	}
+	first := len(e.executed) - len(specResults)
+	for i, res := range specResults {
+		for _, preHook := range preHookFailures {
+			res.AddPreHook(&gauge_messages.ProtoHookFailure{StackTrace: preHook.StackTrace, ErrorMessage: preHook.ErrorMessage, ScreenShot: preHook.ScreenShot, TableRowIndex: preHook.TableRowIndex})
+		}
+		for _, postHook := range postHookFailures {
+			res.AddPostHook(&gauge_messages.ProtoHookFailure{StackTrace: postHook.StackTrace, ErrorMessage: postHook.ErrorMessage, ScreenShot: postHook.ScreenShot, TableRowIndex: postHook.TableRowIndex})
+		}
+		res = spill(res)
+		e.executed[first+i].result = res
+		results = append(results, res)
+	}
+	return results
+}
+
+// executeAndMergeRows executes the rows of a data table spec one at a time and merges the result of each row as soon as it
+// is executed, so that neither the specs nor the results of all the rows are held at once.
+func (e *simpleExecution) executeAndMergeRows(rows *parser.DataTableRows) *result.SpecResult {
+	merger := newRowsMerger()
+	var preHookFailed bool
+	for i := 0; i < rows.Len(); i++ {
+		recoverRunner(e.runner)
+		se := newSpecExecutor(rows.Spec(i), e.runner, e.pluginHandler, e.errMaps, e.stream)
+		res := se.execute(i == 0, !preHookFailed, i == rows.Len()-1)
+		preHookFailed = preHookFailed || len(res.GetPreHook()) > 0
+		merger.add(res)
+	}
+	return spill(merger.merged())
+}
+
+// spillStore holds the items of the completed spec results on disk, when spill_results_to_disk is set.
+var spillStore *result.SpillStore
+
+func initSpillStore() {
+	spillStore = nil
+	if !env.SpillResultsToDisk() {
+		return
+	}
+	s, err := result.NewSpillStore()
+	if err != nil {
+		logger.Warningf(true, "Results will be held in memory. %s", err.Error())
+		return
+	}
+	spillStore = s
+}
+
+func removeSpilledResults() {
+	if spillStore == nil {
+		return
+	}
+	if err := spillStore.Remove(); err != nil {
+		logger.Debugf(true, "Failed to remove spilled results. %s", err.Error())
+	}
+	spillStore = nil
+}
+
+// spill gives the result to be held by the suite result in place of the given one, which is spilled to disk if the
+// spill store is set up.
+func spill(res *result.SpecResult) *result.SpecResult {
+	if spillStore == nil {
+		return res
+	}
+	spilled, err := spillStore.Spill(res)
+	if err != nil {
+		logger.Debugf(true, "Holding result in memory. %s", err.Error())
+		return res
+	}
+	return spilled
+}
+
 func (e *simpleExecution) notifyBeforeSuite() {
 	m := &gauge_messages.Message{MessageType: gauge_messages.Message_ExecutionStarting,
 		ExecutionStartingRequest: &gauge_messages.ExecutionStartingRequest{}}
@@ -159,6 +257,7 @@ func (e *simpleExecution) notifyBeforeSuite() {
 }
 
 func (e *simpleExecution) notifyAfterSuite() {
+	untrackRunner(e.runner)
 	m := &gauge_messages.Message{MessageType: gauge_messages.Message_ExecutionEnding,
 		ExecutionEndingRequest: &gauge_messages.ExecutionEndingRequest{CurrentExecutionInfo: e.currentExecutionInfo}}
 	res := e.executeHook(m)
@@ -181,9 +280,14 @@ func (e *simpleExecution) executeHook(m *gauge_messages.Message) *(gauge_message
 }
 
 func (e *simpleExecution) notifyExecutionResult() {
-	m := &gauge_messages.Message{MessageType: gauge_messages.Message_SuiteExecutionResult,
-		SuiteExecutionResult: &gauge_messages.SuiteExecutionResult{SuiteResult: gauge.ConvertToProtoSuiteResult(e.suiteResult)}}
-	e.pluginHandler.NotifyPlugins(m)
+	notifySuiteResult(e.pluginHandler, e.suiteResult)
+}
+
+// notifySuiteResult sends the suite result to the plugins one spec at a time, reading spilled spec results back as they are sent.
+func notifySuiteResult(h plugin.Handler, r *result.SuiteResult) {
+	h.NotifySuiteResult(gauge.ConvertToProtoSuiteSummary(r), func(f func(*gauge_messages.ProtoSpecResult)) {
+		gauge.EachProtoSpecResult(r, f)
+	})
 }
 
 func (e *simpleExecution) notifyExecutionStop() {
@@ -21,6 +21,9 @@ import (
 	"testing"
 
 	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/validation"
 
 	"github.com/getgauge/gauge/gauge_messages"
 )
@@ -142,3 +145,92 @@ func TestNotifyAfterSuiteShouldAddsAfterSuiteHookScreenshots(t *testing.T) {
 		}
 	}
 }
+
+func TestExecuteSpecsMergesDataTableRowsAsTheyAreExecuted(t *testing.T) {
+	specText := newSpecBuilder().specHeading("A spec heading").
+		tableHeader("id").
+		tableRow("1").
+		tableRow("2").
+		tableRow("3").
+		scenarioHeading("Table driven scenario").
+		step("step with <id>").
+		scenarioHeading("Other scenario").
+		step("other step").
+		String()
+	spec, _, _ := new(parser.SpecParser).Parse(specText, gauge.NewConceptDictionary(), "")
+	spec.FileName = "FILE"
+	var steps []string
+	r := &mockRunner{}
+	r.ExecuteAndGetStatusFunc = func(m *gauge_messages.Message) *gauge_messages.ProtoExecutionResult {
+		if m.MessageType == gauge_messages.Message_ExecuteStep {
+			steps = append(steps, m.GetExecuteStepRequest().GetActualStepText())
+		}
+		params := m.GetExecuteStepRequest().GetParameters()
+		return &gauge_messages.ProtoExecutionResult{Failed: len(params) == 1 && params[0].GetValue() == "2"}
+	}
+	h := &mockPluginHandler{NotifyPluginsfunc: func(m *gauge_messages.Message) {}, GracefullyKillPluginsfunc: func() {}}
+	e := &simpleExecution{runner: r, pluginHandler: h, errMaps: gauge.NewBuildErrors(), currentExecutionInfo: &gauge_messages.ExecutionInfo{}, expandDataTableRows: true}
+
+	results := e.executeSpecs(gauge.NewSpecCollection([]*gauge.Specification{spec}, false))
+
+	if len(steps) != 4 {
+		t.Errorf("Expected the table driven scenario to be executed for each row and the other scenario once. Got %v", steps)
+	}
+	if len(results) != 1 || len(e.executed) != 0 {
+		t.Fatalf("Expected the rows to be merged into a single result as they are executed. Got %d results, %d held rows", len(results), len(e.executed))
+	}
+	res := results[0]
+	if !res.ProtoSpec.GetIsTableDriven() || res.ScenarioCount != 4 || res.ScenarioFailedCount != 1 || !res.GetFailed() {
+		t.Errorf("Expected a failed table driven result with 4 scenarios, 1 failed. Got %v", res.ProtoSpec)
+	}
+}
+
+func TestExecuteSpecsReportsValidationErrorsOnEachDataTableRow(t *testing.T) {
+	specText := newSpecBuilder().specHeading("A spec heading").
+		tableHeader("id").
+		tableRow("1").
+		tableRow("2").
+		scenarioHeading("Table driven scenario").
+		step("unimplemented step").
+		step("step with <id>").
+		scenarioHeading("Other scenario").
+		step("other step").
+		String()
+	spec, _, _ := new(parser.SpecParser).Parse(specText, gauge.NewConceptDictionary(), "")
+	spec.FileName = "FILE"
+	errMap := gauge.NewBuildErrors()
+	err := validation.NewStepValidationError(spec.Scenarios[0].Steps[0], "Step implementation not found", spec.FileName, nil, "")
+	errMap.StepErrs[spec.Scenarios[0].Steps[0]] = err
+	errMap.ScenarioErrs[spec.Scenarios[0]] = []error{err}
+	var steps []string
+	r := &mockRunner{}
+	r.ExecuteAndGetStatusFunc = func(m *gauge_messages.Message) *gauge_messages.ProtoExecutionResult {
+		if m.MessageType == gauge_messages.Message_ExecuteStep {
+			steps = append(steps, m.GetExecuteStepRequest().GetActualStepText())
+		}
+		return &gauge_messages.ProtoExecutionResult{}
+	}
+	h := &mockPluginHandler{NotifyPluginsfunc: func(m *gauge_messages.Message) {}, GracefullyKillPluginsfunc: func() {}}
+	e := &simpleExecution{runner: r, pluginHandler: h, errMaps: errMap, currentExecutionInfo: &gauge_messages.ExecutionInfo{}, expandDataTableRows: true}
+
+	results := e.executeSpecs(gauge.NewSpecCollection([]*gauge.Specification{spec}, false))
+
+	if len(steps) != 1 || steps[0] != "other step" {
+		t.Errorf("Expected only the other scenario to be executed. Got %v", steps)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Expected the rows to be merged into a single result. Got %d results", len(results))
+	}
+	var skipped int
+	for _, item := range results[0].ProtoSpec.GetItems() {
+		if s := item.GetTableDrivenScenario().GetScenario(); s != nil && s.GetSkipped() {
+			skipped++
+			if len(s.GetSkipErrors()) != 1 || s.GetSkipErrors()[0] != err.Error() {
+				t.Errorf("Expected the validation error on the skipped row. Got %v", s.GetSkipErrors())
+			}
+		}
+	}
+	if skipped != 2 || results[0].ScenarioSkippedCount != 2 {
+		t.Errorf("Expected the table driven scenario to be skipped on both rows. Got %d skipped, %d counted", skipped, results[0].ScenarioSkippedCount)
+	}
+}
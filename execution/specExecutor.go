@@ -25,6 +25,7 @@ import (
 
 	"github.com/getgauge/gauge/execution/event"
 	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/filter"
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/gauge_messages"
 	"github.com/getgauge/gauge/logger"
@@ -43,6 +44,8 @@ type specExecutor struct {
 	errMap               *gauge.BuildErrors
 	stream               int
 	scenarioExecutor     executor
+	// executedScenarios holds the scenarios in the order of their results in the spec result.
+	executedScenarios []*gauge.Scenario
 }
 
 func newSpecExecutor(s *gauge.Specification, r runner.Runner, ph plugin.Handler, e *gauge.BuildErrors, stream int) *specExecutor {
@@ -51,7 +54,7 @@ func newSpecExecutor(s *gauge.Specification, r runner.Runner, ph plugin.Handler,
 			Name:     s.Heading.Value,
 			FileName: s.FileName,
 			IsFailed: false,
-			Tags:     getTagValue(s.Tags)},
+			Tags:     append(getTagValue(s.Tags), gauge.MetaTags(s.Meta)...)},
 	}
 
 	return &specExecutor{
@@ -170,6 +173,9 @@ func (e *specExecutor) notifyBeforeSpecHook() {
 	m := &gauge_messages.Message{MessageType: gauge_messages.Message_SpecExecutionStarting,
 		SpecExecutionStartingRequest: &gauge_messages.SpecExecutionStartingRequest{CurrentExecutionInfo: e.currentExecutionInfo}}
 	e.pluginHandler.NotifyPlugins(m)
+	if !runsHook(filter.BeforeSpecHook, e.currentExecutionInfo) {
+		return
+	}
 	res := executeHook(m, e.specResult, e.runner)
 	e.specResult.ProtoSpec.PreHookMessages = res.Message
 	e.specResult.ProtoSpec.PreHookScreenshots = res.Screenshots
@@ -183,12 +189,14 @@ func (e *specExecutor) notifyAfterSpecHook() {
 	e.currentExecutionInfo.CurrentScenario = nil
 	m := &gauge_messages.Message{MessageType: gauge_messages.Message_SpecExecutionEnding,
 		SpecExecutionEndingRequest: &gauge_messages.SpecExecutionEndingRequest{CurrentExecutionInfo: e.currentExecutionInfo}}
-	res := executeHook(m, e.specResult, e.runner)
-	e.specResult.ProtoSpec.PostHookMessages = res.Message
-	e.specResult.ProtoSpec.PostHookScreenshots = res.Screenshots
-	if res.GetFailed() {
-		setSpecFailure(e.currentExecutionInfo)
-		handleHookFailure(e.specResult, res, result.AddPostHook)
+	if runsHook(filter.AfterSpecHook, e.currentExecutionInfo) {
+		res := executeHook(m, e.specResult, e.runner)
+		e.specResult.ProtoSpec.PostHookMessages = res.Message
+		e.specResult.ProtoSpec.PostHookScreenshots = res.Screenshots
+		if res.GetFailed() {
+			setSpecFailure(e.currentExecutionInfo)
+			handleHookFailure(e.specResult, res, result.AddPostHook)
+		}
 	}
 	e.pluginHandler.NotifyPlugins(m)
 }
@@ -272,9 +280,14 @@ func (e *specExecutor) executeScenarios(scenarios []*gauge.Scenario) ([]result.R
 }
 
 func (e *specExecutor) executeScenario(scenario *gauge.Scenario) (*result.ScenarioResult, error) {
+	if recoverRunner(e.runner) {
+		if res := e.initSpecDataStore(); res.GetFailed() {
+			logger.Errorf(true, "Failed to initialize spec datastore on the restarted runner. Error: %s", res.GetErrorMessage())
+		}
+	}
 	e.currentExecutionInfo.CurrentScenario = &gauge_messages.ScenarioInfo{
 		Name:     scenario.Heading.Value,
-		Tags:     getTagValue(scenario.Tags),
+		Tags:     append(getTagValue(scenario.Tags), gauge.MetaTags(scenario.Meta)...),
 		IsFailed: false,
 	}
 
@@ -288,7 +301,10 @@ func (e *specExecutor) executeScenario(scenario *gauge.Scenario) (*result.Scenar
 		return nil, err
 	}
 
+	release := scenarioLocks.acquire(lockNames(e.specification.Meta, scenario.Meta))
 	e.scenarioExecutor.execute(scenario, scenarioResult)
+	release()
+	e.executedScenarios = append(e.executedScenarios, scenario)
 	if scenarioResult.ProtoScenario.GetExecutionStatus() == gauge_messages.ExecutionStatus_SKIPPED {
 		e.specResult.ScenarioSkippedCount++
 	}
@@ -347,26 +363,48 @@ func shouldExecuteForRow(i int) bool {
 	return false
 }
 
+// getDataTableRows gives the zero based indexes of the comma separated row numbers and ranges, like 1,3,5-7.
+// A row selected more than once is executed only once.
 func getDataTableRows(tableRows string) (tableRowIndexes []int) {
 	if strings.TrimSpace(tableRows) == "" {
 		return
-	} else if strings.Contains(tableRows, "-") {
-		indexes := strings.Split(tableRows, "-")
-		startRow, _ := strconv.Atoi(strings.TrimSpace(indexes[0]))
-		endRow, _ := strconv.Atoi(strings.TrimSpace(indexes[1]))
-		for i := startRow - 1; i < endRow; i++ {
+	}
+	selected := make(map[int]bool)
+	add := func(i int) {
+		if !selected[i] {
+			selected[i] = true
 			tableRowIndexes = append(tableRowIndexes, i)
 		}
-	} else {
-		indexes := strings.Split(tableRows, ",")
-		for _, i := range indexes {
-			rowNumber, _ := strconv.Atoi(strings.TrimSpace(i))
-			tableRowIndexes = append(tableRowIndexes, rowNumber-1)
+	}
+	for _, rows := range strings.Split(tableRows, ",") {
+		if strings.Contains(rows, "-") {
+			indexes := strings.Split(rows, "-")
+			startRow, _ := strconv.Atoi(strings.TrimSpace(indexes[0]))
+			endRow, _ := strconv.Atoi(strings.TrimSpace(indexes[1]))
+			for i := startRow - 1; i < endRow; i++ {
+				add(i)
+			}
+		} else {
+			rowNumber, _ := strconv.Atoi(strings.TrimSpace(rows))
+			add(rowNumber - 1)
 		}
 	}
 	return
 }
 
+// hookTags holds the tag expressions restricting the hooks of each level. It is read from gauge_hook_tags when the execution starts.
+var hookTags map[string]string
+
+// runsHook tells if the hooks of the given level apply to the spec or scenario being executed. Spec hooks consider the tags
+// of the spec, while scenario and step hooks consider the tags of both the spec and the scenario.
+func runsHook(level string, ei *gauge_messages.ExecutionInfo) bool {
+	tags := ei.GetCurrentSpec().GetTags()
+	if level != filter.BeforeSpecHook && level != filter.AfterSpecHook {
+		tags = append(append([]string{}, tags...), ei.GetCurrentScenario().GetTags()...)
+	}
+	return filter.RunsHook(hookTags, level, tags)
+}
+
 func executeHook(message *gauge_messages.Message, execTimeTracker result.ExecTimeTracker, r runner.Runner) *gauge_messages.ProtoExecutionResult {
 	executionResult := r.ExecuteAndGetStatus(message)
 	execTimeTracker.AddExecTime(executionResult.GetExecutionTime())
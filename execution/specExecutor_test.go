@@ -29,6 +29,7 @@ import (
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/gauge_messages"
 	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/plugin"
 	"github.com/getgauge/gauge/validation"
 	. "gopkg.in/check.v1"
 )
@@ -113,6 +114,8 @@ var tableRowTests = []*tableRow{
 	{"Valid table rows range", "2-5", []int{1, 2, 3, 4}},
 	{"Empty table rows range", "", []int(nil)},
 	{"Table rows list with spaces", "2, 4 ", []int{1, 3}},
+	{"Mixed table rows list and ranges", "1,3,5-7,12", []int{0, 2, 4, 5, 6, 11}},
+	{"Overlapping table rows list and ranges", "2-4, 3, 1", []int{1, 2, 3, 0}},
 }
 
 func (s *MySuite) TestToGetDataTableRowsRangeFromInputFlag(c *C) {
@@ -284,6 +287,7 @@ func (r *mockRunner) Pid() int {
 
 type mockPluginHandler struct {
 	NotifyPluginsfunc         func(*gauge_messages.Message)
+	InterceptScenariofunc     func(*gauge_messages.Message) *plugin.Interception
 	GracefullyKillPluginsfunc func()
 }
 
@@ -291,6 +295,21 @@ func (h *mockPluginHandler) NotifyPlugins(m *gauge_messages.Message) {
 	h.NotifyPluginsfunc(m)
 }
 
+func (h *mockPluginHandler) NotifySuiteResult(r *gauge_messages.ProtoSuiteResult, specResults plugin.SpecResults) {
+	specResults(func(sr *gauge_messages.ProtoSpecResult) {
+		r.SpecResults = append(r.SpecResults, sr)
+	})
+	h.NotifyPluginsfunc(&gauge_messages.Message{MessageType: gauge_messages.Message_SuiteExecutionResult,
+		SuiteExecutionResult: &gauge_messages.SuiteExecutionResult{SuiteResult: r}})
+}
+
+func (h *mockPluginHandler) InterceptScenario(m *gauge_messages.Message) *plugin.Interception {
+	if h.InterceptScenariofunc == nil {
+		return &plugin.Interception{}
+	}
+	return h.InterceptScenariofunc(m)
+}
+
 func (h *mockPluginHandler) GracefullyKillPlugins() {
 	h.GracefullyKillPluginsfunc()
 }
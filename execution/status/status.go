@@ -0,0 +1,284 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package status keeps track of what is executing, so that operators can see where a seemingly hung run is stuck.
+// The status is written to .gauge/status.json every few seconds, and printed on the console on SIGUSR1. The scenarios
+// executed so far by all the streams can also be summed up on the console periodically.
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/util"
+)
+
+const statusFile = "status.json"
+
+// writeInterval is the interval at which the status file is updated.
+var writeInterval = 5 * time.Second
+
+// staleWrites is the number of writes missed after which the execution writing the status is taken to be gone.
+const staleWrites = 3
+
+var now = time.Now
+
+// ProgressInterval is the interval at which the scenarios executed so far are summed up on the console. Zero disables it.
+var ProgressInterval time.Duration
+
+// Status is the state of the execution written to the status file.
+type Status struct {
+	Started   string    `json:"started"`
+	Updated   string    `json:"updated"`
+	Elapsed   int64     `json:"elapsedMs"`
+	Finished  bool      `json:"finished"`
+	Executed  int       `json:"scenariosExecuted"`
+	Passed    int       `json:"scenariosPassed"`
+	Failed    int       `json:"scenariosFailed"`
+	Skipped   int       `json:"scenariosSkipped"`
+	Streams   []*Stream `json:"streams"`
+	startTime time.Time
+}
+
+// Stream is what is executing in an execution stream. Steps are empty between steps, e.g. while hooks run.
+type Stream struct {
+	Stream      int    `json:"stream"`
+	Spec        string `json:"spec,omitempty"`
+	Scenario    string `json:"scenario,omitempty"`
+	Step        string `json:"step,omitempty"`
+	StepElapsed int64  `json:"stepElapsedMs,omitempty"`
+	stepStart   time.Time
+}
+
+// counts of the scenarios executed by all the streams, updated atomically so that they can be read at any time
+type counts struct {
+	passed, failed, skipped int64
+}
+
+type tracker struct {
+	sync.Mutex
+	status  *Status
+	streams map[int]*Stream
+	counts  counts
+}
+
+func newTracker() *tracker {
+	t := now()
+	return &tracker{status: &Status{startTime: t, Started: t.Format(time.RFC3339)}, streams: make(map[int]*Stream)}
+}
+
+// ListenExecutionEvents tracks the execution, writes it to the status file every few seconds and prints it on SIGUSR1.
+func ListenExecutionEvents(wg *sync.WaitGroup) {
+	ch := make(chan event.ExecutionEvent, 0)
+	event.Register(ch, event.SpecStart, event.ScenarioStart, event.StepStart, event.StepEnd, event.ScenarioEnd, event.SpecEnd, event.SuiteEnd)
+	wg.Add(1)
+	t := newTracker()
+	t.write()
+	ticker := time.NewTicker(writeInterval)
+	dump, stopDump := notifyDump()
+	progress, stopProgress := progressTicker()
+
+	go func() {
+		for {
+			select {
+			case e := <-ch:
+				t.update(e)
+				if e.Topic == event.SuiteEnd {
+					ticker.Stop()
+					stopProgress()
+					stopDump()
+					t.write()
+					wg.Done()
+				}
+			case <-ticker.C:
+				t.write()
+			case <-dump:
+				logger.Info(true, t.String())
+			case <-progress:
+				logger.Info(true, t.progress())
+			}
+		}
+	}()
+}
+
+// progressTicker ticks at ProgressInterval. It never ticks if the interval is zero.
+func progressTicker() (<-chan time.Time, func()) {
+	if ProgressInterval <= 0 {
+		return nil, func() {}
+	}
+	ticker := time.NewTicker(ProgressInterval)
+	return ticker.C, ticker.Stop
+}
+
+func (t *tracker) stream(n int) *Stream {
+	s, ok := t.streams[n]
+	if !ok {
+		s = &Stream{Stream: n}
+		t.streams[n] = s
+	}
+	return s
+}
+
+func (t *tracker) update(e event.ExecutionEvent) {
+	t.Lock()
+	defer t.Unlock()
+	s := t.stream(e.Stream)
+	switch e.Topic {
+	case event.SpecStart:
+		s.Spec = filepath.ToSlash(util.RelPathToProjectRoot(e.Item.(*gauge.Specification).FileName))
+	case event.ScenarioStart:
+		s.Scenario = e.Item.(*gauge.Scenario).Heading.Value
+	case event.StepStart:
+		s.Step, s.stepStart = strings.TrimSpace(e.Item.(*gauge.Step).LineText), now()
+	case event.StepEnd:
+		s.Step = ""
+	case event.ScenarioEnd:
+		s.Scenario, s.Step = "", ""
+		t.count(e.Result)
+	case event.SpecEnd:
+		s.Spec, s.Scenario, s.Step = "", "", ""
+	case event.SuiteEnd:
+		t.status.Finished = true
+		t.streams = make(map[int]*Stream)
+	}
+}
+
+func (t *tracker) count(res result.Result) {
+	r, ok := res.(*result.ScenarioResult)
+	if !ok {
+		return
+	}
+	switch {
+	case r.ProtoScenario.GetExecutionStatus() == gauge_messages.ExecutionStatus_SKIPPED:
+		atomic.AddInt64(&t.counts.skipped, 1)
+	case r.GetFailed():
+		atomic.AddInt64(&t.counts.failed, 1)
+	default:
+		atomic.AddInt64(&t.counts.passed, 1)
+	}
+}
+
+// snapshot gives the status as of now, with the streams ordered by their number.
+func (t *tracker) snapshot() Status {
+	t.Lock()
+	defer t.Unlock()
+	current := now()
+	s := *t.status
+	s.Updated = current.Format(time.RFC3339)
+	s.Elapsed = int64(current.Sub(s.startTime) / time.Millisecond)
+	s.Passed = int(atomic.LoadInt64(&t.counts.passed))
+	s.Failed = int(atomic.LoadInt64(&t.counts.failed))
+	s.Skipped = int(atomic.LoadInt64(&t.counts.skipped))
+	s.Executed = s.Passed + s.Failed + s.Skipped
+	s.Streams = nil
+	for _, stream := range t.streams {
+		st := *stream
+		if st.Step != "" {
+			st.StepElapsed = int64(current.Sub(st.stepStart) / time.Millisecond)
+		}
+		s.Streams = append(s.Streams, &st)
+	}
+	sort.Slice(s.Streams, func(i, j int) bool { return s.Streams[i].Stream < s.Streams[j].Stream })
+	return s
+}
+
+// Read reads the status last written by an execution of the project.
+func Read() (*Status, error) {
+	b, err := ioutil.ReadFile(filepath.Join(config.ProjectRoot, common.DotGauge, statusFile))
+	if err != nil {
+		return nil, err
+	}
+	s := &Status{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("Invalid execution status. %s", err.Error())
+	}
+	return s, nil
+}
+
+// Stale tells whether the status has not been updated for long, i.e. the execution writing it is gone.
+func (s *Status) Stale() bool {
+	updated, err := time.Parse(time.RFC3339, s.Updated)
+	return err != nil || now().Sub(updated) > staleWrites*writeInterval
+}
+
+// InProgress tells whether the execution writing the status is still in progress.
+func (s *Status) InProgress() bool {
+	return !s.Finished && !s.Stale()
+}
+
+func (t *tracker) write() {
+	s := t.snapshot()
+	dotGaugeDir := filepath.Join(config.ProjectRoot, common.DotGauge)
+	if err := os.MkdirAll(dotGaugeDir, common.NewDirectoryPermissions); err != nil {
+		logger.Errorf(false, "Failed to create directory in %s. Reason: %s", dotGaugeDir, err.Error())
+		return
+	}
+	b, err := json.MarshalIndent(s, "", "\t")
+	if err != nil {
+		logger.Errorf(false, "Unable to marshal execution status. %s", err.Error())
+		return
+	}
+	f := filepath.Join(dotGaugeDir, statusFile)
+	if err = ioutil.WriteFile(f, b, common.NewFilePermissions); err != nil {
+		logger.Errorf(false, "Failed to write to %s. Reason: %s", f, err.Error())
+	}
+}
+
+func (t *tracker) String() string {
+	s := t.snapshot()
+	var b strings.Builder
+	fmt.Fprintf(&b, "Execution status after %s: %d passed, %d failed, %d skipped scenario(s)", duration(s.Elapsed), s.Passed, s.Failed, s.Skipped)
+	for _, stream := range s.Streams {
+		if stream.Spec == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "\n  Stream %d: %s", stream.Stream, stream.Spec)
+		if stream.Scenario != "" {
+			fmt.Fprintf(&b, " > %s", stream.Scenario)
+		}
+		if stream.Step != "" {
+			fmt.Fprintf(&b, " > %s (running for %s)", stream.Step, duration(stream.StepElapsed))
+		}
+	}
+	return b.String()
+}
+
+// progress sums up the scenarios executed so far by all the streams in a line.
+func (t *tracker) progress() string {
+	passed, failed, skipped := atomic.LoadInt64(&t.counts.passed), atomic.LoadInt64(&t.counts.failed), atomic.LoadInt64(&t.counts.skipped)
+	elapsed := int64(now().Sub(t.status.startTime) / time.Millisecond)
+	return fmt.Sprintf("Progress after %s: %d scenario(s) executed, %d passed, %d failed, %d skipped", duration(elapsed), passed+failed+skipped, passed, failed, skipped)
+}
+
+func duration(ms int64) time.Duration {
+	return (time.Duration(ms) * time.Millisecond).Round(time.Second)
+}
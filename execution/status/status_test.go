@@ -0,0 +1,152 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+var start = time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+
+func (s *MySuite) SetUpTest(c *C) {
+	dir, err := ioutil.TempDir("", "status")
+	c.Assert(err, IsNil)
+	config.ProjectRoot = dir
+	now = func() time.Time { return start }
+}
+
+func (s *MySuite) TearDownTest(c *C) {
+	os.RemoveAll(config.ProjectRoot)
+	now = time.Now
+}
+
+func newEvent(t event.Topic, i gauge.Item, r result.Result, stream int) event.ExecutionEvent {
+	return event.NewExecutionEvent(t, i, r, stream, gauge_messages.ExecutionInfo{})
+}
+
+func scenarioResult(status gauge_messages.ExecutionStatus) *result.ScenarioResult {
+	return result.NewScenarioResult(&gauge_messages.ProtoScenario{ExecutionStatus: status, Failed: status == gauge_messages.ExecutionStatus_FAILED})
+}
+
+func (s *MySuite) TestTrackerKeepsCurrentSpecScenarioAndStepPerStream(c *C) {
+	t := newTracker()
+	spec := &gauge.Specification{FileName: filepath.Join(config.ProjectRoot, "specs", "login.spec")}
+	t.update(newEvent(event.SpecStart, spec, nil, 1))
+	t.update(newEvent(event.ScenarioStart, &gauge.Scenario{Heading: &gauge.Heading{Value: "Login works"}}, nil, 1))
+	t.update(newEvent(event.StepStart, &gauge.Step{LineText: "Open the login page"}, nil, 1))
+	t.update(newEvent(event.SpecStart, &gauge.Specification{FileName: filepath.Join(config.ProjectRoot, "specs", "cart.spec")}, nil, 2))
+	now = func() time.Time { return start.Add(90 * time.Second) }
+
+	st := t.snapshot()
+
+	c.Assert(st.Elapsed, Equals, int64(90000))
+	c.Assert(len(st.Streams), Equals, 2)
+	c.Assert(*st.Streams[0], DeepEquals, Stream{Stream: 1, Spec: "specs/login.spec", Scenario: "Login works", Step: "Open the login page", StepElapsed: 90000, stepStart: start})
+	c.Assert(*st.Streams[1], DeepEquals, Stream{Stream: 2, Spec: "specs/cart.spec"})
+	c.Assert(t.String(), Equals, "Execution status after 1m30s: 0 passed, 0 failed, 0 skipped scenario(s)\n"+
+		"  Stream 1: specs/login.spec > Login works > Open the login page (running for 1m30s)\n"+
+		"  Stream 2: specs/cart.spec")
+}
+
+func (s *MySuite) TestTrackerCountsScenarios(c *C) {
+	t := newTracker()
+	sce := &gauge.Scenario{Heading: &gauge.Heading{Value: "A scenario"}}
+	t.update(newEvent(event.ScenarioStart, sce, nil, 0))
+	t.update(newEvent(event.ScenarioEnd, sce, scenarioResult(gauge_messages.ExecutionStatus_PASSED), 0))
+	t.update(newEvent(event.ScenarioEnd, sce, scenarioResult(gauge_messages.ExecutionStatus_FAILED), 0))
+	t.update(newEvent(event.ScenarioEnd, sce, scenarioResult(gauge_messages.ExecutionStatus_FAILED), 0))
+	t.update(newEvent(event.ScenarioEnd, sce, scenarioResult(gauge_messages.ExecutionStatus_SKIPPED), 0))
+
+	st := t.snapshot()
+
+	c.Assert(st.Executed, Equals, 4)
+	c.Assert(st.Passed, Equals, 1)
+	c.Assert(st.Failed, Equals, 2)
+	c.Assert(st.Skipped, Equals, 1)
+	c.Assert(st.Streams[0].Scenario, Equals, "")
+}
+
+func (s *MySuite) TestWriteStatusFile(c *C) {
+	t := newTracker()
+	t.update(newEvent(event.SpecStart, &gauge.Specification{FileName: filepath.Join(config.ProjectRoot, "a.spec")}, nil, 0))
+	t.update(newEvent(event.SuiteEnd, nil, nil, 0))
+
+	t.write()
+
+	b, err := ioutil.ReadFile(filepath.Join(config.ProjectRoot, common.DotGauge, statusFile))
+	c.Assert(err, IsNil)
+	var st Status
+	c.Assert(json.Unmarshal(b, &st), IsNil)
+	c.Assert(st.Finished, Equals, true)
+	c.Assert(st.Started, Equals, start.Format(time.RFC3339))
+	c.Assert(len(st.Streams), Equals, 0)
+}
+
+func (s *MySuite) TestProgressSumsUpTheScenariosOfAllStreams(c *C) {
+	t := newTracker()
+	sce := &gauge.Scenario{Heading: &gauge.Heading{Value: "A scenario"}}
+	t.update(newEvent(event.ScenarioEnd, sce, scenarioResult(gauge_messages.ExecutionStatus_PASSED), 1))
+	t.update(newEvent(event.ScenarioEnd, sce, scenarioResult(gauge_messages.ExecutionStatus_PASSED), 2))
+	t.update(newEvent(event.ScenarioEnd, sce, scenarioResult(gauge_messages.ExecutionStatus_FAILED), 3))
+	now = func() time.Time { return start.Add(75 * time.Second) }
+
+	c.Assert(t.progress(), Equals, "Progress after 1m15s: 3 scenario(s) executed, 2 passed, 1 failed, 0 skipped")
+}
+
+func (s *MySuite) TestReadStatusWrittenByExecutionInProgress(c *C) {
+	t := newTracker()
+	t.write()
+
+	st, err := Read()
+
+	c.Assert(err, IsNil)
+	c.Assert(st.InProgress(), Equals, true)
+	now = func() time.Time { return start.Add(staleWrites*writeInterval + time.Second) }
+	c.Assert(st.Stale(), Equals, true)
+	c.Assert(st.InProgress(), Equals, false)
+}
+
+func (s *MySuite) TestFinishedExecutionIsNotInProgress(c *C) {
+	t := newTracker()
+	t.update(newEvent(event.SuiteEnd, nil, nil, 0))
+	t.write()
+
+	st, err := Read()
+
+	c.Assert(err, IsNil)
+	c.Assert(st.InProgress(), Equals, false)
+}
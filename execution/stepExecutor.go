@@ -20,6 +20,7 @@ package execution
 import (
 	"github.com/getgauge/gauge/execution/event"
 	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/filter"
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/gauge_messages"
 	"github.com/getgauge/gauge/plugin"
@@ -74,6 +75,9 @@ func (e *stepExecutor) notifyBeforeStepHook(stepResult *result.StepResult) {
 		StepExecutionStartingRequest: &gauge_messages.StepExecutionStartingRequest{CurrentExecutionInfo: e.currentExecutionInfo},
 	}
 	e.pluginHandler.NotifyPlugins(m)
+	if !runsHook(filter.BeforeStepHook, e.currentExecutionInfo) {
+		return
+	}
 	res := executeHook(m, stepResult, e.runner)
 	stepResult.ProtoStep.PreHookMessages = res.Message
 	stepResult.ProtoStep.PreHookScreenshots = res.Screenshots
@@ -89,12 +93,14 @@ func (e *stepExecutor) notifyAfterStepHook(stepResult *result.StepResult) {
 		StepExecutionEndingRequest: &gauge_messages.StepExecutionEndingRequest{CurrentExecutionInfo: e.currentExecutionInfo},
 	}
 
-	res := executeHook(m, stepResult, e.runner)
-	stepResult.ProtoStep.PostHookMessages = res.Message
-	stepResult.ProtoStep.PostHookScreenshots = res.Screenshots
-	if res.GetFailed() {
-		setStepFailure(e.currentExecutionInfo)
-		handleHookFailure(stepResult, res, result.AddPostHook)
+	if runsHook(filter.AfterStepHook, e.currentExecutionInfo) {
+		res := executeHook(m, stepResult, e.runner)
+		stepResult.ProtoStep.PostHookMessages = res.Message
+		stepResult.ProtoStep.PostHookScreenshots = res.Screenshots
+		if res.GetFailed() {
+			setStepFailure(e.currentExecutionInfo)
+			handleHookFailure(stepResult, res, result.AddPostHook)
+		}
 	}
 	e.pluginHandler.NotifyPlugins(m)
 }
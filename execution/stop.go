@@ -0,0 +1,137 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/runner"
+)
+
+// stopGracePeriod is the time given to the steps running when the execution is stopped, after which the runners are killed.
+var stopGracePeriod = 30 * time.Second
+
+// stopped holds why the execution was stopped before all the specs were executed, on a timeout or an interrupt.
+// The remaining scenarios are skipped, and the runners are killed if the running steps do not complete within
+// the grace period. The after suite hooks run and the results are reported as usual, and a runner is no longer
+// killed once it runs them.
+var stopped = struct {
+	sync.Mutex
+	reason     string
+	exitCode   int
+	graceTimer *time.Timer
+	runners    []runner.Runner
+}{}
+
+// watchForStop stops the execution once the timeout expires or gauge is interrupted. It returns a function to end the watch.
+func watchForStop(timeout time.Duration) func() {
+	resetStop()
+	stopTimeout := startTimeout(timeout)
+	stopInterrupts := watchInterrupts()
+	return func() {
+		stopInterrupts()
+		stopTimeout()
+		endStop()
+	}
+}
+
+func resetStop() {
+	stopped.Lock()
+	defer stopped.Unlock()
+	stopped.reason, stopped.exitCode, stopped.runners = "", Success, nil
+}
+
+// stopExecution skips the scenarios which are yet to start. It returns false if the execution was already stopped.
+func stopExecution(reason string, exitCode int) bool {
+	stopped.Lock()
+	if stopped.reason != "" {
+		stopped.Unlock()
+		return false
+	}
+	stopped.reason, stopped.exitCode = reason, exitCode
+	stopped.graceTimer = time.AfterFunc(stopGracePeriod, killTrackedRunners)
+	stopped.Unlock()
+	logger.Errorf(true, "%s. Skipping the remaining scenarios.", reason)
+	abortExecution(reason)
+	return true
+}
+
+func endStop() {
+	stopped.Lock()
+	defer stopped.Unlock()
+	if stopped.graceTimer != nil {
+		stopped.graceTimer.Stop()
+		stopped.graceTimer = nil
+	}
+	stopped.runners = nil
+}
+
+// trackRunner registers a runner to be killed if the steps running on it outlive the grace period of a stop.
+func trackRunner(r runner.Runner) {
+	if r == nil {
+		return
+	}
+	stopped.Lock()
+	defer stopped.Unlock()
+	stopped.runners = append(stopped.runners, r)
+}
+
+// untrackRunner spares a runner from being killed on a stop, as it has started running the after suite hooks.
+func untrackRunner(r runner.Runner) {
+	stopped.Lock()
+	defer stopped.Unlock()
+	var remaining []runner.Runner
+	for _, tracked := range stopped.runners {
+		if tracked != r {
+			remaining = append(remaining, tracked)
+		}
+	}
+	stopped.runners = remaining
+}
+
+func killTrackedRunners() {
+	stopped.Lock()
+	defer stopped.Unlock()
+	for _, r := range stopped.runners {
+		if !r.Alive() {
+			continue
+		}
+		logger.Errorf(true, "Killing runner with pid %d, as its steps did not complete within %s of stopping the execution.", r.Pid(), stopGracePeriod)
+		if err := r.Kill(); err != nil {
+			logger.Errorf(true, "Failed to kill runner: %s", err.Error())
+		}
+	}
+}
+
+func stopReason() (string, int) {
+	stopped.Lock()
+	defer stopped.Unlock()
+	return stopped.reason, stopped.exitCode
+}
+
+// addStopError fails the suite result if the execution was stopped, so that the reports tell why scenarios were skipped.
+func addStopError(res *result.SuiteResult) {
+	if reason, _ := stopReason(); reason != "" {
+		res.AddUnhandledError(fmt.Errorf("%s", reason))
+		res.SetFailure()
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"syscall"
+	"time"
+
+	"github.com/getgauge/gauge/execution/result"
+	. "gopkg.in/check.v1"
+)
+
+type killableRunner struct {
+	mockRunner
+	killed chan bool
+}
+
+func (r *killableRunner) Alive() bool {
+	return true
+}
+
+func (r *killableRunner) Kill() error {
+	r.killed <- true
+	return nil
+}
+
+func (s *MySuite) TestStoppedExecutionKillsRunnersAfterGracePeriod(c *C) {
+	defer func(d time.Duration) { stopGracePeriod = d }(stopGracePeriod)
+	defer resetAbort()
+	defer resetStop()
+	stopGracePeriod = 10 * time.Millisecond
+	r := &killableRunner{killed: make(chan bool, 1)}
+	trackRunner(r)
+
+	c.Assert(stopExecution("Execution timed out after 1m", TimedOut), Equals, true)
+	c.Assert(stopExecution("Execution interrupted by interrupt", Interrupted), Equals, false)
+
+	select {
+	case <-r.killed:
+	case <-time.After(5 * time.Second):
+		c.Fatal("runner was not killed after the grace period")
+	}
+	reason, code := stopReason()
+	c.Assert(reason, Equals, "Execution timed out after 1m")
+	c.Assert(code, Equals, TimedOut)
+	res := result.NewSuiteResult("", time.Now())
+	addStopError(res)
+	c.Assert(res.IsFailed, Equals, true)
+	c.Assert(res.UnhandledErrors[0].Error(), Equals, reason)
+}
+
+func (s *MySuite) TestEndedStopDoesNotKillRunners(c *C) {
+	defer func(d time.Duration) { stopGracePeriod = d }(stopGracePeriod)
+	defer resetAbort()
+	defer resetStop()
+	stopGracePeriod = 10 * time.Millisecond
+	r := &killableRunner{killed: make(chan bool, 1)}
+	trackRunner(r)
+
+	stopExecution("Execution interrupted by interrupt", Interrupted)
+	endStop()
+	time.Sleep(50 * time.Millisecond)
+
+	c.Assert(len(r.killed), Equals, 0)
+}
+
+func (s *MySuite) TestRunnerRunningAfterSuiteHooksIsNotKilled(c *C) {
+	defer func(d time.Duration) { stopGracePeriod = d }(stopGracePeriod)
+	defer resetAbort()
+	defer resetStop()
+	stopGracePeriod = 50 * time.Millisecond
+	inSteps := &killableRunner{killed: make(chan bool, 1)}
+	inAfterSuite := &killableRunner{killed: make(chan bool, 1)}
+	trackRunner(inSteps)
+	trackRunner(inAfterSuite)
+
+	stopExecution("Execution interrupted by interrupt", Interrupted)
+	untrackRunner(inAfterSuite)
+
+	select {
+	case <-inSteps.killed:
+	case <-time.After(5 * time.Second):
+		c.Fatal("runner was not killed after the grace period")
+	}
+	c.Assert(len(inAfterSuite.killed), Equals, 0)
+	endStop()
+}
+
+func (s *MySuite) TestSecondInterruptExits(c *C) {
+	defer func(f func(int)) { exit = f }(exit)
+	defer resetAbort()
+	defer resetStop()
+	defer func(f func()) { stopFixtures = f }(stopFixtures)
+	exitCode := -1
+	exit = func(code int) { exitCode = code }
+	fixturesStopped := false
+	stopFixtures = func() { fixturesStopped = true }
+
+	onInterrupt(syscall.SIGTERM)
+	c.Assert(exitCode, Equals, -1)
+	c.Assert(fixturesStopped, Equals, false)
+	reason, code := stopReason()
+	c.Assert(reason, Equals, "Execution interrupted by terminated")
+	c.Assert(code, Equals, Interrupted)
+
+	onInterrupt(syscall.SIGINT)
+	c.Assert(exitCode, Equals, Interrupted)
+	c.Assert(fixturesStopped, Equals, true)
+	endStop()
+}
@@ -0,0 +1,93 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+)
+
+const (
+	// streamIDEnv holds the number of the parallel stream a runner executes, starting at 1.
+	streamIDEnv = "GAUGE_STREAM_ID"
+	// streamDirEnv holds the scratch directory of the parallel stream, for runners to write files like downloads to.
+	streamDirEnv = "GAUGE_STREAM_DIR"
+	streamsDir   = "streams"
+)
+
+// streamDir gives the scratch directory of a parallel stream, .gauge/streams/<stream> in the project.
+func streamDir(stream int) string {
+	return filepath.Join(config.ProjectRoot, common.DotGauge, streamsDir, strconv.Itoa(stream))
+}
+
+// prepareStreamDir empties the scratch directory of the stream, left over from an earlier run.
+func prepareStreamDir(stream int) error {
+	dir := streamDir(stream)
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("Failed to clean the directory of stream %d. %s", stream, err.Error())
+	}
+	if err := os.MkdirAll(dir, common.NewDirectoryPermissions); err != nil {
+		return fmt.Errorf("Failed to create the directory of stream %d. %s", stream, err.Error())
+	}
+	return nil
+}
+
+// streamDirs empties the scratch directory of each stream once in a run. Stream 1 runs again for the serial specs
+// after the parallel streams, and keeps the files written in the parallel phase then.
+type streamDirs struct {
+	sync.Mutex
+	prepared map[int]bool
+}
+
+func (d *streamDirs) prepare(stream int) error {
+	d.Lock()
+	defer d.Unlock()
+	if d.prepared[stream] {
+		return nil
+	}
+	if err := prepareStreamDir(stream); err != nil {
+		return err
+	}
+	if d.prepared == nil {
+		d.prepared = make(map[int]bool)
+	}
+	d.prepared[stream] = true
+	return nil
+}
+
+// streamEnv gives the environment of the runner of a parallel stream. The reports and logs of the runner go to a
+// directory of the stream too, so that runners writing files like screenshots in parallel do not overwrite each other.
+func streamEnv(stream int) []string {
+	e := []string{
+		fmt.Sprintf("%s=%d", streamIDEnv, stream),
+		fmt.Sprintf("%s=%s", streamDirEnv, streamDir(stream)),
+	}
+	for _, name := range []string{env.GaugeReportsDir, env.LogsDirectory} {
+		if v := os.Getenv(name); v != "" {
+			e = append(e, fmt.Sprintf("%s=%s", name, filepath.Join(v, fmt.Sprintf("stream-%d", stream))))
+		}
+	}
+	return e
+}
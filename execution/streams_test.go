@@ -0,0 +1,91 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+)
+
+func TestPrepareStreamDirEmptiesTheDirectoryOfTheStream(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "gauge-streams")
+	defer os.RemoveAll(dir)
+	defer func(root string) { config.ProjectRoot = root }(config.ProjectRoot)
+	config.ProjectRoot = dir
+	os.MkdirAll(streamDir(2), 0755)
+	ioutil.WriteFile(filepath.Join(streamDir(2), "download.pdf"), []byte("stale"), 0644)
+
+	if err := prepareStreamDir(2); err != nil {
+		t.Fatalf("Expected no error. Got %s", err.Error())
+	}
+
+	if files, err := ioutil.ReadDir(streamDir(2)); err != nil || len(files) != 0 {
+		t.Errorf("Expected an empty directory. Got %v, %v", files, err)
+	}
+	if streamDir(2) != filepath.Join(dir, ".gauge", "streams", "2") {
+		t.Errorf("Unexpected directory of stream 2, %s", streamDir(2))
+	}
+}
+
+func TestStreamDirIsEmptiedOnceInARun(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "gauge-streams")
+	defer os.RemoveAll(dir)
+	defer func(root string) { config.ProjectRoot = root }(config.ProjectRoot)
+	config.ProjectRoot = dir
+	os.MkdirAll(streamDir(1), 0755)
+	ioutil.WriteFile(filepath.Join(streamDir(1), "stale.pdf"), []byte("stale"), 0644)
+	dirs := &streamDirs{}
+
+	if err := dirs.prepare(1); err != nil {
+		t.Fatalf("Expected no error. Got %s", err.Error())
+	}
+	ioutil.WriteFile(filepath.Join(streamDir(1), "parallel.pdf"), []byte("parallel"), 0644)
+	if err := dirs.prepare(1); err != nil {
+		t.Fatalf("Expected no error. Got %s", err.Error())
+	}
+
+	files, err := ioutil.ReadDir(streamDir(1))
+	if err != nil || len(files) != 1 || files[0].Name() != "parallel.pdf" {
+		t.Errorf("Expected the file of the parallel phase only. Got %v, %v", files, err)
+	}
+}
+
+func TestStreamEnvIsolatesReportsAndLogs(t *testing.T) {
+	defer func(root string) { config.ProjectRoot = root }(config.ProjectRoot)
+	config.ProjectRoot = "project"
+	defer os.Setenv(env.GaugeReportsDir, os.Getenv(env.GaugeReportsDir))
+	defer os.Setenv(env.LogsDirectory, os.Getenv(env.LogsDirectory))
+	os.Setenv(env.GaugeReportsDir, "reports")
+	os.Setenv(env.LogsDirectory, "logs")
+
+	want := []string{
+		"GAUGE_STREAM_ID=3",
+		"GAUGE_STREAM_DIR=" + filepath.Join("project", ".gauge", "streams", "3"),
+		"gauge_reports_dir=" + filepath.Join("reports", "stream-3"),
+		"logs_directory=" + filepath.Join("logs", "stream-3"),
+	}
+	if got := streamEnv(3); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v. Got %v", want, got)
+	}
+}
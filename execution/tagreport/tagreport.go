@@ -0,0 +1,211 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package tagreport sums up the results of the scenarios per tag, with the number of scenarios which passed, failed
+// and were skipped and the time they took, and writes this matrix as JSON or CSV to the reports directory.
+package tagreport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+)
+
+const (
+	// JSON writes the matrix as an array of rows to tags.json
+	JSON = "json"
+	// CSV writes the matrix with a header row to tags.csv
+	CSV = "csv"
+
+	reportDir = "tags"
+)
+
+var writers = map[string]func([]*Row) ([]byte, error){
+	JSON: toJSON,
+	CSV:  toCSV,
+}
+
+// Row holds the results of the scenarios having a tag. The tags of a spec apply to all of its scenarios, and a scenario
+// having many tags is counted in the row of each of them, so the rows do not add up to the whole run.
+type Row struct {
+	Tag      string `json:"tag"`
+	Total    int    `json:"total"`
+	Passed   int    `json:"passed"`
+	Failed   int    `json:"failed"`
+	Skipped  int    `json:"skipped"`
+	Duration int64  `json:"durationMs"`
+}
+
+func (r *Row) add(sce *gauge_messages.ProtoScenario) {
+	r.Total++
+	switch sce.GetExecutionStatus() {
+	case gauge_messages.ExecutionStatus_FAILED:
+		r.Failed++
+	case gauge_messages.ExecutionStatus_PASSED:
+		r.Passed++
+	default:
+		r.Skipped++
+	}
+	r.Duration += sce.GetExecutionTime()
+}
+
+// Matrix gives a row for every tag of the scenarios in the result, sorted by tag. Every row of a table driven scenario
+// is counted as a scenario.
+func Matrix(res *result.SuiteResult) []*Row {
+	rows := make(map[string]*Row)
+	res.EachSpecResult(func(specRes *result.SpecResult) {
+		spec := specRes.ProtoSpec
+		for _, item := range spec.GetItems() {
+			sce := item.GetScenario()
+			if item.GetItemType() == gauge_messages.ProtoItem_TableDrivenScenario {
+				sce = item.GetTableDrivenScenario().GetScenario()
+			}
+			if sce == nil {
+				continue
+			}
+			for _, tag := range tags(spec.GetTags(), sce.GetTags()) {
+				if rows[tag] == nil {
+					rows[tag] = &Row{Tag: tag}
+				}
+				rows[tag].add(sce)
+			}
+		}
+	})
+	matrix := make([]*Row, 0, len(rows))
+	for _, r := range rows {
+		matrix = append(matrix, r)
+	}
+	sort.Slice(matrix, func(i, j int) bool { return matrix[i].Tag < matrix[j].Tag })
+	return matrix
+}
+
+// tags gives the distinct tags of a scenario, along with the ones of its spec.
+func tags(specTags, scenarioTags []string) []string {
+	var distinct []string
+	seen := make(map[string]bool)
+	for _, t := range append(append([]string{}, specTags...), scenarioTags...) {
+		t = strings.TrimSpace(t)
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		distinct = append(distinct, t)
+	}
+	return distinct
+}
+
+func toJSON(rows []*Row) ([]byte, error) {
+	return json.MarshalIndent(rows, "", "  ")
+}
+
+func toCSV(rows []*Row) ([]byte, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	w.Write([]string{"tag", "total", "passed", "failed", "skipped", "durationMs"})
+	for _, r := range rows {
+		w.Write([]string{r.Tag, strconv.Itoa(r.Total), strconv.Itoa(r.Passed), strconv.Itoa(r.Failed), strconv.Itoa(r.Skipped), strconv.FormatInt(r.Duration, 10)})
+	}
+	w.Flush()
+	return b.Bytes(), w.Error()
+}
+
+// Formats gives the formats of the tag report set in the tag_report property. Unknown formats are left out with a warning.
+func Formats() []string {
+	var formats []string
+	for _, f := range strings.Split(os.Getenv(env.TagReport), ",") {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if f == "" {
+			continue
+		}
+		if _, ok := writers[f]; !ok {
+			logger.Warningf(true, "Invalid tag report format '%s'. Possible formats are %s and %s", f, JSON, CSV)
+			continue
+		}
+		formats = append(formats, f)
+	}
+	return formats
+}
+
+// ListenSuiteEnd writes the tag report in each of the formats set in the tag_report property, to <reports dir>/tags/tags.<format>.
+func ListenSuiteEnd(wg *sync.WaitGroup) {
+	formats := Formats()
+	if len(formats) == 0 {
+		return
+	}
+	ch := make(chan event.ExecutionEvent, 0)
+	event.Register(ch, event.SuiteEnd)
+	wg.Add(1)
+
+	go func() {
+		for {
+			e := <-ch
+			if e.Topic == event.SuiteEnd {
+				matrix := Matrix(e.Result.(*result.SuiteResult))
+				for _, f := range formats {
+					write(matrix, f)
+				}
+				wg.Done()
+			}
+		}
+	}()
+}
+
+func write(matrix []*Row, format string) {
+	b, err := writers[format](matrix)
+	if err != nil {
+		logger.Errorf(true, "Failed to generate %s tag report. %s", format, err.Error())
+		return
+	}
+	dir := filepath.Join(reportsDir(), reportDir)
+	if err := os.MkdirAll(dir, common.NewDirectoryPermissions); err != nil {
+		logger.Errorf(true, "Failed to create directory %s. %s", dir, err.Error())
+		return
+	}
+	file := filepath.Join(dir, fmt.Sprintf("tags.%s", format))
+	if err := ioutil.WriteFile(file, b, common.NewFilePermissions); err != nil {
+		logger.Errorf(true, "Failed to write tag report to %s. %s", file, err.Error())
+		return
+	}
+	logger.Infof(true, "Successfully generated %s tag report in => %s", format, file)
+}
+
+func reportsDir() string {
+	dir := os.Getenv(env.GaugeReportsDir)
+	if dir == "" {
+		dir = "reports"
+	}
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(config.ProjectRoot, dir)
+}
@@ -0,0 +1,98 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package tagreport
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge_messages"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) SetUpTest(c *C) {
+	dir, err := ioutil.TempDir("", "tagreport")
+	c.Assert(err, IsNil)
+	config.ProjectRoot = dir
+}
+
+func (s *MySuite) TearDownTest(c *C) {
+	os.RemoveAll(config.ProjectRoot)
+	os.Unsetenv(env.GaugeReportsDir)
+	os.Unsetenv(env.TagReport)
+}
+
+func scenario(status gauge_messages.ExecutionStatus, time int64, tags ...string) *gauge_messages.ProtoItem {
+	return &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_Scenario, Scenario: &gauge_messages.ProtoScenario{ExecutionStatus: status, ExecutionTime: time, Tags: tags}}
+}
+
+func suiteResult() *result.SuiteResult {
+	payments := &gauge_messages.ProtoSpec{Tags: []string{"payments"}, Items: []*gauge_messages.ProtoItem{
+		scenario(gauge_messages.ExecutionStatus_PASSED, 100, "smoke"),
+		scenario(gauge_messages.ExecutionStatus_FAILED, 200, "payments", "refunds"),
+		{ItemType: gauge_messages.ProtoItem_TableDrivenScenario, TableDrivenScenario: &gauge_messages.ProtoTableDrivenScenario{
+			Scenario: &gauge_messages.ProtoScenario{ExecutionStatus: gauge_messages.ExecutionStatus_SKIPPED, Tags: []string{"refunds"}}}},
+	}}
+	login := &gauge_messages.ProtoSpec{Items: []*gauge_messages.ProtoItem{
+		scenario(gauge_messages.ExecutionStatus_PASSED, 50, "smoke"),
+		scenario(gauge_messages.ExecutionStatus_PASSED, 10),
+	}}
+	return &result.SuiteResult{SpecResults: []*result.SpecResult{{ProtoSpec: payments}, {ProtoSpec: login}}}
+}
+
+func (s *MySuite) TestMatrix(c *C) {
+	m := Matrix(suiteResult())
+
+	c.Assert(m, DeepEquals, []*Row{
+		{Tag: "payments", Total: 3, Passed: 1, Failed: 1, Skipped: 1, Duration: 300},
+		{Tag: "refunds", Total: 2, Failed: 1, Skipped: 1, Duration: 200},
+		{Tag: "smoke", Total: 2, Passed: 2, Duration: 150},
+	})
+}
+
+func (s *MySuite) TestToCSV(c *C) {
+	b, err := toCSV([]*Row{{Tag: "a, b", Total: 2, Passed: 1, Failed: 1, Duration: 30}})
+
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, "tag,total,passed,failed,skipped,durationMs\n\"a, b\",2,1,1,0,30\n")
+}
+
+func (s *MySuite) TestFormats(c *C) {
+	os.Setenv(env.TagReport, " JSON, xml ,csv")
+
+	c.Assert(Formats(), DeepEquals, []string{JSON, CSV})
+}
+
+func (s *MySuite) TestWrite(c *C) {
+	write(Matrix(suiteResult()), JSON)
+
+	b, err := ioutil.ReadFile(filepath.Join(config.ProjectRoot, "reports", "tags", "tags.json"))
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `(?s)\[\s*\{\s*"tag": "payments",\s*"total": 3,.*"durationMs": 150\s*\}\s*\]`)
+}
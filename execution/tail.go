@@ -0,0 +1,85 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"sync"
+	"time"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/eventlog"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/execution/status"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/reporter"
+)
+
+// Tail attaches to the execution in progress in the project and reports it on the console, from its start, as it
+// was reported by the execution itself. The events are read from the event log, and the execution is taken to be
+// gone when its status is no longer updated. It returns once the execution ends.
+func Tail() int {
+	if s, err := status.Read(); err != nil || !s.InProgress() {
+		logger.Errorf(true, "No execution is in progress in %s.", config.ProjectRoot)
+		return ExecutionFailed
+	}
+	records := make(chan *eventlog.Record)
+	followed := make(chan error, 1)
+	go func() {
+		followed <- eventlog.Follow(eventlog.Path(), records, func() bool {
+			s, err := status.Read()
+			return err != nil || s.Stale()
+		})
+	}()
+
+	first, ok := <-records
+	if !ok {
+		logger.Errorf(true, "%s", (<-followed).Error())
+		return ExecutionFailed
+	}
+	if first.Streams > 0 {
+		reporter.IsParallel = true
+		reporter.NumberOfExecutionStreams = first.Streams
+	}
+	event.InitRegistry()
+	wg := &sync.WaitGroup{}
+	reporter.ListenExecutionEvents(wg)
+	r := eventlog.NewReplayer()
+	var suiteResult *result.SuiteResult
+	for rec := first; ok; rec, ok = <-records {
+		e, known := r.Event(rec)
+		if !known {
+			continue
+		}
+		event.Notify(e)
+		if e.Topic == event.SuiteEnd {
+			suiteResult = e.Result.(*result.SuiteResult)
+		}
+	}
+	if err := <-followed; err != nil {
+		logger.Errorf(true, "%s", err.Error())
+		return ExecutionFailed
+	}
+	wg.Wait()
+	printCounts(suiteResult)
+	logger.Infof(true, "\nTotal time taken: %s", time.Millisecond*time.Duration(suiteResult.ExecutionTime))
+	if suiteResult.IsFailed {
+		return ExecutionFailed
+	}
+	return Success
+}
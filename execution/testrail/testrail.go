@@ -0,0 +1,202 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package testrail pushes the results of scenarios tagged with `testrail:C<case id>` to a TestRail run at the end of the suite.
+package testrail
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/util"
+)
+
+const (
+	caseTagPrefix = "testrail:C"
+	timeout       = 30 * time.Second
+
+	// status ids defined by TestRail
+	passed  = 1
+	blocked = 2
+	failed  = 5
+)
+
+type credentials struct {
+	url    string
+	user   string
+	apiKey string
+	runID  int
+}
+
+// CaseResult is the result of a TestRail case, as accepted by the add_results_for_cases API
+type CaseResult struct {
+	CaseID   int    `json:"case_id"`
+	StatusID int    `json:"status_id"`
+	Comment  string `json:"comment,omitempty"`
+	Elapsed  string `json:"elapsed,omitempty"`
+}
+
+type results struct {
+	Results []CaseResult `json:"results"`
+}
+
+func loadCredentials() (*credentials, error) {
+	c := &credentials{
+		url:    strings.TrimRight(strings.TrimSpace(os.Getenv(env.TestRailURL)), "/"),
+		user:   strings.TrimSpace(os.Getenv(env.TestRailUser)),
+		apiKey: strings.TrimSpace(os.Getenv(env.TestRailAPIKey)),
+	}
+	if c.url == "" || c.user == "" || c.apiKey == "" {
+		return nil, nil
+	}
+	runID, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(os.Getenv(env.TestRailRunID)), "R"))
+	if err != nil || runID <= 0 {
+		return nil, fmt.Errorf("Invalid value for %s. It should be the id of an existing TestRail run", env.TestRailRunID)
+	}
+	c.runID = runID
+	return c, nil
+}
+
+// CaseID gives the TestRail case id of a scenario from its `testrail:C<id>` tag.
+func CaseID(tags *gauge.Tags) (int, bool) {
+	if tags == nil {
+		return 0, false
+	}
+	for _, tag := range tags.Values() {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "@")
+		if !strings.HasPrefix(tag, caseTagPrefix) {
+			continue
+		}
+		if id, err := strconv.Atoi(strings.TrimPrefix(tag, caseTagPrefix)); err == nil && id > 0 {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// ListenScenarioResults collects the results of the scenarios mapped to TestRail cases and adds them to the
+// TestRail run given by testrail_run_id at the end of the suite. Nothing is pushed if the credentials are not configured.
+func ListenScenarioResults(wg *sync.WaitGroup) {
+	c, err := loadCredentials()
+	if err != nil {
+		logger.Errorf(true, "Results will not be pushed to TestRail. %s", err.Error())
+		return
+	}
+	if c == nil {
+		return
+	}
+	ch := make(chan event.ExecutionEvent, 0)
+	event.Register(ch, event.ScenarioEnd, event.SuiteEnd)
+	wg.Add(1)
+
+	go func() {
+		var res []CaseResult
+		var unmapped []string
+		for {
+			e := <-ch
+			switch e.Topic {
+			case event.ScenarioEnd:
+				sce := e.Item.(*gauge.Scenario)
+				if r, ok := toResult(sce, e.Result.(*result.ScenarioResult)); ok {
+					res = append(res, r)
+				} else {
+					unmapped = append(unmapped, fmt.Sprintf("%s:%d %s", util.RelPathToProjectRoot(e.ExecutionInfo.GetCurrentSpec().GetFileName()), sce.Heading.LineNo, sce.Heading.Value))
+				}
+			case event.SuiteEnd:
+				c.push(res, unmapped)
+				wg.Done()
+			}
+		}
+	}()
+}
+
+func toResult(sce *gauge.Scenario, res *result.ScenarioResult) (CaseResult, bool) {
+	id, ok := CaseID(sce.Tags)
+	if !ok {
+		return CaseResult{}, false
+	}
+	r := CaseResult{CaseID: id, StatusID: passed}
+	switch res.ProtoScenario.GetExecutionStatus() {
+	case gauge_messages.ExecutionStatus_FAILED:
+		r.StatusID = failed
+		r.Comment = res.FailureMessage()
+	case gauge_messages.ExecutionStatus_SKIPPED:
+		r.StatusID = blocked
+		r.Comment = strings.Join(res.ProtoScenario.GetSkipErrors(), "\n")
+	}
+	// TestRail does not accept an elapsed time of 0s
+	if secs := res.ExecTime() / 1000; secs > 0 {
+		r.Elapsed = fmt.Sprintf("%ds", secs)
+	}
+	return r, true
+}
+
+func (c *credentials) push(res []CaseResult, unmapped []string) {
+	if len(res) > 0 {
+		if err := c.addResults(res); err != nil {
+			logger.Errorf(true, "Failed to push results to TestRail run R%d. %s", c.runID, err.Error())
+		} else {
+			logger.Infof(true, "Pushed %d result(s) to TestRail run R%d.", len(res), c.runID)
+		}
+	}
+	if len(unmapped) > 0 {
+		logger.Infof(true, "%d scenario(s) are not mapped to a TestRail case. Tag them with %s<case id>:", len(unmapped), caseTagPrefix)
+		for _, s := range unmapped {
+			logger.Info(true, "  "+s)
+		}
+	}
+}
+
+func (c *credentials) addResults(res []CaseResult) error {
+	b, err := json.Marshal(results{Results: res})
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/index.php?/api/v2/add_results_for_cases/%d", c.url, c.runID)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.user, c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := (&http.Client{Timeout: timeout}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&e)
+		return fmt.Errorf("TestRail responded with %s. %s", resp.Status, e.Error)
+	}
+	return nil
+}
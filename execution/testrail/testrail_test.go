@@ -0,0 +1,104 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package testrail
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) TearDownTest(c *C) {
+	for _, p := range []string{env.TestRailURL, env.TestRailUser, env.TestRailAPIKey, env.TestRailRunID} {
+		os.Unsetenv(p)
+	}
+}
+
+func (s *MySuite) TestCaseID(c *C) {
+	id, ok := CaseID(&gauge.Tags{RawValues: [][]string{{"smoke", "@testrail:C1234"}}})
+
+	c.Assert(ok, Equals, true)
+	c.Assert(id, Equals, 1234)
+}
+
+func (s *MySuite) TestCaseIDWithoutTestRailTag(c *C) {
+	_, ok := CaseID(&gauge.Tags{RawValues: [][]string{{"smoke", "testrail:Cabc"}}})
+
+	c.Assert(ok, Equals, false)
+}
+
+func (s *MySuite) TestLoadCredentialsIsNilWhenNotConfigured(c *C) {
+	cred, err := loadCredentials()
+
+	c.Assert(err, IsNil)
+	c.Assert(cred, IsNil)
+}
+
+func (s *MySuite) TestLoadCredentialsWithInvalidRunID(c *C) {
+	os.Setenv(env.TestRailURL, "https://example.testrail.io")
+	os.Setenv(env.TestRailUser, "user")
+	os.Setenv(env.TestRailAPIKey, "key")
+	os.Setenv(env.TestRailRunID, "abc")
+
+	_, err := loadCredentials()
+
+	c.Assert(err, NotNil)
+}
+
+func (s *MySuite) TestToResultForFailedScenario(c *C) {
+	sce := &gauge.Scenario{Heading: &gauge.Heading{Value: "scenario"}, Tags: &gauge.Tags{RawValues: [][]string{{"testrail:C12"}}}}
+	step := &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_Step, Step: &gauge_messages.ProtoStep{ActualText: "a step", StepExecutionResult: &gauge_messages.ProtoStepExecutionResult{ExecutionResult: &gauge_messages.ProtoExecutionResult{Failed: true, ErrorMessage: "expected 1"}}}}
+	res := result.NewScenarioResult(&gauge_messages.ProtoScenario{ExecutionStatus: gauge_messages.ExecutionStatus_FAILED, ExecutionTime: 2500, ScenarioItems: []*gauge_messages.ProtoItem{step}})
+
+	r, ok := toResult(sce, res)
+
+	c.Assert(ok, Equals, true)
+	c.Assert(r, DeepEquals, CaseResult{CaseID: 12, StatusID: failed, Comment: "Failed Step: a step\nexpected 1", Elapsed: "2s"})
+}
+
+func (s *MySuite) TestAddResults(c *C) {
+	var got results
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, key, _ := r.BasicAuth()
+		c.Check(user, Equals, "user")
+		c.Check(key, Equals, "key")
+		c.Check(r.URL.RawQuery, Equals, "/api/v2/add_results_for_cases/7")
+		json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer server.Close()
+	cred := &credentials{url: server.URL, user: "user", apiKey: "key", runID: 7}
+
+	err := cred.addResults([]CaseResult{{CaseID: 1, StatusID: passed}})
+
+	c.Assert(err, IsNil)
+	c.Assert(got.Results, DeepEquals, []CaseResult{{CaseID: 1, StatusID: passed}})
+}
@@ -0,0 +1,37 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"fmt"
+	"time"
+)
+
+// Timeout is the time after which the execution of the suite is stopped. Zero means the execution never times out.
+var Timeout time.Duration
+
+// startTimeout stops the execution once the timeout expires. It returns a function to cancel the timeout.
+func startTimeout(d time.Duration) func() {
+	if d <= 0 {
+		return func() {}
+	}
+	timer := time.AfterFunc(d, func() {
+		stopExecution(fmt.Sprintf("Execution timed out after %s", d), TimedOut)
+	})
+	return func() { timer.Stop() }
+}
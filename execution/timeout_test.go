@@ -0,0 +1,60 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestTimeoutStopsExecution(c *C) {
+	defer resetAbort()
+	defer resetStop()
+
+	cancel := startTimeout(10 * time.Millisecond)
+	defer cancel()
+
+	c.Assert(waitForStop(), Equals, true)
+	reason, code := stopReason()
+	c.Assert(reason, Equals, "Execution timed out after 10ms")
+	c.Assert(code, Equals, TimedOut)
+	c.Assert(abortReason(), Equals, reason)
+}
+
+func (s *MySuite) TestCancelledTimeoutDoesNotStopExecution(c *C) {
+	defer resetAbort()
+	defer resetStop()
+
+	startTimeout(10 * time.Millisecond)()
+	time.Sleep(50 * time.Millisecond)
+
+	reason, _ := stopReason()
+	c.Assert(reason, Equals, "")
+	c.Assert(abortReason(), Equals, "")
+}
+
+func waitForStop() bool {
+	for i := 0; i < 500; i++ {
+		if reason, _ := stopReason(); reason != "" {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
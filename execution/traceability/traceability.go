@@ -0,0 +1,296 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package traceability maps the requirements named by the tags of scenarios, like REQ-42, to the scenarios covering
+// them and their results, and writes this matrix as JSON, CSV or HTML to the reports directory.
+package traceability
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/util"
+)
+
+const (
+	// JSON writes the matrix as an array of requirements to traceability.json
+	JSON = "json"
+	// CSV writes a row for every scenario of every requirement to traceability.csv
+	CSV = "csv"
+	// HTML writes the matrix as a table to traceability.html
+	HTML = "html"
+
+	reportDir = "traceability"
+
+	passed  = "passed"
+	failed  = "failed"
+	skipped = "skipped"
+)
+
+var writers = map[string]func([]*Requirement) ([]byte, error){
+	JSON: toJSON,
+	CSV:  toCSV,
+	HTML: toHTML,
+}
+
+// Scenario is the result of a scenario covering a requirement.
+type Scenario struct {
+	Spec     string `json:"spec"`
+	Name     string `json:"scenario"`
+	Status   string `json:"status"`
+	Duration int64  `json:"durationMs"`
+}
+
+// Requirement holds the scenarios tagged with a requirement, or whose spec is. The requirement has failed if any of
+// them failed, and has passed if the others passed. It is skipped if all of them were skipped.
+type Requirement struct {
+	ID        string      `json:"requirement"`
+	Status    string      `json:"status"`
+	Scenarios []*Scenario `json:"scenarios"`
+}
+
+func (r *Requirement) add(s *Scenario) {
+	r.Scenarios = append(r.Scenarios, s)
+	switch {
+	case s.Status == failed || r.Status == failed:
+		r.Status = failed
+	case s.Status == passed || r.Status == passed:
+		r.Status = passed
+	default:
+		r.Status = skipped
+	}
+}
+
+// Pattern gives the expression matching the requirement tags, from the requirement_tag_pattern property.
+// The tag has to match it as a whole.
+func Pattern() (*regexp.Regexp, error) {
+	p := strings.TrimSpace(os.Getenv(env.RequirementTagPattern))
+	if p == "" {
+		return nil, fmt.Errorf("%s is not set", env.RequirementTagPattern)
+	}
+	re, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", p))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s '%s'. %s", env.RequirementTagPattern, p, err.Error())
+	}
+	return re, nil
+}
+
+// Matrix gives the requirements named by the tags of the scenarios in the result, sorted by their IDs, with the
+// scenarios in the order they were executed.
+func Matrix(res *result.SuiteResult, pattern *regexp.Regexp) []*Requirement {
+	reqs := make(map[string]*Requirement)
+	res.EachSpecResult(func(specRes *result.SpecResult) {
+		spec := specRes.ProtoSpec
+		fileName := filepath.ToSlash(util.RelPathToProjectRoot(spec.GetFileName()))
+		for _, item := range spec.GetItems() {
+			sce, name := item.GetScenario(), item.GetScenario().GetScenarioHeading()
+			if item.GetItemType() == gauge_messages.ProtoItem_TableDrivenScenario {
+				tds := item.GetTableDrivenScenario()
+				sce, name = tds.GetScenario(), tds.GetScenario().GetScenarioHeading()
+				if tds.GetIsScenarioTableDriven() {
+					name = fmt.Sprintf("%s [row %d]", name, tds.GetScenarioTableRowIndex()+1)
+				}
+			}
+			if sce == nil {
+				continue
+			}
+			s := &Scenario{Spec: fileName, Name: name, Status: status(sce.GetExecutionStatus()), Duration: sce.GetExecutionTime()}
+			for _, id := range requirements(pattern, spec.GetTags(), sce.GetTags()) {
+				if reqs[id] == nil {
+					reqs[id] = &Requirement{ID: id}
+				}
+				reqs[id].add(s)
+			}
+		}
+	})
+	matrix := make([]*Requirement, 0, len(reqs))
+	for _, r := range reqs {
+		matrix = append(matrix, r)
+	}
+	sort.Slice(matrix, func(i, j int) bool { return matrix[i].ID < matrix[j].ID })
+	return matrix
+}
+
+// requirements gives the distinct requirements named by the tags of a scenario and its spec.
+func requirements(pattern *regexp.Regexp, specTags, scenarioTags []string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, t := range append(append([]string{}, specTags...), scenarioTags...) {
+		t = strings.TrimSpace(t)
+		if seen[t] || !pattern.MatchString(t) {
+			continue
+		}
+		seen[t] = true
+		ids = append(ids, t)
+	}
+	return ids
+}
+
+func status(s gauge_messages.ExecutionStatus) string {
+	switch s {
+	case gauge_messages.ExecutionStatus_FAILED:
+		return failed
+	case gauge_messages.ExecutionStatus_PASSED:
+		return passed
+	}
+	return skipped
+}
+
+func toJSON(reqs []*Requirement) ([]byte, error) {
+	return json.MarshalIndent(reqs, "", "  ")
+}
+
+func toCSV(reqs []*Requirement) ([]byte, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	w.Write([]string{"requirement", "requirementStatus", "spec", "scenario", "status", "durationMs"})
+	for _, r := range reqs {
+		for _, s := range r.Scenarios {
+			w.Write([]string{r.ID, r.Status, s.Spec, s.Name, s.Status, strconv.FormatInt(s.Duration, 10)})
+		}
+	}
+	w.Flush()
+	return b.Bytes(), w.Error()
+}
+
+var page = template.Must(template.New("traceability").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Traceability matrix</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+.passed { color: #2e7d32; }
+.failed { color: #c62828; }
+.skipped { color: #757575; }
+</style>
+</head>
+<body>
+<h1>Traceability matrix</h1>
+<table>
+<tr><th>Requirement</th><th>Status</th><th>Spec</th><th>Scenario</th><th>Scenario status</th><th>Time (ms)</th></tr>
+{{range .}}{{$r := .}}{{range $i, $s := .Scenarios}}<tr>
+{{if eq $i 0}}<td rowspan="{{len $r.Scenarios}}">{{$r.ID}}</td><td rowspan="{{len $r.Scenarios}}" class="{{$r.Status}}">{{$r.Status}}</td>{{end}}<td>{{$s.Spec}}</td><td>{{$s.Name}}</td><td class="{{$s.Status}}">{{$s.Status}}</td><td>{{$s.Duration}}</td>
+</tr>
+{{end}}{{end}}</table>
+</body>
+</html>
+`))
+
+func toHTML(reqs []*Requirement) ([]byte, error) {
+	var b bytes.Buffer
+	err := page.Execute(&b, reqs)
+	return b.Bytes(), err
+}
+
+// Formats gives the formats of the traceability matrix set in the traceability_report property. Unknown formats are
+// left out with a warning.
+func Formats() []string {
+	var formats []string
+	for _, f := range strings.Split(os.Getenv(env.TraceabilityReport), ",") {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if f == "" {
+			continue
+		}
+		if _, ok := writers[f]; !ok {
+			logger.Warningf(true, "Invalid traceability report format '%s'. Possible formats are %s, %s and %s", f, JSON, CSV, HTML)
+			continue
+		}
+		formats = append(formats, f)
+	}
+	return formats
+}
+
+// ListenSuiteEnd writes the traceability matrix in each of the formats set in the traceability_report property, to
+// <reports dir>/traceability/traceability.<format>.
+func ListenSuiteEnd(wg *sync.WaitGroup) {
+	formats := Formats()
+	if len(formats) == 0 {
+		return
+	}
+	pattern, err := Pattern()
+	if err != nil {
+		logger.Warningf(true, "Traceability matrix will not be written. %s", err.Error())
+		return
+	}
+	ch := make(chan event.ExecutionEvent, 0)
+	event.Register(ch, event.SuiteEnd)
+	wg.Add(1)
+
+	go func() {
+		for {
+			e := <-ch
+			if e.Topic == event.SuiteEnd {
+				matrix := Matrix(e.Result.(*result.SuiteResult), pattern)
+				for _, f := range formats {
+					write(matrix, f)
+				}
+				wg.Done()
+			}
+		}
+	}()
+}
+
+func write(matrix []*Requirement, format string) {
+	b, err := writers[format](matrix)
+	if err != nil {
+		logger.Errorf(true, "Failed to generate %s traceability matrix. %s", format, err.Error())
+		return
+	}
+	dir := filepath.Join(reportsDir(), reportDir)
+	if err := os.MkdirAll(dir, common.NewDirectoryPermissions); err != nil {
+		logger.Errorf(true, "Failed to create directory %s. %s", dir, err.Error())
+		return
+	}
+	file := filepath.Join(dir, fmt.Sprintf("traceability.%s", format))
+	if err := ioutil.WriteFile(file, b, common.NewFilePermissions); err != nil {
+		logger.Errorf(true, "Failed to write traceability matrix to %s. %s", file, err.Error())
+		return
+	}
+	logger.Infof(true, "Successfully generated %s traceability matrix in => %s", format, file)
+}
+
+func reportsDir() string {
+	dir := os.Getenv(env.GaugeReportsDir)
+	if dir == "" {
+		dir = "reports"
+	}
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(config.ProjectRoot, dir)
+}
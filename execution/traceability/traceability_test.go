@@ -0,0 +1,121 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package traceability
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge_messages"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) SetUpTest(c *C) {
+	dir, err := ioutil.TempDir("", "traceability")
+	c.Assert(err, IsNil)
+	config.ProjectRoot = dir
+}
+
+func (s *MySuite) TearDownTest(c *C) {
+	os.RemoveAll(config.ProjectRoot)
+	os.Unsetenv(env.GaugeReportsDir)
+	os.Unsetenv(env.TraceabilityReport)
+	os.Unsetenv(env.RequirementTagPattern)
+}
+
+func scenario(name string, status gauge_messages.ExecutionStatus, tags ...string) *gauge_messages.ProtoItem {
+	return &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_Scenario, Scenario: &gauge_messages.ProtoScenario{ScenarioHeading: name, ExecutionStatus: status, ExecutionTime: 10, Tags: tags}}
+}
+
+func suiteResult() *result.SuiteResult {
+	payments := &gauge_messages.ProtoSpec{FileName: filepath.Join(config.ProjectRoot, "specs", "payments.spec"), Tags: []string{"REQ-1"}, Items: []*gauge_messages.ProtoItem{
+		scenario("Pay", gauge_messages.ExecutionStatus_PASSED, "smoke", "REQ-2"),
+		scenario("Refund", gauge_messages.ExecutionStatus_FAILED, "REQ-1"),
+		{ItemType: gauge_messages.ProtoItem_TableDrivenScenario, TableDrivenScenario: &gauge_messages.ProtoTableDrivenScenario{IsScenarioTableDriven: true, ScenarioTableRowIndex: 0,
+			Scenario: &gauge_messages.ProtoScenario{ScenarioHeading: "Limits", ExecutionStatus: gauge_messages.ExecutionStatus_SKIPPED, Tags: []string{"REQ-3", "REQ-30x"}}}},
+	}}
+	return &result.SuiteResult{SpecResults: []*result.SpecResult{{ProtoSpec: payments}}}
+}
+
+func (s *MySuite) TestMatrix(c *C) {
+	m := Matrix(suiteResult(), regexp.MustCompile(`^(?:REQ-\d+)$`))
+
+	c.Assert(m, HasLen, 3)
+	c.Assert(m[0].ID, Equals, "REQ-1")
+	c.Assert(m[0].Status, Equals, failed)
+	c.Assert(m[0].Scenarios, HasLen, 3)
+	c.Assert(*m[0].Scenarios[2], DeepEquals, Scenario{Spec: "specs/payments.spec", Name: "Limits [row 1]", Status: skipped})
+	c.Assert(m[1].ID, Equals, "REQ-2")
+	c.Assert(m[1].Status, Equals, passed)
+	c.Assert(m[2].ID, Equals, "REQ-3")
+	c.Assert(m[2].Status, Equals, skipped)
+}
+
+func (s *MySuite) TestPattern(c *C) {
+	os.Setenv(env.RequirementTagPattern, "JIRA-[0-9]+|REQ-[0-9]+")
+	p, err := Pattern()
+	c.Assert(err, IsNil)
+	c.Assert(p.MatchString("JIRA-12"), Equals, true)
+	c.Assert(p.MatchString("REQ-12a"), Equals, false)
+
+	os.Setenv(env.RequirementTagPattern, "REQ-[")
+	_, err = Pattern()
+	c.Assert(err, ErrorMatches, "Invalid requirement_tag_pattern 'REQ-\\['.*")
+}
+
+func (s *MySuite) TestToCSV(c *C) {
+	b, err := toCSV(Matrix(suiteResult(), regexp.MustCompile(`^(?:REQ-2)$`)))
+
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, "requirement,requirementStatus,spec,scenario,status,durationMs\nREQ-2,passed,specs/payments.spec,Pay,passed,10\n")
+}
+
+func (s *MySuite) TestToHTMLEscapesNames(c *C) {
+	b, err := toHTML([]*Requirement{{ID: "REQ-1", Status: passed, Scenarios: []*Scenario{{Spec: "a.spec", Name: "<script>", Status: passed}}}})
+
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(string(b), `<td rowspan="1">REQ-1</td>`), Equals, true)
+	c.Assert(strings.Contains(string(b), "&lt;script&gt;"), Equals, true)
+}
+
+func (s *MySuite) TestWrite(c *C) {
+	write(Matrix(suiteResult(), regexp.MustCompile(`^(?:REQ-\d+)$`)), JSON)
+
+	b, err := ioutil.ReadFile(filepath.Join(config.ProjectRoot, "reports", "traceability", "traceability.json"))
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `(?s)\[\s*\{\s*"requirement": "REQ-1",\s*"status": "failed",.*`)
+}
+
+func (s *MySuite) TestFormats(c *C) {
+	os.Setenv(env.TraceabilityReport, "csv, HTML,pdf")
+
+	c.Assert(Formats(), DeepEquals, []string{CSV, HTML})
+}
@@ -0,0 +1,441 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package webhook posts JSON payloads to the configured webhooks on suite start, suite end and on every spec failure.
+// Spec failures are posted only to the webhooks of the owners of the failed spec, when owner webhooks are configured.
+// Failed requests are retried with exponential backoff, and the payloads which could not be delivered are appended
+// to a dead letter file, from which they can be recovered.
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/owners"
+	"github.com/getgauge/gauge/util"
+)
+
+const (
+	// SuiteStart is posted when the execution of the suite starts
+	SuiteStart = "suite_start"
+	// SuiteEnd is posted when the execution of the suite ends, with the summary of the execution
+	SuiteEnd = "suite_end"
+	// SpecFailure is posted when a spec fails, with the failed scenarios
+	SpecFailure = "spec_failure"
+	// SignatureHeader holds the HMAC-SHA256 of the payload, keyed by the webhook secret, as sha256=<hex digest>
+	SignatureHeader = "X-Gauge-Signature-256"
+
+	queueSize          = 100
+	maxRetryDelay      = time.Minute
+	deadLetterFileName = "webhook-dead-letters.ndjson"
+)
+
+var errQueueFull = fmt.Errorf("The webhook queue is full")
+
+type settings struct {
+	urls       []string
+	ownerURLs  map[string][]string
+	owners     *owners.Owners
+	headers    map[string]string
+	events     map[string]bool
+	timeout    time.Duration
+	retries    int
+	retryDelay time.Duration
+	secret     string
+	deadLetter string
+	// deadLetterMu serializes the writes to the dead letter file by the sender and by the events which overflow the queue
+	deadLetterMu sync.Mutex
+}
+
+// Payload is the JSON posted to the webhooks
+type Payload struct {
+	Event       string   `json:"event"`
+	Project     string   `json:"project"`
+	Environment string   `json:"environment"`
+	Timestamp   string   `json:"timestamp"`
+	Spec        *Spec    `json:"spec,omitempty"`
+	Summary     *Summary `json:"summary,omitempty"`
+	// Metadata holds the metadata of the suite, like the build number. It is only sent with the end of the suite.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Spec holds the details of a failed spec
+type Spec struct {
+	Heading         string   `json:"heading"`
+	FileName        string   `json:"fileName"`
+	FailedScenarios []string `json:"failedScenarios"`
+	// Owners holds the owners of the failed scenarios, or of the spec if it failed outside of its scenarios
+	Owners []string `json:"owners,omitempty"`
+}
+
+type message struct {
+	payload *Payload
+	urls    []string
+}
+
+// DeadLetter is a line of the dead letter file, holding a payload which could not be delivered to a webhook.
+type DeadLetter struct {
+	Time    string          `json:"time"`
+	URL     string          `json:"url"`
+	Event   string          `json:"event"`
+	Error   string          `json:"error"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// Summary holds the summary of the suite execution
+type Summary struct {
+	Success           bool  `json:"success"`
+	ExecutionTime     int64 `json:"executionTime"`
+	SpecsExecuted     int   `json:"specsExecuted"`
+	SpecsFailed       int   `json:"specsFailed"`
+	SpecsSkipped      int   `json:"specsSkipped"`
+	ScenariosExecuted int   `json:"scenariosExecuted"`
+	ScenariosFailed   int   `json:"scenariosFailed"`
+	ScenariosSkipped  int   `json:"scenariosSkipped"`
+}
+
+func loadSettings() (*settings, error) {
+	c := &settings{urls: splitList(os.Getenv(env.WebhookURLs)), ownerURLs: make(map[string][]string), headers: make(map[string]string), events: make(map[string]bool)}
+	for _, ou := range splitList(os.Getenv(env.WebhookOwnerURLs)) {
+		kv := strings.SplitN(ou, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" || strings.TrimSpace(kv[1]) == "" {
+			return nil, fmt.Errorf("Invalid webhook owner url '%s'. Owner urls should be given as owner=url", ou)
+		}
+		owner := strings.TrimSpace(kv[0])
+		c.ownerURLs[owner] = append(c.ownerURLs[owner], strings.TrimSpace(kv[1]))
+	}
+	if len(c.ownerURLs) > 0 {
+		o, err := owners.Load()
+		if err != nil {
+			return nil, err
+		}
+		c.owners = o
+	}
+	for _, h := range splitList(os.Getenv(env.WebhookHeaders)) {
+		nv := strings.SplitN(h, ":", 2)
+		if len(nv) != 2 {
+			return nil, fmt.Errorf("Invalid webhook header '%s'. Headers should be given as name:value", h)
+		}
+		c.headers[strings.TrimSpace(nv[0])] = strings.TrimSpace(nv[1])
+	}
+	for _, e := range splitList(os.Getenv(env.WebhookEvents)) {
+		if e != SuiteStart && e != SuiteEnd && e != SpecFailure {
+			return nil, fmt.Errorf("Invalid webhook event '%s'. Possible events are %s, %s and %s", e, SuiteStart, SuiteEnd, SpecFailure)
+		}
+		c.events[e] = true
+	}
+	timeout, err := strconv.Atoi(strings.TrimSpace(os.Getenv(env.WebhookTimeout)))
+	if err != nil || timeout <= 0 {
+		return nil, fmt.Errorf("Invalid value for %s. It should be a positive number of seconds", env.WebhookTimeout)
+	}
+	c.timeout = time.Duration(timeout) * time.Second
+	c.retries, err = strconv.Atoi(strings.TrimSpace(os.Getenv(env.WebhookRetries)))
+	if err != nil || c.retries < 0 {
+		return nil, fmt.Errorf("Invalid value for %s. It should be a non-negative number", env.WebhookRetries)
+	}
+	delay, err := strconv.Atoi(strings.TrimSpace(os.Getenv(env.WebhookRetryDelay)))
+	if err != nil || delay < 0 {
+		return nil, fmt.Errorf("Invalid value for %s. It should be a non-negative number of milliseconds", env.WebhookRetryDelay)
+	}
+	c.retryDelay = time.Duration(delay) * time.Millisecond
+	c.secret = os.Getenv(env.WebhookSecret)
+	c.deadLetter = strings.TrimSpace(os.Getenv(env.WebhookDeadLetterFile))
+	if c.deadLetter == "" {
+		c.deadLetter = logger.LogFilePath(deadLetterFileName)
+	} else if !filepath.IsAbs(c.deadLetter) {
+		c.deadLetter = filepath.Join(config.ProjectRoot, c.deadLetter)
+	}
+	return c, nil
+}
+
+func splitList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func (c *settings) wants(e string) bool {
+	return len(c.events) == 0 || c.events[e]
+}
+
+// specFailureURLs gives the webhooks of the owners, or the ones given by webhook_urls if none of the owners has a webhook.
+func (c *settings) specFailureURLs(owners []string) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	for _, o := range owners {
+		for _, url := range c.ownerURLs[o] {
+			if !seen[url] {
+				seen[url] = true
+				urls = append(urls, url)
+			}
+		}
+	}
+	if len(urls) == 0 {
+		return c.urls
+	}
+	return urls
+}
+
+// ownersOf gives the owners of the failed scenarios of the spec, or of the spec if none of its scenarios failed.
+func (c *settings) ownersOf(spec *gauge.Specification, failed []*gauge.Scenario) []string {
+	specTags := gauge.MetaTags(spec.Meta)
+	if len(failed) == 0 {
+		return c.owners.Of(spec.FileName, specTags, nil)
+	}
+	var all []string
+	seen := make(map[string]bool)
+	for _, sce := range failed {
+		for _, o := range c.owners.Of(spec.FileName, specTags, gauge.MetaTags(sce.Meta)) {
+			if !seen[o] {
+				seen[o] = true
+				all = append(all, o)
+			}
+		}
+	}
+	return all
+}
+
+// ListenSuiteEvents posts the suite lifecycle events to the webhooks given by the webhook_urls property, if any,
+// and the spec failures to the webhooks of their owners given by the webhook_owner_urls property.
+// The requests are sent in the order of the events, without blocking the execution, and are all completed
+// before the end of the suite is signalled.
+func ListenSuiteEvents(wg *sync.WaitGroup) {
+	if len(splitList(os.Getenv(env.WebhookURLs))) == 0 && len(splitList(os.Getenv(env.WebhookOwnerURLs))) == 0 {
+		return
+	}
+	c, err := loadSettings()
+	if err != nil {
+		logger.Errorf(true, "Webhooks are disabled. %s", err.Error())
+		return
+	}
+	ch := make(chan event.ExecutionEvent, 0)
+	event.Register(ch, event.SuiteStart, event.ScenarioEnd, event.SpecEnd, event.SuiteEnd)
+	wg.Add(1)
+
+	queue := make(chan *message, queueSize)
+	sent := make(chan bool)
+	go func() {
+		client := &http.Client{Timeout: c.timeout}
+		for m := range queue {
+			c.post(client, m.payload, m.urls)
+		}
+		sent <- true
+	}()
+
+	go func() {
+		failedScenarios := make(map[int][]*gauge.Scenario)
+		for {
+			e := <-ch
+			switch e.Topic {
+			case event.SuiteStart:
+				if c.wants(SuiteStart) {
+					queue <- &message{payload: newPayload(SuiteStart), urls: c.urls}
+				}
+			case event.ScenarioEnd:
+				if e.Result.GetFailed() {
+					failedScenarios[e.Stream] = append(failedScenarios[e.Stream], e.Item.(*gauge.Scenario))
+				}
+			case event.SpecEnd:
+				if e.Result.GetFailed() && c.wants(SpecFailure) {
+					spec := e.Item.(*gauge.Specification)
+					p := newPayload(SpecFailure)
+					var headings []string
+					for _, sce := range failedScenarios[e.Stream] {
+						headings = append(headings, sce.Heading.Value)
+					}
+					p.Spec = &Spec{Heading: spec.Heading.Value, FileName: filepath.ToSlash(util.RelPathToProjectRoot(spec.FileName)), FailedScenarios: headings}
+					urls := c.urls
+					if len(c.ownerURLs) > 0 {
+						p.Spec.Owners = c.ownersOf(spec, failedScenarios[e.Stream])
+						urls = c.specFailureURLs(p.Spec.Owners)
+					}
+					c.enqueue(queue, &message{payload: p, urls: urls})
+				}
+				delete(failedScenarios, e.Stream)
+			case event.SuiteEnd:
+				if c.wants(SuiteEnd) {
+					p := newPayload(SuiteEnd)
+					p.Summary = summary(e.Result.(*result.SuiteResult))
+					p.Metadata = e.Result.(*result.SuiteResult).Metadata
+					c.enqueue(queue, &message{payload: p, urls: c.urls})
+				}
+				close(queue)
+				<-sent
+				wg.Done()
+			}
+		}
+	}()
+}
+
+// enqueue queues the message to be posted. It never blocks the execution: if the webhooks are too slow to keep up
+// and the queue is full, the payload is written to the dead letter file instead.
+func (c *settings) enqueue(queue chan<- *message, m *message) {
+	select {
+	case queue <- m:
+	default:
+		b, err := json.Marshal(m.payload)
+		if err != nil {
+			logger.Errorf(true, "Failed to marshal webhook payload. %s", err.Error())
+			return
+		}
+		for _, url := range m.urls {
+			logger.Errorf(true, "Failed to post %s event to webhook %s. %s", m.payload.Event, url, errQueueFull.Error())
+			c.writeDeadLetter(url, m.payload.Event, b, errQueueFull)
+		}
+	}
+}
+
+func newPayload(e string) *Payload {
+	return &Payload{
+		Event:       e,
+		Project:     filepath.Base(config.ProjectRoot),
+		Environment: env.CurrentEnvironments(),
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
+}
+
+func summary(res *result.SuiteResult) *Summary {
+	s := &Summary{
+		Success:       !res.IsFailed,
+		ExecutionTime: res.ExecutionTime,
+		SpecsFailed:   res.SpecsFailedCount,
+		SpecsSkipped:  res.SpecsSkippedCount,
+	}
+	for _, specRes := range res.SpecResults {
+		if !specRes.Skipped {
+			s.SpecsExecuted++
+		}
+		s.ScenariosExecuted += specRes.ScenarioCount - specRes.ScenarioSkippedCount
+		s.ScenariosFailed += specRes.ScenarioFailedCount
+		s.ScenariosSkipped += specRes.ScenarioSkippedCount
+	}
+	return s
+}
+
+func (c *settings) post(client *http.Client, p *Payload, urls []string) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		logger.Errorf(true, "Failed to marshal webhook payload. %s", err.Error())
+		return
+	}
+	for _, url := range urls {
+		if err := c.postWithRetries(client, url, b); err != nil {
+			logger.Errorf(true, "Failed to post %s event to webhook %s. %s", p.Event, url, err.Error())
+			c.writeDeadLetter(url, p.Event, b, err)
+		}
+	}
+}
+
+// postWithRetries posts the body to the url, retrying after a delay which doubles with every attempt, up to a minute.
+func (c *settings) postWithRetries(client *http.Client, url string, body []byte) error {
+	var err error
+	delay := c.retryDelay
+	for attempt := 0; attempt <= c.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(delay)
+			if delay *= 2; delay > maxRetryDelay {
+				delay = maxRetryDelay
+			}
+		}
+		var retry bool
+		if retry, err = c.send(client, url, body); err == nil || !retry {
+			return err
+		}
+		logger.Debugf(true, "Attempt %d to post to webhook %s failed. %s", attempt+1, url, err.Error())
+	}
+	return err
+}
+
+// send posts the body to the url. It returns whether the request can be retried, if it fails.
+func (c *settings) send(client *http.Client, url string, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.secret != "" {
+		req.Header.Set(SignatureHeader, sign(c.secret, body))
+	}
+	for name, value := range c.headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("Webhook responded with %s", resp.Status)
+}
+
+// sign gives the signature of the body, which the receivers of the webhooks can compute with the shared secret
+// to verify that the payload was sent by gauge and not altered.
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// writeDeadLetter appends the payload which could not be delivered to the url to the dead letter file.
+func (c *settings) writeDeadLetter(url, event string, payload []byte, err error) {
+	if c.deadLetter == "" {
+		return
+	}
+	c.deadLetterMu.Lock()
+	defer c.deadLetterMu.Unlock()
+	b, e := json.Marshal(&DeadLetter{Time: time.Now().Format(time.RFC3339), URL: url, Event: event, Error: err.Error(), Payload: payload})
+	if e != nil {
+		logger.Errorf(true, "Failed to write the %s event to the dead letter file. %s", event, e.Error())
+		return
+	}
+	if e := os.MkdirAll(filepath.Dir(c.deadLetter), common.NewDirectoryPermissions); e != nil {
+		logger.Errorf(true, "Failed to write the %s event to the dead letter file. %s", event, e.Error())
+		return
+	}
+	f, e := os.OpenFile(c.deadLetter, os.O_CREATE|os.O_APPEND|os.O_WRONLY, common.NewFilePermissions)
+	if e != nil {
+		logger.Errorf(true, "Failed to write the %s event to the dead letter file. %s", event, e.Error())
+		return
+	}
+	defer f.Close()
+	f.Write(append(b, '\n'))
+}
@@ -0,0 +1,242 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) SetUpTest(c *C) {
+	os.Setenv(env.WebhookRetryDelay, "1")
+	os.Setenv(env.WebhookTimeout, "10")
+	os.Setenv(env.WebhookRetries, "3")
+}
+
+func (s *MySuite) TearDownTest(c *C) {
+	for _, p := range []string{env.WebhookURLs, env.WebhookHeaders, env.WebhookEvents, env.WebhookOwnerURLs, env.WebhookTimeout, env.WebhookRetries, env.WebhookRetryDelay, env.WebhookSecret, env.WebhookDeadLetterFile} {
+		os.Unsetenv(p)
+	}
+}
+
+func (s *MySuite) TestLoadSettings(c *C) {
+	os.Setenv(env.WebhookURLs, "http://a.com/hook, http://b.com/hook")
+	os.Setenv(env.WebhookHeaders, "Authorization: Bearer token:1,X-Team:qa")
+	os.Setenv(env.WebhookEvents, "suite_end")
+
+	settings, err := loadSettings()
+
+	c.Assert(err, IsNil)
+	c.Assert(settings.urls, DeepEquals, []string{"http://a.com/hook", "http://b.com/hook"})
+	c.Assert(settings.headers, DeepEquals, map[string]string{"Authorization": "Bearer token:1", "X-Team": "qa"})
+	c.Assert(settings.wants(SuiteEnd), Equals, true)
+	c.Assert(settings.wants(SuiteStart), Equals, false)
+	c.Assert(settings.timeout, Equals, 10*time.Second)
+	c.Assert(settings.retries, Equals, 3)
+	c.Assert(settings.retryDelay, Equals, time.Millisecond)
+}
+
+func (s *MySuite) TestLoadSettingsWithDeadLetterFileRelativeToProject(c *C) {
+	config.ProjectRoot = filepath.FromSlash("/project")
+	defer func() { config.ProjectRoot = "" }()
+	os.Setenv(env.WebhookDeadLetterFile, "out/dead.ndjson")
+
+	settings, err := loadSettings()
+
+	c.Assert(err, IsNil)
+	c.Assert(settings.deadLetter, Equals, filepath.FromSlash("/project/out/dead.ndjson"))
+}
+
+func (s *MySuite) TestPostSignsPayload(c *C) {
+	var signature string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(SignatureHeader)
+		body, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer server.Close()
+	settings := &settings{urls: []string{server.URL}, secret: "s3cr3t"}
+
+	settings.post(&http.Client{}, newPayload(SuiteStart), settings.urls)
+
+	mac := hmac.New(sha256.New, []byte("s3cr3t"))
+	mac.Write(body)
+	c.Assert(signature, Equals, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+}
+
+func (s *MySuite) TestPostBacksOffExponentially(c *C) {
+	var attempts []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts = append(attempts, time.Now())
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+	settings := &settings{urls: []string{server.URL}, retries: 2, retryDelay: 20 * time.Millisecond}
+
+	settings.post(&http.Client{}, newPayload(SuiteStart), settings.urls)
+
+	c.Assert(len(attempts), Equals, 3)
+	c.Assert(attempts[2].Sub(attempts[1]) >= 40*time.Millisecond, Equals, true)
+}
+
+func (s *MySuite) TestUndeliveredPayloadIsWrittenToDeadLetterFile(c *C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+	deadLetter := filepath.Join(c.MkDir(), "logs", "dead.ndjson")
+	settings := &settings{urls: []string{server.URL}, deadLetter: deadLetter}
+
+	settings.post(&http.Client{}, newPayload(SuiteStart), settings.urls)
+	settings.post(&http.Client{}, newPayload(SuiteEnd), settings.urls)
+
+	b, err := ioutil.ReadFile(deadLetter)
+	c.Assert(err, IsNil)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	c.Assert(len(lines), Equals, 2)
+	var l DeadLetter
+	c.Assert(json.Unmarshal([]byte(lines[1]), &l), IsNil)
+	c.Assert(l.URL, Equals, server.URL)
+	c.Assert(l.Event, Equals, SuiteEnd)
+	c.Assert(l.Error, Equals, "Webhook responded with 400 Bad Request")
+	var p Payload
+	c.Assert(json.Unmarshal(l.Payload, &p), IsNil)
+	c.Assert(p.Event, Equals, SuiteEnd)
+}
+
+func (s *MySuite) TestPayloadIsWrittenToDeadLetterFileWhenQueueIsFull(c *C) {
+	deadLetter := filepath.Join(c.MkDir(), "dead.ndjson")
+	settings := &settings{urls: []string{"http://hook"}, deadLetter: deadLetter}
+	queue := make(chan *message, 1)
+
+	settings.enqueue(queue, &message{payload: newPayload(SuiteStart), urls: settings.urls})
+	settings.enqueue(queue, &message{payload: newPayload(SuiteEnd), urls: settings.urls})
+
+	c.Assert(len(queue), Equals, 1)
+	c.Assert((<-queue).payload.Event, Equals, SuiteStart)
+	b, err := ioutil.ReadFile(deadLetter)
+	c.Assert(err, IsNil)
+	var l DeadLetter
+	c.Assert(json.Unmarshal(b, &l), IsNil)
+	c.Assert(l.Event, Equals, SuiteEnd)
+	c.Assert(l.URL, Equals, "http://hook")
+	c.Assert(l.Error, Equals, "The webhook queue is full")
+}
+
+func (s *MySuite) TestLoadSettingsWithInvalidEvent(c *C) {
+	os.Setenv(env.WebhookEvents, "scenario_end")
+
+	_, err := loadSettings()
+
+	c.Assert(err, ErrorMatches, "Invalid webhook event 'scenario_end'.*")
+}
+
+func (s *MySuite) TestLoadSettingsWithOwnerURLs(c *C) {
+	os.Setenv(env.WebhookOwnerURLs, "team-a=http://a.com/hook, team-b=http://b.com/hook, team-a=http://c.com/hook")
+
+	settings, err := loadSettings()
+
+	c.Assert(err, IsNil)
+	c.Assert(settings.ownerURLs, DeepEquals, map[string][]string{"team-a": {"http://a.com/hook", "http://c.com/hook"}, "team-b": {"http://b.com/hook"}})
+}
+
+func (s *MySuite) TestLoadSettingsWithInvalidOwnerURL(c *C) {
+	os.Setenv(env.WebhookOwnerURLs, "http://a.com/hook")
+
+	_, err := loadSettings()
+
+	c.Assert(err, ErrorMatches, "Invalid webhook owner url 'http://a.com/hook'.*")
+}
+
+func (s *MySuite) TestSpecFailureURLsOfOwners(c *C) {
+	settings := &settings{urls: []string{"http://all.com/hook"}, ownerURLs: map[string][]string{"team-a": {"http://a.com/hook"}, "team-b": {"http://a.com/hook", "http://b.com/hook"}}}
+
+	c.Assert(settings.specFailureURLs([]string{"team-a", "team-b"}), DeepEquals, []string{"http://a.com/hook", "http://b.com/hook"})
+	c.Assert(settings.specFailureURLs([]string{"team-c"}), DeepEquals, []string{"http://all.com/hook"})
+}
+
+func (s *MySuite) TestOwnersOfFailedScenarios(c *C) {
+	settings := &settings{}
+	specMeta, _ := gauge.NewMeta(1, "owner=team-a")
+	sceMeta, _ := gauge.NewMeta(3, "owner=team-b team-c")
+	spec := &gauge.Specification{FileName: "specs/a.spec", Meta: specMeta}
+	failed := []*gauge.Scenario{{Meta: sceMeta}, {}}
+
+	c.Assert(settings.ownersOf(spec, failed), DeepEquals, []string{"team-b", "team-c", "team-a"})
+	c.Assert(settings.ownersOf(spec, nil), DeepEquals, []string{"team-a"})
+}
+
+func (s *MySuite) TestPostRetriesOnServerError(c *C) {
+	var attempts int
+	var got Payload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		c.Check(r.Header.Get("X-Team"), Equals, "qa")
+		json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer server.Close()
+	settings := &settings{urls: []string{server.URL}, headers: map[string]string{"X-Team": "qa"}, retries: 3}
+	p := newPayload(SuiteEnd)
+	p.Summary = summary(&result.SuiteResult{IsFailed: true, SpecsFailedCount: 1, SpecResults: []*result.SpecResult{{ScenarioCount: 2, ScenarioFailedCount: 1}}})
+
+	settings.post(&http.Client{}, p, settings.urls)
+
+	c.Assert(attempts, Equals, 3)
+	c.Assert(got.Event, Equals, SuiteEnd)
+	c.Assert(*got.Summary, DeepEquals, Summary{Success: false, SpecsExecuted: 1, SpecsFailed: 1, ScenariosExecuted: 2, ScenariosFailed: 1})
+}
+
+func (s *MySuite) TestPostDoesNotRetryOnClientError(c *C) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+	settings := &settings{urls: []string{server.URL}, retries: 3}
+
+	settings.post(&http.Client{}, newPayload(SuiteStart), settings.urls)
+
+	c.Assert(attempts, Equals, 1)
+}
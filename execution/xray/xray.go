@@ -0,0 +1,239 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package xray generates an Xray JSON import file for the scenarios tagged with `jira:<test key>`
+// and uploads it to Jira, if configured.
+package xray
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+)
+
+const (
+	testKeyTagPrefix = "jira:"
+	importPath       = "/rest/raven/1.0/import/execution"
+	timeout          = 30 * time.Second
+
+	// test run statuses defined by Xray
+	pass = "PASS"
+	fail = "FAIL"
+	todo = "TODO"
+)
+
+var testKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*-[0-9]+$`)
+
+// Import is the Xray JSON format for importing the results of a test execution
+type Import struct {
+	TestExecutionKey string    `json:"testExecutionKey,omitempty"`
+	Info             Info      `json:"info"`
+	Tests            []TestRun `json:"tests"`
+}
+
+// Info holds the details of the test execution
+type Info struct {
+	Summary          string   `json:"summary"`
+	StartDate        string   `json:"startDate"`
+	FinishDate       string   `json:"finishDate"`
+	TestEnvironments []string `json:"testEnvironments,omitempty"`
+}
+
+// TestRun holds the result of a test. All the scenarios tagged with the same key are reported as one test.
+type TestRun struct {
+	TestKey string `json:"testKey"`
+	Start   string `json:"start"`
+	Finish  string `json:"finish"`
+	Comment string `json:"comment,omitempty"`
+	Status  string `json:"status"`
+}
+
+// TestKey gives the Jira key of the Xray test of a scenario from its `jira:<key>` tag.
+func TestKey(tags *gauge.Tags) (string, bool) {
+	if tags == nil {
+		return "", false
+	}
+	for _, tag := range tags.Values() {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "@")
+		if key := strings.TrimPrefix(tag, testKeyTagPrefix); key != tag && testKeyPattern.MatchString(key) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+type collector struct {
+	start   time.Time
+	started map[int]time.Time
+	tests   []*TestRun
+	byKey   map[string]*TestRun
+}
+
+func newCollector() *collector {
+	return &collector{start: time.Now(), started: make(map[int]time.Time), byKey: make(map[string]*TestRun)}
+}
+
+func (c *collector) add(key string, start, finish time.Time, res *result.ScenarioResult) {
+	status := pass
+	switch res.ProtoScenario.GetExecutionStatus() {
+	case gauge_messages.ExecutionStatus_FAILED:
+		status = fail
+	case gauge_messages.ExecutionStatus_SKIPPED:
+		status = todo
+	}
+	t, ok := c.byKey[key]
+	if !ok {
+		t = &TestRun{TestKey: key, Start: start.Format(time.RFC3339), Status: status}
+		c.byKey[key] = t
+		c.tests = append(c.tests, t)
+	} else if status == fail || t.Status == todo {
+		t.Status = status
+	}
+	t.Finish = finish.Format(time.RFC3339)
+	if msg := res.FailureMessage(); msg != "" {
+		if t.Comment != "" {
+			t.Comment += "\n\n"
+		}
+		t.Comment += msg
+	}
+}
+
+func (c *collector) toImport(finish time.Time) *Import {
+	i := &Import{
+		TestExecutionKey: strings.TrimSpace(os.Getenv(env.XrayTestExecutionKey)),
+		Info: Info{
+			Summary:          fmt.Sprintf("Execution of %s", filepath.Base(config.ProjectRoot)),
+			StartDate:        c.start.Format(time.RFC3339),
+			FinishDate:       finish.Format(time.RFC3339),
+			TestEnvironments: strings.Split(env.CurrentEnvironments(), ","),
+		},
+		Tests: []TestRun{},
+	}
+	for _, t := range c.tests {
+		i.Tests = append(i.Tests, *t)
+	}
+	return i
+}
+
+// ListenScenarioResults collects the results of the scenarios tagged with Jira test keys and, at the end of the suite,
+// writes them to the file given by xray_results_file. The file is uploaded to Jira if xray_url is set.
+func ListenScenarioResults(wg *sync.WaitGroup) {
+	file := strings.TrimSpace(os.Getenv(env.XrayResultsFile))
+	if file == "" {
+		return
+	}
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(config.ProjectRoot, file)
+	}
+	ch := make(chan event.ExecutionEvent, 0)
+	event.Register(ch, event.ScenarioStart, event.ScenarioEnd, event.SuiteEnd)
+	wg.Add(1)
+
+	go func() {
+		c := newCollector()
+		for {
+			e := <-ch
+			switch e.Topic {
+			case event.ScenarioStart:
+				c.started[e.Stream] = time.Now()
+			case event.ScenarioEnd:
+				if key, ok := TestKey(e.Item.(*gauge.Scenario).Tags); ok {
+					c.add(key, c.started[e.Stream], time.Now(), e.Result.(*result.ScenarioResult))
+				}
+			case event.SuiteEnd:
+				export(c.toImport(time.Now()), file)
+				wg.Done()
+			}
+		}
+	}()
+}
+
+func export(i *Import, file string) {
+	b, err := json.MarshalIndent(i, "", "  ")
+	if err != nil {
+		logger.Errorf(true, "Failed to generate Xray import file. %s", err.Error())
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(file), common.NewDirectoryPermissions); err != nil {
+		logger.Errorf(true, "Failed to create directory %s. %s", filepath.Dir(file), err.Error())
+		return
+	}
+	if err := ioutil.WriteFile(file, b, common.NewFilePermissions); err != nil {
+		logger.Errorf(true, "Failed to write Xray import file %s. %s", file, err.Error())
+		return
+	}
+	logger.Debugf(true, "Xray import file written to %s", file)
+	url := strings.TrimRight(strings.TrimSpace(os.Getenv(env.XrayURL)), "/")
+	if url == "" {
+		return
+	}
+	key, err := upload(url, b)
+	if err != nil {
+		logger.Errorf(true, "Failed to upload results to Xray. %s", err.Error())
+		return
+	}
+	logger.Infof(true, "Uploaded results of %d test(s) to Xray test execution %s.", len(i.Tests), key)
+}
+
+// upload imports the execution results into Jira and returns the key of the test execution.
+func upload(url string, body []byte) (string, error) {
+	req, err := http.NewRequest(http.MethodPost, url+importPath, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token := strings.TrimSpace(os.Getenv(env.XrayToken)); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else {
+		req.SetBasicAuth(os.Getenv(env.XrayUser), os.Getenv(env.XrayPassword))
+	}
+	resp, err := (&http.Client{Timeout: timeout}).Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return "", fmt.Errorf("Jira responded with %s. %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	var r struct {
+		TestExecIssue struct {
+			Key string `json:"key"`
+		} `json:"testExecIssue"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return "", fmt.Errorf("Unable to read the response from Jira. %s", err.Error())
+	}
+	return r.TestExecIssue.Key, nil
+}
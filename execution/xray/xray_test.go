@@ -0,0 +1,110 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package xray
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) SetUpTest(c *C) {
+	dir, err := ioutil.TempDir("", "xray")
+	c.Assert(err, IsNil)
+	config.ProjectRoot = dir
+}
+
+func (s *MySuite) TearDownTest(c *C) {
+	os.RemoveAll(config.ProjectRoot)
+	os.Unsetenv(env.XrayURL)
+	os.Unsetenv(env.XrayToken)
+}
+
+func scenarioResult(status gauge_messages.ExecutionStatus) *result.ScenarioResult {
+	return result.NewScenarioResult(&gauge_messages.ProtoScenario{ExecutionStatus: status})
+}
+
+func (s *MySuite) TestTestKey(c *C) {
+	key, ok := TestKey(&gauge.Tags{RawValues: [][]string{{"smoke"}, {"@jira:PROJ-123"}}})
+
+	c.Assert(ok, Equals, true)
+	c.Assert(key, Equals, "PROJ-123")
+}
+
+func (s *MySuite) TestTestKeyWithoutJiraTag(c *C) {
+	_, ok := TestKey(&gauge.Tags{RawValues: [][]string{{"smoke", "jira:not a key"}}})
+
+	c.Assert(ok, Equals, false)
+}
+
+func (s *MySuite) TestCollectorReportsScenariosWithSameKeyAsOneTest(c *C) {
+	col := newCollector()
+	start := time.Date(2017, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	col.add("PROJ-1", start, start.Add(time.Second), scenarioResult(gauge_messages.ExecutionStatus_PASSED))
+	col.add("PROJ-2", start, start.Add(time.Second), scenarioResult(gauge_messages.ExecutionStatus_SKIPPED))
+	col.add("PROJ-1", start.Add(time.Second), start.Add(2*time.Second), scenarioResult(gauge_messages.ExecutionStatus_FAILED))
+
+	tests := col.toImport(start.Add(3 * time.Second)).Tests
+	c.Assert(tests, DeepEquals, []TestRun{
+		{TestKey: "PROJ-1", Start: "2017-01-01T10:00:00Z", Finish: "2017-01-01T10:00:02Z", Status: fail},
+		{TestKey: "PROJ-2", Start: "2017-01-01T10:00:00Z", Finish: "2017-01-01T10:00:01Z", Status: todo},
+	})
+}
+
+func (s *MySuite) TestExportWritesAndUploadsImportFile(c *C) {
+	var got Import
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.URL.Path, Equals, importPath)
+		c.Check(r.Header.Get("Authorization"), Equals, "Bearer token")
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"testExecIssue":{"key":"PROJ-9"}}`))
+	}))
+	defer server.Close()
+	os.Setenv(env.XrayURL, server.URL)
+	os.Setenv(env.XrayToken, "token")
+	file := filepath.Join(config.ProjectRoot, "reports", "xray.json")
+	i := &Import{Tests: []TestRun{{TestKey: "PROJ-1", Status: pass}}}
+
+	export(i, file)
+
+	c.Assert(got.Tests, DeepEquals, i.Tests)
+	b, err := ioutil.ReadFile(file)
+	c.Assert(err, IsNil)
+	var written Import
+	c.Assert(json.Unmarshal(b, &written), IsNil)
+	c.Assert(written.Tests, DeepEquals, i.Tests)
+}
@@ -1,6 +1,11 @@
 package filter
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/util"
@@ -8,9 +13,30 @@ import (
 
 var ExecuteTags string
 var Distribute int
+
+// Deterministic makes the specs of a group, given by Distribute, a stable function of the spec paths and the number of streams.
+var Deterministic bool
 var NumberOfExecutionStreams int
 var ScenariosName []string
 
+// ShardIndex is the 1 based index of the shard to execute, when the scenarios are partitioned into ShardCount shards.
+var ShardIndex int
+
+// SpecPattern is a regular expression which the headings of the specs to execute should match.
+var SpecPattern string
+
+// ScenarioPattern is a regular expression which the headings of the scenarios to execute should match.
+var ScenarioPattern string
+
+// ShardCount is the number of shards the scenarios are partitioned into. Sharding is disabled if it is less than 2.
+var ShardCount int
+
+// Smart selects the scenarios worth running the most, like the recent failures and the ones in changed specs, which fit in the Budget.
+var Smart bool
+
+// Budget is the time within which the scenarios selected by Smart are expected to run, as per their durations in the results history.
+var Budget time.Duration
+
 func FilterSpecs(specs []*gauge.Specification) []*gauge.Specification {
 	specs = applyFilters(specs, specsFilters())
 	if ExecuteTags != "" && len(specs) > 0 {
@@ -23,7 +49,36 @@ func FilterSpecs(specs []*gauge.Specification) []*gauge.Specification {
 }
 
 func specsFilters() []specsFilter {
-	return []specsFilter{&tagsFilter{ExecuteTags}, &specsGroupFilter{Distribute, NumberOfExecutionStreams}, &scenariosFilter{ScenariosName}}
+	return []specsFilter{&tagsFilter{ExecuteTags}, &headingPatternFilter{SpecPattern, ScenarioPattern}, &shardFilter{ShardIndex, ShardCount}, &specsGroupFilter{Distribute, NumberOfExecutionStreams}, &scenariosFilter{ScenariosName}, &smartFilter{Smart, Budget}}
+}
+
+// TagExpression combines the tag expression with the comma separated tags to exclude, so that the
+// scenarios having any of the excluded tags are not executed even if they satisfy the expression.
+func TagExpression(tags, excludeTags string) string {
+	var exclusions []string
+	for _, t := range strings.Split(excludeTags, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			exclusions = append(exclusions, "!"+t)
+		}
+	}
+	if len(exclusions) == 0 {
+		return tags
+	}
+	exp := strings.Join(exclusions, " & ")
+	if strings.TrimSpace(tags) == "" {
+		return exp
+	}
+	return fmt.Sprintf("(%s) & %s", tags, exp)
+}
+
+// ValidatePatterns checks that the spec and scenario heading patterns are valid regular expressions.
+func ValidatePatterns(specPattern, scenarioPattern string) error {
+	for _, p := range []string{specPattern, scenarioPattern} {
+		if _, err := regexp.Compile(p); err != nil {
+			return fmt.Errorf("Invalid heading pattern %s: %s", p, err.Error())
+		}
+	}
+	return nil
 }
 
 func applyFilters(specsToExecute []*gauge.Specification, filters []specsFilter) []*gauge.Specification {
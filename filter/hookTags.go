@@ -0,0 +1,80 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package filter
+
+import (
+	"os"
+	"strings"
+
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/logger"
+)
+
+// Hook levels whose execution can be filtered by tags.
+const (
+	BeforeSpecHook     = "before_spec"
+	AfterSpecHook      = "after_spec"
+	BeforeScenarioHook = "before_scenario"
+	AfterScenarioHook  = "after_scenario"
+	BeforeStepHook     = "before_step"
+	AfterStepHook      = "after_step"
+)
+
+var hookLevels = []string{BeforeSpecHook, AfterSpecHook, BeforeScenarioHook, AfterScenarioHook, BeforeStepHook, AfterStepHook}
+
+// HookTags gives the tag expressions, keyed by hook level, which the tags of a spec or scenario must satisfy for the hooks of that level to run.
+// They are configured as semicolon separated level:expression pairs in the gauge_hook_tags property, e.g. before_scenario:web & !smoke.
+func HookTags() map[string]string {
+	hookTags := make(map[string]string)
+	for _, filter := range strings.Split(os.Getenv(env.HookTags), ";") {
+		if strings.TrimSpace(filter) == "" {
+			continue
+		}
+		i := strings.Index(filter, ":")
+		if i == -1 || !isHookLevel(strings.TrimSpace(filter[:i])) || strings.TrimSpace(filter[i+1:]) == "" {
+			logger.Warningf(true, "Ignoring invalid hook filter %s in %s. Filters should be given as level:tag expression, where level is one of %s", strings.TrimSpace(filter), env.HookTags, strings.Join(hookLevels, ", "))
+			continue
+		}
+		level, tagExpression := strings.TrimSpace(filter[:i]), strings.TrimSpace(filter[i+1:])
+		if err := isValidTagExpression(tagExpression); err != nil {
+			logger.Warningf(true, "Ignoring hook filter %s in %s. %s", strings.TrimSpace(filter), env.HookTags, err.Error())
+			continue
+		}
+		hookTags[level] = tagExpression
+	}
+	return hookTags
+}
+
+// RunsHook tells if the hooks of the given level should run for an item having the given tags.
+// Hooks of a level without a tag expression always run.
+func RunsHook(hookTags map[string]string, level string, tags []string) bool {
+	tagExpression, ok := hookTags[level]
+	if !ok {
+		return true
+	}
+	return newScenarioFilterBasedOnTags(nil, tagExpression).filterTags(tags)
+}
+
+func isHookLevel(level string) bool {
+	for _, l := range hookLevels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package filter
+
+import (
+	"os"
+
+	"github.com/getgauge/gauge/env"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestHookTags(c *C) {
+	os.Setenv(env.HookTags, "before_scenario: web & !smoke; after_step:debug,slow; on_failure:web; before_spec; after_spec:web &")
+	defer os.Unsetenv(env.HookTags)
+
+	c.Assert(HookTags(), DeepEquals, map[string]string{BeforeScenarioHook: "web & !smoke", AfterStepHook: "debug,slow"})
+}
+
+func (s *MySuite) TestRunsHook(c *C) {
+	hookTags := map[string]string{BeforeScenarioHook: "web & !smoke"}
+
+	c.Assert(RunsHook(hookTags, BeforeScenarioHook, []string{"web", "login"}), Equals, true)
+	c.Assert(RunsHook(hookTags, BeforeScenarioHook, []string{"web", "smoke"}), Equals, false)
+	c.Assert(RunsHook(hookTags, BeforeScenarioHook, nil), Equals, false)
+	c.Assert(RunsHook(hookTags, AfterScenarioHook, nil), Equals, true)
+}
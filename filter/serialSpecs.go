@@ -0,0 +1,79 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package filter
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/getgauge/gauge/gauge"
+)
+
+const (
+	// SerialTag marks the specs which cannot run alongside others, as they mutate global state
+	SerialTag = "serial"
+	// ParallelizableKey is the metadata which marks the specs which cannot run alongside others, when false
+	ParallelizableKey = "parallelizable"
+)
+
+// SerialSpecs separates the specs which have to run on their own from the rest. A spec is serial if the spec or any of
+// its scenarios has the serial tag, or parallelizable=false metadata.
+func SerialSpecs(specs []*gauge.Specification) ([]*gauge.Specification, []*gauge.Specification) {
+	var serial, rest []*gauge.Specification
+	for _, spec := range specs {
+		if isSerial(spec) {
+			serial = append(serial, spec)
+		} else {
+			rest = append(rest, spec)
+		}
+	}
+	return serial, rest
+}
+
+func isSerial(spec *gauge.Specification) bool {
+	if hasSerialTag(spec.Tags) || notParallelizable(spec.Meta) {
+		return true
+	}
+	for _, scenario := range spec.Scenarios {
+		if hasSerialTag(scenario.Tags) || notParallelizable(scenario.Meta) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasSerialTag(tags *gauge.Tags) bool {
+	if tags == nil {
+		return false
+	}
+	for _, tag := range tags.Values() {
+		if strings.EqualFold(strings.TrimPrefix(strings.TrimSpace(tag), "@"), SerialTag) {
+			return true
+		}
+	}
+	return false
+}
+
+func notParallelizable(meta *gauge.Meta) bool {
+	v, ok := meta.Get(ParallelizableKey)
+	if !ok {
+		return false
+	}
+	parallelizable, err := strconv.ParseBool(strings.TrimSpace(v))
+	return err == nil && !parallelizable
+}
@@ -0,0 +1,37 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package filter
+
+import (
+	"github.com/getgauge/gauge/gauge"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestSerialSpecsByTagsAndMetadata(c *C) {
+	notParallel, _ := gauge.NewMeta(1, "parallelizable=false")
+	parallel, _ := gauge.NewMeta(1, "parallelizable=true")
+	tagged := &gauge.Specification{FileName: "tagged.spec", Tags: &gauge.Tags{RawValues: [][]string{{"Serial"}}}}
+	scenarioMeta := &gauge.Specification{FileName: "scenario.spec", Scenarios: []*gauge.Scenario{{}, {Meta: notParallel}}}
+	parallelMeta := &gauge.Specification{FileName: "parallel.spec", Meta: parallel}
+	other := &gauge.Specification{FileName: "other.spec", Scenarios: []*gauge.Scenario{{Tags: &gauge.Tags{RawValues: [][]string{{"serials"}}}}}}
+
+	serial, rest := SerialSpecs([]*gauge.Specification{tagged, scenarioMeta, parallelMeta, other})
+
+	c.Assert(serial, DeepEquals, []*gauge.Specification{tagged, scenarioMeta})
+	c.Assert(rest, DeepEquals, []*gauge.Specification{parallelMeta, other})
+}
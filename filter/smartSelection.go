@@ -0,0 +1,197 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package filter
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/getgauge/gauge/execution/history"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/util"
+)
+
+// defaultScenarioDuration is the expected duration of a scenario when no durations are known from the results history.
+const defaultScenarioDuration = 10 * time.Second
+
+const (
+	otherTier = iota
+	changedTier
+	failedTier
+)
+
+type smartFilter struct {
+	enabled bool
+	budget  time.Duration
+}
+
+// candidate is a scenario which can be selected, with what it is worth.
+type candidate struct {
+	scenario *gauge.Scenario
+	tier     int
+	duration time.Duration
+	steps    []string // distinct steps used
+}
+
+func (smartFilter *smartFilter) filter(specs []*gauge.Specification) []*gauge.Specification {
+	if !smartFilter.enabled {
+		return specs
+	}
+	candidates := newCandidates(specs, history.FailedRecently(), util.ChangedRecently(), history.ScenarioDurations())
+	selected, total := selectWithinBudget(candidates, smartFilter.budget)
+	logger.Infof(true, "Selected %d of %d scenarios, expected to run in %s within the budget of %s.", len(selected), len(candidates), total, smartFilter.budget)
+	filteredSpecs := make([]*gauge.Specification, 0)
+	for _, spec := range specs {
+		spec.Filter(&scenarioFilterBasedOnSelection{selected})
+		if len(spec.Scenarios) != 0 {
+			filteredSpecs = append(filteredSpecs, spec)
+		}
+	}
+	return filteredSpecs
+}
+
+// newCandidates gives the scenarios of the specs in their order, tiered by whether they failed in the recent runs or
+// belong to a recently changed spec. Scenarios without a recorded duration are expected to take the average duration.
+func newCandidates(specs []*gauge.Specification, failures map[string]bool, changes map[string]bool, durations map[string]int64) []*candidate {
+	average := defaultScenarioDuration
+	if len(durations) > 0 {
+		var total int64
+		for _, d := range durations {
+			total += d
+		}
+		average = time.Duration(total/int64(len(durations))) * time.Millisecond
+	}
+	candidates := make([]*candidate, 0)
+	for _, spec := range specs {
+		path := util.RelPathToProjectRoot(spec.FileName)
+		changed := changes[util.ResolvedPath(spec.FileName)]
+		for _, sce := range spec.Scenarios {
+			c := &candidate{scenario: sce, tier: otherTier, duration: average, steps: distinct(stepValues(sce.Steps))}
+			key := history.ScenarioKey(path, sce.Heading.Value)
+			if d, ok := durations[key]; ok {
+				c.duration = time.Duration(d) * time.Millisecond
+			}
+			if failures[key] {
+				c.tier = failedTier
+			} else if changed {
+				c.tier = changedTier
+			}
+			candidates = append(candidates, c)
+		}
+	}
+	return candidates
+}
+
+// stepValues gives the steps used by a scenario, including the concepts and the steps within them.
+func stepValues(steps []*gauge.Step) []string {
+	values := make([]string, 0)
+	for _, s := range steps {
+		values = append(values, strings.ToLower(s.Value))
+		if s.IsConcept {
+			values = append(values, stepValues(s.ConceptSteps)...)
+		}
+	}
+	return values
+}
+
+// selectWithinBudget picks the scenarios tier by tier, highest first, for as long as they fit in the budget. Within a tier, the
+// scenario which covers the most steps not covered yet for its duration is picked first. A step is worth the number of candidates
+// using it, so that the scenarios using widely shared steps and concepts are preferred. It gives the picked scenarios and their
+// expected duration.
+func selectWithinBudget(candidates []*candidate, budget time.Duration) (map[*gauge.Scenario]bool, time.Duration) {
+	weights := make(map[string]int)
+	for _, c := range candidates {
+		for _, s := range c.steps {
+			weights[s]++
+		}
+	}
+	covered := make(map[string]bool)
+	gain := func(c *candidate) int {
+		g := 0
+		for _, s := range c.steps {
+			if !covered[s] {
+				g += weights[s]
+			}
+		}
+		return g
+	}
+	remaining := make([]*candidate, len(candidates))
+	copy(remaining, candidates)
+	sort.SliceStable(remaining, func(i, j int) bool {
+		return remaining[i].tier > remaining[j].tier
+	})
+	selected := make(map[*gauge.Scenario]bool)
+	var total time.Duration
+	for len(remaining) > 0 {
+		tier, best, bestValue := remaining[0].tier, -1, -1.0
+		for i, c := range remaining {
+			if c.tier != tier {
+				break
+			}
+			if total+c.duration > budget {
+				continue
+			}
+			if v := float64(gain(c)+1) / float64(c.duration+time.Millisecond); v > bestValue {
+				best, bestValue = i, v
+			}
+		}
+		if best == -1 {
+			remaining = dropTier(remaining, tier)
+			continue
+		}
+		c := remaining[best]
+		selected[c.scenario] = true
+		total += c.duration
+		for _, s := range c.steps {
+			covered[s] = true
+		}
+		remaining = append(remaining[:best], remaining[best+1:]...)
+	}
+	return selected, total
+}
+
+func dropTier(candidates []*candidate, tier int) []*candidate {
+	i := 0
+	for i < len(candidates) && candidates[i].tier == tier {
+		i++
+	}
+	return candidates[i:]
+}
+
+func distinct(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	d := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			d = append(d, v)
+		}
+	}
+	return d
+}
+
+type scenarioFilterBasedOnSelection struct {
+	selected map[*gauge.Scenario]bool
+}
+
+// Filter removes the scenarios which are not selected.
+func (filter *scenarioFilterBasedOnSelection) Filter(item gauge.Item) bool {
+	return item.Kind() == gauge.ScenarioKind && !filter.selected[item.(*gauge.Scenario)]
+}
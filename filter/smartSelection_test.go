@@ -0,0 +1,100 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package filter
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/execution/history"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/util"
+	. "gopkg.in/check.v1"
+)
+
+func scenarioWithSteps(heading string, steps ...string) *gauge.Scenario {
+	sce := &gauge.Scenario{Heading: &gauge.Heading{Value: heading}}
+	for _, s := range steps {
+		sce.Steps = append(sce.Steps, &gauge.Step{Value: s})
+	}
+	return sce
+}
+
+func (s *MySuite) TestSelectWithinBudgetPicksTiersFirst(c *C) {
+	failed := &candidate{scenario: scenarioWithSteps("failed"), tier: failedTier, duration: 4 * time.Second}
+	changed := &candidate{scenario: scenarioWithSteps("changed"), tier: changedTier, duration: 4 * time.Second}
+	other := &candidate{scenario: scenarioWithSteps("other"), tier: otherTier, duration: time.Second}
+
+	selected, total := selectWithinBudget([]*candidate{other, changed, failed}, 8*time.Second)
+
+	c.Assert(selected, DeepEquals, map[*gauge.Scenario]bool{failed.scenario: true, changed.scenario: true})
+	c.Assert(total, Equals, 8*time.Second)
+}
+
+func (s *MySuite) TestSelectWithinBudgetSkipsScenariosWhichDoNotFit(c *C) {
+	long := &candidate{scenario: scenarioWithSteps("long"), tier: failedTier, duration: time.Minute}
+	short := &candidate{scenario: scenarioWithSteps("short"), tier: otherTier, duration: time.Second}
+
+	selected, total := selectWithinBudget([]*candidate{long, short}, 30*time.Second)
+
+	c.Assert(selected, DeepEquals, map[*gauge.Scenario]bool{short.scenario: true})
+	c.Assert(total, Equals, time.Second)
+}
+
+func (s *MySuite) TestSelectWithinBudgetPrefersScenariosCoveringSharedSteps(c *C) {
+	shared := &candidate{scenario: scenarioWithSteps("shared"), duration: time.Second, steps: []string{"login", "search"}}
+	same := &candidate{scenario: scenarioWithSteps("same"), duration: time.Second, steps: []string{"login", "search"}}
+	unique := &candidate{scenario: scenarioWithSteps("unique"), duration: time.Second, steps: []string{"logout"}}
+	partly := &candidate{scenario: scenarioWithSteps("partly"), duration: time.Second, steps: []string{"login", "checkout", "pay"}}
+
+	selected, _ := selectWithinBudget([]*candidate{unique, same, shared, partly}, 2*time.Second)
+
+	c.Assert(selected, DeepEquals, map[*gauge.Scenario]bool{same.scenario: true, partly.scenario: true})
+}
+
+func (s *MySuite) TestStepValuesIncludesConceptSteps(c *C) {
+	concept := &gauge.Step{Value: "Log in as {}", IsConcept: true, ConceptSteps: []*gauge.Step{{Value: "Open {}"}, {Value: "Submit"}}}
+
+	c.Assert(stepValues([]*gauge.Step{{Value: "Search {}"}, concept}), DeepEquals, []string{"search {}", "log in as {}", "open {}", "submit"})
+}
+
+func (s *MySuite) TestSmartFilterRemovesScenariosNotSelected(c *C) {
+	oldFailures, oldChanges, oldDurations, oldRoot := history.FailedRecently, util.ChangedRecently, history.ScenarioDurations, config.ProjectRoot
+	defer func() {
+		history.FailedRecently, util.ChangedRecently, history.ScenarioDurations, config.ProjectRoot = oldFailures, oldChanges, oldDurations, oldRoot
+	}()
+	config.ProjectRoot, _ = filepath.Abs("root")
+	history.FailedRecently = func() map[string]bool {
+		return map[string]bool{history.ScenarioKey("b.spec", "Checkout"): true}
+	}
+	util.ChangedRecently = func() map[string]bool { return map[string]bool{} }
+	history.ScenarioDurations = func() map[string]int64 {
+		return map[string]int64{history.ScenarioKey("a.spec", "Login"): 60000, history.ScenarioKey("b.spec", "Checkout"): 30000}
+	}
+	login, search := scenarioWithSteps("Login", "log in"), scenarioWithSteps("Search", "search")
+	checkout := scenarioWithSteps("Checkout", "check out")
+	a := &gauge.Specification{FileName: filepath.Join(config.ProjectRoot, "a.spec"), Scenarios: []*gauge.Scenario{login, search}, Items: []gauge.Item{login, search}}
+	b := &gauge.Specification{FileName: filepath.Join(config.ProjectRoot, "b.spec"), Scenarios: []*gauge.Scenario{checkout}, Items: []gauge.Item{checkout}}
+
+	specs := (&smartFilter{true, 90 * time.Second}).filter([]*gauge.Specification{a, b})
+
+	c.Assert(len(specs), Equals, 2)
+	c.Assert(a.Scenarios, DeepEquals, []*gauge.Scenario{search})
+	c.Assert(b.Scenarios, DeepEquals, []*gauge.Scenario{checkout})
+}
@@ -22,6 +22,8 @@ import (
 	"go/constant"
 	"go/token"
 	"go/types"
+	"hash/fnv"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -30,6 +32,7 @@ import (
 
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/util"
 )
 
 type scenarioFilterBasedOnSpan struct {
@@ -44,6 +47,16 @@ type scenarioFilterBasedOnName struct {
 	scenariosName []string
 }
 
+type scenarioFilterBasedOnPattern struct {
+	pattern *regexp.Regexp
+}
+
+type scenarioFilterBasedOnShard struct {
+	specFile string
+	index    int
+	count    int
+}
+
 func NewScenarioFilterBasedOnSpan(lineNumbers []int) *scenarioFilterBasedOnSpan {
 	return &scenarioFilterBasedOnSpan{lineNumbers}
 }
@@ -83,7 +96,68 @@ func (filter *scenarioFilterBasedOnName) Filter(item gauge.Item) bool {
 	if item.Kind() != gauge.ScenarioKind {
 		return false
 	}
-	return !item.(*gauge.Scenario).HasAnyHeading(filter.scenariosName)
+	heading := item.(*gauge.Scenario).Heading.Value
+	for _, name := range filter.scenariosName {
+		if matchesScenarioName(heading, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesScenarioName tells if the scenario heading matches the name given to --scenario. The name is
+// matched exactly, unless it is enclosed in slashes, as in /^Login/, which makes it a regular expression,
+// or it has a * or ? wildcard, which makes it a glob pattern that has to match the whole heading.
+func matchesScenarioName(heading, name string) bool {
+	if heading == name {
+		return true
+	}
+	r, err := scenarioNamePattern(name)
+	if err != nil || r == nil {
+		return false
+	}
+	return r.MatchString(heading)
+}
+
+// scenarioNamePattern gives the regular expression for a regex or glob scenario name, and nil for an exact name.
+func scenarioNamePattern(name string) (*regexp.Regexp, error) {
+	if len(name) > 2 && strings.HasPrefix(name, "/") && strings.HasSuffix(name, "/") {
+		return regexp.Compile(name[1 : len(name)-1])
+	}
+	if !strings.ContainsAny(name, "*?") {
+		return nil, nil
+	}
+	glob := regexp.QuoteMeta(name)
+	glob = strings.Replace(glob, `\*`, ".*", -1)
+	glob = strings.Replace(glob, `\?`, ".", -1)
+	return regexp.Compile("^" + glob + "$")
+}
+
+func newScenarioFilterBasedOnPattern(pattern *regexp.Regexp) *scenarioFilterBasedOnPattern {
+	return &scenarioFilterBasedOnPattern{pattern}
+}
+
+// Filter removes the scenarios whose heading does not match the pattern.
+func (filter *scenarioFilterBasedOnPattern) Filter(item gauge.Item) bool {
+	if item.Kind() != gauge.ScenarioKind {
+		return false
+	}
+	return !filter.pattern.MatchString(item.(*gauge.Scenario).Heading.Value)
+}
+
+func newScenarioFilterBasedOnShard(specFile string, index, count int) *scenarioFilterBasedOnShard {
+	return &scenarioFilterBasedOnShard{specFile, index, count}
+}
+
+// Filter removes the scenarios which do not belong to the shard. A scenario is assigned to a shard using
+// a hash of its spec file and heading, so that the partitioning is stable across machines and runs.
+func (filter *scenarioFilterBasedOnShard) Filter(item gauge.Item) bool {
+	if item.Kind() != gauge.ScenarioKind {
+		return false
+	}
+	h := fnv.New32a()
+	h.Write([]byte(filepath.ToSlash(filter.specFile) + ":" + item.(*gauge.Scenario).Heading.Value))
+	return int(h.Sum32()%uint32(filter.count))+1 != filter.index
 }
 
 func sanitize(tag string) string {
@@ -223,12 +297,16 @@ func filterSpecsByTags(specs []*gauge.Specification, tagExpression string) []*ga
 }
 
 func validateTagExpression(tagExpression string) {
+	if err := isValidTagExpression(tagExpression); err != nil {
+		logger.Fatalf(true, err.Error())
+	}
+}
+
+func isValidTagExpression(tagExpression string) error {
 	filter := &ScenarioFilterBasedOnTags{tagExpression: tagExpression}
 	filter.replaceSpecialChar()
 	_, err := filter.formatAndEvaluateExpression(make(map[string]bool, 0), func(a map[string]bool, b string) bool { return true })
-	if err != nil {
-		logger.Fatalf(true, err.Error())
-	}
+	return err
 }
 
 func filterSpecsByScenarioName(specs []*gauge.Specification, scenariosName []string) []*gauge.Specification {
@@ -236,6 +314,25 @@ func filterSpecsByScenarioName(specs []*gauge.Specification, scenariosName []str
 	scenarios := filterValidScenarios(specs, scenariosName)
 	for _, spec := range specs {
 		spec.Filter(newScenarioFilterBasedOnName(scenarios))
+		if len(spec.Scenarios) != 0 {
+			logger.Debugf(true, "Scenario name(s) resolved to %s", util.RelPathToProjectRoot(spec.FileName))
+			filteredSpecs = append(filteredSpecs, spec)
+		}
+	}
+	return filteredSpecs
+}
+
+// filterSpecsByHeadingPattern retains the specs whose heading matches the spec pattern and, within them,
+// the scenarios whose heading matches the scenario pattern. An empty pattern matches everything.
+func filterSpecsByHeadingPattern(specs []*gauge.Specification, specPattern, scenarioPattern string) []*gauge.Specification {
+	specRegex := regexp.MustCompile(specPattern)
+	scenarioRegex := regexp.MustCompile(scenarioPattern)
+	filteredSpecs := make([]*gauge.Specification, 0)
+	for _, spec := range specs {
+		if spec.Heading == nil || !specRegex.MatchString(spec.Heading.Value) {
+			continue
+		}
+		spec.Filter(newScenarioFilterBasedOnPattern(scenarioRegex))
 		if len(spec.Scenarios) != 0 {
 			filteredSpecs = append(filteredSpecs, spec)
 		}
@@ -248,14 +345,16 @@ func filterValidScenarios(specs []*gauge.Specification, headings []string) []str
 	allScenarios := GetAllScenarios(specs)
 	var exists = func(scenarios []string, heading string) bool {
 		for _, scenario := range scenarios {
-			if strings.Compare(scenario, heading) == 0 {
+			if matchesScenarioName(scenario, heading) {
 				return true
 			}
 		}
 		return false
 	}
 	for _, heading := range headings {
-		if exists(allScenarios, heading) {
+		if _, err := scenarioNamePattern(heading); err != nil {
+			logger.Warningf(true, "Warning: invalid scenario name pattern - \"%s\": %s", heading, err.Error())
+		} else if exists(allScenarios, heading) {
 			filteredScenarios = append(filteredScenarios, heading)
 		} else {
 			logger.Warningf(true, "Warning: scenario name - \"%s\" not found", heading)
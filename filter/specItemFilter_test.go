@@ -786,3 +786,83 @@ func (s *MySuite) TestFilterInvalidScenarios(c *C) {
 	c.Assert(len(filteredScenarios), Equals, 1)
 	c.Assert(filteredScenarios[0], Equals, "First Scenario")
 }
+
+func (s *MySuite) TestFilterScenariosByGlobAndRegexName(c *C) {
+	scenario1 := &gauge.Scenario{
+		Heading: &gauge.Heading{Value: "User can reset password"},
+	}
+	scenario2 := &gauge.Scenario{
+		Heading: &gauge.Heading{Value: "User can login"},
+	}
+	scenario3 := &gauge.Scenario{
+		Heading: &gauge.Heading{Value: "Admin can reset password"},
+	}
+	spec1 := &gauge.Specification{
+		Items:     []gauge.Item{scenario1, scenario2},
+		Scenarios: []*gauge.Scenario{scenario1, scenario2},
+	}
+	spec2 := &gauge.Specification{
+		Items:     []gauge.Item{scenario3},
+		Scenarios: []*gauge.Scenario{scenario3},
+	}
+
+	specs := filterSpecsByScenarioName([]*gauge.Specification{spec1, spec2}, []string{"User * password", "/^Admin/"})
+
+	c.Assert(len(specs), Equals, 2)
+	c.Assert(specs[0].Scenarios, DeepEquals, []*gauge.Scenario{scenario1})
+	c.Assert(specs[1].Scenarios, DeepEquals, []*gauge.Scenario{scenario3})
+}
+
+func (s *MySuite) TestMatchesScenarioName(c *C) {
+	c.Assert(matchesScenarioName("User can login", "User can login"), Equals, true)
+	c.Assert(matchesScenarioName("User can login", "User can"), Equals, false)
+	c.Assert(matchesScenarioName("User can login", "User*"), Equals, true)
+	c.Assert(matchesScenarioName("User can login", "*can"), Equals, false)
+	c.Assert(matchesScenarioName("User can log.n", "User can log?n"), Equals, true)
+	c.Assert(matchesScenarioName("[API] Get user", "/^\\[API\\]/"), Equals, true)
+	c.Assert(matchesScenarioName("User can login", "/(/"), Equals, false)
+}
+
+func (s *MySuite) TestFilterValidScenariosWithInvalidPattern(c *C) {
+	scenario1 := &gauge.Scenario{
+		Heading: &gauge.Heading{Value: "First Scenario"},
+	}
+	spec1 := &gauge.Specification{
+		Items:     []gauge.Item{scenario1},
+		Scenarios: []*gauge.Scenario{scenario1},
+	}
+
+	filteredScenarios := filterValidScenarios([]*gauge.Specification{spec1}, []string{"/(/", "First*"})
+
+	c.Assert(filteredScenarios, DeepEquals, []string{"First*"})
+}
+
+func (s *MySuite) TestTagExpressionWithExcludedTags(c *C) {
+	c.Assert(TagExpression("login", ""), Equals, "login")
+	c.Assert(TagExpression("", "wip, slow"), Equals, "!wip & !slow")
+	c.Assert(TagExpression("login | signup", "wip"), Equals, "(login | signup) & !wip")
+}
+
+func (s *MySuite) TestFilterSpecsByTagsWithExcludedTags(c *C) {
+	scenario1 := &gauge.Scenario{
+		Heading: &gauge.Heading{Value: "First Scenario"},
+		Tags:    &gauge.Tags{RawValues: [][]string{{"login"}}},
+	}
+	scenario2 := &gauge.Scenario{
+		Heading: &gauge.Heading{Value: "Second Scenario"},
+		Tags:    &gauge.Tags{RawValues: [][]string{{"login", "wip"}}},
+	}
+	scenario3 := &gauge.Scenario{
+		Heading: &gauge.Heading{Value: "Third Scenario"},
+		Tags:    &gauge.Tags{RawValues: [][]string{{"signup"}}},
+	}
+	spec1 := &gauge.Specification{
+		Items:     []gauge.Item{scenario1, scenario2, scenario3},
+		Scenarios: []*gauge.Scenario{scenario1, scenario2, scenario3},
+	}
+
+	specs := filterSpecsByTags([]*gauge.Specification{spec1}, TagExpression("login", "wip,slow"))
+
+	c.Assert(len(specs), Equals, 1)
+	c.Assert(specs[0].Scenarios, DeepEquals, []*gauge.Scenario{scenario1})
+}
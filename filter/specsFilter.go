@@ -18,8 +18,14 @@
 package filter
 
 import (
+	"hash/fnv"
+	"path/filepath"
+	"sort"
+
+	"github.com/getgauge/gauge/execution/history"
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/util"
 )
 
 type specsFilter interface {
@@ -39,6 +45,16 @@ type scenariosFilter struct {
 	scenarios []string
 }
 
+type headingPatternFilter struct {
+	specPattern     string
+	scenarioPattern string
+}
+
+type shardFilter struct {
+	index int
+	count int
+}
+
 func (tagsFilter *tagsFilter) filter(specs []*gauge.Specification) []*gauge.Specification {
 	if tagsFilter.tagExp != "" {
 		validateTagExpression(tagsFilter.tagExp)
@@ -51,11 +67,16 @@ func (groupFilter *specsGroupFilter) filter(specs []*gauge.Specification) []*gau
 	if groupFilter.group == -1 {
 		return specs
 	}
-	logger.Infof(true, "Using the -g flag will make the distribution strategy 'eager'. The --strategy setting will be overridden.")
 	if groupFilter.group < 1 || groupFilter.group > groupFilter.execStreams {
 		return make([]*gauge.Specification, 0)
 	}
-	group := DistributeSpecs(specs, groupFilter.execStreams)[groupFilter.group-1]
+	var group *gauge.SpecCollection
+	if Deterministic {
+		group = DistributeSpecsDeterministically(specs, groupFilter.execStreams)[groupFilter.group-1]
+	} else {
+		logger.Infof(true, "Using the -g flag will make the distribution strategy 'eager'. The --strategy setting will be overridden.")
+		group = distributeSpecsInGroups(specs, groupFilter.execStreams)[groupFilter.group-1]
+	}
 	if group == nil {
 		return make([]*gauge.Specification, 0)
 	}
@@ -69,7 +90,89 @@ func (scenarioFilter *scenariosFilter) filter(specs []*gauge.Specification) []*g
 	return specs
 }
 
+func (patternFilter *headingPatternFilter) filter(specs []*gauge.Specification) []*gauge.Specification {
+	if patternFilter.specPattern == "" && patternFilter.scenarioPattern == "" {
+		return specs
+	}
+	if err := ValidatePatterns(patternFilter.specPattern, patternFilter.scenarioPattern); err != nil {
+		logger.Fatal(true, err.Error())
+	}
+	return filterSpecsByHeadingPattern(specs, patternFilter.specPattern, patternFilter.scenarioPattern)
+}
+
+func (shardFilter *shardFilter) filter(specs []*gauge.Specification) []*gauge.Specification {
+	if shardFilter.count < 2 {
+		return specs
+	}
+	filteredSpecs := make([]*gauge.Specification, 0)
+	for _, spec := range specs {
+		spec.Filter(newScenarioFilterBasedOnShard(util.RelPathToProjectRoot(spec.FileName), shardFilter.index, shardFilter.count))
+		if len(spec.Scenarios) != 0 {
+			filteredSpecs = append(filteredSpecs, spec)
+		}
+	}
+	return filteredSpecs
+}
+
+// DistributeSpecs splits the specifications into the streams of a --parallel run. The specs pinned to a stream
+// are put in the group of that stream. If the durations of the remaining specs are known from previous runs,
+// they are bin-packed using longest processing time first, otherwise they are distributed in a round robin manner.
 func DistributeSpecs(specifications []*gauge.Specification, distributions int) []*gauge.SpecCollection {
+	return distributeWithPins(specifications, distributions, distributeSpecs)
+}
+
+// distributeSpecsInGroups splits the specifications into the groups run by -g. Each group is usually run on another
+// machine, each with its own history, so the specs are distributed in a round robin manner rather than by their
+// durations. Otherwise machines could split the specs differently, and run some specs twice and others not at all.
+func distributeSpecsInGroups(specifications []*gauge.Specification, distributions int) []*gauge.SpecCollection {
+	return distributeWithPins(specifications, distributions, distributeSpecsInRoundRobin)
+}
+
+func distributeWithPins(specifications []*gauge.Specification, distributions int, distribute func([]*gauge.Specification, int) []*gauge.SpecCollection) []*gauge.SpecCollection {
+	pinned, rest := PinSpecs(specifications, StreamPins(), distributions)
+	s := distribute(rest, distributions)
+	for stream, specs := range pinned {
+		if s[stream-1] != nil {
+			specs = append(specs, s[stream-1].Specs()...)
+		}
+		s[stream-1] = gauge.NewSpecCollection(specs, false)
+	}
+	return s
+}
+
+// DistributeSpecsDeterministically splits the specifications into the given number of groups, with the group of each spec
+// depending only on its path relative to the project and the number of groups. Unlike DistributeSpecs, a spec stays in
+// the same group across runs, whatever the other specs and their durations are. The specs pinned to a stream are put in
+// the group of that stream.
+func DistributeSpecsDeterministically(specifications []*gauge.Specification, distributions int) []*gauge.SpecCollection {
+	s := make([]*gauge.SpecCollection, distributions)
+	if distributions < 1 {
+		return s
+	}
+	pinned, rest := PinSpecs(specifications, StreamPins(), distributions)
+	for stream, specs := range pinned {
+		s[stream-1] = gauge.NewSpecCollection(specs, false)
+	}
+	for _, spec := range rest {
+		h := fnv.New32a()
+		h.Write([]byte(filepath.ToSlash(util.RelPathToProjectRoot(spec.FileName))))
+		i := int(h.Sum32() % uint32(distributions))
+		if s[i] == nil {
+			s[i] = gauge.NewSpecCollection(make([]*gauge.Specification, 0), false)
+		}
+		s[i].Add(spec)
+	}
+	return s
+}
+
+func distributeSpecs(specifications []*gauge.Specification, distributions int) []*gauge.SpecCollection {
+	if durations := history.SpecDurations(); len(durations) > 0 {
+		return distributeSpecsByDuration(specifications, distributions, durations)
+	}
+	return distributeSpecsInRoundRobin(specifications, distributions)
+}
+
+func distributeSpecsInRoundRobin(specifications []*gauge.Specification, distributions int) []*gauge.SpecCollection {
 	s := make([]*gauge.SpecCollection, distributions)
 	for i := 0; i < len(specifications); i++ {
 		mod := i % distributions
@@ -80,3 +183,71 @@ func DistributeSpecs(specifications []*gauge.Specification, distributions int) [
 	}
 	return s
 }
+
+func distributeSpecsByDuration(specifications []*gauge.Specification, distributions int, durations map[string]int64) []*gauge.SpecCollection {
+	s := make([]*gauge.SpecCollection, distributions)
+	if distributions < 1 {
+		return s
+	}
+	loads := make([]int64, distributions)
+	weights := estimateDurations(specifications, durations)
+	bins := make(map[*gauge.Specification]int, len(specifications))
+	for _, spec := range SortSpecsByDuration(specifications, durations) {
+		min := 0
+		for i := range loads {
+			if loads[i] < loads[min] {
+				min = i
+			}
+		}
+		bins[spec] = min
+		loads[min] += weights[spec]
+	}
+	// specs are added in the given order, so that the order within each group is retained
+	for _, spec := range specifications {
+		i := bins[spec]
+		if s[i] == nil {
+			s[i] = gauge.NewSpecCollection(make([]*gauge.Specification, 0), false)
+		}
+		s[i].Add(spec)
+	}
+	return s
+}
+
+// SortSpecsByDuration orders the specs so that the longest running specs, as per the given durations, come first.
+// The relative order of specs with equal durations is retained.
+func SortSpecsByDuration(specifications []*gauge.Specification, durations map[string]int64) []*gauge.Specification {
+	weights := estimateDurations(specifications, durations)
+	specs := make([]*gauge.Specification, len(specifications))
+	copy(specs, specifications)
+	sort.SliceStable(specs, func(i, j int) bool {
+		return weights[specs[i]] > weights[specs[j]]
+	})
+	return specs
+}
+
+// estimateDurations gives the expected execution time of each spec. Specs without a recorded duration
+// are assumed to take the average time of the known specs. The duration of a file is shared among all
+// the specs from that file, since data table specs can be split into multiple specs per row.
+func estimateDurations(specifications []*gauge.Specification, durations map[string]int64) map[*gauge.Specification]int64 {
+	var total int64
+	for _, d := range durations {
+		total += d
+	}
+	var average int64
+	if len(durations) > 0 {
+		average = total / int64(len(durations))
+	}
+	count := make(map[string]int64)
+	for _, spec := range specifications {
+		count[spec.FileName]++
+	}
+	weights := make(map[*gauge.Specification]int64)
+	for _, spec := range specifications {
+		d, ok := durations[util.RelPathToProjectRoot(spec.FileName)]
+		if !ok {
+			d = average
+		}
+		weights[spec] = d / count[spec.FileName]
+	}
+	return weights
+}
@@ -20,6 +20,7 @@ package filter
 import (
 	"fmt"
 
+	"github.com/getgauge/gauge/execution/history"
 	"github.com/getgauge/gauge/gauge"
 	. "gopkg.in/check.v1"
 )
@@ -152,3 +153,124 @@ func (s *MySuite) TestToRunSpecificSetOfSpecsGivesEmptySpecsIfDistributableNumbe
 	specsToExecute1 = groupFilter.filter(specs)
 	c.Assert(len(specsToExecute1), Equals, 0)
 }
+
+func (s *MySuite) TestGroupFilterIgnoresRecordedDurations(c *C) {
+	oldDurations := history.SpecDurations
+	defer func() { history.SpecDurations = oldDurations }()
+	history.SpecDurations = func() map[string]int64 {
+		return map[string]int64{"spec0": 10, "spec1": 70, "spec2": 30, "spec3": 40, "spec4": 20}
+	}
+	specs := createSpecsList(5)
+
+	group := (&specsGroupFilter{1, 2}).filter(specs)
+
+	c.Assert(gauge.NewSpecCollection(group, false).SpecNames(), DeepEquals, []string{"spec0", "spec2", "spec4"})
+}
+
+func (s *MySuite) TestDistributionOfSpecsByDuration(c *C) {
+	specs := createSpecsList(5)
+	durations := map[string]int64{"spec0": 10, "spec1": 70, "spec2": 30, "spec3": 40, "spec4": 20}
+
+	specCollections := distributeSpecsByDuration(specs, 2, durations)
+
+	c.Assert(len(specCollections), Equals, 2)
+	c.Assert(specCollections[0].SpecNames(), DeepEquals, []string{"spec1", "spec4"})
+	c.Assert(specCollections[1].SpecNames(), DeepEquals, []string{"spec0", "spec2", "spec3"})
+}
+
+func (s *MySuite) TestSortSpecsByDurationUsesAverageForUnknownSpecs(c *C) {
+	specs := createSpecsList(3)
+	durations := map[string]int64{"spec0": 10, "spec1": 50}
+
+	got := SortSpecsByDuration(specs, durations)
+
+	c.Assert(gauge.NewSpecCollection(got, false).SpecNames(), DeepEquals, []string{"spec1", "spec2", "spec0"})
+}
+
+func (s *MySuite) TestShardsPartitionScenariosDisjointly(c *C) {
+	createSpecs := func() []*gauge.Specification {
+		var specs []*gauge.Specification
+		for i := 0; i < 5; i++ {
+			spec := &gauge.Specification{FileName: fmt.Sprint("spec", i)}
+			for j := 0; j < 4; j++ {
+				sce := &gauge.Scenario{Heading: &gauge.Heading{Value: fmt.Sprint("scenario", j)}}
+				spec.Scenarios = append(spec.Scenarios, sce)
+				spec.Items = append(spec.Items, sce)
+			}
+			specs = append(specs, spec)
+		}
+		return specs
+	}
+	executed := make(map[string]int)
+	for index := 1; index <= 3; index++ {
+		for _, spec := range (&shardFilter{index, 3}).filter(createSpecs()) {
+			for _, sce := range spec.Scenarios {
+				executed[spec.FileName+":"+sce.Heading.Value]++
+			}
+		}
+	}
+
+	c.Assert(len(executed), Equals, 20)
+	for _, count := range executed {
+		c.Assert(count, Equals, 1)
+	}
+}
+
+func (s *MySuite) TestShardFilterIsNoOpForSingleShard(c *C) {
+	specs := createSpecsList(3)
+
+	c.Assert((&shardFilter{1, 1}).filter(specs), DeepEquals, specs)
+}
+
+func (s *MySuite) TestHeadingPatternFilterMatchesSpecAndScenarioHeadings(c *C) {
+	scn1 := &gauge.Scenario{Heading: &gauge.Heading{Value: "[API] Get user"}}
+	scn2 := &gauge.Scenario{Heading: &gauge.Heading{Value: "[UI] Login"}}
+	scn3 := &gauge.Scenario{Heading: &gauge.Heading{Value: "[API] Delete user"}}
+	spec1 := &gauge.Specification{Heading: &gauge.Heading{Value: "Users"}, Items: []gauge.Item{scn1, scn2}, Scenarios: []*gauge.Scenario{scn1, scn2}}
+	spec2 := &gauge.Specification{Heading: &gauge.Heading{Value: "Admin"}, Items: []gauge.Item{scn3}, Scenarios: []*gauge.Scenario{scn3}}
+
+	specs := (&headingPatternFilter{specPattern: "^Users$", scenarioPattern: `^\[API\]`}).filter([]*gauge.Specification{spec1, spec2})
+
+	c.Assert(len(specs), Equals, 1)
+	c.Assert(specs[0].Scenarios, DeepEquals, []*gauge.Scenario{scn1})
+}
+
+func (s *MySuite) TestHeadingPatternFilterIsNoOpWithoutPatterns(c *C) {
+	specs := createSpecsList(3)
+
+	c.Assert((&headingPatternFilter{}).filter(specs), DeepEquals, specs)
+}
+
+func (s *MySuite) TestValidatePatterns(c *C) {
+	c.Assert(ValidatePatterns(`^\[API\]`, ""), IsNil)
+	c.Assert(ValidatePatterns("", "(login"), NotNil)
+}
+
+func groupOf(groups []*gauge.SpecCollection) map[string]int {
+	g := make(map[string]int)
+	for i, group := range groups {
+		if group == nil {
+			continue
+		}
+		for _, spec := range group.Specs() {
+			g[spec.FileName] = i
+		}
+	}
+	return g
+}
+
+func (s *MySuite) TestDistributeSpecsDeterministicallyKeepsSpecsInTheirGroup(c *C) {
+	specs := createSpecsList(10)
+
+	all := groupOf(DistributeSpecsDeterministically(specs, 3))
+	some := groupOf(DistributeSpecsDeterministically([]*gauge.Specification{specs[7], specs[2], specs[5]}, 3))
+
+	c.Assert(len(all), Equals, 10)
+	for name, group := range some {
+		c.Assert(group, Equals, all[name])
+	}
+}
+
+func (s *MySuite) TestDistributeSpecsDeterministicallyWithoutGroups(c *C) {
+	c.Assert(DistributeSpecsDeterministically(createSpecsList(2), 0), HasLen, 0)
+}
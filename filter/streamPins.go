@@ -0,0 +1,91 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package filter
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/logger"
+)
+
+// StreamPins gives the parallel streams, numbered from 1, to which the specs having a tag are pinned.
+// The pins are configured as comma separated tag:stream pairs in the gauge_stream_pins property, e.g. db-migration:1.
+func StreamPins() map[string]int {
+	pins := make(map[string]int)
+	for _, pin := range strings.Split(os.Getenv(env.StreamPins), ",") {
+		if strings.TrimSpace(pin) == "" {
+			continue
+		}
+		i := strings.LastIndex(pin, ":")
+		if i == -1 {
+			logger.Warningf(true, "Ignoring invalid stream pin %s in %s. Pins should be given as tag:stream", strings.TrimSpace(pin), env.StreamPins)
+			continue
+		}
+		tag := strings.TrimPrefix(strings.TrimSpace(pin[:i]), "@")
+		stream, err := strconv.Atoi(strings.TrimSpace(pin[i+1:]))
+		if tag == "" || err != nil || stream < 1 {
+			logger.Warningf(true, "Ignoring invalid stream pin %s in %s. Pins should be given as tag:stream", strings.TrimSpace(pin), env.StreamPins)
+			continue
+		}
+		pins[tag] = stream
+	}
+	return pins
+}
+
+// PinSpecs separates the specs pinned to a stream from the rest. A spec is pinned if the spec or any of its
+// scenarios has a pinned tag, so that all the specs sharing a resource run one after the other on the same stream.
+// Pins to streams beyond the number of streams wrap around.
+func PinSpecs(specs []*gauge.Specification, pins map[string]int, streams int) (map[int][]*gauge.Specification, []*gauge.Specification) {
+	if len(pins) == 0 || streams < 1 {
+		return nil, specs
+	}
+	pinned := make(map[int][]*gauge.Specification)
+	var rest []*gauge.Specification
+	for _, spec := range specs {
+		stream, ok := pinnedStream(spec, pins)
+		if !ok {
+			rest = append(rest, spec)
+			continue
+		}
+		stream = (stream-1)%streams + 1
+		pinned[stream] = append(pinned[stream], spec)
+	}
+	return pinned, rest
+}
+
+func pinnedStream(spec *gauge.Specification, pins map[string]int) (int, bool) {
+	tags := []*gauge.Tags{spec.Tags}
+	for _, scenario := range spec.Scenarios {
+		tags = append(tags, scenario.Tags)
+	}
+	for _, t := range tags {
+		if t == nil {
+			continue
+		}
+		for _, tag := range t.Values() {
+			if stream, ok := pins[strings.TrimSpace(tag)]; ok {
+				return stream, true
+			}
+		}
+	}
+	return 0, false
+}
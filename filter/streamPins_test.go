@@ -0,0 +1,57 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package filter
+
+import (
+	"os"
+
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/gauge"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestStreamPins(c *C) {
+	os.Setenv(env.StreamPins, "@db-migration:1, ui : 2, invalid, bad:x, zero:0")
+	defer os.Unsetenv(env.StreamPins)
+
+	c.Assert(StreamPins(), DeepEquals, map[string]int{"db-migration": 1, "ui": 2})
+}
+
+func (s *MySuite) TestPinSpecsByScenarioAndSpecTags(c *C) {
+	migration := &gauge.Specification{FileName: "migration.spec", Tags: &gauge.Tags{RawValues: [][]string{{"db-migration"}}}}
+	ui := &gauge.Specification{FileName: "ui.spec", Scenarios: []*gauge.Scenario{{Tags: &gauge.Tags{RawValues: [][]string{{"ui"}}}}}}
+	other := &gauge.Specification{FileName: "other.spec"}
+
+	pinned, rest := PinSpecs([]*gauge.Specification{migration, ui, other}, map[string]int{"db-migration": 1, "ui": 3}, 2)
+
+	c.Assert(pinned, DeepEquals, map[int][]*gauge.Specification{1: {migration, ui}})
+	c.Assert(rest, DeepEquals, []*gauge.Specification{other})
+}
+
+func (s *MySuite) TestDistributeSpecsPutsPinnedSpecsInTheirStream(c *C) {
+	os.Setenv(env.StreamPins, "db-migration:2")
+	defer os.Unsetenv(env.StreamPins)
+	specs := createSpecsList(4)
+	specs[0].Tags = &gauge.Tags{RawValues: [][]string{{"db-migration"}}}
+	specs[3].Tags = &gauge.Tags{RawValues: [][]string{{"db-migration"}}}
+
+	groups := DistributeSpecs(specs, 2)
+
+	c.Assert(groups[0].Specs(), DeepEquals, []*gauge.Specification{specs[1]})
+	c.Assert(groups[1].Specs(), DeepEquals, []*gauge.Specification{specs[0], specs[3], specs[2]})
+}
@@ -0,0 +1,112 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package formatter
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/util"
+)
+
+// Export copies the specs in the given dirs and all the concept files of the project into outDir, keeping their
+// paths relative to the project root. When a redactor is given, the files are parsed, redacted and formatted
+// instead of being copied. Files which fail to parse are skipped and reported in the error.
+// It returns the exported files relative to outDir.
+func Export(specDirs []string, outDir string, redactor *Redactor) ([]string, error) {
+	specFiles := util.GetSpecFiles(specDirs)
+	conceptFiles := util.GetConceptFiles()
+	if redactor == nil {
+		return copyFiles(append(specFiles, conceptFiles...), outDir)
+	}
+	dictionary, result, err := parser.CreateConceptsDictionary()
+	if err != nil {
+		return nil, err
+	}
+	if !result.Ok {
+		return nil, fmt.Errorf("Failed to parse concepts.\n%s", strings.Join(result.Errors(), "\n"))
+	}
+	contents := make(map[string]string)
+	var skipped []string
+	specs, results := parser.ParseSpecFiles(specFiles, dictionary, gauge.NewBuildErrors())
+	for _, res := range results {
+		if !res.Ok {
+			skipped = append(skipped, res.Errors()...)
+		}
+	}
+	resultsMap := getParseResult(results)
+	for _, spec := range specs {
+		if res := resultsMap[spec.FileName]; res != nil && !res.Ok {
+			continue
+		}
+		redactor.RedactSpec(spec)
+		contents[spec.FileName] = FormatSpecification(spec)
+	}
+	redactor.RedactConcepts(dictionary)
+	for file, text := range FormatConcepts(dictionary) {
+		contents[file] = text
+	}
+	exported, err := writeFiles(contents, outDir)
+	if err != nil {
+		return exported, err
+	}
+	if len(skipped) > 0 {
+		return exported, fmt.Errorf("Skipped specs with parse errors:\n%s", strings.Join(skipped, "\n"))
+	}
+	return exported, nil
+}
+
+func copyFiles(files []string, outDir string) ([]string, error) {
+	contents := make(map[string]string)
+	for _, file := range files {
+		text, err := common.ReadFileContents(file)
+		if err != nil {
+			return nil, err
+		}
+		contents[file] = text
+	}
+	return writeFiles(contents, outDir)
+}
+
+func writeFiles(contents map[string]string, outDir string) ([]string, error) {
+	var written []string
+	for file, text := range contents {
+		rel, err := filepath.Rel(config.ProjectRoot, file)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			rel = filepath.Base(file)
+		}
+		dest := filepath.Join(outDir, rel)
+		if err := os.MkdirAll(filepath.Dir(dest), common.NewDirectoryPermissions); err != nil {
+			return written, err
+		}
+		if err := ioutil.WriteFile(dest, []byte(text), common.NewFilePermissions); err != nil {
+			return written, fmt.Errorf("Failed to write to '%s': %s", dest, err.Error())
+		}
+		written = append(written, rel)
+	}
+	sort.Strings(written)
+	return written, nil
+}
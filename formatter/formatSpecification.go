@@ -51,6 +51,16 @@ func (formatter *formatter) Tags(tags *gauge.Tags) {
 	}
 }
 
+func (formatter *formatter) Meta(meta *gauge.Meta) {
+	if !strings.HasSuffix(formatter.buffer.String(), "\n\n") {
+		formatter.buffer.WriteString("\n")
+	}
+	formatter.buffer.WriteString(FormatMeta(meta))
+	if formatter.itemQueue.Peek() != nil && (formatter.itemQueue.Peek().Kind() != gauge.CommentKind || strings.TrimSpace(formatter.itemQueue.Peek().(*gauge.Comment).Value) != "") {
+		formatter.buffer.WriteString("\n")
+	}
+}
+
 func (formatter *formatter) Table(table *gauge.Table) {
 	formatter.buffer.WriteString(strings.TrimPrefix(FormatTable(table), "\n"))
 }
@@ -23,6 +23,7 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/getgauge/common"
 	"github.com/getgauge/gauge/gauge"
@@ -102,7 +103,7 @@ func FormatTable(table *gauge.Table) string {
 	for i, header := range table.Headers {
 		//table.get(header) returns a list of cells in that particular column
 		cells, _ := table.Get(header)
-		columnToWidthMap[i] = findLongestCellWidth(cells, len(header))
+		columnToWidthMap[i] = findLongestCellWidth(cells, cellWidth(header))
 	}
 
 	var tableStringBuffer bytes.Buffer
@@ -137,14 +138,14 @@ func FormatTable(table *gauge.Table) string {
 }
 
 func addPaddingToCell(cellValue string, width int) string {
-	padding := getRepeatedChars(" ", width-len(cellValue))
+	padding := getRepeatedChars(" ", width-cellWidth(cellValue))
 	return fmt.Sprintf("%s%s", cellValue, padding)
 }
 
 func findLongestCellWidth(columnCells []gauge.TableCell, minValue int) int {
 	longestLength := minValue
 	for _, cellValue := range columnCells {
-		cellValueLen := len(cellValue.GetValue())
+		cellValueLen := cellWidth(cellValue.GetValue())
 		if cellValueLen > longestLength {
 			longestLength = cellValueLen
 		}
@@ -164,7 +165,8 @@ func FormatTags(tags *gauge.Tags) string {
 		return ""
 	}
 	var b bytes.Buffer
-	b.WriteString("tags: ")
+	keyword := parser.Keyword(parser.TagsKeyword) + ": "
+	b.WriteString(keyword)
 	for i, tag := range tags.RawValues {
 		for j, tagString := range tag {
 			b.WriteString(tagString)
@@ -174,18 +176,26 @@ func FormatTags(tags *gauge.Tags) string {
 		}
 		b.WriteString("\n")
 		if i != len(tags.RawValues)-1 {
-			b.WriteString("      ")
+			b.WriteString(strings.Repeat(" ", utf8.RuneCountInString(keyword)))
 		}
 	}
 	return string(b.Bytes())
 }
 
+// FormatMeta gives the metadata as key=value pairs on a single line.
+func FormatMeta(meta *gauge.Meta) string {
+	if meta == nil || len(meta.Keys) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s: %s\n", parser.Keyword(parser.MetaKeyword), strings.Join(meta.Pairs(), ", "))
+}
+
 func formatExternalDataTable(dataTable *gauge.DataTable) string {
 	if dataTable == nil || len(dataTable.Value) == 0 {
 		return ""
 	}
 	var b bytes.Buffer
-	b.WriteString(dataTable.Value)
+	b.WriteString(parser.Keyword(parser.TableKeyword) + strings.TrimPrefix(dataTable.Value, parser.TableKeyword))
 	b.WriteString("\n")
 	return string(b.Bytes())
 }
@@ -198,6 +208,15 @@ func formatAndSave(spec *gauge.Specification) error {
 	return nil
 }
 
+// IsFormatted tells whether the file of the given spec already has the content gauge format would write to it.
+func IsFormatted(spec *gauge.Specification) (bool, error) {
+	content, err := common.ReadFileContents(spec.FileName)
+	if err != nil {
+		return false, err
+	}
+	return content == FormatSpecification(spec), nil
+}
+
 func FormatSpecification(specification *gauge.Specification) string {
 	var formattedSpec bytes.Buffer
 	queue := &gauge.ItemQueue{Items: specification.AllItems()}
@@ -18,8 +18,12 @@
 package formatter
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/getgauge/gauge/config"
 	"github.com/getgauge/gauge/env"
 
 	"github.com/getgauge/gauge/gauge"
@@ -80,6 +84,49 @@ func (s *MySuite) TestFormatTable(c *C) {
 	c.Assert(got, Equals, want)
 }
 
+func (s *MySuite) TestFormatTableWithWideCharacters(c *C) {
+	headers := []string{"名前", "city"}
+	cols := [][]gauge.TableCell{{{Value: "山田太郎", CellType: gauge.Static}, {Value: "john", CellType: gauge.Static}}, {{Value: "東京", CellType: gauge.Static}, {Value: "🍣 bar", CellType: gauge.Static}}}
+	table := gauge.NewTable(headers, cols, 10)
+
+	got := FormatTable(table)
+	want := `
+   |名前    |city  |
+   |--------|------|
+   |山田太郎|東京  |
+   |john    |🍣 bar|
+`
+
+	c.Assert(got, Equals, want)
+}
+
+func (s *MySuite) TestFormatTableAlignedByRuneCount(c *C) {
+	os.Setenv(env.TableAlignment, "rune_count")
+	defer os.Unsetenv(env.TableAlignment)
+	headers := []string{"名前", "city"}
+	cols := [][]gauge.TableCell{{{Value: "山田太郎", CellType: gauge.Static}}, {{Value: "café", CellType: gauge.Static}}}
+	table := gauge.NewTable(headers, cols, 10)
+
+	got := FormatTable(table)
+	want := `
+   |名前  |city|
+   |----|----|
+   |山田太郎|café|
+`
+
+	c.Assert(got, Equals, want)
+}
+
+func (s *MySuite) TestCellWidth(c *C) {
+	c.Assert(cellWidth("abc"), Equals, 3)
+	c.Assert(cellWidth("café"), Equals, 4)
+	c.Assert(cellWidth("cafe\u0301"), Equals, 4)
+	c.Assert(cellWidth("日本語"), Equals, 6)
+	c.Assert(cellWidth("ｶﾀｶﾅ"), Equals, 4)
+	c.Assert(cellWidth("👍"), Equals, 2)
+	c.Assert(cellWidth("❤\ufe0f"), Equals, 1)
+}
+
 func (s *MySuite) TestFormatConcepts(c *C) {
 	dictionary := gauge.NewConceptDictionary()
 	step1 := &gauge.Step{Value: "sdsf", LineText: "sdsf", IsConcept: true, LineNo: 1, PreComments: []*gauge.Comment{&gauge.Comment{Value: "COMMENT", LineNo: 1}}}
@@ -129,6 +176,33 @@ tags: tag3, tag4
 
 }
 
+func (s *MySuite) TestFormatSpecificationWithMeta(c *C) {
+	spec, _, _ := new(parser.SpecParser).Parse(`# My Spec Heading
+tags: tag1
+meta: owner = team-payments,severity=critical
+## Scenario Heading
+meta: severity=low
+* Example step
+`, gauge.NewConceptDictionary(), "")
+	formatted := FormatSpecification(spec)
+	c.Assert(formatted, Equals,
+		`# My Spec Heading
+
+tags: tag1
+
+meta: owner=team-payments, severity=critical
+
+## Scenario Heading
+
+meta: severity=low
+
+* Example step
+`)
+
+	spec, _, _ = new(parser.SpecParser).Parse(formatted, gauge.NewConceptDictionary(), "")
+	c.Assert(FormatSpecification(spec), Equals, formatted)
+}
+
 func (s *MySuite) TestFormatSpecificationWithTagsInMutipleLines(c *C) {
 	tokens := []*parser.Token{
 		&parser.Token{Kind: gauge.SpecKind, Value: "My Spec Heading", LineNo: 1},
@@ -446,3 +520,157 @@ func (s *MySuite) TestFormatShouldNotAddExtraNewLinesBeforeDataTable(c *C) {
    |Rhythm|0          |
 `)
 }
+
+func (s *MySuite) TestFormatSpecificationWithLocalizedKeywords(c *C) {
+	defer func(l func() string) { env.SpecLanguage = l }(env.SpecLanguage)
+	env.SpecLanguage = func() string { return "es" }
+	tokens := []*parser.Token{
+		&parser.Token{Kind: gauge.SpecKind, Value: "Spec Heading", LineNo: 1},
+		&parser.Token{Kind: gauge.TagKind, Args: []string{"tag1", "tag2"}, LineNo: 2},
+		&parser.Token{Kind: gauge.TagKind, Args: []string{"tag3"}, LineNo: 3},
+		&parser.Token{Kind: gauge.ScenarioKind, Value: "Scenario Heading", LineNo: 4},
+		&parser.Token{Kind: gauge.StepKind, Value: "Example step", LineNo: 5, LineText: "Example step"},
+	}
+
+	spec, _, _ := new(parser.SpecParser).CreateSpecification(tokens, gauge.NewConceptDictionary(), "")
+	formatted := FormatSpecification(spec)
+
+	c.Assert(formatted, Equals,
+		`# Spec Heading
+
+etiquetas: tag1, tag2,`+" \n           "+`tag3
+
+## Scenario Heading
+* Example step
+`)
+	c.Assert(formatExternalDataTable(&gauge.DataTable{Value: "table: data.csv", IsExternal: true}), Equals, "tabla: data.csv\n")
+}
+
+func (s *MySuite) TestImportAndExportTable(c *C) {
+	dir, err := ioutil.TempDir("", "gauge-table")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+	defer func(root string) { config.ProjectRoot = root }(config.ProjectRoot)
+	config.ProjectRoot = dir
+	specFile := filepath.Join(dir, "example.spec")
+	csvFile := filepath.Join(dir, "data.csv")
+	ioutil.WriteFile(specFile, []byte("# Spec\n\ntags: a\n\n* context step\n\n## Scenario\n\n* step\n"), 0644)
+	ioutil.WriteFile(csvFile, []byte("name,count\njohn,12\n"), 0644)
+
+	c.Assert(ImportTable(csvFile, specFile, ""), IsNil)
+	b, _ := ioutil.ReadFile(specFile)
+	c.Assert(string(b), Equals, "# Spec\n\ntags: a\n\n   |name|count|\n   |----|-----|\n   |john|12   |\n\n* context step\n\n## Scenario\n\n* step\n")
+
+	exported := filepath.Join(dir, "exported.csv")
+	c.Assert(ExportTable(specFile, exported, ""), IsNil)
+	b, _ = ioutil.ReadFile(exported)
+	c.Assert(string(b), Equals, "name,count\njohn,12\n")
+	b, _ = ioutil.ReadFile(specFile)
+	c.Assert(string(b), Equals, "# Spec\n\ntags: a\n\ntable: exported.csv\n\n* context step\n\n## Scenario\n\n* step\n")
+}
+
+func (s *MySuite) TestImportTableIntoScenarioNeedsScenarioDataTables(c *C) {
+	defer func(f func() bool) { env.AllowScenarioDatatable = f }(env.AllowScenarioDatatable)
+	env.AllowScenarioDatatable = func() bool { return false }
+
+	err := ImportTable("data.csv", "example.spec", "Scenario")
+
+	c.Assert(err, NotNil)
+}
+
+func (s *MySuite) TestExportTableWithoutInlineTable(c *C) {
+	dir, err := ioutil.TempDir("", "gauge-table")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+	specFile := filepath.Join(dir, "example.spec")
+	ioutil.WriteFile(specFile, []byte("# Spec\n\n## Scenario\n\n* step\n"), 0644)
+
+	err = ExportTable(specFile, filepath.Join(dir, "data.csv"), "")
+
+	c.Assert(err, ErrorMatches, "No inline data table found in .*")
+}
+
+func (s *MySuite) TestRedactSpec(c *C) {
+	text := `# Spec
+
+   |name|card         |
+   |----|-------------|
+   |john|4111111111111|
+
+## Public scenario
+
+* Pay with card <card> as "john"
+* Check the payments
+   |id|amount|
+   |--|------|
+   |1 |<card>|
+
+## Secret scenario
+
+tags: sensitive
+
+* Log in as "john" with password "secret"
+`
+	spec, result, _ := new(parser.SpecParser).Parse(text, gauge.NewConceptDictionary(), "example.spec")
+	c.Assert(result.Ok, Equals, true)
+
+	NewRedactor([]string{"Sensitive"}).RedactSpec(spec)
+
+	c.Assert(FormatSpecification(spec), Equals, `# Spec
+
+   |name  |card  |
+   |------|------|
+   |name_1|card_1|
+
+## Public scenario
+
+* Pay with card <card> as "john"
+* Check the payments`+" "+`
+
+   |id  |amount|
+   |----|------|
+   |id_1|<card>|
+
+## Secret scenario
+
+tags: sensitive
+
+* Log in as "redacted-1" with password "redacted-2"
+`)
+}
+
+func (s *MySuite) TestRedactExternalTableAndFileParams(c *C) {
+	r := NewRedactor(nil)
+	dataTable := &gauge.DataTable{Value: "table: customers.csv", IsExternal: true}
+	step := &gauge.Step{Args: []*gauge.StepArg{{Name: "file:keys/id_rsa.pub", ArgType: gauge.SpecialString}, {Value: "john", ArgType: gauge.Static}}}
+
+	r.redactItems([]gauge.Item{dataTable, step}, false)
+
+	c.Assert(dataTable.Value, Equals, "table: redacted-1.csv")
+	c.Assert(step.Args[0].Name, Equals, "file:redacted-2.pub")
+	c.Assert(step.Args[1].Value, Equals, "john")
+}
+
+func (s *MySuite) TestExportRedactsSpecsAndConcepts(c *C) {
+	dir, err := ioutil.TempDir("", "gauge-export")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+	defer func(root string) { config.ProjectRoot = root }(config.ProjectRoot)
+	config.ProjectRoot = dir
+	os.MkdirAll(filepath.Join(dir, "specs"), 0755)
+	specFile := filepath.Join(dir, "specs", "example.spec")
+	ioutil.WriteFile(specFile, []byte("# Spec\n\n## Scenario\n\ntags: sensitive\n\n* Log in as \"john\"\n"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "specs", "login.cpt"), []byte("# Log in as <user>\n\n* Enter user <user>\n* Enter\n\n   |key  |\n   |-----|\n   |pass1|\n"), 0644)
+	outDir := filepath.Join(dir, "out")
+
+	exported, err := Export([]string{filepath.Join(dir, "specs")}, outDir, NewRedactor([]string{"sensitive"}))
+
+	c.Assert(err, IsNil)
+	c.Assert(exported, DeepEquals, []string{filepath.Join("specs", "example.spec"), filepath.Join("specs", "login.cpt")})
+	b, _ := ioutil.ReadFile(filepath.Join(outDir, "specs", "example.spec"))
+	c.Assert(string(b), Equals, "# Spec\n\n## Scenario\n\ntags: sensitive\n\n* Log in as \"redacted-1\"\n")
+	b, _ = ioutil.ReadFile(filepath.Join(outDir, "specs", "login.cpt"))
+	c.Assert(string(b), Equals, "# Log in as <user>\n\n* Enter user <user>\n* Enter \n\n   |key  |\n   |-----|\n   |key_1|\n")
+	b, _ = ioutil.ReadFile(specFile)
+	c.Assert(string(b), Equals, "# Spec\n\n## Scenario\n\ntags: sensitive\n\n* Log in as \"john\"\n")
+}
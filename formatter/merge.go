@@ -0,0 +1,462 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+)
+
+// Conflict is a part of the specs being merged which is not the same in all of them, like their contexts or the
+// steps of scenarios with the same heading. Each distinct version is listed once, in the order of the specs.
+type Conflict struct {
+	Subject  string
+	Versions []*Version
+}
+
+// Version is the text of a part of the specs, along with the specs which have it.
+type Version struct {
+	Files []string
+	Text  string
+	specs []int
+	value interface{}
+}
+
+// Resolver chooses the version of a conflict to keep in the merged spec, as an index of its versions.
+type Resolver func(conflict *Conflict) (int, error)
+
+// PreferFirst resolves every conflict with the version of the first spec having the part.
+func PreferFirst(conflict *Conflict) (int, error) {
+	return 0, nil
+}
+
+// PreferLast resolves every conflict with the version of the last spec having the part.
+func PreferLast(conflict *Conflict) (int, error) {
+	last, lastSpec := 0, -1
+	for i, v := range conflict.Versions {
+		if s := v.specs[len(v.specs)-1]; s > lastSpec {
+			last, lastSpec = i, s
+		}
+	}
+	return last, nil
+}
+
+// MergeSpecs combines the scenarios of the spec files into a single spec, which is written to the into file.
+// The merged spec has the heading of the first spec, all the tags, metadata and comments of the specs, and
+// their scenarios in order. Scenarios which are the same in several specs are added once. Contexts, teardown
+// steps, data tables, metadata values and scenarios with the same heading which differ are handed to resolve.
+func MergeSpecs(specFiles []string, into string, resolve Resolver) error {
+	var specs []*gauge.Specification
+	for _, file := range specFiles {
+		spec, err := parseSpecFile(file)
+		if err != nil {
+			return err
+		}
+		specs = append(specs, spec)
+	}
+	merged, err := mergeSpecs(specs, resolve)
+	if err != nil {
+		return err
+	}
+	merged.FileName = into
+	contents := FormatSpecification(merged)
+	if _, res, err := new(parser.SpecParser).Parse(contents, &gauge.ConceptDictionary{}, into); err != nil || !res.Ok {
+		if err == nil {
+			err = fmt.Errorf("%s", strings.Join(res.Errors(), "\n"))
+		}
+		return fmt.Errorf("The merged spec is not valid, so %s is not written.\n%s", into, err.Error())
+	}
+	return common.SaveFile(into, contents, common.FileExists(into))
+}
+
+// specSections splits the items of a spec into those before the scenarios, the scenarios and the teardown.
+type specSections struct {
+	comments  []gauge.Item
+	tags      *gauge.Tags
+	meta      *gauge.Meta
+	dataTable *gauge.DataTable
+	contexts  []gauge.Item
+	scenarios []*gauge.Scenario
+	teardown  []gauge.Item
+}
+
+func sectionsOf(spec *gauge.Specification) *specSections {
+	s := &specSections{}
+	inTeardown := false
+	for _, item := range spec.Items {
+		switch {
+		case inTeardown:
+			s.teardown = append(s.teardown, item)
+		case item.Kind() == gauge.TearDownKind:
+			inTeardown = true
+			s.teardown = append(s.teardown, item)
+		case item.Kind() == gauge.ScenarioKind:
+			s.scenarios = append(s.scenarios, item.(*gauge.Scenario))
+		case len(s.contexts) > 0 || item.Kind() == gauge.StepKind:
+			s.contexts = append(s.contexts, item)
+		case item.Kind() == gauge.CommentKind:
+			s.comments = append(s.comments, item)
+		case item.Kind() == gauge.TagKind:
+			s.tags = item.(*gauge.Tags)
+		case item.Kind() == gauge.MetaKind:
+			s.meta = item.(*gauge.Meta)
+		case item.Kind() == gauge.DataTableKind:
+			s.dataTable = item.(*gauge.DataTable)
+		}
+	}
+	s.contexts = trimBlankComments(s.contexts)
+	s.teardown = trimBlankComments(s.teardown)
+	return s
+}
+
+func mergeSpecs(specs []*gauge.Specification, resolve Resolver) (*gauge.Specification, error) {
+	var sections []*specSections
+	for _, spec := range specs {
+		sections = append(sections, sectionsOf(spec))
+	}
+	merger := &specMerger{specs: specs, resolve: resolve}
+	dataTable, err := merger.dataTable(sections)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := merger.meta(sections)
+	if err != nil {
+		return nil, err
+	}
+	contexts, err := merger.steps("contexts", sections, func(s *specSections) []gauge.Item { return s.contexts })
+	if err != nil {
+		return nil, err
+	}
+	scenarios, err := merger.scenarios(sections)
+	if err != nil {
+		return nil, err
+	}
+	teardown, err := merger.steps("teardown steps", sections, func(s *specSections) []gauge.Item { return s.teardown })
+	if err != nil {
+		return nil, err
+	}
+
+	blank := &gauge.Comment{Value: "\n"}
+	items := []gauge.Item{blank}
+	if comments := mergeComments(sections); len(comments) > 0 {
+		items = append(append(items, comments...), blank)
+	}
+	if tags := mergeTags(sections); tags != nil {
+		items = append(items, tags)
+	}
+	if meta != nil {
+		items = append(items, meta)
+	}
+	if dataTable != nil {
+		items = append(items, dataTable, blank)
+	}
+	items = append(items, endingWithBlankLine(contexts, true)...)
+	for i, scenario := range scenarios {
+		s := *scenario
+		s.Items = endingWithBlankLine(scenario.Items, i < len(scenarios)-1 || len(teardown) > 0)
+		items = append(items, &s)
+	}
+	items = append(items, endingWithBlankLine(teardown, false)...)
+	return &gauge.Specification{Heading: specs[0].Heading, Items: items}, nil
+}
+
+type specMerger struct {
+	specs   []*gauge.Specification
+	resolve Resolver
+}
+
+// choose gives the value of the only version, or that of the version chosen by resolve when there are several.
+func (m *specMerger) choose(subject string, versions []*Version) (interface{}, error) {
+	if len(versions) == 1 {
+		return versions[0].value, nil
+	}
+	for _, v := range versions {
+		for _, s := range v.specs {
+			v.Files = append(v.Files, m.specs[s].FileName)
+		}
+	}
+	i, err := m.resolve(&Conflict{Subject: subject, Versions: versions})
+	if err != nil {
+		return nil, err
+	}
+	if i < 0 || i >= len(versions) {
+		return nil, fmt.Errorf("Invalid choice %d for the %s", i+1, subject)
+	}
+	return versions[i].value, nil
+}
+
+// addVersion adds the spec to the version having the text, or a new version if there is none.
+func addVersion(versions []*Version, spec int, text string, value interface{}) []*Version {
+	for _, v := range versions {
+		if v.Text == text {
+			v.specs = append(v.specs, spec)
+			return versions
+		}
+	}
+	return append(versions, &Version{Text: text, specs: []int{spec}, value: value})
+}
+
+func (m *specMerger) steps(subject string, sections []*specSections, steps func(*specSections) []gauge.Item) ([]gauge.Item, error) {
+	var versions []*Version
+	for i, s := range sections {
+		versions = addVersion(versions, i, itemsText(steps(s)), steps(s))
+	}
+	chosen, err := m.choose(subject, versions)
+	if err != nil {
+		return nil, err
+	}
+	return chosen.([]gauge.Item), nil
+}
+
+// dataTable gives the data table of the specs. Inline tables with the same headers are combined into one
+// having the distinct rows of all of them.
+func (m *specMerger) dataTable(sections []*specSections) (*gauge.DataTable, error) {
+	var versions []*Version
+	for i, s := range sections {
+		if v := combinableWith(versions, s.dataTable); v != nil {
+			v.specs = append(v.specs, i)
+			v.value = combineTables(v.value.(*gauge.DataTable), s.dataTable)
+			v.Text = dataTableText(v.value.(*gauge.DataTable))
+			continue
+		}
+		versions = addVersion(versions, i, dataTableText(s.dataTable), s.dataTable)
+	}
+	chosen, err := m.choose("data table", versions)
+	if err != nil {
+		return nil, err
+	}
+	return chosen.(*gauge.DataTable), nil
+}
+
+func combinableWith(versions []*Version, dataTable *gauge.DataTable) *Version {
+	if dataTable == nil || dataTable.IsExternal {
+		return nil
+	}
+	for _, v := range versions {
+		if t, ok := v.value.(*gauge.DataTable); ok && t != nil && !t.IsExternal && sameHeaders(t.Table.Headers, dataTable.Table.Headers) {
+			return v
+		}
+	}
+	return nil
+}
+
+func sameHeaders(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func combineTables(a, b *gauge.DataTable) *gauge.DataTable {
+	table := &gauge.Table{}
+	table.AddHeaders(a.Table.Headers)
+	seen := make(map[string]bool)
+	for _, t := range []*gauge.DataTable{a, b} {
+		for _, row := range t.Table.Rows() {
+			key := strings.Join(row, "\x00")
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			table.AddRowValues(table.CreateTableCells(row))
+		}
+	}
+	table.LineNo = a.Table.LineNo
+	return &gauge.DataTable{Table: *table}
+}
+
+// meta gives the metadata of all the specs. Keys having different values in the specs are handed to resolve.
+func (m *specMerger) meta(sections []*specSections) (*gauge.Meta, error) {
+	merged := &gauge.Meta{Values: make(map[string]string)}
+	versions := make(map[string][]*Version)
+	for i, s := range sections {
+		if s.meta == nil {
+			continue
+		}
+		for _, k := range s.meta.Keys {
+			if _, ok := merged.Values[k]; !ok {
+				merged.Add(k, s.meta.Values[k])
+			}
+			versions[k] = addVersion(versions[k], i, s.meta.Values[k], s.meta.Values[k])
+		}
+	}
+	if len(merged.Keys) == 0 {
+		return nil, nil
+	}
+	for _, k := range merged.Keys {
+		chosen, err := m.choose(fmt.Sprintf("metadata %s", k), versions[k])
+		if err != nil {
+			return nil, err
+		}
+		merged.Values[k] = chosen.(string)
+	}
+	return merged, nil
+}
+
+// scenarios gives the scenarios of the specs in order. Scenarios with the same heading which differ are handed
+// to resolve, and the chosen one takes the place of the first of them.
+func (m *specMerger) scenarios(sections []*specSections) ([]*gauge.Scenario, error) {
+	var headings []string
+	versions := make(map[string][]*Version)
+	for i, s := range sections {
+		for _, scenario := range s.scenarios {
+			heading := strings.TrimSpace(scenario.Heading.Value)
+			if _, ok := versions[heading]; !ok {
+				headings = append(headings, heading)
+			}
+			versions[heading] = addVersion(versions[heading], i, scenarioText(scenario), scenario)
+		}
+	}
+	var scenarios []*gauge.Scenario
+	for _, heading := range headings {
+		chosen, err := m.choose(fmt.Sprintf("scenario '%s'", heading), versions[heading])
+		if err != nil {
+			return nil, err
+		}
+		scenarios = append(scenarios, chosen.(*gauge.Scenario))
+	}
+	return scenarios, nil
+}
+
+// mergeComments gives the comments of the first spec followed by those of the others which are not already there.
+func mergeComments(sections []*specSections) []gauge.Item {
+	var comments []gauge.Item
+	seen := make(map[string]bool)
+	for _, s := range sections {
+		var added []gauge.Item
+		for _, item := range trimBlankComments(s.comments) {
+			value := item.(*gauge.Comment).Value
+			if value != "\n" && seen[strings.TrimSpace(value)] {
+				continue
+			}
+			seen[strings.TrimSpace(value)] = true
+			added = append(added, item)
+		}
+		if added = trimBlankComments(added); len(added) == 0 {
+			continue
+		}
+		if len(comments) > 0 {
+			comments = append(comments, &gauge.Comment{Value: "\n"})
+		}
+		comments = append(comments, added...)
+	}
+	return comments
+}
+
+// mergeTags gives the tags of the first spec having any, followed by the tags of the others which are not already there.
+func mergeTags(sections []*specSections) *gauge.Tags {
+	var merged *gauge.Tags
+	seen := make(map[string]bool)
+	var others []string
+	for _, s := range sections {
+		if s.tags == nil {
+			continue
+		}
+		if merged == nil {
+			merged = &gauge.Tags{RawValues: append([][]string{}, s.tags.RawValues...), LineNo: s.tags.LineNo}
+			for _, t := range s.tags.Values() {
+				seen[t] = true
+			}
+			continue
+		}
+		for _, t := range s.tags.Values() {
+			if !seen[t] {
+				seen[t] = true
+				others = append(others, t)
+			}
+		}
+	}
+	if len(others) > 0 {
+		last := len(merged.RawValues) - 1
+		merged.RawValues[last] = append(append([]string{}, merged.RawValues[last]...), others...)
+	}
+	return merged
+}
+
+func trimBlankComments(items []gauge.Item) []gauge.Item {
+	isBlank := func(item gauge.Item) bool {
+		return item.Kind() == gauge.CommentKind && strings.TrimSpace(item.(*gauge.Comment).Value) == ""
+	}
+	for len(items) > 0 && isBlank(items[0]) {
+		items = items[1:]
+	}
+	for len(items) > 0 && isBlank(items[len(items)-1]) {
+		items = items[:len(items)-1]
+	}
+	return items
+}
+
+// endingWithBlankLine gives the items with a single blank line after them, or none, whichever spec they came from.
+// The blank line after the last step is dropped from it, as the parser keeps it with the step.
+func endingWithBlankLine(items []gauge.Item, blank bool) []gauge.Item {
+	items = append([]gauge.Item{}, trimBlankComments(items)...)
+	if len(items) == 0 {
+		return items
+	}
+	if step, ok := items[len(items)-1].(*gauge.Step); ok {
+		s := *step
+		s.Suffix = ""
+		items[len(items)-1] = &s
+	}
+	if blank {
+		items = append(items, &gauge.Comment{Value: "\n"})
+	}
+	return items
+}
+
+// itemsText gives the formatted items without blank lines, to tell whether the items of specs differ.
+func itemsText(items []gauge.Item) string {
+	var b strings.Builder
+	for _, item := range items {
+		if item.Kind() == gauge.MetaKind {
+			b.WriteString(FormatMeta(item.(*gauge.Meta)))
+			continue
+		}
+		b.WriteString(formatItem(item))
+	}
+	var lines []string
+	for _, line := range strings.Split(b.String(), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func scenarioText(scenario *gauge.Scenario) string {
+	return FormatHeading(scenario.Heading.Value, "##") + itemsText(trimBlankComments(scenario.Items))
+}
+
+func dataTableText(dataTable *gauge.DataTable) string {
+	if dataTable == nil {
+		return ""
+	}
+	if dataTable.IsExternal {
+		return strings.TrimSpace(formatExternalDataTable(dataTable))
+	}
+	return strings.TrimSpace(FormatTable(&dataTable.Table))
+}
@@ -0,0 +1,155 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package formatter
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+func writeSpecs(c *C, specs ...string) (string, []string) {
+	dir, err := ioutil.TempDir("", "gauge-merge")
+	c.Assert(err, IsNil)
+	var files []string
+	for i, spec := range specs {
+		file := filepath.Join(dir, fmt.Sprintf("%d.spec", i+1))
+		c.Assert(ioutil.WriteFile(file, []byte(spec), 0644), IsNil)
+		files = append(files, file)
+	}
+	return dir, files
+}
+
+func (s *MySuite) TestMergeSpecs(c *C) {
+	dir, files := writeSpecs(c,
+		"# Login\n\nLogging in.\n\ntags: login, smoke\n\n   |user |\n   |-----|\n   |alice|\n\n* Open the app\n\n## Valid login\n* Login as <user>\n\n## Logout\n* Logout\n",
+		"# Sign in\n\nSigning in.\n\ntags: smoke, signin\n\n   |user|\n   |----|\n   |bob |\n\n* Open the app\n## Valid login\n\n* Login as <user>\n\n## Remember me\n* Login as <user> and remember\n",
+	)
+	defer os.RemoveAll(dir)
+	into := filepath.Join(dir, "merged.spec")
+
+	err := MergeSpecs(files, into, func(conflict *Conflict) (int, error) {
+		return 0, fmt.Errorf("Unexpected conflict in %s", conflict.Subject)
+	})
+
+	c.Assert(err, IsNil)
+	b, _ := ioutil.ReadFile(into)
+	c.Assert(string(b), Equals, `# Login
+
+Logging in.
+
+Signing in.
+
+tags: login, smoke, signin
+
+   |user |
+   |-----|
+   |alice|
+   |bob  |
+
+* Open the app
+
+## Valid login
+* Login as <user>
+
+## Logout
+* Logout
+
+## Remember me
+* Login as <user> and remember
+`)
+}
+
+func (s *MySuite) TestMergeSpecsResolvesConflicts(c *C) {
+	dir, files := writeSpecs(c,
+		"# Login\n\nmeta: owner=auth\n\n* Open the app\n\n## Valid login\n* Login\n\n## Logout\n* Logout\n\n____\n* Close the app\n",
+		"# Sign in\n\nmeta: owner=identity, tier=1\n\n* Open the app in a new window\n\n## Valid login\n* Sign in\n\n____\n* Close the app\n",
+	)
+	defer os.RemoveAll(dir)
+	into := filepath.Join(dir, "merged.spec")
+	var subjects []string
+
+	err := MergeSpecs(files, into, func(conflict *Conflict) (int, error) {
+		subjects = append(subjects, conflict.Subject)
+		c.Assert(conflict.Versions, HasLen, 2)
+		c.Assert(conflict.Versions[1].Files, DeepEquals, files[1:])
+		return PreferLast(conflict)
+	})
+
+	c.Assert(err, IsNil)
+	c.Assert(subjects, DeepEquals, []string{"metadata owner", "contexts", "scenario 'Valid login'"})
+	b, _ := ioutil.ReadFile(into)
+	c.Assert(string(b), Equals, `# Login
+
+meta: owner=identity, tier=1
+
+* Open the app in a new window
+
+## Valid login
+* Sign in
+
+## Logout
+* Logout
+
+____
+* Close the app
+`)
+}
+
+func (s *MySuite) TestMergeSpecsWithUnresolvedConflict(c *C) {
+	dir, files := writeSpecs(c, "# A\n\n* Open the app\n\n## Scenario\n* Step\n", "# B\n\n## Another scenario\n* Step\n")
+	defer os.RemoveAll(dir)
+	into := filepath.Join(dir, "merged.spec")
+
+	err := MergeSpecs(files, into, func(conflict *Conflict) (int, error) {
+		c.Assert(conflict.Versions[1].Text, Equals, "")
+		return 0, fmt.Errorf("Conflicting %s", conflict.Subject)
+	})
+
+	c.Assert(err, ErrorMatches, "Conflicting contexts")
+	_, err = os.Stat(into)
+	c.Assert(os.IsNotExist(err), Equals, true)
+}
+
+func (s *MySuite) TestMergeSpecsDoesNotWriteAnInvalidSpec(c *C) {
+	dir, files := writeSpecs(c,
+		"# A\n\n## Scenario\n* Step\n",
+		"# B\n\n   |user |\n   |-----|\n   |alice|\n\n## Login\n* Login as <user>\n",
+	)
+	defer os.RemoveAll(dir)
+	into := filepath.Join(dir, "merged.spec")
+
+	err := MergeSpecs(files, into, PreferFirst)
+
+	c.Assert(err, ErrorMatches, "(?s)The merged spec is not valid, so .* is not written.*user.*")
+	_, err = os.Stat(into)
+	c.Assert(os.IsNotExist(err), Equals, true)
+}
+
+func (s *MySuite) TestPreferLastChoosesTheVersionOfTheLastSpec(c *C) {
+	conflict := &Conflict{Versions: []*Version{{specs: []int{0, 2}}, {specs: []int{1}}}}
+
+	last, _ := PreferLast(conflict)
+	first, _ := PreferFirst(conflict)
+
+	c.Assert(last, Equals, 0)
+	c.Assert(first, Equals, 0)
+}
@@ -0,0 +1,142 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package formatter
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+)
+
+const redactedValue = "redacted"
+
+// Redactor replaces the data in specs and concepts with placeholders, keeping their structure. Table values,
+// file and table parameters are always redacted. Static parameters are redacted only in specs and scenarios
+// tagged with one of the SensitiveTags.
+type Redactor struct {
+	SensitiveTags []string
+	count         int
+}
+
+// NewRedactor creates a Redactor treating the given tags as sensitive.
+func NewRedactor(sensitiveTags []string) *Redactor {
+	return &Redactor{SensitiveTags: sensitiveTags}
+}
+
+// RedactSpec replaces the data in the given spec in place.
+func (r *Redactor) RedactSpec(spec *gauge.Specification) {
+	sensitive := r.isSensitive(spec.Tags)
+	r.redactItems(spec.Items, sensitive)
+	for _, scenario := range spec.Scenarios {
+		r.redactItems(scenario.Items, sensitive || r.isSensitive(scenario.Tags))
+	}
+}
+
+// RedactConcepts replaces the data in the steps of all the concepts in the dictionary in place.
+func (r *Redactor) RedactConcepts(conceptDictionary *gauge.ConceptDictionary) {
+	for _, concept := range conceptDictionary.ConceptsMap {
+		r.redactItems(concept.ConceptStep.Items, false)
+	}
+}
+
+func (r *Redactor) redactItems(items []gauge.Item, sensitive bool) {
+	for _, item := range items {
+		switch item.Kind() {
+		case gauge.StepKind:
+			r.redactStep(item.(*gauge.Step), sensitive)
+		case gauge.DataTableKind:
+			r.redactDataTable(item.(*gauge.DataTable))
+		}
+	}
+}
+
+func (r *Redactor) redactStep(step *gauge.Step, sensitive bool) {
+	for _, arg := range step.Args {
+		switch arg.ArgType {
+		case gauge.TableArg:
+			r.redactTable(&arg.Table)
+		case gauge.SpecialString, gauge.SpecialTable:
+			arg.Name = r.redactSpecialParam(arg.Name)
+		case gauge.Static:
+			if sensitive {
+				arg.Value = r.next()
+			}
+		}
+	}
+}
+
+func (r *Redactor) redactDataTable(dataTable *gauge.DataTable) {
+	if dataTable.IsExternal {
+		file := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(dataTable.Value, parser.TableKeyword)), ":"))
+		dataTable.Value = fmt.Sprintf("%s: %s", parser.TableKeyword, r.redactFileName(file))
+		return
+	}
+	r.redactTable(&dataTable.Table)
+}
+
+// redactTable replaces every static cell with the header and row number of the cell. Dynamic cells only
+// refer to parameters, so they are kept.
+func (r *Redactor) redactTable(table *gauge.Table) {
+	for i, column := range table.Columns {
+		for j := range column {
+			cell := &column[j]
+			switch cell.CellType {
+			case gauge.Dynamic:
+			case gauge.SpecialString, gauge.SpecialTable:
+				cell.Value = r.redactSpecialParam(cell.Value)
+			default:
+				cell.Value = fmt.Sprintf("%s_%d", table.Headers[i], j+1)
+			}
+		}
+	}
+}
+
+// redactSpecialParam replaces the file of a special parameter like file:data.txt, keeping its extension.
+func (r *Redactor) redactSpecialParam(param string) string {
+	parts := strings.SplitN(param, ":", 2)
+	if len(parts) != 2 {
+		return param
+	}
+	return parts[0] + ":" + r.redactFileName(parts[1])
+}
+
+func (r *Redactor) redactFileName(file string) string {
+	return r.next() + filepath.Ext(strings.TrimSpace(file))
+}
+
+func (r *Redactor) next() string {
+	r.count++
+	return fmt.Sprintf("%s-%d", redactedValue, r.count)
+}
+
+func (r *Redactor) isSensitive(tags *gauge.Tags) bool {
+	if tags == nil {
+		return false
+	}
+	for _, tag := range tags.Values() {
+		for _, sensitiveTag := range r.SensitiveTags {
+			if strings.EqualFold(strings.TrimSpace(tag), strings.TrimSpace(sensitiveTag)) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package formatter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+)
+
+// ImportTable replaces the data table of the spec, or of the given scenario, with an inline table having
+// the contents of the csv or markdown file. The table is added if the spec or scenario does not have one.
+func ImportTable(tableFile, specFile, scenarioHeading string) error {
+	table, err := parser.TableFromFile(tableFile)
+	if err != nil {
+		return fmt.Errorf("Failed to read table from %s. %s", tableFile, err.Error())
+	}
+	spec, err := parseSpecFile(specFile)
+	if err != nil {
+		return err
+	}
+	dataTable := &gauge.DataTable{Table: *table}
+	if scenarioHeading == "" {
+		spec.DataTable = *dataTable
+		spec.Items = setDataTable(spec.Items, dataTable)
+		return formatAndSave(spec)
+	}
+	if !env.AllowScenarioDatatable() {
+		return fmt.Errorf("Scenario data tables are not enabled. Set allow_scenario_datatable to true to import a table into a scenario.")
+	}
+	scenario, err := findScenario(spec, scenarioHeading)
+	if err != nil {
+		return err
+	}
+	scenario.DataTable = *dataTable
+	scenario.Items = setDataTable(scenario.Items, dataTable)
+	return formatAndSave(spec)
+}
+
+// ExportTable writes the inline data table of the spec, or of the given scenario, to a csv file. The table of the
+// spec is replaced by a reference to the file. Scenarios cannot refer to external tables, so their table is kept as is.
+func ExportTable(specFile, tableFile, scenarioHeading string) error {
+	spec, err := parseSpecFile(specFile)
+	if err != nil {
+		return err
+	}
+	items := spec.Items
+	if scenarioHeading != "" {
+		scenario, err := findScenario(spec, scenarioHeading)
+		if err != nil {
+			return err
+		}
+		items = scenario.Items
+	}
+	index := dataTableIndex(items)
+	if index == -1 || items[index].(*gauge.DataTable).IsExternal {
+		return fmt.Errorf("No inline data table found in %s", specFile)
+	}
+	dataTable := items[index].(*gauge.DataTable)
+	contents, err := tableToCSV(&dataTable.Table)
+	if err != nil {
+		return err
+	}
+	if err := common.SaveFile(tableFile, contents, common.FileExists(tableFile)); err != nil {
+		return err
+	}
+	if scenarioHeading != "" {
+		return nil
+	}
+	items[index] = &gauge.DataTable{Table: dataTable.Table, Value: fmt.Sprintf("%s: %s", parser.TableKeyword, tableReference(tableFile)), IsExternal: true}
+	return formatAndSave(spec)
+}
+
+func parseSpecFile(specFile string) (*gauge.Specification, error) {
+	specs, results := parser.ParseSpecFiles([]string{specFile}, &gauge.ConceptDictionary{}, gauge.NewBuildErrors())
+	if len(specs) == 0 || !results[0].Ok {
+		return nil, fmt.Errorf("Failed to parse %s.\n%s", specFile, strings.Join(results[0].Errors(), "\n"))
+	}
+	return specs[0], nil
+}
+
+func findScenario(spec *gauge.Specification, heading string) (*gauge.Scenario, error) {
+	for _, scenario := range spec.Scenarios {
+		if scenario.Heading.Value == strings.TrimSpace(heading) {
+			return scenario, nil
+		}
+	}
+	return nil, fmt.Errorf("Scenario '%s' not found in %s", heading, spec.FileName)
+}
+
+func dataTableIndex(items []gauge.Item) int {
+	for i, item := range items {
+		if item.Kind() == gauge.DataTableKind {
+			return i
+		}
+	}
+	return -1
+}
+
+// setDataTable replaces the data table among the items, or adds it before the first step, scenario or teardown.
+func setDataTable(items []gauge.Item, dataTable *gauge.DataTable) []gauge.Item {
+	if i := dataTableIndex(items); i != -1 {
+		items[i] = dataTable
+		return items
+	}
+	at := len(items)
+	for i, item := range items {
+		if k := item.Kind(); k == gauge.StepKind || k == gauge.ScenarioKind || k == gauge.TearDownKind {
+			at = i
+			break
+		}
+	}
+	result := make([]gauge.Item, 0, len(items)+2)
+	result = append(result, items[:at]...)
+	result = append(result, dataTable, &gauge.Comment{Value: "\n"})
+	return append(result, items[at:]...)
+}
+
+func tableToCSV(table *gauge.Table) (string, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	if d := os.Getenv(env.CsvDelimiter); d != "" {
+		w.Comma = []rune(d)[0]
+	}
+	if err := w.Write(table.Headers); err != nil {
+		return "", err
+	}
+	if err := w.WriteAll(table.Rows()); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// tableReference gives the path of the table file as it is resolved by the parser, i.e. relative to the project root.
+func tableReference(tableFile string) string {
+	abs, err := filepath.Abs(tableFile)
+	if err != nil {
+		return tableFile
+	}
+	if rel, err := filepath.Rel(config.ProjectRoot, abs); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return abs
+}
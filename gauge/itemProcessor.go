@@ -21,6 +21,7 @@ type ItemProcessor interface {
 	Specification(*Specification)
 	Heading(*Heading)
 	Tags(*Tags)
+	Meta(*Meta)
 	Table(*Table)
 	DataTable(*DataTable)
 	Scenario(*Scenario)
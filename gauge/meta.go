@@ -0,0 +1,115 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package gauge
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MetaTagPrefix marks the tags which carry the metadata of specs and scenarios, as meta:key=value, to runners,
+// plugins and results, since their messages have no other room for it.
+const MetaTagPrefix = "meta:"
+
+// Meta holds the key=value annotations of a spec or scenario, like owner=team-payments, in the order they are written.
+type Meta struct {
+	Keys   []string
+	Values map[string]string
+	LineNo int
+}
+
+// NewMeta creates metadata from the key=value pairs given.
+func NewMeta(lineNo int, pairs ...string) (*Meta, error) {
+	m := &Meta{Values: make(map[string]string), LineNo: lineNo}
+	for _, p := range pairs {
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("Invalid metadata '%s'. Metadata should be given as key=value", p)
+		}
+		m.Add(strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1]))
+	}
+	return m, nil
+}
+
+// Add sets the value of the key. A key which is already set keeps its position.
+func (m *Meta) Add(key, value string) {
+	if _, ok := m.Values[key]; !ok {
+		m.Keys = append(m.Keys, key)
+	}
+	m.Values[key] = value
+}
+
+// Get gives the value of the key.
+func (m *Meta) Get(key string) (string, bool) {
+	if m == nil {
+		return "", false
+	}
+	v, ok := m.Values[key]
+	return v, ok
+}
+
+// Pairs gives the metadata as key=value pairs, in the order they are written.
+func (m *Meta) Pairs() []string {
+	if m == nil {
+		return nil
+	}
+	var pairs []string
+	for _, k := range m.Keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, m.Values[k]))
+	}
+	return pairs
+}
+
+func (m *Meta) Kind() TokenKind {
+	return MetaKind
+}
+
+// MergeMeta gives the metadata of a scenario along with that of its spec. The values of the scenario take precedence.
+func MergeMeta(spec, scenario *Meta) *Meta {
+	merged := &Meta{Values: make(map[string]string)}
+	for _, m := range []*Meta{spec, scenario} {
+		if m == nil {
+			continue
+		}
+		for _, k := range m.Keys {
+			merged.Add(k, m.Values[k])
+		}
+	}
+	return merged
+}
+
+// MetaTags gives the metadata as tags, each prefixed with MetaTagPrefix.
+func MetaTags(m *Meta) []string {
+	var tags []string
+	for _, p := range m.Pairs() {
+		tags = append(tags, MetaTagPrefix+p)
+	}
+	return tags
+}
+
+// ParseMetaTag gives the key and value of a tag carrying metadata.
+func ParseMetaTag(tag string) (key, value string, ok bool) {
+	if !strings.HasPrefix(tag, MetaTagPrefix) {
+		return "", "", false
+	}
+	kv := strings.SplitN(strings.TrimPrefix(tag, MetaTagPrefix), "=", 2)
+	if len(kv) != 2 {
+		return "", "", false
+	}
+	return kv[0], kv[1], true
+}
@@ -0,0 +1,60 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package gauge
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestNewMeta(c *C) {
+	m, err := NewMeta(3, "owner = team-payments", "severity=critical", "owner=team-refunds", "query=a=b")
+
+	c.Assert(err, IsNil)
+	c.Assert(m.Pairs(), DeepEquals, []string{"owner=team-refunds", "severity=critical", "query=a=b"})
+	v, ok := m.Get("severity")
+	c.Assert(ok, Equals, true)
+	c.Assert(v, Equals, "critical")
+}
+
+func (s *MySuite) TestNewMetaWithoutValue(c *C) {
+	_, err := NewMeta(3, "owner")
+
+	c.Assert(err, ErrorMatches, "Invalid metadata 'owner'. Metadata should be given as key=value")
+}
+
+func (s *MySuite) TestMetaTags(c *C) {
+	m, _ := NewMeta(1, "owner=team-payments", "link=https://example.org/?a=b")
+
+	tags := MetaTags(m)
+
+	c.Assert(tags, DeepEquals, []string{"meta:owner=team-payments", "meta:link=https://example.org/?a=b"})
+	k, v, ok := ParseMetaTag(tags[1])
+	c.Assert(ok, Equals, true)
+	c.Assert(k, Equals, "link")
+	c.Assert(v, Equals, "https://example.org/?a=b")
+	_, _, ok = ParseMetaTag("smoke")
+	c.Assert(ok, Equals, false)
+	c.Assert(MetaTags(nil), IsNil)
+}
+
+func (s *MySuite) TestProtoScenarioCarriesMetaAsTags(c *C) {
+	m, _ := NewMeta(2, "owner=team-payments")
+	scenario := &Scenario{Heading: &Heading{Value: "Pay"}, Tags: &Tags{RawValues: [][]string{{"smoke"}}}, Meta: m, Span: &Span{}}
+
+	c.Assert(NewProtoScenario(scenario).GetTags(), DeepEquals, []string{"smoke", "meta:owner=team-payments"})
+}
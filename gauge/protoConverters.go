@@ -18,6 +18,8 @@
 package gauge
 
 import (
+	"strings"
+
 	"github.com/getgauge/gauge/execution/result"
 	"github.com/getgauge/gauge/gauge_messages"
 )
@@ -37,6 +39,9 @@ func ConvertToProtoItem(item Item) *gauge_messages.ProtoItem {
 	case TearDownKind:
 		teardown := item.(*TearDown)
 		return convertToProtoCommentItem(&Comment{LineNo: teardown.LineNo, Value: teardown.Value})
+	case MetaKind:
+		meta := item.(*Meta)
+		return convertToProtoCommentItem(&Comment{LineNo: meta.LineNo, Value: "meta: " + strings.Join(meta.Pairs(), ", ")})
 	}
 	return nil
 }
@@ -175,14 +180,26 @@ func ConvertToProtoTable(table *Table) *gauge_messages.ProtoTable {
 	return protoTableParam
 }
 
+// ConvertToProtoSuiteResult converts the suite result, along with the results of its specs. The items of spilled spec
+// results are all read back into it, so ConvertToProtoSuiteSummary and EachProtoSpecResult are used instead to send or
+// write a suite result one spec at a time.
 func ConvertToProtoSuiteResult(suiteResult *result.SuiteResult) *gauge_messages.ProtoSuiteResult {
-	protoSuiteResult := &gauge_messages.ProtoSuiteResult{
+	protoSuiteResult := ConvertToProtoSuiteSummary(suiteResult)
+	protoSuiteResult.SpecResults = make([]*gauge_messages.ProtoSpecResult, 0)
+	EachProtoSpecResult(suiteResult, func(r *gauge_messages.ProtoSpecResult) {
+		protoSuiteResult.SpecResults = append(protoSuiteResult.SpecResults, r)
+	})
+	return protoSuiteResult
+}
+
+// ConvertToProtoSuiteSummary converts the suite result without the results of its specs.
+func ConvertToProtoSuiteSummary(suiteResult *result.SuiteResult) *gauge_messages.ProtoSuiteResult {
+	return &gauge_messages.ProtoSuiteResult{
 		PreHookFailure:      suiteResult.PreSuite,
 		PostHookFailure:     suiteResult.PostSuite,
 		Failed:              suiteResult.IsFailed,
 		SpecsFailedCount:    int32(suiteResult.SpecsFailedCount),
 		ExecutionTime:       suiteResult.ExecutionTime,
-		SpecResults:         convertToProtoSpecResult(suiteResult.SpecResults),
 		SuccessRate:         getSuccessRate(len(suiteResult.SpecResults), suiteResult.SpecsFailedCount+suiteResult.SpecsSkippedCount),
 		Environment:         suiteResult.Environment,
 		Tags:                suiteResult.Tags,
@@ -194,7 +211,6 @@ func ConvertToProtoSuiteResult(suiteResult *result.SuiteResult) *gauge_messages.
 		PreHookScreenshots:  suiteResult.PreHookScreenshots,
 		PostHookScreenshots: suiteResult.PostHookScreenshots,
 	}
-	return protoSuiteResult
 }
 
 func getSuccessRate(totalSpecs int, failedSpecs int) float32 {
@@ -204,23 +220,27 @@ func getSuccessRate(totalSpecs int, failedSpecs int) float32 {
 	return (float32)(100.0 * (totalSpecs - failedSpecs) / totalSpecs)
 }
 
-func convertToProtoSpecResult(specResults []*result.SpecResult) []*gauge_messages.ProtoSpecResult {
-	protoSpecResults := make([]*gauge_messages.ProtoSpecResult, 0)
-	for _, specResult := range specResults {
-		protoSpecResult := &gauge_messages.ProtoSpecResult{
-			ProtoSpec:            specResult.ProtoSpec,
-			ScenarioCount:        int32(specResult.ScenarioCount),
-			ScenarioFailedCount:  int32(specResult.ScenarioFailedCount),
-			Failed:               specResult.IsFailed,
-			FailedDataTableRows:  specResult.FailedDataTableRows,
-			ExecutionTime:        specResult.ExecutionTime,
-			Skipped:              specResult.Skipped,
-			ScenarioSkippedCount: int32(specResult.ScenarioSkippedCount),
-			Errors:               specResult.Errors,
-		}
-		protoSpecResults = append(protoSpecResults, protoSpecResult)
+// EachProtoSpecResult calls f with the proto message of the result of every spec of the suite, along with its items.
+// Spilled spec results are read back one at a time.
+func EachProtoSpecResult(suiteResult *result.SuiteResult, f func(*gauge_messages.ProtoSpecResult)) {
+	suiteResult.EachSpecResult(func(specResult *result.SpecResult) {
+		f(ConvertToProtoSpecResult(specResult))
+	})
+}
+
+// ConvertToProtoSpecResult converts the result of a spec to its proto message.
+func ConvertToProtoSpecResult(specResult *result.SpecResult) *gauge_messages.ProtoSpecResult {
+	return &gauge_messages.ProtoSpecResult{
+		ProtoSpec:            specResult.ProtoSpec,
+		ScenarioCount:        int32(specResult.ScenarioCount),
+		ScenarioFailedCount:  int32(specResult.ScenarioFailedCount),
+		Failed:               specResult.IsFailed,
+		FailedDataTableRows:  specResult.FailedDataTableRows,
+		ExecutionTime:        specResult.ExecutionTime,
+		Skipped:              specResult.Skipped,
+		ScenarioSkippedCount: int32(specResult.ScenarioSkippedCount),
+		Errors:               specResult.Errors,
 	}
-	return protoSpecResults
 }
 
 func ConvertToProtoSpec(spec *Specification) *gauge_messages.ProtoSpec {
@@ -250,7 +270,7 @@ func newProtoSpec(specification *Specification) *gauge_messages.ProtoSpec {
 		SpecHeading:   specification.Heading.Value,
 		IsTableDriven: specification.DataTable.IsInitialized(),
 		FileName:      specification.FileName,
-		Tags:          getTags(specification.Tags),
+		Tags:          append(getTags(specification.Tags), MetaTags(specification.Meta)...),
 	}
 
 }
@@ -267,7 +287,7 @@ func NewProtoScenario(scenario *Scenario) *gauge_messages.ProtoScenario {
 		ScenarioHeading: scenario.Heading.Value,
 		Failed:          false,
 		Skipped:         false,
-		Tags:            getTags(scenario.Tags),
+		Tags:            append(getTags(scenario.Tags), MetaTags(scenario.Meta)...),
 		Contexts:        make([]*gauge_messages.ProtoItem, 0),
 		ExecutionTime:   0,
 		TearDownSteps:   make([]*gauge_messages.ProtoItem, 0),
@@ -26,6 +26,7 @@ type Scenario struct {
 	Steps                     []*Step
 	Comments                  []*Comment
 	Tags                      *Tags
+	Meta                      *Meta
 	Items                     []Item
 	DataTable                 DataTable
 	SpecDataTableRow          Table
@@ -60,6 +61,12 @@ func (scenario *Scenario) AddTags(tags *Tags) {
 	scenario.AddItem(tags)
 }
 
+// AddMeta sets the metadata of the scenario, whose values take precedence over the ones of its spec.
+func (scenario *Scenario) AddMeta(meta *Meta) {
+	scenario.Meta = meta
+	scenario.AddItem(meta)
+}
+
 func (scenario *Scenario) NTags() int {
 	if scenario.Tags == nil {
 		return 0
@@ -23,6 +23,7 @@ type SpecCollection struct {
 	mutex sync.Mutex
 	index int
 	specs [][]*Specification
+	next  *SpecCollection
 }
 
 func NewSpecCollection(s []*Specification, groupDataTableSpecs bool) *SpecCollection {
@@ -63,15 +64,27 @@ func (s *SpecCollection) Specs() (specs []*Specification) {
 	return specs
 }
 
+// Chain makes the collection continue with the specs of next once its own specs are exhausted.
+// The next collection can be shared by multiple collections, e.g. the ones of different streams.
+func (s *SpecCollection) Chain(next *SpecCollection) *SpecCollection {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.next = next
+	return s
+}
+
 func (s *SpecCollection) HasNext() bool {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	return s.index < len(s.specs)
+	return s.index < len(s.specs) || (s.next != nil && s.next.HasNext())
 }
 
 func (s *SpecCollection) Next() []*Specification {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
+	if s.index >= len(s.specs) && s.next != nil {
+		return s.next.Next()
+	}
 	spec := s.specs[s.index]
 	s.index++
 	return spec
@@ -47,3 +47,22 @@ func set(s [][]*Specification) map[int][]*Specification {
 	}
 	return specs
 }
+
+func TestChainedSpecCollection(t *testing.T) {
+	s1 := &Specification{FileName: "filename1"}
+	s2 := &Specification{FileName: "filename2"}
+	s3 := &Specification{FileName: "filename3"}
+	shared := NewSpecCollection([]*Specification{s2, s3}, false)
+	first := NewSpecCollection([]*Specification{s1}, false).Chain(shared)
+	second := NewSpecCollection(nil, false).Chain(shared)
+
+	got := [][]*Specification{first.Next(), second.Next(), first.Next()}
+	want := [][]*Specification{{s1}, {s2}, {s3}}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Chained Spec Collection Failed\n\tWant: %v\n\t Got:%v", want, got)
+	}
+	if first.HasNext() || second.HasNext() {
+		t.Errorf("Chained Spec Collection should be exhausted")
+	}
+}
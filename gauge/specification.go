@@ -42,6 +42,7 @@ const (
 	TableKind
 	DataTableKind
 	TearDownKind
+	MetaKind
 )
 
 type Specification struct {
@@ -52,6 +53,7 @@ type Specification struct {
 	Contexts      []*Step
 	FileName      string
 	Tags          *Tags
+	Meta          *Meta
 	Items         []Item
 	TearDownSteps []*Step
 }
@@ -162,6 +164,12 @@ func (spec *Specification) AddTags(tags *Tags) {
 	spec.AddItem(spec.Tags)
 }
 
+// AddMeta sets the metadata of the spec, which applies to all of its scenarios.
+func (spec *Specification) AddMeta(meta *Meta) {
+	spec.Meta = meta
+	spec.AddItem(meta)
+}
+
 func (spec *Specification) NTags() int {
 	if spec.Tags == nil {
 		return 0
@@ -272,6 +280,8 @@ func (spec *Specification) Traverse(processor ItemProcessor, queue *ItemQueue) {
 			processor.Table(item.(*Table))
 		case TagKind:
 			processor.Tags(item.(*Tags))
+		case MetaKind:
+			processor.Meta(item.(*Meta))
 		case TearDownKind:
 			processor.TearDown(item.(*TearDown))
 		case DataTableKind:
@@ -346,6 +356,7 @@ func (t *TearDown) Kind() TokenKind {
 
 type Tags struct {
 	RawValues [][]string
+	LineNo    int
 }
 
 func (tags *Tags) Add(values []string) {
@@ -28,4 +28,13 @@ sed  -i.backup '/import gauge_messages1 "spec.pb"/d' gauge_messages/api.pb.go &&
 
 sed -i.backup '/import "."/d' gauge_messages/api.pb.go && rm gauge_messages/api.pb.go.backup
 sed -i.backup '/import "."/d' gauge_messages/messages.pb.go && rm gauge_messages/messages.pb.go.backup
+cd parser/cache
+PATH=$PATH:$GOPATH/bin protoc --go_out=. cache.proto
+cd ../..
+cd execution/resultstream
+PATH=$PATH:$GOPATH/bin protoc -I. -I../../gauge-proto --go_out=plugins=grpc,Mspec.proto=github.com/getgauge/gauge/gauge_messages,Mmessages.proto=github.com/getgauge/gauge/gauge_messages,Mlsp.proto=github.com/getgauge/gauge/gauge_messages:. resultstream.proto
+cd ../..
+cd api/daemon
+PATH=$PATH:$GOPATH/bin protoc -I. -I../../gauge-proto --go_out=plugins=grpc,Mapi.proto=github.com/getgauge/gauge/gauge_messages,Mspec.proto=github.com/getgauge/gauge/gauge_messages:. daemon.proto
+cd ../..
 # go fmt github.com/getgauge/gauge/...
@@ -0,0 +1,199 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package graph builds the graph of the concepts used by specs and by other concepts, to visualize it and to find the
+// concepts which too many specs and concepts depend on.
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/util"
+)
+
+const (
+	// DOT is the format of Graphviz.
+	DOT = "dot"
+	// JSON is the format of the graph as nodes and edges.
+	JSON = "json"
+
+	specKind    = "spec"
+	conceptKind = "concept"
+)
+
+// Node is a spec or concept. A concept is identified by its step, with the parameters left out, and a spec by its file.
+//
+// The depth of a concept is the number of levels of concepts it is made of, 1 for a concept using no other concept.
+// The depth of a spec is that of the deepest concept it uses. Fan-in is the number of specs and concepts using a
+// concept, and fan-out the number of concepts used by a spec or concept.
+type Node struct {
+	ID     string `json:"id"`
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	File   string `json:"file"`
+	Depth  int    `json:"depth"`
+	FanIn  int    `json:"fanIn"`
+	FanOut int    `json:"fanOut"`
+}
+
+// Edge tells that a spec or concept uses a concept, and in how many steps.
+type Edge struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Count int    `json:"count"`
+}
+
+// Graph holds the specs and concepts, the specs first, and the concepts they use.
+type Graph struct {
+	Nodes []*Node `json:"nodes"`
+	Edges []*Edge `json:"edges"`
+}
+
+// Build gives the graph of the concepts used by the specs and by the concepts. All the concepts are in the graph,
+// including those used by none of the specs.
+func Build(specs []*gauge.Specification, concepts *gauge.ConceptDictionary) *Graph {
+	g := &Graph{}
+	nodes := make(map[string]*Node)
+	edges := make(map[[2]string]*Edge)
+	uses := make(map[string][]string)
+	use := func(from *Node, steps []*gauge.Step) {
+		for _, s := range steps {
+			if !s.IsConcept {
+				continue
+			}
+			key := [2]string{from.ID, s.Value}
+			if edges[key] == nil {
+				edges[key] = &Edge{From: from.ID, To: s.Value}
+				uses[from.ID] = append(uses[from.ID], s.Value)
+			}
+			edges[key].Count++
+		}
+	}
+
+	var conceptNodes []*Node
+	for _, c := range concepts.ConceptsMap {
+		n := &Node{ID: c.ConceptStep.Value, Kind: conceptKind, Name: c.ConceptStep.LineText, File: relPath(c.FileName)}
+		nodes[n.ID] = n
+		conceptNodes = append(conceptNodes, n)
+		use(n, c.ConceptStep.ConceptSteps)
+	}
+	var specNodes []*Node
+	for _, spec := range specs {
+		n := &Node{ID: relPath(spec.FileName), Kind: specKind, Name: spec.Heading.Value, File: relPath(spec.FileName)}
+		nodes[n.ID] = n
+		specNodes = append(specNodes, n)
+		use(n, spec.Contexts)
+		for _, sce := range spec.Scenarios {
+			use(n, sce.Steps)
+		}
+		use(n, spec.TearDownSteps)
+	}
+	sort.Slice(specNodes, func(i, j int) bool { return specNodes[i].ID < specNodes[j].ID })
+	sort.Slice(conceptNodes, func(i, j int) bool { return conceptNodes[i].ID < conceptNodes[j].ID })
+	g.Nodes = append(specNodes, conceptNodes...)
+
+	for _, e := range edges {
+		if to, ok := nodes[e.To]; ok {
+			to.FanIn++
+		}
+		nodes[e.From].FanOut++
+		g.Edges = append(g.Edges, e)
+	}
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+
+	depths := make(map[string]int)
+	var depth func(id string, visiting map[string]bool) int
+	depth = func(id string, visiting map[string]bool) int {
+		if d, ok := depths[id]; ok {
+			return d
+		}
+		if visiting[id] {
+			return 0
+		}
+		visiting[id] = true
+		deepest := 0
+		for _, used := range uses[id] {
+			if d := depth(used, visiting); d > deepest {
+				deepest = d
+			}
+		}
+		delete(visiting, id)
+		if nodes[id] != nil && nodes[id].Kind == conceptKind {
+			deepest++
+		}
+		depths[id] = deepest
+		return deepest
+	}
+	for _, n := range g.Nodes {
+		n.Depth = depth(n.ID, make(map[string]bool))
+	}
+	return g
+}
+
+func relPath(file string) string {
+	return filepath.ToSlash(util.RelPathToProjectRoot(file))
+}
+
+// Format gives the graph in the dot or json format.
+func (g *Graph) Format(format string) (string, error) {
+	switch format {
+	case DOT:
+		return g.dot(), nil
+	case JSON:
+		b, err := json.MarshalIndent(g, "", "\t")
+		return string(b), err
+	}
+	return "", fmt.Errorf("Unknown format %s. The format can be %s or %s.", format, DOT, JSON)
+}
+
+// dot gives the graph in the format of Graphviz, with the specs as notes and the concepts as boxes. A concept is drawn
+// bolder the more specs and concepts use it, so that the central ones stand out.
+func (g *Graph) dot() string {
+	var b strings.Builder
+	b.WriteString("digraph concepts {\n\trankdir=LR;\n")
+	for _, n := range g.Nodes {
+		if n.Kind == specKind {
+			fmt.Fprintf(&b, "\t%s [shape=note, label=%s];\n", quote(n.ID), quote(n.Name+"\n"+n.File))
+			continue
+		}
+		label := fmt.Sprintf("%s\nfan-in %d, fan-out %d, depth %d", n.Name, n.FanIn, n.FanOut, n.Depth)
+		fmt.Fprintf(&b, "\t%s [shape=box, penwidth=%d, label=%s];\n", quote(n.ID), 1+n.FanIn/5, quote(label))
+	}
+	for _, e := range g.Edges {
+		if e.Count > 1 {
+			fmt.Fprintf(&b, "\t%s -> %s [label=\"%d\"];\n", quote(e.From), quote(e.To), e.Count)
+			continue
+		}
+		fmt.Fprintf(&b, "\t%s -> %s;\n", quote(e.From), quote(e.To))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
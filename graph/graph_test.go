@@ -0,0 +1,136 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package graph
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+const concepts = `# Login as <user>
+* Open <user>
+* Type <user>
+
+# Open <page>
+* Navigate to <page>
+
+# Checkout
+* Login as "bob"
+* Pay
+
+# Logout
+* Click "logout"
+`
+
+func build(c *C, specs ...string) *Graph {
+	config.ProjectRoot = filepath.FromSlash("/project")
+	dictionary := gauge.NewConceptDictionary()
+	cpts, res := new(parser.ConceptParser).Parse(concepts, filepath.FromSlash("/project/specs/app.cpt"))
+	c.Assert(res.Errors(), HasLen, 0)
+	errs, err := parser.AddConcept(cpts, filepath.FromSlash("/project/specs/app.cpt"), dictionary)
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+	var parsed []*gauge.Specification
+	for i, text := range specs {
+		spec, res, err := new(parser.SpecParser).Parse(text, dictionary, filepath.FromSlash(fmt.Sprintf("/project/specs/%d.spec", i+1)))
+		c.Assert(err, IsNil)
+		c.Assert(res.Ok, Equals, true)
+		parsed = append(parsed, spec)
+	}
+	return Build(parsed, dictionary)
+}
+
+func node(g *Graph, id string) *Node {
+	for _, n := range g.Nodes {
+		if n.ID == id {
+			return n
+		}
+	}
+	return nil
+}
+
+func (s *MySuite) TestBuild(c *C) {
+	g := build(c,
+		"# Shop\n* Login as \"alice\"\n## Buy\n* Login as \"x\"\n* Checkout\n## Browse\n* Plain step\n",
+		"# Admin\n## Manage\n* Login as \"admin\"\n",
+	)
+
+	var ids []string
+	for _, n := range g.Nodes {
+		ids = append(ids, n.ID)
+	}
+	c.Assert(ids, DeepEquals, []string{"specs/1.spec", "specs/2.spec", "Checkout", "Login as {}", "Logout", "Open {}"})
+	c.Assert(*node(g, "specs/1.spec"), DeepEquals, Node{ID: "specs/1.spec", Kind: specKind, Name: "Shop", File: "specs/1.spec", Depth: 3, FanOut: 2})
+	c.Assert(*node(g, "Login as {}"), DeepEquals, Node{ID: "Login as {}", Kind: conceptKind, Name: "Login as <user>", File: "specs/app.cpt", Depth: 2, FanIn: 3, FanOut: 1})
+	c.Assert(*node(g, "Checkout"), DeepEquals, Node{ID: "Checkout", Kind: conceptKind, Name: "Checkout", File: "specs/app.cpt", Depth: 3, FanIn: 1, FanOut: 1})
+	c.Assert(*node(g, "Logout"), DeepEquals, Node{ID: "Logout", Kind: conceptKind, Name: "Logout", File: "specs/app.cpt", Depth: 1})
+	c.Assert(g.Edges, DeepEquals, []*Edge{
+		{From: "Checkout", To: "Login as {}", Count: 1},
+		{From: "Login as {}", To: "Open {}", Count: 1},
+		{From: "specs/1.spec", To: "Checkout", Count: 1},
+		{From: "specs/1.spec", To: "Login as {}", Count: 2},
+		{From: "specs/2.spec", To: "Login as {}", Count: 1},
+	})
+}
+
+func (s *MySuite) TestFormatDot(c *C) {
+	g := &Graph{
+		Nodes: []*Node{
+			{ID: "specs/a.spec", Kind: specKind, Name: `Say "hi"`, File: "specs/a.spec", Depth: 1, FanOut: 1},
+			{ID: "Greet {}", Kind: conceptKind, Name: "Greet <name>", File: "specs/a.cpt", Depth: 1, FanIn: 10},
+		},
+		Edges: []*Edge{{From: "specs/a.spec", To: "Greet {}", Count: 2}},
+	}
+
+	out, err := g.Format(DOT)
+
+	c.Assert(err, IsNil)
+	c.Assert(out, Equals, `digraph concepts {
+	rankdir=LR;
+	"specs/a.spec" [shape=note, label="Say \"hi\"\nspecs/a.spec"];
+	"Greet {}" [shape=box, penwidth=3, label="Greet <name>\nfan-in 10, fan-out 0, depth 1"];
+	"specs/a.spec" -> "Greet {}" [label="2"];
+}`)
+}
+
+func (s *MySuite) TestFormatJSON(c *C) {
+	g := &Graph{Nodes: []*Node{{ID: "Logout", Kind: conceptKind, Name: "Logout", File: "a.cpt", Depth: 1}}}
+
+	out, err := g.Format(JSON)
+
+	c.Assert(err, IsNil)
+	c.Assert(out, Equals, "{\n\t\"nodes\": [\n\t\t{\n\t\t\t\"id\": \"Logout\",\n\t\t\t\"kind\": \"concept\",\n\t\t\t\"name\": \"Logout\",\n\t\t\t\"file\": \"a.cpt\",\n\t\t\t\"depth\": 1,\n\t\t\t\"fanIn\": 0,\n\t\t\t\"fanOut\": 0\n\t\t}\n\t],\n\t\"edges\": null\n}")
+}
+
+func (s *MySuite) TestFormatUnknown(c *C) {
+	_, err := (&Graph{}).Format("svg")
+
+	c.Assert(err, ErrorMatches, "Unknown format svg. The format can be dot or json.")
+}
@@ -0,0 +1,284 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package lint
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/util"
+)
+
+const (
+	conceptLocationRule   = "concept-location"
+	oneConceptPerFileRule = "one-concept-per-file"
+	conceptSizeRule       = "concept-size"
+)
+
+// ConceptsDir is the directory, relative to the project root, under which the concept files are expected. Empty allows them anywhere.
+var ConceptsDir string
+
+// OneConceptPerFile reports the concept files defining more than one concept.
+var OneConceptPerFile bool
+
+// MaxConceptSteps is the number of steps beyond which a concept is reported. Zero disables the rule.
+var MaxConceptSteps = 15
+
+// conceptFiles gives the concepts of each concept file, in the order they are defined in.
+func conceptFiles(concepts *gauge.ConceptDictionary) map[string][]*gauge.Concept {
+	files := make(map[string][]*gauge.Concept)
+	if concepts == nil {
+		return files
+	}
+	for _, cpt := range concepts.ConceptsMap {
+		files[cpt.FileName] = append(files[cpt.FileName], cpt)
+	}
+	for _, cpts := range files {
+		sort.Sort(gauge.ByLineNo(cpts))
+	}
+	return files
+}
+
+// misplacedConcepts reports the concept files which are not under ConceptsDir.
+func misplacedConcepts(specs []*gauge.Specification, concepts *gauge.ConceptDictionary) []Finding {
+	if ConceptsDir == "" {
+		return nil
+	}
+	var findings []Finding
+	for file, cpts := range conceptFiles(concepts) {
+		if !inConceptsDir(file) {
+			findings = append(findings, Finding{
+				Rule:     conceptLocationRule,
+				FileName: file,
+				LineNo:   cpts[0].ConceptStep.LineNo,
+				Message:  fmt.Sprintf("Concept file is not under %s.", filepath.ToSlash(ConceptsDir)),
+			})
+		}
+	}
+	return findings
+}
+
+func inConceptsDir(file string) bool {
+	rel, err := filepath.Rel(filepath.Join(config.ProjectRoot, ConceptsDir), file)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// crowdedConceptFiles reports the concept files defining more than one concept, at the second concept.
+func crowdedConceptFiles(specs []*gauge.Specification, concepts *gauge.ConceptDictionary) []Finding {
+	if !OneConceptPerFile {
+		return nil
+	}
+	var findings []Finding
+	for file, cpts := range conceptFiles(concepts) {
+		if len(cpts) > 1 {
+			findings = append(findings, Finding{
+				Rule:     oneConceptPerFileRule,
+				FileName: file,
+				LineNo:   cpts[1].ConceptStep.LineNo,
+				Message:  fmt.Sprintf("Concept file defines %d concepts. Consider keeping each concept in a file of its own.", len(cpts)),
+			})
+		}
+	}
+	return findings
+}
+
+// largeConcepts reports the concepts having more than MaxConceptSteps steps.
+func largeConcepts(specs []*gauge.Specification, concepts *gauge.ConceptDictionary) []Finding {
+	if MaxConceptSteps == 0 {
+		return nil
+	}
+	var findings []Finding
+	for file, cpts := range conceptFiles(concepts) {
+		for _, cpt := range cpts {
+			if n := len(cpt.ConceptStep.ConceptSteps); n > MaxConceptSteps {
+				findings = append(findings, Finding{
+					Rule:     conceptSizeRule,
+					FileName: file,
+					LineNo:   cpt.ConceptStep.LineNo,
+					Message: fmt.Sprintf("Concept %q has %d steps, more than the maximum of %d. Consider splitting it into smaller concepts.",
+						cpt.ConceptStep.LineText, n, MaxConceptSteps),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// Fix moves the concept files reported by the concept-location rule under ConceptsDir, and splits those reported by the
+// one-concept-per-file rule into a file per concept, named after the concept. It gives the findings which are left and
+// a description of each fix. A file is not moved over an existing file.
+func Fix(findings []Finding, concepts *gauge.ConceptDictionary) ([]Finding, []string, error) {
+	fixable := make(map[string]map[string]bool)
+	for _, f := range findings {
+		if f.Rule == conceptLocationRule || f.Rule == oneConceptPerFileRule {
+			if fixable[f.FileName] == nil {
+				fixable[f.FileName] = make(map[string]bool)
+			}
+			fixable[f.FileName][f.Rule] = true
+		}
+	}
+	files := conceptFiles(concepts)
+	fixed := make(map[string]bool)
+	var fixes []string
+	for _, file := range sortedKeys(fixable) {
+		rules := fixable[file]
+		dir := filepath.Dir(file)
+		if rules[conceptLocationRule] {
+			dir = filepath.Join(config.ProjectRoot, ConceptsDir)
+		}
+		var moves []string
+		var err error
+		if rules[oneConceptPerFileRule] {
+			moves, err = splitConceptFile(file, files[file], dir)
+		} else {
+			moves, err = moveConceptFile(file, dir)
+		}
+		if err != nil {
+			return nil, fixes, err
+		}
+		if len(moves) > 0 {
+			fixed[file] = true
+			fixes = append(fixes, moves...)
+		}
+	}
+	var remaining []Finding
+	for _, f := range findings {
+		if !fixed[f.FileName] || (f.Rule != conceptLocationRule && f.Rule != oneConceptPerFileRule) {
+			remaining = append(remaining, f)
+		}
+	}
+	return remaining, fixes, nil
+}
+
+func moveConceptFile(file, dir string) ([]string, error) {
+	target := filepath.Join(dir, filepath.Base(file))
+	if common.FileExists(target) {
+		return nil, nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(file, target); err != nil {
+		return nil, err
+	}
+	return []string{fmt.Sprintf("Moved %s to %s", rel(file), rel(target))}, nil
+}
+
+// splitConceptFile writes every concept of the file, along with the comments following it, to a file of its own in the
+// given directory. The first concept keeps the name of the file, along with the comments preceding it.
+func splitConceptFile(file string, cpts []*gauge.Concept, dir string) ([]string, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.SplitAfter(string(content), "\n")
+	targets := make([]string, len(cpts))
+	taken := make(map[string]bool)
+	for i, cpt := range cpts {
+		name := filepath.Base(file)
+		if i > 0 {
+			name = conceptFileName(cpt.ConceptStep.LineText)
+		}
+		targets[i] = uniquePath(filepath.Join(dir, name), taken, file)
+		if targets[i] == "" {
+			return nil, nil
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	var fixes []string
+	for i := range cpts {
+		start, end := 0, len(lines)
+		if i > 0 {
+			start = cpts[i].ConceptStep.LineNo - 1
+		}
+		if i < len(cpts)-1 {
+			end = cpts[i+1].ConceptStep.LineNo - 1
+		}
+		text := strings.TrimRight(strings.Join(lines[start:end], ""), "\r\n") + "\n"
+		if err := ioutil.WriteFile(targets[i], []byte(text), 0644); err != nil {
+			return nil, err
+		}
+		if targets[i] != file {
+			fixes = append(fixes, fmt.Sprintf("Moved concept %q from %s to %s", cpts[i].ConceptStep.LineText, rel(file), rel(targets[i])))
+		}
+	}
+	if targets[0] != file {
+		if err := os.Remove(file); err != nil {
+			return nil, err
+		}
+	}
+	return fixes, nil
+}
+
+var nonWordChars = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// conceptFileName gives a name for the file of a concept from its heading, e.g. login_as_user.cpt for "Login as <user>".
+func conceptFileName(heading string) string {
+	name := strings.Trim(nonWordChars.ReplaceAllString(strings.ToLower(heading), "_"), "_")
+	if name == "" {
+		name = "concept"
+	}
+	return name + ".cpt"
+}
+
+// uniquePath gives the path, or the path with a number added to its name, which is neither an existing file nor taken.
+// The given source file does not count as existing. It gives an empty path if the path of the source file is taken.
+func uniquePath(path string, taken map[string]bool, source string) string {
+	if path == source {
+		if taken[path] {
+			return ""
+		}
+		taken[path] = true
+		return path
+	}
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	for i := 1; ; i++ {
+		p := path
+		if i > 1 {
+			p = fmt.Sprintf("%s_%d%s", base, i, ext)
+		}
+		if !taken[p] && p != source && !common.FileExists(p) {
+			taken[p] = true
+			return p
+		}
+	}
+}
+
+func rel(path string) string {
+	return filepath.ToSlash(util.RelPathToProjectRoot(path))
+}
+
+func sortedKeys(m map[string]map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
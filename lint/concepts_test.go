@@ -0,0 +1,118 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package lint
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+	. "gopkg.in/check.v1"
+)
+
+const loginConcepts = `# Login as <user>
+* Open "home" page
+* Enter <user>
+
+// logging out
+# Logout
+* Click "logout"
+`
+
+// conceptProject writes the concept files in a new project directory and gives their concepts.
+func conceptProject(c *C, files map[string]string) *gauge.ConceptDictionary {
+	config.ProjectRoot = c.MkDir()
+	var paths []string
+	for name, content := range files {
+		path := filepath.Join(config.ProjectRoot, filepath.FromSlash(name))
+		c.Assert(os.MkdirAll(filepath.Dir(path), 0755), IsNil)
+		c.Assert(ioutil.WriteFile(path, []byte(content), 0644), IsNil)
+		paths = append(paths, path)
+	}
+	concepts := gauge.NewConceptDictionary()
+	_, errs, err := parser.AddConcepts(paths, concepts)
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+	return concepts
+}
+
+func readFile(c *C, name string) string {
+	b, err := ioutil.ReadFile(filepath.Join(config.ProjectRoot, filepath.FromSlash(name)))
+	c.Assert(err, IsNil)
+	return string(b)
+}
+
+func (s *MySuite) TestConceptRules(c *C) {
+	ConceptsDir, OneConceptPerFile, MaxConceptSteps = "concepts", true, 1
+	defer func() { ConceptsDir, OneConceptPerFile, MaxConceptSteps = "", false, 15 }()
+	concepts := conceptProject(c, map[string]string{"specs/login.cpt": loginConcepts, "concepts/search.cpt": "# Search\n* Search\n"})
+	file := filepath.Join(config.ProjectRoot, "specs", "login.cpt")
+
+	findings := Lint(nil, concepts)
+
+	c.Assert(findings, DeepEquals, []Finding{
+		{Rule: conceptLocationRule, FileName: file, LineNo: 1, Message: "Concept file is not under concepts."},
+		{Rule: conceptSizeRule, FileName: file, LineNo: 1, Message: `Concept "Login as <user>" has 2 steps, more than the maximum of 1. Consider splitting it into smaller concepts.`},
+		{Rule: oneConceptPerFileRule, FileName: file, LineNo: 6, Message: "Concept file defines 2 concepts. Consider keeping each concept in a file of its own."},
+	})
+}
+
+func (s *MySuite) TestConceptRulesAreDisabledByDefault(c *C) {
+	concepts := conceptProject(c, map[string]string{"specs/login.cpt": loginConcepts})
+
+	c.Assert(Lint(nil, concepts), HasLen, 0)
+}
+
+func (s *MySuite) TestFixMovesConceptFilesUnderConceptsDir(c *C) {
+	ConceptsDir = "concepts"
+	defer func() { ConceptsDir = "" }()
+	concepts := conceptProject(c, map[string]string{"specs/login.cpt": loginConcepts})
+
+	remaining, fixes, err := Fix(Lint(nil, concepts), concepts)
+
+	c.Assert(err, IsNil)
+	c.Assert(remaining, HasLen, 0)
+	c.Assert(fixes, DeepEquals, []string{"Moved specs/login.cpt to concepts/login.cpt"})
+	c.Assert(readFile(c, "concepts/login.cpt"), Equals, loginConcepts)
+	_, err = os.Stat(filepath.Join(config.ProjectRoot, "specs", "login.cpt"))
+	c.Assert(os.IsNotExist(err), Equals, true)
+}
+
+func (s *MySuite) TestFixSplitsConceptFiles(c *C) {
+	OneConceptPerFile, MaxConceptSteps = true, 1
+	defer func() { OneConceptPerFile, MaxConceptSteps = false, 15 }()
+	concepts := conceptProject(c, map[string]string{"specs/login.cpt": loginConcepts, "specs/logout.cpt": "# Log out now\n* Log out\n"})
+
+	remaining, fixes, err := Fix(Lint(nil, concepts), concepts)
+
+	c.Assert(err, IsNil)
+	c.Assert(remaining, HasLen, 1)
+	c.Assert(remaining[0].Rule, Equals, conceptSizeRule)
+	c.Assert(fixes, DeepEquals, []string{`Moved concept "Logout" from specs/login.cpt to specs/logout_2.cpt`})
+	c.Assert(readFile(c, "specs/login.cpt"), Equals, strings.Join([]string{"# Login as <user>", `* Open "home" page`, "* Enter <user>", "", "// logging out", ""}, "\n"))
+	c.Assert(readFile(c, "specs/logout_2.cpt"), Equals, "# Logout\n* Click \"logout\"\n")
+}
+
+func (s *MySuite) TestConceptFileName(c *C) {
+	c.Assert(conceptFileName("Login as <user> with \"password\""), Equals, "login_as_user_with_password.cpt")
+	c.Assert(conceptFileName("<>"), Equals, "concept.cpt")
+}
@@ -0,0 +1,121 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/util"
+)
+
+const duplicateScenariosRule = "duplicate-scenario"
+
+// MinSimilarity is the percentage of steps two scenarios must share, in the same order, to be reported as duplicates.
+var MinSimilarity = 80
+
+// minDuplicateSteps is the number of steps below which scenarios are too small to be worth consolidating.
+const minDuplicateSteps = 2
+
+type scenarioSteps struct {
+	fileName string
+	scenario *gauge.Scenario
+	steps    []string
+}
+
+// duplicateScenarios reports the scenarios whose steps are the same as, or similar to, those of an earlier scenario.
+// Steps are compared by their text with the parameters left out, so scenarios differing only in the values they use are identical.
+func duplicateScenarios(specs []*gauge.Specification, concepts *gauge.ConceptDictionary) []Finding {
+	var scenarios []scenarioSteps
+	for _, spec := range specs {
+		for _, sce := range spec.Scenarios {
+			if len(sce.Steps) < minDuplicateSteps {
+				continue
+			}
+			steps := make([]string, len(sce.Steps))
+			for i, step := range sce.Steps {
+				steps[i] = step.Value
+			}
+			scenarios = append(scenarios, scenarioSteps{fileName: spec.FileName, scenario: sce, steps: steps})
+		}
+	}
+	sort.SliceStable(scenarios, func(i, j int) bool {
+		if scenarios[i].fileName != scenarios[j].fileName {
+			return scenarios[i].fileName < scenarios[j].fileName
+		}
+		return scenarios[i].scenario.Heading.LineNo < scenarios[j].scenario.Heading.LineNo
+	})
+	var findings []Finding
+	for j := range scenarios {
+		best, bestSimilarity := -1, 0
+		for i := 0; i < j; i++ {
+			if s := similarity(scenarios[i].steps, scenarios[j].steps); s >= MinSimilarity && s > bestSimilarity {
+				best, bestSimilarity = i, s
+			}
+		}
+		if best == -1 {
+			continue
+		}
+		original, duplicate := scenarios[best], scenarios[j]
+		what := fmt.Sprintf("%d%% similar to", bestSimilarity)
+		if bestSimilarity == 100 {
+			what = "identical to"
+		}
+		findings = append(findings, Finding{
+			Rule:     duplicateScenariosRule,
+			FileName: duplicate.fileName,
+			LineNo:   duplicate.scenario.Heading.LineNo,
+			Message: fmt.Sprintf("Steps of scenario %q are %s those of %q (%s:%d). Consider extracting them into a concept.",
+				duplicate.scenario.Heading.Value, what, original.scenario.Heading.Value, util.RelPathToProjectRoot(original.fileName), original.scenario.Heading.LineNo),
+		})
+	}
+	return findings
+}
+
+// similarity gives the percentage of steps shared by both sequences in the same order, based on their longest common subsequence.
+func similarity(a, b []string) int {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	// the similarity can not exceed the ratio of the lengths, which saves comparing scenarios of very different sizes
+	if shorter := min(len(a), len(b)); 200*shorter/(len(a)+len(b)) < MinSimilarity {
+		return 0
+	}
+	lcs := make([]int, len(b)+1)
+	for i := range a {
+		prev := 0
+		for j := range b {
+			current := lcs[j+1]
+			if a[i] == b[j] {
+				lcs[j+1] = prev + 1
+			} else if lcs[j] > lcs[j+1] {
+				lcs[j+1] = lcs[j]
+			}
+			prev = current
+		}
+	}
+	return 200 * lcs[len(b)] / (len(a) + len(b))
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
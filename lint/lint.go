@@ -0,0 +1,77 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package lint finds specs and concepts which can be organized better. Unlike validation errors, its findings do not stop the execution.
+package lint
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/util"
+)
+
+// Finding is a problem found by a lint rule, at a line of a spec or concept file.
+type Finding struct {
+	Rule     string
+	FileName string
+	LineNo   int
+	Message  string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d [%s] %s", util.RelPathToProjectRoot(f.FileName), f.LineNo, f.Rule, f.Message)
+}
+
+type rule struct {
+	name  string
+	check func(specs []*gauge.Specification, concepts *gauge.ConceptDictionary) []Finding
+}
+
+var rules = []rule{
+	{name: duplicateScenariosRule, check: duplicateScenarios},
+	{name: conceptLocationRule, check: misplacedConcepts},
+	{name: oneConceptPerFileRule, check: crowdedConceptFiles},
+	{name: conceptSizeRule, check: largeConcepts},
+}
+
+// Lint checks the specs and concepts with all the rules. The findings are sorted by file and line.
+func Lint(specs []*gauge.Specification, concepts *gauge.ConceptDictionary) []Finding {
+	var findings []Finding
+	for _, r := range rules {
+		findings = append(findings, r.check(specs, concepts)...)
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].FileName != findings[j].FileName {
+			return findings[i].FileName < findings[j].FileName
+		}
+		return findings[i].LineNo < findings[j].LineNo
+	})
+	return findings
+}
+
+// Print writes the findings, one per line, followed by their count.
+func Print(w io.Writer, findings []Finding) {
+	for _, f := range findings {
+		fmt.Fprintln(w, f.String())
+	}
+	if len(findings) > 0 {
+		fmt.Fprintf(w, "%d problem(s) found.\n", len(findings))
+	}
+}
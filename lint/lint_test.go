@@ -0,0 +1,110 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package lint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func parseSpec(c *C, fileName, text string) *gauge.Specification {
+	spec, res, err := new(parser.SpecParser).Parse(text, gauge.NewConceptDictionary(), fileName)
+	c.Assert(err, IsNil)
+	c.Assert(res.Ok, Equals, true)
+	return spec
+}
+
+func (s *MySuite) TestDuplicateScenariosAreReportedAfterParamNormalization(c *C) {
+	MinSimilarity = 70
+	defer func() { MinSimilarity = 80 }()
+	login := parseSpec(c, "login.spec", `# Login
+## Admin logs in
+* Open "home" page
+* Login as "admin"
+* Verify dashboard
+
+## Short scenario
+* Open "home" page
+`)
+	orders := parseSpec(c, "orders.spec", `# Orders
+## Customer logs in
+* Open "shop" page
+* Login as "customer"
+* Verify dashboard
+
+## Customer orders
+* Open "shop" page
+* Login as "customer"
+* Search for "book"
+* Add to cart
+* Verify dashboard
+
+## Short scenario
+* Open "home" page
+`)
+
+	findings := Lint([]*gauge.Specification{orders, login}, gauge.NewConceptDictionary())
+
+	c.Assert(findings, DeepEquals, []Finding{
+		{Rule: duplicateScenariosRule, FileName: "orders.spec", LineNo: 2, Message: `Steps of scenario "Customer logs in" are identical to those of "Admin logs in" (login.spec:2). Consider extracting them into a concept.`},
+		{Rule: duplicateScenariosRule, FileName: "orders.spec", LineNo: 7, Message: `Steps of scenario "Customer orders" are 75% similar to those of "Admin logs in" (login.spec:2). Consider extracting them into a concept.`},
+	})
+}
+
+func (s *MySuite) TestDuplicateScenariosBelowMinSimilarityAreNotReported(c *C) {
+	spec := parseSpec(c, "a.spec", `# A
+## First
+* Open "shop" page
+* Login as "customer"
+* Verify dashboard
+
+## Second
+* Open "shop" page
+* Login as "customer"
+* Search for "book"
+* Add to cart
+* Verify dashboard
+`)
+
+	c.Assert(Lint([]*gauge.Specification{spec}, gauge.NewConceptDictionary()), HasLen, 0)
+}
+
+func (s *MySuite) TestSimilarity(c *C) {
+	c.Assert(similarity([]string{"a", "b", "c"}, []string{"a", "b", "c"}), Equals, 100)
+	c.Assert(similarity([]string{"a", "b", "c", "d"}, []string{"a", "x", "c", "d"}), Equals, 75)
+	c.Assert(similarity([]string{"a", "b"}, []string{"b", "a"}), Equals, 50)
+	c.Assert(similarity(nil, []string{"a"}), Equals, 0)
+}
+
+func (s *MySuite) TestPrint(c *C) {
+	b := &bytes.Buffer{}
+
+	Print(b, []Finding{{Rule: "rule", FileName: "a.spec", LineNo: 3, Message: "Something is wrong."}})
+
+	c.Assert(b.String(), Equals, "a.spec:3 [rule] Something is wrong.\n1 problem(s) found.\n")
+}
@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/getgauge/gauge/plugin/pluginInfo"
 	"github.com/getgauge/gauge/version"
@@ -59,6 +60,10 @@ var initialized bool
 var ActiveLogFile string
 var machineReadable bool
 
+var fatalHooksMu sync.Mutex
+var fatalHooks = make(map[int]func())
+var nextFatalHook int
+
 // Info logs INFO messages. stdout flag indicates if message is to be written to stdout in addition to log.
 func Info(stdout bool, msg string) {
 	Infof(stdout, msg)
@@ -115,9 +120,41 @@ func Fatalf(stdout bool, msg string, args ...interface{}) {
 		return
 	}
 	write(stdout, message)
+	runFatalHooks()
 	activeLogger.Fatalf(msg, args...)
 }
 
+// OnFatal registers a function which is run before gauge exits on a fatal error or through Exit, e.g. to release external resources.
+// It returns a function unregistering it.
+func OnFatal(hook func()) func() {
+	fatalHooksMu.Lock()
+	defer fatalHooksMu.Unlock()
+	id := nextFatalHook
+	nextFatalHook++
+	fatalHooks[id] = hook
+	return func() {
+		fatalHooksMu.Lock()
+		defer fatalHooksMu.Unlock()
+		delete(fatalHooks, id)
+	}
+}
+
+// Exit runs the functions registered with OnFatal and exits with the given code.
+func Exit(code int) {
+	runFatalHooks()
+	os.Exit(code)
+}
+
+func runFatalHooks() {
+	fatalHooksMu.Lock()
+	hooks := fatalHooks
+	fatalHooks = make(map[int]func())
+	fatalHooksMu.Unlock()
+	for _, hook := range hooks {
+		hook()
+	}
+}
+
 // Debug logs DEBUG messages. stdout flag indicates if message is to be written to stdout in addition to log.
 func Debug(stdout bool, msg string) {
 	Debugf(stdout, msg)
@@ -236,6 +273,11 @@ func addLogsDirPath(logFileName string) string {
 	return filepath.Join(customLogsDir, logFileName)
 }
 
+// LogFilePath gives the path of the given file in the logs directory of the project.
+func LogFilePath(logFileName string) string {
+	return getLogFile(logFileName)
+}
+
 func getLogFile(logFileName string) string {
 	logDirPath := addLogsDirPath(logFileName)
 	if filepath.IsAbs(logDirPath) {
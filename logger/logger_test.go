@@ -204,3 +204,17 @@ Your Environment Information -----------
 		}
 	}
 }
+
+func TestFatalHooksRunOnceUnlessUnregistered(t *testing.T) {
+	var ran []string
+	OnFatal(func() { ran = append(ran, "fixtures") })
+	unregister := OnFatal(func() { ran = append(ran, "unregistered") })
+	unregister()
+
+	runFatalHooks()
+	runFatalHooks()
+
+	if len(ran) != 1 || ran[0] != "fixtures" {
+		t.Errorf("Expected only the registered hook to run once. Got %v", ran)
+	}
+}
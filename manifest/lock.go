@@ -0,0 +1,87 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+)
+
+// LockFile records the exact versions of the plugins of a project, so that every machine installs the same versions.
+const LockFile = "gauge.lock"
+
+// Lock maps the language runner and plugins of a project to their resolved versions.
+type Lock struct {
+	Plugins map[string]string
+}
+
+// ProjectLock reads the lock file of the project. Returns nil if the project has no lock file.
+func ProjectLock() (*Lock, error) {
+	lockFile := filepath.Join(config.ProjectRoot, LockFile)
+	if !common.FileExists(lockFile) {
+		return nil, nil
+	}
+	contents, err := common.ReadFileContents(lockFile)
+	if err != nil {
+		return nil, err
+	}
+	var l Lock
+	if err := json.Unmarshal([]byte(contents), &l); err != nil {
+		return nil, fmt.Errorf("Failed to read %s. %s", LockFile, err.Error())
+	}
+	if l.Plugins == nil {
+		l.Plugins = make(map[string]string)
+	}
+	return &l, nil
+}
+
+// Save writes the lock file to the project root.
+func (l *Lock) Save() error {
+	b, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(config.ProjectRoot, LockFile), append(b, '\n'), common.NewFilePermissions)
+}
+
+// Drift lists the differences between the plugins of the manifest and the lock file.
+func (l *Lock) Drift(m *Manifest) []string {
+	var drift []string
+	expected := map[string]bool{m.Language: true}
+	for _, p := range m.Plugins {
+		expected[p] = true
+	}
+	for p := range expected {
+		if _, ok := l.Plugins[p]; !ok {
+			drift = append(drift, fmt.Sprintf("%s is not locked", p))
+		}
+	}
+	for p := range l.Plugins {
+		if !expected[p] {
+			drift = append(drift, fmt.Sprintf("%s is locked but not in %s", p, common.ManifestFile))
+		}
+	}
+	sort.Strings(drift)
+	return drift
+}
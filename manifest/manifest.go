@@ -27,11 +27,26 @@ import (
 
 	"github.com/getgauge/common"
 	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/version"
 )
 
 type Manifest struct {
 	Language string
 	Plugins  []string
+	// Profiles holds the named execution profiles applied by gauge run --profile
+	Profiles map[string]*Profile `json:",omitempty"`
+	// PluginVersions constrains the versions gauge upgrade moves plugins to, e.g. {"java": {"Minimum": "0.6.0", "Maximum": "0.6.9"}}
+	PluginVersions map[string]version.VersionSupport `json:",omitempty"`
+}
+
+// Profile is a named set of settings for gauge run, e.g. {"Flags": {"tags": "smoke"}, "Env": {"BROWSER": "chrome"}, "Reporters": ["html-report"]}
+type Profile struct {
+	// Flags of gauge run, by their long names
+	Flags map[string]interface{} `json:",omitempty"`
+	// Env holds the variables available to the step implementations and hooks as environment variables
+	Env map[string]string `json:",omitempty"`
+	// Reporters are the reporting plugins started in place of the Plugins
+	Reporters []string `json:",omitempty"`
 }
 
 func ProjectManifest() (*Manifest, error) {
@@ -58,5 +73,5 @@ func (m *Manifest) Save() error {
 	if err != nil {
 		return err
 	}
-	return ioutil.WriteFile(common.ManifestFile, b, common.NewFilePermissions)
+	return ioutil.WriteFile(filepath.Join(config.ProjectRoot, common.ManifestFile), b, common.NewFilePermissions)
 }
@@ -0,0 +1,154 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package move moves spec and concept files, or directories of them, within a project, and rewrites the files of
+// the project which refer to the moved specs by path, so that the history of the specs is kept.
+package move
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/execution/history"
+	"github.com/getgauge/gauge/execution/rerun"
+	"github.com/getgauge/gauge/owners"
+	"github.com/getgauge/gauge/util"
+)
+
+// referrers give the new contents of the files which refer to specs renamed by the given function, keyed by file.
+var referrers = []func(func(string) (string, bool)) (map[string][]byte, error){
+	history.RenameSpecs,
+	rerun.RenameSpecs,
+	owners.RenameSpecs,
+}
+
+// Move moves the spec or concept file, or the directory, from one path to another in the project, like mv. A file
+// or directory moved to an existing directory is moved into it. The references to the moved specs in the results
+// history, the failures of the last run and the owners file are rewritten along with the move. If any of them cannot
+// be rewritten, the move is undone. It gives the files whose references were rewritten.
+func Move(from, to string) ([]string, error) {
+	from, to, err := paths(from, to)
+	if err != nil {
+		return nil, err
+	}
+	relFrom, relTo := relToProjectRoot(from), relToProjectRoot(to)
+	rename := func(p string) (string, bool) {
+		if p == relFrom {
+			return relTo, true
+		}
+		if strings.HasPrefix(p, relFrom+"/") {
+			return relTo + strings.TrimPrefix(p, relFrom), true
+		}
+		return p, false
+	}
+	updates := make(map[string][]byte)
+	for _, referrer := range referrers {
+		contents, err := referrer(rename)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read the references to %s. %s", relFrom, err.Error())
+		}
+		for file, c := range contents {
+			updates[file] = c
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(to), common.NewDirectoryPermissions); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(from, to); err != nil {
+		return nil, err
+	}
+	var files []string
+	for file := range updates {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	originals := make(map[string][]byte)
+	for _, file := range files {
+		original, err := ioutil.ReadFile(file)
+		if err == nil {
+			err = writeFile(file, updates[file])
+		}
+		if err != nil {
+			for f, o := range originals {
+				writeFile(f, o)
+			}
+			os.Rename(to, from)
+			return nil, fmt.Errorf("Failed to update the references in %s, so %s is not moved. %s", file, relFrom, err.Error())
+		}
+		originals[file] = original
+	}
+	return files, nil
+}
+
+// paths gives the absolute paths of the move, after checking that it is a move of specs or concepts in the project.
+func paths(from, to string) (string, string, error) {
+	from, err := filepath.Abs(from)
+	if err != nil {
+		return "", "", err
+	}
+	if to, err = filepath.Abs(to); err != nil {
+		return "", "", err
+	}
+	info, err := os.Stat(from)
+	if err != nil {
+		return "", "", fmt.Errorf("Cannot move %s. %s", from, err.Error())
+	}
+	if util.IsDir(to) {
+		to = filepath.Join(to, filepath.Base(from))
+	}
+	if common.FileExists(to) || util.IsDir(to) {
+		return "", "", fmt.Errorf("Cannot move %s to %s, since it already exists", from, to)
+	}
+	if !info.IsDir() {
+		if util.IsSpec(from) != util.IsSpec(to) || util.IsConcept(from) != util.IsConcept(to) {
+			return "", "", fmt.Errorf("Cannot move %s to %s, since they are not files of the same kind", from, to)
+		}
+		if !util.IsGaugeFile(from) {
+			return "", "", fmt.Errorf("Cannot move %s, since it is not a spec or concept file", from)
+		}
+	}
+	for _, p := range []string{from, to} {
+		if rel, err := filepath.Rel(config.ProjectRoot, p); err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			return "", "", fmt.Errorf("Cannot move %s to %s, since both should be in the project %s", from, to, config.ProjectRoot)
+		}
+	}
+	if info.IsDir() && strings.HasPrefix(to, from+string(filepath.Separator)) {
+		return "", "", fmt.Errorf("Cannot move %s into itself", from)
+	}
+	return from, to, nil
+}
+
+func relToProjectRoot(path string) string {
+	rel, _ := filepath.Rel(config.ProjectRoot, path)
+	return filepath.ToSlash(rel)
+}
+
+// writeFile replaces the contents of the file at once, so that it is never left half written.
+func writeFile(file string, contents []byte) error {
+	tmp := file + ".tmp"
+	if err := ioutil.WriteFile(tmp, contents, common.NewFilePermissions); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
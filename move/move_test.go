@@ -0,0 +1,113 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package move
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/execution/history"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) SetUpTest(c *C) {
+	config.ProjectRoot = c.MkDir()
+	os.MkdirAll(filepath.Join(config.ProjectRoot, "specs", "auth"), common.NewDirectoryPermissions)
+	ioutil.WriteFile(filepath.Join(config.ProjectRoot, "specs", "a.spec"), []byte("# A\n## S\n* step\n"), common.NewFilePermissions)
+	ioutil.WriteFile(filepath.Join(config.ProjectRoot, "specs", "auth", "b.spec"), []byte("# B\n## S\n* step\n"), common.NewFilePermissions)
+	os.MkdirAll(filepath.Join(config.ProjectRoot, common.DotGauge), common.NewDirectoryPermissions)
+	durations := "{\"specs/a.spec\": 10, \"specs/auth/b.spec\": 20}"
+	ioutil.WriteFile(filepath.Join(config.ProjectRoot, common.DotGauge, "spec_durations.json"), []byte(durations), common.NewFilePermissions)
+}
+
+func (s *MySuite) TestMoveSpec(c *C) {
+	updated, err := Move(filepath.Join(config.ProjectRoot, "specs", "a.spec"), filepath.Join(config.ProjectRoot, "specs", "login", "a.spec"))
+
+	c.Assert(err, IsNil)
+	c.Assert(updated, DeepEquals, []string{filepath.Join(config.ProjectRoot, common.DotGauge, "spec_durations.json")})
+	c.Assert(common.FileExists(filepath.Join(config.ProjectRoot, "specs", "login", "a.spec")), Equals, true)
+	c.Assert(common.FileExists(filepath.Join(config.ProjectRoot, "specs", "a.spec")), Equals, false)
+	durations := history.SpecDurations()
+	c.Assert(durations[filepath.Join("specs", "login", "a.spec")], Equals, int64(10))
+	c.Assert(durations[filepath.Join("specs", "auth", "b.spec")], Equals, int64(20))
+}
+
+func (s *MySuite) TestMoveDirectoryIntoAnotherDirectory(c *C) {
+	os.MkdirAll(filepath.Join(config.ProjectRoot, "specs", "web"), common.NewDirectoryPermissions)
+
+	_, err := Move(filepath.Join(config.ProjectRoot, "specs", "auth"), filepath.Join(config.ProjectRoot, "specs", "web"))
+
+	c.Assert(err, IsNil)
+	c.Assert(common.FileExists(filepath.Join(config.ProjectRoot, "specs", "web", "auth", "b.spec")), Equals, true)
+	c.Assert(history.SpecDurations()[filepath.Join("specs", "web", "auth", "b.spec")], Equals, int64(20))
+}
+
+func (s *MySuite) TestMoveIsUndoneIfReferencesCannotBeUpdated(c *C) {
+	defer func(r []func(func(string) (string, bool)) (map[string][]byte, error)) { referrers = r }(referrers)
+	missing := filepath.Join(config.ProjectRoot, "missing", "refs.json")
+	referrers = append(referrers, func(rename func(string) (string, bool)) (map[string][]byte, error) {
+		return map[string][]byte{missing: []byte("{}")}, nil
+	})
+
+	_, err := Move(filepath.Join(config.ProjectRoot, "specs", "a.spec"), filepath.Join(config.ProjectRoot, "specs", "login.spec"))
+
+	c.Assert(err, ErrorMatches, "Failed to update the references in .*refs.json, so specs/a.spec is not moved.*")
+	c.Assert(common.FileExists(filepath.Join(config.ProjectRoot, "specs", "a.spec")), Equals, true)
+	c.Assert(common.FileExists(filepath.Join(config.ProjectRoot, "specs", "login.spec")), Equals, false)
+	c.Assert(history.SpecDurations()[filepath.Join("specs", "a.spec")], Equals, int64(10))
+}
+
+func (s *MySuite) TestMoveReferrerError(c *C) {
+	defer func(r []func(func(string) (string, bool)) (map[string][]byte, error)) { referrers = r }(referrers)
+	referrers = []func(func(string) (string, bool)) (map[string][]byte, error){
+		func(rename func(string) (string, bool)) (map[string][]byte, error) {
+			return nil, errors.New("invalid history")
+		},
+	}
+
+	_, err := Move(filepath.Join(config.ProjectRoot, "specs", "a.spec"), filepath.Join(config.ProjectRoot, "specs", "login.spec"))
+
+	c.Assert(err, ErrorMatches, "Failed to read the references to specs/a.spec. invalid history")
+	c.Assert(common.FileExists(filepath.Join(config.ProjectRoot, "specs", "a.spec")), Equals, true)
+}
+
+func (s *MySuite) TestInvalidMoves(c *C) {
+	spec := filepath.Join(config.ProjectRoot, "specs", "a.spec")
+	for _, to := range []string{
+		filepath.Join(config.ProjectRoot, "specs", "auth", "b.spec"),
+		filepath.Join(config.ProjectRoot, "specs", "a.cpt"),
+		filepath.Join(os.TempDir(), "a.spec"),
+	} {
+		_, err := Move(spec, to)
+		c.Assert(err, NotNil)
+	}
+	_, err := Move(filepath.Join(config.ProjectRoot, "specs"), filepath.Join(config.ProjectRoot, "specs", "auth", "specs"))
+	c.Assert(err, ErrorMatches, "Cannot move .* into itself")
+	c.Assert(common.FileExists(spec), Equals, true)
+}
@@ -0,0 +1,77 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package order
+
+import (
+	"sort"
+
+	"github.com/getgauge/gauge/execution/history"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/util"
+)
+
+const (
+	unchangedRank = iota
+	changedRank
+	failedRank
+)
+
+// FailuresFirst moves the scenarios that failed in the recent runs, and then the ones in recently changed specs, to the front.
+var FailuresFirst bool
+
+// SortByFailuresFirst orders the scenarios within each spec, and then the specs, so that the ones that failed in the recent runs
+// come first, followed by the ones in specs changed recently. The relative order is retained otherwise.
+func SortByFailuresFirst(specs []*gauge.Specification) []*gauge.Specification {
+	failures, changes := history.FailedRecently(), util.ChangedRecently()
+	if len(failures) == 0 && len(changes) == 0 {
+		return specs
+	}
+	ranks := make(map[*gauge.Specification]int, len(specs))
+	for _, spec := range specs {
+		ranks[spec] = rankScenarios(spec, failures, changes[util.ResolvedPath(spec.FileName)])
+	}
+	sort.SliceStable(specs, func(i, j int) bool {
+		return ranks[specs[i]] > ranks[specs[j]]
+	})
+	return specs
+}
+
+// rankScenarios orders the scenarios of the spec by their rank and gives the highest rank among them.
+func rankScenarios(spec *gauge.Specification, failures map[string]bool, specChanged bool) int {
+	path := util.RelPathToProjectRoot(spec.FileName)
+	ranks := make(map[*gauge.Scenario]int, len(spec.Scenarios))
+	rank := unchangedRank
+	if specChanged {
+		rank = changedRank
+	}
+	for _, sce := range spec.Scenarios {
+		ranks[sce] = rank
+		if sce.Heading != nil && failures[history.ScenarioKey(path, sce.Heading.Value)] {
+			ranks[sce] = failedRank
+		}
+	}
+	sort.SliceStable(spec.Scenarios, func(i, j int) bool {
+		return ranks[spec.Scenarios[i]] > ranks[spec.Scenarios[j]]
+	})
+	for _, r := range ranks {
+		if r > rank {
+			rank = r
+		}
+	}
+	return rank
+}
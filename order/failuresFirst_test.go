@@ -0,0 +1,103 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package order
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/execution/history"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/util"
+)
+
+func scenario(heading string) *gauge.Scenario {
+	return &gauge.Scenario{Heading: &gauge.Heading{Value: heading}}
+}
+
+func stubRecent(failures []string, changes []string) func() {
+	oldFailures, oldChanges, oldRoot := history.FailedRecently, util.ChangedRecently, config.ProjectRoot
+	config.ProjectRoot, _ = filepath.Abs("root")
+	history.FailedRecently = func() map[string]bool {
+		m := make(map[string]bool)
+		for i := 0; i < len(failures); i += 2 {
+			m[history.ScenarioKey(failures[i], failures[i+1])] = true
+		}
+		return m
+	}
+	util.ChangedRecently = func() map[string]bool {
+		m := make(map[string]bool)
+		for _, c := range changes {
+			m[filepath.Join(config.ProjectRoot, c)] = true
+		}
+		return m
+	}
+	return func() {
+		history.FailedRecently, util.ChangedRecently, config.ProjectRoot = oldFailures, oldChanges, oldRoot
+	}
+}
+
+func TestSortByFailuresFirst(t *testing.T) {
+	defer stubRecent([]string{"specs/c.spec", "Checkout [row 2]"}, []string{"specs/b.spec"})()
+	a := &gauge.Specification{FileName: filepath.Join(config.ProjectRoot, "specs", "a.spec"), Scenarios: []*gauge.Scenario{scenario("Login")}}
+	b := &gauge.Specification{FileName: filepath.Join(config.ProjectRoot, "specs", "b.spec"), Scenarios: []*gauge.Scenario{scenario("Search")}}
+	checkout := scenario("checkout")
+	c := &gauge.Specification{FileName: filepath.Join(config.ProjectRoot, "specs", "c.spec"), Scenarios: []*gauge.Scenario{scenario("Cart"), checkout}}
+
+	got := SortByFailuresFirst([]*gauge.Specification{a, b, c})
+
+	expected := []*gauge.Specification{c, b, a}
+	for i, s := range got {
+		if expected[i] != s {
+			t.Errorf("Expected '%s' at position %d, got %s", expected[i].FileName, i, s.FileName)
+		}
+	}
+	if c.Scenarios[0] != checkout {
+		t.Errorf("Expected the failed scenario to run first in its spec, got %s", c.Scenarios[0].Heading.Value)
+	}
+}
+
+func TestSortWithFailuresFirstRetainsPriority(t *testing.T) {
+	defer stubRecent([]string{"a.spec", "Login"}, nil)()
+	a := &gauge.Specification{FileName: filepath.Join(config.ProjectRoot, "a.spec"), Scenarios: []*gauge.Scenario{scenario("Login")}}
+	b := &gauge.Specification{FileName: filepath.Join(config.ProjectRoot, "b.spec"), Tags: &gauge.Tags{RawValues: [][]string{{"priority:1"}}}}
+	c := &gauge.Specification{FileName: filepath.Join(config.ProjectRoot, "c.spec")}
+	Sorted, FailuresFirst = false, true
+	defer func() { FailuresFirst = false }()
+
+	got := Sort([]*gauge.Specification{c, b, a})
+
+	expected := []*gauge.Specification{b, a, c}
+	for i, s := range got {
+		if expected[i] != s {
+			t.Errorf("Expected '%s' at position %d, got %s", expected[i].FileName, i, s.FileName)
+		}
+	}
+}
+
+func TestSortByFailuresFirstWithoutHistoryOrChanges(t *testing.T) {
+	defer stubRecent(nil, nil)()
+	a, b := &gauge.Specification{FileName: "a.spec"}, &gauge.Specification{FileName: "b.spec"}
+
+	got := SortByFailuresFirst([]*gauge.Specification{b, a})
+
+	if got[0] != b || got[1] != a {
+		t.Errorf("Expected the order to be retained, got %s, %s", got[0].FileName, got[1].FileName)
+	}
+}
@@ -19,10 +19,18 @@ package order
 
 import (
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/getgauge/gauge/gauge"
 )
 
+const (
+	priorityTagPrefix = "priority:"
+	// defaultPriority is the priority of the specs without a priority tag. Specs with a negative priority run after them.
+	defaultPriority = 0
+)
+
 var Sorted bool
 
 type byFileName []*gauge.Specification
@@ -39,9 +47,58 @@ func (s byFileName) Less(i, j int) bool {
 	return s[i].FileName < s[j].FileName
 }
 
+// Sort orders the specs alphabetically if Sorted is set, moves the recent failures and changes to the front if FailuresFirst is set,
+// and then moves the specs with higher priority to the front.
 func Sort(specs []*gauge.Specification) []*gauge.Specification {
 	if Sorted {
 		sort.Sort(byFileName(specs))
 	}
+	if FailuresFirst {
+		specs = SortByFailuresFirst(specs)
+	}
+	return SortByPriority(specs)
+}
+
+// SortByPriority orders the specs by descending priority, retaining the relative order of specs with same priority.
+func SortByPriority(specs []*gauge.Specification) []*gauge.Specification {
+	priorities := make(map[*gauge.Specification]int, len(specs))
+	for _, s := range specs {
+		priorities[s] = Priority(s)
+	}
+	sort.SliceStable(specs, func(i, j int) bool {
+		return priorities[specs[i]] > priorities[specs[j]]
+	})
 	return specs
 }
+
+// Priority gives the priority of a spec from its `priority:<n>` tag. If the spec is not tagged,
+// the highest priority among its tagged scenarios is used. Specs without any priority have the default priority 0.
+// Priorities can be negative, to run specs after those without a priority.
+func Priority(spec *gauge.Specification) int {
+	if p, ok := priorityFromTags(spec.Tags); ok {
+		return p
+	}
+	priority, found := defaultPriority, false
+	for _, sce := range spec.Scenarios {
+		if p, ok := priorityFromTags(sce.Tags); ok && (!found || p > priority) {
+			priority, found = p, true
+		}
+	}
+	return priority
+}
+
+func priorityFromTags(tags *gauge.Tags) (int, bool) {
+	if tags == nil {
+		return defaultPriority, false
+	}
+	for _, t := range tags.Values() {
+		t = strings.TrimSpace(t)
+		if !strings.HasPrefix(strings.ToLower(t), priorityTagPrefix) {
+			continue
+		}
+		if p, err := strconv.Atoi(strings.TrimSpace(t[len(priorityTagPrefix):])); err == nil {
+			return p, true
+		}
+	}
+	return defaultPriority, false
+}
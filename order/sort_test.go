@@ -41,3 +41,43 @@ func TestToSortSpecs(t *testing.T) {
 		}
 	}
 }
+
+func TestToSortSpecsByPriority(t *testing.T) {
+	spec1 := &gauge.Specification{FileName: "a"}
+	spec2 := &gauge.Specification{FileName: "b", Tags: &gauge.Tags{RawValues: [][]string{{"smoke", "priority:2"}}}}
+	spec3 := &gauge.Specification{FileName: "c", Scenarios: []*gauge.Scenario{
+		{Tags: &gauge.Tags{RawValues: [][]string{{"priority:1"}}}},
+		{Tags: &gauge.Tags{RawValues: [][]string{{"priority:5"}}}},
+	}}
+	spec4 := &gauge.Specification{FileName: "d", Tags: &gauge.Tags{RawValues: [][]string{{"priority:-1"}}}}
+
+	got := SortByPriority([]*gauge.Specification{spec1, spec4, spec2, spec3})
+	expected := []*gauge.Specification{spec3, spec2, spec1, spec4}
+
+	for i, s := range got {
+		if expected[i].FileName != s.FileName {
+			t.Errorf("Expected '%s' at position %d, got %s", expected[i].FileName, i, s.FileName)
+		}
+	}
+}
+
+func TestNegativePrioritySortsAfterSpecsWithoutPriority(t *testing.T) {
+	spec1 := &gauge.Specification{FileName: "a", Scenarios: []*gauge.Scenario{
+		{Tags: &gauge.Tags{RawValues: [][]string{{"priority:-2"}}}},
+		{Tags: &gauge.Tags{RawValues: [][]string{{"priority:-1"}}}},
+	}}
+	spec2 := &gauge.Specification{FileName: "b", Tags: &gauge.Tags{RawValues: [][]string{{"priority:-3"}}}}
+	spec3 := &gauge.Specification{FileName: "c", Scenarios: []*gauge.Scenario{{}}}
+
+	got := SortByPriority([]*gauge.Specification{spec2, spec1, spec3})
+	expected := []*gauge.Specification{spec3, spec1, spec2}
+
+	for i, s := range got {
+		if expected[i].FileName != s.FileName {
+			t.Errorf("Expected '%s' at position %d, got %s", expected[i].FileName, i, s.FileName)
+		}
+	}
+	if p := Priority(spec1); p != -1 {
+		t.Errorf("Expected the highest priority among the scenarios, -1. Got %d", p)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package orphans keeps track of the runner and plugin processes started by a run of gauge, so that the processes left
+// behind by runs which crashed or were killed can be cleaned up later.
+//
+// Every run writes the processes it started to <gauge home>/pids/<pid of gauge>.json and removes them as they exit.
+// Only the processes started directly by gauge are tracked, not the processes they start in turn.
+package orphans
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/logger"
+)
+
+const (
+	pidsDir = "pids"
+	// Runner is the kind of a language runner process.
+	Runner = "runner"
+	// Plugin is the kind of a plugin process.
+	Plugin = "plugin"
+)
+
+// Process is a runner or plugin process started by gauge.
+type Process struct {
+	Pid     int    `json:"pid"`
+	Kind    string `json:"kind"`
+	Command string `json:"command"`
+}
+
+type run struct {
+	Gauge     int       `json:"gauge"`
+	Processes []Process `json:"processes"`
+}
+
+var (
+	mutex   = &sync.Mutex{}
+	current = &run{Gauge: os.Getpid()}
+)
+
+var pidsDirectory = func() (string, error) {
+	home, err := common.GetGaugeHomeDirectory()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, pidsDir), nil
+}
+
+var isRunning = running
+
+var kill = func(pid int) error {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return p.Kill()
+}
+
+func runFile(dir string, pid int) string {
+	return filepath.Join(dir, fmt.Sprintf("%d.json", pid))
+}
+
+// Track records a process started by this run of gauge.
+func Track(cmd *exec.Cmd, kind string) {
+	if cmd == nil || cmd.Process == nil {
+		return
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	current.Processes = append(current.Processes, Process{Pid: cmd.Process.Pid, Kind: kind, Command: cmd.Path})
+	save(current)
+}
+
+// Untrack forgets a process which has exited or has been killed by this run of gauge.
+func Untrack(pid int) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	var processes []Process
+	for _, p := range current.Processes {
+		if p.Pid != pid {
+			processes = append(processes, p)
+		}
+	}
+	if len(processes) == len(current.Processes) {
+		return
+	}
+	current.Processes = processes
+	save(current)
+}
+
+func save(r *run) {
+	dir, err := pidsDirectory()
+	if err != nil {
+		logger.Debugf(true, "Failed to track runner and plugin processes. %s", err.Error())
+		return
+	}
+	f := runFile(dir, r.Gauge)
+	if len(r.Processes) == 0 {
+		os.Remove(f)
+		return
+	}
+	if err = os.MkdirAll(dir, common.NewDirectoryPermissions); err != nil {
+		logger.Debugf(true, "Failed to create directory %s. %s", dir, err.Error())
+		return
+	}
+	b, err := json.MarshalIndent(r, "", "\t")
+	if err != nil {
+		logger.Debugf(true, "Failed to track runner and plugin processes. %s", err.Error())
+		return
+	}
+	if err = ioutil.WriteFile(f, b, common.NewFilePermissions); err != nil {
+		logger.Debugf(true, "Failed to write to %s. %s", f, err.Error())
+	}
+}
+
+// Cleanup kills the processes left behind by the runs of gauge which are no longer running, and gives the processes it killed.
+// A process is killed only if it is still running the command it was started with, so that reused pids are left alone.
+func Cleanup() ([]Process, error) {
+	dir, err := pidsDirectory()
+	if err != nil {
+		return nil, err
+	}
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read %s. %s", dir, err.Error())
+	}
+	var killed []Process
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		file := filepath.Join(dir, f.Name())
+		var r run
+		b, err := ioutil.ReadFile(file)
+		if err == nil {
+			err = json.Unmarshal(b, &r)
+		}
+		if err != nil {
+			logger.Debugf(true, "Removing unreadable file %s. %s", file, err.Error())
+			os.Remove(file)
+			continue
+		}
+		if r.Gauge == os.Getpid() || isRunning(r.Gauge, "") {
+			continue
+		}
+		for _, p := range r.Processes {
+			if !isRunning(p.Pid, p.Command) {
+				continue
+			}
+			if err := kill(p.Pid); err != nil {
+				logger.Warningf(true, "Failed to kill %s process %d (%s). %s", p.Kind, p.Pid, p.Command, err.Error())
+				continue
+			}
+			killed = append(killed, p)
+		}
+		os.Remove(file)
+	}
+	return killed, nil
+}
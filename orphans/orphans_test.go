@@ -0,0 +1,136 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package orphans
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"testing"
+
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct {
+	dir string
+}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) SetUpTest(c *C) {
+	dir, err := ioutil.TempDir("", "orphans")
+	c.Assert(err, IsNil)
+	s.dir = dir
+	pidsDirectory = func() (string, error) { return dir, nil }
+	current = &run{Gauge: os.Getpid()}
+}
+
+func (s *MySuite) TearDownTest(c *C) {
+	os.RemoveAll(s.dir)
+	isRunning = running
+	kill = func(pid int) error {
+		p, err := os.FindProcess(pid)
+		if err != nil {
+			return err
+		}
+		return p.Kill()
+	}
+}
+
+func (s *MySuite) writeRun(c *C, r run) string {
+	b, err := json.Marshal(r)
+	c.Assert(err, IsNil)
+	f := runFile(s.dir, r.Gauge)
+	c.Assert(ioutil.WriteFile(f, b, 0644), IsNil)
+	return f
+}
+
+func (s *MySuite) TestTrackAndUntrackWriteTheRunFile(c *C) {
+	Track(&exec.Cmd{Path: "bin/gauge-java", Process: &os.Process{Pid: 101}}, Runner)
+	Track(&exec.Cmd{Path: "bin/html-report", Process: &os.Process{Pid: 102}}, Plugin)
+
+	b, err := ioutil.ReadFile(runFile(s.dir, os.Getpid()))
+	c.Assert(err, IsNil)
+	var r run
+	c.Assert(json.Unmarshal(b, &r), IsNil)
+	c.Assert(r.Gauge, Equals, os.Getpid())
+	c.Assert(r.Processes, DeepEquals, []Process{{Pid: 101, Kind: Runner, Command: "bin/gauge-java"}, {Pid: 102, Kind: Plugin, Command: "bin/html-report"}})
+
+	Untrack(101)
+	Untrack(102)
+
+	_, err = os.Stat(runFile(s.dir, os.Getpid()))
+	c.Assert(os.IsNotExist(err), Equals, true)
+}
+
+func (s *MySuite) TestCleanupKillsProcessesOfRunsWhichAreNotRunning(c *C) {
+	deadRun := s.writeRun(c, run{Gauge: 1, Processes: []Process{{Pid: 11, Kind: Runner, Command: "bin/gauge-java"}, {Pid: 12, Kind: Plugin, Command: "bin/html-report"}}})
+	liveRun := s.writeRun(c, run{Gauge: 2, Processes: []Process{{Pid: 21, Kind: Runner, Command: "bin/gauge-java"}}})
+	alive := map[int]bool{2: true, 11: true, 21: true}
+	isRunning = func(pid int, command string) bool { return alive[pid] }
+	var killedPids []int
+	kill = func(pid int) error {
+		killedPids = append(killedPids, pid)
+		return nil
+	}
+
+	killed, err := Cleanup()
+
+	c.Assert(err, IsNil)
+	c.Assert(killed, DeepEquals, []Process{{Pid: 11, Kind: Runner, Command: "bin/gauge-java"}})
+	c.Assert(killedPids, DeepEquals, []int{11})
+	_, err = os.Stat(deadRun)
+	c.Assert(os.IsNotExist(err), Equals, true)
+	_, err = os.Stat(liveRun)
+	c.Assert(err, IsNil)
+}
+
+func (s *MySuite) TestCleanupSkipsTheCurrentRun(c *C) {
+	Track(&exec.Cmd{Path: "bin/gauge-java", Process: &os.Process{Pid: 101}}, Runner)
+	isRunning = func(pid int, command string) bool { return pid == 101 }
+	kill = func(pid int) error {
+		c.Fatalf("should not kill %d", pid)
+		return nil
+	}
+
+	killed, err := Cleanup()
+
+	c.Assert(err, IsNil)
+	c.Assert(len(killed), Equals, 0)
+}
+
+func (s *MySuite) TestCleanupWithoutPidsDirectory(c *C) {
+	os.RemoveAll(s.dir)
+
+	killed, err := Cleanup()
+
+	c.Assert(err, IsNil)
+	c.Assert(len(killed), Equals, 0)
+}
+
+func (s *MySuite) TestRunningChecksTheCommand(c *C) {
+	self, err := os.Executable()
+	c.Assert(err, IsNil)
+
+	c.Assert(running(os.Getpid(), ""), Equals, true)
+	c.Assert(running(os.Getpid(), self), Equals, true)
+	c.Assert(running(os.Getpid(), "bin/some-other-runner"), Equals, false)
+}
@@ -0,0 +1,258 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package owners tells which teams own a spec or scenario. The owners are given by the owner metadata of the scenario,
+// else of its spec, else by the owners file of the project.
+//
+// Like CODEOWNERS, every line of the owners file maps a path pattern to one or more owners, and the last line
+// matching a spec file wins. Patterns are relative to the project root and use the syntax of path.Match. A pattern
+// without a slash matches a file or directory of that name anywhere, one with a trailing slash matches directories only.
+package owners
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/util"
+)
+
+// OwnerKey is the metadata key naming the owners of a spec or scenario. Many owners are separated by spaces.
+const OwnerKey = "owner"
+
+type rule struct {
+	pattern string
+	owners  []string
+}
+
+// Owners holds the rules of the owners file.
+type Owners struct {
+	rules []rule
+}
+
+// Load reads the owners file given by the owners_file property. A missing file has no rules.
+func Load() (*Owners, error) {
+	file := ownersFile()
+	if file == "" {
+		return &Owners{}, nil
+	}
+	f, err := os.Open(file)
+	if os.IsNotExist(err) {
+		return &Owners{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	o, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read owners file %s. %s", file, err.Error())
+	}
+	return o, nil
+}
+
+func ownersFile() string {
+	file := strings.TrimSpace(os.Getenv(env.OwnersFile))
+	if file != "" && !filepath.IsAbs(file) {
+		file = filepath.Join(config.ProjectRoot, file)
+	}
+	return file
+}
+
+// RenameSpecs gives the new contents of the owners file, with the patterns naming a spec or directory renamed by
+// rename, keyed by file. rename is given the paths relative to the project root, with forward slashes, and tells
+// whether the path was renamed. Patterns with wildcards or without a slash, which may match other paths, are kept.
+func RenameSpecs(rename func(string) (string, bool)) (map[string][]byte, error) {
+	contents := make(map[string][]byte)
+	file := ownersFile()
+	if file == "" || !common.FileExists(file) {
+		return contents, nil
+	}
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.SplitAfter(string(b), "\n")
+	changed := false
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") || strings.ContainsAny(fields[0], "*?[\\") {
+			continue
+		}
+		pattern := strings.Trim(fields[0], "/")
+		if !strings.Contains(pattern, "/") && !strings.HasPrefix(fields[0], "/") {
+			continue
+		}
+		if to, ok := rename(pattern); ok {
+			lines[i], changed = strings.Replace(line, pattern, to, 1), true
+		}
+	}
+	if changed {
+		contents[file] = []byte(strings.Join(lines, ""))
+	}
+	return contents, nil
+}
+
+// Parse reads the rules of an owners file. Blank lines and lines starting with # are skipped.
+func Parse(r io.Reader) (*Owners, error) {
+	o := &Owners{}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("Line %d has no owners. Lines should be given as <pattern> <owner>...", n)
+		}
+		if _, err := path.Match(strings.Trim(fields[0], "/"), ""); err != nil {
+			return nil, fmt.Errorf("Invalid pattern %s on line %d", fields[0], n)
+		}
+		o.rules = append(o.rules, rule{pattern: fields[0], owners: fields[1:]})
+	}
+	return o, scanner.Err()
+}
+
+// Of gives the owners of a scenario of the given spec file. The metadata of the spec and scenario are given as tags,
+// as in their results, see gauge.MetaTags.
+func (o *Owners) Of(fileName string, specTags, scenarioTags []string) []string {
+	for _, tags := range [][]string{scenarioTags, specTags} {
+		if owners := fromTags(tags); len(owners) > 0 {
+			return owners
+		}
+	}
+	return o.OfFile(fileName)
+}
+
+// OfFile gives the owners of a spec file by the owners file.
+func (o *Owners) OfFile(fileName string) []string {
+	if o == nil {
+		return nil
+	}
+	file := filepath.ToSlash(util.RelPathToProjectRoot(fileName))
+	for i := len(o.rules) - 1; i >= 0; i-- {
+		if matches(o.rules[i].pattern, file) {
+			return o.rules[i].owners
+		}
+	}
+	return nil
+}
+
+func fromTags(tags []string) []string {
+	for _, t := range tags {
+		if k, v, ok := gauge.ParseMetaTag(t); ok && k == OwnerKey {
+			return strings.Fields(v)
+		}
+	}
+	return nil
+}
+
+// matches tells if the pattern matches the file or any of the directories it is in.
+func matches(pattern, file string) bool {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.Trim(pattern, "/")
+	parts := strings.Split(file, "/")
+	for start := range parts {
+		if anchored && start > 0 {
+			break
+		}
+		for end := start + 1; end <= len(parts); end++ {
+			if dirOnly && end == len(parts) {
+				continue
+			}
+			if ok, _ := path.Match(pattern, strings.Join(parts[start:end], "/")); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Group holds the failures owned by a team, as spec file > scenario. Specs failing outside of their scenarios, like in
+// hooks, are given by their file alone.
+type Group struct {
+	Owner    string
+	Failures []string
+}
+
+// GroupFailures gives the failures of the suite grouped by their owners, sorted by owner, with the failures having no
+// owner last, under an empty owner. A failure with many owners is in the group of each of them. Nothing is returned
+// if none of the failures has an owner.
+func (o *Owners) GroupFailures(res *result.SuiteResult) []*Group {
+	groups := make(map[string]*Group)
+	owned := false
+	add := func(owners []string, failure string) {
+		if len(owners) == 0 {
+			owners = []string{""}
+		} else {
+			owned = true
+		}
+		for _, owner := range owners {
+			if groups[owner] == nil {
+				groups[owner] = &Group{Owner: owner}
+			}
+			groups[owner].Failures = append(groups[owner].Failures, failure)
+		}
+	}
+	res.EachSpecResult(func(specRes *result.SpecResult) {
+		spec := specRes.ProtoSpec
+		file := filepath.ToSlash(util.RelPathToProjectRoot(spec.GetFileName()))
+		scenarioFailed := false
+		for _, item := range spec.GetItems() {
+			sce := item.GetScenario()
+			if item.GetItemType() == gauge_messages.ProtoItem_TableDrivenScenario {
+				sce = item.GetTableDrivenScenario().GetScenario()
+			}
+			if sce.GetExecutionStatus() != gauge_messages.ExecutionStatus_FAILED {
+				continue
+			}
+			scenarioFailed = true
+			add(o.Of(spec.GetFileName(), spec.GetTags(), sce.GetTags()), fmt.Sprintf("%s > %s", file, sce.GetScenarioHeading()))
+		}
+		if specRes.GetFailed() && !scenarioFailed {
+			add(o.Of(spec.GetFileName(), spec.GetTags(), nil), file)
+		}
+	})
+	if !owned {
+		return nil
+	}
+	var sorted []*Group
+	for _, g := range groups {
+		sorted = append(sorted, g)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Owner == "" || sorted[j].Owner == "" {
+			return sorted[j].Owner == ""
+		}
+		return sorted[i].Owner < sorted[j].Owner
+	})
+	return sorted
+}
@@ -0,0 +1,154 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package owners
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge_messages"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) SetUpTest(c *C) {
+	config.ProjectRoot = filepath.FromSlash("/project")
+}
+
+func (s *MySuite) TearDownTest(c *C) {
+	config.ProjectRoot = ""
+	os.Unsetenv(env.OwnersFile)
+}
+
+func (s *MySuite) TestParse(c *C) {
+	o, err := Parse(strings.NewReader("# owners\n\nspecs/payments/ team-payments\n*.spec  team-qa team-dev\n"))
+
+	c.Assert(err, IsNil)
+	c.Assert(o.rules, DeepEquals, []rule{{pattern: "specs/payments/", owners: []string{"team-payments"}}, {pattern: "*.spec", owners: []string{"team-qa", "team-dev"}}})
+}
+
+func (s *MySuite) TestParseLineWithoutOwners(c *C) {
+	_, err := Parse(strings.NewReader("*.spec team-qa\nspecs/\n"))
+
+	c.Assert(err, ErrorMatches, "Line 2 has no owners.*")
+}
+
+func (s *MySuite) TestParseInvalidPattern(c *C) {
+	_, err := Parse(strings.NewReader("specs/[ team-qa\n"))
+
+	c.Assert(err, ErrorMatches, "Invalid pattern specs/\\[ on line 1")
+}
+
+func (s *MySuite) TestMatches(c *C) {
+	c.Assert(matches("*.spec", "specs/a/b.spec"), Equals, true)
+	c.Assert(matches("a", "specs/a/b.spec"), Equals, true)
+	c.Assert(matches("b.spec/", "specs/a/b.spec"), Equals, false)
+	c.Assert(matches("specs/a/", "specs/a/b.spec"), Equals, true)
+	c.Assert(matches("a/b.spec", "specs/a/b.spec"), Equals, false)
+	c.Assert(matches("specs/*/b.spec", "specs/a/b.spec"), Equals, true)
+}
+
+func (s *MySuite) TestOfFileLastMatchingRuleWins(c *C) {
+	o, _ := Parse(strings.NewReader("* team-all\nspecs/payments/ team-payments\n"))
+
+	c.Assert(o.OfFile(filepath.FromSlash("/project/specs/payments/refund.spec")), DeepEquals, []string{"team-payments"})
+	c.Assert(o.OfFile(filepath.FromSlash("/project/specs/login.spec")), DeepEquals, []string{"team-all"})
+}
+
+func (s *MySuite) TestOfPrefersScenarioThenSpecMetadata(c *C) {
+	o, _ := Parse(strings.NewReader("* team-all\n"))
+	file := filepath.FromSlash("/project/specs/a.spec")
+
+	c.Assert(o.Of(file, []string{"meta:owner=team-a"}, []string{"smoke", "meta:owner=team-b team-c"}), DeepEquals, []string{"team-b", "team-c"})
+	c.Assert(o.Of(file, []string{"meta:owner=team-a"}, []string{"smoke"}), DeepEquals, []string{"team-a"})
+	c.Assert(o.Of(file, nil, nil), DeepEquals, []string{"team-all"})
+}
+
+func (s *MySuite) TestLoadWithoutOwnersFile(c *C) {
+	config.ProjectRoot = c.MkDir()
+	os.Setenv(env.OwnersFile, "OWNERS")
+
+	o, err := Load()
+
+	c.Assert(err, IsNil)
+	c.Assert(o.OfFile(filepath.Join(config.ProjectRoot, "specs", "a.spec")), IsNil)
+}
+
+func (s *MySuite) TestGroupFailures(c *C) {
+	o, _ := Parse(strings.NewReader("specs/payments/ team-payments\n"))
+	failed := &gauge_messages.ProtoScenario{ScenarioHeading: "Refund", ExecutionStatus: gauge_messages.ExecutionStatus_FAILED}
+	passed := &gauge_messages.ProtoScenario{ScenarioHeading: "Pay", ExecutionStatus: gauge_messages.ExecutionStatus_PASSED}
+	owned := &gauge_messages.ProtoScenario{ScenarioHeading: "Login", ExecutionStatus: gauge_messages.ExecutionStatus_FAILED, Tags: []string{"meta:owner=team-auth"}}
+	res := &result.SuiteResult{SpecResults: []*result.SpecResult{
+		{IsFailed: true, ProtoSpec: &gauge_messages.ProtoSpec{FileName: filepath.FromSlash("/project/specs/payments/refund.spec"), Items: []*gauge_messages.ProtoItem{
+			{ItemType: gauge_messages.ProtoItem_Scenario, Scenario: failed},
+			{ItemType: gauge_messages.ProtoItem_Scenario, Scenario: passed},
+		}}},
+		{IsFailed: true, ProtoSpec: &gauge_messages.ProtoSpec{FileName: filepath.FromSlash("/project/specs/login.spec"), Items: []*gauge_messages.ProtoItem{
+			{ItemType: gauge_messages.ProtoItem_Scenario, Scenario: owned},
+		}}},
+		{IsFailed: true, ProtoSpec: &gauge_messages.ProtoSpec{FileName: filepath.FromSlash("/project/specs/hooks.spec")}},
+	}}
+
+	groups := o.GroupFailures(res)
+
+	c.Assert(groups, DeepEquals, []*Group{
+		{Owner: "team-auth", Failures: []string{"specs/login.spec > Login"}},
+		{Owner: "team-payments", Failures: []string{"specs/payments/refund.spec > Refund"}},
+		{Owner: "", Failures: []string{"specs/hooks.spec"}},
+	})
+}
+
+func (s *MySuite) TestGroupFailuresWithoutOwners(c *C) {
+	res := &result.SuiteResult{SpecResults: []*result.SpecResult{{IsFailed: true, ProtoSpec: &gauge_messages.ProtoSpec{FileName: filepath.FromSlash("/project/specs/a.spec")}}}}
+
+	c.Assert((&Owners{}).GroupFailures(res), IsNil)
+}
+
+func (s *MySuite) TestRenameSpecs(c *C) {
+	dir := c.MkDir()
+	config.ProjectRoot = dir
+	file := filepath.Join(dir, "OWNERS")
+	ioutil.WriteFile(file, []byte("# specs/a.spec is the oldest\nspecs/a.spec  team-a\n/specs/pay/ team-pay\na.spec team-b\nspecs/*.spec team-c\n"), 0644)
+	os.Setenv(env.OwnersFile, "OWNERS")
+	rename := func(p string) (string, bool) {
+		if p == "specs/a.spec" {
+			return "specs/auth/login.spec", true
+		}
+		if p == "specs/pay" {
+			return "specs/payments", true
+		}
+		return p, false
+	}
+
+	contents, err := RenameSpecs(rename)
+
+	c.Assert(err, IsNil)
+	c.Assert(string(contents[file]), Equals, "# specs/a.spec is the oldest\nspecs/auth/login.spec  team-a\n/specs/payments/ team-pay\na.spec team-b\nspecs/*.spec team-c\n")
+}
@@ -0,0 +1,219 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/parser/cache"
+	"github.com/getgauge/gauge/version"
+	"github.com/golang/protobuf/proto"
+)
+
+const cacheDir = "cache"
+
+var pruneCacheOnce sync.Once
+
+// parseSpecWithCache parses the given spec file contents, taking the parsed spec from .gauge/cache if the file has not
+// changed since it was last parsed. The concept steps of the spec are replaced after it is taken from the cache, since
+// the concepts can change independently of the spec.
+func parseSpecWithCache(text, fileName string, conceptDictionary *gauge.ConceptDictionary) (*gauge.Specification, *ParseResult, error) {
+	parser := new(SpecParser)
+	cacheFile := cacheFilePath(fileName)
+	if cacheFile == "" {
+		tokens, errs := parser.GenerateTokens(text, fileName)
+		return parser.parseTokens(tokens, errs, conceptDictionary, fileName)
+	}
+	hash := contentHash(text)
+	if entry, ok := readCacheEntry(cacheFile); ok && entry.Hash == hash && entry.Spec != nil {
+		if spec, ok := entrySpec(entry, fileName); ok {
+			res, errs := entryResult(entry)
+			return parser.completeSpecification(spec, res, errs, conceptDictionary, fileName)
+		}
+	}
+	tokens, errs := parser.GenerateTokens(text, fileName)
+	spec, res := parser.createSpecification(tokens, fileName)
+	if !readsFiles(tokens) {
+		writeCacheEntry(cacheFile, newSpecCacheEntry(hash, spec, res, errs))
+	}
+	return parser.completeSpecification(spec, res, errs, conceptDictionary, fileName)
+}
+
+// parseConceptsWithCache parses the given concept file contents, taking the concepts from .gauge/cache if the file has not
+// changed since it was last parsed.
+func parseConceptsWithCache(text, fileName string) ([]*gauge.Step, *ParseResult) {
+	cacheFile := cacheFilePath(fileName)
+	if cacheFile == "" {
+		tokens, errs := new(SpecParser).GenerateTokens(text, fileName)
+		return new(ConceptParser).parseTokens(tokens, errs, fileName)
+	}
+	hash := contentHash(text)
+	if entry, ok := readCacheEntry(cacheFile); ok && entry.Hash == hash && entry.Spec == nil {
+		if concepts, ok := entryConcepts(entry, fileName); ok {
+			res, errs := entryResult(entry)
+			return concepts, &ParseResult{ParseErrors: append(errs, res.ParseErrors...), Warnings: res.Warnings}
+		}
+	}
+	tokens, errs := new(SpecParser).GenerateTokens(text, fileName)
+	concepts, res := new(ConceptParser).createConcepts(tokens, fileName)
+	if !readsFiles(tokens) {
+		writeCacheEntry(cacheFile, newConceptCacheEntry(hash, concepts, res, errs))
+	}
+	return concepts, &ParseResult{ParseErrors: append(errs, res.ParseErrors...), Warnings: res.Warnings}
+}
+
+var fileParamMatcher = regexp.MustCompile("^<file:.*>$")
+
+// readsFiles tells if parsing the tokens reads other files, like the ones of special params and external data tables,
+// in which case the parsed file is not cached, as the files it reads can change independently of it.
+func readsFiles(tokens []*Token) bool {
+	for _, token := range tokens {
+		switch token.Kind {
+		case gauge.DataTableKind:
+			return true
+		case gauge.StepKind:
+			_, argTypes := extractStepValueAndParameterTypes(token.Value)
+			for _, argType := range argTypes {
+				if argType == "special" {
+					return true
+				}
+			}
+		case gauge.TableHeader, gauge.TableRow:
+			for _, arg := range token.Args {
+				if fileParamMatcher.MatchString(strings.TrimSpace(arg)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// contentHash includes the gauge version, the parser toggles and the keywords, since the parsed file depends on them.
+func contentHash(text string) string {
+	h := sha256.New()
+	h.Write([]byte(version.FullVersion()))
+	h.Write([]byte(strconv.FormatBool(env.AllowMultiLineStep())))
+	h.Write([]byte(strconv.FormatBool(env.AllowScenarioDatatable())))
+	for _, keyword := range []string{TagsKeyword, TableKeyword, TearDownKeyword, MetaKeyword} {
+		h.Write([]byte(Keyword(keyword)))
+	}
+	h.Write([]byte(text))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cacheFilePath gives the path of the cache entry of the given file, which mirrors the path of the file in the project,
+// so that the entries of the files which no longer exist can be found. It is empty if the cache is not enabled or the
+// file is outside the project.
+func cacheFilePath(fileName string) string {
+	if !env.EnableParseCache() {
+		return ""
+	}
+	abs, err := filepath.Abs(fileName)
+	if err != nil {
+		return ""
+	}
+	rel, err := filepath.Rel(config.ProjectRoot, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return ""
+	}
+	pruneCacheOnce.Do(pruneCache)
+	return filepath.Join(cacheRoot(), rel)
+}
+
+func cacheRoot() string {
+	return filepath.Join(config.ProjectRoot, common.DotGauge, cacheDir)
+}
+
+// pruneCache removes the entries of the files which no longer exist in the project.
+func pruneCache() {
+	root := cacheRoot()
+	filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil
+		}
+		if _, err := os.Stat(filepath.Join(config.ProjectRoot, rel)); os.IsNotExist(err) {
+			if err := os.Remove(path); err != nil {
+				logger.Debugf(true, "Failed to remove parse cache %s. %s", path, err.Error())
+			}
+		}
+		return nil
+	})
+}
+
+func readCacheEntry(cacheFile string) (*cache.Entry, bool) {
+	b, err := ioutil.ReadFile(cacheFile)
+	if err != nil {
+		return nil, false
+	}
+	entry := &cache.Entry{}
+	if err := proto.Unmarshal(b, entry); err != nil {
+		logger.Debugf(true, "Ignoring invalid parse cache %s. %s", cacheFile, err.Error())
+		return nil, false
+	}
+	return entry, true
+}
+
+// writeCacheEntry writes to a temporary file first and renames it, so that concurrent readers
+// never see a partially written entry.
+func writeCacheEntry(cacheFile string, entry *cache.Entry) {
+	if !refersToAll(entry) {
+		return
+	}
+	dir := filepath.Dir(cacheFile)
+	if err := os.MkdirAll(dir, common.NewDirectoryPermissions); err != nil {
+		logger.Debugf(true, "Failed to create directory %s. %s", dir, err.Error())
+		return
+	}
+	b, err := proto.Marshal(entry)
+	if err != nil {
+		logger.Debugf(true, "Failed to encode parse cache. %s", err.Error())
+		return
+	}
+	f, err := ioutil.TempFile(dir, "tmp")
+	if err != nil {
+		logger.Debugf(true, "Failed to write parse cache. %s", err.Error())
+		return
+	}
+	_, err = f.Write(b)
+	f.Close()
+	if err == nil {
+		err = os.Rename(f.Name(), cacheFile)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		logger.Debugf(true, "Failed to write parse cache %s. %s", cacheFile, err.Error())
+	}
+}
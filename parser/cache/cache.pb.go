@@ -0,0 +1,1233 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: cache.proto
+
+package cache
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Item_Kind int32
+
+const (
+	Item_Scenario     Item_Kind = 0
+	Item_Step         Item_Kind = 1
+	Item_TearDownStep Item_Kind = 2
+	Item_Comment      Item_Kind = 3
+	Item_TearDown     Item_Kind = 4
+	Item_Tags         Item_Kind = 5
+	Item_Meta         Item_Kind = 6
+	Item_DataTable    Item_Kind = 7
+	Item_Concept      Item_Kind = 8
+)
+
+var Item_Kind_name = map[int32]string{
+	0: "Scenario",
+	1: "Step",
+	2: "TearDownStep",
+	3: "Comment",
+	4: "TearDown",
+	5: "Tags",
+	6: "Meta",
+	7: "DataTable",
+	8: "Concept",
+}
+
+var Item_Kind_value = map[string]int32{
+	"Scenario":     0,
+	"Step":         1,
+	"TearDownStep": 2,
+	"Comment":      3,
+	"TearDown":     4,
+	"Tags":         5,
+	"Meta":         6,
+	"DataTable":    7,
+	"Concept":      8,
+}
+
+func (x Item_Kind) String() string {
+	return proto.EnumName(Item_Kind_name, int32(x))
+}
+
+func (Item_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5fca3b110c9bbf3a, []int{1, 0}
+}
+
+// / Entry of the parse cache, holding a spec as it is parsed, before its concept steps are replaced, or the concepts
+// / of a concept file, with the errors and warnings of parsing the file.
+type Entry struct {
+	// / Hash of the contents of the parsed file
+	Hash                 string     `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Spec                 *Spec      `protobuf:"bytes,2,opt,name=spec,proto3" json:"spec,omitempty"`
+	Concepts             []*Step    `protobuf:"bytes,3,rep,name=concepts,proto3" json:"concepts,omitempty"`
+	LexErrors            []*Error   `protobuf:"bytes,4,rep,name=lexErrors,proto3" json:"lexErrors,omitempty"`
+	Errors               []*Error   `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+	Warnings             []*Warning `protobuf:"bytes,6,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	Ok                   bool       `protobuf:"varint,7,opt,name=ok,proto3" json:"ok,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *Entry) Reset()         { *m = Entry{} }
+func (m *Entry) String() string { return proto.CompactTextString(m) }
+func (*Entry) ProtoMessage()    {}
+func (*Entry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5fca3b110c9bbf3a, []int{0}
+}
+
+func (m *Entry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Entry.Unmarshal(m, b)
+}
+func (m *Entry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Entry.Marshal(b, m, deterministic)
+}
+func (m *Entry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Entry.Merge(m, src)
+}
+func (m *Entry) XXX_Size() int {
+	return xxx_messageInfo_Entry.Size(m)
+}
+func (m *Entry) XXX_DiscardUnknown() {
+	xxx_messageInfo_Entry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Entry proto.InternalMessageInfo
+
+func (m *Entry) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *Entry) GetSpec() *Spec {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+func (m *Entry) GetConcepts() []*Step {
+	if m != nil {
+		return m.Concepts
+	}
+	return nil
+}
+
+func (m *Entry) GetLexErrors() []*Error {
+	if m != nil {
+		return m.LexErrors
+	}
+	return nil
+}
+
+func (m *Entry) GetErrors() []*Error {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+func (m *Entry) GetWarnings() []*Warning {
+	if m != nil {
+		return m.Warnings
+	}
+	return nil
+}
+
+func (m *Entry) GetOk() bool {
+	if m != nil {
+		return m.Ok
+	}
+	return false
+}
+
+// / Items of specs, scenarios and concepts, which refer to one of the lists of their parent by index.
+type Item struct {
+	Kind                 Item_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=gauge.cache.Item_Kind" json:"kind,omitempty"`
+	Index                int32     `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Item) Reset()         { *m = Item{} }
+func (m *Item) String() string { return proto.CompactTextString(m) }
+func (*Item) ProtoMessage()    {}
+func (*Item) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5fca3b110c9bbf3a, []int{1}
+}
+
+func (m *Item) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Item.Unmarshal(m, b)
+}
+func (m *Item) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Item.Marshal(b, m, deterministic)
+}
+func (m *Item) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Item.Merge(m, src)
+}
+func (m *Item) XXX_Size() int {
+	return xxx_messageInfo_Item.Size(m)
+}
+func (m *Item) XXX_DiscardUnknown() {
+	xxx_messageInfo_Item.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Item proto.InternalMessageInfo
+
+func (m *Item) GetKind() Item_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return Item_Scenario
+}
+
+func (m *Item) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+type Spec struct {
+	Heading       *Heading    `protobuf:"bytes,1,opt,name=heading,proto3" json:"heading,omitempty"`
+	Scenarios     []*Scenario `protobuf:"bytes,2,rep,name=scenarios,proto3" json:"scenarios,omitempty"`
+	Comments      []*Comment  `protobuf:"bytes,3,rep,name=comments,proto3" json:"comments,omitempty"`
+	DataTable     *DataTable  `protobuf:"bytes,4,opt,name=dataTable,proto3" json:"dataTable,omitempty"`
+	Contexts      []*Step     `protobuf:"bytes,5,rep,name=contexts,proto3" json:"contexts,omitempty"`
+	Tags          *Tags       `protobuf:"bytes,6,opt,name=tags,proto3" json:"tags,omitempty"`
+	Meta          *Meta       `protobuf:"bytes,7,opt,name=meta,proto3" json:"meta,omitempty"`
+	Items         []*Item     `protobuf:"bytes,8,rep,name=items,proto3" json:"items,omitempty"`
+	TearDownSteps []*Step     `protobuf:"bytes,9,rep,name=tearDownSteps,proto3" json:"tearDownSteps,omitempty"`
+	// / The teardown markers of the spec, which have a value and a line like comments
+	TearDowns            []*Comment `protobuf:"bytes,10,rep,name=tearDowns,proto3" json:"tearDowns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *Spec) Reset()         { *m = Spec{} }
+func (m *Spec) String() string { return proto.CompactTextString(m) }
+func (*Spec) ProtoMessage()    {}
+func (*Spec) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5fca3b110c9bbf3a, []int{2}
+}
+
+func (m *Spec) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Spec.Unmarshal(m, b)
+}
+func (m *Spec) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Spec.Marshal(b, m, deterministic)
+}
+func (m *Spec) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Spec.Merge(m, src)
+}
+func (m *Spec) XXX_Size() int {
+	return xxx_messageInfo_Spec.Size(m)
+}
+func (m *Spec) XXX_DiscardUnknown() {
+	xxx_messageInfo_Spec.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Spec proto.InternalMessageInfo
+
+func (m *Spec) GetHeading() *Heading {
+	if m != nil {
+		return m.Heading
+	}
+	return nil
+}
+
+func (m *Spec) GetScenarios() []*Scenario {
+	if m != nil {
+		return m.Scenarios
+	}
+	return nil
+}
+
+func (m *Spec) GetComments() []*Comment {
+	if m != nil {
+		return m.Comments
+	}
+	return nil
+}
+
+func (m *Spec) GetDataTable() *DataTable {
+	if m != nil {
+		return m.DataTable
+	}
+	return nil
+}
+
+func (m *Spec) GetContexts() []*Step {
+	if m != nil {
+		return m.Contexts
+	}
+	return nil
+}
+
+func (m *Spec) GetTags() *Tags {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *Spec) GetMeta() *Meta {
+	if m != nil {
+		return m.Meta
+	}
+	return nil
+}
+
+func (m *Spec) GetItems() []*Item {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *Spec) GetTearDownSteps() []*Step {
+	if m != nil {
+		return m.TearDownSteps
+	}
+	return nil
+}
+
+func (m *Spec) GetTearDowns() []*Comment {
+	if m != nil {
+		return m.TearDowns
+	}
+	return nil
+}
+
+type Scenario struct {
+	Heading              *Heading   `protobuf:"bytes,1,opt,name=heading,proto3" json:"heading,omitempty"`
+	Steps                []*Step    `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"`
+	Comments             []*Comment `protobuf:"bytes,3,rep,name=comments,proto3" json:"comments,omitempty"`
+	Tags                 *Tags      `protobuf:"bytes,4,opt,name=tags,proto3" json:"tags,omitempty"`
+	Meta                 *Meta      `protobuf:"bytes,5,opt,name=meta,proto3" json:"meta,omitempty"`
+	Items                []*Item    `protobuf:"bytes,6,rep,name=items,proto3" json:"items,omitempty"`
+	DataTable            *DataTable `protobuf:"bytes,7,opt,name=dataTable,proto3" json:"dataTable,omitempty"`
+	SpanStart            int32      `protobuf:"varint,8,opt,name=spanStart,proto3" json:"spanStart,omitempty"`
+	SpanEnd              int32      `protobuf:"varint,9,opt,name=spanEnd,proto3" json:"spanEnd,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *Scenario) Reset()         { *m = Scenario{} }
+func (m *Scenario) String() string { return proto.CompactTextString(m) }
+func (*Scenario) ProtoMessage()    {}
+func (*Scenario) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5fca3b110c9bbf3a, []int{3}
+}
+
+func (m *Scenario) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Scenario.Unmarshal(m, b)
+}
+func (m *Scenario) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Scenario.Marshal(b, m, deterministic)
+}
+func (m *Scenario) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Scenario.Merge(m, src)
+}
+func (m *Scenario) XXX_Size() int {
+	return xxx_messageInfo_Scenario.Size(m)
+}
+func (m *Scenario) XXX_DiscardUnknown() {
+	xxx_messageInfo_Scenario.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Scenario proto.InternalMessageInfo
+
+func (m *Scenario) GetHeading() *Heading {
+	if m != nil {
+		return m.Heading
+	}
+	return nil
+}
+
+func (m *Scenario) GetSteps() []*Step {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
+
+func (m *Scenario) GetComments() []*Comment {
+	if m != nil {
+		return m.Comments
+	}
+	return nil
+}
+
+func (m *Scenario) GetTags() *Tags {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *Scenario) GetMeta() *Meta {
+	if m != nil {
+		return m.Meta
+	}
+	return nil
+}
+
+func (m *Scenario) GetItems() []*Item {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *Scenario) GetDataTable() *DataTable {
+	if m != nil {
+		return m.DataTable
+	}
+	return nil
+}
+
+func (m *Scenario) GetSpanStart() int32 {
+	if m != nil {
+		return m.SpanStart
+	}
+	return 0
+}
+
+func (m *Scenario) GetSpanEnd() int32 {
+	if m != nil {
+		return m.SpanEnd
+	}
+	return 0
+}
+
+type Step struct {
+	LineNo         int32      `protobuf:"varint,1,opt,name=lineNo,proto3" json:"lineNo,omitempty"`
+	Value          string     `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	LineText       string     `protobuf:"bytes,3,opt,name=lineText,proto3" json:"lineText,omitempty"`
+	Args           []*Arg     `protobuf:"bytes,4,rep,name=args,proto3" json:"args,omitempty"`
+	IsConcept      bool       `protobuf:"varint,5,opt,name=isConcept,proto3" json:"isConcept,omitempty"`
+	ConceptSteps   []*Step    `protobuf:"bytes,6,rep,name=conceptSteps,proto3" json:"conceptSteps,omitempty"`
+	HasInlineTable bool       `protobuf:"varint,7,opt,name=hasInlineTable,proto3" json:"hasInlineTable,omitempty"`
+	PreComments    []*Comment `protobuf:"bytes,8,rep,name=preComments,proto3" json:"preComments,omitempty"`
+	Suffix         string     `protobuf:"bytes,9,opt,name=suffix,proto3" json:"suffix,omitempty"`
+	// / The comments of a concept, referred to by its items
+	Comments             []*Comment `protobuf:"bytes,10,rep,name=comments,proto3" json:"comments,omitempty"`
+	Items                []*Item    `protobuf:"bytes,11,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *Step) Reset()         { *m = Step{} }
+func (m *Step) String() string { return proto.CompactTextString(m) }
+func (*Step) ProtoMessage()    {}
+func (*Step) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5fca3b110c9bbf3a, []int{4}
+}
+
+func (m *Step) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Step.Unmarshal(m, b)
+}
+func (m *Step) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Step.Marshal(b, m, deterministic)
+}
+func (m *Step) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Step.Merge(m, src)
+}
+func (m *Step) XXX_Size() int {
+	return xxx_messageInfo_Step.Size(m)
+}
+func (m *Step) XXX_DiscardUnknown() {
+	xxx_messageInfo_Step.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Step proto.InternalMessageInfo
+
+func (m *Step) GetLineNo() int32 {
+	if m != nil {
+		return m.LineNo
+	}
+	return 0
+}
+
+func (m *Step) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *Step) GetLineText() string {
+	if m != nil {
+		return m.LineText
+	}
+	return ""
+}
+
+func (m *Step) GetArgs() []*Arg {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *Step) GetIsConcept() bool {
+	if m != nil {
+		return m.IsConcept
+	}
+	return false
+}
+
+func (m *Step) GetConceptSteps() []*Step {
+	if m != nil {
+		return m.ConceptSteps
+	}
+	return nil
+}
+
+func (m *Step) GetHasInlineTable() bool {
+	if m != nil {
+		return m.HasInlineTable
+	}
+	return false
+}
+
+func (m *Step) GetPreComments() []*Comment {
+	if m != nil {
+		return m.PreComments
+	}
+	return nil
+}
+
+func (m *Step) GetSuffix() string {
+	if m != nil {
+		return m.Suffix
+	}
+	return ""
+}
+
+func (m *Step) GetComments() []*Comment {
+	if m != nil {
+		return m.Comments
+	}
+	return nil
+}
+
+func (m *Step) GetItems() []*Item {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type Arg struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	ArgType              string   `protobuf:"bytes,3,opt,name=argType,proto3" json:"argType,omitempty"`
+	Table                *Table   `protobuf:"bytes,4,opt,name=table,proto3" json:"table,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Arg) Reset()         { *m = Arg{} }
+func (m *Arg) String() string { return proto.CompactTextString(m) }
+func (*Arg) ProtoMessage()    {}
+func (*Arg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5fca3b110c9bbf3a, []int{5}
+}
+
+func (m *Arg) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Arg.Unmarshal(m, b)
+}
+func (m *Arg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Arg.Marshal(b, m, deterministic)
+}
+func (m *Arg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Arg.Merge(m, src)
+}
+func (m *Arg) XXX_Size() int {
+	return xxx_messageInfo_Arg.Size(m)
+}
+func (m *Arg) XXX_DiscardUnknown() {
+	xxx_messageInfo_Arg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Arg proto.InternalMessageInfo
+
+func (m *Arg) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Arg) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *Arg) GetArgType() string {
+	if m != nil {
+		return m.ArgType
+	}
+	return ""
+}
+
+func (m *Arg) GetTable() *Table {
+	if m != nil {
+		return m.Table
+	}
+	return nil
+}
+
+type Table struct {
+	Initialized          bool      `protobuf:"varint,1,opt,name=initialized,proto3" json:"initialized,omitempty"`
+	Headers              []string  `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty"`
+	Columns              []*Column `protobuf:"bytes,3,rep,name=columns,proto3" json:"columns,omitempty"`
+	LineNo               int32     `protobuf:"varint,4,opt,name=lineNo,proto3" json:"lineNo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Table) Reset()         { *m = Table{} }
+func (m *Table) String() string { return proto.CompactTextString(m) }
+func (*Table) ProtoMessage()    {}
+func (*Table) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5fca3b110c9bbf3a, []int{6}
+}
+
+func (m *Table) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Table.Unmarshal(m, b)
+}
+func (m *Table) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Table.Marshal(b, m, deterministic)
+}
+func (m *Table) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Table.Merge(m, src)
+}
+func (m *Table) XXX_Size() int {
+	return xxx_messageInfo_Table.Size(m)
+}
+func (m *Table) XXX_DiscardUnknown() {
+	xxx_messageInfo_Table.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Table proto.InternalMessageInfo
+
+func (m *Table) GetInitialized() bool {
+	if m != nil {
+		return m.Initialized
+	}
+	return false
+}
+
+func (m *Table) GetHeaders() []string {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *Table) GetColumns() []*Column {
+	if m != nil {
+		return m.Columns
+	}
+	return nil
+}
+
+func (m *Table) GetLineNo() int32 {
+	if m != nil {
+		return m.LineNo
+	}
+	return 0
+}
+
+type Column struct {
+	Values               []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	CellTypes            []string `protobuf:"bytes,2,rep,name=cellTypes,proto3" json:"cellTypes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Column) Reset()         { *m = Column{} }
+func (m *Column) String() string { return proto.CompactTextString(m) }
+func (*Column) ProtoMessage()    {}
+func (*Column) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5fca3b110c9bbf3a, []int{7}
+}
+
+func (m *Column) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Column.Unmarshal(m, b)
+}
+func (m *Column) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Column.Marshal(b, m, deterministic)
+}
+func (m *Column) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Column.Merge(m, src)
+}
+func (m *Column) XXX_Size() int {
+	return xxx_messageInfo_Column.Size(m)
+}
+func (m *Column) XXX_DiscardUnknown() {
+	xxx_messageInfo_Column.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Column proto.InternalMessageInfo
+
+func (m *Column) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+func (m *Column) GetCellTypes() []string {
+	if m != nil {
+		return m.CellTypes
+	}
+	return nil
+}
+
+type DataTable struct {
+	Table                *Table   `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	LineNo               int32    `protobuf:"varint,3,opt,name=lineNo,proto3" json:"lineNo,omitempty"`
+	IsExternal           bool     `protobuf:"varint,4,opt,name=isExternal,proto3" json:"isExternal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataTable) Reset()         { *m = DataTable{} }
+func (m *DataTable) String() string { return proto.CompactTextString(m) }
+func (*DataTable) ProtoMessage()    {}
+func (*DataTable) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5fca3b110c9bbf3a, []int{8}
+}
+
+func (m *DataTable) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataTable.Unmarshal(m, b)
+}
+func (m *DataTable) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataTable.Marshal(b, m, deterministic)
+}
+func (m *DataTable) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataTable.Merge(m, src)
+}
+func (m *DataTable) XXX_Size() int {
+	return xxx_messageInfo_DataTable.Size(m)
+}
+func (m *DataTable) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataTable.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataTable proto.InternalMessageInfo
+
+func (m *DataTable) GetTable() *Table {
+	if m != nil {
+		return m.Table
+	}
+	return nil
+}
+
+func (m *DataTable) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *DataTable) GetLineNo() int32 {
+	if m != nil {
+		return m.LineNo
+	}
+	return 0
+}
+
+func (m *DataTable) GetIsExternal() bool {
+	if m != nil {
+		return m.IsExternal
+	}
+	return false
+}
+
+type Heading struct {
+	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	LineNo               int32    `protobuf:"varint,2,opt,name=lineNo,proto3" json:"lineNo,omitempty"`
+	HeadingType          int32    `protobuf:"varint,3,opt,name=headingType,proto3" json:"headingType,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Heading) Reset()         { *m = Heading{} }
+func (m *Heading) String() string { return proto.CompactTextString(m) }
+func (*Heading) ProtoMessage()    {}
+func (*Heading) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5fca3b110c9bbf3a, []int{9}
+}
+
+func (m *Heading) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Heading.Unmarshal(m, b)
+}
+func (m *Heading) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Heading.Marshal(b, m, deterministic)
+}
+func (m *Heading) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Heading.Merge(m, src)
+}
+func (m *Heading) XXX_Size() int {
+	return xxx_messageInfo_Heading.Size(m)
+}
+func (m *Heading) XXX_DiscardUnknown() {
+	xxx_messageInfo_Heading.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Heading proto.InternalMessageInfo
+
+func (m *Heading) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *Heading) GetLineNo() int32 {
+	if m != nil {
+		return m.LineNo
+	}
+	return 0
+}
+
+func (m *Heading) GetHeadingType() int32 {
+	if m != nil {
+		return m.HeadingType
+	}
+	return 0
+}
+
+type Comment struct {
+	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	LineNo               int32    `protobuf:"varint,2,opt,name=lineNo,proto3" json:"lineNo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Comment) Reset()         { *m = Comment{} }
+func (m *Comment) String() string { return proto.CompactTextString(m) }
+func (*Comment) ProtoMessage()    {}
+func (*Comment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5fca3b110c9bbf3a, []int{10}
+}
+
+func (m *Comment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Comment.Unmarshal(m, b)
+}
+func (m *Comment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Comment.Marshal(b, m, deterministic)
+}
+func (m *Comment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Comment.Merge(m, src)
+}
+func (m *Comment) XXX_Size() int {
+	return xxx_messageInfo_Comment.Size(m)
+}
+func (m *Comment) XXX_DiscardUnknown() {
+	xxx_messageInfo_Comment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Comment proto.InternalMessageInfo
+
+func (m *Comment) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *Comment) GetLineNo() int32 {
+	if m != nil {
+		return m.LineNo
+	}
+	return 0
+}
+
+type Tags struct {
+	Rows                 []*TagRow `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	LineNo               int32     `protobuf:"varint,2,opt,name=lineNo,proto3" json:"lineNo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Tags) Reset()         { *m = Tags{} }
+func (m *Tags) String() string { return proto.CompactTextString(m) }
+func (*Tags) ProtoMessage()    {}
+func (*Tags) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5fca3b110c9bbf3a, []int{11}
+}
+
+func (m *Tags) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Tags.Unmarshal(m, b)
+}
+func (m *Tags) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Tags.Marshal(b, m, deterministic)
+}
+func (m *Tags) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Tags.Merge(m, src)
+}
+func (m *Tags) XXX_Size() int {
+	return xxx_messageInfo_Tags.Size(m)
+}
+func (m *Tags) XXX_DiscardUnknown() {
+	xxx_messageInfo_Tags.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Tags proto.InternalMessageInfo
+
+func (m *Tags) GetRows() []*TagRow {
+	if m != nil {
+		return m.Rows
+	}
+	return nil
+}
+
+func (m *Tags) GetLineNo() int32 {
+	if m != nil {
+		return m.LineNo
+	}
+	return 0
+}
+
+type TagRow struct {
+	Values               []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TagRow) Reset()         { *m = TagRow{} }
+func (m *TagRow) String() string { return proto.CompactTextString(m) }
+func (*TagRow) ProtoMessage()    {}
+func (*TagRow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5fca3b110c9bbf3a, []int{12}
+}
+
+func (m *TagRow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TagRow.Unmarshal(m, b)
+}
+func (m *TagRow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TagRow.Marshal(b, m, deterministic)
+}
+func (m *TagRow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TagRow.Merge(m, src)
+}
+func (m *TagRow) XXX_Size() int {
+	return xxx_messageInfo_TagRow.Size(m)
+}
+func (m *TagRow) XXX_DiscardUnknown() {
+	xxx_messageInfo_TagRow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TagRow proto.InternalMessageInfo
+
+func (m *TagRow) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type Meta struct {
+	Keys                 []string `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	Values               []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	LineNo               int32    `protobuf:"varint,3,opt,name=lineNo,proto3" json:"lineNo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Meta) Reset()         { *m = Meta{} }
+func (m *Meta) String() string { return proto.CompactTextString(m) }
+func (*Meta) ProtoMessage()    {}
+func (*Meta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5fca3b110c9bbf3a, []int{13}
+}
+
+func (m *Meta) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Meta.Unmarshal(m, b)
+}
+func (m *Meta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Meta.Marshal(b, m, deterministic)
+}
+func (m *Meta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Meta.Merge(m, src)
+}
+func (m *Meta) XXX_Size() int {
+	return xxx_messageInfo_Meta.Size(m)
+}
+func (m *Meta) XXX_DiscardUnknown() {
+	xxx_messageInfo_Meta.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Meta proto.InternalMessageInfo
+
+func (m *Meta) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *Meta) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+func (m *Meta) GetLineNo() int32 {
+	if m != nil {
+		return m.LineNo
+	}
+	return 0
+}
+
+type Error struct {
+	FileName             string   `protobuf:"bytes,1,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	LineNo               int32    `protobuf:"varint,2,opt,name=lineNo,proto3" json:"lineNo,omitempty"`
+	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	LineText             string   `protobuf:"bytes,4,opt,name=lineText,proto3" json:"lineText,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Error) Reset()         { *m = Error{} }
+func (m *Error) String() string { return proto.CompactTextString(m) }
+func (*Error) ProtoMessage()    {}
+func (*Error) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5fca3b110c9bbf3a, []int{14}
+}
+
+func (m *Error) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Error.Unmarshal(m, b)
+}
+func (m *Error) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Error.Marshal(b, m, deterministic)
+}
+func (m *Error) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Error.Merge(m, src)
+}
+func (m *Error) XXX_Size() int {
+	return xxx_messageInfo_Error.Size(m)
+}
+func (m *Error) XXX_DiscardUnknown() {
+	xxx_messageInfo_Error.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Error proto.InternalMessageInfo
+
+func (m *Error) GetFileName() string {
+	if m != nil {
+		return m.FileName
+	}
+	return ""
+}
+
+func (m *Error) GetLineNo() int32 {
+	if m != nil {
+		return m.LineNo
+	}
+	return 0
+}
+
+func (m *Error) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *Error) GetLineText() string {
+	if m != nil {
+		return m.LineText
+	}
+	return ""
+}
+
+type Warning struct {
+	FileName             string   `protobuf:"bytes,1,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	LineNo               int32    `protobuf:"varint,2,opt,name=lineNo,proto3" json:"lineNo,omitempty"`
+	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Deprecated           bool     `protobuf:"varint,4,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Warning) Reset()         { *m = Warning{} }
+func (m *Warning) String() string { return proto.CompactTextString(m) }
+func (*Warning) ProtoMessage()    {}
+func (*Warning) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5fca3b110c9bbf3a, []int{15}
+}
+
+func (m *Warning) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Warning.Unmarshal(m, b)
+}
+func (m *Warning) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Warning.Marshal(b, m, deterministic)
+}
+func (m *Warning) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Warning.Merge(m, src)
+}
+func (m *Warning) XXX_Size() int {
+	return xxx_messageInfo_Warning.Size(m)
+}
+func (m *Warning) XXX_DiscardUnknown() {
+	xxx_messageInfo_Warning.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Warning proto.InternalMessageInfo
+
+func (m *Warning) GetFileName() string {
+	if m != nil {
+		return m.FileName
+	}
+	return ""
+}
+
+func (m *Warning) GetLineNo() int32 {
+	if m != nil {
+		return m.LineNo
+	}
+	return 0
+}
+
+func (m *Warning) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *Warning) GetDeprecated() bool {
+	if m != nil {
+		return m.Deprecated
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterEnum("gauge.cache.Item_Kind", Item_Kind_name, Item_Kind_value)
+	proto.RegisterType((*Entry)(nil), "gauge.cache.Entry")
+	proto.RegisterType((*Item)(nil), "gauge.cache.Item")
+	proto.RegisterType((*Spec)(nil), "gauge.cache.Spec")
+	proto.RegisterType((*Scenario)(nil), "gauge.cache.Scenario")
+	proto.RegisterType((*Step)(nil), "gauge.cache.Step")
+	proto.RegisterType((*Arg)(nil), "gauge.cache.Arg")
+	proto.RegisterType((*Table)(nil), "gauge.cache.Table")
+	proto.RegisterType((*Column)(nil), "gauge.cache.Column")
+	proto.RegisterType((*DataTable)(nil), "gauge.cache.DataTable")
+	proto.RegisterType((*Heading)(nil), "gauge.cache.Heading")
+	proto.RegisterType((*Comment)(nil), "gauge.cache.Comment")
+	proto.RegisterType((*Tags)(nil), "gauge.cache.Tags")
+	proto.RegisterType((*TagRow)(nil), "gauge.cache.TagRow")
+	proto.RegisterType((*Meta)(nil), "gauge.cache.Meta")
+	proto.RegisterType((*Error)(nil), "gauge.cache.Error")
+	proto.RegisterType((*Warning)(nil), "gauge.cache.Warning")
+}
+
+func init() { proto.RegisterFile("cache.proto", fileDescriptor_5fca3b110c9bbf3a) }
+
+var fileDescriptor_5fca3b110c9bbf3a = []byte{
+	// 986 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x4f, 0x8f, 0xdc, 0x34,
+	0x14, 0x27, 0x99, 0x64, 0x92, 0xbc, 0xd9, 0xae, 0x82, 0x29, 0x95, 0x85, 0x50, 0x35, 0x8a, 0x80,
+	0x5d, 0x55, 0xea, 0xa8, 0xda, 0x02, 0xbd, 0x21, 0x95, 0x76, 0x05, 0x05, 0xd1, 0x83, 0x77, 0x24,
+	0x04, 0x37, 0x37, 0xe3, 0xcd, 0x44, 0x9b, 0x71, 0x42, 0xec, 0x65, 0x66, 0xe1, 0xc8, 0x8d, 0x1b,
+	0x5f, 0x80, 0x6f, 0xc3, 0x57, 0xe1, 0x43, 0x70, 0x42, 0xb6, 0xe3, 0xfc, 0x99, 0x6e, 0xb6, 0x5b,
+	0xc4, 0x2d, 0xef, 0xbd, 0x9f, 0xed, 0xe7, 0xdf, 0xfb, 0xbd, 0xe7, 0xc0, 0x2c, 0xa5, 0xe9, 0x9a,
+	0x2d, 0xaa, 0xba, 0x94, 0x25, 0x9a, 0x65, 0xf4, 0x32, 0x63, 0x0b, 0xed, 0x4a, 0xfe, 0x70, 0xc1,
+	0x3f, 0xe5, 0xb2, 0xbe, 0x42, 0x08, 0xbc, 0x35, 0x15, 0x6b, 0xec, 0xcc, 0x9d, 0xe3, 0x88, 0xe8,
+	0x6f, 0xf4, 0x31, 0x78, 0xa2, 0x62, 0x29, 0x76, 0xe7, 0xce, 0xf1, 0xec, 0xe4, 0xdd, 0x45, 0x6f,
+	0xe5, 0xe2, 0xac, 0x62, 0x29, 0xd1, 0x61, 0xf4, 0x10, 0xc2, 0xb4, 0xe4, 0x29, 0xab, 0xa4, 0xc0,
+	0x93, 0xf9, 0xe4, 0x75, 0xa8, 0x64, 0x15, 0x69, 0x21, 0xe8, 0x11, 0x44, 0x05, 0xdb, 0x9d, 0xd6,
+	0x75, 0x59, 0x0b, 0xec, 0x69, 0x3c, 0x1a, 0xe0, 0x75, 0x88, 0x74, 0x20, 0xf4, 0x00, 0xa6, 0xcc,
+	0xc0, 0xfd, 0x51, 0x78, 0x83, 0x40, 0x8f, 0x20, 0xdc, 0xd2, 0x9a, 0xe7, 0x3c, 0x13, 0x78, 0xaa,
+	0xd1, 0x77, 0x07, 0xe8, 0xef, 0x4d, 0x90, 0xb4, 0x28, 0x74, 0x08, 0x6e, 0x79, 0x81, 0x83, 0xb9,
+	0x73, 0x1c, 0x12, 0xb7, 0xbc, 0x48, 0xfe, 0x72, 0xc0, 0x7b, 0x21, 0xd9, 0x06, 0x3d, 0x00, 0xef,
+	0x22, 0xe7, 0x2b, 0x4d, 0xc9, 0xe1, 0xc9, 0xbd, 0xc1, 0x36, 0x0a, 0xb0, 0xf8, 0x36, 0xe7, 0x2b,
+	0xa2, 0x31, 0xe8, 0x2e, 0xf8, 0x39, 0x5f, 0xb1, 0x9d, 0xe6, 0xca, 0x27, 0xc6, 0x48, 0x7e, 0x05,
+	0x4f, 0x61, 0xd0, 0x01, 0x84, 0x67, 0x29, 0xe3, 0xb4, 0xce, 0xcb, 0xf8, 0x1d, 0x14, 0x82, 0xa7,
+	0x28, 0x89, 0x1d, 0x14, 0xc3, 0xc1, 0x92, 0xd1, 0xfa, 0x79, 0xb9, 0xe5, 0xda, 0xe3, 0xa2, 0x19,
+	0x04, 0xcf, 0xca, 0xcd, 0x86, 0x71, 0x19, 0x4f, 0xd4, 0x32, 0x1b, 0x8e, 0x3d, 0xb5, 0x6c, 0x49,
+	0x33, 0x11, 0xfb, 0xea, 0xeb, 0x3b, 0x26, 0x69, 0x3c, 0x45, 0x77, 0x20, 0x7a, 0x4e, 0x25, 0x5d,
+	0xd2, 0x57, 0x05, 0x8b, 0x03, 0xb3, 0x5a, 0xd3, 0x1c, 0x87, 0xc9, 0xdf, 0x13, 0xf0, 0x54, 0x95,
+	0xd0, 0x02, 0x82, 0x35, 0xa3, 0xab, 0x9c, 0x67, 0xfa, 0x2a, 0xfb, 0x8c, 0x7c, 0x6d, 0x62, 0xc4,
+	0x82, 0xd0, 0x63, 0x88, 0x44, 0x93, 0xad, 0xc0, 0xae, 0xe6, 0xf0, 0xfd, 0x61, 0x41, 0x9b, 0x28,
+	0xe9, 0x70, 0x8a, 0xf7, 0xd4, 0x24, 0x6e, 0x45, 0x30, 0x3c, 0xa5, 0xb9, 0x15, 0x69, 0x51, 0xe8,
+	0x53, 0x88, 0x56, 0x36, 0x77, 0xec, 0xe9, 0xc4, 0x86, 0x1c, 0xb7, 0x37, 0x23, 0x1d, 0xb0, 0x11,
+	0x9b, 0x64, 0x3b, 0x69, 0xd5, 0x30, 0x22, 0x36, 0x0d, 0x51, 0x12, 0x96, 0x54, 0x4b, 0xe1, 0x75,
+	0x09, 0x2b, 0x36, 0x89, 0x0e, 0x2b, 0xd8, 0x86, 0x49, 0x8a, 0x83, 0x6b, 0x60, 0x8a, 0x6a, 0xa2,
+	0xc3, 0xe8, 0x08, 0xfc, 0x5c, 0xb2, 0x8d, 0xc0, 0xe1, 0x35, 0x27, 0x2b, 0x49, 0x10, 0x13, 0x47,
+	0x4f, 0xe0, 0x8e, 0xec, 0x15, 0x56, 0xe0, 0x68, 0x2c, 0xd5, 0x21, 0x0e, 0x9d, 0x40, 0x64, 0x1d,
+	0x02, 0xc3, 0x0d, 0x3c, 0x76, 0xb0, 0xe4, 0x1f, 0xb7, 0x93, 0xd7, 0x5b, 0x17, 0xfb, 0x08, 0x7c,
+	0xa1, 0x33, 0x74, 0xc7, 0x32, 0x34, 0xf1, 0xff, 0x50, 0x60, 0xcb, 0xbd, 0x77, 0x3b, 0xee, 0xfd,
+	0x5b, 0x72, 0x3f, 0x7d, 0x03, 0xf7, 0x03, 0x5d, 0x05, 0xb7, 0xd5, 0xd5, 0x87, 0x10, 0x89, 0x8a,
+	0xf2, 0x33, 0x49, 0x6b, 0x89, 0x43, 0xdd, 0xc4, 0x9d, 0x03, 0x61, 0x08, 0x94, 0x71, 0xca, 0x57,
+	0x38, 0xd2, 0x31, 0x6b, 0x26, 0x7f, 0x4e, 0x4c, 0x37, 0xa3, 0x7b, 0x30, 0x2d, 0x72, 0xce, 0x5e,
+	0x96, 0x9a, 0x77, 0x9f, 0x34, 0x96, 0x9a, 0x0c, 0x3f, 0xd3, 0xe2, 0x92, 0xe9, 0xc9, 0x10, 0x11,
+	0x63, 0xa0, 0x0f, 0x20, 0x54, 0xf1, 0x25, 0xdb, 0x49, 0x3c, 0xd1, 0x81, 0xd6, 0x46, 0x1f, 0x81,
+	0x47, 0xeb, 0xcc, 0xce, 0xc6, 0x78, 0x90, 0xfb, 0xd3, 0x3a, 0x23, 0x3a, 0xaa, 0x12, 0xce, 0x45,
+	0xd3, 0xed, 0x9a, 0xbb, 0x90, 0x74, 0x0e, 0xf4, 0x19, 0x1c, 0x34, 0x03, 0xd7, 0xe8, 0x6f, 0x3a,
+	0x56, 0xdd, 0x01, 0x0c, 0x7d, 0x02, 0x87, 0x6b, 0x2a, 0x5e, 0x70, 0x9d, 0x4b, 0x4b, 0x60, 0x48,
+	0xf6, 0xbc, 0xe8, 0x73, 0x98, 0x55, 0x35, 0x7b, 0x66, 0xf5, 0x10, 0xde, 0xa0, 0x87, 0x3e, 0x50,
+	0x91, 0x24, 0x2e, 0xcf, 0xcf, 0xf3, 0x9d, 0xa6, 0x31, 0x22, 0x8d, 0x35, 0x10, 0x17, 0xdc, 0x4a,
+	0x5c, 0xad, 0x1c, 0x66, 0x37, 0xcb, 0x21, 0x11, 0x30, 0x79, 0x5a, 0x67, 0xea, 0x7d, 0xe3, 0x74,
+	0xc3, 0xec, 0xfb, 0xa6, 0xbe, 0x47, 0x4a, 0x83, 0x21, 0xa0, 0x75, 0xb6, 0xbc, 0xaa, 0x58, 0x53,
+	0x19, 0x6b, 0xa2, 0x63, 0xf0, 0x65, 0x6f, 0x5a, 0xa1, 0x3d, 0x45, 0x2b, 0x45, 0x19, 0x40, 0xf2,
+	0xbb, 0x03, 0xbe, 0x61, 0x6a, 0x0e, 0xb3, 0x9c, 0xe7, 0x32, 0xa7, 0x45, 0xfe, 0x0b, 0x33, 0x6f,
+	0x49, 0x48, 0xfa, 0x2e, 0x75, 0x9e, 0x6a, 0x46, 0x56, 0x9b, 0x1e, 0x8c, 0x88, 0x35, 0xd1, 0x43,
+	0x08, 0xd2, 0xb2, 0xb8, 0xdc, 0x70, 0xdb, 0x71, 0xef, 0xed, 0x91, 0xa2, 0x62, 0xc4, 0x62, 0x7a,
+	0x0a, 0xf4, 0xfa, 0x0a, 0x4c, 0xbe, 0x80, 0xa9, 0x81, 0x2a, 0x84, 0xbe, 0xa3, 0xc0, 0x8e, 0x3e,
+	0xa9, 0xb1, 0x94, 0x96, 0x52, 0x56, 0x14, 0xea, 0x92, 0x36, 0x89, 0xce, 0x91, 0xfc, 0xe6, 0xf4,
+	0x5e, 0x99, 0x8e, 0x04, 0xe7, 0x0d, 0x24, 0x8c, 0xd0, 0xdb, 0x65, 0x39, 0x19, 0xf4, 0xc9, 0x7d,
+	0x80, 0x5c, 0x9c, 0xee, 0x24, 0xab, 0x39, 0x2d, 0xf4, 0x0d, 0x42, 0xd2, 0xf3, 0x24, 0x3f, 0x40,
+	0xd0, 0x0c, 0xaf, 0x6e, 0x63, 0xe7, 0xfa, 0x8d, 0xdd, 0xc1, 0xc6, 0x73, 0x98, 0x35, 0xc3, 0xae,
+	0xad, 0xa9, 0x4f, 0xfa, 0xae, 0xe4, 0x49, 0xfb, 0xe8, 0xbe, 0xdd, 0xd6, 0xc9, 0x57, 0xe6, 0x49,
+	0x46, 0x47, 0xe0, 0xd5, 0xe5, 0xd6, 0xb0, 0xba, 0x5f, 0xa5, 0x25, 0xcd, 0x48, 0xb9, 0x25, 0x1a,
+	0x30, 0xba, 0xd1, 0x1c, 0xa6, 0x06, 0x37, 0x56, 0xa2, 0xe4, 0x1b, 0xf3, 0xe6, 0x2b, 0x1d, 0x5f,
+	0xb0, 0x2b, 0x1b, 0xd5, 0xdf, 0xbd, 0x35, 0xee, 0xa0, 0xac, 0x23, 0x54, 0x27, 0x3f, 0x81, 0xaf,
+	0x7f, 0x9a, 0xd4, 0x14, 0x3a, 0xcf, 0x0b, 0xf6, 0xb2, 0x6b, 0x8c, 0xd6, 0x1e, 0xa5, 0x13, 0x43,
+	0xb0, 0x61, 0x42, 0xd0, 0xac, 0x6d, 0x8f, 0xc6, 0x1c, 0xcc, 0x34, 0x6f, 0x38, 0xd3, 0x92, 0x2d,
+	0x04, 0xcd, 0x9f, 0xd7, 0xff, 0x7c, 0xe8, 0x7d, 0x80, 0x15, 0xab, 0x6a, 0x96, 0x52, 0xc9, 0x56,
+	0x56, 0x36, 0x9d, 0xe7, 0xcb, 0xe0, 0x47, 0x5f, 0xd7, 0xe1, 0xd5, 0x54, 0xff, 0xfe, 0x3e, 0xfe,
+	0x77, 0x00, 0x5f, 0x25, 0x52, 0xcc, 0x0d, 0x0b, 0x00, 0x00,
+}
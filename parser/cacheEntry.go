@@ -0,0 +1,474 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package parser
+
+import (
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser/cache"
+)
+
+// The parse cache entries are the cache.Entry messages generated from cache/cache.proto. They hold a spec as it is
+// parsed, before its concept steps are replaced, or the concepts of a concept file, so that a cached file needs neither
+// to be lexed nor parsed again.
+
+func newSpecCacheEntry(hash string, spec *gauge.Specification, res *ParseResult, lexErrs []ParseError) *cache.Entry {
+	e := &cache.Entry{Hash: hash, Spec: toCachedSpec(spec), LexErrors: toCachedErrors(lexErrs), Ok: res.Ok}
+	e.Errors, e.Warnings = toCachedErrors(res.ParseErrors), toCachedWarnings(res.Warnings)
+	return e
+}
+
+func newConceptCacheEntry(hash string, concepts []*gauge.Step, res *ParseResult, lexErrs []ParseError) *cache.Entry {
+	e := &cache.Entry{Hash: hash, LexErrors: toCachedErrors(lexErrs), Ok: res.Ok}
+	for _, c := range concepts {
+		e.Concepts = append(e.Concepts, toCachedStep(c))
+	}
+	e.Errors, e.Warnings = toCachedErrors(res.ParseErrors), toCachedWarnings(res.Warnings)
+	return e
+}
+
+// entryResult gives the parse result and the lexer errors held by the entry.
+func entryResult(e *cache.Entry) (*ParseResult, []ParseError) {
+	res := &ParseResult{ParseErrors: fromCachedErrors(e.Errors), Ok: e.Ok}
+	for _, w := range e.Warnings {
+		res.Warnings = append(res.Warnings, &Warning{FileName: w.FileName, LineNo: int(w.LineNo), Message: w.Message, Deprecated: w.Deprecated})
+	}
+	return res, fromCachedErrors(e.LexErrors)
+}
+
+// entrySpec gives the spec held by the entry. It is not ok if the entry refers to elements the spec does not have.
+func entrySpec(e *cache.Entry, fileName string) (spec *gauge.Specification, ok bool) {
+	defer func() {
+		if recover() != nil {
+			spec, ok = nil, false
+		}
+	}()
+	return fromCachedSpec(e.Spec, fileName), true
+}
+
+// entryConcepts gives the concepts held by the entry. It is not ok if the entry refers to elements the concepts do not have.
+func entryConcepts(e *cache.Entry, fileName string) (concepts []*gauge.Step, ok bool) {
+	defer func() {
+		if recover() != nil {
+			concepts, ok = nil, false
+		}
+	}()
+	for _, c := range e.Concepts {
+		concepts = append(concepts, fromCachedStep(c, fileName))
+	}
+	return concepts, true
+}
+
+func toCachedErrors(errs []ParseError) (cached []*cache.Error) {
+	for _, err := range errs {
+		cached = append(cached, &cache.Error{FileName: err.FileName, LineNo: int32(err.LineNo), Message: err.Message, LineText: err.LineText})
+	}
+	return
+}
+
+func fromCachedErrors(cached []*cache.Error) (errs []ParseError) {
+	for _, err := range cached {
+		errs = append(errs, ParseError{FileName: err.FileName, LineNo: int(err.LineNo), Message: err.Message, LineText: err.LineText})
+	}
+	return
+}
+
+func toCachedWarnings(warnings []*Warning) (cached []*cache.Warning) {
+	for _, w := range warnings {
+		cached = append(cached, &cache.Warning{FileName: w.FileName, LineNo: int32(w.LineNo), Message: w.Message, Deprecated: w.Deprecated})
+	}
+	return
+}
+
+func toCachedSpec(spec *gauge.Specification) *cache.Spec {
+	s := &cache.Spec{
+		Heading:       toCachedHeading(spec.Heading),
+		Comments:      toCachedComments(spec.Comments),
+		DataTable:     toCachedDataTable(&spec.DataTable),
+		Contexts:      toCachedSteps(spec.Contexts),
+		Tags:          toCachedTags(spec.Tags),
+		Meta:          toCachedMeta(spec.Meta),
+		TearDownSteps: toCachedSteps(spec.TearDownSteps),
+	}
+	for _, scn := range spec.Scenarios {
+		s.Scenarios = append(s.Scenarios, toCachedScenario(scn))
+	}
+	for _, item := range spec.Items {
+		switch i := item.(type) {
+		case *gauge.Scenario:
+			s.Items = append(s.Items, &cache.Item{Kind: cache.Item_Scenario, Index: indexOf(len(spec.Scenarios), func(j int) bool { return spec.Scenarios[j] == i })})
+		case *gauge.Step:
+			if index := indexOf(len(spec.Contexts), func(j int) bool { return spec.Contexts[j] == i }); index >= 0 {
+				s.Items = append(s.Items, &cache.Item{Kind: cache.Item_Step, Index: index})
+			} else {
+				s.Items = append(s.Items, &cache.Item{Kind: cache.Item_TearDownStep, Index: indexOf(len(spec.TearDownSteps), func(j int) bool { return spec.TearDownSteps[j] == i })})
+			}
+		case *gauge.Comment:
+			s.Items = append(s.Items, &cache.Item{Kind: cache.Item_Comment, Index: indexOf(len(spec.Comments), func(j int) bool { return spec.Comments[j] == i })})
+		case *gauge.TearDown:
+			s.Items = append(s.Items, &cache.Item{Kind: cache.Item_TearDown, Index: int32(len(s.TearDowns))})
+			s.TearDowns = append(s.TearDowns, &cache.Comment{Value: i.Value, LineNo: int32(i.LineNo)})
+		case *gauge.Tags:
+			s.Items = append(s.Items, &cache.Item{Kind: cache.Item_Tags})
+		case *gauge.Meta:
+			s.Items = append(s.Items, &cache.Item{Kind: cache.Item_Meta})
+		case *gauge.DataTable:
+			s.Items = append(s.Items, &cache.Item{Kind: cache.Item_DataTable})
+		}
+	}
+	return s
+}
+
+func fromCachedSpec(s *cache.Spec, fileName string) *gauge.Specification {
+	spec := &gauge.Specification{
+		FileName:      fileName,
+		Heading:       fromCachedHeading(s.Heading),
+		Comments:      fromCachedComments(s.Comments),
+		Contexts:      fromCachedSteps(s.Contexts, fileName),
+		Tags:          fromCachedTags(s.Tags),
+		Meta:          fromCachedMeta(s.Meta),
+		TearDownSteps: fromCachedSteps(s.TearDownSteps, fileName),
+	}
+	if s.DataTable != nil {
+		spec.DataTable = *fromCachedDataTable(s.DataTable)
+	}
+	for _, scn := range s.Scenarios {
+		spec.Scenarios = append(spec.Scenarios, fromCachedScenario(scn, fileName))
+	}
+	for _, item := range s.Items {
+		switch item.Kind {
+		case cache.Item_Scenario:
+			spec.AddItem(spec.Scenarios[item.Index])
+		case cache.Item_Step:
+			spec.AddItem(spec.Contexts[item.Index])
+		case cache.Item_TearDownStep:
+			spec.AddItem(spec.TearDownSteps[item.Index])
+		case cache.Item_Comment:
+			spec.AddItem(spec.Comments[item.Index])
+		case cache.Item_TearDown:
+			spec.AddItem(&gauge.TearDown{Value: s.TearDowns[item.Index].Value, LineNo: int(s.TearDowns[item.Index].LineNo)})
+		case cache.Item_Tags:
+			spec.AddItem(spec.Tags)
+		case cache.Item_Meta:
+			spec.AddItem(spec.Meta)
+		case cache.Item_DataTable:
+			spec.AddItem(&spec.DataTable)
+		}
+	}
+	return spec
+}
+
+func toCachedScenario(scn *gauge.Scenario) *cache.Scenario {
+	s := &cache.Scenario{
+		Heading:   toCachedHeading(scn.Heading),
+		Steps:     toCachedSteps(scn.Steps),
+		Comments:  toCachedComments(scn.Comments),
+		Tags:      toCachedTags(scn.Tags),
+		Meta:      toCachedMeta(scn.Meta),
+		DataTable: toCachedDataTable(&scn.DataTable),
+	}
+	if scn.Span != nil {
+		s.SpanStart, s.SpanEnd = int32(scn.Span.Start), int32(scn.Span.End)
+	}
+	for _, item := range scn.Items {
+		switch i := item.(type) {
+		case *gauge.Step:
+			s.Items = append(s.Items, &cache.Item{Kind: cache.Item_Step, Index: indexOf(len(scn.Steps), func(j int) bool { return scn.Steps[j] == i })})
+		case *gauge.Comment:
+			s.Items = append(s.Items, &cache.Item{Kind: cache.Item_Comment, Index: indexOf(len(scn.Comments), func(j int) bool { return scn.Comments[j] == i })})
+		case *gauge.Tags:
+			s.Items = append(s.Items, &cache.Item{Kind: cache.Item_Tags})
+		case *gauge.Meta:
+			s.Items = append(s.Items, &cache.Item{Kind: cache.Item_Meta})
+		case *gauge.DataTable:
+			s.Items = append(s.Items, &cache.Item{Kind: cache.Item_DataTable})
+		}
+	}
+	return s
+}
+
+func fromCachedScenario(s *cache.Scenario, fileName string) *gauge.Scenario {
+	scn := &gauge.Scenario{
+		Heading:  fromCachedHeading(s.Heading),
+		Steps:    fromCachedSteps(s.Steps, fileName),
+		Comments: fromCachedComments(s.Comments),
+		Tags:     fromCachedTags(s.Tags),
+		Meta:     fromCachedMeta(s.Meta),
+		Span:     &gauge.Span{Start: int(s.SpanStart), End: int(s.SpanEnd)},
+	}
+	if s.DataTable != nil {
+		scn.DataTable = *fromCachedDataTable(s.DataTable)
+	}
+	for _, item := range s.Items {
+		switch item.Kind {
+		case cache.Item_Step:
+			scn.AddItem(scn.Steps[item.Index])
+		case cache.Item_Comment:
+			scn.AddItem(scn.Comments[item.Index])
+		case cache.Item_Tags:
+			scn.AddItem(scn.Tags)
+		case cache.Item_Meta:
+			scn.AddItem(scn.Meta)
+		case cache.Item_DataTable:
+			scn.AddItem(&scn.DataTable)
+		}
+	}
+	return scn
+}
+
+func toCachedSteps(steps []*gauge.Step) (cached []*cache.Step) {
+	for _, step := range steps {
+		cached = append(cached, toCachedStep(step))
+	}
+	return
+}
+
+func fromCachedSteps(cached []*cache.Step, fileName string) (steps []*gauge.Step) {
+	for _, step := range cached {
+		steps = append(steps, fromCachedStep(step, fileName))
+	}
+	return
+}
+
+func toCachedStep(step *gauge.Step) *cache.Step {
+	s := &cache.Step{
+		LineNo:         int32(step.LineNo),
+		Value:          step.Value,
+		LineText:       step.LineText,
+		IsConcept:      step.IsConcept,
+		ConceptSteps:   toCachedSteps(step.ConceptSteps),
+		HasInlineTable: step.HasInlineTable,
+		PreComments:    toCachedComments(step.PreComments),
+		Suffix:         step.Suffix,
+	}
+	for _, arg := range step.Args {
+		s.Args = append(s.Args, &cache.Arg{Name: arg.Name, Value: arg.Value, ArgType: string(arg.ArgType), Table: toCachedTable(&arg.Table)})
+	}
+	for _, item := range step.Items {
+		switch i := item.(type) {
+		case *gauge.Step:
+			if i == step {
+				s.Items = append(s.Items, &cache.Item{Kind: cache.Item_Concept})
+			} else {
+				s.Items = append(s.Items, &cache.Item{Kind: cache.Item_Step, Index: indexOf(len(step.ConceptSteps), func(j int) bool { return step.ConceptSteps[j] == i })})
+			}
+		case *gauge.Comment:
+			s.Items = append(s.Items, &cache.Item{Kind: cache.Item_Comment, Index: int32(len(s.Comments))})
+			s.Comments = append(s.Comments, &cache.Comment{Value: i.Value, LineNo: int32(i.LineNo)})
+		}
+	}
+	return s
+}
+
+// fromCachedStep creates the step with the lookup of a concept heading, which has the names of its parameters, since
+// the steps of a spec are given their lookups only once their concepts are replaced.
+func fromCachedStep(s *cache.Step, fileName string) *gauge.Step {
+	step := &gauge.Step{
+		LineNo:         int(s.LineNo),
+		FileName:       fileName,
+		Value:          s.Value,
+		LineText:       s.LineText,
+		IsConcept:      s.IsConcept,
+		ConceptSteps:   fromCachedSteps(s.ConceptSteps, fileName),
+		HasInlineTable: s.HasInlineTable,
+		PreComments:    fromCachedComments(s.PreComments),
+		Suffix:         s.Suffix,
+	}
+	args := make([]*gauge.StepArg, 0)
+	for _, arg := range s.Args {
+		a := &gauge.StepArg{Name: arg.Name, Value: arg.Value, ArgType: gauge.ArgType(arg.ArgType)}
+		if arg.Table != nil {
+			a.Table = *fromCachedTable(arg.Table)
+		}
+		args = append(args, a)
+	}
+	step.AddArgs(args...)
+	if step.IsConcept {
+		for _, arg := range step.Args {
+			step.Lookup.AddArgName(arg.Value)
+		}
+	}
+	for _, item := range s.Items {
+		switch item.Kind {
+		case cache.Item_Concept:
+			step.Items = append(step.Items, step)
+		case cache.Item_Step:
+			step.Items = append(step.Items, step.ConceptSteps[item.Index])
+		case cache.Item_Comment:
+			step.Items = append(step.Items, &gauge.Comment{Value: s.Comments[item.Index].Value, LineNo: int(s.Comments[item.Index].LineNo)})
+		}
+	}
+	return step
+}
+
+func toCachedTable(t *gauge.Table) *cache.Table {
+	c := &cache.Table{Initialized: t.IsInitialized(), Headers: t.Headers, LineNo: int32(t.LineNo)}
+	for _, column := range t.Columns {
+		cc := &cache.Column{}
+		for _, cell := range column {
+			cc.Values = append(cc.Values, cell.Value)
+			cc.CellTypes = append(cc.CellTypes, string(cell.CellType))
+		}
+		c.Columns = append(c.Columns, cc)
+	}
+	return c
+}
+
+func fromCachedTable(c *cache.Table) *gauge.Table {
+	t := &gauge.Table{LineNo: int(c.LineNo)}
+	if !c.Initialized {
+		return t
+	}
+	t.AddHeaders(c.Headers)
+	for i, column := range c.Columns {
+		if i >= len(t.Columns) {
+			break
+		}
+		for j, value := range column.Values {
+			t.Columns[i] = append(t.Columns[i], gauge.TableCell{Value: value, CellType: gauge.ArgType(column.CellTypes[j])})
+		}
+	}
+	return t
+}
+
+func toCachedDataTable(d *gauge.DataTable) *cache.DataTable {
+	return &cache.DataTable{Table: toCachedTable(&d.Table), Value: d.Value, LineNo: int32(d.LineNo), IsExternal: d.IsExternal}
+}
+
+func fromCachedDataTable(c *cache.DataTable) *gauge.DataTable {
+	d := &gauge.DataTable{Value: c.Value, LineNo: int(c.LineNo), IsExternal: c.IsExternal}
+	if c.Table != nil {
+		d.Table = *fromCachedTable(c.Table)
+	}
+	return d
+}
+
+func toCachedHeading(h *gauge.Heading) *cache.Heading {
+	if h == nil {
+		return nil
+	}
+	return &cache.Heading{Value: h.Value, LineNo: int32(h.LineNo), HeadingType: int32(h.HeadingType)}
+}
+
+func fromCachedHeading(c *cache.Heading) *gauge.Heading {
+	if c == nil {
+		return nil
+	}
+	return &gauge.Heading{Value: c.Value, LineNo: int(c.LineNo), HeadingType: gauge.HeadingType(c.HeadingType)}
+}
+
+func toCachedComments(comments []*gauge.Comment) (cached []*cache.Comment) {
+	for _, c := range comments {
+		cached = append(cached, &cache.Comment{Value: c.Value, LineNo: int32(c.LineNo)})
+	}
+	return
+}
+
+func fromCachedComments(cached []*cache.Comment) (comments []*gauge.Comment) {
+	for _, c := range cached {
+		comments = append(comments, &gauge.Comment{Value: c.Value, LineNo: int(c.LineNo)})
+	}
+	return
+}
+
+func toCachedTags(t *gauge.Tags) *cache.Tags {
+	if t == nil {
+		return nil
+	}
+	c := &cache.Tags{LineNo: int32(t.LineNo)}
+	for _, row := range t.RawValues {
+		c.Rows = append(c.Rows, &cache.TagRow{Values: row})
+	}
+	return c
+}
+
+func fromCachedTags(c *cache.Tags) *gauge.Tags {
+	if c == nil {
+		return nil
+	}
+	t := &gauge.Tags{LineNo: int(c.LineNo)}
+	for _, row := range c.Rows {
+		t.RawValues = append(t.RawValues, row.Values)
+	}
+	return t
+}
+
+func toCachedMeta(m *gauge.Meta) *cache.Meta {
+	if m == nil {
+		return nil
+	}
+	c := &cache.Meta{Keys: m.Keys, LineNo: int32(m.LineNo)}
+	for _, key := range m.Keys {
+		c.Values = append(c.Values, m.Values[key])
+	}
+	return c
+}
+
+func fromCachedMeta(c *cache.Meta) *gauge.Meta {
+	if c == nil {
+		return nil
+	}
+	m := &gauge.Meta{Values: make(map[string]string), LineNo: int(c.LineNo)}
+	for i, key := range c.Keys {
+		m.Add(key, c.Values[i])
+	}
+	return m
+}
+
+// refersToAll tells if every item of the entry refers to an element of its parent, which is not the case if the parsed
+// spec has items that are not held by any of its lists.
+func refersToAll(e *cache.Entry) bool {
+	steps := append([]*cache.Step{}, e.Concepts...)
+	if e.Spec != nil {
+		if !validItems(e.Spec.Items) {
+			return false
+		}
+		steps = append(append(steps, e.Spec.Contexts...), e.Spec.TearDownSteps...)
+		for _, scn := range e.Spec.Scenarios {
+			if !validItems(scn.Items) {
+				return false
+			}
+			steps = append(steps, scn.Steps...)
+		}
+	}
+	for len(steps) > 0 {
+		step := steps[0]
+		if !validItems(step.Items) {
+			return false
+		}
+		steps = append(steps[1:], step.ConceptSteps...)
+	}
+	return true
+}
+
+func validItems(items []*cache.Item) bool {
+	for _, item := range items {
+		if item.Index < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func indexOf(n int, matches func(i int) bool) int32 {
+	for i := 0; i < n; i++ {
+		if matches(i) {
+			return int32(i)
+		}
+	}
+	return -1
+}
@@ -0,0 +1,137 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package parser
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/gauge"
+	. "gopkg.in/check.v1"
+)
+
+func withParseCache(c *C, f func(dir string)) {
+	oldRoot := config.ProjectRoot
+	dir, err := ioutil.TempDir("", "cache")
+	c.Assert(err, IsNil)
+	config.ProjectRoot = dir
+	pruneCacheOnce = sync.Once{}
+	os.Setenv("enable_parse_cache", "true")
+	defer func() {
+		os.Unsetenv("enable_parse_cache")
+		config.ProjectRoot = oldRoot
+		os.RemoveAll(dir)
+	}()
+	f(dir)
+}
+
+func (s *MySuite) TestParseSpecWithCacheGivesTheSpecOfAFreshParse(c *C) {
+	withParseCache(c, func(dir string) {
+		text := `# Spec heading
+tags: foo, bar
+* context step "a"
+
+## Scenario heading
+comment
+* a step with table
+   |id|name|
+   |--|----|
+   |1 |foo |
+* concept step
+___
+* teardown step
+`
+		file := filepath.Join(dir, "specs", "foo.spec")
+		dictionary := gauge.NewConceptDictionary()
+		concepts, res := new(ConceptParser).Parse("# concept step\n* a step\n", "foo.cpt")
+		c.Assert(len(res.ParseErrors), Equals, 0)
+		_, err := AddConcept(concepts, "foo.cpt", dictionary)
+		c.Assert(err, IsNil)
+
+		fresh, freshRes, err := parseSpecWithCache(text, file, dictionary)
+		c.Assert(err, IsNil)
+		c.Assert(common.FileExists(cacheFilePath(file)), Equals, true)
+
+		cached, cachedRes, err := parseSpecWithCache(text, file, dictionary)
+		c.Assert(err, IsNil)
+		c.Assert(cachedRes.Ok, Equals, freshRes.Ok)
+		c.Assert(cachedRes.ParseErrors, DeepEquals, freshRes.ParseErrors)
+		c.Assert(len(cachedRes.Warnings), Equals, len(freshRes.Warnings))
+		c.Assert(cached, DeepEquals, fresh)
+	})
+}
+
+func (s *MySuite) TestParseSpecWithCacheUsesEntryOnlyIfContentIsUnchanged(c *C) {
+	withParseCache(c, func(dir string) {
+		text := "# Spec heading\n## Scenario heading\n* a step\n"
+		file := filepath.Join(dir, "foo.spec")
+		spec, res := new(SpecParser).ParseSpecText("# Cached heading\n## Scenario heading\n* a step\n", file)
+		writeCacheEntry(cacheFilePath(file), newSpecCacheEntry(contentHash(text), spec, res, nil))
+
+		spec, _, _ = parseSpecWithCache(text, file, gauge.NewConceptDictionary())
+		c.Assert(spec.Heading.Value, Equals, "Cached heading")
+
+		spec, _, _ = parseSpecWithCache(text+"* another step\n", file, gauge.NewConceptDictionary())
+		c.Assert(spec.Heading.Value, Equals, "Spec heading")
+		c.Assert(len(spec.Scenarios[0].Steps), Equals, 2)
+	})
+}
+
+func (s *MySuite) TestParseConceptsWithCacheGivesTheConceptsOfAFreshParse(c *C) {
+	withParseCache(c, func(dir string) {
+		text := "# concept with <a>\n* a step with <a>\n* another step\n"
+		file := filepath.Join(dir, "foo.cpt")
+
+		fresh, freshRes := parseConceptsWithCache(text, file)
+		cached, cachedRes := parseConceptsWithCache(text, file)
+
+		c.Assert(cachedRes, DeepEquals, freshRes)
+		c.Assert(cached, DeepEquals, fresh)
+	})
+}
+
+func (s *MySuite) TestSpecsThatReadFilesAreNotCached(c *C) {
+	withParseCache(c, func(dir string) {
+		file := filepath.Join(dir, "foo.spec")
+
+		parseSpecWithCache("# Spec heading\n## Scenario heading\n* a step with <file:foo.txt>\n", file, gauge.NewConceptDictionary())
+
+		c.Assert(common.FileExists(cacheFilePath(file)), Equals, false)
+	})
+}
+
+func (s *MySuite) TestCacheEntriesOfRemovedFilesArePruned(c *C) {
+	withParseCache(c, func(dir string) {
+		text := "# Spec heading\n## Scenario heading\n* a step\n"
+		kept, removed := filepath.Join(dir, "kept.spec"), filepath.Join(dir, "removed.spec")
+		c.Assert(ioutil.WriteFile(kept, []byte(text), common.NewFilePermissions), IsNil)
+		for _, file := range []string{kept, removed} {
+			spec, res := new(SpecParser).ParseSpecText(text, file)
+			writeCacheEntry(filepath.Join(cacheRoot(), filepath.Base(file)), newSpecCacheEntry(contentHash(text), spec, res, nil))
+		}
+
+		cacheFilePath(kept)
+
+		c.Assert(common.FileExists(filepath.Join(cacheRoot(), "kept.spec")), Equals, true)
+		c.Assert(common.FileExists(filepath.Join(cacheRoot(), "removed.spec")), Equals, false)
+	})
+}
@@ -19,9 +19,11 @@ package parser
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/env"
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/util"
@@ -36,10 +38,14 @@ type ConceptParser struct {
 // Parse Generates token for the given concept file and cretes concepts(array of steps) and parse results.
 // concept file can have multiple concept headings.
 func (parser *ConceptParser) Parse(text, fileName string) ([]*gauge.Step, *ParseResult) {
-	defer parser.resetState()
-
 	specParser := new(SpecParser)
 	tokens, errs := specParser.GenerateTokens(text, fileName)
+	return parser.parseTokens(tokens, errs, fileName)
+}
+
+func (parser *ConceptParser) parseTokens(tokens []*Token, errs []ParseError, fileName string) ([]*gauge.Step, *ParseResult) {
+	defer parser.resetState()
+
 	concepts, res := parser.createConcepts(tokens, fileName)
 	return concepts, &ParseResult{ParseErrors: append(errs, res.ParseErrors...), Warnings: res.Warnings}
 }
@@ -50,7 +56,7 @@ func (parser *ConceptParser) ParseFile(file string) ([]*gauge.Step, *ParseResult
 	if fileReadErr != nil {
 		return nil, &ParseResult{ParseErrors: []ParseError{{Message: fmt.Sprintf("failed to read concept file %s", file)}}}
 	}
-	return parser.Parse(fileText, file)
+	return parseConceptsWithCache(fileText, file)
 }
 
 func (parser *ConceptParser) resetState() {
@@ -114,6 +120,10 @@ func (parser *ConceptParser) createConcepts(tokens []*Token, fileName string) ([
 			retainStates(&parser.currentState, conceptScope)
 			addStates(&parser.currentState, commentScope)
 			comment := &gauge.Comment{Value: token.Value, LineNo: token.LineNo}
+			if token.Kind == gauge.MetaKind {
+				// concepts have no metadata, the line is kept as it is written
+				comment.Value = common.TrimTrailingSpace(token.LineText)
+			}
 			if parser.currentConcept == nil {
 				preComments = append(preComments, comment)
 				addPreComments = true
@@ -225,6 +235,7 @@ func CreateConceptsDictionary() (*gauge.ConceptDictionary, *ParseResult, error)
 	for cpt := range cptFilesMap {
 		conceptFiles = append(conceptFiles, cpt)
 	}
+	sort.Strings(conceptFiles)
 	conceptsDictionary := gauge.NewConceptDictionary()
 	res := &ParseResult{Ok: true}
 	if _, errs, e := AddConcepts(conceptFiles, conceptsDictionary); len(errs) > 0 {
@@ -242,6 +253,7 @@ func CreateConceptsDictionary() (*gauge.ConceptDictionary, *ParseResult, error)
 		res.Ok = false
 		res.ParseErrors = append(res.ParseErrors, vRes.ParseErrors...)
 	}
+	res.Warnings = append(res.Warnings, vRes.Warnings...)
 	return conceptsDictionary, res, nil
 }
 
@@ -273,11 +285,17 @@ func AddConcept(concepts []*gauge.Step, file string, conceptDictionary *gauge.Co
 }
 
 // AddConcepts parses the given concept file and adds each concept to the concept dictionary.
+// The files are parsed concurrently, but the concepts are added to the dictionary in the order of the given files.
 func AddConcepts(conceptFiles []string, conceptDictionary *gauge.ConceptDictionary) ([]*gauge.Step, []ParseError, error) {
 	var conceptSteps []*gauge.Step
 	var parseResults []*ParseResult
-	for _, conceptFile := range conceptFiles {
-		concepts, parseRes := new(ConceptParser).ParseFile(conceptFile)
+	parsedConcepts := make([][]*gauge.Step, len(conceptFiles))
+	conceptParseResults := make([]*ParseResult, len(conceptFiles))
+	forEachConcurrently(len(conceptFiles), func(i int) {
+		parsedConcepts[i], conceptParseResults[i] = new(ConceptParser).ParseFile(conceptFiles[i])
+	})
+	for i, conceptFile := range conceptFiles {
+		concepts, parseRes := parsedConcepts[i], conceptParseResults[i]
 		if parseRes != nil && parseRes.Warnings != nil {
 			for _, warning := range parseRes.Warnings {
 				logger.Warningf(true, warning.String())
@@ -302,21 +320,26 @@ func collectAllParseErrors(results []*ParseResult) (errs []ParseError) {
 	return
 }
 
-// ValidateConcepts ensures that there are no circular references within
+// ValidateConcepts ensures that there are no circular references within concepts. Every cycle is reported with
+// the full chain of concepts, and the concepts in it are removed from the dictionary. It also warns about
+// concepts nested deeper than the configured maximum.
 func ValidateConcepts(conceptDictionary *gauge.ConceptDictionary) *ParseResult {
 	res := &ParseResult{ParseErrors: []ParseError{}}
+	cycles := findConceptCycles(conceptDictionary)
 	var conceptsWithError []*gauge.Concept
-	for _, concept := range conceptDictionary.ConceptsMap {
-		errs := checkCircularReferencing(conceptDictionary, concept.ConceptStep, nil)
-		if errs != nil {
-			delete(conceptDictionary.ConceptsMap, concept.ConceptStep.Value)
-			res.ParseErrors = append(res.ParseErrors, errs...)
-			conceptsWithError = append(conceptsWithError, concept)
+	for _, cycle := range cycles {
+		res.ParseErrors = append(res.ParseErrors, cycle.errors()...)
+		for _, hop := range cycle {
+			conceptsWithError = append(conceptsWithError, hop.concept)
 		}
 	}
+	for _, con := range conceptsWithError {
+		delete(conceptDictionary.ConceptsMap, con.ConceptStep.Value)
+	}
 	for _, con := range conceptsWithError {
 		removeAllReferences(conceptDictionary, con)
 	}
+	res.Warnings = checkNestingDepth(conceptDictionary, env.MaxConceptNestingDepth())
 	return res
 }
 
@@ -332,41 +355,150 @@ func removeAllReferences(conceptDictionary *gauge.ConceptDictionary, concept *ga
 	}
 }
 
-func checkCircularReferencing(conceptDictionary *gauge.ConceptDictionary, concept *gauge.Step, traversedSteps map[string]string) []ParseError {
-	if traversedSteps == nil {
-		traversedSteps = make(map[string]string, 0)
+// conceptHop is a step of a concept which uses another concept.
+type conceptHop struct {
+	concept *gauge.Concept
+	step    *gauge.Step
+}
+
+// conceptCycle is the chain of hops which leads back to the concept it starts from.
+type conceptCycle []conceptHop
+
+func (cycle conceptCycle) String() string {
+	var hops []string
+	for _, hop := range cycle {
+		hops = append(hops, fmt.Sprintf("\"%s\" (%s:%d)", hop.concept.ConceptStep.LineText, hop.concept.FileName, hop.step.LineNo))
+	}
+	first := cycle[0].concept
+	hops = append(hops, fmt.Sprintf("\"%s\" (%s:%d)", first.ConceptStep.LineText, first.FileName, first.ConceptStep.LineNo))
+	return strings.Join(hops, " -> ")
+}
+
+// errors reports the cycle on the step which closes it and on the heading of the concept having that step.
+func (cycle conceptCycle) errors() []ParseError {
+	last := cycle[len(cycle)-1]
+	msg := fmt.Sprintf("Circular reference found in concept. %s", cycle)
+	return []ParseError{
+		{FileName: last.concept.FileName, LineText: last.step.LineText, LineNo: last.step.LineNo, Message: msg},
+		{FileName: last.concept.FileName, LineText: last.concept.ConceptStep.LineText, LineNo: last.concept.ConceptStep.LineNo, Message: msg},
 	}
-	con := conceptDictionary.Search(concept.Value)
-	if con == nil {
+}
+
+const (
+	unvisited = iota
+	visiting
+	visited
+)
+
+type cycleDetector struct {
+	dictionary *gauge.ConceptDictionary
+	state      map[string]int
+	path       []conceptHop
+	cycles     []conceptCycle
+}
+
+// findConceptCycles walks the concepts depth first, in the order they are defined, and gives every cycle
+// found through a step referring to a concept that is still being walked.
+func findConceptCycles(conceptDictionary *gauge.ConceptDictionary) []conceptCycle {
+	d := &cycleDetector{dictionary: conceptDictionary, state: make(map[string]int)}
+	for _, concept := range sortedConcepts(conceptDictionary) {
+		if d.state[concept.ConceptStep.Value] == unvisited {
+			d.visit(concept)
+		}
+	}
+	return d.cycles
+}
+
+func (d *cycleDetector) visit(concept *gauge.Concept) {
+	d.state[concept.ConceptStep.Value] = visiting
+	for _, step := range concept.ConceptStep.ConceptSteps {
+		next := d.dictionary.Search(step.Value)
+		if next == nil {
+			continue
+		}
+		d.path = append(d.path, conceptHop{concept: concept, step: step})
+		switch d.state[next.ConceptStep.Value] {
+		case visiting:
+			d.cycles = append(d.cycles, d.cycleFrom(next))
+		case unvisited:
+			d.visit(next)
+		}
+		d.path = d.path[:len(d.path)-1]
+	}
+	d.state[concept.ConceptStep.Value] = visited
+}
+
+func (d *cycleDetector) cycleFrom(concept *gauge.Concept) conceptCycle {
+	for i, hop := range d.path {
+		if hop.concept.ConceptStep.Value == concept.ConceptStep.Value {
+			return append(conceptCycle{}, d.path[i:]...)
+		}
+	}
+	return nil
+}
+
+// checkNestingDepth warns about the outermost concepts which nest other concepts deeper than the given depth.
+// The dictionary must not have cycles. A depth of zero disables the check.
+func checkNestingDepth(conceptDictionary *gauge.ConceptDictionary, maxDepth int) []*Warning {
+	if maxDepth <= 0 {
 		return nil
 	}
-	currentConceptFileName := con.FileName
-	traversedSteps[concept.Value] = currentConceptFileName
-	for _, step := range concept.ConceptSteps {
-		if _, exists := traversedSteps[step.Value]; exists {
-			conceptDictionary.Remove(concept.Value)
-			return []ParseError{
-				{
-					FileName: step.FileName,
-					LineText: step.LineText,
-					LineNo:   step.LineNo,
-					Message:  fmt.Sprintf("Circular reference found in concept. \"%s\" => %s:%d", concept.LineText, concept.FileName, concept.LineNo),
-				},
-				{
-					FileName: concept.FileName,
-					LineText: concept.LineText,
-					LineNo:   concept.LineNo,
-					Message:  fmt.Sprintf("Circular reference found in concept. \"%s\" => %s:%d", step.LineText, step.FileName, step.LineNo),
-				},
-			}
+	depths := make(map[string][]*gauge.Concept)
+	used := make(map[string]bool)
+	var deepest func(concept *gauge.Concept) []*gauge.Concept
+	deepest = func(concept *gauge.Concept) []*gauge.Concept {
+		if chain, ok := depths[concept.ConceptStep.Value]; ok {
+			return chain
 		}
-		if step.IsConcept {
-			if errs := checkCircularReferencing(conceptDictionary, step, traversedSteps); errs != nil {
-				conceptDictionary.Remove(concept.Value)
-				return errs
+		var longest []*gauge.Concept
+		for _, step := range concept.ConceptStep.ConceptSteps {
+			if next := conceptDictionary.Search(step.Value); next != nil {
+				used[next.ConceptStep.Value] = true
+				if chain := deepest(next); len(chain) > len(longest) {
+					longest = chain
+				}
 			}
 		}
+		chain := append([]*gauge.Concept{concept}, longest...)
+		depths[concept.ConceptStep.Value] = chain
+		return chain
 	}
-	delete(traversedSteps, concept.Value)
-	return nil
+	concepts := sortedConcepts(conceptDictionary)
+	for _, concept := range concepts {
+		deepest(concept)
+	}
+	var warnings []*Warning
+	for _, concept := range concepts {
+		chain := depths[concept.ConceptStep.Value]
+		if used[concept.ConceptStep.Value] || len(chain) <= maxDepth {
+			continue
+		}
+		var names []string
+		for _, c := range chain {
+			names = append(names, fmt.Sprintf("\"%s\"", c.ConceptStep.LineText))
+		}
+		warnings = append(warnings, &Warning{
+			FileName: concept.FileName,
+			LineNo:   concept.ConceptStep.LineNo,
+			Message:  fmt.Sprintf("Concept nests %d levels deep, more than the maximum of %d: %s", len(chain), maxDepth, strings.Join(names, " -> ")),
+		})
+	}
+	return warnings
+}
+
+func sortedConcepts(conceptDictionary *gauge.ConceptDictionary) []*gauge.Concept {
+	var concepts []*gauge.Concept
+	for _, concept := range conceptDictionary.ConceptsMap {
+		concepts = append(concepts, concept)
+	}
+	sort.Slice(concepts, func(i, j int) bool {
+		if concepts[i].FileName != concepts[j].FileName {
+			return concepts[i].FileName < concepts[j].FileName
+		}
+		if concepts[i].ConceptStep.LineNo != concepts[j].ConceptStep.LineNo {
+			return concepts[i].ConceptStep.LineNo < concepts[j].ConceptStep.LineNo
+		}
+		return concepts[i].ConceptStep.Value < concepts[j].ConceptStep.Value
+	})
+	return concepts
 }
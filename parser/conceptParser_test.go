@@ -594,6 +594,53 @@ func (s *MySuite) TestErrorParsingConceptWithRecursiveCallToConcept(c *C) {
 	c.Assert(containsAny(res.ParseErrors, "Circular reference found"), Equals, true)
 }
 
+func (s *MySuite) TestCircularReferenceErrorHasTheFullChain(c *C) {
+	cd := gauge.NewConceptDictionary()
+	a := &gauge.Step{LineText: "A", Value: "A", IsConcept: true, LineNo: 1, ConceptSteps: []*gauge.Step{&gauge.Step{LineText: "B", Value: "B", IsConcept: true, LineNo: 2}}}
+	b := &gauge.Step{LineText: "B", Value: "B", IsConcept: true, LineNo: 4, ConceptSteps: []*gauge.Step{&gauge.Step{LineText: "C", Value: "C", IsConcept: true, LineNo: 5}}}
+	cpt := &gauge.Step{LineText: "C", Value: "C", IsConcept: true, LineNo: 1, ConceptSteps: []*gauge.Step{&gauge.Step{LineText: "A", Value: "A", IsConcept: true, LineNo: 3}}}
+	AddConcept([]*gauge.Step{a, b}, "a.cpt", cd)
+	AddConcept([]*gauge.Step{cpt}, "c.cpt", cd)
+
+	res := ValidateConcepts(cd)
+
+	c.Assert(len(res.ParseErrors), Equals, 2)
+	msg := "Circular reference found in concept. \"A\" (a.cpt:2) -> \"B\" (a.cpt:5) -> \"C\" (c.cpt:3) -> \"A\" (a.cpt:1)"
+	c.Assert(res.ParseErrors[0], Equals, ParseError{FileName: "c.cpt", LineNo: 3, LineText: "A", Message: msg})
+	c.Assert(res.ParseErrors[1], Equals, ParseError{FileName: "c.cpt", LineNo: 1, LineText: "C", Message: msg})
+	c.Assert(len(cd.ConceptsMap), Equals, 0)
+}
+
+func (s *MySuite) TestConceptUsingACycleIsNotPartOfIt(c *C) {
+	cd := gauge.NewConceptDictionary()
+	outer := &gauge.Step{LineText: "outer", Value: "outer", IsConcept: true, LineNo: 1, ConceptSteps: []*gauge.Step{&gauge.Step{LineText: "A", Value: "A", IsConcept: true, LineNo: 2}}}
+	a := &gauge.Step{LineText: "A", Value: "A", IsConcept: true, LineNo: 4, ConceptSteps: []*gauge.Step{&gauge.Step{LineText: "A", Value: "A", IsConcept: true, LineNo: 5}}}
+	AddConcept([]*gauge.Step{outer, a}, "a.cpt", cd)
+
+	res := ValidateConcepts(cd)
+
+	c.Assert(len(res.ParseErrors), Equals, 2)
+	c.Assert(res.ParseErrors[0].Message, Equals, "Circular reference found in concept. \"A\" (a.cpt:5) -> \"A\" (a.cpt:4)")
+	c.Assert(cd.ConceptsMap["outer"], NotNil)
+	c.Assert(len(cd.ConceptsMap["outer"].ConceptStep.ConceptSteps), Equals, 0)
+}
+
+func (s *MySuite) TestWarningForConceptsNestedDeeperThanMaximum(c *C) {
+	cd := gauge.NewConceptDictionary()
+	a := &gauge.Step{LineText: "A", Value: "A", IsConcept: true, LineNo: 1, ConceptSteps: []*gauge.Step{&gauge.Step{LineText: "B", Value: "B", IsConcept: true, LineNo: 2}}}
+	b := &gauge.Step{LineText: "B", Value: "B", IsConcept: true, LineNo: 4, ConceptSteps: []*gauge.Step{&gauge.Step{LineText: "C", Value: "C", IsConcept: true, LineNo: 5}}}
+	cpt := &gauge.Step{LineText: "C", Value: "C", IsConcept: true, LineNo: 7, ConceptSteps: []*gauge.Step{&gauge.Step{LineText: "a step", Value: "a step", LineNo: 8}}}
+	AddConcept([]*gauge.Step{a, b, cpt}, "a.cpt", cd)
+
+	c.Assert(len(checkNestingDepth(cd, 3)), Equals, 0)
+	c.Assert(len(checkNestingDepth(cd, 0)), Equals, 0)
+
+	warnings := checkNestingDepth(cd, 2)
+
+	c.Assert(len(warnings), Equals, 1)
+	c.Assert(*warnings[0], Equals, Warning{FileName: "a.cpt", LineNo: 1, Message: "Concept nests 3 levels deep, more than the maximum of 2: \"A\" -> \"B\" -> \"C\""})
+}
+
 func (s *MySuite) TestConceptHavingDynamicParameters(c *C) {
 	conceptText := newSpecBuilder().
 		specHeading("create user <user:id> <user:name> and <file>").
@@ -605,6 +652,13 @@ func (s *MySuite) TestConceptHavingDynamicParameters(c *C) {
 	c.Assert(step[0].Args[2].ArgType, Equals, gauge.Dynamic)
 }
 
+func (s *MySuite) TestConceptKeepsMetaLineAsComment(c *C) {
+	concepts, parseRes := new(ConceptParser).Parse("# my concept\nmeta: owner\n* a step\n", "")
+
+	c.Assert(len(parseRes.ParseErrors), Equals, 0)
+	c.Assert(concepts[0].Items[1].(*gauge.Comment).Value, Equals, "meta: owner")
+}
+
 func (s *MySuite) TestConceptHavingInvalidSpecialParameters(c *C) {
 	conceptText := newSpecBuilder().
 		specHeading("create user <user:id> <table:name> and <file>").
@@ -26,14 +26,17 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 	scenarioConverter := converterFn(func(token *Token, state *int) bool {
 		return token.Kind == gauge.ScenarioKind
 	}, func(token *Token, spec *gauge.Specification, state *int) ParseResult {
+		result := ParseResult{Ok: true}
 		if spec.Heading == nil {
-			return ParseResult{Ok: false, ParseErrors: []ParseError{ParseError{spec.FileName, token.LineNo, "Scenario should be defined after the spec heading", token.LineText}}}
+			result = ParseResult{Ok: false, ParseErrors: []ParseError{ParseError{spec.FileName, token.LineNo, "Scenario should be defined after the spec heading", token.LineText}}}
 		}
 		for _, scenario := range spec.Scenarios {
 			if strings.ToLower(scenario.Heading.Value) == strings.ToLower(token.Value) {
-				return ParseResult{Ok: false, ParseErrors: []ParseError{ParseError{spec.FileName, token.LineNo, "Duplicate scenario definition '" + scenario.Heading.Value + "' found in the same specification", token.LineText}}}
+				result = ParseResult{Ok: false, ParseErrors: []ParseError{ParseError{spec.FileName, token.LineNo, "Duplicate scenario definition '" + scenario.Heading.Value + "' found in the same specification", token.LineText}}}
+				break
 			}
 		}
+		// the scenario is added even if it is not valid, so that the errors in its steps and tables are reported too
 		scenario := &gauge.Scenario{Span: &gauge.Span{Start: token.LineNo, End: token.LineNo}}
 		if len(spec.Scenarios) > 0 {
 			spec.LatestScenario().Span.End = token.LineNo - 1
@@ -43,7 +46,7 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 
 		retainStates(state, specScope)
 		addStates(state, scenarioScope)
-		return ParseResult{Ok: true}
+		return result
 	})
 
 	stepConverter := converterFn(func(token *Token, state *int) bool {
@@ -52,6 +55,8 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 		latestScenario := spec.LatestScenario()
 		stepToAdd, parseDetails := createStep(spec, latestScenario, token)
 		if stepToAdd == nil {
+			retainStates(state, specScope, scenarioScope)
+			addStates(state, invalidStepScope)
 			return ParseResult{ParseErrors: parseDetails.ParseErrors, Ok: false, Warnings: parseDetails.Warnings}
 		}
 		latestScenario.AddStep(stepToAdd)
@@ -71,6 +76,8 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 	}, func(token *Token, spec *gauge.Specification, state *int) ParseResult {
 		stepToAdd, parseDetails := createStep(spec, nil, token)
 		if stepToAdd == nil {
+			retainStates(state, specScope)
+			addStates(state, invalidStepScope)
 			return ParseResult{ParseErrors: parseDetails.ParseErrors, Ok: false, Warnings: parseDetails.Warnings}
 		}
 		spec.AddContext(stepToAdd)
@@ -100,6 +107,8 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 	}, func(token *Token, spec *gauge.Specification, state *int) ParseResult {
 		stepToAdd, parseDetails := createStep(spec, nil, token)
 		if stepToAdd == nil {
+			retainStates(state, specScope, tearDownScope)
+			addStates(state, invalidStepScope)
 			return ParseResult{ParseErrors: parseDetails.ParseErrors, Ok: false, Warnings: parseDetails.Warnings}
 		}
 		spec.TearDownSteps = append(spec.TearDownSteps, stepToAdd)
@@ -147,11 +156,11 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 		} else if isInState(*state, specScope) && spec.DataTable.IsInitialized() {
 			value := "Multiple data table present, ignoring table"
 			spec.AddComment(&gauge.Comment{Value: token.LineText, LineNo: token.LineNo})
-			return ParseResult{Ok: false, Warnings: []*Warning{&Warning{spec.FileName, token.LineNo, value}}}
+			return ParseResult{Ok: false, Warnings: []*Warning{&Warning{FileName: spec.FileName, LineNo: token.LineNo, Message: value}}}
 		} else {
 			value := "Data table not associated with spec"
 			spec.AddComment(&gauge.Comment{Value: token.LineText, LineNo: token.LineNo})
-			return ParseResult{Ok: false, Warnings: []*Warning{&Warning{spec.FileName, token.LineNo, value}}}
+			return ParseResult{Ok: false, Warnings: []*Warning{&Warning{FileName: spec.FileName, LineNo: token.LineNo, Message: value}}}
 		}
 		retainStates(state, specScope)
 		addStates(state, keywordScope)
@@ -161,6 +170,11 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 	tableHeaderConverter := converterFn(func(token *Token, state *int) bool {
 		return token.Kind == gauge.TableHeader && isInAnyState(*state, specScope)
 	}, func(token *Token, spec *gauge.Specification, state *int) ParseResult {
+		if isInState(*state, invalidStepScope) {
+			// the inline table of a step which could not be parsed is ignored, rather than taken for a data table
+			addComment(spec, token, state)
+			return ParseResult{Ok: true}
+		}
 		if isInState(*state, stepScope) {
 			latestScenario := spec.LatestScenario()
 			latestStep := latestScenario.LatestStep()
@@ -184,7 +198,7 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 			} else {
 				scn.AddComment(&gauge.Comment{Value: token.LineText, LineNo: token.LineNo})
 				return ParseResult{Ok: false, Warnings: []*Warning{
-					&Warning{spec.FileName, token.LineNo, "Multiple data table present, ignoring table"}}}
+					&Warning{FileName: spec.FileName, LineNo: token.LineNo, Message: "Multiple data table present, ignoring table"}}}
 			}
 		} else {
 			if !spec.DataTable.Table.IsInitialized() {
@@ -193,8 +207,8 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 				spec.AddDataTable(dataTable)
 			} else {
 				spec.AddComment(&gauge.Comment{Value: token.LineText, LineNo: token.LineNo})
-				return ParseResult{Ok: false, Warnings: []*Warning{&Warning{spec.FileName,
-					token.LineNo, "Multiple data table present, ignoring table"}}}
+				return ParseResult{Ok: false, Warnings: []*Warning{&Warning{FileName: spec.FileName,
+					LineNo: token.LineNo, Message: "Multiple data table present, ignoring table"}}}
 			}
 		}
 		retainStates(state, specScope, scenarioScope, stepScope, contextScope, tearDownScope)
@@ -207,12 +221,8 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 	}, func(token *Token, spec *gauge.Specification, state *int) ParseResult {
 		var result ParseResult
 		//When table is to be treated as a comment
-		if !isInState(*state, tableScope) {
-			if isInState(*state, scenarioScope) {
-				spec.LatestScenario().AddComment(&gauge.Comment{Value: token.LineText, LineNo: token.LineNo})
-			} else {
-				spec.AddComment(&gauge.Comment{Value: token.LineText, LineNo: token.LineNo})
-			}
+		if !isInState(*state, tableScope) || isInState(*state, invalidStepScope) {
+			addComment(spec, token, state)
 		} else if areUnderlined(token.Args) && !isInState(*state, tableSeparatorScope) {
 			retainStates(state, specScope, scenarioScope, stepScope, contextScope, tearDownScope, tableScope)
 			addStates(state, tableSeparatorScope)
@@ -246,14 +256,14 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 				result = ParseResult{Ok: true, Warnings: warnings}
 			}
 		}
-		retainStates(state, specScope, scenarioScope, stepScope, contextScope, tearDownScope, tableScope, tableSeparatorScope)
+		retainStates(state, specScope, scenarioScope, stepScope, contextScope, tearDownScope, tableScope, tableSeparatorScope, invalidStepScope)
 		return result
 	})
 
 	tagConverter := converterFn(func(token *Token, state *int) bool {
 		return (token.Kind == gauge.TagKind)
 	}, func(token *Token, spec *gauge.Specification, state *int) ParseResult {
-		tags := &gauge.Tags{RawValues: [][]string{token.Args}}
+		tags := &gauge.Tags{RawValues: [][]string{token.Args}, LineNo: token.LineNo}
 		if isInState(*state, scenarioScope) {
 			if isInState(*state, tagsScope) {
 				spec.LatestScenario().Tags.Add(tags.RawValues[0])
@@ -277,8 +287,29 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 		return ParseResult{Ok: true}
 	})
 
+	metaConverter := converterFn(func(token *Token, state *int) bool {
+		return token.Kind == gauge.MetaKind
+	}, func(token *Token, spec *gauge.Specification, state *int) ParseResult {
+		meta, err := gauge.NewMeta(token.LineNo, token.Args...)
+		if err != nil {
+			return ParseResult{Ok: false, ParseErrors: []ParseError{ParseError{FileName: spec.FileName, LineNo: token.LineNo, Message: err.Error(), LineText: token.LineText}}}
+		}
+		if isInState(*state, scenarioScope) {
+			if spec.LatestScenario().Meta != nil {
+				return ParseResult{Ok: false, ParseErrors: []ParseError{ParseError{FileName: spec.FileName, LineNo: token.LineNo, Message: "Metadata can be defined only once per scenario", LineText: token.LineText}}}
+			}
+			spec.LatestScenario().AddMeta(meta)
+		} else {
+			if spec.Meta != nil {
+				return ParseResult{Ok: false, ParseErrors: []ParseError{ParseError{FileName: spec.FileName, LineNo: token.LineNo, Message: "Metadata can be defined only once per specification", LineText: token.LineText}}}
+			}
+			spec.AddMeta(meta)
+		}
+		return ParseResult{Ok: true}
+	})
+
 	converter := []func(*Token, *int, *gauge.Specification) ParseResult{
-		specConverter, scenarioConverter, stepConverter, contextConverter, commentConverter, tableHeaderConverter, tableRowConverter, tagConverter, keywordConverter, tearDownConverter, tearDownStepConverter,
+		specConverter, scenarioConverter, stepConverter, contextConverter, commentConverter, tableHeaderConverter, tableRowConverter, tagConverter, metaConverter, keywordConverter, tearDownConverter, tearDownStepConverter,
 	}
 
 	return converter
@@ -293,6 +324,14 @@ func converterFn(predicate func(token *Token, state *int) bool, apply func(token
 	}
 }
 
+func addComment(spec *gauge.Specification, token *Token, state *int) {
+	if isInState(*state, scenarioScope) {
+		spec.LatestScenario().AddComment(&gauge.Comment{Value: token.LineText, LineNo: token.LineNo})
+	} else {
+		spec.AddComment(&gauge.Comment{Value: token.LineText, LineNo: token.LineNo})
+	}
+}
+
 //Step value is modified when inline table is found to account for the new parameter by appending {}
 //todo validate headers for dynamic
 func addInlineTableHeader(step *gauge.Step, token *Token) {
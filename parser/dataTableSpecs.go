@@ -25,41 +25,85 @@ import (
 // GetSpecsForDataTableRows creates a spec for each data table row
 func GetSpecsForDataTableRows(s []*gauge.Specification, errMap *gauge.BuildErrors) (specs []*gauge.Specification) {
 	for _, spec := range s {
-		if spec.DataTable.IsInitialized() {
-			if spec.UsesArgsInContextTeardown(spec.DataTable.Table.Headers...) {
-				specs = append(specs, createSpecsForTableRows(spec, spec.Scenarios, errMap)...)
-			} else {
-				nonTableRelatedScenarios, tableRelatedScenarios := FilterTableRelatedScenarios(spec.Scenarios, func(scenario *gauge.Scenario) bool {
-					return scenario.UsesArgsInSteps(spec.DataTable.Table.Headers...)
-				})
-				if len(tableRelatedScenarios) > 0 {
-					s := createSpecsForTableRows(spec, tableRelatedScenarios, errMap)
-					s[0].Scenarios = append(s[0].Scenarios, nonTableRelatedScenarios...)
-					specs = append(specs, s...)
-				} else {
-					specs = append(specs, createSpec(copyScenarios(nonTableRelatedScenarios, gauge.Table{}, 0, errMap), &gauge.Table{}, spec, errMap))
-				}
-			}
-		} else {
-			spec.Scenarios = copyScenarios(spec.Scenarios, gauge.Table{}, 0, errMap)
-			specs = append(specs, spec)
+		rows := NewDataTableRows(spec, errMap)
+		for i := 0; i < rows.Len(); i++ {
+			specs = append(specs, rows.Spec(i))
 		}
 	}
 	return
 }
 
-func createSpecsForTableRows(spec *gauge.Specification, scns []*gauge.Scenario, errMap *gauge.BuildErrors) (specs []*gauge.Specification) {
-	for i := range spec.DataTable.Table.Rows() {
-		t := getTableWithOneRow(spec.DataTable.Table, i)
-		newSpec := createSpec(copyScenarios(scns, *t, i, errMap), t, spec, errMap)
-		specs = append(specs, newSpec)
+// DataTableRows creates the specs of the rows of a data table spec one at a time, as they are asked for, so that
+// the specs of all the rows of a large table need not be held at once. A spec without a data table is its only row.
+type DataTableRows struct {
+	spec      *gauge.Specification
+	scenarios []*gauge.Scenario
+	others    []*gauge.Scenario
+	perRow    bool
+	count     int
+	errMap    *gauge.BuildErrors
+}
+
+// NewDataTableRows gives the rows of the given spec. The scenarios which do not use the data table are executed
+// along with the first row.
+func NewDataTableRows(spec *gauge.Specification, errMap *gauge.BuildErrors) *DataTableRows {
+	r := &DataTableRows{spec: spec, errMap: errMap, count: 1}
+	if !spec.DataTable.IsInitialized() {
+		return r
 	}
-	return
+	if spec.UsesArgsInContextTeardown(spec.DataTable.Table.Headers...) {
+		r.scenarios, r.perRow = spec.Scenarios, true
+	} else {
+		r.others, r.scenarios = FilterTableRelatedScenarios(spec.Scenarios, func(scenario *gauge.Scenario) bool {
+			return scenario.UsesArgsInSteps(spec.DataTable.Table.Headers...)
+		})
+		r.perRow = len(r.scenarios) > 0
+	}
+	if r.perRow {
+		r.count = spec.DataTable.Table.GetRowCount()
+	}
+	return r
+}
+
+// Len gives the number of rows.
+func (r *DataTableRows) Len() int {
+	return r.count
+}
+
+// ScenarioCount gives the number of scenarios of all the rows, without creating their specs.
+func (r *DataTableRows) ScenarioCount() int {
+	if !r.spec.DataTable.IsInitialized() {
+		return len(r.spec.Scenarios)
+	}
+	if !r.perRow {
+		return len(r.others)
+	}
+	if r.count == 0 {
+		return 0
+	}
+	return len(r.scenarios)*r.count + len(r.others)
+}
+
+// Spec creates the spec of the row at the given index.
+func (r *DataTableRows) Spec(i int) *gauge.Specification {
+	if !r.spec.DataTable.IsInitialized() {
+		r.spec.Scenarios = copyScenarios(r.spec.Scenarios, gauge.Table{}, 0, r.errMap)
+		return r.spec
+	}
+	if !r.perRow {
+		return createSpec(copyScenarios(r.others, gauge.Table{}, 0, r.errMap), &gauge.Table{}, r.spec, r.errMap)
+	}
+	t := getTableWithOneRow(r.spec.DataTable.Table, i)
+	s := createSpec(copyScenarios(r.scenarios, *t, i, r.errMap), t, r.spec, r.errMap)
+	if i == 0 {
+		s.Scenarios = append(s.Scenarios, r.others...)
+	}
+	return s
 }
 
 func createSpec(scns []*gauge.Scenario, table *gauge.Table, spec *gauge.Specification, errMap *gauge.BuildErrors) *gauge.Specification {
 	dt := &gauge.DataTable{Table: *table, Value: spec.DataTable.Value, LineNo: spec.DataTable.LineNo, IsExternal: spec.DataTable.IsExternal}
-	s := &gauge.Specification{DataTable: *dt, FileName: spec.FileName, Heading: spec.Heading, Scenarios: scns, Contexts: spec.Contexts, TearDownSteps: spec.TearDownSteps, Tags: spec.Tags}
+	s := &gauge.Specification{DataTable: *dt, FileName: spec.FileName, Heading: spec.Heading, Scenarios: scns, Contexts: spec.Contexts, TearDownSteps: spec.TearDownSteps, Tags: spec.Tags, Meta: spec.Meta}
 	index := 0
 	for _, item := range spec.Items {
 		if item.Kind() == gauge.DataTableKind {
@@ -91,6 +135,7 @@ func copyScenarios(scenarios []*gauge.Scenario, table gauge.Table, i int, errMap
 			SpecDataTableRow:      table,
 			SpecDataTableRowIndex: i,
 			Tags:     scn.Tags,
+			Meta:     scn.Meta,
 			Comments: scn.Comments,
 			Span:     scn.Span,
 		}
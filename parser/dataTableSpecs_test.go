@@ -127,7 +127,7 @@ func TestGetTableWithOneRow(t *testing.T) {
 	}
 }
 
-func TestCreateSpecsForTableRows(t *testing.T) {
+func TestDataTableRows(t *testing.T) {
 	spec := &gauge.Specification{
 		Heading:   &gauge.Heading{},
 		Scenarios: []*gauge.Scenario{{Steps: []*gauge.Step{{Args: []*gauge.StepArg{{Value: "header", ArgType: gauge.Dynamic, Name: "header"}}}}}},
@@ -185,7 +185,11 @@ func TestCreateSpecsForTableRows(t *testing.T) {
 		},
 	}
 
-	got := createSpecsForTableRows(spec, spec.Scenarios, gauge.NewBuildErrors())
+	rows := NewDataTableRows(spec, gauge.NewBuildErrors())
+	var got []*gauge.Specification
+	for i := 0; i < rows.Len(); i++ {
+		got = append(got, rows.Spec(i))
+	}
 
 	if !reflect.DeepEqual(want, got) {
 		gotJSON, _ := json.Marshal(got)
@@ -193,3 +197,25 @@ func TestCreateSpecsForTableRows(t *testing.T) {
 		t.Errorf("Failed: Create specs for table row.\n\tWanted: %v\n\tGot: %v", string(wantJSON), string(gotJSON))
 	}
 }
+
+func TestDataTableRowsScenarioCount(t *testing.T) {
+	tableScenario := &gauge.Scenario{Steps: []*gauge.Step{{Args: []*gauge.StepArg{{Value: "header", ArgType: gauge.Dynamic, Name: "header"}}}}}
+	otherScenario := &gauge.Scenario{Steps: []*gauge.Step{{Args: []*gauge.StepArg{{Value: "abc", ArgType: gauge.Static}}}}}
+	spec := &gauge.Specification{
+		Heading:   &gauge.Heading{},
+		Scenarios: []*gauge.Scenario{tableScenario, otherScenario},
+		DataTable: gauge.DataTable{Table: *gauge.NewTable([]string{"header"}, [][]gauge.TableCell{
+			{{Value: "row1", CellType: gauge.Static}, {Value: "row2", CellType: gauge.Static}, {Value: "row3", CellType: gauge.Static}},
+		}, 0)},
+	}
+
+	rows := NewDataTableRows(spec, gauge.NewBuildErrors())
+
+	scenarios := 0
+	for i := 0; i < rows.Len(); i++ {
+		scenarios += len(rows.Spec(i).Scenarios)
+	}
+	if rows.Len() != 3 || rows.ScenarioCount() != 4 || scenarios != 4 {
+		t.Errorf("Failed: Scenarios of data table rows. Wanted: 3 rows, 4 scenarios, Got: %d rows, %d scenarios, %d counted", rows.Len(), scenarios, rows.ScenarioCount())
+	}
+}
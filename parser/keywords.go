@@ -0,0 +1,70 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package parser
+
+import (
+	"github.com/getgauge/gauge/env"
+)
+
+// Keywords of the spec language. They can be written in the language of the project, set using the
+// gauge_spec_language property, and are normalized to English by the lexer.
+const (
+	TagsKeyword     = "tags"
+	TableKeyword    = "table"
+	TearDownKeyword = "teardown"
+	MetaKeyword     = "meta"
+)
+
+// keywordTranslations holds the built-in translations of the keywords, by language.
+var keywordTranslations = map[string]map[string]string{
+	"de": {TagsKeyword: "schlagwörter", TableKeyword: "tabelle", TearDownKeyword: "abschluss", MetaKeyword: "metadaten"},
+	"es": {TagsKeyword: "etiquetas", TableKeyword: "tabla", TearDownKeyword: "limpieza", MetaKeyword: "metadatos"},
+	"fr": {TagsKeyword: "étiquettes", TableKeyword: "tableau", TearDownKeyword: "nettoyage", MetaKeyword: "métadonnées"},
+	"it": {TagsKeyword: "etichette", TableKeyword: "tabella", TearDownKeyword: "pulizia", MetaKeyword: "metadati"},
+	"nl": {TagsKeyword: "labels", TableKeyword: "tabel", TearDownKeyword: "opruimen", MetaKeyword: "metadata"},
+	"pt": {TagsKeyword: "etiquetas", TableKeyword: "tabela", TearDownKeyword: "limpeza", MetaKeyword: "metadados"},
+}
+
+// Keyword gives the keyword as it is written in the language of the project.
+func Keyword(keyword string) string {
+	if t, ok := translatedKeyword(keyword); ok {
+		return t
+	}
+	return keyword
+}
+
+// KeywordSpellings gives all the spellings of the keyword understood by the lexer, the translation first.
+// Teardown is always marked by underscores, its translation can be used in addition to them.
+func KeywordSpellings(keyword string) []string {
+	var spellings []string
+	if t, ok := translatedKeyword(keyword); ok {
+		spellings = append(spellings, t)
+	}
+	if keyword != TearDownKeyword {
+		spellings = append(spellings, keyword)
+	}
+	return spellings
+}
+
+func translatedKeyword(keyword string) (string, bool) {
+	if t, ok := env.SpecKeywords()[keyword]; ok {
+		return t, true
+	}
+	t, ok := keywordTranslations[env.SpecLanguage()][keyword]
+	return t, ok
+}
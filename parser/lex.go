@@ -43,6 +43,7 @@ const (
 	keywordScope        = 1 << iota
 	tagsScope           = 1 << iota
 	newLineScope        = 1 << iota
+	invalidStepScope    = 1 << iota
 )
 
 // Token defines the type of entity identified by the lexer
@@ -62,6 +63,7 @@ func (parser *SpecParser) initialize() {
 	parser.processors[gauge.CommentKind] = processComment
 	parser.processors[gauge.StepKind] = processStep
 	parser.processors[gauge.TagKind] = processTag
+	parser.processors[gauge.MetaKind] = processMeta
 	parser.processors[gauge.TableHeader] = processTable
 	parser.processors[gauge.TableRow] = processTable
 	parser.processors[gauge.DataTableKind] = processDataTable
@@ -121,6 +123,8 @@ func (parser *SpecParser) GenerateTokens(specText, fileName string) ([]*Token, [
 				parser.clearState()
 			}
 			newToken = &Token{Kind: gauge.TagKind, LineNo: parser.lineNo, LineText: line, Value: strings.TrimSpace(trimmedLine[startIndex:])}
+		} else if found, startIndex := parser.checkMeta(trimmedLine); found {
+			newToken = &Token{Kind: gauge.MetaKind, LineNo: parser.lineNo, LineText: line, Value: strings.TrimSpace(trimmedLine[startIndex:])}
 		} else if parser.isTableRow(trimmedLine) {
 			kind := parser.tokenKindBasedOnCurrentState(tableScope, gauge.TableRow, gauge.TableHeader)
 			newToken = &Token{Kind: kind, LineNo: parser.lineNo, LineText: line, Value: strings.TrimSpace(trimmedLine)}
@@ -151,13 +155,23 @@ func (parser *SpecParser) tokenKindBasedOnCurrentState(state int, matchingToken
 }
 
 func (parser *SpecParser) checkTag(text string) (bool, int) {
-	lowerCased := strings.ToLower
-	tagColon := "tags:"
-	tagSpaceColon := "tags :"
-	if tagStartIndex := strings.Index(lowerCased(text), tagColon); tagStartIndex == 0 {
-		return true, len(tagColon)
-	} else if tagStartIndex := strings.Index(lowerCased(text), tagSpaceColon); tagStartIndex == 0 {
-		return true, len(tagSpaceColon)
+	for _, tags := range KeywordSpellings(TagsKeyword) {
+		for _, prefix := range []string{tags + ":", tags + " :"} {
+			if len(text) >= len(prefix) && strings.EqualFold(text[:len(prefix)], prefix) {
+				return true, len(prefix)
+			}
+		}
+	}
+	return false, -1
+}
+
+func (parser *SpecParser) checkMeta(text string) (bool, int) {
+	for _, meta := range KeywordSpellings(MetaKeyword) {
+		for _, prefix := range []string{meta + ":", meta + " :"} {
+			if len(text) >= len(prefix) && strings.EqualFold(text[:len(prefix)], prefix) {
+				return true, len(prefix)
+			}
+		}
 	}
 	return false, -1
 }
@@ -198,7 +212,15 @@ func (parser *SpecParser) isTableRow(text string) bool {
 }
 
 func (parser *SpecParser) isTearDown(text string) bool {
-	return isUnderline(text, rune('_'))
+	if isUnderline(text, rune('_')) {
+		return true
+	}
+	for _, teardown := range KeywordSpellings(TearDownKeyword) {
+		if strings.EqualFold(strings.TrimSpace(strings.TrimSuffix(text, ":")), teardown) {
+			return true
+		}
+	}
+	return false
 }
 
 func (parser *SpecParser) isSpecUnderline(text string) bool {
@@ -206,10 +228,9 @@ func (parser *SpecParser) isSpecUnderline(text string) bool {
 }
 
 func (parser *SpecParser) isDataTable(text string) (string, bool) {
-	if regexp.MustCompile(`^\s*[tT][aA][bB][lL][eE]\s*:(\s*)`).FindIndex([]byte(text)) != nil {
-		index := strings.Index(text, ":")
-		if index != -1 {
-			return "table:" + " " + strings.TrimSpace(strings.SplitAfterN(text, ":", 2)[1]), true
+	for _, table := range KeywordSpellings(TableKeyword) {
+		if regexp.MustCompile(`(?i)^\s*`+regexp.QuoteMeta(table)+`\s*:(\s*)`).FindIndex([]byte(text)) != nil {
+			return TableKeyword + ":" + " " + strings.TrimSpace(strings.SplitAfterN(text, ":", 2)[1]), true
 		}
 	}
 	return "", false
@@ -672,3 +672,58 @@ func (s *MySuite) TestParsingSpecWithTearDownSteps(c *C) {
 	c.Assert(tokens[6].Kind, Equals, gauge.StepKind)
 	c.Assert(tokens[6].Value, Equals, "step2")
 }
+
+func (s *MySuite) TestParsingSpecWithLocalizedKeywords(c *C) {
+	defer func(l func() string) { env.SpecLanguage = l }(env.SpecLanguage)
+	env.SpecLanguage = func() string { return "de" }
+	specText := `# Spezifikation
+Schlagwörter: schnell, langsam
+Tabelle: daten.csv
+## Szenario
+* Schritt
+Abschluss:
+* Aufräumen
+`
+	tokens, errs := new(SpecParser).GenerateTokens(specText, "")
+
+	c.Assert(errs, IsNil)
+	c.Assert(tokens[1].Kind, Equals, gauge.TagKind)
+	c.Assert(tokens[1].Value, Equals, "schnell, langsam")
+	c.Assert(tokens[2].Kind, Equals, gauge.DataTableKind)
+	c.Assert(tokens[2].Value, Equals, "table: daten.csv")
+	c.Assert(tokens[5].Kind, Equals, gauge.TearDownKind)
+}
+
+func (s *MySuite) TestParsingSpecWithEnglishKeywordsWhenLanguageIsSet(c *C) {
+	defer func(l func() string) { env.SpecLanguage = l }(env.SpecLanguage)
+	env.SpecLanguage = func() string { return "es" }
+	specText := newSpecBuilder().specHeading("Spec").tags("fast").text("table: data.csv").String()
+
+	tokens, errs := new(SpecParser).GenerateTokens(specText, "")
+
+	c.Assert(errs, IsNil)
+	c.Assert(tokens[1].Kind, Equals, gauge.TagKind)
+	c.Assert(tokens[2].Kind, Equals, gauge.DataTableKind)
+}
+
+func (s *MySuite) TestParsingSpecWithConfiguredKeywords(c *C) {
+	defer func(k func() map[string]string) { env.SpecKeywords = k }(env.SpecKeywords)
+	env.SpecKeywords = func() map[string]string {
+		return map[string]string{TagsKeyword: "Etiketten", TearDownKeyword: "Nachher"}
+	}
+	specText := `# Spec
+etiketten : fast
+## Scenario
+* step
+nachher
+* cleanup
+`
+	tokens, errs := new(SpecParser).GenerateTokens(specText, "")
+
+	c.Assert(errs, IsNil)
+	c.Assert(tokens[1].Kind, Equals, gauge.TagKind)
+	c.Assert(tokens[1].Value, Equals, "fast")
+	c.Assert(tokens[4].Kind, Equals, gauge.TearDownKind)
+	c.Assert(Keyword(TagsKeyword), Equals, "Etiketten")
+	c.Assert(Keyword(TableKeyword), Equals, TableKeyword)
+}
@@ -39,6 +39,7 @@ package parser
 import (
 	"runtime/debug"
 	"strings"
+	"sync"
 
 	"regexp"
 	"strconv"
@@ -53,30 +54,26 @@ import (
 
 // ParseSpecFiles gets all the spec files and parse each spec file.
 // Generates specifications and parse results.
-// TODO: Use single channel instead of one for spec and another for result, so that mapping is consistent
+// The files are parsed concurrently, but the specifications and parse results are in the order of the given files.
 func ParseSpecFiles(specFiles []string, conceptDictionary *gauge.ConceptDictionary, buildErrors *gauge.BuildErrors) ([]*gauge.Specification, []*ParseResult) {
-	parseResultsChan := make(chan *ParseResult, len(specFiles))
-	specsChan := make(chan *gauge.Specification, len(specFiles))
-	var parseResults []*ParseResult
+	parsedSpecs := make([]*gauge.Specification, len(specFiles))
+	parseResults := make([]*ParseResult, len(specFiles))
 	var specs []*gauge.Specification
 
-	for _, specFile := range specFiles {
-		go parseSpec(specFile, conceptDictionary, specsChan, parseResultsChan)
-	}
-	for range specFiles {
-		parseRes := <-parseResultsChan
-		spec := <-specsChan
+	forEachConcurrently(len(specFiles), func(i int) {
+		parsedSpecs[i], parseResults[i] = parseSpec(specFiles[i], conceptDictionary)
+	})
+	for i, spec := range parsedSpecs {
 		if spec != nil {
 			specs = append(specs, spec)
 			var parseErrs []error
-			for _, e := range parseRes.ParseErrors {
+			for _, e := range parseResults[i].ParseErrors {
 				parseErrs = append(parseErrs, e)
 			}
 			if len(parseErrs) != 0 {
 				buildErrors.SpecErrs[spec] = parseErrs
 			}
 		}
-		parseResults = append(parseResults, parseRes)
 	}
 	return specs, parseResults
 }
@@ -94,7 +91,9 @@ func ParseConcepts() (*gauge.ConceptDictionary, *ParseResult, error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	HandleParseResult(conceptParseResult)
+	if HandleParseResult(conceptParseResult) {
+		conceptParseResult.Ok = false
+	}
 	return conceptsDictionary, conceptParseResult, nil
 }
 
@@ -104,20 +103,42 @@ func recoverPanic() {
 	}
 }
 
-func parseSpec(specFile string, conceptDictionary *gauge.ConceptDictionary, specChannel chan *gauge.Specification, parseResultChan chan *ParseResult) {
-	defer recoverPanic()
+func parseSpec(specFile string, conceptDictionary *gauge.ConceptDictionary) (*gauge.Specification, *ParseResult) {
 	specFileContent, err := common.ReadFileContents(specFile)
 	if err != nil {
-		specChannel <- nil
-		parseResultChan <- &ParseResult{ParseErrors: []ParseError{ParseError{FileName: specFile, Message: err.Error()}}, Ok: false}
-		return
+		return nil, &ParseResult{ParseErrors: []ParseError{ParseError{FileName: specFile, Message: err.Error()}}, Ok: false}
 	}
-	spec, parseResult, err := new(SpecParser).Parse(specFileContent, conceptDictionary, specFile)
+	spec, parseResult, err := parseSpecWithCache(specFileContent, specFile, conceptDictionary)
 	if err != nil {
 		logger.Fatalf(true, err.Error())
 	}
-	specChannel <- spec
-	parseResultChan <- parseResult
+	return spec, parseResult
+}
+
+// forEachConcurrently invokes f for every index in [0, n) using a pool of workers, one per CPU core.
+// It returns once all the invocations are complete.
+func forEachConcurrently(n int, f func(i int)) {
+	indices := make(chan int, n)
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+	workers := util.NumberOfCores()
+	if workers > n {
+		workers = n
+	}
+	wg := &sync.WaitGroup{}
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			defer recoverPanic()
+			for i := range indices {
+				f(i)
+			}
+		}()
+	}
+	wg.Wait()
 }
 
 type specFile struct {
@@ -139,6 +160,7 @@ func parseSpecsInDirs(conceptDictionary *gauge.ConceptDictionary, specDirs []str
 		i, _ := getIndexFor(specFiles, spec.FileName)
 		specFile := specFiles[i]
 		if len(specFile.indices) > 0 {
+			warnLinesOutsideScenarios(spec, specFile.indices)
 			spec.Filter(filter.NewScenarioFilterBasedOnSpan(specFile.indices))
 		}
 		allSpecs[i] = spec
@@ -146,6 +168,23 @@ func parseSpecsInDirs(conceptDictionary *gauge.ConceptDictionary, specDirs []str
 	return allSpecs, !passed
 }
 
+// warnLinesOutsideScenarios warns about the line numbers given as spec:line which are not within
+// any scenario of the spec, since nothing is executed for them.
+func warnLinesOutsideScenarios(spec *gauge.Specification, lineNumbers []int) {
+	for _, lineNumber := range lineNumbers {
+		found := false
+		for _, scenario := range spec.Scenarios {
+			if scenario.InSpan(lineNumber) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			logger.Warningf(true, "No scenario found at %s:%d", util.RelPathToProjectRoot(spec.FileName), lineNumber)
+		}
+	}
+}
+
 func getAllSpecFiles(specDirs []string) (givenSpecs []string, specFiles []*specFile) {
 	for _, specSource := range specDirs {
 		if isIndexedSpec(specSource) {
@@ -255,6 +294,7 @@ func getParameterizeStepValue(stepValue string, params []string) string {
 }
 
 // HandleParseResult collates list of parse result and determines if gauge has to break flow.
+// With WarningsAsErrors, the warnings are reported as errors and break the flow too.
 func HandleParseResult(results ...*ParseResult) bool {
 	var failed = false
 	for _, result := range results {
@@ -264,9 +304,12 @@ func HandleParseResult(results ...*ParseResult) bool {
 			}
 			failed = true
 		}
-		if result.Warnings != nil {
-			for _, warning := range result.Warnings {
-				logger.Warningf(true, "[ParseWarning] %s", warning)
+		for _, warning := range result.WarningMessages() {
+			if WarningsAsErrors {
+				logger.Errorf(true, "%s", warning)
+				failed = true
+			} else {
+				logger.Warningf(true, "%s", warning)
 			}
 		}
 	}
@@ -289,3 +289,20 @@ func specialTableArg(val string) *gauge.StepArg {
 func specialStringArg(val string) *gauge.StepArg {
 	return &gauge.StepArg{ArgType: gauge.SpecialString, Name: val}
 }
+
+func (s *MySuite) TestParseSpecFilesRetainsOrderOfFiles(c *C) {
+	sample, _ := filepath.Abs(filepath.Join("testdata", "sample.spec"))
+	sample2, _ := filepath.Abs(filepath.Join("testdata", "sample2.spec"))
+	missing, _ := filepath.Abs(filepath.Join("testdata", "missing.spec"))
+	files := []string{sample2, missing, sample, sample2}
+
+	specs, results := ParseSpecFiles(files, gauge.NewConceptDictionary(), gauge.NewBuildErrors())
+
+	c.Assert(len(specs), Equals, 3)
+	c.Assert(specs[0].FileName, Equals, sample2)
+	c.Assert(specs[1].FileName, Equals, sample)
+	c.Assert(specs[2].FileName, Equals, sample2)
+	c.Assert(len(results), Equals, 4)
+	c.Assert(results[1].Ok, Equals, false)
+	c.Assert(results[1].ParseErrors[0].FileName, Equals, missing)
+}
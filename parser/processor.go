@@ -30,7 +30,7 @@ func processSpec(parser *SpecParser, token *Token) ([]error, bool) {
 }
 
 func processTearDown(parser *SpecParser, token *Token) ([]error, bool) {
-	if len(token.Value) < 3 {
+	if isUnderline(token.Value, rune('_')) && len(token.Value) < 3 {
 		return []error{fmt.Errorf("Teardown should have at least three underscore characters")}, true
 	}
 	return []error{}, false
@@ -73,6 +73,24 @@ func processTag(parser *SpecParser, token *Token) ([]error, bool) {
 	return []error{}, false
 }
 
+// processMeta splits the metadata into key=value pairs. A part without a key continues the value of the previous pair,
+// so that values can be lists, e.g. locks=database, smtp.
+func processMeta(parser *SpecParser, token *Token) ([]error, bool) {
+	parser.clearState()
+	for _, pair := range strings.Split(token.Value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		if n := len(token.Args); n > 0 && !strings.Contains(pair, "=") {
+			token.Args[n-1] += ", " + pair
+			continue
+		}
+		token.Args = append(token.Args, pair)
+	}
+	return []error{}, false
+}
+
 func processTable(parser *SpecParser, token *Token) ([]error, bool) {
 	var buffer bytes.Buffer
 	shouldEscape := false
@@ -60,8 +60,22 @@ type Warning struct {
 	FileName string
 	LineNo   int
 	Message  string
+	// Deprecated marks the warnings about syntax which is still understood, but will not be in a future release.
+	Deprecated bool
 }
 
 func (warning *Warning) String() string {
 	return fmt.Sprintf("%s:%d %s", warning.FileName, warning.LineNo, warning.Message)
 }
+
+// WarningMessages gives the warnings, with the deprecations told apart from the other warnings.
+func (result *ParseResult) WarningMessages() (warnings []string) {
+	for _, w := range result.Warnings {
+		kind := "ParseWarning"
+		if w.Deprecated {
+			kind = "Deprecation"
+		}
+		warnings = append(warnings, fmt.Sprintf("[%s] %s", kind, w.String()))
+	}
+	return
+}
@@ -37,8 +37,17 @@ type SpecParser struct {
 // Parse generates tokens for the given spec text and creates the specification.
 func (parser *SpecParser) Parse(specText string, conceptDictionary *gauge.ConceptDictionary, specFile string) (*gauge.Specification, *ParseResult, error) {
 	tokens, errs := parser.GenerateTokens(specText, specFile)
-	spec, res, err := parser.CreateSpecification(tokens, conceptDictionary, specFile)
-	if err != nil {
+	return parser.parseTokens(tokens, errs, conceptDictionary, specFile)
+}
+
+func (parser *SpecParser) parseTokens(tokens []*Token, errs []ParseError, conceptDictionary *gauge.ConceptDictionary, specFile string) (*gauge.Specification, *ParseResult, error) {
+	spec, res := parser.createSpecification(tokens, specFile)
+	return parser.completeSpecification(spec, res, errs, conceptDictionary, specFile)
+}
+
+// completeSpecification resolves the concepts of the created specification and adds the lexer errors to its result.
+func (parser *SpecParser) completeSpecification(spec *gauge.Specification, res *ParseResult, errs []ParseError, conceptDictionary *gauge.ConceptDictionary, specFile string) (*gauge.Specification, *ParseResult, error) {
+	if err := parser.resolveSpecification(spec, res, conceptDictionary); err != nil {
 		return nil, nil, err
 	}
 	res.FileName = specFile
@@ -63,17 +72,23 @@ func (parser *SpecParser) ParseSpecText(specText string, specFile string) (*gaug
 
 // CreateSpecification creates specification from the given set of tokens.
 func (parser *SpecParser) CreateSpecification(tokens []*Token, conceptDictionary *gauge.ConceptDictionary, specFile string) (*gauge.Specification, *ParseResult, error) {
-	parser.conceptDictionary = conceptDictionary
 	specification, finalResult := parser.createSpecification(tokens, specFile)
-	if err := specification.ProcessConceptStepsFrom(conceptDictionary); err != nil {
+	if err := parser.resolveSpecification(specification, finalResult, conceptDictionary); err != nil {
 		return nil, nil, err
 	}
-	err := parser.validateSpec(specification)
-	if err != nil {
+	return specification, finalResult, nil
+}
+
+func (parser *SpecParser) resolveSpecification(specification *gauge.Specification, finalResult *ParseResult, conceptDictionary *gauge.ConceptDictionary) error {
+	parser.conceptDictionary = conceptDictionary
+	if err := specification.ProcessConceptStepsFrom(conceptDictionary); err != nil {
+		return err
+	}
+	if errs := parser.validateSpec(specification); len(errs) > 0 {
 		finalResult.Ok = false
-		finalResult.ParseErrors = append([]ParseError{err.(ParseError)}, finalResult.ParseErrors...)
+		finalResult.ParseErrors = append(errs, finalResult.ParseErrors...)
 	}
-	return specification, finalResult, nil
+	return nil
 }
 
 func (parser *SpecParser) createSpecification(tokens []*Token, specFile string) (*gauge.Specification, *ParseResult) {
@@ -97,39 +112,59 @@ func (parser *SpecParser) createSpecification(tokens []*Token, specFile string)
 				finalResult.Warnings = append(finalResult.Warnings, result.Warnings...)
 			}
 		}
+		if warnings := tokenWarnings(token, specFile); len(warnings) > 0 {
+			finalResult.Warnings = append(finalResult.Warnings, warnings...)
+		}
 	}
 	if len(specification.Scenarios) > 0 {
-		specification.LatestScenario().Span.End = tokens[len(tokens)-1].LineNo
+		specification.LatestScenario().Span.End = lastScenarioEnd(specification, tokens[len(tokens)-1].LineNo)
 	}
 	return specification, finalResult
 }
 
-func (parser *SpecParser) validateSpec(specification *gauge.Specification) error {
+// lastScenarioEnd gives the line on which the last scenario ends, which is the end of the file
+// unless the spec has teardown steps after the scenario.
+func lastScenarioEnd(spec *gauge.Specification, lastLine int) int {
+	start := spec.LatestScenario().Span.Start
+	for _, item := range spec.Items {
+		if t, ok := item.(*gauge.TearDown); ok && t.LineNo > start {
+			return t.LineNo - 1
+		}
+	}
+	return lastLine
+}
+
+// validateSpec gives all the errors in the structure of the specification, so that they can be fixed in one go.
+func (parser *SpecParser) validateSpec(specification *gauge.Specification) []ParseError {
 	if len(specification.Items) == 0 {
 		specification.AddHeading(&gauge.Heading{})
-		return ParseError{FileName: specification.FileName, LineNo: 1, Message: "Spec does not have any elements"}
+		return []ParseError{{FileName: specification.FileName, LineNo: 1, Message: "Spec does not have any elements"}}
 	}
+	var errs []ParseError
+	headingLineNo := 1
 	if specification.Heading == nil {
 		specification.AddHeading(&gauge.Heading{})
-		return ParseError{FileName: specification.FileName, LineNo: 1, Message: "Spec heading not found"}
-	}
-	if len(strings.TrimSpace(specification.Heading.Value)) < 1 {
-		return ParseError{FileName: specification.FileName, LineNo: specification.Heading.LineNo, Message: "Spec heading should have at least one character"}
+		errs = append(errs, ParseError{FileName: specification.FileName, LineNo: 1, Message: "Spec heading not found"})
+	} else {
+		headingLineNo = specification.Heading.LineNo
+		if len(strings.TrimSpace(specification.Heading.Value)) < 1 {
+			errs = append(errs, ParseError{FileName: specification.FileName, LineNo: headingLineNo, Message: "Spec heading should have at least one character"})
+		}
 	}
 
 	dataTable := specification.DataTable.Table
 	if dataTable.IsInitialized() && dataTable.GetRowCount() == 0 {
-		return ParseError{FileName: specification.FileName, LineNo: dataTable.LineNo, Message: "Data table should have at least 1 data row"}
+		errs = append(errs, ParseError{FileName: specification.FileName, LineNo: dataTable.LineNo, Message: "Data table should have at least 1 data row"})
 	}
 	if len(specification.Scenarios) == 0 {
-		return ParseError{FileName: specification.FileName, LineNo: specification.Heading.LineNo, Message: "Spec should have atleast one scenario"}
+		errs = append(errs, ParseError{FileName: specification.FileName, LineNo: headingLineNo, Message: "Spec should have atleast one scenario"})
 	}
 	for _, sce := range specification.Scenarios {
 		if len(sce.Steps) == 0 {
-			return ParseError{FileName: specification.FileName, LineNo: sce.Heading.LineNo, Message: "Scenario should have atleast one step"}
+			errs = append(errs, ParseError{FileName: specification.FileName, LineNo: sce.Heading.LineNo, Message: "Scenario should have atleast one step"})
 		}
 	}
-	return nil
+	return errs
 }
 
 func createStep(spec *gauge.Specification, scn *gauge.Scenario, stepToken *Token) (*gauge.Step, *ParseResult) {
@@ -174,8 +174,11 @@ func (s *MySuite) TestThrowsErrorForDuplicateScenariosWithinTheSameSpec(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(result.Ok, Equals, false)
 
-	c.Assert(result.ParseErrors[0].Message, Equals, "Duplicate scenario definition 'Scenario Heading' found in the same specification")
+	c.Assert(len(result.ParseErrors), Equals, 2)
+	c.Assert(result.ParseErrors[0].Message, Equals, "Scenario should have atleast one step")
 	c.Assert(result.ParseErrors[0].LineNo, Equals, 4)
+	c.Assert(result.ParseErrors[1].Message, Equals, "Duplicate scenario definition 'Scenario Heading' found in the same specification")
+	c.Assert(result.ParseErrors[1].LineNo, Equals, 4)
 }
 
 func (s *MySuite) TestSpecWithHeadingAndSimpleSteps(c *C) {
@@ -1092,6 +1095,26 @@ comment3
 	c.Assert(spec.Scenarios[2].Span.End, Equals, 17)
 }
 
+func (s *MySuite) TestLastScenarioSpanEndsBeforeTearDown(c *C) {
+	p := new(SpecParser)
+
+	spec, _, err := p.Parse(`# Spec 1
+## Scenario 1
+* def "sd"
+
+## Scenario 2
+* def "sd"
+
+___
+* teardown
+`, gauge.NewConceptDictionary(), "")
+	c.Assert(err, IsNil)
+	c.Assert(len(spec.Scenarios), Equals, 2)
+	c.Assert(spec.Scenarios[1].Span.Start, Equals, 5)
+	c.Assert(spec.Scenarios[1].Span.End, Equals, 7)
+	c.Assert(spec.Scenarios[1].InSpan(9), Equals, false)
+}
+
 func TestParseScenarioWithDataTable(t *testing.T) {
 	p := new(SpecParser)
 	var subject = func() *gauge.Scenario {
@@ -1212,6 +1235,55 @@ tags: blah
 	c.Assert(spec.Tags.Values()[0], Equals, "tag1")
 }
 
+func (s *MySuite) TestSpecAndScenarioWithMeta(c *C) {
+	p := new(SpecParser)
+	spec, parseRes, err := p.Parse(`# Spec Heading
+tags: tag1
+meta: owner=team-payments, severity = critical
+
+## Scenario
+Meta: severity=low, link=https://example.org/?a=b
+* step
+`, gauge.NewConceptDictionary(), "")
+	c.Assert(err, IsNil)
+	c.Assert(parseRes.Ok, Equals, true)
+	c.Assert(spec.Meta.Pairs(), DeepEquals, []string{"owner=team-payments", "severity=critical"})
+	c.Assert(spec.Meta.LineNo, Equals, 3)
+	c.Assert(spec.Scenarios[0].Meta.Pairs(), DeepEquals, []string{"severity=low", "link=https://example.org/?a=b"})
+	c.Assert(len(spec.Scenarios[0].Steps), Equals, 1)
+	c.Assert(gauge.MergeMeta(spec.Meta, spec.Scenarios[0].Meta).Pairs(), DeepEquals, []string{"owner=team-payments", "severity=low", "link=https://example.org/?a=b"})
+}
+
+func (s *MySuite) TestMetaWithListValue(c *C) {
+	p := new(SpecParser)
+	spec, parseRes, err := p.Parse(`# Spec Heading
+meta: locks=database, smtp, owner=team-a
+
+## Scenario
+* step
+`, gauge.NewConceptDictionary(), "")
+	c.Assert(err, IsNil)
+	c.Assert(parseRes.Ok, Equals, true)
+	c.Assert(spec.Meta.Pairs(), DeepEquals, []string{"locks=database, smtp", "owner=team-a"})
+}
+
+func (s *MySuite) TestSpecWithInvalidOrRepeatedMeta(c *C) {
+	p := new(SpecParser)
+	_, parseRes, err := p.Parse(`# Spec Heading
+meta: owner
+meta: owner=a
+
+## Scenario
+meta: owner=b
+meta: owner=c
+* step
+`, gauge.NewConceptDictionary(), "")
+	c.Assert(err, IsNil)
+	c.Assert(len(parseRes.ParseErrors), Equals, 2)
+	c.Assert(parseRes.ParseErrors[0].Message, Equals, "Invalid metadata 'owner'. Metadata should be given as key=value")
+	c.Assert(parseRes.ParseErrors[1].Message, Equals, "Metadata can be defined only once per scenario")
+}
+
 func (s *MySuite) TestDatatTableWithEmptyHeaders(c *C) {
 	p := new(SpecParser)
 	_, parseRes, err := p.Parse(`Something
@@ -1308,3 +1380,73 @@ func (s *MySuite) TestDataTableForSpecialParameterWhenFileIsNotFound(c *C) {
 	c.Assert(res.ParseErrors[0].Message, Equals, "Dynamic param <file:notFound.txt> could not be resolved, Missing file: notFound.txt")
 	c.Assert(res.ParseErrors[0].LineText, Equals, "|james|<file:notFound.txt>|")
 }
+
+func (s *MySuite) TestParsingReportsAllStructuralErrors(c *C) {
+	p := new(SpecParser)
+
+	_, res, err := p.Parse(`#
+|id|
+## Scenario 1
+
+## Scenario 2
+`, gauge.NewConceptDictionary(), "foo.spec")
+
+	c.Assert(err, IsNil)
+	c.Assert(len(res.ParseErrors), Equals, 4)
+	c.Assert(res.ParseErrors[0].Error(), Equals, "foo.spec:1 Spec heading should have at least one character => ''")
+	c.Assert(res.ParseErrors[1].Error(), Equals, "foo.spec:2 Data table should have at least 1 data row => ''")
+	c.Assert(res.ParseErrors[2].Error(), Equals, "foo.spec:3 Scenario should have atleast one step => ''")
+	c.Assert(res.ParseErrors[3].Error(), Equals, "foo.spec:5 Scenario should have atleast one step => ''")
+}
+
+func (s *MySuite) TestParsingContinuesWithStepsOfDuplicateScenario(c *C) {
+	p := new(SpecParser)
+
+	spec, res, err := p.Parse(`# Spec
+## Scenario
+* first step
+## Scenario
+* step with <unknown>
+`, gauge.NewConceptDictionary(), "foo.spec")
+
+	c.Assert(err, IsNil)
+	c.Assert(len(spec.Scenarios), Equals, 2)
+	c.Assert(len(spec.Scenarios[0].Steps), Equals, 1)
+	c.Assert(len(res.ParseErrors), Equals, 2)
+	c.Assert(res.ParseErrors[0].Error(), Equals, "foo.spec:4 Duplicate scenario definition 'Scenario' found in the same specification => '## Scenario'")
+	c.Assert(res.ParseErrors[1].Error(), Equals, "foo.spec:5 Dynamic parameter <unknown> could not be resolved => 'step with <unknown>'")
+}
+
+func (s *MySuite) TestParsingContinuesWithStepsOfScenarioWithoutSpecHeading(c *C) {
+	p := new(SpecParser)
+
+	_, res, err := p.Parse(`## Scenario
+* step with <unknown>
+`, gauge.NewConceptDictionary(), "foo.spec")
+
+	c.Assert(err, IsNil)
+	c.Assert(len(res.ParseErrors), Equals, 3)
+	c.Assert(res.ParseErrors[0].Error(), Equals, "foo.spec:1 Spec heading not found => ''")
+	c.Assert(res.ParseErrors[1].Error(), Equals, "foo.spec:1 Scenario should be defined after the spec heading => '## Scenario'")
+	c.Assert(res.ParseErrors[2].Error(), Equals, "foo.spec:2 Dynamic parameter <unknown> could not be resolved => 'step with <unknown>'")
+}
+
+func (s *MySuite) TestParsingIgnoresInlineTableOfInvalidStep(c *C) {
+	p := new(SpecParser)
+
+	spec, res, err := p.Parse(`# Spec
+## Scenario
+* step with {static}
+|id|
+|--|
+|1 |
+* step with <unknown>
+`, gauge.NewConceptDictionary(), "foo.spec")
+
+	c.Assert(err, IsNil)
+	c.Assert(res.Warnings, IsNil)
+	c.Assert(spec.Scenarios[0].DataTable.Table.IsInitialized(), Equals, false)
+	c.Assert(len(res.ParseErrors), Equals, 3)
+	c.Assert(res.ParseErrors[1].Error(), Equals, "foo.spec:3 Step text should not have '{static}' or '{dynamic}' or '{special}' => 'step with {static}'")
+	c.Assert(res.ParseErrors[2].Error(), Equals, "foo.spec:7 Dynamic parameter <unknown> could not be resolved => 'step with <unknown>'")
+}
@@ -19,13 +19,51 @@ package parser
 
 import (
 	"encoding/csv"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/getgauge/gauge/env"
 	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/util"
 )
 
+// TableFromFile gives the table in the given csv file, or the first table in the given markdown file.
+func TableFromFile(filePath string) (*gauge.Table, error) {
+	contents, err := util.GetFileContents(filePath)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".md", ".markdown":
+		return convertMarkdownToTable(contents, filePath)
+	}
+	return convertCsvToTable(contents)
+}
+
+func convertMarkdownToTable(contents, fileName string) (*gauge.Table, error) {
+	tokens, _ := new(SpecParser).GenerateTokens(contents, fileName)
+	var table *gauge.Table
+	for _, token := range tokens {
+		switch {
+		case token.Kind == gauge.TableHeader && table == nil:
+			table = new(gauge.Table)
+			table.AddHeaders(token.Args)
+		case token.Kind == gauge.TableRow && table != nil:
+			if !areUnderlined(token.Args) {
+				table.AddRowValues(table.CreateTableCells(token.Args))
+			}
+		case table != nil:
+			return table, nil
+		}
+	}
+	if table == nil {
+		return nil, fmt.Errorf("No table found in %s", fileName)
+	}
+	return table, nil
+}
+
 func convertCsvToTable(csvContents string) (*gauge.Table, error) {
 	r := csv.NewReader(strings.NewReader(csvContents))
 	var de = os.Getenv(env.CsvDelimiter)
@@ -34,3 +34,18 @@ func (s *MySuite) TestIgnoreCommentLines(c *C) {
 	c.Assert(table.Rows()[0][1], Equals, "bar")
 	c.Assert(table.Rows()[0][2], Equals, "baz")
 }
+
+func (s *MySuite) TestConvertMarkdownToTableGivesFirstTable(c *C) {
+	contents := "Some text\n\n| name | count |\n|------|-------|\n| john | 3     |\n| jane | 4     |\n\nmore text\n\n| other |\n| ----- |\n"
+	table, err := convertMarkdownToTable(contents, "data.md")
+
+	c.Assert(err, IsNil)
+	c.Assert(table.Headers, DeepEquals, []string{"name", "count"})
+	c.Assert(table.Rows(), DeepEquals, [][]string{{"john", "3"}, {"jane", "4"}})
+}
+
+func (s *MySuite) TestConvertMarkdownToTableWithoutTable(c *C) {
+	_, err := convertMarkdownToTable("Some text\n", "data.md")
+
+	c.Assert(err, ErrorMatches, "No table found in data.md")
+}
@@ -0,0 +1,95 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/getgauge/gauge/gauge"
+)
+
+// WarningsAsErrors fails the parsing of the specs and concepts which have warnings, as if the warnings were errors.
+var WarningsAsErrors bool
+
+// tokenWarnings gives the warnings about syntax of the token which is understood, but is likely not what the author meant
+// or is deprecated.
+func tokenWarnings(token *Token, fileName string) []*Warning {
+	switch token.Kind {
+	case gauge.TagKind:
+		return append(deprecatedKeywordWarnings(token, fileName, TagsKeyword), emptyTagWarnings(token, fileName)...)
+	case gauge.MetaKind:
+		return deprecatedKeywordWarnings(token, fileName, MetaKeyword)
+	case gauge.TableHeader, gauge.TableRow:
+		return tableCellWarnings(token, fileName)
+	}
+	return nil
+}
+
+// deprecatedKeywordWarnings warns about a space between the keyword and its colon, as in `tags :`.
+func deprecatedKeywordWarnings(token *Token, fileName string, keyword string) []*Warning {
+	line := strings.ToLower(strings.TrimSpace(token.LineText))
+	for _, k := range KeywordSpellings(keyword) {
+		if strings.HasPrefix(line, k+" :") {
+			return []*Warning{{FileName: fileName, LineNo: token.LineNo, Deprecated: true,
+				Message: fmt.Sprintf("A space before the colon of '%s :' is deprecated, use '%s:' instead", k, k)}}
+		}
+	}
+	return nil
+}
+
+// emptyTagWarnings warns about the empty tags, which are dropped. A trailing comma continues the tags on the next line.
+func emptyTagWarnings(token *Token, fileName string) []*Warning {
+	tags := strings.Split(strings.TrimSuffix(strings.TrimSpace(token.Value), ","), ",")
+	for _, t := range tags {
+		if strings.TrimSpace(t) == "" {
+			return []*Warning{{FileName: fileName, LineNo: token.LineNo, Message: "Empty tag is ignored"}}
+		}
+	}
+	return nil
+}
+
+// tableCellWarnings warns about the escaped whitespace at the end of a table cell, which is trimmed along with the padding.
+func tableCellWarnings(token *Token, fileName string) []*Warning {
+	var warnings []*Warning
+	escaped, lastEscaped := false, false
+	var cell []rune
+	for i, r := range strings.TrimSpace(token.LineText) {
+		switch {
+		case i == 0:
+		case escaped:
+			cell = append(cell, r)
+			escaped, lastEscaped = false, unicode.IsSpace(r)
+		case r == '\\':
+			escaped = true
+		case r == '|':
+			if lastEscaped {
+				warnings = append(warnings, &Warning{FileName: fileName, LineNo: token.LineNo,
+					Message: fmt.Sprintf("Whitespace at the end of table cell '%s' is ignored", strings.TrimSpace(string(cell)))})
+			}
+			cell, lastEscaped = nil, false
+		default:
+			cell = append(cell, r)
+			if !unicode.IsSpace(r) {
+				lastEscaped = false
+			}
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,75 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package parser
+
+import (
+	"github.com/getgauge/gauge/gauge"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestParsingWarnsAboutDeprecatedKeywordSpelling(c *C) {
+	_, res, err := new(SpecParser).Parse(`# Spec
+tags : smoke
+## Scenario
+meta : owner=qa
+* step
+`, gauge.NewConceptDictionary(), "foo.spec")
+
+	c.Assert(err, IsNil)
+	c.Assert(res.Ok, Equals, true)
+	c.Assert(res.WarningMessages(), DeepEquals, []string{
+		"[Deprecation] foo.spec:2 A space before the colon of 'tags :' is deprecated, use 'tags:' instead",
+		"[Deprecation] foo.spec:4 A space before the colon of 'meta :' is deprecated, use 'meta:' instead",
+	})
+}
+
+func (s *MySuite) TestParsingWarnsAboutEmptyTags(c *C) {
+	_, res, err := new(SpecParser).Parse(`# Spec
+tags: smoke,
+ regression
+## Scenario
+tags: api, , slow
+* step
+`, gauge.NewConceptDictionary(), "foo.spec")
+
+	c.Assert(err, IsNil)
+	c.Assert(res.WarningMessages(), DeepEquals, []string{"[ParseWarning] foo.spec:5 Empty tag is ignored"})
+}
+
+func (s *MySuite) TestParsingWarnsAboutEscapedWhitespaceAtTheEndOfTableCells(c *C) {
+	_, res, err := new(SpecParser).Parse(`# Spec
+## Scenario
+* step
+|name   |greeting   |
+|-------|-----------|
+|a\ b   |hello\     |
+`, gauge.NewConceptDictionary(), "foo.spec")
+
+	c.Assert(err, IsNil)
+	c.Assert(res.WarningMessages(), DeepEquals, []string{"[ParseWarning] foo.spec:6 Whitespace at the end of table cell 'hello' is ignored"})
+}
+
+func (s *MySuite) TestHandleParseResultWithWarningsAsErrors(c *C) {
+	res := &ParseResult{Ok: true, Warnings: []*Warning{{FileName: "foo.spec", LineNo: 2, Message: "Empty tag is ignored"}}}
+
+	c.Assert(HandleParseResult(res), Equals, false)
+
+	WarningsAsErrors = true
+	defer func() { WarningsAsErrors = false }()
+	c.Assert(HandleParseResult(res), Equals, true)
+}
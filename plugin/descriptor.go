@@ -26,7 +26,11 @@ import (
 type pluginCapability string
 
 const (
-	streamResultCapability pluginCapability = "stream_result"
+	streamResultCapability       pluginCapability = "stream_result"
+	interceptExecutionCapability pluginCapability = "intercept_execution"
+	consoleReporterCapability    pluginCapability = "console_reporter"
+	unixSocketCapability         pluginCapability = "unix_socket"
+	namedPipeCapability          pluginCapability = "named_pipe"
 )
 
 type pluginDescriptor struct {
@@ -18,17 +18,34 @@
 package plugin
 
 import (
+	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/getgauge/gauge/gauge_messages"
 	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/reporter"
 )
 
 type Handler interface {
 	NotifyPlugins(*gauge_messages.Message)
+	NotifySuiteResult(*gauge_messages.ProtoSuiteResult, SpecResults)
+	InterceptScenario(*gauge_messages.Message) *Interception
 	GracefullyKillPlugins()
 }
 
+// SpecResults calls the given function with the result of every spec of the suite, one spec at a time.
+type SpecResults func(func(*gauge_messages.ProtoSpecResult))
+
+// Interception is what the plugins with the intercept_execution capability want done with a scenario about to be executed.
+// Messages are added to the result of the scenario.
+type Interception struct {
+	Skip     bool
+	Abort    bool
+	Reason   string
+	Messages []string
+}
+
 type GaugePlugins struct {
 	pluginsMap map[string]*plugin
 }
@@ -45,39 +62,87 @@ func (gp *GaugePlugins) removePlugin(pluginID string) {
 }
 
 func (gp *GaugePlugins) NotifyPlugins(message *gauge_messages.Message) {
-	var handle = func(id string, p *plugin, err error) {
-		if err != nil {
-			logger.Errorf(true, "Unable to connect to plugin %s %s. %s\n", p.descriptor.Name, p.descriptor.Version, err.Error())
-			gp.killPlugin(id)
+	if message.MessageType == gauge_messages.Message_SuiteExecutionResult {
+		summary := *message.SuiteExecutionResult.GetSuiteResult()
+		specResults := summary.SpecResults
+		summary.SpecResults = nil
+		gp.NotifySuiteResult(&summary, func(f func(*gauge_messages.ProtoSpecResult)) {
+			for _, sr := range specResults {
+				f(sr)
+			}
+		})
+		return
+	}
+	for id, plugin := range gp.pluginsMap {
+		if message.MessageType == gauge_messages.Message_ScenarioExecutionStarting && plugin.descriptor.hasCapability(interceptExecutionCapability) {
+			// these plugins get the message through InterceptScenario
+			continue
 		}
+		gp.handleSendError(id, plugin.sendMessage(message))
 	}
+}
 
+// NotifySuiteResult sends the suite result, which has no spec results of its own, to the plugins with the results of
+// the specs given by specResults. The spec results are sent one at a time, so that the items of all the specs need not
+// be held in memory at once. Plugins with the stream_result capability get the items as SuiteExecutionResultItem messages
+// after the SuiteExecutionResult.
+func (gp *GaugePlugins) NotifySuiteResult(suiteResult *gauge_messages.ProtoSuiteResult, specResults SpecResults) {
 	for id, plugin := range gp.pluginsMap {
-		if !plugin.descriptor.hasCapability(streamResultCapability) {
-			handle(id, plugin, plugin.sendMessage(message))
+		if plugin.descriptor.hasCapability(streamResultCapability) {
+			gp.handleSendError(id, plugin.sendChunkedSuiteResult(suiteResult, specResults))
+		} else {
+			gp.handleSendError(id, plugin.sendSuiteResult(suiteResult, specResults))
+		}
+	}
+}
+
+func (gp *GaugePlugins) handleSendError(pluginID string, err error) {
+	if err != nil {
+		p := gp.pluginsMap[pluginID]
+		logger.Errorf(true, "Unable to connect to plugin %s %s. %s\n", p.descriptor.Name, p.descriptor.Version, err.Error())
+		gp.killPlugin(pluginID)
+	}
+}
+
+// InterceptScenario sends the ScenarioExecutionStarting message to the plugins with the intercept_execution capability
+// and waits for them to respond with an ExecutionStatusResponse. A failed result with a recoverable error skips the scenario,
+// and one with an unrecoverable error aborts the execution. The error message is the reason for it.
+func (gp *GaugePlugins) InterceptScenario(message *gauge_messages.Message) *Interception {
+	interception := &Interception{}
+	var ids []string
+	for id, p := range gp.pluginsMap {
+		if p.descriptor.hasCapability(interceptExecutionCapability) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		p := gp.pluginsMap[id]
+		res, err := p.getResponse(message)
+		if err == nil && res.MessageType != gauge_messages.Message_ExecutionStatusResponse {
+			err = fmt.Errorf("Expected ExecutionStatusResponse, got %s", res.MessageType)
+		}
+		if err != nil {
+			logger.Errorf(true, "Unable to get response from plugin %s %s. %s\n", p.descriptor.Name, p.descriptor.Version, err.Error())
+			gp.killPlugin(id)
 			continue
 		}
-		items := []*gauge_messages.ProtoItem{}
-		for _, sr := range message.SuiteExecutionResult.GetSuiteResult().GetSpecResults() {
-			for _, i := range sr.ProtoSpec.Items {
-				i.FileName = sr.ProtoSpec.FileName
-				items = append(items, i)
-			}
-			sr.ProtoSpec.ItemCount = int64(len(sr.ProtoSpec.Items))
-			sr.ProtoSpec.Items = nil
+		r := res.GetExecutionStatusResponse().GetExecutionResult()
+		interception.Messages = append(interception.Messages, r.GetMessage()...)
+		if !r.GetFailed() {
+			continue
 		}
-		if message.MessageType == gauge_messages.Message_SuiteExecutionResult {
-			message.SuiteExecutionResult.SuiteResult.Chunked = true
-			message.SuiteExecutionResult.SuiteResult.ChunkSize = int64(len(items))
-			handle(id, plugin, plugin.sendMessage(message))
-			for _, i := range items {
-				m := &gauge_messages.Message{MessageType: gauge_messages.Message_SuiteExecutionResultItem, SuiteExecutionResultItem: &gauge_messages.SuiteExecutionResultItem{ResultItem: i}}
-				handle(id, plugin, plugin.sendMessage(m))
-			}
-		} else {
-			handle(id, plugin, plugin.sendMessage(message))
+		if !r.GetRecoverableError() {
+			interception.Skip, interception.Abort = false, true
+			interception.Reason = fmt.Sprintf("Execution aborted by plugin %s: %s", p.descriptor.Name, r.GetErrorMessage())
+			return interception
+		}
+		if !interception.Skip {
+			interception.Skip = true
+			interception.Reason = fmt.Sprintf("Skipped by plugin %s: %s", p.descriptor.Name, r.GetErrorMessage())
 		}
 	}
+	return interception
 }
 
 func (gp *GaugePlugins) killPlugin(pluginID string) {
@@ -97,4 +162,9 @@ func (gp *GaugePlugins) GracefullyKillPlugins() {
 		go plugin.kill(&wg)
 	}
 	wg.Wait()
+	for _, plugin := range gp.pluginsMap {
+		if plugin.reporterSink != nil {
+			reporter.RemoveSink(plugin.reporterSink)
+		}
+	}
 }
@@ -0,0 +1,176 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package install
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/version"
+)
+
+// pluginDependency is a plugin, within a range of versions, which a version of a plugin needs to work.
+type pluginDependency struct {
+	Name    string
+	Minimum string
+	Maximum string
+}
+
+func (d pluginDependency) versionSupport() *version.VersionSupport {
+	return &version.VersionSupport{Minimum: d.Minimum, Maximum: d.Maximum}
+}
+
+func (d pluginDependency) String() string {
+	switch {
+	case d.Minimum != "" && d.Maximum != "":
+		return fmt.Sprintf("%s %s - %s", d.Name, d.Minimum, d.Maximum)
+	case d.Minimum != "":
+		return fmt.Sprintf("%s >= %s", d.Name, d.Minimum)
+	case d.Maximum != "":
+		return fmt.Sprintf("%s <= %s", d.Name, d.Maximum)
+	}
+	return d.Name
+}
+
+type requirement struct {
+	dependency pluginDependency
+	requiredBy string
+}
+
+func (r requirement) String() string {
+	return fmt.Sprintf("%s requires %s", r.requiredBy, r.dependency)
+}
+
+// resolvedPlugin is a version of a plugin to be installed.
+type resolvedPlugin struct {
+	desc    *installDescription
+	version *versionInstallDescription
+}
+
+var getDependencyDescription = func(pluginName string) (*installDescription, error) {
+	desc, result := getInstallDescription(pluginName, true)
+	if !result.Success {
+		return nil, result.Error
+	}
+	return desc, nil
+}
+
+var installedPluginVersion = installedVersion
+
+type dependencyResolver struct {
+	requirements map[string][]requirement
+	chosen       map[string]string
+	order        []resolvedPlugin
+}
+
+// resolveDependencies returns the transitive dependencies of the given version of a plugin which are not installed,
+// in the order in which they are to be installed. Returns an error if the requirements on a plugin cannot be met together.
+func resolveDependencies(pluginName string, v *versionInstallDescription) ([]resolvedPlugin, error) {
+	r := &dependencyResolver{requirements: make(map[string][]requirement), chosen: map[string]string{pluginName: v.Version}}
+	requiredBy := fmt.Sprintf("%s %s", pluginName, v.Version)
+	for _, d := range v.Dependencies {
+		if err := r.resolve(d, requiredBy); err != nil {
+			return nil, err
+		}
+	}
+	return r.order, nil
+}
+
+func (r *dependencyResolver) resolve(d pluginDependency, requiredBy string) error {
+	r.requirements[d.Name] = append(r.requirements[d.Name], requirement{d, requiredBy})
+	if v, ok := r.chosen[d.Name]; ok {
+		if ok, err := r.satisfiesAll(d.Name, v); err != nil || !ok {
+			return r.conflict(d.Name, fmt.Sprintf("%s %s is already chosen", d.Name, v), err)
+		}
+		return nil
+	}
+	if installed, err := installedPluginVersion(d.Name); err == nil {
+		if ok, err := r.satisfiesAll(d.Name, installed); err == nil && ok {
+			r.chosen[d.Name] = installed
+			return nil
+		}
+	}
+	desc, err := getDependencyDescription(d.Name)
+	if err != nil {
+		return fmt.Errorf("Could not find plugin %s required by %s. %s", d.Name, requiredBy, err.Error())
+	}
+	desc.sortVersionInstallDescriptions()
+	for i, candidate := range desc.Versions {
+		if version.CheckCompatibility(version.CurrentGaugeVersion, &candidate.GaugeVersionSupport) != nil {
+			continue
+		}
+		if ok, err := r.satisfiesAll(d.Name, candidate.Version); err != nil {
+			return r.conflict(d.Name, "", err)
+		} else if !ok {
+			continue
+		}
+		r.chosen[d.Name] = candidate.Version
+		for _, next := range candidate.Dependencies {
+			if err := r.resolve(next, fmt.Sprintf("%s %s", d.Name, candidate.Version)); err != nil {
+				return err
+			}
+		}
+		r.order = append(r.order, resolvedPlugin{desc, &desc.Versions[i]})
+		return nil
+	}
+	return r.conflict(d.Name, fmt.Sprintf("no version of %s compatible with gauge %s meets all of them", d.Name, version.CurrentGaugeVersion), nil)
+}
+
+func (r *dependencyResolver) satisfiesAll(pluginName, v string) (bool, error) {
+	pv, err := version.ParseVersion(v)
+	if err != nil {
+		return false, err
+	}
+	for _, req := range r.requirements[pluginName] {
+		ok, err := satisfies(pv, req.dependency.versionSupport())
+		if err != nil {
+			return false, fmt.Errorf("Invalid version range in dependency %s of %s. %s", req.dependency, req.requiredBy, err.Error())
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (r *dependencyResolver) conflict(pluginName, reason string, err error) error {
+	if err != nil {
+		return err
+	}
+	var reqs []string
+	for _, req := range r.requirements[pluginName] {
+		reqs = append(reqs, req.String())
+	}
+	return fmt.Errorf("Conflicting requirements on plugin %s, %s:\n  %s", pluginName, reason, strings.Join(reqs, "\n  "))
+}
+
+func installDependencies(pluginName string, v *versionInstallDescription, silent bool) InstallResult {
+	dependencies, err := resolveDependencies(pluginName, v)
+	if err != nil {
+		return installError(fmt.Errorf("Could not resolve dependencies of plugin %s %s. %s", pluginName, v.Version, err.Error()))
+	}
+	for _, d := range dependencies {
+		logger.Infof(true, "Installing plugin %s %s required by %s...", d.desc.Name, d.version.Version, pluginName)
+		result := installPluginVersion(d.desc, d.version, silent)
+		if !result.Success && !result.Skipped {
+			return installError(fmt.Errorf("Failed to install plugin %s %s required by %s. %s", d.desc.Name, d.version.Version, pluginName, result.getMessage()))
+		}
+	}
+	return installSuccess("")
+}
@@ -0,0 +1,102 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package install
+
+import (
+	"fmt"
+
+	"github.com/getgauge/gauge/version"
+	. "gopkg.in/check.v1"
+)
+
+func stubPluginRepository(descriptions map[string]*installDescription, installed map[string]string) func() {
+	oldDesc, oldInstalled := getDependencyDescription, installedPluginVersion
+	getDependencyDescription = func(name string) (*installDescription, error) {
+		if d, ok := descriptions[name]; ok {
+			return d, nil
+		}
+		return nil, fmt.Errorf("plugin %s not found", name)
+	}
+	installedPluginVersion = func(name string) (string, error) {
+		if v, ok := installed[name]; ok {
+			return v, nil
+		}
+		return "", fmt.Errorf("plugin %s is not installed", name)
+	}
+	return func() { getDependencyDescription, installedPluginVersion = oldDesc, oldInstalled }
+}
+
+func describePlugin(name string, versions ...versionInstallDescription) *installDescription {
+	for i := range versions {
+		versions[i].GaugeVersionSupport = version.VersionSupport{Minimum: "0.0.1"}
+	}
+	return &installDescription{Name: name, Versions: versions}
+}
+
+func (s *MySuite) TestResolveDependenciesInstallsTransitiveDependenciesFirst(c *C) {
+	defer stubPluginRepository(map[string]*installDescription{
+		"screenshot": describePlugin("screenshot",
+			versionInstallDescription{Version: "0.1.0"},
+			versionInstallDescription{Version: "0.2.0", Dependencies: []pluginDependency{{Name: "image-utils", Minimum: "1.0.0"}}}),
+		"image-utils": describePlugin("image-utils", versionInstallDescription{Version: "1.2.0"}),
+	}, nil)()
+	v := &versionInstallDescription{Version: "4.0.0", Dependencies: []pluginDependency{{Name: "screenshot", Minimum: "0.2.0"}}}
+
+	deps, err := resolveDependencies("html-report", v)
+
+	c.Assert(err, IsNil)
+	c.Assert(len(deps), Equals, 2)
+	c.Assert(deps[0].desc.Name+" "+deps[0].version.Version, Equals, "image-utils 1.2.0")
+	c.Assert(deps[1].desc.Name+" "+deps[1].version.Version, Equals, "screenshot 0.2.0")
+}
+
+func (s *MySuite) TestResolveDependenciesSkipsInstalledDependencies(c *C) {
+	defer stubPluginRepository(nil, map[string]string{"screenshot": "0.2.1"})()
+	v := &versionInstallDescription{Version: "4.0.0", Dependencies: []pluginDependency{{Name: "screenshot", Minimum: "0.2.0"}}}
+
+	deps, err := resolveDependencies("html-report", v)
+
+	c.Assert(err, IsNil)
+	c.Assert(deps, HasLen, 0)
+}
+
+func (s *MySuite) TestResolveDependenciesReportsConflicts(c *C) {
+	defer stubPluginRepository(map[string]*installDescription{
+		"screenshot": describePlugin("screenshot", versionInstallDescription{Version: "0.1.0"}, versionInstallDescription{Version: "0.2.0"}),
+		"xml-report": describePlugin("xml-report", versionInstallDescription{Version: "0.3.0", Dependencies: []pluginDependency{{Name: "screenshot", Maximum: "0.1.9"}}}),
+	}, nil)()
+	v := &versionInstallDescription{Version: "4.0.0", Dependencies: []pluginDependency{
+		{Name: "screenshot", Minimum: "0.2.0"},
+		{Name: "xml-report"},
+	}}
+
+	_, err := resolveDependencies("html-report", v)
+
+	c.Assert(err, ErrorMatches, `(?s)Conflicting requirements on plugin screenshot, screenshot 0.2.0 is already chosen:.*html-report 4.0.0 requires screenshot >= 0.2.0.*xml-report 0.3.0 requires screenshot <= 0.1.9`)
+}
+
+func (s *MySuite) TestResolveDependenciesWhenNoVersionMeetsRequirement(c *C) {
+	defer stubPluginRepository(map[string]*installDescription{
+		"screenshot": describePlugin("screenshot", versionInstallDescription{Version: "0.1.0"}),
+	}, nil)()
+	v := &versionInstallDescription{Version: "4.0.0", Dependencies: []pluginDependency{{Name: "screenshot", Minimum: "0.2.0"}}}
+
+	_, err := resolveDependencies("html-report", v)
+
+	c.Assert(err, ErrorMatches, `(?s)Conflicting requirements on plugin screenshot, no version of screenshot compatible with gauge .* meets all of them.*`)
+}
@@ -57,6 +57,9 @@ type versionInstallDescription struct {
 	GaugeVersionSupport version.VersionSupport
 	Install             platformSpecificCommand
 	DownloadUrls        downloadUrls
+	// Checksums are the hex encoded SHA-256 checksums of the plugin zips, laid out like DownloadUrls.
+	Checksums    downloadUrls
+	Dependencies []pluginDependency
 }
 
 type downloadUrls struct {
@@ -245,6 +248,11 @@ func installPluginWithDescription(installDescription *installDescription, curren
 			return installError(fmt.Errorf("Could not find compatible version for plugin %s. : %s", installDescription.Name, err))
 		}
 	}
+	if !common.IsPluginInstalled(installDescription.Name, versionInstallDescription.Version) {
+		if result := installDependencies(installDescription.Name, versionInstallDescription, silent); !result.Success {
+			return result
+		}
+	}
 	return installPluginVersion(installDescription, versionInstallDescription, silent)
 }
 
@@ -265,6 +273,9 @@ func installPluginVersion(installDesc *installDescription, versionInstallDescrip
 	if err != nil {
 		return installError(fmt.Errorf("Failed to download the plugin. %s", err.Error()))
 	}
+	if err := verifyDownload(pluginZip, downloadLink, platformValue(versionInstallDescription.Checksums)); err != nil {
+		return installError(fmt.Errorf("Failed to verify plugin %s %s. %s", installDesc.Name, versionInstallDescription.Version, err.Error()))
+	}
 	return InstallPluginFromZipFile(pluginZip, installDesc.Name)
 }
 
@@ -358,29 +369,30 @@ func uninstallVersionOfPlugin(pluginDir, pluginName, uninstallVersion string) er
 }
 
 func getDownloadLink(downloadUrls downloadUrls) (string, error) {
-	var platformLinks *platformSpecificURL
+	downloadLink := platformValue(downloadUrls)
+	if downloadLink == "" {
+		return "", fmt.Errorf("Platform not supported for %s. Download URL not specified.", runtime.GOOS)
+	}
+	return downloadLink, nil
+}
+
+// platformValue returns the value given for the current OS and architecture.
+func platformValue(values downloadUrls) string {
+	var platformValues *platformSpecificURL
 	if strings.Contains(runtime.GOARCH, "64") {
-		platformLinks = &downloadUrls.X64
+		platformValues = &values.X64
 	} else {
-		platformLinks = &downloadUrls.X86
+		platformValues = &values.X86
 	}
 
-	var downloadLink string
 	switch runtime.GOOS {
 	case "windows":
-		downloadLink = platformLinks.Windows
-		break
+		return platformValues.Windows
 	case "darwin":
-		downloadLink = platformLinks.Darwin
-		break
+		return platformValues.Darwin
 	default:
-		downloadLink = platformLinks.Linux
-		break
+		return platformValues.Linux
 	}
-	if downloadLink == "" {
-		return "", fmt.Errorf("Platform not supported for %s. Download URL not specified.", runtime.GOOS)
-	}
-	return downloadLink, nil
 }
 
 func getInstallDescription(plugin string, silent bool) (*installDescription, InstallResult) {
@@ -471,13 +483,25 @@ func getRunnerJSONContents(file string) (*runner.RunnerInfo, error) {
 	return &r, nil
 }
 
-// AllPlugins install the latest version of all plugins specified in Gauge project manifest file
-func AllPlugins(silent bool) {
-	manifest, err := manifest.ProjectManifest()
+// AllPlugins install all plugins specified in Gauge project manifest file. Plugins locked in gauge.lock are installed
+// at their locked versions, others at their latest version, after which the lock file is updated.
+// With frozen, the lock file is not updated and the install fails if it does not match the manifest.
+func AllPlugins(silent, frozen bool) {
+	m, err := manifest.ProjectManifest()
 	if err != nil {
 		logger.Fatalf(true, err.Error())
 	}
-	installPluginsFromManifest(manifest, silent)
+	lock, err := projectLock(m, frozen)
+	if err != nil {
+		logger.Fatal(true, err.Error())
+	}
+	installPluginsFromManifest(m, lock, silent)
+	if frozen {
+		return
+	}
+	if err := updateLock(m, lock); err != nil {
+		logger.Errorf(true, "Failed to update %s. %s", manifest.LockFile, err.Error())
+	}
 }
 
 // UpdatePlugins updates all the currently installed plugins to its latest version
@@ -547,7 +571,7 @@ func HandleUpdateResult(result InstallResult, pluginName string, exitIfFailure b
 	return true
 }
 
-func installPluginsFromManifest(manifest *manifest.Manifest, silent bool) {
+func installPluginsFromManifest(manifest *manifest.Manifest, lock *manifest.Lock, silent bool) {
 	pluginsMap := make(map[string]bool, 0)
 	pluginsMap[manifest.Language] = true
 	for _, plugin := range manifest.Plugins {
@@ -555,7 +579,9 @@ func installPluginsFromManifest(manifest *manifest.Manifest, silent bool) {
 	}
 
 	for pluginName, isRunner := range pluginsMap {
-		if !IsCompatiblePluginInstalled(pluginName, isRunner) {
+		if v, ok := lock.Plugins[pluginName]; ok {
+			installLockedVersion(pluginName, v, silent)
+		} else if !IsCompatiblePluginInstalled(pluginName, isRunner) {
 			logger.Infof(true, "Compatible version of plugin %s not found. Installing plugin %s...", pluginName, pluginName)
 			HandleInstallResult(Plugin(pluginName, "", silent), pluginName, false)
 		} else {
@@ -0,0 +1,138 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package install
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/manifest"
+	"github.com/getgauge/gauge/plugin"
+)
+
+// projectLock returns the lock file of the project. With frozen, the lock file must exist and match the manifest.
+func projectLock(m *manifest.Manifest, frozen bool) (*manifest.Lock, error) {
+	lock, err := manifest.ProjectLock()
+	if err != nil {
+		return nil, err
+	}
+	if lock == nil {
+		if frozen {
+			return nil, fmt.Errorf("%s not found. Run `gauge install` to create it", manifest.LockFile)
+		}
+		return &manifest.Lock{Plugins: make(map[string]string)}, nil
+	}
+	if drift := lock.Drift(m); frozen && len(drift) > 0 {
+		return nil, fmt.Errorf("%s is out of date with %s:\n  %s\nRun `gauge install` to update it", manifest.LockFile, common.ManifestFile, strings.Join(drift, "\n  "))
+	}
+	return lock, nil
+}
+
+func installLockedVersion(pluginName, v string, silent bool) {
+	if isVersionInstalled(pluginName, v) {
+		logger.Debugf(true, "Plugin %s %s is already installed.", pluginName, v)
+		return
+	}
+	logger.Infof(true, "Installing plugin %s %s locked in %s...", pluginName, v, manifest.LockFile)
+	HandleInstallResult(Plugin(pluginName, v, silent), pluginName, false)
+}
+
+func isVersionInstalled(pluginName, v string) bool {
+	dir, err := plugin.GetInstallDir(pluginName, v)
+	return err == nil && common.DirExists(dir)
+}
+
+// installedVersion returns the version of the plugin which gauge uses, i.e. the latest installed one.
+func installedVersion(pluginName string) (string, error) {
+	dir, err := plugin.GetInstallDir(pluginName, "")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Base(dir), nil
+}
+
+// updateLock locks the plugins of the manifest which are not locked yet to their installed version,
+// and removes the plugins no longer in the manifest.
+func updateLock(m *manifest.Manifest, lock *manifest.Lock) error {
+	plugins := make(map[string]string)
+	for _, p := range append([]string{m.Language}, m.Plugins...) {
+		if v, ok := lock.Plugins[p]; ok {
+			plugins[p] = v
+			continue
+		}
+		v, err := installedVersion(p)
+		if err != nil {
+			logger.Debugf(true, "Not locking plugin %s. %s", p, err.Error())
+			continue
+		}
+		plugins[p] = v
+	}
+	if sameVersions(plugins, lock.Plugins) && common.FileExists(filepath.Join(config.ProjectRoot, manifest.LockFile)) {
+		return nil
+	}
+	lock.Plugins = plugins
+	return lock.Save()
+}
+
+func sameVersions(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// LockPlugin records the given version of a plugin of the project in the lock file, or its installed version
+// when no version is given. Plugins which are not part of the project are not locked.
+func LockPlugin(pluginName, v string) error {
+	m, err := manifest.ProjectManifest()
+	if err != nil {
+		return nil
+	}
+	if m.Language != pluginName && !contains(m.Plugins, pluginName) {
+		return nil
+	}
+	lock, err := projectLock(m, false)
+	if err != nil {
+		return err
+	}
+	if v == "" {
+		if v, err = installedVersion(pluginName); err != nil {
+			return err
+		}
+	}
+	lock.Plugins[pluginName] = v
+	return lock.Save()
+}
+
+func contains(list []string, item string) bool {
+	for _, l := range list {
+		if l == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package install
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/manifest"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestProjectLockWhenFrozenAndLockFileIsMissing(c *C) {
+	old := config.ProjectRoot
+	defer func() { config.ProjectRoot = old }()
+	config.ProjectRoot = c.MkDir()
+
+	_, err := projectLock(&manifest.Manifest{Language: "java"}, true)
+
+	c.Assert(err, ErrorMatches, "gauge.lock not found.*")
+}
+
+func (s *MySuite) TestProjectLockWhenFrozenAndLockFileDrifts(c *C) {
+	old := config.ProjectRoot
+	defer func() { config.ProjectRoot = old }()
+	config.ProjectRoot = c.MkDir()
+	ioutil.WriteFile(filepath.Join(config.ProjectRoot, manifest.LockFile), []byte(`{"Plugins": {"java": "0.6.5", "xml-report": "0.2.0"}}`), 0644)
+	m := &manifest.Manifest{Language: "java", Plugins: []string{"html-report"}}
+
+	_, err := projectLock(m, true)
+
+	c.Assert(err, ErrorMatches, "(?s)gauge.lock is out of date with manifest.json:.*html-report is not locked.*xml-report is locked but not in manifest.json.*")
+
+	lock, err := projectLock(m, false)
+	c.Assert(err, IsNil)
+	c.Assert(lock.Plugins["java"], Equals, "0.6.5")
+}
+
+func (s *MySuite) TestProjectLockWhenFrozenAndLockFileMatches(c *C) {
+	old := config.ProjectRoot
+	defer func() { config.ProjectRoot = old }()
+	config.ProjectRoot = c.MkDir()
+	ioutil.WriteFile(filepath.Join(config.ProjectRoot, manifest.LockFile), []byte(`{"Plugins": {"java": "0.6.5", "html-report": "4.0.2"}}`), 0644)
+
+	lock, err := projectLock(&manifest.Manifest{Language: "java", Plugins: []string{"html-report"}}, true)
+
+	c.Assert(err, IsNil)
+	c.Assert(lock.Plugins, DeepEquals, map[string]string{"java": "0.6.5", "html-report": "4.0.2"})
+}
@@ -0,0 +1,141 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package install
+
+import (
+	"fmt"
+
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/plugin/pluginInfo"
+	"github.com/getgauge/gauge/version"
+)
+
+// Upgrade is a planned move of gauge or an installed plugin to a newer version.
+type Upgrade struct {
+	Name           string
+	CurrentVersion string
+	Version        string
+	// Message explains how the upgrade is done, when gauge cannot do it by itself.
+	Message string
+	desc    *installDescription
+}
+
+// UpgradePlan lists the newest versions of gauge and the installed plugins that are compatible with the current gauge version
+// and satisfy the given version constraints, keyed by plugin name.
+func UpgradePlan(constraints map[string]version.VersionSupport) ([]Upgrade, error) {
+	var plan []Upgrade
+	for _, u := range checkGaugeUpdate() {
+		plan = append(plan, Upgrade{Name: u.Name, CurrentVersion: version.CurrentGaugeVersion.String(), Version: u.CompatibleVersion, Message: u.Message})
+	}
+	plugins, err := pluginInfo.GetAllInstalledPluginsWithVersion()
+	if err != nil {
+		return plan, nil
+	}
+	for _, p := range plugins {
+		desc, result := getInstallDescription(p.Name, true)
+		if result.Error != nil {
+			logger.Debugf(true, "Failed to check upgrades of plugin %s. %s", p.Name, result.Error.Error())
+			continue
+		}
+		var constraint *version.VersionSupport
+		if c, ok := constraints[p.Name]; ok {
+			constraint = &c
+		}
+		v, err := upgradeVersion(desc, p.Version, constraint)
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			plan = append(plan, Upgrade{Name: p.Name, CurrentVersion: p.Version.String(), Version: v.Version, desc: desc})
+		}
+	}
+	return plan, nil
+}
+
+// upgradeVersion returns the newest version of the plugin, greater than the installed one, which supports the current gauge version
+// and lies within the constraint. Returns nil if there is no such version.
+func upgradeVersion(desc *installDescription, installed *version.Version, constraint *version.VersionSupport) (*versionInstallDescription, error) {
+	desc.sortVersionInstallDescriptions()
+	for i, v := range desc.Versions {
+		pv, err := version.ParseVersion(v.Version)
+		if err != nil || !pv.IsGreaterThan(installed) {
+			continue
+		}
+		if version.CheckCompatibility(version.CurrentGaugeVersion, &v.GaugeVersionSupport) != nil {
+			continue
+		}
+		if constraint != nil {
+			ok, err := satisfies(pv, constraint)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid version constraint for plugin %s in manifest. %s", desc.Name, err.Error())
+			}
+			if !ok {
+				continue
+			}
+		}
+		return &desc.Versions[i], nil
+	}
+	return nil, nil
+}
+
+func satisfies(v *version.Version, constraint *version.VersionSupport) (bool, error) {
+	if constraint.Minimum == "" && constraint.Maximum == "" {
+		return true, nil
+	}
+	c := *constraint
+	if c.Minimum == "" {
+		c.Minimum = "0.0.0"
+	}
+	if _, err := version.ParseVersion(c.Minimum); err != nil {
+		return false, err
+	}
+	if c.Maximum != "" {
+		if _, err := version.ParseVersion(c.Maximum); err != nil {
+			return false, err
+		}
+	}
+	return version.CheckCompatibility(v, &c) == nil, nil
+}
+
+// PrintUpgradePlan prints the upgrades, one per line.
+func PrintUpgradePlan(plan []Upgrade) {
+	if len(plan) == 0 {
+		logger.Infof(true, "Gauge and all the plugins are up to date.")
+		return
+	}
+	for _, u := range plan {
+		logger.Infof(true, "%-15s\t%-10s =>  %-10s\t%s", u.Name, u.CurrentVersion, u.Version, u.Message)
+	}
+}
+
+// UpgradeAll installs the planned plugin versions. Upgrades which gauge cannot do by itself, like those of gauge, are skipped.
+// Returns the names of the plugins which failed to upgrade.
+func UpgradeAll(plan []Upgrade, silent bool) []string {
+	var failed []string
+	for _, u := range plan {
+		if u.desc == nil {
+			logger.Infof(true, "Skipping %s. %s", u.Name, u.Message)
+			continue
+		}
+		logger.Debugf(true, "Upgrading plugin '%s' from %s to %s", u.Name, u.CurrentVersion, u.Version)
+		if !HandleUpdateResult(installPluginWithDescription(u.desc, u.Version, silent), u.Name, false) {
+			failed = append(failed, u.Name)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,71 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package install
+
+import (
+	"github.com/getgauge/gauge/version"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestUpgradeVersionIsNewestCompatibleVersion(c *C) {
+	desc := createInstallDescriptionWithVersions("0.3.2", "0.3.4", "0.4.0", "0.5.0")
+	addVersionSupportToInstallDescription(desc,
+		&version.VersionSupport{Minimum: "0.0.1"},
+		&version.VersionSupport{Minimum: "0.0.1"},
+		&version.VersionSupport{Minimum: "0.0.1"},
+		&version.VersionSupport{Minimum: "99.0.0"})
+
+	v, err := upgradeVersion(desc, &version.Version{Major: 0, Minor: 3, Patch: 2}, nil)
+
+	c.Assert(err, IsNil)
+	c.Assert(v.Version, Equals, "0.4.0")
+}
+
+func (s *MySuite) TestUpgradeVersionRespectsConstraint(c *C) {
+	desc := createInstallDescriptionWithVersions("0.3.2", "0.3.4", "0.4.0")
+	addVersionSupportToInstallDescription(desc,
+		&version.VersionSupport{Minimum: "0.0.1"},
+		&version.VersionSupport{Minimum: "0.0.1"},
+		&version.VersionSupport{Minimum: "0.0.1"})
+
+	v, err := upgradeVersion(desc, &version.Version{Major: 0, Minor: 3, Patch: 2}, &version.VersionSupport{Maximum: "0.3.9"})
+
+	c.Assert(err, IsNil)
+	c.Assert(v.Version, Equals, "0.3.4")
+}
+
+func (s *MySuite) TestUpgradeVersionWhenAlreadyNewest(c *C) {
+	desc := createInstallDescriptionWithVersions("0.3.2", "0.3.4")
+	addVersionSupportToInstallDescription(desc,
+		&version.VersionSupport{Minimum: "0.0.1"},
+		&version.VersionSupport{Minimum: "0.0.1"})
+
+	v, err := upgradeVersion(desc, &version.Version{Major: 0, Minor: 3, Patch: 4}, nil)
+
+	c.Assert(err, IsNil)
+	c.Assert(v, IsNil)
+}
+
+func (s *MySuite) TestUpgradeVersionWithInvalidConstraint(c *C) {
+	desc := createInstallDescriptionWithVersions("0.3.4")
+	addVersionSupportToInstallDescription(desc, &version.VersionSupport{Minimum: "0.0.1"})
+
+	_, err := upgradeVersion(desc, &version.Version{Major: 0, Minor: 3, Patch: 2}, &version.VersionSupport{Maximum: "0.3"})
+
+	c.Assert(err, NotNil)
+}
@@ -0,0 +1,102 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package install
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/util"
+	"golang.org/x/crypto/ed25519"
+)
+
+// signatureExt is the extension of the detached signature published next to a plugin zip.
+const signatureExt = ".sig"
+
+var downloadSignature = func(url, targetDir string) (string, error) {
+	return util.Download(url, targetDir, "", true)
+}
+
+// verifyDownload checks the downloaded plugin zip against its checksum, if given, and its detached signature
+// when signing keys are configured. A signature is mandatory if plugin_signature_required is set.
+func verifyDownload(zip, downloadLink, checksum string) error {
+	contents, err := ioutil.ReadFile(zip)
+	if err != nil {
+		return err
+	}
+	if checksum != "" {
+		if err := verifyChecksum(contents, checksum); err != nil {
+			return err
+		}
+	}
+	keys := config.PluginSigningKeys()
+	required := config.PluginSignatureRequired()
+	if len(keys) == 0 {
+		if required {
+			return fmt.Errorf("Plugin signatures are required, but no signing keys are configured. Set them with `gauge config plugin_signing_keys <keys>`")
+		}
+		return nil
+	}
+	sigFile, err := downloadSignature(downloadLink+signatureExt, filepath.Dir(zip))
+	if err != nil {
+		if required {
+			return fmt.Errorf("Signature of %s not found. %s", filepath.Base(zip), err.Error())
+		}
+		logger.Debugf(true, "Signature of %s not found. %s", filepath.Base(zip), err.Error())
+		return nil
+	}
+	signature, err := ioutil.ReadFile(sigFile)
+	if err != nil {
+		return err
+	}
+	return verifySignature(contents, string(signature), keys)
+}
+
+func verifyChecksum(contents []byte, checksum string) error {
+	sum := sha256.Sum256(contents)
+	if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, strings.TrimSpace(checksum)) {
+		return fmt.Errorf("Checksum mismatch. Expected SHA-256 %s, got %s", checksum, actual)
+	}
+	return nil
+}
+
+// verifySignature checks that the base64 encoded ed25519 signature of contents is made by one of the keys.
+func verifySignature(contents []byte, signature string, keys []string) error {
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(signature))
+	if err != nil {
+		return fmt.Errorf("Invalid signature. %s", err.Error())
+	}
+	for _, k := range keys {
+		key, err := base64.StdEncoding.DecodeString(k)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			logger.Warningf(true, "Ignoring invalid plugin signing key %s", k)
+			continue
+		}
+		if ed25519.Verify(ed25519.PublicKey(key), contents, sig) {
+			return nil
+		}
+	}
+	return fmt.Errorf("Signature is not made by any of the trusted plugin signing keys")
+}
@@ -0,0 +1,50 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package install
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+
+	"golang.org/x/crypto/ed25519"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestVerifyChecksum(c *C) {
+	contents := []byte("plugin zip")
+	sum := sha256.Sum256(contents)
+
+	c.Assert(verifyChecksum(contents, hex.EncodeToString(sum[:])), IsNil)
+	c.Assert(verifyChecksum([]byte("tampered zip"), hex.EncodeToString(sum[:])), ErrorMatches, "Checksum mismatch.*")
+}
+
+func (s *MySuite) TestVerifySignature(c *C) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	otherPub, _, _ := ed25519.GenerateKey(rand.Reader)
+	contents := []byte("plugin zip")
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(priv, contents))
+	key := base64.StdEncoding.EncodeToString(pub)
+	otherKey := base64.StdEncoding.EncodeToString(otherPub)
+
+	c.Assert(verifySignature(contents, signature+"\n", []string{otherKey, key}), IsNil)
+	c.Assert(verifySignature(contents, signature, []string{otherKey}), ErrorMatches, "Signature is not made by any of the trusted plugin signing keys")
+	c.Assert(verifySignature([]byte("tampered zip"), signature, []string{key}), NotNil)
+	c.Assert(verifySignature(contents, "not base64!", []string{key}), ErrorMatches, "Invalid signature.*")
+}
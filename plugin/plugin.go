@@ -36,6 +36,7 @@ import (
 	"github.com/getgauge/gauge/gauge_messages"
 	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/manifest"
+	"github.com/getgauge/gauge/orphans"
 	"github.com/getgauge/gauge/plugin/pluginInfo"
 	"github.com/getgauge/gauge/reporter"
 	"github.com/getgauge/gauge/version"
@@ -45,17 +46,26 @@ import (
 type pluginScope string
 
 const (
-	executionScope          pluginScope = "execution"
-	docScope                pluginScope = "documentation"
-	pluginConnectionPortEnv             = "plugin_connection_port"
-	debugEnv                            = "debugging"
+	executionScope            pluginScope = "execution"
+	docScope                  pluginScope = "documentation"
+	pluginConnectionPortEnv               = "plugin_connection_port"
+	pluginReporterPortEnv                 = "plugin_reporter_port"
+	pluginConnectionSocketEnv             = "plugin_connection_socket"
+	pluginReporterSocketEnv               = "plugin_reporter_socket"
+	pluginConnectionPipeEnv               = "plugin_connection_pipe"
+	pluginReporterPipeEnv                 = "plugin_reporter_pipe"
+	debugEnv                              = "debugging"
 )
 
 type plugin struct {
 	mutex      *sync.Mutex
+	reqMutex   sync.Mutex
 	connection net.Conn
-	pluginCmd  *exec.Cmd
-	descriptor *pluginDescriptor
+	// reporterConnection streams the console events to plugins with the console_reporter capability.
+	reporterConnection net.Conn
+	reporterSink       *reporterSink
+	pluginCmd          *exec.Cmd
+	descriptor         *pluginDescriptor
 }
 
 func (p *plugin) IsProcessRunning() bool {
@@ -69,6 +79,9 @@ func (p *plugin) kill(wg *sync.WaitGroup) error {
 	defer wg.Done()
 	if p.IsProcessRunning() {
 		defer p.connection.Close()
+		if p.reporterConnection != nil {
+			defer p.reporterConnection.Close()
+		}
 		conn.SendProcessKillMessage(p.connection)
 
 		exited := make(chan bool, 1)
@@ -177,9 +190,11 @@ func StartPlugin(pd *pluginDescriptor, action pluginScope) (*plugin, error) {
 	if err != nil {
 		return nil, err
 	}
+	orphans.Track(cmd, orphans.Plugin)
 	var mutex = &sync.Mutex{}
 	go func() {
 		pState, _ := cmd.Process.Wait()
+		orphans.Untrack(cmd.Process.Pid)
 		mutex.Lock()
 		cmd.ProcessState = pState
 		mutex.Unlock()
@@ -232,12 +247,20 @@ func startPluginsForExecution(manifest *manifest.Manifest) (Handler, []string) {
 			continue
 		}
 		if pd.hasScope(executionScope) {
-			gaugeConnectionHandler, err := conn.NewGaugeConnectionHandler(0, nil)
+			gaugeConnectionHandler, err := newConnectionHandler(pd, envProperties, pluginConnectionPortEnv, pluginConnectionSocketEnv, pluginConnectionPipeEnv)
 			if err != nil {
 				warnings = append(warnings, err.Error())
 				continue
 			}
-			envProperties[pluginConnectionPortEnv] = strconv.Itoa(gaugeConnectionHandler.ConnectionPortNumber())
+			var reporterConnectionHandler *conn.GaugeConnectionHandler
+			envProperties[pluginReporterPortEnv], envProperties[pluginReporterSocketEnv] = "", ""
+			if pd.hasCapability(consoleReporterCapability) {
+				reporterConnectionHandler, err = newConnectionHandler(pd, envProperties, pluginReporterPortEnv, pluginReporterSocketEnv, pluginReporterPipeEnv)
+				if err != nil {
+					warnings = append(warnings, err.Error())
+					continue
+				}
+			}
 			err = SetEnvForPlugin(executionScope, pd, manifest, envProperties)
 			if err != nil {
 				warnings = append(warnings, fmt.Sprintf("Error setting environment for plugin %s %s. %s", pd.Name, pd.Version, err.Error()))
@@ -256,6 +279,16 @@ func startPluginsForExecution(manifest *manifest.Manifest) (Handler, []string) {
 				continue
 			}
 			plugin.connection = pluginConnection
+			if reporterConnectionHandler != nil {
+				reporterConnection, err := reporterConnectionHandler.AcceptConnection(config.PluginConnectionTimeout(), make(chan error))
+				if err != nil {
+					warnings = append(warnings, fmt.Sprintf("Plugin %s %s did not connect to receive console events. %s", pd.Name, pd.Version, err.Error()))
+				} else {
+					plugin.reporterConnection = reporterConnection
+					plugin.reporterSink = &reporterSink{plugin: plugin}
+					reporter.AddSink(plugin.reporterSink)
+				}
+			}
 			handler.addPlugin(pluginID, plugin)
 		}
 
@@ -263,6 +296,35 @@ func startPluginsForExecution(manifest *manifest.Manifest) (Handler, []string) {
 	return handler, warnings
 }
 
+// newConnectionHandler listens for the plugin to connect, over a named pipe on windows or a unix socket if the plugin
+// supports it and on one of the plugin ports otherwise. The address is set in the port, socket or pipe property of the
+// environment of the plugin.
+func newConnectionHandler(pd *pluginDescriptor, envProperties map[string]string, portEnv, socketEnv, pipeEnv string) (*conn.GaugeConnectionHandler, error) {
+	envProperties[portEnv], envProperties[socketEnv], envProperties[pipeEnv] = "", "", ""
+	if runtime.GOOS == "windows" && pd.hasCapability(namedPipeCapability) && config.NamedPipes() {
+		handler, err := conn.NewGaugePipeConnectionHandler(nil)
+		if err == nil {
+			envProperties[pipeEnv] = handler.PipeName()
+			return handler, nil
+		}
+		logger.Debugf(true, "Failed to listen on a named pipe for plugin %s. %s", pd.Name, err.Error())
+	}
+	if pd.hasCapability(unixSocketCapability) && config.UnixSockets() {
+		handler, err := conn.NewGaugeSocketConnectionHandler(nil)
+		if err == nil {
+			envProperties[socketEnv] = handler.SocketPath()
+			return handler, nil
+		}
+		logger.Debugf(true, "Failed to listen on a unix socket for plugin %s, using a port instead. %s", pd.Name, err.Error())
+	}
+	handler, err := conn.NewGaugeConnectionHandlerOnPorts(config.PluginPorts(), nil)
+	if err != nil {
+		return nil, err
+	}
+	envProperties[portEnv] = strconv.Itoa(handler.ConnectionPortNumber())
+	return handler, nil
+}
+
 func GenerateDoc(pluginName string, specDirs []string, port int) {
 	pd, err := GetPluginDescriptor(pluginName, "")
 	if err != nil {
@@ -290,6 +352,24 @@ func GenerateDoc(pluginName string, specDirs []string, port int) {
 	}
 }
 
+// reporterSink writes the console events to the reporter connection of a plugin, one JSON object per line.
+// Once a write fails, the plugin is considered gone and the remaining events are discarded.
+type reporterSink struct {
+	plugin *plugin
+	failed bool
+}
+
+func (s *reporterSink) Write(b []byte) (int, error) {
+	if s.failed {
+		return len(b), nil
+	}
+	if _, err := s.plugin.reporterConnection.Write(b); err != nil {
+		s.failed = true
+		logger.Debugf(true, "Failed to send console events to plugin %s. %s", s.plugin.descriptor.ID, err.Error())
+	}
+	return len(b), nil
+}
+
 func (p *plugin) sendMessage(message *gauge_messages.Message) error {
 	messageID := common.GetUniqueID()
 	message.MessageId = messageID
@@ -304,6 +384,88 @@ func (p *plugin) sendMessage(message *gauge_messages.Message) error {
 	return nil
 }
 
+// sendSuiteResult sends the suite result as a SuiteExecutionResult message whose spec results are marshalled and written
+// one at a time. Protobuf merges concatenated messages when decoding them, so the message is written as the suite result
+// without spec results followed by a message with each spec result. The spec results are gone through twice, first to
+// get the length of the message.
+func (p *plugin) sendSuiteResult(suiteResult *gauge_messages.ProtoSuiteResult, specResults SpecResults) error {
+	header, err := proto.Marshal(&gauge_messages.Message{MessageType: gauge_messages.Message_SuiteExecutionResult, MessageId: common.GetUniqueID(),
+		SuiteExecutionResult: &gauge_messages.SuiteExecutionResult{SuiteResult: suiteResult}})
+	if err != nil {
+		return err
+	}
+	length := len(header)
+	specResults(func(r *gauge_messages.ProtoSpecResult) {
+		length += proto.Size(specResultMessage(r))
+	})
+	err = conn.WriteParts(p.connection, length, func(write func([]byte) error) error {
+		if err := write(header); err != nil {
+			return err
+		}
+		var err error
+		specResults(func(r *gauge_messages.ProtoSpecResult) {
+			if err != nil {
+				return
+			}
+			var b []byte
+			if b, err = proto.Marshal(specResultMessage(r)); err == nil {
+				err = write(b)
+			}
+		})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("[Warning] Failed to send message to plugin: %s  %s", p.descriptor.ID, err.Error())
+	}
+	return nil
+}
+
+func specResultMessage(r *gauge_messages.ProtoSpecResult) *gauge_messages.Message {
+	return &gauge_messages.Message{SuiteExecutionResult: &gauge_messages.SuiteExecutionResult{
+		SuiteResult: &gauge_messages.ProtoSuiteResult{SpecResults: []*gauge_messages.ProtoSpecResult{r}}}}
+}
+
+// sendChunkedSuiteResult sends the suite result with the spec results stripped of their items, followed by a
+// SuiteExecutionResultItem message for every item, one spec at a time.
+func (p *plugin) sendChunkedSuiteResult(suiteResult *gauge_messages.ProtoSuiteResult, specResults SpecResults) error {
+	chunked := *suiteResult
+	chunked.Chunked = true
+	chunked.ChunkSize = 0
+	chunked.SpecResults = nil
+	specResults(func(r *gauge_messages.ProtoSpecResult) {
+		spec := *r.ProtoSpec
+		spec.ItemCount = int64(len(spec.Items))
+		spec.Items = nil
+		sr := *r
+		sr.ProtoSpec = &spec
+		chunked.SpecResults = append(chunked.SpecResults, &sr)
+		chunked.ChunkSize += spec.ItemCount
+	})
+	err := p.sendMessage(&gauge_messages.Message{MessageType: gauge_messages.Message_SuiteExecutionResult,
+		SuiteExecutionResult: &gauge_messages.SuiteExecutionResult{SuiteResult: &chunked}})
+	if err != nil {
+		return err
+	}
+	specResults(func(r *gauge_messages.ProtoSpecResult) {
+		for _, i := range r.ProtoSpec.Items {
+			if err != nil {
+				return
+			}
+			i.FileName = r.ProtoSpec.FileName
+			err = p.sendMessage(&gauge_messages.Message{MessageType: gauge_messages.Message_SuiteExecutionResultItem,
+				SuiteExecutionResultItem: &gauge_messages.SuiteExecutionResultItem{ResultItem: i}})
+		}
+	})
+	return err
+}
+
+// getResponse sends a request to the plugin and waits for its response. Requests from parallel streams are sent one at a time.
+func (p *plugin) getResponse(message *gauge_messages.Message) (*gauge_messages.Message, error) {
+	p.reqMutex.Lock()
+	defer p.reqMutex.Unlock()
+	return conn.GetResponseForMessageWithTimeout(message, p.connection, config.PluginRequestTimeout())
+}
+
 func StartPlugins(manifest *manifest.Manifest) Handler {
 	pluginHandler, warnings := startPluginsForExecution(manifest)
 	logger.HandleWarningMessages(true, warnings)
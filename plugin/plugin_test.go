@@ -18,17 +18,25 @@
 package plugin
 
 import (
+	"bytes"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"reflect"
+	"runtime"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/getgauge/common"
 	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/conn"
+	"github.com/getgauge/gauge/gauge_messages"
 	"github.com/getgauge/gauge/plugin/pluginInfo"
 	"github.com/getgauge/gauge/version"
 
+	"github.com/golang/protobuf/proto"
 	. "gopkg.in/check.v1"
 )
 
@@ -102,3 +110,247 @@ func TestGetPluginsWithoutScope(t *testing.T) {
 		t.Errorf("Failed GetPluginWithoutScope.\n\tWant: %v\n\tGot: %v", want, got)
 	}
 }
+
+// interceptingPlugin responds to one request with the given result.
+func interceptingPlugin(name string, result *gauge_messages.ProtoExecutionResult) *plugin {
+	gaugeEnd, pluginEnd := net.Pipe()
+	go func() {
+		data := make([]byte, 8192)
+		n, err := pluginEnd.Read(data)
+		if err != nil {
+			return
+		}
+		length, l := proto.DecodeVarint(data[:n])
+		req := &gauge_messages.Message{}
+		proto.Unmarshal(data[l:l+int(length)], req)
+		res := &gauge_messages.Message{MessageId: req.MessageId, MessageType: gauge_messages.Message_ExecutionStatusResponse,
+			ExecutionStatusResponse: &gauge_messages.ExecutionStatusResponse{ExecutionResult: result}}
+		b, _ := proto.Marshal(res)
+		conn.Write(pluginEnd, b)
+	}()
+	pd := &pluginDescriptor{ID: name, Name: name, Capabilities: []string{string(interceptExecutionCapability)}}
+	return &plugin{mutex: &sync.Mutex{}, connection: gaugeEnd, descriptor: pd}
+}
+
+var scenarioStarting = &gauge_messages.Message{MessageType: gauge_messages.Message_ScenarioExecutionStarting,
+	ScenarioExecutionStartingRequest: &gauge_messages.ScenarioExecutionStartingRequest{}}
+
+func TestInterceptScenarioCollectsMessagesAndSkips(t *testing.T) {
+	gp := &GaugePlugins{}
+	gp.addPlugin("a", interceptingPlugin("a", &gauge_messages.ProtoExecutionResult{Message: []string{"owner: team-a"}}))
+	gp.addPlugin("b", interceptingPlugin("b", &gauge_messages.ProtoExecutionResult{Failed: true, RecoverableError: true, ErrorMessage: "quarantined"}))
+
+	got := gp.InterceptScenario(scenarioStarting)
+
+	want := &Interception{Skip: true, Reason: "Skipped by plugin b: quarantined", Messages: []string{"owner: team-a"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestInterceptScenarioAborts(t *testing.T) {
+	gp := &GaugePlugins{}
+	gp.addPlugin("a", interceptingPlugin("a", &gauge_messages.ProtoExecutionResult{Failed: true, ErrorMessage: "environment is down"}))
+
+	got := gp.InterceptScenario(scenarioStarting)
+
+	want := &Interception{Abort: true, Reason: "Execution aborted by plugin a: environment is down"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestInterceptScenarioIgnoresOtherPlugins(t *testing.T) {
+	gp := &GaugePlugins{}
+	gp.addPlugin("html-report", &plugin{mutex: &sync.Mutex{}, descriptor: &pluginDescriptor{ID: "html-report"}})
+
+	got := gp.InterceptScenario(scenarioStarting)
+
+	if !reflect.DeepEqual(got, &Interception{}) {
+		t.Errorf("want no interception, got %+v", got)
+	}
+}
+
+func TestReporterSinkDiscardsEventsOnceThePluginIsGone(t *testing.T) {
+	gaugeEnd, pluginEnd := net.Pipe()
+	p := &plugin{reporterConnection: gaugeEnd, descriptor: &pluginDescriptor{ID: "dashboard"}}
+	s := &reporterSink{plugin: p}
+	event := []byte(`{"type":"specStart"}` + "\n")
+
+	received := make(chan string, 1)
+	go func() {
+		b := make([]byte, len(event))
+		n, _ := pluginEnd.Read(b)
+		received <- string(b[:n])
+	}()
+	if n, err := s.Write(event); n != len(event) || err != nil {
+		t.Fatalf("Expected event to be written. Got %d, %v", n, err)
+	}
+	if got := <-received; got != string(event) {
+		t.Errorf("Expected plugin to receive %q. Got %q", string(event), got)
+	}
+
+	pluginEnd.Close()
+	for i := 0; i < 2; i++ {
+		if n, err := s.Write(event); n != len(event) || err != nil {
+			t.Errorf("Expected event to be discarded without error. Got %d, %v", n, err)
+		}
+	}
+	if !s.failed {
+		t.Errorf("Expected sink to stop writing to the plugin")
+	}
+}
+
+func (s *MySuite) TestNewConnectionHandlerUsesUnixSocketWhenSupported(c *C) {
+	os.Setenv("unix_sockets", "true")
+	defer os.Unsetenv("unix_sockets")
+	env := map[string]string{}
+
+	h, err := newConnectionHandler(&pluginDescriptor{Name: "html-report", Capabilities: []string{"unix_socket"}}, env, pluginConnectionPortEnv, pluginConnectionSocketEnv, pluginConnectionPipeEnv)
+
+	c.Assert(err, IsNil)
+	c.Assert(env[pluginConnectionPortEnv], Equals, "")
+	c.Assert(env[pluginConnectionSocketEnv], Equals, h.SocketPath())
+	c.Assert(h.SocketPath(), Not(Equals), "")
+}
+
+func (s *MySuite) TestNewConnectionHandlerUsesPortWithoutUnixSocketSupport(c *C) {
+	env := map[string]string{pluginConnectionSocketEnv: "@stale"}
+
+	h, err := newConnectionHandler(&pluginDescriptor{Name: "html-report"}, env, pluginConnectionPortEnv, pluginConnectionSocketEnv, pluginConnectionPipeEnv)
+
+	c.Assert(err, IsNil)
+	c.Assert(env[pluginConnectionPortEnv], Equals, fmt.Sprintf("%d", h.ConnectionPortNumber()))
+	c.Assert(env[pluginConnectionSocketEnv], Equals, "")
+}
+
+func (s *MySuite) TestNewConnectionHandlerUsesPipeOnlyOnWindows(c *C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("named pipes are used on windows")
+	}
+	env := map[string]string{}
+
+	h, err := newConnectionHandler(&pluginDescriptor{Name: "html-report", Capabilities: []string{"named_pipe"}}, env, pluginConnectionPortEnv, pluginConnectionSocketEnv, pluginConnectionPipeEnv)
+
+	c.Assert(err, IsNil)
+	c.Assert(env[pluginConnectionPipeEnv], Equals, "")
+	c.Assert(env[pluginConnectionPortEnv], Equals, fmt.Sprintf("%d", h.ConnectionPortNumber()))
+}
+
+// pluginReceiving collects all that is sent to the plugin until the connection is closed.
+type pluginReceiving struct {
+	mutex    sync.Mutex
+	received []byte
+	done     chan struct{}
+}
+
+func receive(pluginEnd net.Conn) *pluginReceiving {
+	r := &pluginReceiving{done: make(chan struct{})}
+	go func() {
+		b := make([]byte, 8192)
+		for {
+			n, err := pluginEnd.Read(b)
+			r.mutex.Lock()
+			r.received = append(r.received, b[:n]...)
+			r.mutex.Unlock()
+			if err != nil {
+				close(r.done)
+				return
+			}
+		}
+	}()
+	return r
+}
+
+// has tells if s is received, or if anything is, for an empty s.
+func (r *pluginReceiving) has(s string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return len(r.received) > 0 && bytes.Contains(r.received, []byte(s))
+}
+
+func (r *pluginReceiving) messages(t *testing.T) []*gauge_messages.Message {
+	<-r.done
+	var messages []*gauge_messages.Message
+	for b := r.received; len(b) > 0; {
+		length, l := proto.DecodeVarint(b)
+		m := &gauge_messages.Message{}
+		if err := proto.Unmarshal(b[l:l+int(length)], m); err != nil {
+			t.Fatalf("Expected a message. Got %s", err.Error())
+		}
+		messages = append(messages, m)
+		b = b[l+int(length):]
+	}
+	return messages
+}
+
+// readBack gives n spec results, each built afresh whenever it is asked for, the way spilled spec results are read back.
+// Once the plugin is being sent data, it fails the test if a spec result is asked for before the plugin has received
+// the one before it.
+func readBack(t *testing.T, r *pluginReceiving, n int) SpecResults {
+	return func(f func(*gauge_messages.ProtoSpecResult)) {
+		sending := r.has("")
+		for i := 0; i < n; i++ {
+			if sending && i > 0 {
+				previous := fmt.Sprintf("specs/%d.spec", i-1)
+				for deadline := time.Now().Add(5 * time.Second); !r.has(previous); time.Sleep(time.Millisecond) {
+					if time.Now().After(deadline) {
+						t.Fatalf("Expected %s to be received before reading back the next spec result", previous)
+					}
+				}
+			}
+			item := &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_Scenario, Scenario: &gauge_messages.ProtoScenario{ScenarioHeading: "Scenario"}}
+			f(&gauge_messages.ProtoSpecResult{ProtoSpec: &gauge_messages.ProtoSpec{FileName: fmt.Sprintf("specs/%d.spec", i),
+				Items: []*gauge_messages.ProtoItem{item}}, ScenarioCount: 1})
+		}
+	}
+}
+
+func TestNotifySuiteResultSendsSpecResultsOneAtATime(t *testing.T) {
+	gaugeEnd, pluginEnd := net.Pipe()
+	gp := &GaugePlugins{}
+	gp.addPlugin("html-report", &plugin{mutex: &sync.Mutex{}, connection: gaugeEnd, descriptor: &pluginDescriptor{ID: "html-report"}})
+	r := receive(pluginEnd)
+
+	gp.NotifySuiteResult(&gauge_messages.ProtoSuiteResult{ProjectName: "project"}, readBack(t, r, 3))
+	gaugeEnd.Close()
+
+	messages := r.messages(t)
+	if len(messages) != 1 {
+		t.Fatalf("Expected one message. Got %d", len(messages))
+	}
+	got := messages[0].GetSuiteExecutionResult().GetSuiteResult()
+	if got.ProjectName != "project" || len(got.SpecResults) != 3 {
+		t.Fatalf("Expected the suite result with 3 spec results. Got %v", got)
+	}
+	for i, sr := range got.SpecResults {
+		if sr.ProtoSpec.FileName != fmt.Sprintf("specs/%d.spec", i) || len(sr.ProtoSpec.Items) != 1 {
+			t.Errorf("Expected spec result %d with its item. Got %v", i, sr)
+		}
+	}
+}
+
+func TestNotifySuiteResultStreamsItemsOneSpecAtATime(t *testing.T) {
+	gaugeEnd, pluginEnd := net.Pipe()
+	gp := &GaugePlugins{}
+	gp.addPlugin("html-report", &plugin{mutex: &sync.Mutex{}, connection: gaugeEnd,
+		descriptor: &pluginDescriptor{ID: "html-report", Capabilities: []string{string(streamResultCapability)}}})
+	r := receive(pluginEnd)
+
+	gp.NotifySuiteResult(&gauge_messages.ProtoSuiteResult{ProjectName: "project"}, readBack(t, r, 3))
+	gaugeEnd.Close()
+
+	messages := r.messages(t)
+	if len(messages) != 4 {
+		t.Fatalf("Expected the suite result and 3 items. Got %d messages", len(messages))
+	}
+	got := messages[0].GetSuiteExecutionResult().GetSuiteResult()
+	if !got.Chunked || got.ChunkSize != 3 || len(got.SpecResults) != 3 || got.SpecResults[0].ProtoSpec.ItemCount != 1 || got.SpecResults[0].ProtoSpec.Items != nil {
+		t.Fatalf("Expected the chunked suite result with spec results without items. Got %v", got)
+	}
+	for i, m := range messages[1:] {
+		if m.MessageType != gauge_messages.Message_SuiteExecutionResultItem || m.GetSuiteExecutionResultItem().GetResultItem().FileName != fmt.Sprintf("specs/%d.spec", i) {
+			t.Errorf("Expected the item of spec %d. Got %v", i, m)
+		}
+	}
+}
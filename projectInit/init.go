@@ -42,32 +42,47 @@ func initializeTemplate(templateName string) error {
 	if err != nil {
 		return err
 	}
+	return copyTemplate(filepath.Join(unzippedTemplate, templateName), templateName)
+}
 
+// copyTemplate copies the contents of templateDir to the project root, fills in the placeholders
+// and runs the post install command given in the template's metadata.
+func copyTemplate(templateDir, templateName string) error {
 	wd := config.ProjectRoot
 
 	if common.FileExists(gitignoreFileName) {
-		templateGitIgnore := filepath.Join(unzippedTemplate, templateName, gitignoreFileName)
-		if err := common.AppendToFile(gitignoreFileName, templateGitIgnore); err != nil {
-			return err
+		templateGitIgnore := filepath.Join(templateDir, gitignoreFileName)
+		if common.FileExists(templateGitIgnore) {
+			if err := common.AppendToFile(gitignoreFileName, templateGitIgnore); err != nil {
+				return err
+			}
 		}
 	}
 
 	logger.Infof(true, "Copying Gauge template %s to current directory ...", templateName)
-	filesAdded, err := common.MirrorDir(filepath.Join(unzippedTemplate, templateName), wd)
+	filesAdded, err := mirrorTemplate(templateDir, wd)
 	if err != nil {
 		return fmt.Errorf("Failed to copy Gauge template: %s", err.Error())
 	}
-
-	metadataFile := filepath.Join(wd, metadataFileName)
-	metadataContents, err := common.ReadFileContents(metadataFile)
-	if err != nil {
-		return fmt.Errorf("Failed to read file contents of %s: %s", metadataFile, err.Error())
+	for _, file := range filesAdded {
+		if file != metadataFileName {
+			created(file)
+		}
+	}
+	if err := fillPlaceholders(wd, filesAdded, filepath.Base(wd)); err != nil {
+		return err
 	}
 
 	metadata := &templateMetadata{}
-	err = json.Unmarshal([]byte(metadataContents), metadata)
-	if err != nil {
-		return err
+	metadataFile := filepath.Join(wd, metadataFileName)
+	if common.FileExists(metadataFile) {
+		metadataContents, err := common.ReadFileContents(metadataFile)
+		if err != nil {
+			return fmt.Errorf("Failed to read file contents of %s: %s", metadataFile, err.Error())
+		}
+		if err = json.Unmarshal([]byte(metadataContents), metadata); err != nil {
+			return err
+		}
 	}
 
 	if metadata.PostInstallCmd != "" {
@@ -84,7 +99,7 @@ func initializeTemplate(templateName string) error {
 			return err
 		}
 	}
-	fmt.Printf("Successfully initialized the project. %s\n", metadata.PostInstallMsg)
+	logger.Infof(true, "Successfully initialized the project. %s", metadata.PostInstallMsg)
 
 	util.Remove(metadataFile)
 	return nil
@@ -107,8 +122,7 @@ func isGaugeProject() bool {
 	return m.Language != ""
 }
 
-func installRunner(templateName string, silent bool) {
-	language := getTemplateLanguage(templateName)
+func installRunner(language string, silent bool) {
 	if !install.IsCompatiblePluginInstalled(language, true) {
 		logger.Infof(true, "Compatible language plugin %s is not installed. Installing plugin...", language)
 
@@ -116,8 +130,37 @@ func installRunner(templateName string, silent bool) {
 	}
 }
 
-// InitializeProject initializes a Gauge project with specified template
-func InitializeProject(templateName string, silent bool) {
+func installPlugins(plugins []string, silent bool) {
+	for _, p := range plugins {
+		if !install.IsCompatiblePluginInstalled(p, false) {
+			logger.Infof(true, "Compatible version of plugin %s not found. Installing plugin %s...", p, p)
+			install.HandleInstallResult(install.Plugin(p, "", silent), p, true)
+		}
+	}
+}
+
+// Options specify how a project is initialized, so that it can be done without any prompts.
+type Options struct {
+	// Template is a Gauge template name, a git repository or a local directory.
+	Template string
+	// Language is the language runner of the project. Defaults to the language of the template.
+	Language string
+	// Plugins are registered in the manifest of the project. They replace the default plugins
+	// of a new project and are added to the plugins of a template.
+	Plugins []string
+	// MachineReadable prints what was created in JSON format.
+	MachineReadable bool
+}
+
+func (o Options) language() string {
+	if o.Language != "" {
+		return o.Language
+	}
+	return getTemplateLanguage(o.Template)
+}
+
+// InitializeProject initializes a Gauge project in the current directory as specified by the options.
+func InitializeProject(opts Options) {
 	wd, err := os.Getwd()
 	if err != nil {
 		logger.Fatalf(true, "Failed to find working directory. %s", err.Error())
@@ -126,17 +169,100 @@ func InitializeProject(templateName string, silent bool) {
 	if isGaugeProject() {
 		logger.Fatalf(true, "This is already a Gauge Project. Please try to initialize a Gauge project in a different location.")
 	}
-	exists, _ := common.UrlExists(getTemplateURL(templateName))
-	if exists {
-		err = initializeTemplate(templateName)
-		installRunner(templateName, silent)
+	filesCreated = nil
+	silent := opts.MachineReadable
+	if opts.Template == "" {
+		opts.Template = opts.Language
+	}
+	if isTemplateSource(opts.Template) {
+		err = initializeTemplateFromSource(opts.Template)
+		if err == nil {
+			err = registerPlugins(opts.Plugins)
+		}
+		if err == nil {
+			install.AllPlugins(silent, false)
+		}
+	} else if exists, _ := common.UrlExists(getTemplateURL(opts.Template)); exists {
+		err = initializeTemplate(opts.Template)
+		if err == nil {
+			err = registerPlugins(opts.Plugins)
+		}
+		installRunner(opts.language(), silent)
+		installPlugins(opts.Plugins, silent)
 	} else {
-		installRunner(templateName, silent)
-		err = createProjectTemplate(templateName)
+		if opts.Plugins != nil {
+			defaultPlugins = opts.Plugins
+		}
+		installRunner(opts.language(), silent)
+		err = createProjectTemplate(opts.language())
+		installPlugins(opts.Plugins, silent)
 	}
 	if err != nil {
 		logger.Fatalf(true, "Failed to initialize project. %s", err.Error())
 	}
+	if opts.MachineReadable {
+		printJSONSummary(wd, opts.Template)
+	}
+}
+
+// registerPlugins adds the plugins to the manifest of the project, if not already present.
+func registerPlugins(plugins []string) error {
+	if len(plugins) == 0 {
+		return nil
+	}
+	m, err := manifest.ProjectManifest()
+	if err != nil {
+		return err
+	}
+	for _, p := range plugins {
+		if !contains(m.Plugins, p) {
+			m.Plugins = append(m.Plugins, p)
+		}
+	}
+	return m.Save()
+}
+
+func contains(list []string, item string) bool {
+	for _, l := range list {
+		if l == item {
+			return true
+		}
+	}
+	return false
+}
+
+type initSummary struct {
+	Type      string   `json:"type"`
+	Directory string   `json:"directory"`
+	Template  string   `json:"template"`
+	Language  string   `json:"language"`
+	Plugins   []string `json:"plugins"`
+	Files     []string `json:"files"`
+}
+
+func printJSONSummary(wd, template string) {
+	summary := initSummary{Type: "init", Directory: wd, Template: template, Plugins: []string{}, Files: filesCreated}
+	if m, err := manifest.ProjectManifest(); err == nil {
+		summary.Language = m.Language
+		if m.Plugins != nil {
+			summary.Plugins = m.Plugins
+		}
+	}
+	if summary.Files == nil {
+		summary.Files = []string{}
+	}
+	b, err := json.Marshal(summary)
+	if err != nil {
+		logger.Fatalf(true, "Failed to print the initialized project. %s", err.Error())
+	}
+	fmt.Println(string(b))
+}
+
+// filesCreated holds the files and directories created while initializing the project, relative to the project root.
+var filesCreated []string
+
+func created(path string) {
+	filesCreated = append(filesCreated, filepath.ToSlash(path))
 }
 
 func showMessage(action, filename string) {
@@ -162,7 +288,7 @@ func createProjectTemplate(language string) error {
 	if err := createEnvDirectory(); err != nil {
 		return err
 	}
-	fmt.Println("Successfully initialized the project. Run specifications with \"gauge run specs/\".")
+	logger.Infof(true, "Successfully initialized the project. Run specifications with \"gauge run specs/\".")
 	return nil
 }
 
@@ -172,6 +298,8 @@ func createEnvDirectory() error {
 		err := os.Mkdir(common.EnvDirectoryName, common.NewDirectoryPermissions)
 		if err != nil {
 			showMessage("error", fmt.Sprintf("Failed to create %s. %s", common.EnvDirectoryName, err.Error()))
+		} else {
+			created(common.EnvDirectoryName)
 		}
 	}
 	defaultEnv := filepath.Join(common.EnvDirectoryName, envDefaultDirName)
@@ -180,6 +308,8 @@ func createEnvDirectory() error {
 		err := os.Mkdir(defaultEnv, common.NewDirectoryPermissions)
 		if err != nil {
 			showMessage("error", fmt.Sprintf("Failed to create %s. %s", defaultEnv, err.Error()))
+		} else {
+			created(defaultEnv)
 		}
 	}
 	defaultJSON, err := common.GetSkeletonFilePath(filepath.Join(common.EnvDirectoryName, common.DefaultEnvFileName))
@@ -193,6 +323,7 @@ func createEnvDirectory() error {
 		showMessage("error", fmt.Sprintf("Failed to create %s. %s", defaultJSONDest, err.Error()))
 		return err
 	}
+	created(defaultJSONDest)
 	return nil
 }
 
@@ -208,6 +339,7 @@ func createOrAppendGitignoreFile() error {
 		showMessage("error", err.Error())
 		return err
 	}
+	created(destFile)
 	return nil
 }
 
@@ -227,6 +359,7 @@ func createSkeletonFile() error {
 			showMessage("error", fmt.Sprintf("Failed to create %s. %s", specFile, err.Error()))
 			return err
 		}
+		created(specFile)
 	}
 	return nil
 }
@@ -239,6 +372,7 @@ func createSpecDirectory() error {
 			showMessage("error", fmt.Sprintf("Failed to create %s. %s", specsDirName, err.Error()))
 			return err
 		}
+		created(specsDirName)
 	} else {
 		showMessage("skip", specsDirName)
 	}
@@ -254,5 +388,6 @@ func createManifestFile(language string) error {
 	if err := manifest.Save(); err != nil {
 		return err
 	}
+	created(common.ManifestFile)
 	return nil
 }
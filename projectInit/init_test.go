@@ -18,10 +18,13 @@
 package projectInit
 
 import (
+	"io/ioutil"
 	"path/filepath"
 	"testing"
 
+	"github.com/getgauge/common"
 	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/manifest"
 	. "gopkg.in/check.v1"
 )
 
@@ -54,3 +57,22 @@ func (s *MySuite) TestIfGaugeProjectGivenDirWithNonGaugeManifest(c *C) {
 	config.ProjectRoot = path
 	c.Assert(isGaugeProject(), Equals, false)
 }
+
+func (s *MySuite) TestLanguageDefaultsToTemplateLanguage(c *C) {
+	c.Assert(Options{Template: "java_maven_selenium"}.language(), Equals, "java")
+	c.Assert(Options{Template: "github.com/org/template", Language: "js"}.language(), Equals, "js")
+}
+
+func (s *MySuite) TestRegisterPluginsAddsMissingPluginsToManifest(c *C) {
+	old := config.ProjectRoot
+	defer func() { config.ProjectRoot = old }()
+	config.ProjectRoot = c.MkDir()
+	ioutil.WriteFile(filepath.Join(config.ProjectRoot, common.ManifestFile), []byte(`{"Language": "java", "Plugins": ["html-report"]}`), 0644)
+
+	err := registerPlugins([]string{"html-report", "xml-report"})
+
+	c.Assert(err, IsNil)
+	m, err := manifest.ProjectManifest()
+	c.Assert(err, IsNil)
+	c.Assert(m.Plugins, DeepEquals, []string{"html-report", "xml-report"})
+}
@@ -0,0 +1,148 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package projectInit
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/util"
+)
+
+// projectNamePlaceholder is replaced with the name of the project directory in the files of a template
+// taken from a git repository or a local directory.
+const projectNamePlaceholder = "{{project_name}}"
+
+const gitDirName = ".git"
+
+var gitHostPattern = regexp.MustCompile(`^[a-zA-Z0-9.-]+\.[a-z]+/[^/]+/[^/]+`)
+
+// isGitTemplate returns true if the template is a git repository, like https://github.com/org/repo,
+// git@github.com:org/repo.git or github.com/org/repo.
+func isGitTemplate(template string) bool {
+	return strings.HasPrefix(template, "git@") ||
+		strings.HasPrefix(template, "https://") ||
+		strings.HasPrefix(template, "http://") ||
+		strings.HasPrefix(template, "ssh://") ||
+		strings.HasPrefix(template, "file://") ||
+		strings.HasSuffix(template, ".git") ||
+		gitHostPattern.MatchString(template)
+}
+
+// isTemplateSource returns true if the template is a git repository or a path to a local directory
+// instead of a template name.
+func isTemplateSource(template string) bool {
+	if isGitTemplate(template) {
+		return true
+	}
+	isPath := strings.HasPrefix(template, ".") || strings.ContainsAny(template, `/\`)
+	return isPath && common.DirExists(template)
+}
+
+// gitCloneURL returns the url to clone a git template from. A template without a scheme, like github.com/org/repo,
+// is cloned over https.
+func gitCloneURL(template string) string {
+	if gitHostPattern.MatchString(template) {
+		return "https://" + template
+	}
+	return template
+}
+
+func initializeTemplateFromSource(source string) error {
+	templateDir := source
+	if isGitTemplate(source) {
+		tempDir := common.GetTempDir()
+		defer util.Remove(tempDir)
+		templateDir = filepath.Join(tempDir, "template")
+		if err := cloneTemplate(gitCloneURL(source), templateDir); err != nil {
+			return err
+		}
+	}
+	if !common.FileExists(filepath.Join(templateDir, common.ManifestFile)) {
+		return fmt.Errorf("%s is not a Gauge template. %s not found in it", source, common.ManifestFile)
+	}
+	return copyTemplate(templateDir, source)
+}
+
+func cloneTemplate(url, dir string) error {
+	logger.Infof(true, "Cloning Gauge template from %s ...", url)
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", "clone", "--depth", "1", url, dir)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("Failed to clone template %s: %s %s", url, err.Error(), strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// mirrorTemplate copies all the files of the template to dst, leaving out the git metadata of the template repository.
+func mirrorTemplate(src, dst string) ([]string, error) {
+	var filesAdded []string
+	err := filepath.Walk(src, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			if fi.Name() == gitDirName {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if err := common.MirrorFile(path, filepath.Join(dst, rel)); err != nil {
+			return err
+		}
+		filesAdded = append(filesAdded, rel)
+		return nil
+	})
+	return filesAdded, err
+}
+
+// fillPlaceholders replaces the project name placeholder in the given files, relative to dir.
+func fillPlaceholders(dir string, files []string, projectName string) error {
+	for _, file := range files {
+		path := filepath.Join(dir, file)
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if !bytes.Contains(contents, []byte(projectNamePlaceholder)) {
+			continue
+		}
+		fi, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		contents = bytes.Replace(contents, []byte(projectNamePlaceholder), []byte(projectName), -1)
+		if err := ioutil.WriteFile(path, contents, fi.Mode()); err != nil {
+			return fmt.Errorf("Failed to fill placeholders in %s: %s", file, err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package projectInit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestIsGitTemplate(c *C) {
+	c.Assert(isGitTemplate("github.com/org/gauge-template-java-selenium"), Equals, true)
+	c.Assert(isGitTemplate("https://github.com/org/gauge-template-java-selenium"), Equals, true)
+	c.Assert(isGitTemplate("git@github.com:org/gauge-template-java-selenium.git"), Equals, true)
+	c.Assert(isGitTemplate("java"), Equals, false)
+	c.Assert(isGitTemplate("java_maven_selenium"), Equals, false)
+}
+
+func (s *MySuite) TestGitCloneURL(c *C) {
+	c.Assert(gitCloneURL("github.com/org/template"), Equals, "https://github.com/org/template")
+	c.Assert(gitCloneURL("git@github.com:org/template.git"), Equals, "git@github.com:org/template.git")
+	c.Assert(gitCloneURL("https://example.com/org/template"), Equals, "https://example.com/org/template")
+}
+
+func (s *MySuite) TestIsTemplateSourceGivenLocalDirectory(c *C) {
+	c.Assert(isTemplateSource(filepath.Join("_testdata", "gaugeProject")), Equals, true)
+	c.Assert(isTemplateSource(filepath.Join("_testdata", "unknown")), Equals, false)
+	c.Assert(isTemplateSource("_testdata"), Equals, false)
+}
+
+func (s *MySuite) TestMirrorTemplateLeavesOutGitMetadataAndFillsPlaceholders(c *C) {
+	src := c.MkDir()
+	dst := filepath.Join(c.MkDir(), "my-project")
+	os.MkdirAll(filepath.Join(src, ".git"), 0755)
+	os.MkdirAll(filepath.Join(src, "specs"), 0755)
+	ioutil.WriteFile(filepath.Join(src, ".git", "HEAD"), []byte("ref: refs/heads/master"), 0644)
+	ioutil.WriteFile(filepath.Join(src, "specs", "example.spec"), []byte("# {{project_name}} specification"), 0644)
+
+	files, err := mirrorTemplate(src, dst)
+	c.Assert(err, IsNil)
+	c.Assert(files, DeepEquals, []string{filepath.Join("specs", "example.spec")})
+
+	err = fillPlaceholders(dst, files, filepath.Base(dst))
+	c.Assert(err, IsNil)
+	contents, _ := ioutil.ReadFile(filepath.Join(dst, "specs", "example.spec"))
+	c.Assert(string(contents), Equals, "# my-project specification")
+	_, err = os.Stat(filepath.Join(dst, ".git"))
+	c.Assert(os.IsNotExist(err), Equals, true)
+}
@@ -16,14 +16,14 @@
 // along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
 
 /*
-   Given old and new step gives the filenames of specification, concepts and files in code changed.
-
-   Refactoring Flow:
-	- Refactor specs and concepts in memory
-	- Checks if it is a concept or not
-	- In case of concept - writes to file and skips the runner
-	- If its not a concept (its a step) - need to know the text, so makes a call to runner to get the text(step name)
-	- Refactors the text(changes param positions ect) and sends it to runner to refactor implementations.
+	   Given old and new step gives the filenames of specification, concepts and files in code changed.
+
+	   Refactoring Flow:
+		- Refactor specs and concepts in memory
+		- Checks if it is a concept or not
+		- In case of concept - writes to file and skips the runner
+		- If its not a concept (its a step) - need to know the text, so makes a call to runner to get the text(step name)
+		- Refactors the text(changes param positions ect) and sends it to runner to refactor implementations.
 */
 package refactor
 
@@ -49,6 +49,10 @@ type rephraseRefactorer struct {
 	newStep   *gauge.Step
 	isConcept bool
 	runner    runner.Runner
+	// aliases of the step implementation that have to be rephrased along with the step, since they share its parameters.
+	aliases []*rephraseRefactorer
+	// orderMap is set for aliases, whose parameters are reordered in the same way as the step they are aliased with.
+	orderMap map[int]int
 }
 
 type refactoringResult struct {
@@ -221,6 +225,7 @@ func (agent *rephraseRefactorer) performRefactoringOn(specs []*gauge.Specificati
 	if !result.Success {
 		return result
 	}
+	agent.rephraseAliasesInSpecsAndConcepts(&specs, conceptDictionary, specsRefactored, conceptFilesRefactored)
 	result.SpecsChanged, result.ConceptsChanged = getFileChanges(specs, conceptDictionary, specsRefactored, conceptFilesRefactored)
 	writeFileChangesToDisk(result)
 	return result
@@ -241,6 +246,7 @@ func (agent *rephraseRefactorer) getRefactoringChangesFor(specs []*gauge.Specifi
 	if !result.Success {
 		return result
 	}
+	agent.rephraseAliasesInSpecsAndConcepts(&specs, conceptDictionary, specsRefactored, conceptFilesRefactored)
 	result.SpecsChanged, result.ConceptsChanged = getFileChanges(specs, conceptDictionary, specsRefactored, conceptFilesRefactored)
 	return result
 }
@@ -260,6 +266,14 @@ func (agent *rephraseRefactorer) refactorStepImplementations(shouldSaveChanges b
 				return result
 			}
 			result.RunnerFilesChanged = runnerFilesChanged
+			for _, alias := range agent.aliases {
+				aliasFilesChanged, err := alias.requestRunnerForRefactoring(agent.runner, alias.oldStep.LineText, shouldSaveChanges)
+				if err != nil {
+					result.Errors = append(result.Errors, fmt.Sprintf("Cannot perform refactoring of alias '%s': %s", alias.oldStep.LineText, err))
+					return result
+				}
+				result.RunnerFilesChanged = append(result.RunnerFilesChanged, aliasFilesChanged...)
+			}
 		} else {
 			result.Warnings = append(result.Warnings, warning.Message)
 		}
@@ -294,7 +308,22 @@ func (agent *rephraseRefactorer) rephraseInSpecsAndConcepts(specs *[]*gauge.Spec
 	return specsRefactored, conceptsRefactored
 }
 
+func (agent *rephraseRefactorer) rephraseAliasesInSpecsAndConcepts(specs *[]*gauge.Specification, conceptDictionary *gauge.ConceptDictionary, specsRefactored map[*gauge.Specification][]*gauge.StepDiff, conceptsRefactored map[string][]*gauge.StepDiff) {
+	for _, alias := range agent.aliases {
+		aliasSpecs, aliasConcepts := alias.rephraseInSpecsAndConcepts(specs, conceptDictionary)
+		for spec, diffs := range aliasSpecs {
+			specsRefactored[spec] = append(specsRefactored[spec], diffs...)
+		}
+		for file, diffs := range aliasConcepts {
+			conceptsRefactored[file] = append(conceptsRefactored[file], diffs...)
+		}
+	}
+}
+
 func (agent *rephraseRefactorer) createOrderOfArgs() map[int]int {
+	if agent.orderMap != nil {
+		return agent.orderMap
+	}
 	orderMap := make(map[int]int, len(agent.newStep.Args))
 	for i, arg := range agent.newStep.Args {
 		orderMap[i] = SliceIndex(len(agent.oldStep.Args), func(i int) bool { return agent.oldStep.Args[i].String() == arg.String() })
@@ -357,7 +386,7 @@ func (agent *rephraseRefactorer) sendRefactorRequest(testRunner runner.Runner, r
 	return response.GetRefactorResponse()
 }
 
-//Todo: Check for inline tables
+// Todo: Check for inline tables
 func (agent *rephraseRefactorer) createRefactorRequest(runner runner.Runner, stepName string, shouldSaveChanges bool) (*gauge_messages.Message, error) {
 	oldStepValue, err := agent.getStepValueFor(agent.oldStep, stepName)
 	if err != nil {
@@ -375,7 +404,7 @@ func (agent *rephraseRefactorer) createRefactorRequest(runner runner.Runner, ste
 		RefactorRequest: &gauge_messages.RefactorRequest{
 			OldStepValue:   oldProtoStepValue,
 			NewStepValue:   newProtoStepValue,
-			ParamPositions: agent.createParameterPositions(orderMap),
+			ParamPositions: agent.createParameterPositions(agent.parameterOrder(orderMap)),
 			SaveChanges:    shouldSaveChanges,
 		},
 	}, nil
@@ -405,11 +434,107 @@ func (agent *rephraseRefactorer) getStepNameFromRunner(runner runner.Runner) (st
 		return "", nil, &parser.Warning{Message: fmt.Sprintf("Step implementation not found: %s", agent.oldStep.LineText)}
 	}
 	if responseMessage.GetStepNameResponse().GetHasAlias() {
-		return "", fmt.Errorf("steps with aliases : '%s' cannot be refactored", strings.Join(responseMessage.GetStepNameResponse().GetStepName(), "', '")), nil
+		return agent.stepNameFromAliases(responseMessage.GetStepNameResponse().GetStepName())
 	}
 	return responseMessage.GetStepNameResponse().GetStepName()[0], nil, nil
 }
 
+// stepNameFromAliases gives the alias being rephrased. If the parameters of the step change,
+// the other aliases of the implementation are rephrased as well so that they keep matching it.
+func (agent *rephraseRefactorer) stepNameFromAliases(aliases []string) (string, error, *parser.Warning) {
+	stepName := ""
+	var others []string
+	for _, alias := range aliases {
+		value, err := parser.ExtractStepValueAndParams(alias, false)
+		if err != nil {
+			return "", err, nil
+		}
+		if value.StepValue == agent.oldStep.Value && stepName == "" {
+			stepName = alias
+			continue
+		}
+		if value.StepValue == agent.newStep.Value {
+			return "", fmt.Errorf("Cannot refactor to '%s', it is already an alias of the same implementation: '%s'", agent.newStep.LineText, alias), nil
+		}
+		others = append(others, alias)
+	}
+	if stepName == "" {
+		return "", fmt.Errorf("none of the aliases : '%s' match the step '%s'", strings.Join(aliases, "', '"), agent.oldStep.LineText), nil
+	}
+	agent.aliases = nil
+	orderMap := agent.createOrderOfArgs()
+	if !parametersChanged(len(agent.oldStep.Args), orderMap) {
+		return stepName, nil, nil
+	}
+	for _, alias := range others {
+		aliasAgent, errs := getRefactorAgent(alias, agent.aliasWithNewParameters(alias), agent.runner)
+		if len(errs) > 0 {
+			return "", fmt.Errorf("Cannot refactor alias '%s': %s", alias, errs[0].Error()), nil
+		}
+		aliasAgent.orderMap = orderMap
+		agent.aliases = append(agent.aliases, aliasAgent)
+	}
+	return stepName, nil, nil
+}
+
+// aliasWithNewParameters replaces the parameters of the alias with those of the new step, positionally.
+// Parameters that were removed are dropped and the ones that were added are appended to the alias.
+func (agent *rephraseRefactorer) aliasWithNewParameters(alias string) string {
+	aliasAgent, errs := getRefactorAgent(alias, alias, nil)
+	if len(errs) > 0 {
+		return alias
+	}
+	aliasAgent.oldStep.PopulateFragments()
+	agent.newStep.PopulateFragments()
+	var params []*gauge_messages.Fragment
+	for _, fragment := range agent.newStep.Fragments {
+		if fragment.GetFragmentType() == gauge_messages.Fragment_Parameter {
+			params = append(params, fragment)
+		}
+	}
+	var fragments []*gauge_messages.Fragment
+	paramIndex := 0
+	for _, fragment := range aliasAgent.oldStep.Fragments {
+		if fragment.GetFragmentType() != gauge_messages.Fragment_Parameter {
+			fragments = append(fragments, fragment)
+			continue
+		}
+		if paramIndex < len(params) {
+			fragments = append(fragments, params[paramIndex])
+		}
+		paramIndex++
+	}
+	for ; paramIndex < len(params); paramIndex++ {
+		fragments = append(fragments, &gauge_messages.Fragment{FragmentType: gauge_messages.Fragment_Text, Text: " "}, params[paramIndex])
+	}
+	return strings.Join(strings.Fields(parser.ConvertToStepText(fragments)), " ")
+}
+
+// parameterOrder gives the parameter positions to be sent to the runner. Implementation parameters are reordered
+// along with the step itself, so the aliases refactored after it keep them as they are.
+func (agent *rephraseRefactorer) parameterOrder(orderMap map[int]int) map[int]int {
+	if agent.orderMap == nil {
+		return orderMap
+	}
+	unchanged := make(map[int]int, len(orderMap))
+	for k := range orderMap {
+		unchanged[k] = k
+	}
+	return unchanged
+}
+
+func parametersChanged(oldArgsCount int, orderMap map[int]int) bool {
+	if oldArgsCount != len(orderMap) {
+		return true
+	}
+	for k, v := range orderMap {
+		if k != v {
+			return true
+		}
+	}
+	return false
+}
+
 func (agent *rephraseRefactorer) createParameterPositions(orderMap map[int]int) []*gauge_messages.ParameterPosition {
 	paramPositions := make([]*gauge_messages.ParameterPosition, 0)
 	for k, v := range orderMap {
@@ -229,7 +229,7 @@ func (s *MySuite) TestCreateOrderGivesMapOfOldArgsAndNewArgs(c *C) {
 	step1 := &gauge.Step{Args: []*gauge.StepArg{&gauge.StepArg{Name: "a"}, &gauge.StepArg{Name: "b"}, &gauge.StepArg{Name: "c"}, &gauge.StepArg{Name: "d"}}}
 	step2 := &gauge.Step{Args: []*gauge.StepArg{&gauge.StepArg{Name: "d"}, &gauge.StepArg{Name: "b"}, &gauge.StepArg{Name: "c"}, &gauge.StepArg{Name: "a"}}}
 
-	agent := &rephraseRefactorer{step1, step2, false, nil}
+	agent := &rephraseRefactorer{oldStep: step1, newStep: step2}
 	orderMap := agent.createOrderOfArgs()
 
 	c.Assert(orderMap[0], Equals, 3)
@@ -241,7 +241,7 @@ func (s *MySuite) TestCreateOrderGivesMapOfOldArgsAndNewWhenArgsAreAdded(c *C) {
 	step1 := &gauge.Step{Args: []*gauge.StepArg{&gauge.StepArg{Name: "a"}, &gauge.StepArg{Name: "b"}, &gauge.StepArg{Name: "c"}, &gauge.StepArg{Name: "d"}}}
 	step2 := &gauge.Step{Args: []*gauge.StepArg{&gauge.StepArg{Name: "d"}, &gauge.StepArg{Name: "e"}, &gauge.StepArg{Name: "b"}, &gauge.StepArg{Name: "c"}, &gauge.StepArg{Name: "a"}}}
 
-	agent := &rephraseRefactorer{step1, step2, false, nil}
+	agent := &rephraseRefactorer{oldStep: step1, newStep: step2}
 	orderMap := agent.createOrderOfArgs()
 
 	c.Assert(orderMap[0], Equals, 3)
@@ -255,7 +255,7 @@ func (s *MySuite) TestCreateOrderGivesMapOfOldArgsAndNewWhenArgsAreRemoved(c *C)
 	step1 := &gauge.Step{Args: []*gauge.StepArg{&gauge.StepArg{Name: "a"}, &gauge.StepArg{Name: "b"}, &gauge.StepArg{Name: "c"}, &gauge.StepArg{Name: "d"}}}
 	step2 := &gauge.Step{Args: []*gauge.StepArg{&gauge.StepArg{Name: "d"}, &gauge.StepArg{Name: "b"}, &gauge.StepArg{Name: "c"}}}
 
-	agent := &rephraseRefactorer{step1, step2, false, nil}
+	agent := &rephraseRefactorer{oldStep: step1, newStep: step2}
 	orderMap := agent.createOrderOfArgs()
 
 	c.Assert(orderMap[0], Equals, 3)
@@ -495,3 +495,59 @@ func (s *MySuite) TestRefactoringInTearDownStep(c *C) {
 	c.Assert(specs[0].TearDownSteps[0].Args[2].Value, Equals, "number")
 	c.Assert(specs[0].TearDownSteps[0].Args[3].Value, Equals, "name")
 }
+
+func (s *MySuite) TestStepNameFromAliasesGivesTheAliasBeingRephrased(c *C) {
+	agent, _ := getRefactorAgent("pay <a> to <b>", "transfer <a> to <b>", nil)
+
+	stepName, err, warning := agent.stepNameFromAliases([]string{"send <x> to <y>", "pay <amount> to <name>"})
+
+	c.Assert(err, IsNil)
+	c.Assert(warning, IsNil)
+	c.Assert(stepName, Equals, "pay <amount> to <name>")
+	c.Assert(len(agent.aliases), Equals, 0)
+}
+
+func (s *MySuite) TestStepNameFromAliasesFailsWhenNewStepIsAnotherAlias(c *C) {
+	agent, _ := getRefactorAgent("pay <a> to <b>", "send <a> to <b>", nil)
+
+	_, err, _ := agent.stepNameFromAliases([]string{"send <x> to <y>", "pay <amount> to <name>"})
+
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Equals, "Cannot refactor to 'send <a> to <b>', it is already an alias of the same implementation: 'send <x> to <y>'")
+}
+
+func (s *MySuite) TestRephrasingAliasedStepWithReorderedParametersRephrasesOtherAliases(c *C) {
+	tokens := []*parser.Token{
+		&parser.Token{Kind: gauge.SpecKind, Value: "Spec Heading", LineNo: 1},
+		&parser.Token{Kind: gauge.ScenarioKind, Value: "Scenario Heading", LineNo: 2},
+		&parser.Token{Kind: gauge.StepKind, Value: "pay {static} to {static}", LineNo: 3, Args: []string{"10", "john"}},
+		&parser.Token{Kind: gauge.StepKind, Value: "send {static} to {static}", LineNo: 4, Args: []string{"20", "jane"}},
+	}
+	spec, _, _ := new(parser.SpecParser).CreateSpecification(tokens, gauge.NewConceptDictionary(), "")
+	specs := []*gauge.Specification{spec}
+	dictionary := gauge.NewConceptDictionary()
+	agent, _ := getRefactorAgent("pay <a> to <b>", "pay <b> from <a> now <c>", nil)
+	specsRefactored, conceptsRefactored := agent.rephraseInSpecsAndConcepts(&specs, dictionary)
+
+	_, err, _ := agent.stepNameFromAliases([]string{"pay <a> to <b>", "send <x> to <y>"})
+	c.Assert(err, IsNil)
+	c.Assert(len(agent.aliases), Equals, 1)
+	c.Assert(agent.aliases[0].newStep.LineText, Equals, "send <b> to <a> <c>")
+
+	agent.rephraseAliasesInSpecsAndConcepts(&specs, dictionary, specsRefactored, conceptsRefactored)
+
+	steps := specs[0].Scenarios[0].Steps
+	c.Assert(steps[0].Value, Equals, "pay {} from {} now {}")
+	c.Assert(steps[1].Value, Equals, "send {} to {} {}")
+	c.Assert(steps[1].Args[0].Value, Equals, "jane")
+	c.Assert(steps[1].Args[1].Value, Equals, "20")
+	c.Assert(len(specsRefactored[spec]), Equals, 2)
+}
+
+func (s *MySuite) TestParameterOrderOfAliasKeepsImplementationParameters(c *C) {
+	orderMap := map[int]int{0: 1, 1: 0}
+	agent, _ := getRefactorAgent("send <x> to <y>", "send <b> to <a>", nil)
+	agent.orderMap = orderMap
+
+	c.Assert(agent.parameterOrder(orderMap), DeepEquals, map[int]int{0: 0, 1: 1})
+}
@@ -0,0 +1,122 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/util"
+)
+
+var (
+	azureMessageEscaper  = strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A")
+	azurePropertyEscaper = strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A", ";", "%3B", "]", "%5D")
+)
+
+// azureReporter emits Azure Pipelines logging commands. Failed scenarios and validation errors are logged as issues,
+// which are shown in the summary of the run, and the progress of the task is updated after every scenario.
+type azureReporter struct {
+	writer io.Writer
+	// failures holds the first failure of the scenario currently executing in each stream
+	failures map[int]string
+	executed int
+}
+
+func newAzureReporter(out io.Writer) *azureReporter {
+	return &azureReporter{writer: out, failures: make(map[int]string)}
+}
+
+func (a *azureReporter) handle(e event.ExecutionEvent) {
+	switch e.Topic {
+	case event.ScenarioStart:
+		delete(a.failures, e.Stream)
+	case event.StepEnd:
+		stepRes := e.Result.(*result.StepResult)
+		if _, ok := a.failures[e.Stream]; ok || !stepRes.GetFailed() {
+			return
+		}
+		if stepRes.GetStepFailed() {
+			a.failures[e.Stream] = fmt.Sprintf("Failed Step: %s => %s", e.Item.(gauge.Step).LineText, stepRes.GetErrorMessage())
+		} else if f := hookFailures(stepRes); len(f) > 0 {
+			a.failures[e.Stream] = firstLine(f[0])
+		}
+	case event.ScenarioEnd:
+		sce := e.Item.(*gauge.Scenario)
+		sceRes := e.Result.(*result.ScenarioResult)
+		if sceRes.GetFailed() {
+			msg := fmt.Sprintf("Scenario '%s' failed", sce.Heading.Value)
+			if f, ok := a.failures[e.Stream]; ok {
+				msg += ". " + f
+			} else if f := hookFailures(sceRes); len(f) > 0 {
+				msg += ". " + firstLine(f[0])
+			}
+			a.logIssue(e.ExecutionInfo.GetCurrentSpec().GetFileName(), sce.Heading.LineNo, msg)
+		}
+		delete(a.failures, e.Stream)
+		a.executed++
+		a.progress()
+	case event.SpecEnd:
+		spec := e.Item.(*gauge.Specification)
+		for _, f := range hookFailures(e.Result) {
+			a.logIssue(spec.FileName, 0, fmt.Sprintf("Specification '%s' failed. %s", spec.Heading.Value, firstLine(f)))
+		}
+	case event.SuiteEnd:
+		for _, f := range hookFailures(e.Result) {
+			a.logIssue("", 0, firstLine(f))
+		}
+		for _, err := range e.Result.(*result.SuiteResult).UnhandledErrors {
+			a.logIssue("", 0, err.Error())
+		}
+	}
+}
+
+func (a *azureReporter) validationError(fileName string, lineNo int, message string) {
+	a.logIssue(fileName, lineNo, message)
+}
+
+func (a *azureReporter) progress() {
+	if ExpectedScenarios <= 0 {
+		return
+	}
+	percent := a.executed * 100 / ExpectedScenarios
+	if percent > 100 {
+		percent = 100
+	}
+	fmt.Fprintf(a.writer, "##vso[task.setprogress value=%d;]Executed %d of %d scenarios%s", percent, a.executed, ExpectedScenarios, newline)
+}
+
+// logIssue logs an error issue. The source file and line number are omitted if not known.
+func (a *azureReporter) logIssue(fileName string, lineNo int, message string) {
+	properties := "type=error;"
+	if fileName != "" {
+		properties += fmt.Sprintf("sourcepath=%s;", azurePropertyEscaper.Replace(util.RelPathToProjectRoot(fileName)))
+		if lineNo > 0 {
+			properties += fmt.Sprintf("linenumber=%d;", lineNo)
+		}
+	}
+	fmt.Fprintf(a.writer, "##vso[task.logissue %s]%s%s", properties, azureMessageEscaper.Replace(message), newline)
+}
+
+func firstLine(s string) string {
+	return strings.SplitN(s, newline, 2)[0]
+}
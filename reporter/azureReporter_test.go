@@ -0,0 +1,72 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package reporter
+
+import (
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestAzureReporterLogsIssueForFailedScenario(c *C) {
+	ExpectedScenarios = 2
+	defer func() { ExpectedScenarios = 0 }()
+	dw := newDummyWriter()
+	a := newAzureReporter(dw)
+	info := gauge_messages.ExecutionInfo{CurrentSpec: &gauge_messages.SpecInfo{FileName: "foo.spec"}}
+	sce := &gauge.Scenario{Heading: &gauge.Heading{Value: "scenario", LineNo: 4}}
+	sceRes := result.NewScenarioResult(&gauge_messages.ProtoScenario{ExecutionStatus: gauge_messages.ExecutionStatus_FAILED})
+	stepRes := result.NewStepResult(&gauge_messages.ProtoStep{StepExecutionResult: &gauge_messages.ProtoStepExecutionResult{ExecutionResult: &gauge_messages.ProtoExecutionResult{Failed: true, ErrorMessage: "100% wrong\nat foo"}}})
+	stepRes.SetStepFailure()
+
+	a.handle(event.NewExecutionEvent(event.ScenarioStart, sce, sceRes, 0, info))
+	a.handle(event.NewExecutionEvent(event.StepEnd, gauge.Step{LineText: "a step"}, stepRes, 0, info))
+	a.handle(event.NewExecutionEvent(event.ScenarioEnd, sce, sceRes, 0, info))
+
+	want := `##vso[task.logissue type=error;sourcepath=foo.spec;linenumber=4;]Scenario 'scenario' failed. Failed Step: a step => 100%AZP25 wrong%0Aat foo
+##vso[task.setprogress value=50;]Executed 1 of 2 scenarios
+`
+	c.Assert(dw.output, Equals, want)
+}
+
+func (s *MySuite) TestAzureReporterDoesNotLogIssueForPassedScenario(c *C) {
+	dw := newDummyWriter()
+	a := newAzureReporter(dw)
+	sce := &gauge.Scenario{Heading: &gauge.Heading{Value: "scenario"}}
+	sceRes := result.NewScenarioResult(&gauge_messages.ProtoScenario{ExecutionStatus: gauge_messages.ExecutionStatus_PASSED})
+
+	a.handle(event.NewExecutionEvent(event.ScenarioStart, sce, sceRes, 0, gauge_messages.ExecutionInfo{}))
+	a.handle(event.NewExecutionEvent(event.ScenarioEnd, sce, sceRes, 0, gauge_messages.ExecutionInfo{}))
+
+	c.Assert(dw.output, Equals, "")
+}
+
+func (s *MySuite) TestAzureReporterLogsValidationError(c *C) {
+	dw := newDummyWriter()
+	a := newAzureReporter(dw)
+
+	a.validationError("foo.spec", 7, "Step implementation not found => 'a step'")
+	a.validationError("bar.spec", 0, "Spec has no heading")
+
+	want := `##vso[task.logissue type=error;sourcepath=foo.spec;linenumber=7;]Step implementation not found => 'a step'
+##vso[task.logissue type=error;sourcepath=bar.spec;]Spec has no heading
+`
+	c.Assert(dw.output, Equals, want)
+}
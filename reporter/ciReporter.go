@@ -0,0 +1,87 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package reporter
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"github.com/getgauge/gauge/execution/event"
+)
+
+// CIFormat is the format of the messages emitted for CI servers, in addition to the console reporting.
+var CIFormat string
+
+const (
+	// TeamCityFormat emits TeamCity service messages, so that TeamCity can track the scenarios as tests.
+	TeamCityFormat = "teamcity"
+	// AzureFormat emits Azure Pipelines logging commands, so that the failures are shown in the run summary.
+	AzureFormat = "azure"
+)
+
+// IsValidCIFormat checks if the given CI format is supported.
+func IsValidCIFormat(format string) bool {
+	return format == "" || format == TeamCityFormat || format == AzureFormat
+}
+
+// ciReporter translates the execution events to the messages understood by a CI server.
+type ciReporter interface {
+	handle(event.ExecutionEvent)
+	validationError(fileName string, lineNo int, message string)
+}
+
+func newCIReporter(format string, out io.Writer) ciReporter {
+	switch format {
+	case TeamCityFormat:
+		return newTeamCityReporter(out)
+	case AzureFormat:
+		return newAzureReporter(out)
+	}
+	return nil
+}
+
+// ValidationError reports the validation error to the CI server given by CIFormat, if any.
+// A lineNo of 0 means the error is not specific to a line in the file.
+func ValidationError(fileName string, lineNo int, message string) {
+	if r := newCIReporter(CIFormat, os.Stdout); r != nil {
+		r.validationError(fileName, lineNo, message)
+	}
+}
+
+// ListenCIEvents listens to the execution events and emits the messages for the CI server given by CIFormat.
+func ListenCIEvents(wg *sync.WaitGroup) {
+	r := newCIReporter(CIFormat, os.Stdout)
+	if r == nil {
+		return
+	}
+	ch := make(chan event.ExecutionEvent, 0)
+	event.Register(ch, event.SpecStart, event.SpecEnd, event.ScenarioStart, event.ScenarioEnd, event.StepEnd, event.SuiteEnd)
+	wg.Add(1)
+
+	go func() {
+		defer recoverPanic()
+		for {
+			e := <-ch
+			r.handle(e)
+			if e.Topic == event.SuiteEnd {
+				wg.Done()
+			}
+		}
+	}()
+}
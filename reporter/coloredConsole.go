@@ -50,7 +50,7 @@ func (c *coloredConsole) SpecStart(spec *gauge.Specification, res result.Result)
 	}
 	msg := formatSpec(spec.Heading.Value)
 	logger.Info(false, msg)
-	c.displayMessage(msg+newline, ct.Cyan)
+	c.displayMessage(msg+newline, currentTheme().spec)
 	c.writer.Reset()
 }
 
@@ -73,7 +73,7 @@ func (c *coloredConsole) ScenarioStart(scenario *gauge.Scenario, i gauge_message
 	logger.Info(false, msg)
 
 	indentedText := indent(msg+"\t", c.indentation)
-	c.displayMessage(indentedText, ct.Yellow)
+	c.displayMessage(indentedText, currentTheme().scenario)
 }
 
 func (c *coloredConsole) ScenarioEnd(scenario *gauge.Scenario, res result.Result, i gauge_messages.ExecutionInfo) {
@@ -82,7 +82,7 @@ func (c *coloredConsole) ScenarioEnd(scenario *gauge.Scenario, res result.Result
 	}
 	if printHookFailureCC(c, res, res.GetPreHook) {
 		if c.sceFailuresBuf.Len() != 0 {
-			c.displayMessage(newline+strings.Trim(c.sceFailuresBuf.String(), newline)+newline, ct.Red)
+			c.displayMessage(newline+strings.Trim(c.sceFailuresBuf.String(), newline)+newline, currentTheme().failure)
 		} else {
 			c.displayMessage(newline, ct.None)
 		}
@@ -103,9 +103,9 @@ func (c *coloredConsole) StepEnd(step gauge.Step, res result.Result, execInfo ga
 	stepRes := res.(*result.StepResult)
 	if !(hookFailed(res.GetPreHook) || hookFailed(res.GetPostHook)) {
 		if stepRes.GetStepFailed() {
-			c.displayMessage(getFailureSymbol(), ct.Red)
+			c.displayMessage(getFailureSymbol(), currentTheme().failure)
 		} else {
-			c.displayMessage(getSuccessSymbol(), ct.Green)
+			c.displayMessage(getSuccessSymbol(), currentTheme().success)
 		}
 	}
 	if printHookFailureCC(c, res, res.GetPreHook) && stepRes.GetStepFailed() {
@@ -140,13 +140,13 @@ func (c *coloredConsole) SuiteEnd(res result.Result) {
 	printHookFailureCC(c, res, res.GetPostHook)
 	for _, e := range suiteRes.UnhandledErrors {
 		logger.Error(false, e.Error())
-		c.displayMessage(indent(e.Error(), c.indentation+errorIndentation)+newline, ct.Red)
+		c.displayMessage(indent(e.Error(), c.indentation+errorIndentation)+newline, currentTheme().failure)
 	}
 }
 
 func (c *coloredConsole) DataTable(table string) {
 	logger.Debug(false, table)
-	c.displayMessage(table, ct.Yellow)
+	c.displayMessage(table, currentTheme().table)
 	c.writer.Reset()
 }
 
@@ -154,7 +154,7 @@ func (c *coloredConsole) Errorf(text string, args ...interface{}) {
 	msg := fmt.Sprintf(text, args...)
 	logger.Error(false, msg)
 	msg = indent(msg, c.indentation+errorIndentation) + newline
-	c.displayMessage(msg, ct.Red)
+	c.displayMessage(msg, currentTheme().failure)
 }
 
 // Write writes the bytes to console via goterminal's writer.
@@ -166,8 +166,9 @@ func (c *coloredConsole) Write(b []byte) (int, error) {
 }
 
 func (c *coloredConsole) displayMessage(msg string, color ct.Color) {
-	ct.Foreground(color, false)
-	defer ct.ResetColor()
+	if setColor(color) {
+		defer ct.ResetColor()
+	}
 	fmt.Fprint(c.writer, msg)
 	c.writer.Print()
 }
@@ -178,7 +179,7 @@ func printHookFailureCC(c *coloredConsole, res result.Result, hookFailure func()
 		logger.Error(false, errMsg)
 		stacktrace := prepStacktrace(hookFailure()[0].GetStackTrace())
 		logger.Error(false, stacktrace)
-		c.displayMessage(newline+formatErrorFragment(errMsg, c.indentation)+formatErrorFragment(stacktrace, c.indentation), ct.Red)
+		c.displayMessage(newline+formatErrorFragment(errMsg, c.indentation)+formatErrorFragment(stacktrace, c.indentation), currentTheme().failure)
 		return false
 	}
 	return true
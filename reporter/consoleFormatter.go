@@ -32,6 +32,7 @@ const (
 	failureSymbol       = "✘"
 	successChar         = "P"
 	failureChar         = "F"
+	maxParameterLength  = 80
 )
 
 func formatScenario(scenarioHeading string) string {
@@ -54,17 +55,27 @@ func spaces(numOfSpaces int) string {
 }
 
 func getFailureSymbol() string {
-	if util.IsWindows() {
-		return spaces(1) + failureChar
-	}
-	return spaces(1) + failureSymbol
+	return symbol(currentTheme().failureSymbol, failureChar)
 }
 
 func getSuccessSymbol() string {
-	if util.IsWindows() {
-		return spaces(1) + successChar
+	return symbol(currentTheme().successSymbol, successChar)
+}
+
+// truncateParameter shortens a parameter value to a single line, so that large values like file contents
+// do not flood the console.
+func truncateParameter(value string) string {
+	truncated := false
+	if i := strings.IndexAny(value, "\r\n"); i != -1 {
+		value, truncated = value[:i], true
+	}
+	if runes := []rune(value); len(runes) > maxParameterLength {
+		value, truncated = string(runes[:maxParameterLength]), true
+	}
+	if truncated {
+		return value + "..."
 	}
-	return spaces(1) + successSymbol
+	return value
 }
 
 func prepErrorMessage(msg string) string {
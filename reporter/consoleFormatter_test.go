@@ -18,6 +18,7 @@
 package reporter
 
 import (
+	"strings"
 	"testing"
 
 	. "gopkg.in/check.v1"
@@ -33,3 +34,9 @@ func (s *MySuite) TestIndent(c *C) {
 	c.Assert(indent("foo bar", 2), Equals, "  foo bar")
 	c.Assert(indent("\nfoo bar", 2), Equals, "  \n  foo bar")
 }
+
+func (s *MySuite) TestTruncateParameter(c *C) {
+	c.Assert(truncateParameter("john"), Equals, "john")
+	c.Assert(truncateParameter("first line\nsecond line"), Equals, "first line...")
+	c.Assert(truncateParameter(strings.Repeat("a", maxParameterLength+1)), Equals, strings.Repeat("a", maxParameterLength)+"...")
+}
@@ -73,13 +73,22 @@ type executionEvent struct {
 }
 
 type executionResult struct {
-	Status            status           `json:"status,omitempty"`
-	Time              int64            `json:"time"`
-	Stdout            string           `json:"out,omitempty"`
-	Errors            []executionError `json:"errors,omitempty"`
-	BeforeHookFailure *executionError  `json:"beforeHookFailure,omitempty"`
-	AfterHookFailure  *executionError  `json:"afterHookFailure,omitempty"`
-	Table             *tableInfo       `json:"table,omitempty"`
+	Status            status            `json:"status,omitempty"`
+	Time              int64             `json:"time"`
+	Stdout            string            `json:"out,omitempty"`
+	Errors            []executionError  `json:"errors,omitempty"`
+	BeforeHookFailure *executionError   `json:"beforeHookFailure,omitempty"`
+	AfterHookFailure  *executionError   `json:"afterHookFailure,omitempty"`
+	Table             *tableInfo        `json:"table,omitempty"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+	SlowSteps         []slowStep        `json:"slowSteps,omitempty"`
+}
+
+type slowStep struct {
+	Text     string `json:"text"`
+	Filename string `json:"filename"`
+	Scenario string `json:"scenario"`
+	Time     int64  `json:"time"`
 }
 
 type tableInfo struct {
@@ -116,6 +125,8 @@ func (c *jsonConsole) SuiteEnd(res result.Result) {
 			Status:            getStatus(sRes.IsFailed, false),
 			BeforeHookFailure: getHookFailure(res.GetPreHook(), "Before Suite"),
 			AfterHookFailure:  getHookFailure(res.GetPostHook(), "After Suite"),
+			Metadata:          sRes.Metadata,
+			SlowSteps:         getSlowSteps(sRes.SlowSteps),
 		},
 	})
 }
@@ -344,3 +355,11 @@ func getHookFailure(hookFailure []*gm.ProtoHookFailure, text string) *executionE
 	}
 	return nil
 }
+
+func getSlowSteps(steps []*result.SlowStep) []slowStep {
+	var slowSteps []slowStep
+	for _, s := range steps {
+		slowSteps = append(slowSteps, slowStep{Text: s.Text, Filename: s.SpecFile, Scenario: s.Scenario, Time: s.ExecutionTime})
+	}
+	return slowSteps
+}
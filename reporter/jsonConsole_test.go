@@ -793,3 +793,13 @@ func (s *MySuite) TestSuiteEndWithBeforeAndAfterHookFailure_JSONConsole(c *C) {
 	jc.SuiteEnd(res)
 	c.Assert(dw.output, Equals, expected)
 }
+
+func (s *MySuite) TestSuiteEndWithSlowSteps_JSONConsole(c *C) {
+	dw, jc := setupJSONConsole()
+	res := &result.SuiteResult{SlowSteps: []*result.SlowStep{{Text: "Wait for the build", SpecFile: "build.spec", Scenario: "Build", ExecutionTime: 2500}}}
+
+	jc.SuiteEnd(res)
+
+	c.Assert(dw.output, Equals, `{"type":"suiteEnd","result":{"status":"pass","time":0,"slowSteps":[{"text":"Wait for the build","filename":"build.spec","scenario":"Build","time":2500}]}}
+`)
+}
@@ -0,0 +1,203 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+)
+
+const progressBarWidth = 30
+
+// progressConsole shows a single, continuously updated line with a progress bar, the number of scenarios
+// completed, passed and failed, and the estimated time to finish. Failed scenarios are printed above the bar.
+type progressConsole struct {
+	mu        *sync.Mutex
+	writer    io.Writer
+	startTime time.Time
+	now       func() time.Time
+	total     int
+	completed int
+	passed    int
+	failed    int
+	lineWidth int
+}
+
+func newProgressConsole(out io.Writer, total int) *progressConsole {
+	return &progressConsole{mu: &sync.Mutex{}, writer: out, total: total, now: time.Now}
+}
+
+func (p *progressConsole) SuiteStart() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.startTime = p.now()
+	p.render()
+}
+
+func (p *progressConsole) SpecStart(spec *gauge.Specification, res result.Result) {
+	logger.Info(false, formatSpec(spec.Heading.Value))
+}
+
+func (p *progressConsole) SpecEnd(spec *gauge.Specification, res result.Result) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.printHookFailures(res)
+}
+
+func (p *progressConsole) ScenarioStart(scenario *gauge.Scenario, i gauge_messages.ExecutionInfo, res result.Result) {
+	logger.Info(false, formatScenario(scenario.Heading.Value))
+}
+
+func (p *progressConsole) ScenarioEnd(scenario *gauge.Scenario, res result.Result, i gauge_messages.ExecutionInfo) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.completed++
+	switch res.(*result.ScenarioResult).ProtoScenario.GetExecutionStatus() {
+	case gauge_messages.ExecutionStatus_FAILED:
+		p.failed++
+		p.printAboveBar(fmt.Sprintf("%s %s > %s", strings.TrimSpace(getFailureSymbol()), i.GetCurrentSpec().GetName(), scenario.Heading.Value))
+	case gauge_messages.ExecutionStatus_PASSED:
+		p.passed++
+	}
+	p.printHookFailures(res)
+	p.render()
+}
+
+func (p *progressConsole) StepStart(stepText string) {
+	logger.Debug(false, stepText)
+}
+
+func (p *progressConsole) StepEnd(step gauge.Step, res result.Result, execInfo gauge_messages.ExecutionInfo) {
+	stepRes := res.(*result.StepResult)
+	if stepRes.GetStepFailed() {
+		logger.Error(false, prepStepMsg(step.LineText))
+		logger.Error(false, prepErrorMessage(stepRes.ProtoStepExecResult().GetExecutionResult().GetErrorMessage()))
+	}
+}
+
+func (p *progressConsole) ConceptStart(conceptHeading string) {
+	logger.Debug(false, conceptHeading)
+}
+
+func (p *progressConsole) ConceptEnd(res result.Result) {
+}
+
+func (p *progressConsole) DataTable(table string) {
+	logger.Debug(false, table)
+}
+
+func (p *progressConsole) SuiteEnd(res result.Result) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.render()
+	fmt.Fprint(p.writer, newline)
+	p.lineWidth = 0
+	p.printHookFailures(res)
+	for _, e := range res.(*result.SuiteResult).UnhandledErrors {
+		logger.Error(false, e.Error())
+		fmt.Fprint(p.writer, indent(e.Error(), errorIndentation)+newline)
+	}
+}
+
+func (p *progressConsole) Errorf(text string, args ...interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	msg := fmt.Sprintf(text, args...)
+	logger.Error(false, msg)
+	p.printAboveBar(indent(msg, errorIndentation))
+	p.render()
+}
+
+// Write prints the output of the runner above the progress bar.
+func (p *progressConsole) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.printAboveBar(strings.TrimRight(string(b), newline))
+	p.render()
+	return len(b), nil
+}
+
+func (p *progressConsole) printHookFailures(res result.Result) {
+	for _, hookFailure := range [][]*gauge_messages.ProtoHookFailure{res.GetPreHook(), res.GetPostHook()} {
+		if len(hookFailure) > 0 {
+			errMsg := prepErrorMessage(hookFailure[0].GetErrorMessage())
+			logger.Error(false, errMsg)
+			p.printAboveBar(indent(errMsg, errorIndentation))
+		}
+	}
+}
+
+func (p *progressConsole) printAboveBar(text string) {
+	p.clearLine()
+	fmt.Fprint(p.writer, text+newline)
+}
+
+func (p *progressConsole) clearLine() {
+	if p.lineWidth > 0 {
+		fmt.Fprint(p.writer, "\r"+spaces(p.lineWidth)+"\r")
+		p.lineWidth = 0
+	}
+}
+
+func (p *progressConsole) render() {
+	line := p.progressLine()
+	p.clearLine()
+	fmt.Fprint(p.writer, line)
+	p.lineWidth = len([]rune(line))
+}
+
+func (p *progressConsole) progressLine() string {
+	filled := progressBarWidth
+	if p.total > 0 {
+		filled = progressBarWidth * p.completed / p.total
+		if filled > progressBarWidth {
+			filled = progressBarWidth
+		}
+	}
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", progressBarWidth-filled)
+	return fmt.Sprintf("[%s] %d/%d scenarios | %d passed | %d failed | ETA %s", bar, p.completed, p.total, p.passed, p.failed, p.eta())
+}
+
+// eta is based on the expected duration of the suite from previous runs if known,
+// else on the average time taken by the scenarios completed so far.
+func (p *progressConsole) eta() string {
+	if p.startTime.IsZero() {
+		return "-"
+	}
+	elapsed := p.now().Sub(p.startTime)
+	var remaining time.Duration
+	if ExpectedDuration > 0 {
+		remaining = ExpectedDuration - elapsed
+	} else if p.completed > 0 && p.total > p.completed {
+		remaining = elapsed / time.Duration(p.completed) * time.Duration(p.total-p.completed)
+	} else if p.completed == 0 {
+		return "-"
+	}
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining.Round(time.Second).String()
+}
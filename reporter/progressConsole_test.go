@@ -0,0 +1,78 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package reporter
+
+import (
+	"strings"
+	"time"
+
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	. "gopkg.in/check.v1"
+)
+
+func setupProgressConsole(total int) (*dummyWriter, *progressConsole, *time.Time) {
+	dw := newDummyWriter()
+	pc := newProgressConsole(dw, total)
+	now := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	pc.now = func() time.Time { return now }
+	return dw, pc, &now
+}
+
+func scenarioResultWithStatus(status gauge_messages.ExecutionStatus) *result.ScenarioResult {
+	return result.NewScenarioResult(&gauge_messages.ProtoScenario{ExecutionStatus: status})
+}
+
+func (s *MySuite) TestProgressConsoleShowsCountsAndETA(c *C) {
+	ExpectedDuration = 0
+	dw, pc, now := setupProgressConsole(4)
+	pc.SuiteStart()
+	sce := &gauge.Scenario{Heading: &gauge.Heading{Value: "scenario"}}
+
+	*now = now.Add(10 * time.Second)
+	pc.ScenarioEnd(sce, scenarioResultWithStatus(gauge_messages.ExecutionStatus_PASSED), gauge_messages.ExecutionInfo{})
+
+	c.Assert(strings.HasSuffix(dw.output, "[=======                       ] 1/4 scenarios | 1 passed | 0 failed | ETA 30s"), Equals, true)
+}
+
+func (s *MySuite) TestProgressConsoleUsesExpectedDurationForETA(c *C) {
+	ExpectedDuration = time.Minute
+	defer func() { ExpectedDuration = 0 }()
+	dw, pc, now := setupProgressConsole(4)
+	pc.SuiteStart()
+
+	*now = now.Add(10 * time.Second)
+	pc.ScenarioEnd(&gauge.Scenario{Heading: &gauge.Heading{Value: "scenario"}}, scenarioResultWithStatus(gauge_messages.ExecutionStatus_PASSED), gauge_messages.ExecutionInfo{})
+
+	c.Assert(strings.HasSuffix(dw.output, "ETA 50s"), Equals, true)
+}
+
+func (s *MySuite) TestProgressConsolePrintsFailedScenarioAboveBar(c *C) {
+	ExpectedDuration = 0
+	dw, pc, _ := setupProgressConsole(2)
+	pc.SuiteStart()
+	info := gauge_messages.ExecutionInfo{CurrentSpec: &gauge_messages.SpecInfo{Name: "spec"}}
+
+	pc.ScenarioEnd(&gauge.Scenario{Heading: &gauge.Heading{Value: "scenario"}}, scenarioResultWithStatus(gauge_messages.ExecutionStatus_FAILED), info)
+
+	lines := strings.Split(dw.output, newline)
+	c.Assert(len(lines), Equals, 2)
+	c.Assert(strings.HasSuffix(lines[0], "spec > scenario"), Equals, true)
+	c.Assert(strings.Contains(lines[1], "1/2 scenarios | 0 passed | 1 failed"), Equals, true)
+}
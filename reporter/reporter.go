@@ -22,9 +22,12 @@ import (
 	"io"
 	"os"
 	"runtime/debug"
+	"strings"
 
 	"sync"
+	"time"
 
+	"github.com/getgauge/gauge/env"
 	"github.com/getgauge/gauge/execution/event"
 	"github.com/getgauge/gauge/execution/result"
 	"github.com/getgauge/gauge/formatter"
@@ -48,6 +51,22 @@ var Verbose bool
 // MachineReadable represents if output should be in JSON format.
 var MachineReadable bool
 
+// Mode represents the kind of console reporting. If empty, the default console reporting is used.
+var Mode string
+
+// ExpectedScenarios is the number of scenarios which are expected to be executed.
+var ExpectedScenarios int
+
+// ExpectedDuration is the estimated time to execute the suite, based on previous runs. It is 0 if not known.
+var ExpectedDuration time.Duration
+
+const (
+	// ProgressMode reports the progress of execution as a progress bar.
+	ProgressMode = "progress"
+	// SummaryMode reports only the failures and a summary at the end of execution.
+	SummaryMode = "summary"
+)
+
 const newline = "\n"
 
 // Reporter reports the progress of spec execution. It reports
@@ -71,8 +90,18 @@ type Reporter interface {
 	io.Writer
 }
 
+// parametersReporter is implemented by the reporters which also report the resolved parameters of each step.
+type parametersReporter interface {
+	StepParameters([]*gauge_messages.Parameter)
+}
+
 var currentReporter Reporter
 
+// IsValidMode checks if the given console reporting mode is supported.
+func IsValidMode(mode string) bool {
+	return mode == "" || mode == ProgressMode || mode == SummaryMode
+}
+
 func reporter(e event.ExecutionEvent) Reporter {
 	if IsParallel {
 		return ParallelReporter(e.Stream)
@@ -85,6 +114,10 @@ func Current() Reporter {
 	if currentReporter == nil {
 		if MachineReadable {
 			currentReporter = newJSONConsole(os.Stdout, IsParallel, 0)
+		} else if Mode == ProgressMode {
+			currentReporter = newProgressConsole(os.Stdout, ExpectedScenarios)
+		} else if Mode == SummaryMode {
+			currentReporter = newSummaryConsole(os.Stdout)
 		} else if SimpleConsoleOutput {
 			currentReporter = newSimpleConsole(os.Stdout)
 		} else if Verbose {
@@ -96,14 +129,6 @@ func Current() Reporter {
 	return currentReporter
 }
 
-type parallelReportWriter struct {
-	nRunner int
-}
-
-func (p *parallelReportWriter) Write(b []byte) (int, error) {
-	return fmt.Printf("[runner: %d] %s", p.nRunner, string(b))
-}
-
 // ParallelReporter returns the instance of parallel console reporter
 func ParallelReporter(n int) Reporter {
 	if r, ok := parallelReporters[n]; ok {
@@ -114,14 +139,19 @@ func ParallelReporter(n int) Reporter {
 
 var parallelReporters map[int]Reporter
 
+var parallelWriters []*streamWriter
+
 func initParallelReporters() {
 	parallelReporters = make(map[int]Reporter, NumberOfExecutionStreams)
+	parallelWriters = newStreamWriters(NumberOfExecutionStreams, os.Stdout)
 	for i := 1; i <= NumberOfExecutionStreams; i++ {
 		if MachineReadable {
 			parallelReporters[i] = newJSONConsole(os.Stdout, true, i)
+		} else if Mode != "" {
+			// all the streams report to the same console
+			continue
 		} else {
-			writer := &parallelReportWriter{nRunner: i}
-			parallelReporters[i] = newSimpleConsole(writer)
+			parallelReporters[i] = newSimpleConsole(parallelWriters[i-1])
 		}
 	}
 }
@@ -138,45 +168,124 @@ func ListenExecutionEvents(wg *sync.WaitGroup) {
 		defer recoverPanic()
 		for {
 			e := <-ch
-			r = reporter(e)
-			switch e.Topic {
-			case event.SuiteStart:
-				r.SuiteStart()
-			case event.SpecStart:
-				r.SpecStart(e.Item.(*gauge.Specification), e.Result)
-			case event.ScenarioStart:
-				skipped := e.Result.(*result.ScenarioResult).ProtoScenario.GetExecutionStatus() == gauge_messages.ExecutionStatus_SKIPPED
-				sce := e.Item.(*gauge.Scenario)
-				// if it is datatable driven execution
-				if !skipped {
-					if sce.SpecDataTableRow.GetRowCount() != 0 {
-						r.DataTable(formatter.FormatTable(&sce.SpecDataTableRow))
-					}
-					if sce.ScenarioDataTableRow.GetRowCount() != 0 {
-						r.DataTable(formatter.FormatTable(&sce.ScenarioDataTableRow))
-					}
+			if e.Topic == event.SuiteEnd {
+				// the streams are done, so their last lines go before the summary
+				for _, w := range parallelWriters {
+					w.flush()
 				}
-				r.ScenarioStart(sce, e.ExecutionInfo, e.Result)
-			case event.ConceptStart:
-				r.ConceptStart(formatter.FormatStep(e.Item.(*gauge.Step)))
-			case event.StepStart:
-				r.StepStart(formatter.FormatStep(e.Item.(*gauge.Step)))
-			case event.StepEnd:
-				r.StepEnd(e.Item.(gauge.Step), e.Result, e.ExecutionInfo)
-			case event.ConceptEnd:
-				r.ConceptEnd(e.Result)
-			case event.ScenarioEnd:
-				r.ScenarioEnd(e.Item.(*gauge.Scenario), e.Result, e.ExecutionInfo)
-			case event.SpecEnd:
-				r.SpecEnd(e.Item.(*gauge.Specification), e.Result)
-			case event.SuiteEnd:
-				r.SuiteEnd(e.Result)
+			}
+			r = reporter(e)
+			report(r, e)
+			for _, s := range currentSinks() {
+				report(s.reporter(e.Stream), e)
+			}
+			if e.Topic == event.SuiteEnd {
 				wg.Done()
 			}
 		}
 	}()
 }
 
+func report(r Reporter, e event.ExecutionEvent) {
+	switch e.Topic {
+	case event.SuiteStart:
+		r.SuiteStart()
+	case event.SpecStart:
+		r.SpecStart(e.Item.(*gauge.Specification), e.Result)
+	case event.ScenarioStart:
+		skipped := e.Result.(*result.ScenarioResult).ProtoScenario.GetExecutionStatus() == gauge_messages.ExecutionStatus_SKIPPED
+		sce := e.Item.(*gauge.Scenario)
+		// if it is datatable driven execution
+		if !skipped {
+			if sce.SpecDataTableRow.GetRowCount() != 0 {
+				r.DataTable(formatter.FormatTable(&sce.SpecDataTableRow))
+			}
+			if sce.ScenarioDataTableRow.GetRowCount() != 0 {
+				r.DataTable(formatter.FormatTable(&sce.ScenarioDataTableRow))
+			}
+		}
+		r.ScenarioStart(sce, e.ExecutionInfo, e.Result)
+	case event.ConceptStart:
+		r.ConceptStart(formatter.FormatStep(e.Item.(*gauge.Step)))
+	case event.StepStart:
+		r.StepStart(formatter.FormatStep(e.Item.(*gauge.Step)))
+		if pr, ok := r.(parametersReporter); ok && e.ExecutionInfo.CurrentStep != nil {
+			pr.StepParameters(e.ExecutionInfo.CurrentStep.GetStep().GetParameters())
+		}
+	case event.StepEnd:
+		step := e.Item.(gauge.Step)
+		r.StepEnd(step, e.Result, e.ExecutionInfo)
+		reportSlowStep(r, step, e.Result)
+	case event.ConceptEnd:
+		r.ConceptEnd(e.Result)
+	case event.ScenarioEnd:
+		r.ScenarioEnd(e.Item.(*gauge.Scenario), e.Result, e.ExecutionInfo)
+	case event.SpecEnd:
+		r.SpecEnd(e.Item.(*gauge.Specification), e.Result)
+	case event.SuiteEnd:
+		r.SuiteEnd(e.Result)
+	}
+}
+
+// reportSlowStep flags the step on the console if it took longer than the slow step threshold.
+// The machine readable output lists the slow steps in the result of the suite instead.
+func reportSlowStep(r Reporter, step gauge.Step, res result.Result) {
+	threshold := env.SlowStepThreshold()
+	if _, ok := r.(*jsonConsole); ok || threshold <= 0 || res.ExecTime() <= threshold {
+		return
+	}
+	msg := fmt.Sprintf("Slow step: %s took %s, more than %dms", strings.TrimSpace(step.LineText), time.Millisecond*time.Duration(res.ExecTime()), threshold)
+	logger.Debug(false, msg)
+	r.Write([]byte(msg + newline))
+}
+
+// sink receives the execution events in the machine readable format, with a reporter for each execution stream.
+type sink struct {
+	writer    io.Writer
+	reporters map[int]Reporter
+}
+
+func (s *sink) reporter(stream int) Reporter {
+	r, ok := s.reporters[stream]
+	if !ok {
+		r = newJSONConsole(s.writer, IsParallel, stream)
+		s.reporters[stream] = r
+	}
+	return r
+}
+
+var sinks = struct {
+	sync.Mutex
+	s []*sink
+}{}
+
+// AddSink streams the execution events to w, in addition to the console. The events are written in the
+// machine readable format, one JSON object per line. Plugins use it to provide their own live output.
+func AddSink(w io.Writer) {
+	sinks.Lock()
+	defer sinks.Unlock()
+	sinks.s = append(sinks.s, &sink{writer: w, reporters: make(map[int]Reporter)})
+}
+
+// RemoveSink stops streaming the execution events to a writer added with AddSink.
+func RemoveSink(w io.Writer) {
+	sinks.Lock()
+	defer sinks.Unlock()
+	var remaining []*sink
+	for _, s := range sinks.s {
+		if s.writer != w {
+			remaining = append(remaining, s)
+		}
+	}
+	sinks.s = remaining
+}
+
+func currentSinks() []*sink {
+	sinks.Lock()
+	defer sinks.Unlock()
+	return sinks.s
+}
+
 func recoverPanic() {
 	if r := recover(); r != nil {
 		logger.Fatalf(true, "%v\n%s", r, string(debug.Stack()))
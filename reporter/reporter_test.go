@@ -20,6 +20,7 @@ package reporter
 import (
 	"sync"
 
+	"github.com/getgauge/gauge/env"
 	"github.com/getgauge/gauge/execution/event"
 	"github.com/getgauge/gauge/execution/result"
 	"github.com/getgauge/gauge/gauge"
@@ -179,6 +180,31 @@ func (s *MySuite) TestSubscribeSuiteEnd(c *C) {
 	c.Assert(<-e, Equals, event.SuiteEnd)
 }
 
+func (s *MySuite) TestSinkReceivesExecutionEventsAsJSON(c *C) {
+	e := make(chan event.Topic)
+	currentReporter = &dummyConsole{event: e}
+	event.InitRegistry()
+	w := &chanWriter{c: make(chan string, 1)}
+	AddSink(w)
+	defer RemoveSink(w)
+	spec := &gauge.Specification{Heading: &gauge.Heading{Value: "My Spec Heading"}, FileName: "foo.spec"}
+
+	ListenExecutionEvents(&sync.WaitGroup{})
+
+	event.Notify(event.NewExecutionEvent(event.SpecStart, spec, nil, 0, gauge_messages.ExecutionInfo{}))
+	c.Assert(<-e, Equals, event.SpecStart)
+	c.Assert(<-w.c, Matches, `\{"type":"specStart","id":"foo.spec","name":"My Spec Heading".*\}\n`)
+}
+
+type chanWriter struct {
+	c chan string
+}
+
+func (w *chanWriter) Write(b []byte) (int, error) {
+	w.c <- string(b)
+	return len(b), nil
+}
+
 type dummyConsole struct {
 	event chan event.Topic
 }
@@ -233,3 +259,19 @@ func (dc *dummyConsole) Errorf(err string, args ...interface{}) {
 func (dc *dummyConsole) Write(b []byte) (int, error) {
 	return len(b), nil
 }
+
+func (s *MySuite) TestReportSlowStep(c *C) {
+	defer func(f func() int64) { env.SlowStepThreshold = f }(env.SlowStepThreshold)
+	env.SlowStepThreshold = func() int64 { return 500 }
+	dw, sc := setupSimpleConsole()
+	stepResult := func(t int64) result.Result {
+		return result.NewStepResult(&gauge_messages.ProtoStep{StepExecutionResult: &gauge_messages.ProtoStepExecutionResult{ExecutionResult: &gauge_messages.ProtoExecutionResult{ExecutionTime: t}}})
+	}
+
+	reportSlowStep(sc, gauge.Step{LineText: "Fast step"}, stepResult(500))
+	reportSlowStep(sc, gauge.Step{LineText: "Slow step"}, stepResult(1500))
+	_, jc := setupJSONConsole()
+	reportSlowStep(jc, gauge.Step{LineText: "Slow step"}, stepResult(1500))
+
+	c.Assert(dw.output, Equals, "Slow step: Slow step took 1.5s, more than 500ms\n")
+}
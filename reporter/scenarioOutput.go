@@ -0,0 +1,70 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package reporter
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// captures holds the output captured for the scenario being executed in every stream
+var captures = struct {
+	sync.Mutex
+	buffers map[int]*bytes.Buffer
+}{buffers: make(map[int]*bytes.Buffer)}
+
+// runnerWriter writes the output of the runner of a stream to its console, and to the capture of the stream, if any.
+type runnerWriter struct {
+	stream int
+	out    io.Writer
+}
+
+// RunnerWriter gives the writer for the output of the runner of the stream, which is written to the console of the
+// stream and captured for the scenario being executed in the stream, if any.
+func RunnerWriter(stream int) io.Writer {
+	return &runnerWriter{stream: stream, out: ParallelReporter(stream)}
+}
+
+func (w *runnerWriter) Write(b []byte) (int, error) {
+	captures.Lock()
+	if buf, ok := captures.buffers[w.stream]; ok {
+		buf.Write(b)
+	}
+	captures.Unlock()
+	return w.out.Write(b)
+}
+
+// StartCapture starts capturing the output of the runner of the stream.
+func StartCapture(stream int) {
+	captures.Lock()
+	defer captures.Unlock()
+	captures.buffers[stream] = &bytes.Buffer{}
+}
+
+// StopCapture stops capturing the output of the runner of the stream and gives the output captured since it started.
+func StopCapture(stream int) []byte {
+	captures.Lock()
+	defer captures.Unlock()
+	buf, ok := captures.buffers[stream]
+	if !ok {
+		return nil
+	}
+	delete(captures.buffers, stream)
+	return buf.Bytes()
+}
@@ -0,0 +1,39 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package reporter
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestRunnerWriterCapturesOutputOfItsStream(c *C) {
+	dw1, dw2 := newDummyWriter(), newDummyWriter()
+	w1, w2 := &runnerWriter{stream: 1, out: dw1}, &runnerWriter{stream: 2, out: dw2}
+
+	w1.Write([]byte("before\n"))
+	StartCapture(1)
+	w1.Write([]byte("during\n"))
+	w2.Write([]byte("other stream\n"))
+	output := StopCapture(1)
+	w1.Write([]byte("after\n"))
+
+	c.Assert(string(output), Equals, "during\n")
+	c.Assert(dw1.output, Equals, "before\nduring\nafter\n")
+	c.Assert(dw2.output, Equals, "other stream\n")
+	c.Assert(StopCapture(1), IsNil)
+}
@@ -0,0 +1,75 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package reporter
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// streamWriter prefixes each line written by a parallel stream with the stream, like [stream-2]. Only whole lines are
+// written, so the output of streams executing at the same time can interleave line by line but never within a line.
+type streamWriter struct {
+	stream int
+	out    io.Writer
+	// mutex is shared by the writers of all the streams writing to out
+	mutex   *sync.Mutex
+	pending []byte
+}
+
+func newStreamWriters(n int, out io.Writer) []*streamWriter {
+	mutex := &sync.Mutex{}
+	writers := make([]*streamWriter, n)
+	for i := range writers {
+		writers[i] = &streamWriter{stream: i + 1, out: out, mutex: mutex}
+	}
+	return writers
+}
+
+func (w *streamWriter) Write(b []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.pending = append(w.pending, b...)
+	for {
+		i := bytes.IndexByte(w.pending, '\n')
+		if i < 0 {
+			return len(b), nil
+		}
+		if err := w.writeLine(w.pending[:i+1]); err != nil {
+			return 0, err
+		}
+		w.pending = w.pending[i+1:]
+	}
+}
+
+// flush writes the last line of the stream, if it did not end with a newline.
+func (w *streamWriter) flush() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if len(w.pending) > 0 {
+		w.writeLine(append(w.pending, '\n'))
+		w.pending = nil
+	}
+}
+
+func (w *streamWriter) writeLine(line []byte) error {
+	_, err := fmt.Fprintf(w.out, "[stream-%d] %s", w.stream, line)
+	return err
+}
@@ -0,0 +1,43 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package reporter
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestStreamWriterPrefixesEachLine(c *C) {
+	dw := newDummyWriter()
+	w := newStreamWriters(2, dw)[1]
+
+	w.Write([]byte("# Spec heading\n  ## Scenario\n"))
+
+	c.Assert(dw.output, Equals, "[stream-2] # Spec heading\n[stream-2]   ## Scenario\n")
+}
+
+func (s *MySuite) TestStreamWriterHoldsPartialLines(c *C) {
+	dw := newDummyWriter()
+	writers := newStreamWriters(2, dw)
+
+	writers[0].Write([]byte("downloading"))
+	writers[1].Write([]byte("started\n"))
+	writers[0].Write([]byte(" done\nuploading"))
+	writers[0].flush()
+
+	c.Assert(dw.output, Equals, "[stream-2] started\n[stream-1] downloading done\n[stream-1] uploading\n")
+}
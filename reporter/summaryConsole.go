@@ -0,0 +1,169 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package reporter
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/util"
+)
+
+// summaryConsole prints only the failures as they happen and a summary of all the failures at the end.
+// Everything else, including the output of the runner, is written only to the logs.
+type summaryConsole struct {
+	mu       *sync.Mutex
+	writer   io.Writer
+	failures map[string]*bytes.Buffer
+	failed   []string
+}
+
+func newSummaryConsole(out io.Writer) *summaryConsole {
+	return &summaryConsole{mu: &sync.Mutex{}, writer: out, failures: make(map[string]*bytes.Buffer)}
+}
+
+// scenarioKey identifies the scenario being executed, since events from parallel streams are interleaved.
+func scenarioKey(i gauge_messages.ExecutionInfo) string {
+	return i.GetCurrentSpec().GetFileName() + ":" + i.GetCurrentScenario().GetName()
+}
+
+func (s *summaryConsole) SuiteStart() {
+}
+
+func (s *summaryConsole) SpecStart(spec *gauge.Specification, res result.Result) {
+	logger.Info(false, formatSpec(spec.Heading.Value))
+}
+
+func (s *summaryConsole) SpecEnd(spec *gauge.Specification, res result.Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if hookFailed(res.GetPreHook) || hookFailed(res.GetPostHook) {
+		heading := fmt.Sprintf("%s %s", strings.TrimSpace(getFailureSymbol()), util.RelPathToProjectRoot(spec.FileName))
+		s.failed = append(s.failed, heading)
+		fmt.Fprint(s.writer, heading+newline)
+		s.printHookFailures(res)
+	}
+}
+
+func (s *summaryConsole) ScenarioStart(scenario *gauge.Scenario, i gauge_messages.ExecutionInfo, res result.Result) {
+	logger.Info(false, formatScenario(scenario.Heading.Value))
+}
+
+func (s *summaryConsole) ScenarioEnd(scenario *gauge.Scenario, res result.Result, i gauge_messages.ExecutionInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := scenarioKey(i)
+	defer delete(s.failures, key)
+	if res.(*result.ScenarioResult).ProtoScenario.GetExecutionStatus() != gauge_messages.ExecutionStatus_FAILED {
+		return
+	}
+	heading := fmt.Sprintf("%s %s:%d %s", strings.TrimSpace(getFailureSymbol()), util.RelPathToProjectRoot(i.GetCurrentSpec().GetFileName()), scenario.Heading.LineNo, scenario.Heading.Value)
+	s.failed = append(s.failed, heading)
+	fmt.Fprint(s.writer, heading+newline)
+	if b, ok := s.failures[key]; ok {
+		fmt.Fprint(s.writer, b.String())
+	}
+	s.printHookFailures(res)
+}
+
+func (s *summaryConsole) StepStart(stepText string) {
+	logger.Debug(false, stepText)
+}
+
+func (s *summaryConsole) StepEnd(step gauge.Step, res result.Result, execInfo gauge_messages.ExecutionInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stepRes := res.(*result.StepResult)
+	if !stepRes.GetStepFailed() {
+		return
+	}
+	stepText := strings.TrimLeft(prepStepMsg(step.LineText), newline)
+	errMsg := prepErrorMessage(stepRes.ProtoStepExecResult().GetExecutionResult().GetErrorMessage())
+	stacktrace := prepStacktrace(stepRes.ProtoStepExecResult().GetExecutionResult().GetStackTrace())
+	logger.Error(false, stepText)
+	logger.Error(false, errMsg)
+	logger.Error(false, stacktrace)
+	key := scenarioKey(execInfo)
+	if _, ok := s.failures[key]; !ok {
+		s.failures[key] = &bytes.Buffer{}
+	}
+	s.failures[key].WriteString(formatErrorFragment(stepText, 0) + formatErrorFragment(errMsg, 0) + formatErrorFragment(stacktrace, 0))
+}
+
+func (s *summaryConsole) ConceptStart(conceptHeading string) {
+	logger.Debug(false, conceptHeading)
+}
+
+func (s *summaryConsole) ConceptEnd(res result.Result) {
+}
+
+func (s *summaryConsole) DataTable(table string) {
+	logger.Debug(false, table)
+}
+
+func (s *summaryConsole) SuiteEnd(res result.Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.printHookFailures(res)
+	suiteRes := res.(*result.SuiteResult)
+	for _, e := range suiteRes.UnhandledErrors {
+		logger.Error(false, e.Error())
+		fmt.Fprint(s.writer, indent(e.Error(), errorIndentation)+newline)
+	}
+	if len(s.failed) == 0 {
+		return
+	}
+	fmt.Fprintf(s.writer, "%sFailures (%d):%s", newline, len(s.failed), newline)
+	for _, f := range s.failed {
+		fmt.Fprint(s.writer, indent(f, errorIndentation)+newline)
+	}
+	fmt.Fprint(s.writer, newline)
+}
+
+func (s *summaryConsole) Errorf(text string, args ...interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msg := fmt.Sprintf(text, args...)
+	logger.Error(false, msg)
+	fmt.Fprint(s.writer, indent(msg, errorIndentation)+newline)
+}
+
+// Write logs the output of the runner, without printing it on console.
+func (s *summaryConsole) Write(b []byte) (int, error) {
+	logger.Debug(false, strings.TrimRight(string(b), newline))
+	return len(b), nil
+}
+
+func (s *summaryConsole) printHookFailures(res result.Result) {
+	for _, hookFailure := range [][]*gauge_messages.ProtoHookFailure{res.GetPreHook(), res.GetPostHook()} {
+		if len(hookFailure) > 0 {
+			errMsg := prepErrorMessage(hookFailure[0].GetErrorMessage())
+			stacktrace := prepStacktrace(hookFailure[0].GetStackTrace())
+			logger.Error(false, errMsg)
+			logger.Error(false, stacktrace)
+			fmt.Fprint(s.writer, formatErrorFragment(errMsg, 0)+formatErrorFragment(stacktrace, 0))
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package reporter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestSummaryConsolePrintsNothingForPassingScenario(c *C) {
+	dw := newDummyWriter()
+	sc := newSummaryConsole(dw)
+	info := gauge_messages.ExecutionInfo{CurrentSpec: &gauge_messages.SpecInfo{FileName: "foo.spec"}, CurrentScenario: &gauge_messages.ScenarioInfo{Name: "scenario"}}
+	sce := &gauge.Scenario{Heading: &gauge.Heading{Value: "scenario", LineNo: 3}}
+
+	sc.ScenarioStart(sce, info, scenarioResultWithStatus(gauge_messages.ExecutionStatus_PASSED))
+	sc.Write([]byte("runner output\n"))
+	sc.ScenarioEnd(sce, scenarioResultWithStatus(gauge_messages.ExecutionStatus_PASSED), info)
+	sc.SuiteEnd(result.NewSuiteResult("", time.Now()))
+
+	c.Assert(dw.output, Equals, "")
+}
+
+func (s *MySuite) TestSummaryConsolePrintsFailuresAndSummary(c *C) {
+	dw := newDummyWriter()
+	sc := newSummaryConsole(dw)
+	info := gauge_messages.ExecutionInfo{CurrentSpec: &gauge_messages.SpecInfo{FileName: "foo.spec"}, CurrentScenario: &gauge_messages.ScenarioInfo{Name: "scenario"}}
+	sce := &gauge.Scenario{Heading: &gauge.Heading{Value: "scenario", LineNo: 3}}
+	stepRes := result.NewStepResult(&gauge_messages.ProtoStep{StepExecutionResult: &gauge_messages.ProtoStepExecutionResult{ExecutionResult: &gauge_messages.ProtoExecutionResult{Failed: true, ErrorMessage: "assertion failed"}}})
+	stepRes.SetStepFailure()
+
+	sc.StepEnd(gauge.Step{LineText: "a step"}, stepRes, info)
+	sc.ScenarioEnd(sce, scenarioResultWithStatus(gauge_messages.ExecutionStatus_FAILED), info)
+	sc.SuiteEnd(result.NewSuiteResult("", time.Now()))
+
+	heading := fmt.Sprintf("%s foo.spec:3 scenario", strings.TrimSpace(getFailureSymbol()))
+	c.Assert(strings.HasPrefix(dw.output, heading+newline), Equals, true)
+	c.Assert(strings.Contains(dw.output, "Failed Step: a step"), Equals, true)
+	c.Assert(strings.Contains(dw.output, "Error Message: assertion failed"), Equals, true)
+	c.Assert(strings.HasSuffix(dw.output, "\nFailures (1):\n  "+heading+"\n\n"), Equals, true)
+}
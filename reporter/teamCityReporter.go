@@ -0,0 +1,126 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/util"
+)
+
+var teamCityEscaper = strings.NewReplacer("|", "||", "'", "|'", "\n", "|n", "\r", "|r", "[", "|[", "]", "|]")
+
+// teamCityReporter emits TeamCity service messages, reporting the specs as test suites and the scenarios as tests.
+// Every execution stream is reported as a separate flow, so that parallel executions are tracked correctly.
+type teamCityReporter struct {
+	writer io.Writer
+	// failures holds the failures of the scenario currently executing in each stream
+	failures map[int][]string
+}
+
+func newTeamCityReporter(out io.Writer) *teamCityReporter {
+	return &teamCityReporter{writer: out, failures: make(map[int][]string)}
+}
+
+func (t *teamCityReporter) handle(e event.ExecutionEvent) {
+	flowID := strconv.Itoa(e.Stream)
+	switch e.Topic {
+	case event.SpecStart:
+		t.message("testSuiteStarted", "name", e.Item.(*gauge.Specification).Heading.Value, "flowId", flowID)
+	case event.ScenarioStart:
+		if isSkipped(e.Result) {
+			return
+		}
+		delete(t.failures, e.Stream)
+		t.message("testStarted", "name", e.Item.(*gauge.Scenario).Heading.Value, "flowId", flowID)
+	case event.StepEnd:
+		step := e.Item.(gauge.Step)
+		stepRes := e.Result.(*result.StepResult)
+		if stepRes.GetStepFailed() {
+			t.failures[e.Stream] = append(t.failures[e.Stream], fmt.Sprintf("Failed Step: %s\n%s\n%s", step.LineText, stepRes.GetErrorMessage(), stepRes.GetStackTrace()))
+		}
+		t.failures[e.Stream] = append(t.failures[e.Stream], hookFailures(stepRes)...)
+	case event.ScenarioEnd:
+		name := e.Item.(*gauge.Scenario).Heading.Value
+		sceRes := e.Result.(*result.ScenarioResult)
+		if isSkipped(e.Result) {
+			t.message("testIgnored", "name", name, "message", strings.Join(sceRes.ProtoScenario.GetSkipErrors(), newline), "flowId", flowID)
+			return
+		}
+		if sceRes.GetFailed() {
+			failures := append(t.failures[e.Stream], hookFailures(sceRes)...)
+			message := "Scenario failed"
+			if len(failures) > 0 {
+				message = firstLine(failures[0])
+			}
+			t.message("testFailed", "name", name, "message", message, "details", strings.Join(failures, newline+newline), "flowId", flowID)
+		}
+		delete(t.failures, e.Stream)
+		t.message("testFinished", "name", name, "duration", strconv.FormatInt(sceRes.ExecTime(), 10), "flowId", flowID)
+	case event.SpecEnd:
+		t.errors(hookFailures(e.Result), flowID)
+		t.message("testSuiteFinished", "name", e.Item.(*gauge.Specification).Heading.Value, "flowId", flowID)
+	case event.SuiteEnd:
+		t.errors(hookFailures(e.Result), flowID)
+		for _, err := range e.Result.(*result.SuiteResult).UnhandledErrors {
+			t.errors([]string{err.Error()}, flowID)
+		}
+	}
+}
+
+func (t *teamCityReporter) validationError(fileName string, lineNo int, message string) {
+	text := fmt.Sprintf("%s:%d %s", util.RelPathToProjectRoot(fileName), lineNo, message)
+	if lineNo == 0 {
+		text = fmt.Sprintf("%s %s", util.RelPathToProjectRoot(fileName), message)
+	}
+	t.message("message", "text", text, "status", "ERROR")
+}
+
+func (t *teamCityReporter) errors(errs []string, flowID string) {
+	for _, err := range errs {
+		t.message("message", "text", firstLine(err), "errorDetails", err, "status", "ERROR", "flowId", flowID)
+	}
+}
+
+// message writes a service message with the given attributes, given as name value pairs.
+func (t *teamCityReporter) message(name string, attributes ...string) {
+	msg := "##teamcity[" + name
+	for i := 0; i+1 < len(attributes); i += 2 {
+		msg += fmt.Sprintf(" %s='%s'", attributes[i], teamCityEscaper.Replace(attributes[i+1]))
+	}
+	fmt.Fprint(t.writer, msg+"]"+newline)
+}
+
+func isSkipped(res result.Result) bool {
+	return res.(*result.ScenarioResult).ProtoScenario.GetExecutionStatus() == gauge_messages.ExecutionStatus_SKIPPED
+}
+
+func hookFailures(res result.Result) []string {
+	var failures []string
+	for _, f := range append(res.GetPreHook(), res.GetPostHook()...) {
+		failures = append(failures, fmt.Sprintf("%s\n%s", f.GetErrorMessage(), f.GetStackTrace()))
+	}
+	return failures
+}
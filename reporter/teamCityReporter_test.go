@@ -0,0 +1,77 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package reporter
+
+import (
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/gauge_messages"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestTeamCityReporterReportsPassingScenario(c *C) {
+	dw := newDummyWriter()
+	t := newTeamCityReporter(dw)
+	spec := &gauge.Specification{Heading: &gauge.Heading{Value: "spec"}}
+	sce := &gauge.Scenario{Heading: &gauge.Heading{Value: "scenario"}}
+	sceRes := result.NewScenarioResult(&gauge_messages.ProtoScenario{ExecutionStatus: gauge_messages.ExecutionStatus_PASSED, ExecutionTime: 12})
+
+	t.handle(event.NewExecutionEvent(event.SpecStart, spec, &result.SpecResult{}, 1, gauge_messages.ExecutionInfo{}))
+	t.handle(event.NewExecutionEvent(event.ScenarioStart, sce, sceRes, 1, gauge_messages.ExecutionInfo{}))
+	t.handle(event.NewExecutionEvent(event.ScenarioEnd, sce, sceRes, 1, gauge_messages.ExecutionInfo{}))
+	t.handle(event.NewExecutionEvent(event.SpecEnd, spec, &result.SpecResult{ProtoSpec: &gauge_messages.ProtoSpec{}}, 1, gauge_messages.ExecutionInfo{}))
+
+	want := `##teamcity[testSuiteStarted name='spec' flowId='1']
+##teamcity[testStarted name='scenario' flowId='1']
+##teamcity[testFinished name='scenario' duration='12' flowId='1']
+##teamcity[testSuiteFinished name='spec' flowId='1']
+`
+	c.Assert(dw.output, Equals, want)
+}
+
+func (s *MySuite) TestTeamCityReporterReportsFailingScenario(c *C) {
+	dw := newDummyWriter()
+	t := newTeamCityReporter(dw)
+	sce := &gauge.Scenario{Heading: &gauge.Heading{Value: "it's [broken]"}}
+	sceRes := result.NewScenarioResult(&gauge_messages.ProtoScenario{ExecutionStatus: gauge_messages.ExecutionStatus_FAILED})
+	stepRes := result.NewStepResult(&gauge_messages.ProtoStep{StepExecutionResult: &gauge_messages.ProtoStepExecutionResult{ExecutionResult: &gauge_messages.ProtoExecutionResult{Failed: true, ErrorMessage: "expected 1", StackTrace: "at foo"}}})
+	stepRes.SetStepFailure()
+
+	t.handle(event.NewExecutionEvent(event.ScenarioStart, sce, sceRes, 0, gauge_messages.ExecutionInfo{}))
+	t.handle(event.NewExecutionEvent(event.StepEnd, gauge.Step{LineText: "a step"}, stepRes, 0, gauge_messages.ExecutionInfo{}))
+	t.handle(event.NewExecutionEvent(event.ScenarioEnd, sce, sceRes, 0, gauge_messages.ExecutionInfo{}))
+
+	want := `##teamcity[testStarted name='it|'s |[broken|]' flowId='0']
+##teamcity[testFailed name='it|'s |[broken|]' message='Failed Step: a step' details='Failed Step: a step|nexpected 1|nat foo' flowId='0']
+##teamcity[testFinished name='it|'s |[broken|]' duration='0' flowId='0']
+`
+	c.Assert(dw.output, Equals, want)
+}
+
+func (s *MySuite) TestTeamCityReporterReportsSkippedScenarioAsIgnored(c *C) {
+	dw := newDummyWriter()
+	t := newTeamCityReporter(dw)
+	sce := &gauge.Scenario{Heading: &gauge.Heading{Value: "scenario"}}
+	sceRes := result.NewScenarioResult(&gauge_messages.ProtoScenario{ExecutionStatus: gauge_messages.ExecutionStatus_SKIPPED, SkipErrors: []string{"step not implemented"}})
+
+	t.handle(event.NewExecutionEvent(event.ScenarioStart, sce, sceRes, 0, gauge_messages.ExecutionInfo{}))
+	t.handle(event.NewExecutionEvent(event.ScenarioEnd, sce, sceRes, 0, gauge_messages.ExecutionInfo{}))
+
+	c.Assert(dw.output, Equals, "##teamcity[testIgnored name='scenario' message='step not implemented' flowId='0']\n")
+}
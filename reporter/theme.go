@@ -0,0 +1,125 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package reporter
+
+import (
+	"os"
+
+	ct "github.com/daviddengcn/go-colortext"
+	"github.com/getgauge/gauge/util"
+	"github.com/mattn/go-isatty"
+)
+
+const (
+	// ColorAuto colors the console output if it is a terminal and colors are not disabled by NO_COLOR or GAUGE_NO_COLOR.
+	ColorAuto = "auto"
+	// ColorAlways always colors the console output.
+	ColorAlways = "always"
+	// ColorNever never colors the console output.
+	ColorNever = "never"
+
+	// DefaultTheme is the theme meant for terminals with a dark background.
+	DefaultTheme = "default"
+	// HighContrastTheme uses bold colors which are readable on both light and dark backgrounds.
+	HighContrastTheme = "high-contrast"
+	// MonochromeTheme uses no colors and plain ascii symbols.
+	MonochromeTheme = "monochrome"
+
+	noColorEnv      = "NO_COLOR"
+	gaugeNoColorEnv = "GAUGE_NO_COLOR"
+)
+
+// ColorMode decides if the console output is colored. It is one of ColorAuto, ColorAlways or ColorNever.
+var ColorMode = ColorAuto
+
+// Theme is the name of the theme used to color the console output.
+var Theme = DefaultTheme
+
+type theme struct {
+	spec          ct.Color
+	scenario      ct.Color
+	concept       ct.Color
+	table         ct.Color
+	success       ct.Color
+	failure       ct.Color
+	bold          bool
+	successSymbol string
+	failureSymbol string
+}
+
+var themes = map[string]*theme{
+	DefaultTheme: {
+		spec: ct.Cyan, scenario: ct.Yellow, concept: ct.Magenta, table: ct.Yellow, success: ct.Green, failure: ct.Red,
+		successSymbol: successSymbol, failureSymbol: failureSymbol,
+	},
+	HighContrastTheme: {
+		spec: ct.Blue, scenario: ct.Magenta, concept: ct.Blue, table: ct.Magenta, success: ct.Green, failure: ct.Red, bold: true,
+		successSymbol: successSymbol, failureSymbol: failureSymbol,
+	},
+	MonochromeTheme: {
+		spec: ct.None, scenario: ct.None, concept: ct.None, table: ct.None, success: ct.None, failure: ct.None,
+		successSymbol: successChar, failureSymbol: failureChar,
+	},
+}
+
+// IsValidColorMode checks if the given color mode is supported.
+func IsValidColorMode(mode string) bool {
+	return mode == ColorAuto || mode == ColorAlways || mode == ColorNever
+}
+
+// IsValidTheme checks if a theme with the given name exists.
+func IsValidTheme(name string) bool {
+	_, ok := themes[name]
+	return ok
+}
+
+func currentTheme() *theme {
+	if t, ok := themes[Theme]; ok {
+		return t
+	}
+	return themes[DefaultTheme]
+}
+
+func colorEnabled() bool {
+	switch ColorMode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if os.Getenv(noColorEnv) != "" || os.Getenv(gaugeNoColorEnv) != "" {
+		return false
+	}
+	return isatty.IsTerminal(os.Stdout.Fd())
+}
+
+// setColor changes the foreground color of the console, unless coloring is disabled.
+func setColor(color ct.Color) bool {
+	if color == ct.None || !colorEnabled() {
+		return false
+	}
+	ct.Foreground(color, currentTheme().bold)
+	return true
+}
+
+func symbol(themeSymbol, windowsSymbol string) string {
+	if util.IsWindows() {
+		return spaces(1) + windowsSymbol
+	}
+	return spaces(1) + themeSymbol
+}
@@ -0,0 +1,72 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package reporter
+
+import (
+	"os"
+
+	"github.com/getgauge/gauge/util"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestColorEnabledForColorModes(c *C) {
+	defer func(mode string) { ColorMode = mode }(ColorMode)
+
+	ColorMode = ColorAlways
+	c.Assert(colorEnabled(), Equals, true)
+	ColorMode = ColorNever
+	c.Assert(colorEnabled(), Equals, false)
+}
+
+func (s *MySuite) TestNoColorEnvDisablesColorsInAutoMode(c *C) {
+	defer func(mode string) { ColorMode = mode }(ColorMode)
+	for _, env := range []string{noColorEnv, gaugeNoColorEnv} {
+		os.Setenv(env, "1")
+
+		ColorMode = ColorAuto
+		c.Assert(colorEnabled(), Equals, false)
+		ColorMode = ColorAlways
+		c.Assert(colorEnabled(), Equals, true)
+
+		os.Unsetenv(env)
+	}
+}
+
+func (s *MySuite) TestThemeSymbols(c *C) {
+	if util.IsWindows() {
+		c.Skip("Windows always uses ascii symbols")
+	}
+	defer func(name string) { Theme = name }(Theme)
+
+	Theme = MonochromeTheme
+	c.Assert(getSuccessSymbol(), Equals, " P")
+	c.Assert(getFailureSymbol(), Equals, " F")
+	Theme = HighContrastTheme
+	c.Assert(getSuccessSymbol(), Equals, " ✔")
+	Theme = "unknown"
+	c.Assert(getFailureSymbol(), Equals, " ✘")
+}
+
+func (s *MySuite) TestIsValidTheme(c *C) {
+	c.Assert(IsValidTheme(DefaultTheme), Equals, true)
+	c.Assert(IsValidTheme(HighContrastTheme), Equals, true)
+	c.Assert(IsValidTheme(MonochromeTheme), Equals, true)
+	c.Assert(IsValidTheme("solarized"), Equals, false)
+	c.Assert(IsValidColorMode("auto"), Equals, true)
+	c.Assert(IsValidColorMode("sometimes"), Equals, false)
+}
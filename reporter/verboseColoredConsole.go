@@ -34,6 +34,7 @@ import (
 type verboseColoredConsole struct {
 	writer               *goterminal.Writer
 	headingBuffer        bytes.Buffer
+	parametersBuffer     bytes.Buffer
 	pluginMessagesBuffer bytes.Buffer
 	errorMessagesBuffer  bytes.Buffer
 	indentation          int
@@ -52,7 +53,7 @@ func (c *verboseColoredConsole) SpecStart(spec *gauge.Specification, res result.
 	}
 	msg := formatSpec(spec.Heading.Value)
 	logger.Info(false, msg)
-	c.displayMessage(msg+newline, ct.Cyan)
+	c.displayMessage(msg+newline, currentTheme().spec)
 	c.writer.Reset()
 }
 
@@ -75,10 +76,26 @@ func (c *verboseColoredConsole) ScenarioStart(scenario *gauge.Scenario, i gauge_
 	logger.Info(false, msg)
 
 	indentedText := indent(msg+"\t", c.indentation)
-	c.displayMessage(indentedText+newline, ct.Yellow)
+	c.displayMessage(indentedText+newline, currentTheme().scenario)
+	c.displayRow("Spec data row", &scenario.SpecDataTableRow, scenario.SpecDataTableRowIndex)
+	c.displayRow("Scenario data row", &scenario.ScenarioDataTableRow, scenario.ScenarioDataTableRowIndex)
 	c.writer.Reset()
 }
 
+// displayRow prints the column values of the data table row for which the scenario is executed.
+func (c *verboseColoredConsole) displayRow(label string, row *gauge.Table, index int) {
+	if row.GetRowCount() == 0 {
+		return
+	}
+	values := make([]string, len(row.Headers))
+	for i, header := range row.Headers {
+		values[i] = fmt.Sprintf("%s = %s", header, row.Rows()[0][i])
+	}
+	msg := fmt.Sprintf("%s %d: %s", label, index+1, strings.Join(values, ", "))
+	logger.Info(false, msg)
+	c.displayMessage(indent(msg, c.indentation+stepIndentation)+newline, ct.None)
+}
+
 func (c *verboseColoredConsole) ScenarioEnd(scenario *gauge.Scenario, res result.Result, i gauge_messages.ExecutionInfo) {
 	if res.(*result.ScenarioResult).ProtoScenario.ExecutionStatus == gauge_messages.ExecutionStatus_SKIPPED {
 		return
@@ -100,21 +117,42 @@ func (c *verboseColoredConsole) StepStart(stepText string) {
 	c.displayMessage(c.headingBuffer.String()+newline, ct.None)
 }
 
+// StepParameters prints the values which the parameters of the current step resolve to. Static parameters are
+// already part of the step text and inline tables are printed with it, so they are left out.
+func (c *verboseColoredConsole) StepParameters(parameters []*gauge_messages.Parameter) {
+	for _, p := range parameters {
+		var value string
+		switch p.GetParameterType() {
+		case gauge_messages.Parameter_Dynamic, gauge_messages.Parameter_Special_String:
+			value = truncateParameter(p.GetValue())
+		case gauge_messages.Parameter_Special_Table:
+			value = fmt.Sprintf("table with %d row(s)", len(p.GetTable().GetRows()))
+		default:
+			continue
+		}
+		msg := fmt.Sprintf("<%s> = %s", p.GetName(), value)
+		logger.Debug(false, msg)
+		c.parametersBuffer.WriteString(indent(msg, c.indentation+errorIndentation) + newline)
+	}
+	c.displayMessage(c.parametersBuffer.String(), ct.None)
+}
+
 func (c *verboseColoredConsole) StepEnd(step gauge.Step, res result.Result, execInfo gauge_messages.ExecutionInfo) {
 	stepRes := res.(*result.StepResult)
 	c.writer.Clear()
 	if !(hookFailed(res.GetPreHook) || hookFailed(res.GetPostHook)) {
 		if stepRes.GetStepFailed() {
-			c.displayMessage(c.headingBuffer.String()+"\t ...[FAIL]\n", ct.Red)
+			c.displayMessage(c.headingBuffer.String()+"\t ...[FAIL]\n", currentTheme().failure)
 		} else {
-			c.displayMessage(c.headingBuffer.String()+"\t ...[PASS]\n", ct.Green)
+			c.displayMessage(c.headingBuffer.String()+"\t ...[PASS]\n", currentTheme().success)
 		}
 	} else {
 		c.displayMessage(c.headingBuffer.String()+newline, ct.None)
 	}
+	c.displayMessage(c.parametersBuffer.String(), ct.None)
 	printHookFailureVCC(c, res, res.GetPreHook)
 	c.displayMessage(c.pluginMessagesBuffer.String(), ct.None)
-	c.displayMessage(c.errorMessagesBuffer.String(), ct.Red)
+	c.displayMessage(c.errorMessagesBuffer.String(), currentTheme().failure)
 	if stepRes.GetStepFailed() {
 		stepText := prepStepMsg(step.LineText)
 		logger.Error(false, stepText)
@@ -127,7 +165,7 @@ func (c *verboseColoredConsole) StepEnd(step gauge.Step, res result.Result, exec
 
 		msg := formatErrorFragment(stepText, c.indentation) + formatErrorFragment(specInfo, c.indentation) + formatErrorFragment(errMsg, c.indentation) + formatErrorFragment(stacktrace, c.indentation)
 
-		c.displayMessage(msg, ct.Red)
+		c.displayMessage(msg, currentTheme().failure)
 	}
 	printHookFailureVCC(c, res, res.GetPostHook)
 	c.indentation -= stepIndentation
@@ -138,7 +176,7 @@ func (c *verboseColoredConsole) StepEnd(step gauge.Step, res result.Result, exec
 func (c *verboseColoredConsole) ConceptStart(conceptHeading string) {
 	c.indentation += stepIndentation
 	logger.Debug(false, conceptHeading)
-	c.displayMessage(indent(strings.TrimSpace(conceptHeading), c.indentation)+newline, ct.Magenta)
+	c.displayMessage(indent(strings.TrimSpace(conceptHeading), c.indentation)+newline, currentTheme().concept)
 	c.writer.Reset()
 }
 
@@ -152,13 +190,13 @@ func (c *verboseColoredConsole) SuiteEnd(res result.Result) {
 	printHookFailureVCC(c, res, res.GetPostHook)
 	for _, e := range suiteRes.UnhandledErrors {
 		logger.Error(false, e.Error())
-		c.displayMessage(indent(e.Error(), c.indentation+errorIndentation)+newline, ct.Red)
+		c.displayMessage(indent(e.Error(), c.indentation+errorIndentation)+newline, currentTheme().failure)
 	}
 }
 
 func (c *verboseColoredConsole) DataTable(table string) {
 	logger.Debug(false, table)
-	c.displayMessage(table, ct.Yellow)
+	c.displayMessage(table, currentTheme().table)
 	c.writer.Reset()
 }
 
@@ -166,7 +204,7 @@ func (c *verboseColoredConsole) Errorf(text string, args ...interface{}) {
 	msg := fmt.Sprintf(text, args...)
 	logger.Error(false, msg)
 	msg = indent(msg, c.indentation+errorIndentation) + newline
-	c.displayMessage(msg, ct.Red)
+	c.displayMessage(msg, currentTheme().failure)
 	c.errorMessagesBuffer.WriteString(msg)
 }
 
@@ -180,14 +218,16 @@ func (c *verboseColoredConsole) Write(b []byte) (int, error) {
 }
 
 func (c *verboseColoredConsole) displayMessage(msg string, color ct.Color) {
-	ct.Foreground(color, false)
-	defer ct.ResetColor()
+	if setColor(color) {
+		defer ct.ResetColor()
+	}
 	fmt.Fprint(c.writer, msg)
 	c.writer.Print()
 }
 
 func (c *verboseColoredConsole) resetBuffers() {
 	c.headingBuffer.Reset()
+	c.parametersBuffer.Reset()
 	c.pluginMessagesBuffer.Reset()
 	c.errorMessagesBuffer.Reset()
 }
@@ -198,7 +238,7 @@ func printHookFailureVCC(c *verboseColoredConsole, res result.Result, hookFailur
 		logger.Error(false, errMsg)
 		stacktrace := prepStacktrace(hookFailure()[0].GetStackTrace())
 		logger.Error(false, stacktrace)
-		c.displayMessage(formatErrorFragment(errMsg, c.indentation)+formatErrorFragment(stacktrace, c.indentation), ct.Red)
+		c.displayMessage(formatErrorFragment(errMsg, c.indentation)+formatErrorFragment(stacktrace, c.indentation), currentTheme().failure)
 		return false
 	}
 	return true
@@ -19,6 +19,7 @@ package reporter
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/getgauge/gauge/execution/result"
@@ -403,3 +404,47 @@ func (s *MySuite) TestSuiteEndWithPostHookFailure_ColoredConsole(c *C) {
 	want := ind + "Error Message: " + errMsg + newline + ind + "Stacktrace: \n" + ind + stackTrace + newline
 	c.Assert(dw.output, Equals, want)
 }
+
+func (s *MySuite) TestScenarioStartInVerbosePrintsDataTableRows(c *C) {
+	dw, cc := setupVerboseColoredConsole()
+	scnRes := result.NewScenarioResult(&gauge_messages.ProtoScenario{ExecutionStatus: gauge_messages.ExecutionStatus_PASSED})
+	specRow := gauge.NewTable([]string{"name", "count"}, [][]gauge.TableCell{{{Value: "john", CellType: gauge.Static}}, {{Value: "12", CellType: gauge.Static}}}, 1)
+	scenarioRow := gauge.NewTable([]string{"word"}, [][]gauge.TableCell{{{Value: "gauge", CellType: gauge.Static}}}, 5)
+	scenario := &gauge.Scenario{
+		Heading:                   &gauge.Heading{Value: "Vowel counts"},
+		SpecDataTableRow:          *specRow,
+		SpecDataTableRowIndex:     1,
+		ScenarioDataTableRow:      *scenarioRow,
+		ScenarioDataTableRowIndex: 0,
+	}
+
+	cc.ScenarioStart(scenario, gauge_messages.ExecutionInfo{}, scnRes)
+
+	c.Assert(dw.output, Equals, "  ## Vowel counts\t\n      Spec data row 2: name = john, count = 12\n      Scenario data row 1: word = gauge\n")
+}
+
+func (s *MySuite) TestStepParametersInVerbose(c *C) {
+	dw, cc := setupVerboseColoredConsole()
+	cc.indentation = 2
+	stepText := "* Greet <name> with <file:greeting.txt>"
+	parameters := []*gauge_messages.Parameter{
+		{ParameterType: gauge_messages.Parameter_Static, Value: "ignored"},
+		{ParameterType: gauge_messages.Parameter_Dynamic, Name: "name", Value: "john"},
+		{ParameterType: gauge_messages.Parameter_Special_String, Name: "file:greeting.txt", Value: "Hello\nWorld"},
+		{ParameterType: gauge_messages.Parameter_Special_Table, Name: "table:users.csv", Table: &gauge_messages.ProtoTable{Rows: []*gauge_messages.ProtoTableRow{{}, {}}}},
+	}
+
+	cc.StepStart(stepText)
+	cc.StepParameters(parameters)
+
+	expectedParameters := `        <name> = john
+        <file:greeting.txt> = Hello...
+        <table:users.csv> = table with 2 row(s)
+`
+	c.Assert(dw.output, Equals, spaces(6)+stepText+newline+expectedParameters)
+	dw.output = ""
+
+	cc.StepEnd(gauge.Step{LineText: stepText}, result.NewStepResult(&gauge_messages.ProtoStep{StepExecutionResult: &gauge_messages.ProtoStepExecutionResult{ExecutionResult: &gauge_messages.ProtoExecutionResult{}}}), gauge_messages.ExecutionInfo{})
+
+	c.Assert(dw.output, Equals, strings.Repeat(cursorUp+eraseLine, 4)+spaces(6)+stepText+"\t ...[PASS]\n"+expectedParameters)
+}
@@ -27,8 +27,10 @@ import (
 	"time"
 
 	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/conn"
 	gm "github.com/getgauge/gauge/gauge_messages"
 	"github.com/getgauge/gauge/manifest"
+	"github.com/getgauge/gauge/orphans"
 	"google.golang.org/grpc"
 )
 
@@ -122,6 +124,7 @@ func (r *GrpcRunner) Kill() error {
 	if err := r.cmd.Process.Kill(); err != nil {
 		return err
 	}
+	orphans.Untrack(r.cmd.Process.Pid)
 	return nil
 }
 
@@ -153,7 +156,7 @@ func (w customWriter) Write(p []byte) (n int, err error) {
 // ConnectToGrpcRunner makes a connection with grpc server
 func ConnectToGrpcRunner(manifest *manifest.Manifest, outFile io.Writer, timeout time.Duration) (*GrpcRunner, error) {
 	portChan := make(chan string)
-	cmd, _, err := runRunnerCommand(manifest, "0", false, customWriter{file: outFile, port: portChan})
+	cmd, _, err := runRunnerCommand(manifest, "0", "", "", nil, false, customWriter{file: outFile, port: portChan})
 	if err != nil {
 		return nil, err
 	}
@@ -165,9 +168,9 @@ func ConnectToGrpcRunner(manifest *manifest.Manifest, outFile io.Writer, timeout
 		return nil, fmt.Errorf("Timed out connecting to %s", manifest.Language)
 	}
 
-	conn, err := grpc.Dial(fmt.Sprintf("%s:%s", host, port), grpc.WithInsecure(), grpc.WithBlock())
+	c, err := grpc.Dial(fmt.Sprintf("%s:%s", host, port), grpc.WithInsecure(), grpc.WithBlock(), grpc.WithTimeout(conn.DialTimeout))
 	if err != nil {
 		return nil, err
 	}
-	return &GrpcRunner{Client: gm.NewLspServiceClient(conn), cmd: cmd, conn: conn, Timeout: timeout}, nil
+	return &GrpcRunner{Client: gm.NewLspServiceClient(c), cmd: cmd, conn: c, Timeout: timeout}, nil
 }
@@ -39,6 +39,7 @@ import (
 	"github.com/getgauge/gauge/gauge_messages"
 	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/manifest"
+	"github.com/getgauge/gauge/orphans"
 	"github.com/getgauge/gauge/plugin"
 	"github.com/getgauge/gauge/version"
 )
@@ -78,7 +79,7 @@ func (r *MultithreadedRunner) IsMultithreaded() bool {
 }
 
 func (r *MultithreadedRunner) SetConnection(c net.Conn) {
-	r.r = &LanguageRunner{connection: c}
+	r.r = &LanguageRunner{connection: c, mutex: &sync.Mutex{}}
 }
 
 func (r *MultithreadedRunner) Kill() error {
@@ -152,6 +153,27 @@ type RunnerInfo struct {
 	Multithreaded       bool
 	GaugeVersionSupport version.VersionSupport
 	LspLangId           string
+	Capabilities        []string
+}
+
+const (
+	// unixSocketCapability is advertised by the runners which can connect to gauge over a unix socket.
+	unixSocketCapability = "unix_socket"
+	// gaugeInternalSocketEnvName holds the path of the unix socket the runner connects to. It is abstract if it starts with @.
+	gaugeInternalSocketEnvName = "GAUGE_INTERNAL_SOCKET"
+	// namedPipeCapability is advertised by the runners which can connect to gauge over a named pipe on windows.
+	namedPipeCapability = "named_pipe"
+	// gaugeInternalPipeEnvName holds the name of the named pipe the runner connects to.
+	gaugeInternalPipeEnvName = "GAUGE_INTERNAL_PIPE"
+)
+
+func (r *RunnerInfo) hasCapability(capability string) bool {
+	for _, c := range r.Capabilities {
+		if strings.ToLower(c) == capability {
+			return true
+		}
+	}
+	return false
 }
 
 func ExecuteInitHookForRunner(language string) error {
@@ -213,7 +235,7 @@ func (r *LanguageRunner) Alive() bool {
 }
 
 func (r *LanguageRunner) EnsureConnected() bool {
-	if r.lostContact {
+	if r.LostContact() {
 		return false
 	}
 	c := r.connection
@@ -221,19 +243,41 @@ func (r *LanguageRunner) EnsureConnected() bool {
 	var one []byte
 	_, err := c.Read(one)
 	if err == io.EOF {
-		r.lostContact = true
-		logger.Fatalf(true, "Connection to runner with Pid %d lost. The runner probably quit unexpectedly. Inspect logs for potential reasons. Error : %s", r.Cmd.Process.Pid, err.Error())
+		r.loseContact(err)
+		return false
 	}
 	opErr, ok := err.(*net.OpError)
 	if ok && !(opErr.Temporary() || opErr.Timeout()) {
-		r.lostContact = true
-		logger.Fatalf(true, "Connection to runner with Pid %d lost. The runner probably quit unexpectedly. Inspect logs for potential reasons. Error : %s", r.Cmd.Process.Pid, err.Error())
+		r.loseContact(err)
+		return false
 	}
 	var zero time.Time
 	c.SetReadDeadline(zero)
 	return true
 }
 
+func (r *LanguageRunner) loseContact(err error) {
+	r.mutex.Lock()
+	r.lostContact = true
+	r.mutex.Unlock()
+	logger.Errorf(true, "Connection to runner with Pid %d lost. The runner probably quit unexpectedly. Inspect logs for potential reasons. Error : %s", r.pid(), err.Error())
+}
+
+// pid gives the pid of the runner, or -1 for a connection to a multithreaded runner which is not started by it.
+func (r *LanguageRunner) pid() int {
+	if r.Cmd == nil || r.Cmd.Process == nil {
+		return -1
+	}
+	return r.Cmd.Process.Pid
+}
+
+// LostContact tells if the connection to the runner was lost, which happens when the runner quits unexpectedly.
+func (r *LanguageRunner) LostContact() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.lostContact
+}
+
 func (r *LanguageRunner) IsMultithreaded() bool {
 	return r.multiThreaded
 }
@@ -283,10 +327,12 @@ func (r *LanguageRunner) Pid() int {
 // ExecuteAndGetStatus invokes the runner with a request and waits for response. error is thrown only when unable to connect to runner
 func (r *LanguageRunner) ExecuteAndGetStatus(message *gauge_messages.Message) *gauge_messages.ProtoExecutionResult {
 	if !r.EnsureConnected() {
-		return nil
+		return errorResult(fmt.Sprintf("Lost connection to runner with Pid %d.", r.pid()))
 	}
 	response, err := conn.GetResponseForMessageWithTimeout(message, r.connection, 0)
 	if err != nil {
+		// the connection is closed when reading from it fails, which the check marks as lost contact
+		r.EnsureConnected()
 		return &gauge_messages.ProtoExecutionResult{Failed: true, ErrorMessage: err.Error()}
 	}
 
@@ -305,7 +351,9 @@ func (r *LanguageRunner) ExecuteAndGetStatus(message *gauge_messages.Message) *g
 }
 
 func (r *LanguageRunner) ExecuteMessageWithTimeout(message *gauge_messages.Message) (*gauge_messages.Message, error) {
-	r.EnsureConnected()
+	if !r.EnsureConnected() {
+		return nil, fmt.Errorf("Lost connection to runner with Pid %d.", r.pid())
+	}
 	return conn.GetResponseForMessageWithTimeout(message, r.Connection(), config.RunnerRequestTimeout())
 }
 
@@ -313,7 +361,7 @@ func errorResult(message string) *gauge_messages.ProtoExecutionResult {
 	return &gauge_messages.ProtoExecutionResult{Failed: true, ErrorMessage: message, RecoverableError: false}
 }
 
-func runRunnerCommand(manifest *manifest.Manifest, port string, debug bool, outputStreamWriter io.Writer) (*exec.Cmd, *RunnerInfo, error) {
+func runRunnerCommand(manifest *manifest.Manifest, port, socket, pipe string, extraEnv []string, debug bool, outputStreamWriter io.Writer) (*exec.Cmd, *RunnerInfo, error) {
 	var r RunnerInfo
 	runnerDir, err := getLanguageJSONFilePath(manifest, &r)
 	if err != nil {
@@ -327,14 +375,28 @@ func runRunnerCommand(manifest *manifest.Manifest, port string, debug bool, outp
 	env := getCleanEnv(port, os.Environ(), debug, getPluginPaths())
 	env = append(env, fmt.Sprintf("GAUGE_UNIQUE_INSTALLATION_ID=%s", config.UniqueID()))
 	env = append(env, fmt.Sprintf("GAUGE_TELEMETRY_ENABLED=%v", config.TelemetryEnabled()))
+	if socket != "" {
+		env = append(env, fmt.Sprintf("%s=%s", gaugeInternalSocketEnvName, socket))
+	}
+	if pipe != "" {
+		env = append(env, fmt.Sprintf("%s=%s", gaugeInternalPipeEnvName, pipe))
+	}
+	env = append(env, extraEnv...)
 	cmd, err := common.ExecuteCommandWithEnv(command, runnerDir, outputStreamWriter, outputStreamWriter, env)
+	if err == nil {
+		orphans.Track(cmd, orphans.Runner)
+	}
 	return cmd, &r, err
 }
 
 // Looks for a runner configuration inside the runner directory
 // finds the runner configuration matching to the manifest and executes the commands for the current OS
 func StartRunner(manifest *manifest.Manifest, port string, outputStreamWriter io.Writer, killChannel chan bool, debug bool) (*LanguageRunner, error) {
-	cmd, r, err := runRunnerCommand(manifest, port, debug, outputStreamWriter)
+	return startRunner(manifest, port, "", "", nil, outputStreamWriter, killChannel, debug)
+}
+
+func startRunner(manifest *manifest.Manifest, port, socket, pipe string, extraEnv []string, outputStreamWriter io.Writer, killChannel chan bool, debug bool) (*LanguageRunner, error) {
+	cmd, r, err := runRunnerCommand(manifest, port, socket, pipe, extraEnv, debug, outputStreamWriter)
 	if err != nil {
 		return nil, err
 	}
@@ -377,6 +439,7 @@ func getLanguageJSONFilePath(manifest *manifest.Manifest, r *RunnerInfo) (string
 func (r *LanguageRunner) waitAndGetErrorMessage() {
 	go func() {
 		pState, err := r.Cmd.Process.Wait()
+		orphans.Untrack(r.Cmd.Process.Pid)
 		r.mutex.Lock()
 		r.Cmd.ProcessState = pState
 		r.mutex.Unlock()
@@ -440,16 +503,49 @@ type StartChannels struct {
 	KillChan chan bool
 }
 
-func Start(manifest *manifest.Manifest, outputStreamWriter io.Writer, killChannel chan bool, debug bool) (Runner, error) {
-	port, err := conn.GetPortFromEnvironmentVariable(common.GaugePortEnvName)
-	if err != nil {
-		port = 0
+// NewConnectionHandler listens for a runner to connect, on the port given by GAUGE_PORT or else on one of the runner ports.
+func NewConnectionHandler() (*conn.GaugeConnectionHandler, error) {
+	if port, err := conn.GetPortFromEnvironmentVariable(common.GaugePortEnvName); err == nil {
+		return conn.NewGaugeConnectionHandler(port, nil)
+	}
+	return conn.NewGaugeConnectionHandlerOnPorts(config.RunnerPorts(), nil)
+}
+
+// newConnectionHandlerFor listens for the runner of the project to connect over a named pipe on windows or a unix socket,
+// if the runner supports it and no port is given by GAUGE_PORT. Otherwise it listens on a localhost port.
+func newConnectionHandlerFor(manifest *manifest.Manifest) (*conn.GaugeConnectionHandler, error) {
+	var r RunnerInfo
+	if _, err := getLanguageJSONFilePath(manifest, &r); err != nil || os.Getenv(common.GaugePortEnvName) != "" {
+		return NewConnectionHandler()
+	}
+	if runtime.GOOS == "windows" && r.hasCapability(namedPipeCapability) && config.NamedPipes() {
+		handler, err := conn.NewGaugePipeConnectionHandler(nil)
+		if err == nil {
+			return handler, nil
+		}
+		logger.Debugf(true, "Failed to listen on a named pipe. %s", err.Error())
+	}
+	if r.hasCapability(unixSocketCapability) && config.UnixSockets() {
+		handler, err := conn.NewGaugeSocketConnectionHandler(nil)
+		if err == nil {
+			return handler, nil
+		}
+		logger.Debugf(true, "Failed to listen on a unix socket, using a port instead. %s", err.Error())
 	}
-	handler, err := conn.NewGaugeConnectionHandler(port, nil)
+	return NewConnectionHandler()
+}
+
+func Start(manifest *manifest.Manifest, outputStreamWriter io.Writer, killChannel chan bool, debug bool) (Runner, error) {
+	return StartWithEnv(manifest, nil, outputStreamWriter, killChannel, debug)
+}
+
+// StartWithEnv starts the runner with the given variables, like KEY=value, added to its environment.
+func StartWithEnv(manifest *manifest.Manifest, env []string, outputStreamWriter io.Writer, killChannel chan bool, debug bool) (Runner, error) {
+	handler, err := newConnectionHandlerFor(manifest)
 	if err != nil {
 		return nil, err
 	}
-	runner, err := StartRunner(manifest, strconv.Itoa(handler.ConnectionPortNumber()), outputStreamWriter, killChannel, debug)
+	runner, err := startRunner(manifest, strconv.Itoa(handler.ConnectionPortNumber()), handler.SocketPath(), handler.PipeName(), env, outputStreamWriter, killChannel, debug)
 	if err != nil {
 		return nil, err
 	}
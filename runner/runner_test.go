@@ -18,12 +18,16 @@
 package runner
 
 import (
+	"net"
 	"os"
+	"os/exec"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/gauge_messages"
 )
 
 func TestGetCleanEnvRemovesGAUGE_INTERNAL_PORTAndSetsPortNumber(t *testing.T) {
@@ -57,3 +61,29 @@ func TestGetCleanEnvAddsToPath(t *testing.T) {
 		t.Errorf("getCleanEnv failed. Did not append to path.\n\tWanted PATH to contain: `%s`", want)
 	}
 }
+
+func TestExecuteAndGetStatusFailsWhenRunnerQuit(t *testing.T) {
+	runnerEnd, gaugeEnd := net.Pipe()
+	runnerEnd.Close()
+	r := &LanguageRunner{Cmd: &exec.Cmd{Process: &os.Process{Pid: 42}}, connection: gaugeEnd, mutex: &sync.Mutex{}}
+
+	res := r.ExecuteAndGetStatus(&gauge_messages.Message{MessageType: gauge_messages.Message_ExecuteStep})
+
+	if !res.GetFailed() || res.GetErrorMessage() != "Lost connection to runner with Pid 42." {
+		t.Errorf("Expected execution to fail as the runner quit. Got %v", res)
+	}
+	if !r.LostContact() {
+		t.Error("Expected contact with the runner to be lost")
+	}
+}
+
+func TestRunnerInfoHasCapability(t *testing.T) {
+	r := &RunnerInfo{Capabilities: []string{"Unix_Socket"}}
+
+	if !r.hasCapability(unixSocketCapability) {
+		t.Error("Expected the runner to support unix sockets")
+	}
+	if (&RunnerInfo{}).hasCapability(unixSocketCapability) {
+		t.Error("Expected a runner without capabilities not to support unix sockets")
+	}
+}
@@ -49,6 +49,98 @@ csv_delimiter = ,
 
 # Allows steps to be written in multiline
 allow_multiline_step = false
+
+# How the cells of tables are aligned when formatting. Possible values are 'display_width', which accounts for
+# wide characters like CJK and emoji, and 'rune_count', which counts every character as one column.
+table_alignment = display_width
+
+# The language in which the keywords (tags, table and teardown) of specs are written, e.g. de, es, fr, it, nl, pt.
+# English keywords are always understood. Translations can be overridden with gauge_spec_keywords, e.g. tags=labels
+gauge_spec_language = en
+
+# A warning is shown for concepts which nest other concepts deeper than this. Set to 0 to disable the warning.
+max_concept_nesting_depth = 10
+
+# Steps taking longer than this many milliseconds are flagged on the console and listed among the slowest steps
+# at the end of execution. Set to 0 to disable.
+slow_step_threshold_ms = 0
+
+# Number of times a runner which quits unexpectedly is restarted during an execution. The scenario which was running
+# fails, and the remaining ones are executed on the new runner. Set to 0 to skip the remaining scenarios instead.
+max_runner_restarts = 3
+
+# In parallel runs, the scenarios executed by all the streams are summed up on the console every this many seconds.
+# Set to 0 to disable.
+parallel_progress_interval = 10
+
+# The results of this many runs are kept in .gauge/runs.db, to be queried with gauge query.
+# Set to 0 to disable.
+results_history_size = 100
+
+# gauge lint reports the concept files which are not under this directory, relative to the project root, e.g. concepts.
+# Leave empty to allow concept files anywhere in the project.
+lint_concepts_dir =
+
+# gauge lint reports the concept files defining more than one concept if set to true.
+lint_one_concept_per_file = false
+
+# gauge lint reports the concepts having more steps than this. Set to 0 to disable.
+lint_max_concept_steps = 15
+
+# Hooks of a level run only for the specs and scenarios matching the tag expression given for the level.
+# Levels are before_spec, after_spec, before_scenario, after_scenario, before_step and after_step,
+# e.g. before_scenario:web & !smoke; after_step:debug
+gauge_hook_tags =
+
+# Formats, json and/or csv, of the tag report written to <gauge_reports_dir>/tags at the end of execution. It has the
+# number of scenarios which passed, failed and were skipped, and the time they took, for every tag, e.g. json,csv
+tag_report =
+
+# Formats, json, csv and/or html, of the traceability matrix written to <gauge_reports_dir>/traceability at the end of
+# execution. It lists the scenarios tagged with each requirement along with their results, e.g. csv,html
+traceability_report =
+
+# The regular expression which tags naming a requirement match as a whole. Backslashes have to be doubled, e.g. REQ-\\d+
+requirement_tag_pattern = REQ-[0-9]+
+
+# The file mapping spec paths to the teams owning them, like CODEOWNERS. Every line has a path pattern followed by the
+# owners, and the last matching line wins. The owner metadata of a spec or scenario, e.g. meta: owner=team-payments,
+# takes precedence. Failures are grouped by their owners at the end of execution, and spec failures are posted to the
+# webhooks of their owners given by webhook_owner_urls.
+owners_file = OWNERS
+
+# The docker-compose file of the services, like databases, started before the suite and torn down after it.
+# The host and published ports of the services are exported to the runner, e.g. DB_HOST and DB_PORT for a service db.
+fixtures_compose_file =
+
+# The commands starting and tearing down the services, when they are not given by a compose file.
+fixtures_start_command =
+fixtures_stop_command =
+
+# The command which succeeds once the services are ready. It is retried every second, up to fixtures_health_timeout seconds.
+fixtures_health_check =
+fixtures_health_timeout = 60
+
+# The file of name=value pairs, e.g. written by the start command, which are exported to the runner.
+fixtures_env_file =
+
+# Set to true to write the output of the runner during every scenario to a file in the artifacts/output directory
+# of the reports directory, so that the output of a failed scenario need not be looked up in the whole log.
+capture_scenario_output = false
+
+# Publish the suite, spec, scenario and step events as they occur to Kafka, through a Kafka REST proxy, or to NATS
+# e.g. event_sink = kafka-rest-proxy or event_sink = nats
+# The URLs are those of the Kafka REST proxies, e.g. https://kafka-rest:8082, or of the NATS servers, e.g. tls://nats:4222
+# The credentials are given as user:password, or as a token for NATS. NATS servers are sent them only over TLS
+event_sink =
+event_sink_urls =
+event_sink_topic = gauge.events
+event_sink_auth =
+
+# Stream the execution events and the spec results over gRPC during the execution, e.g. result_stream_address = localhost:8050
+# Dashboards subscribe with clients generated from the ResultStream service of resultstream.proto
+# Addresses beyond localhost need api_tls_cert_file, api_tls_key_file and api_tls_client_ca_file in gauge.properties
+result_stream_address =
 `
 var ExampleSpec = `# Specification Heading
 
@@ -0,0 +1,140 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package track
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/logger"
+)
+
+// maxSpooledEvents bounds the events kept while gauge cannot reach the telemetry engine. The oldest ones are dropped first.
+const maxSpooledEvents = 100
+
+const spoolFileName = "events.json"
+
+type event struct {
+	Category string
+	Action   string
+	Label    string
+	Medium   string
+}
+
+var spoolMutex = &sync.Mutex{}
+
+var spoolFile = func() (string, error) {
+	home, err := common.GetGaugeHomeDirectory()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "telemetry", spoolFileName), nil
+}
+
+// sendEvent spools the event and then sends all the spooled events, keeping the ones which could not be sent.
+// Failures are only logged, so telemetry never affects the command being run.
+func sendEvent(e event) {
+	spoolMutex.Lock()
+	defer spoolMutex.Unlock()
+	events := append(readSpool(), e)
+	if err := writeSpool(events); err != nil {
+		logger.Debugf(true, "Unable to spool analytics data, %s", err)
+	}
+	for i, e := range events {
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		if !send(e.Category, e.Action, e.Label, e.Medium, wg) {
+			// most likely offline, so the rest are kept for later
+			events = events[i:]
+			break
+		}
+		if i == len(events)-1 {
+			events = nil
+		}
+	}
+	if err := writeSpool(events); err != nil {
+		logger.Debugf(true, "Unable to spool analytics data, %s", err)
+	}
+}
+
+func readSpool() []event {
+	f, err := spoolFile()
+	if err != nil {
+		return nil
+	}
+	contents, err := ioutil.ReadFile(f)
+	if err != nil {
+		return nil
+	}
+	var events []event
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		var e event
+		if json.Unmarshal(scanner.Bytes(), &e) == nil {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+func writeSpool(events []event) error {
+	f, err := spoolFile()
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		if err := os.Remove(f); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if len(events) > maxSpooledEvents {
+		events = events[len(events)-maxSpooledEvents:]
+	}
+	var b bytes.Buffer
+	for _, e := range events {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		b.Write(append(line, '\n'))
+	}
+	if err := os.MkdirAll(filepath.Dir(f), common.NewDirectoryPermissions); err != nil {
+		return err
+	}
+	tmp := f + ".tmp"
+	if err := ioutil.WriteFile(tmp, b.Bytes(), common.NewFilePermissions); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f)
+}
+
+// ClearSpool removes the events which are waiting to be sent, e.g. when telemetry is turned off.
+func ClearSpool() {
+	spoolMutex.Lock()
+	defer spoolMutex.Unlock()
+	if err := writeSpool(nil); err != nil {
+		logger.Debugf(true, "Unable to clear spooled analytics data, %s", err)
+	}
+}
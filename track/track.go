@@ -118,14 +118,14 @@ func send(category, action, label, medium string, wg *sync.WaitGroup) bool {
 		if err != nil {
 			logger.Debugf(true, "Unable to send analytics data, %s", err)
 		}
-		c <- true
+		c <- err == nil
 	}(sendChan)
 
 	for {
 		select {
-		case <-sendChan:
+		case sent := <-sendChan:
 			wg.Done()
-			return true
+			return sent
 		case <-time.After(timeout * time.Second):
 			logger.Debugf(true, "Unable to send analytics data, timed out")
 			wg.Done()
@@ -136,19 +136,23 @@ func send(category, action, label, medium string, wg *sync.WaitGroup) bool {
 
 func recoverPanic() {
 	if r := recover(); r != nil {
-		logger.Errorf(true, "%v\n%s", r, string(debug.Stack()))
+		logger.Debugf(true, "%v\n%s", r, string(debug.Stack()))
 	}
 }
 
+// trackConsole sends the event in the background, so that it never holds up the caller.
 func trackConsole(category, action, label string) {
+	if !telemetryEnabled {
+		return
+	}
 	var medium = consoleMedium
 	if isCI() {
 		medium = ciMedium
 	}
-	wg := &sync.WaitGroup{}
-	wg.Add(1)
-	defer wg.Wait()
-	go send(category, action, label, medium, wg)
+	go func() {
+		defer recoverPanic()
+		sendEvent(event{Category: category, Action: action, Label: label, Medium: medium})
+	}()
 }
 
 func isCI() bool {
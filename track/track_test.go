@@ -18,7 +18,10 @@
 package track
 
 import (
+	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"fmt"
@@ -86,3 +89,89 @@ func TestSend(t *testing.T) {
 		t.Error("Expected to send request")
 	}
 }
+
+type mockOfflineRoundTripper struct {
+}
+
+func (r mockOfflineRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, fmt.Errorf("network is unreachable")
+}
+
+func useTempSpool(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "gauge-telemetry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := spoolFile
+	spoolFile = func() (string, error) { return filepath.Join(dir, spoolFileName), nil }
+	return func() {
+		spoolFile = old
+		os.RemoveAll(dir)
+	}
+}
+
+func TestSendEventSpoolsWhenOffline(t *testing.T) {
+	defer useTempSpool(t)()
+	gaHTTPTransport = mockOfflineRoundTripper{}
+	telemetryEnabled = true
+	telemetryLogEnabled = false
+
+	sendEvent(event{Category: "daemon", Action: "lsp", Label: "java", Medium: "console"})
+	sendEvent(event{Category: "daemon", Action: "api", Label: "java", Medium: "console"})
+
+	events := readSpool()
+	if len(events) != 2 || events[0].Action != "lsp" || events[1].Action != "api" {
+		t.Errorf("Expected both events to be spooled, got %v", events)
+	}
+}
+
+func TestSendEventSendsSpooledEvents(t *testing.T) {
+	defer useTempSpool(t)()
+	telemetryEnabled = true
+	telemetryLogEnabled = false
+	writeSpool([]event{{Category: "daemon", Action: "lsp"}})
+	gaHTTPTransport = mockRoundTripper{}
+
+	sendEvent(event{Category: "daemon", Action: "api"})
+
+	if events := readSpool(); len(events) != 0 {
+		t.Errorf("Expected spooled events to be sent, got %v", events)
+	}
+}
+
+func TestSpoolIsBounded(t *testing.T) {
+	defer useTempSpool(t)()
+	var events []event
+	for i := 0; i < maxSpooledEvents+10; i++ {
+		events = append(events, event{Category: "daemon", Label: fmt.Sprint(i)})
+	}
+
+	writeSpool(events)
+
+	spooled := readSpool()
+	if len(spooled) != maxSpooledEvents || spooled[0].Label != "10" {
+		t.Errorf("Expected the newest %d events to be spooled, got %d starting with %s", maxSpooledEvents, len(spooled), spooled[0].Label)
+	}
+}
+
+func TestTrackConsoleDoesNotWaitForTheEventToBeSent(t *testing.T) {
+	defer useTempSpool(t)()
+	telemetryEnabled = true
+	telemetryLogEnabled = false
+	gaHTTPTransport = mockTimeoutRoundTripper{}
+
+	start := time.Now()
+	trackConsole("daemon", "lsp", "java")
+
+	if time.Since(start) > 500*time.Millisecond {
+		t.Errorf("Expected tracking to return immediately")
+	}
+	for i := 0; i < 100 && len(readSpool()) == 0; i++ {
+		time.Sleep(20 * time.Millisecond)
+	}
+	spoolMutex.Lock()
+	defer spoolMutex.Unlock()
+	if events := readSpool(); len(events) != 1 {
+		t.Errorf("Expected the event which timed out to be spooled, got %v", events)
+	}
+}
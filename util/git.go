@@ -0,0 +1,88 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/logger"
+)
+
+// RecentCommits is the number of the latest commits in which a change to a file counts as recent.
+const RecentCommits = 5
+
+// ChangedRecently gives the absolute paths of the files which are modified, untracked or changed in the last RecentCommits commits.
+// It is empty if the project is not in a git repository.
+var ChangedRecently = func() map[string]bool {
+	files, err := changedFiles(RecentCommits)
+	if err != nil {
+		logger.Debugf(true, "Failed to find the git repository of the project. %s", err.Error())
+		return map[string]bool{}
+	}
+	return files
+}
+
+// changedFiles gives the files in the git repository of the project which are modified, untracked or changed in the
+// last given number of commits. It returns an error if the project is not in a git repository.
+func changedFiles(commits int) (map[string]bool, error) {
+	top, err := git("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	root := strings.TrimSpace(top)
+	files := make(map[string]bool)
+	for _, args := range [][]string{
+		{"diff", "--name-only", "HEAD"},
+		{"ls-files", "--others", "--exclude-standard", "--full-name"},
+		{"log", "--name-only", "--pretty=format:", "-n", strconv.Itoa(commits)},
+	} {
+		out, err := git(args...)
+		if err != nil {
+			logger.Debugf(true, "Failed to list the changed files with git %s. %s", args[0], err.Error())
+			continue
+		}
+		for _, f := range strings.Split(out, "\n") {
+			if f = strings.TrimSpace(f); f != "" {
+				files[filepath.Join(root, filepath.FromSlash(f))] = true
+			}
+		}
+	}
+	return files, nil
+}
+
+func git(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = config.ProjectRoot
+	out, err := cmd.Output()
+	return string(out), err
+}
+
+// ResolvedPath gives the absolute path with any symbolic links evaluated, so that it can be compared with the paths given by git.
+func ResolvedPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return path
+}
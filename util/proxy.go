@@ -0,0 +1,130 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/getgauge/gauge/config"
+)
+
+var proxyFromEnvironment = http.ProxyFromEnvironment
+
+// Proxy returns the proxy for a request of gauge. It is the proxy_url in gauge properties, if set,
+// otherwise the one given by the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+// The proxy_user and proxy_password are used to authenticate with a proxy which has no credentials in its url.
+func Proxy(req *http.Request) (*url.URL, error) {
+	var proxy *url.URL
+	if configured := config.ProxyURL(); configured != "" {
+		if bypassProxy(req.URL.Hostname()) {
+			return nil, nil
+		}
+		u, err := parseProxyURL(configured)
+		if err != nil {
+			return nil, err
+		}
+		proxy = u
+	} else {
+		u, err := proxyFromEnvironment(req)
+		if err != nil || u == nil {
+			return u, err
+		}
+		proxy = u
+	}
+	if user, password := config.ProxyCredentials(); proxy.User == nil && user != "" {
+		withCredentials := *proxy
+		withCredentials.User = url.UserPassword(user, password)
+		proxy = &withCredentials
+	}
+	return proxy, nil
+}
+
+// RedactedProxy gives the proxy url with its password masked, so that it can be shown.
+func RedactedProxy(proxy *url.URL) string {
+	if proxy.User == nil {
+		return proxy.String()
+	}
+	if _, ok := proxy.User.Password(); !ok {
+		return proxy.String()
+	}
+	redacted := *proxy
+	redacted.User = url.UserPassword(proxy.User.Username(), "xxxxx")
+	return redacted.String()
+}
+
+// SetupProxy makes the default transport, which all requests of gauge go through, use Proxy.
+func SetupProxy() {
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		t.Proxy = Proxy
+	}
+}
+
+func parseProxyURL(proxy string) (*url.URL, error) {
+	u, err := url.Parse(proxy)
+	if err != nil || u.Host == "" {
+		// proxies are often given without a scheme, like proxy.example.com:3128
+		if u, err = url.Parse("http://" + proxy); err != nil {
+			return nil, fmt.Errorf("Invalid proxy address %s. %s", proxy, err.Error())
+		}
+	}
+	return u, nil
+}
+
+// bypassProxy tells if the host is local or matches the NO_PROXY environment variable.
+func bypassProxy(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	if ip != nil && ip.IsLoopback() {
+		return true
+	}
+	noProxy := os.Getenv("NO_PROXY")
+	if noProxy == "" {
+		noProxy = os.Getenv("no_proxy")
+	}
+	for _, p := range strings.Split(noProxy, ",") {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if p == "" {
+			continue
+		}
+		if p == "*" {
+			return true
+		}
+		if _, cidr, err := net.ParseCIDR(p); err == nil {
+			if ip != nil && cidr.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if h, _, err := net.SplitHostPort(p); err == nil {
+			p = h
+		}
+		p = strings.TrimPrefix(p, "*")
+		h := strings.ToLower(host)
+		if h == strings.TrimPrefix(p, ".") || strings.HasSuffix(h, "."+strings.TrimPrefix(p, ".")) {
+			return true
+		}
+	}
+	return false
+}
@@ -61,6 +61,16 @@ type SpecValidator struct {
 	conceptsDictionary  *gauge.ConceptDictionary
 	validationErrors    []error
 	stepValidationCache map[string]error
+	// aliasImplementations maps the value of every alias seen so far to the implementation it belongs to.
+	aliasImplementations map[string]string
+	// aliasLookups holds the valid steps whose aliases are looked up once all the specs are validated.
+	aliasLookups []aliasLookup
+}
+
+type aliasLookup struct {
+	step     *gauge.Step
+	spec     *gauge.Specification
+	fileName string
 }
 
 type StepValidationError struct {
@@ -134,7 +144,7 @@ func Validate(args []string) {
 		os.Exit(1)
 	}
 	res.Runner.Kill()
-	if res.ErrMap.HasErrors() {
+	if res.ErrMap.HasErrors() || !res.ParseOk {
 		os.Exit(1)
 	}
 	logger.Infof(true, "No errors found.")
@@ -142,7 +152,7 @@ func Validate(args []string) {
 
 //TODO : duplicate in execute.go. Need to fix runner init.
 func startAPI(debug bool) runner.Runner {
-	sc := api.StartAPI(debug, reporter.Current())
+	sc := api.StartAPI(debug, reporter.RunnerWriter(0))
 	select {
 	case runner := <-sc.RunnerChan:
 		return runner
@@ -152,6 +162,20 @@ func startAPI(debug bool) runner.Runner {
 	return nil
 }
 
+// ValidateSteps starts the runner, validates the steps of the given specs against it and kills it once done.
+// The validation errors are returned without duplicates, an error is returned if the runner could not be started.
+func ValidateSteps(specs []*gauge.Specification, conceptDict *gauge.ConceptDictionary) ([]error, error) {
+	sc := api.StartAPI(false, reporter.RunnerWriter(0))
+	var r runner.Runner
+	select {
+	case r = <-sc.RunnerChan:
+	case err := <-sc.ErrorChan:
+		return nil, err
+	}
+	defer r.Kill()
+	return FilterDuplicates(NewValidator(specs, r, conceptDict).Validate()), nil
+}
+
 type ValidationResult struct {
 	SpecCollection *gauge.SpecCollection
 	ErrMap         *gauge.BuildErrors
@@ -176,7 +200,6 @@ func ValidateSpecs(args []string, debug bool) *ValidationResult {
 	r := startAPI(debug)
 	vErrs := NewValidator(s, r, conceptDict).Validate()
 	errMap = getErrMap(errMap, vErrs)
-	s = parser.GetSpecsForDataTableRows(s, errMap)
 	printValidationFailures(vErrs)
 	showSuggestion(vErrs)
 	if !res.Ok {
@@ -244,6 +267,12 @@ func fillSpecErrors(spec *gauge.Specification, errMap *gauge.BuildErrors, steps
 func printValidationFailures(validationErrors validationErrors) {
 	for _, e := range FilterDuplicates(validationErrors) {
 		logger.Errorf(true, "[ValidationError] %s", e.Error())
+		switch vErr := e.(type) {
+		case StepValidationError:
+			reporter.ValidationError(vErr.fileName, vErr.step.LineNo, fmt.Sprintf("%s => '%s'", vErr.message, vErr.step.GetLineText()))
+		case SpecValidationError:
+			reporter.ValidationError(vErr.fileName, 0, vErr.message)
+		}
 	}
 }
 
@@ -277,7 +306,7 @@ func NewValidator(s []*gauge.Specification, r runner.Runner, c *gauge.ConceptDic
 
 func (v *validator) Validate() validationErrors {
 	validationStatus := make(validationErrors)
-	specValidator := &SpecValidator{runner: v.runner, conceptsDictionary: v.conceptsDictionary, stepValidationCache: make(map[string]error)}
+	specValidator := &SpecValidator{runner: v.runner, conceptsDictionary: v.conceptsDictionary, stepValidationCache: make(map[string]error), aliasImplementations: make(map[string]string)}
 	for _, spec := range v.specsToExecute {
 		specValidator.specification = spec
 		validationErrors := specValidator.validate()
@@ -285,6 +314,9 @@ func (v *validator) Validate() validationErrors {
 			validationStatus[spec] = validationErrors
 		}
 	}
+	for spec, errs := range specValidator.validateAliases() {
+		validationStatus[spec] = append(validationStatus[spec], errs...)
+	}
 	if len(validationStatus) > 0 {
 		return validationStatus
 	}
@@ -358,11 +390,78 @@ func (v *SpecValidator) validateStep(s *gauge.Step) error {
 			return vErr
 
 		}
+		v.aliasLookups = append(v.aliasLookups, aliasLookup{step: s, spec: v.specification, fileName: v.stepFileName(s)})
 		return nil
 	}
 	return NewStepValidationError(s, "Invalid response from runner for Validation request", v.specification.FileName, &invalidResponse, "")
 }
 
+var duplicateStepImplementation = gm.StepValidateResponse_DUPLICATE_STEP_IMPLEMENTATION
+
+// validateAliases checks that the aliases of the implementations of the valid steps are unique, within an implementation
+// and across the implementations. The aliases of an implementation are looked up once, for the first of its steps.
+func (v *SpecValidator) validateAliases() validationErrors {
+	errs := make(validationErrors)
+	if v.aliasImplementations == nil {
+		v.aliasImplementations = make(map[string]string)
+	}
+	for _, l := range v.aliasLookups {
+		if _, ok := v.aliasImplementations[l.step.Value]; ok {
+			continue
+		}
+		if err := v.validateAliasesOf(l); err != nil {
+			errs[l.spec] = append(errs[l.spec], err)
+		}
+	}
+	v.aliasLookups = nil
+	return errs
+}
+
+func (v *SpecValidator) validateAliasesOf(l aliasLookup) error {
+	m := &gm.Message{MessageType: gm.Message_StepNameRequest, StepNameRequest: &gm.StepNameRequest{StepValue: l.step.Value}}
+	r, err := v.runner.ExecuteMessageWithTimeout(m)
+	if err != nil || !r.GetStepNameResponse().GetIsStepPresent() || !r.GetStepNameResponse().GetHasAlias() {
+		return nil
+	}
+	res := r.GetStepNameResponse()
+	implementation := fmt.Sprintf("%s:%d", res.GetFileName(), res.GetSpan().GetStart())
+	seen := make(map[string]string)
+	var vErr error
+	for _, alias := range res.GetStepName() {
+		value, err := parser.ExtractStepValueAndParams(alias, false)
+		if err != nil {
+			continue
+		}
+		other, duplicate := seen[value.StepValue]
+		impl, implemented := v.aliasImplementations[value.StepValue]
+		switch {
+		case vErr != nil:
+		case duplicate:
+			msg := fmt.Sprintf("Duplicate step implementation: aliases '%s' and '%s' of the same implementation are the same step", other, alias)
+			vErr = NewStepValidationError(l.step, msg, l.fileName, &duplicateStepImplementation, "")
+		case implemented && impl != implementation:
+			msg := fmt.Sprintf("Duplicate step implementation: alias '%s' is also implemented in %s", alias, impl)
+			vErr = NewStepValidationError(l.step, msg, l.fileName, &duplicateStepImplementation, "")
+		}
+		if !duplicate {
+			seen[value.StepValue] = alias
+		}
+	}
+	for value := range seen {
+		if _, ok := v.aliasImplementations[value]; !ok {
+			v.aliasImplementations[value] = implementation
+		}
+	}
+	return vErr
+}
+
+func (v *SpecValidator) stepFileName(s *gauge.Step) string {
+	if s.Parent == nil {
+		return v.specification.FileName
+	}
+	return v.conceptsDictionary.Search(s.Parent.Value).FileName
+}
+
 func getMessage(message string) string {
 	lower := strings.ToLower(strings.Replace(message, "_", " ", -1))
 	return strings.ToUpper(lower[:1]) + lower[1:]
@@ -377,6 +476,9 @@ func (v *SpecValidator) Heading(heading *gauge.Heading) {
 func (v *SpecValidator) Tags(tags *gauge.Tags) {
 }
 
+func (v *SpecValidator) Meta(meta *gauge.Meta) {
+}
+
 func (v *SpecValidator) Table(dataTable *gauge.Table) {
 
 }
@@ -401,14 +503,21 @@ func (v *SpecValidator) Specification(specification *gauge.Specification) {
 	}
 }
 
+// validateDataTableRange validates the comma separated row numbers and ranges, like 1,3,5-7, against the row count.
 func validateDataTableRange(rowCount int) error {
 	if TableRows == "" {
 		return nil
 	}
-	if strings.Contains(TableRows, "-") {
-		indexes := strings.Split(TableRows, "-")
+	for _, rows := range strings.Split(TableRows, ",") {
+		if !strings.Contains(rows, "-") {
+			if err := validateTableRow(rows, rowCount); err != nil {
+				return err
+			}
+			continue
+		}
+		indexes := strings.Split(rows, "-")
 		if len(indexes) > 2 {
-			return fmt.Errorf("Table rows range '%s' is invalid => Table rows range should be of format rowNumber-rowNumber", TableRows)
+			return fmt.Errorf("Table rows range '%s' is invalid => Table rows range should be of format rowNumber-rowNumber", strings.TrimSpace(rows))
 		}
 		if err := validateTableRow(indexes[0], rowCount); err != nil {
 			return err
@@ -416,12 +525,10 @@ func validateDataTableRange(rowCount int) error {
 		if err := validateTableRow(indexes[1], rowCount); err != nil {
 			return err
 		}
-	} else {
-		indexes := strings.Split(TableRows, ",")
-		for _, i := range indexes {
-			if err := validateTableRow(i, rowCount); err != nil {
-				return err
-			}
+		start, _ := strconv.Atoi(strings.TrimSpace(indexes[0]))
+		end, _ := strconv.Atoi(strings.TrimSpace(indexes[1]))
+		if start > end {
+			return fmt.Errorf("Table rows range '%s' is invalid => Start row number should not be greater than end row number", strings.TrimSpace(rows))
 		}
 	}
 	return nil
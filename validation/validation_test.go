@@ -199,6 +199,75 @@ func (s *MySuite) TestValidateStepInConcept(c *C) {
 		"}")
 }
 
+func aliasRunner(fileName string, aliases ...string) *mockRunner {
+	return countingAliasRunner(new(int), fileName, aliases...)
+}
+
+func countingAliasRunner(lookups *int, fileName string, aliases ...string) *mockRunner {
+	return &mockRunner{
+		ExecuteMessageFunc: func(m *gauge_messages.Message) (*gauge_messages.Message, error) {
+			if m.MessageType == gauge_messages.Message_StepNameRequest {
+				*lookups++
+				res := &gauge_messages.StepNameResponse{IsStepPresent: true, HasAlias: true, StepName: aliases, FileName: fileName, Span: &gauge_messages.Span{Start: 3}}
+				return &gauge_messages.Message{MessageType: gauge_messages.Message_StepNameResponse, StepNameResponse: res}, nil
+			}
+			res := &gauge_messages.StepValidateResponse{IsValid: true}
+			return &gauge_messages.Message{MessageType: gauge_messages.Message_StepValidateResponse, StepValidateResponse: res}, nil
+		},
+	}
+}
+
+func (s *MySuite) TestValidateStepWithUniqueAliases(c *C) {
+	myStep := &gauge.Step{Value: "pay {}", LineText: "pay <amount>", LineNo: 3}
+	specVal := &SpecValidator{specification: &gauge.Specification{FileName: "foo.spec"}, runner: aliasRunner("Steps.java", "pay <amount>", "send <amount>")}
+
+	c.Assert(specVal.validateStep(myStep), IsNil)
+	c.Assert(specVal.validateStep(myStep), IsNil)
+	c.Assert(specVal.validateAliases(), HasLen, 0)
+}
+
+func (s *MySuite) TestValidateAliasesLooksUpAnImplementationOnce(c *C) {
+	lookups := 0
+	spec := &gauge.Specification{FileName: "foo.spec"}
+	specVal := &SpecValidator{specification: spec, runner: countingAliasRunner(&lookups, "Steps.java", "pay <amount>", "send <amount>")}
+
+	c.Assert(specVal.validateStep(&gauge.Step{Value: "pay {}", LineText: "pay <amount>", LineNo: 3}), IsNil)
+	c.Assert(specVal.validateStep(&gauge.Step{Value: "send {}", LineText: "send <amount>", LineNo: 4}), IsNil)
+	c.Assert(lookups, Equals, 0)
+
+	c.Assert(specVal.validateAliases(), HasLen, 0)
+	c.Assert(lookups, Equals, 1)
+}
+
+func (s *MySuite) TestValidateStepWithDuplicateAliasesInAnImplementation(c *C) {
+	myStep := &gauge.Step{Value: "pay {}", LineText: "pay <amount>", LineNo: 3}
+	spec := &gauge.Specification{FileName: "foo.spec"}
+	specVal := &SpecValidator{specification: spec, runner: aliasRunner("Steps.java", "pay <amount>", "pay <money>")}
+	c.Assert(specVal.validateStep(myStep), IsNil)
+
+	errs := specVal.validateAliases()[spec]
+
+	c.Assert(errs, HasLen, 1)
+	valErr := errs[0]
+	c.Assert(*valErr.(StepValidationError).errorType, Equals, gauge_messages.StepValidateResponse_DUPLICATE_STEP_IMPLEMENTATION)
+	c.Assert(valErr.Error(), Equals, "foo.spec:3 Duplicate step implementation: aliases 'pay <amount>' and 'pay <money>' of the same implementation are the same step => 'pay <amount>'")
+}
+
+func (s *MySuite) TestValidateStepWithAliasDuplicatedAcrossImplementations(c *C) {
+	spec := &gauge.Specification{FileName: "foo.spec"}
+	specVal := &SpecValidator{specification: spec, runner: aliasRunner("Steps.java", "pay <amount>", "send <amount>")}
+	c.Assert(specVal.validateStep(&gauge.Step{Value: "pay {}", LineText: "pay <amount>", LineNo: 3}), IsNil)
+	c.Assert(specVal.validateAliases(), HasLen, 0)
+
+	specVal.runner = aliasRunner("Other.java", "give <amount>", "send <amount>")
+	c.Assert(specVal.validateStep(&gauge.Step{Value: "give {}", LineText: "give <amount>", LineNo: 4}), IsNil)
+	errs := specVal.validateAliases()[spec]
+
+	c.Assert(errs, HasLen, 1)
+	valErr := errs[0]
+	c.Assert(valErr.Error(), Equals, "foo.spec:4 Duplicate step implementation: alias 'send <amount>' is also implemented in Steps.java:3 => 'give <amount>'")
+}
+
 func (s *MySuite) TestFilterDuplicateValidationErrors(c *C) {
 	specText := `Specification Heading
 =====================
@@ -271,6 +340,10 @@ var tableRowTests = []*tableRow{
 	{"Row count is zero with non empty input", "1", 0, errors.New("Table rows range validation failed => Table row number '1' is out of range")},
 	{"Row count is non-zero with empty input", "", 2, nil},
 	{"Row count is non-zero with non-empty input", "2", 2, nil},
+	{"Valid mixed table rows list and ranges", "1,3,5-7,12", 12, nil},
+	{"Invalid mixed table rows list and ranges", "1,3,5-7,12", 11, errors.New("Table rows range validation failed => Table row number '12' is out of range")},
+	{"Invalid range in table rows list", "1,5-7", 6, errors.New("Table rows range validation failed => Table row number '7' is out of range")},
+	{"Reversed range in table rows list", "1, 7-5", 8, errors.New("Table rows range '7-5' is invalid => Start row number should not be greater than end row number")},
 }
 
 func (s *MySuite) TestToValidateDataTableRowsRangeFromInputFlag(c *C) {
@@ -0,0 +1,161 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package verify checks a project without executing it. The specs and concepts are parsed, their steps are validated
+// against the runner, linted and checked to be formatted, and the problems found by all of these are gathered in one report.
+package verify
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/getgauge/gauge/formatter"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/lint"
+	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/util"
+	"github.com/getgauge/gauge/validation"
+)
+
+const (
+	parseCheck      = "Parse"
+	validationCheck = "Validation"
+	lintCheck       = "Lint"
+	formatCheck     = "Format"
+)
+
+// SkipValidation turns off the validation of steps, for projects whose runner is not available where they are verified.
+var SkipValidation = false
+
+// Check holds the problems found by one of the checks.
+type Check struct {
+	Name     string
+	Problems []string
+	Skipped  bool
+}
+
+// Report holds the checks in the order they were run.
+type Report struct {
+	Checks []*Check
+}
+
+// Problems returns the number of problems found by all the checks.
+func (r *Report) Problems() int {
+	count := 0
+	for _, c := range r.Checks {
+		count += len(c.Problems)
+	}
+	return count
+}
+
+// Verify parses the specs in the given directories along with the concepts of the project and runs all the checks on them.
+// Only the specs which could be parsed are validated, linted and checked to be formatted.
+// An error is returned if the checks could not be run at all, e.g. when the runner fails to start.
+func Verify(specDirs []string) (*Report, error) {
+	concepts, conceptsResult, err := parser.CreateConceptsDictionary()
+	if err != nil {
+		return nil, err
+	}
+	specFiles := util.GetSpecFiles(specDirs)
+	specs, results := parser.ParseSpecFiles(specFiles, concepts, gauge.NewBuildErrors())
+
+	parse := &Check{Name: parseCheck}
+	failed := make(map[string]bool)
+	for _, e := range conceptsResult.ParseErrors {
+		parse.Problems = append(parse.Problems, e.Error())
+	}
+	for i, res := range results {
+		if !res.Ok {
+			failed[specFiles[i]] = true
+		}
+		for _, e := range res.ParseErrors {
+			parse.Problems = append(parse.Problems, e.Error())
+		}
+	}
+	var parsed []*gauge.Specification
+	for _, spec := range specs {
+		if !failed[spec.FileName] {
+			parsed = append(parsed, spec)
+		}
+	}
+
+	validate, err := validateSteps(parsed, concepts)
+	if err != nil {
+		return nil, err
+	}
+	return &Report{Checks: []*Check{parse, validate, lintSpecs(parsed, concepts), checkFormat(parsed)}}, nil
+}
+
+func validateSteps(specs []*gauge.Specification, concepts *gauge.ConceptDictionary) (*Check, error) {
+	check := &Check{Name: validationCheck, Skipped: SkipValidation}
+	if SkipValidation {
+		return check, nil
+	}
+	errs, err := validation.ValidateSteps(specs, concepts)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to start the runner to validate the steps. %s", err.Error())
+	}
+	for _, e := range errs {
+		check.Problems = append(check.Problems, e.Error())
+	}
+	sort.Strings(check.Problems)
+	return check, nil
+}
+
+func lintSpecs(specs []*gauge.Specification, concepts *gauge.ConceptDictionary) *Check {
+	check := &Check{Name: lintCheck}
+	for _, f := range lint.Lint(specs, concepts) {
+		check.Problems = append(check.Problems, f.String())
+	}
+	return check
+}
+
+func checkFormat(specs []*gauge.Specification) *Check {
+	check := &Check{Name: formatCheck}
+	for _, spec := range specs {
+		ok, err := formatter.IsFormatted(spec)
+		if err != nil {
+			check.Problems = append(check.Problems, err.Error())
+		} else if !ok {
+			check.Problems = append(check.Problems, fmt.Sprintf("%s is not formatted, run gauge format on it", util.RelPathToProjectRoot(spec.FileName)))
+		}
+	}
+	return check
+}
+
+// Print writes the problems of each check under its name, followed by the number of problems found in all.
+func Print(w io.Writer, r *Report) {
+	for _, c := range r.Checks {
+		switch {
+		case c.Skipped:
+			fmt.Fprintf(w, "%s: skipped\n", c.Name)
+		case len(c.Problems) == 0:
+			fmt.Fprintf(w, "%s: ok\n", c.Name)
+		default:
+			fmt.Fprintf(w, "%s: %d problem(s)\n", c.Name, len(c.Problems))
+			for _, p := range c.Problems {
+				fmt.Fprintf(w, "  %s\n", p)
+			}
+		}
+	}
+	if n := r.Problems(); n > 0 {
+		fmt.Fprintf(w, "%d problem(s) found.\n", n)
+	} else {
+		fmt.Fprintln(w, "No problems found.")
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package verify
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getgauge/gauge/config"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func writeSpec(c *C, dir, name, text string) {
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, name), []byte(text), 0644), IsNil)
+}
+
+func (s *MySuite) TestVerifyReportsProblemsOfEachCheck(c *C) {
+	dir, err := ioutil.TempDir("", "verify")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+	specs := filepath.Join(dir, "specs")
+	c.Assert(os.Mkdir(specs, 0755), IsNil)
+	writeSpec(c, specs, "formatted.spec", "# Spec\n## Scenario\n* step one\n")
+	writeSpec(c, specs, "unformatted.spec", "Spec\n====\nScenario\n--------\n*   step two\n")
+	writeSpec(c, specs, "broken.spec", "* step\n")
+	oldRoot := config.ProjectRoot
+	config.ProjectRoot = dir
+	defer func() { config.ProjectRoot = oldRoot }()
+	SkipValidation = true
+	defer func() { SkipValidation = false }()
+
+	r, err := Verify([]string{specs})
+
+	c.Assert(err, IsNil)
+	c.Assert(len(r.Checks), Equals, 4)
+	c.Assert(r.Checks[0].Name, Equals, parseCheck)
+	c.Assert(len(r.Checks[0].Problems), Equals, 1)
+	c.Assert(r.Checks[1].Skipped, Equals, true)
+	c.Assert(r.Checks[2].Problems, HasLen, 0)
+	c.Assert(r.Checks[3].Problems, DeepEquals, []string{filepath.Join("specs", "unformatted.spec") + " is not formatted, run gauge format on it"})
+	c.Assert(r.Problems(), Equals, 2)
+}
+
+func (s *MySuite) TestPrint(c *C) {
+	r := &Report{Checks: []*Check{
+		{Name: parseCheck},
+		{Name: validationCheck, Skipped: true},
+		{Name: formatCheck, Problems: []string{"specs/a.spec is not formatted, run gauge format on it"}},
+	}}
+	var b bytes.Buffer
+
+	Print(&b, r)
+
+	c.Assert(b.String(), Equals, `Parse: ok
+Validation: skipped
+Format: 1 problem(s)
+  specs/a.spec is not formatted, run gauge format on it
+1 problem(s) found.
+`)
+}
+
+func (s *MySuite) TestPrintWithoutProblems(c *C) {
+	var b bytes.Buffer
+
+	Print(&b, &Report{Checks: []*Check{{Name: lintCheck}}})
+
+	c.Assert(b.String(), Equals, "Lint: ok\nNo problems found.\n")
+}
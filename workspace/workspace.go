@@ -0,0 +1,64 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package workspace reads the workspace file, which lists the gauge projects of a monorepo, so that
+// commands can be executed across all of them.
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/getgauge/common"
+)
+
+// Workspace holds the gauge projects of a monorepo.
+type Workspace struct {
+	// Projects holds the directories of the projects, relative to the workspace file
+	Projects []string `json:"projects"`
+}
+
+// Load reads the workspace file and gives the absolute paths of its projects.
+func Load(file string) ([]string, error) {
+	contents, err := common.ReadFileContents(file)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read workspace %s. %s", file, err.Error())
+	}
+	w := &Workspace{}
+	if err := json.Unmarshal([]byte(contents), w); err != nil {
+		return nil, fmt.Errorf("Failed to parse workspace %s. %s", file, err.Error())
+	}
+	if len(w.Projects) == 0 {
+		return nil, fmt.Errorf("No projects found in workspace %s", file)
+	}
+	root, err := filepath.Abs(filepath.Dir(file))
+	if err != nil {
+		return nil, err
+	}
+	var projects []string
+	for _, p := range w.Projects {
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(root, p)
+		}
+		if !common.FileExists(filepath.Join(p, common.ManifestFile)) {
+			return nil, fmt.Errorf("%s in workspace %s is not a gauge project. %s not found", p, file, common.ManifestFile)
+		}
+		projects = append(projects, p)
+	}
+	return projects, nil
+}
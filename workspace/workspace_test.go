@@ -0,0 +1,58 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package workspace
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) TestLoadResolvesProjectsRelativeToWorkspaceFile(c *C) {
+	dir := c.MkDir()
+	for _, p := range []string{"api", "ui"} {
+		os.MkdirAll(filepath.Join(dir, p), 0755)
+		ioutil.WriteFile(filepath.Join(dir, p, "manifest.json"), []byte(`{"Language": "java"}`), 0644)
+	}
+	file := filepath.Join(dir, "gauge-workspace.json")
+	ioutil.WriteFile(file, []byte(`{"projects": ["api", "ui"]}`), 0644)
+
+	projects, err := Load(file)
+
+	c.Assert(err, IsNil)
+	c.Assert(projects, DeepEquals, []string{filepath.Join(dir, "api"), filepath.Join(dir, "ui")})
+}
+
+func (s *MySuite) TestLoadFailsForDirectoryWithoutManifest(c *C) {
+	dir := c.MkDir()
+	file := filepath.Join(dir, "gauge-workspace.json")
+	ioutil.WriteFile(file, []byte(`{"projects": ["api"]}`), 0644)
+
+	_, err := Load(file)
+
+	c.Assert(err, ErrorMatches, ".*api in workspace .* is not a gauge project. manifest.json not found")
+}